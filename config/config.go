@@ -9,13 +9,26 @@ import (
 )
 
 type Config struct {
-	Server   ServerConfig
-	Logger   Logger
-	Postgres PostgresConfig
-	Redis    RedisConfig
-	Http     Http
-	Cookie   Cookie
-	Session  Session
+	Server       ServerConfig
+	Logger       Logger
+	Postgres     PostgresConfig
+	Redis        RedisConfig
+	Http         Http
+	Cookie       Cookie
+	Session      Session
+	Oidc         Oidc
+	Retention    Retention
+	Payment      Payment
+	Idempotency  Idempotency
+	Delivery     Delivery
+	Courier      Courier
+	Outbox       Outbox
+	Webhook      Webhook
+	Jobs         Jobs
+	Notification Notification
+	OrderStream  OrderStream
+	Report       Report
+	Export       Export
 }
 
 type ServerConfig struct {
@@ -91,6 +104,145 @@ type Session struct {
 	Expire int
 }
 
+type Oidc struct {
+	StateExpire int
+	Providers   map[string]OidcProvider
+}
+
+type OidcProvider struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	// TrustEmail link a sign in to the account registered with its verified email, only for providers that own the
+	// email addresses they verify
+	TrustEmail bool
+}
+
+type Retention struct {
+	PurgeEnabled bool
+	DeletedDays  int
+	Interval     time.Duration
+}
+
+type Payment struct {
+	Provider      string
+	Currency      string
+	BaseURL       string
+	ApiKey        string
+	WebhookSecret string
+	// TestMode allows the fake provider and the confirm endpoint, never set it in production
+	TestMode bool
+}
+
+type Idempotency struct {
+	Expire     int
+	LockExpire int
+}
+
+type Delivery struct {
+	GeocoderFile string
+	BaseFee      float64
+	PerKm        float64
+	WeightTiers  []DeliveryWeightTier
+}
+
+// DeliveryWeightTier fee added for shipments up to MaxWeight grams, a zero MaxWeight has no bound
+type DeliveryWeightTier struct {
+	MaxWeight uint64
+	Fee       float64
+}
+
+type Courier struct {
+	Provider      string
+	WebhookSecret string
+}
+
+// Outbox relay publishes outbox events to Stream every Interval, consumers give up on an event after MaxDeliveries
+// and move it to DeadLetterStream
+type Outbox struct {
+	RelayEnabled     bool
+	Stream           string
+	DeadLetterStream string
+	MaxLen           int64
+	BatchSize        int
+	Interval         time.Duration
+	Lease            time.Duration
+	Block            time.Duration
+	ClaimIdle        time.Duration
+	MaxDeliveries    int64
+}
+
+// Webhook dispatcher sends due brand webhook deliveries every Interval, a failed delivery is retried after Backoff
+// doubled on every attempt up to MaxBackoff, and given up after MaxAttempts
+type Webhook struct {
+	DispatchEnabled bool
+	BatchSize       int
+	Interval        time.Duration
+	Lease           time.Duration
+	Backoff         time.Duration
+	MaxBackoff      time.Duration
+	MaxAttempts     int
+	// AllowPrivateNetworks lets webhook urls target loopback and private addresses, for local development only
+	AllowPrivateNetworks bool
+}
+
+// Jobs Workers poll the job queue every PollInterval, a failed job is retried after Backoff doubled on every attempt
+// up to MaxBackoff. A job must finish within Lease or it is handed to another worker. On shutdown running jobs get
+// DrainTimeout to finish, finished jobs are purged after KeepFinished
+type Jobs struct {
+	Enabled      bool
+	Workers      int
+	PollInterval time.Duration
+	Lease        time.Duration
+	Backoff      time.Duration
+	MaxBackoff   time.Duration
+	MaxAttempts  int
+	DrainTimeout time.Duration
+	KeepFinished time.Duration
+}
+
+// Notification order emails are sent From the address through Mailer, smtp or file. The file mailer drops every
+// message as an .eml file in FileDir. Recipients without a locale get DefaultLocale templates
+type Notification struct {
+	Enabled       bool
+	Mailer        string
+	From          string
+	DefaultLocale string
+	FileDir       string
+	SMTPHost      string
+	SMTPPort      int
+	SMTPUsername  string
+	SMTPPassword  string
+}
+
+// OrderStream order updates are published on the Redis pub/sub Channel and kept for resuming in the Buffer stream,
+// trimmed to about BufferSize entries. Streams get a Heartbeat comment while idle and end after MaxDuration, which
+// must stay under the server write timeout, clients reconnect after Retry and resume from the buffer
+type OrderStream struct {
+	Enabled     bool
+	Channel     string
+	Buffer      string
+	BufferSize  int64
+	Heartbeat   time.Duration
+	MaxDuration time.Duration
+	Retry       time.Duration
+	ClientQueue int
+}
+
+// Report sales rollups are refreshed on the RefreshSchedule cron spec, recomputing the days with orders changed since
+// the previous refresh less RefreshOverlap, which covers orders whose transactions committed late
+type Report struct {
+	RefreshSchedule string
+	RefreshOverlap  time.Duration
+}
+
+// Export files of the exports run in the background are kept in Dir
+type Export struct {
+	Dir string
+}
+
 // LoadConfig Load config file from given path
 func LoadConfig(filename string) (*viper.Viper, error) {
 	v := viper.New()
@@ -0,0 +1,47 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/internal/models"
+)
+
+type AddressResponseDto struct {
+	AddressID  uuid.UUID `json:"address_id"`
+	Label      string    `json:"label"`
+	Recipient  string    `json:"recipient"`
+	Phone      string    `json:"phone"`
+	Street     string    `json:"street"`
+	City       string    `json:"city"`
+	PostalCode string    `json:"postal_code"`
+	Region     string    `json:"region"`
+	Country    string    `json:"country"`
+	Latitude   *float64  `json:"latitude,omitempty"`
+	Longitude  *float64  `json:"longitude,omitempty"`
+	IsDefault  bool      `json:"is_default"`
+	Version    int       `json:"version"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+func AddressResponseFromModel(address *models.UserAddress) *AddressResponseDto {
+	return &AddressResponseDto{
+		AddressID:  address.AddressID,
+		Label:      address.Label,
+		Recipient:  address.Recipient,
+		Phone:      address.Phone,
+		Street:     address.Street,
+		City:       address.City,
+		PostalCode: address.PostalCode,
+		Region:     address.Region,
+		Country:    address.Country,
+		Latitude:   address.Latitude,
+		Longitude:  address.Longitude,
+		IsDefault:  address.IsDefault,
+		Version:    address.Version,
+		CreatedAt:  address.CreatedAt,
+		UpdatedAt:  address.UpdatedAt,
+	}
+}
@@ -0,0 +1,5 @@
+package dto
+
+type AddressFindResponseDto struct {
+	Data []*AddressResponseDto `json:"data"`
+}
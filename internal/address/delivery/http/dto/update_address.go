@@ -0,0 +1,15 @@
+package dto
+
+type AddressUpdateRequestDto struct {
+	Label      *string  `json:"label" validate:"omitempty,lte=32"`
+	Recipient  *string  `json:"recipient" validate:"omitempty,min=1,lte=60"`
+	Phone      *string  `json:"phone" validate:"omitempty,min=1,lte=20"`
+	Street     *string  `json:"street" validate:"omitempty,min=1,lte=250"`
+	City       *string  `json:"city" validate:"omitempty,min=1,lte=60"`
+	PostalCode *string  `json:"postal_code" validate:"omitempty,lte=16"`
+	Region     *string  `json:"region" validate:"omitempty,lte=3,alphanum"`
+	Country    *string  `json:"country" validate:"omitempty,len=2,alpha"`
+	Latitude   *float64 `json:"latitude" validate:"omitempty,gte=-90,lte=90"`
+	Longitude  *float64 `json:"longitude" validate:"omitempty,gte=-180,lte=180"`
+	IsDefault  *bool    `json:"is_default"`
+}
@@ -0,0 +1,342 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/address"
+	"github.com/dinorain/kalobranded/internal/address/delivery/http/dto"
+	"github.com/dinorain/kalobranded/internal/middlewares"
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/internal/server/router"
+	"github.com/dinorain/kalobranded/pkg/constants"
+	"github.com/dinorain/kalobranded/pkg/geo"
+	httpErrors "github.com/dinorain/kalobranded/pkg/http_errors"
+	"github.com/dinorain/kalobranded/pkg/logger"
+	"github.com/dinorain/kalobranded/pkg/utils"
+)
+
+type addressHandlersHTTP struct {
+	router    *router.Router
+	logger    logger.Logger
+	cfg       *config.Config
+	mw        middlewares.MiddlewareManager
+	v         *validator.Validate
+	addressUC address.AddressUseCase
+}
+
+var _ address.AddressHandlers = (*addressHandlersHTTP)(nil)
+
+func NewAddressHandlersHTTP(
+	router *router.Router,
+	logger logger.Logger,
+	cfg *config.Config,
+	mw middlewares.MiddlewareManager,
+	v *validator.Validate,
+	addressUC address.AddressUseCase,
+) *addressHandlersHTTP {
+	return &addressHandlersHTTP{router: router, logger: logger, cfg: cfg, mw: mw, v: v, addressUC: addressUC}
+}
+
+// Create
+// @Tags Addresses
+// @Summary Create address
+// @Description User add an address to their address book, the address becomes the default when is_default is set or the user has no default yet
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param payload body dto.AddressCreateRequestDto true "Payload"
+// @Success 201 {object} dto.AddressResponseDto
+// @Router /user/addresses [post]
+func (h *addressHandlersHTTP) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userUUID, err := h.getCaller(w, r)
+	if err != nil {
+		return
+	}
+
+	createDto := &dto.AddressCreateRequestDto{}
+	if err := json.NewDecoder(r.Body).Decode(createDto); err != nil {
+		h.logger.Errorf("decoder.Decode: %v", err)
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	if err := h.v.Struct(createDto); err != nil {
+		h.logger.Errorf("h.v.Struct: %v", err)
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	userAddress := &models.UserAddress{
+		UserID:     userUUID,
+		Label:      createDto.Label,
+		Recipient:  createDto.Recipient,
+		Phone:      createDto.Phone,
+		Street:     createDto.Street,
+		City:       createDto.City,
+		PostalCode: createDto.PostalCode,
+		Region:     createDto.Region,
+		Country:    createDto.Country,
+		Latitude:   createDto.Latitude,
+		Longitude:  createDto.Longitude,
+		IsDefault:  createDto.IsDefault,
+	}
+	if err := userAddress.PrepareCreate(); err != nil {
+		h.logger.Errorf("userAddress.PrepareCreate: %v", err)
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	createdAddress, err := h.addressUC.Create(ctx, userAddress)
+	if err != nil {
+		h.logger.Errorf("addressUC.Create: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	w.Header().Set(constants.ETag, utils.ETag(createdAddress.Version))
+	res, _ := json.Marshal(dto.AddressResponseFromModel(createdAddress))
+	w.WriteHeader(http.StatusCreated)
+	w.Write(res)
+	return
+}
+
+// FindAll
+// @Tags Addresses
+// @Summary Find my addresses
+// @Description User find the addresses of their address book, the default first
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} dto.AddressFindResponseDto
+// @Router /user/addresses [get]
+func (h *addressHandlersHTTP) FindAll(w http.ResponseWriter, r *http.Request) {
+	userUUID, err := h.getCaller(w, r)
+	if err != nil {
+		return
+	}
+
+	addresses, err := h.addressUC.FindAllByUserId(r.Context(), userUUID)
+	if err != nil {
+		h.logger.Errorf("addressUC.FindAllByUserId: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	resDto := dto.AddressFindResponseDto{Data: make([]*dto.AddressResponseDto, 0, len(addresses))}
+	for i := range addresses {
+		resDto.Data = append(resDto.Data, dto.AddressResponseFromModel(&addresses[i]))
+	}
+
+	res, _ := json.Marshal(resDto)
+	w.WriteHeader(http.StatusOK)
+	w.Write(res)
+	return
+}
+
+// FindById
+// @Tags Addresses
+// @Summary Find address by id
+// @Description User find an address of their address book
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "address uuid"
+// @Success 200 {object} dto.AddressResponseDto
+// @Header 200 {string} ETag "resource version"
+// @Router /user/addresses/{id} [get]
+func (h *addressHandlersHTTP) FindById(w http.ResponseWriter, r *http.Request) {
+	foundAddress, err := h.findAddress(w, r)
+	if err != nil {
+		return
+	}
+
+	w.Header().Set(constants.ETag, utils.ETag(foundAddress.Version))
+	res, _ := json.Marshal(dto.AddressResponseFromModel(foundAddress))
+	w.WriteHeader(http.StatusOK)
+	w.Write(res)
+	return
+}
+
+// UpdateById
+// @Tags Addresses
+// @Summary Update address
+// @Description User update an address of their address book, only provided fields are changed. Coordinates are cleared when the street, postal code, city or country change without new ones. Setting is_default takes the flag from the previous default. Orders keep the address they were placed with
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "address uuid"
+// @Param If-Match header string false "ETag of the version being changed"
+// @Param payload body dto.AddressUpdateRequestDto true "Payload"
+// @Success 200 {object} dto.AddressResponseDto
+// @Header 200 {string} ETag "resource version"
+// @Router /user/addresses/{id} [patch]
+func (h *addressHandlersHTTP) UpdateById(w http.ResponseWriter, r *http.Request) {
+	updateDto := &dto.AddressUpdateRequestDto{}
+	if err := json.NewDecoder(r.Body).Decode(updateDto); err != nil {
+		h.logger.Errorf("decoder.Decode: %v", err)
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	if err := h.v.Struct(updateDto); err != nil {
+		h.logger.Errorf("h.v.Struct: %v", err)
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	foundAddress, err := h.findAddress(w, r)
+	if err != nil {
+		return
+	}
+
+	if !utils.IfMatch(r.Header.Get(constants.IfMatch), foundAddress.Version) {
+		_ = httpErrors.ErrorCtxResponse(w, httpErrors.PreconditionFailed, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	if err := updateReqToAddressModel(foundAddress, updateDto); err != nil {
+		h.logger.Errorf("updateReqToAddressModel: %v", err)
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	updatedAddress, err := h.addressUC.UpdateById(r.Context(), foundAddress)
+	if err != nil {
+		h.logger.Errorf("addressUC.UpdateById: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	w.Header().Set(constants.ETag, utils.ETag(updatedAddress.Version))
+	res, _ := json.Marshal(dto.AddressResponseFromModel(updatedAddress))
+	w.WriteHeader(http.StatusOK)
+	w.Write(res)
+	return
+}
+
+// DeleteById
+// @Tags Addresses
+// @Summary Delete address
+// @Description User delete an address of their address book, orders keep the address they were placed with. Deleting the default leaves the user without one until another address is made default
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "address uuid"
+// @Success 204 {object} nil
+// @Router /user/addresses/{id} [delete]
+func (h *addressHandlersHTTP) DeleteById(w http.ResponseWriter, r *http.Request) {
+	foundAddress, err := h.findAddress(w, r)
+	if err != nil {
+		return
+	}
+
+	if err := h.addressUC.DeleteById(r.Context(), foundAddress.AddressID); err != nil {
+		h.logger.Errorf("addressUC.DeleteById: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return
+}
+
+func updateReqToAddressModel(userAddress *models.UserAddress, r *dto.AddressUpdateRequestDto) error {
+	located := userAddress.Format()
+
+	if r.Label != nil {
+		userAddress.Label = *r.Label
+	}
+	if r.Recipient != nil {
+		userAddress.Recipient = *r.Recipient
+	}
+	if r.Phone != nil {
+		userAddress.Phone = *r.Phone
+	}
+	if r.Street != nil {
+		userAddress.Street = *r.Street
+	}
+	if r.City != nil {
+		userAddress.City = *r.City
+	}
+	if r.PostalCode != nil {
+		userAddress.PostalCode = *r.PostalCode
+	}
+	if r.Region != nil {
+		userAddress.Region = *r.Region
+	}
+	if r.Country != nil {
+		userAddress.Country = *r.Country
+	}
+	if r.IsDefault != nil {
+		userAddress.IsDefault = *r.IsDefault
+	}
+
+	if err := userAddress.PrepareCreate(); err != nil {
+		return err
+	}
+
+	if userAddress.Format() != located {
+		// coordinates of the previous address would misplace the new one, it gets geocoded instead
+		userAddress.Latitude, userAddress.Longitude = nil, nil
+	}
+	if r.Latitude != nil || r.Longitude != nil {
+		if err := geo.CheckCoordinates(r.Latitude, r.Longitude); err != nil {
+			return err
+		}
+		userAddress.Latitude, userAddress.Longitude = r.Latitude, r.Longitude
+	}
+
+	return nil
+}
+
+// findAddress find the address of the path id, users reach their own addresses only. Error response is already
+// written when err is not nil
+func (h *addressHandlersHTTP) findAddress(w http.ResponseWriter, r *http.Request) (*models.UserAddress, error) {
+	addressUUID, err := uuid.Parse(router.Param(r, constants.ID))
+	if err != nil {
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return nil, err
+	}
+
+	userUUID, err := h.getCaller(w, r)
+	if err != nil {
+		return nil, err
+	}
+
+	foundAddress, err := h.addressUC.FindById(r.Context(), addressUUID)
+	if err != nil {
+		h.logger.Errorf("addressUC.FindById: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return nil, err
+	}
+
+	if foundAddress.UserID != userUUID {
+		return nil, httpErrors.NewForbiddenError(w, nil, h.cfg.Http.DebugErrorsResponse)
+	}
+
+	return foundAddress, nil
+}
+
+// getCaller user uuid of the token, error response is already written when err is not nil
+func (h *addressHandlersHTTP) getCaller(w http.ResponseWriter, r *http.Request) (userUUID uuid.UUID, err error) {
+	jwtClaims, err := h.mw.GetJWTClaims(w, r)
+	if err != nil {
+		return
+	}
+	claims := *jwtClaims
+	userID, _ := claims["user_id"].(string)
+
+	userUUID, err = uuid.Parse(userID)
+	if err != nil {
+		_ = httpErrors.NewUnauthorizedError(w, nil, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+	return
+}
@@ -0,0 +1,186 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/golang-jwt/jwt"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/address/delivery/http/dto"
+	"github.com/dinorain/kalobranded/internal/address/mock"
+	"github.com/dinorain/kalobranded/internal/middlewares"
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/internal/server/router"
+	"github.com/dinorain/kalobranded/pkg/logger"
+	"github.com/dinorain/kalobranded/pkg/utils"
+)
+
+func signedToken(t *testing.T, cfg *config.Config, userUUID uuid.UUID) string {
+	token := jwt.New(jwt.SigningMethodHS256)
+	claims := token.Claims.(jwt.MapClaims)
+	claims["session_id"] = uuid.New().String()
+	claims["user_id"] = userUUID.String()
+	claims["role"] = models.UserRoleUser
+	claims["exp"] = time.Now().Add(time.Minute * 15).Unix()
+	validToken, err := token.SignedString([]byte(cfg.Server.JwtSecretKey))
+	require.NoError(t, err)
+	return validToken
+}
+
+func TestAddressesHandler_Create(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	addressUC := mock.NewMockAddressUseCase(ctrl)
+
+	cfg := &config.Config{Session: config.Session{Expire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	appLogger := logger.NewAppLogger(cfg)
+	appLogger.InitLogger()
+	mw := middlewares.NewMiddlewareManager(appLogger, cfg)
+
+	v := validator.New()
+
+	rt := router.NewRouter(false)
+	handlers := NewAddressHandlersHTTP(rt, appLogger, cfg, mw, v, addressUC)
+
+	userUUID := uuid.New()
+	token := signedToken(t, cfg, userUUID)
+
+	t.Run("Created", func(t *testing.T) {
+		body := `{"label": "Home", "recipient": " Recipient ", "phone": "08123456789", "street": "Jl. Sudirman 1", "city": "Jakarta", "postal_code": "10220", "region": "jk", "country": "id", "latitude": -6.2146, "longitude": 106.8223}`
+		req := httptest.NewRequest(http.MethodPost, "/user/addresses", strings.NewReader(body))
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", token))
+		w := httptest.NewRecorder()
+
+		addressUUID := uuid.New()
+		addressUC.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, userAddress *models.UserAddress) (*models.UserAddress, error) {
+			require.Equal(t, userUUID, userAddress.UserID)
+			require.Equal(t, "Recipient", userAddress.Recipient)
+			require.Equal(t, "ID", userAddress.Country)
+			require.Equal(t, "ID-JK", userAddress.TaxRegion())
+			require.Equal(t, "Jl. Sudirman 1, 10220, Jakarta, ID", userAddress.Format())
+			created := *userAddress
+			created.AddressID = addressUUID
+			created.IsDefault = true
+			return &created, nil
+		})
+
+		http.HandlerFunc(handlers.Create).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusCreated, w.Code)
+		resDto := &dto.AddressResponseDto{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), resDto))
+		require.Equal(t, addressUUID, resDto.AddressID)
+		require.True(t, resDto.IsDefault)
+	})
+
+	t.Run("IncompleteCoordinates", func(t *testing.T) {
+		body := `{"recipient": "Recipient", "phone": "08123456789", "street": "Jl. Sudirman 1", "city": "Jakarta", "country": "ID", "latitude": -6.2146}`
+		req := httptest.NewRequest(http.MethodPost, "/user/addresses", strings.NewReader(body))
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", token))
+		w := httptest.NewRecorder()
+
+		http.HandlerFunc(handlers.Create).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestAddressesHandler_UpdateById(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	addressUC := mock.NewMockAddressUseCase(ctrl)
+
+	cfg := &config.Config{Session: config.Session{Expire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	appLogger := logger.NewAppLogger(cfg)
+	appLogger.InitLogger()
+	mw := middlewares.NewMiddlewareManager(appLogger, cfg)
+
+	v := validator.New()
+
+	rt := router.NewRouter(false)
+	handlers := NewAddressHandlersHTTP(rt, appLogger, cfg, mw, v, addressUC)
+
+	userUUID := uuid.New()
+	addressUUID := uuid.New()
+	newAddress := func() *models.UserAddress {
+		lat, lng := -6.2146, 106.8223
+		return &models.UserAddress{
+			AddressID: addressUUID,
+			UserID:    userUUID,
+			Recipient: "Recipient",
+			Phone:     "08123456789",
+			Street:    "Jl. Sudirman 1",
+			City:      "Jakarta",
+			Country:   "ID",
+			Latitude:  &lat,
+			Longitude: &lng,
+			Version:   1,
+		}
+	}
+	newRequest := func(userUUID uuid.UUID, body string) *http.Request {
+		req := router.WithParams(httptest.NewRequest(http.MethodPatch, "/user/addresses/"+addressUUID.String(), strings.NewReader(body)), map[string]string{"id": addressUUID.String()})
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", signedToken(t, cfg, userUUID)))
+		req.Header.Set("If-Match", utils.ETag(1))
+		return req
+	}
+
+	t.Run("Updated", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		addressUC.EXPECT().FindById(gomock.Any(), addressUUID).Return(newAddress(), nil)
+		addressUC.EXPECT().UpdateById(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, userAddress *models.UserAddress) (*models.UserAddress, error) {
+			require.Equal(t, "Jl. Thamrin 10", userAddress.Street)
+			require.True(t, userAddress.IsDefault)
+			require.Nil(t, userAddress.Point())
+			updated := *userAddress
+			updated.Version = 2
+			return &updated, nil
+		})
+
+		http.HandlerFunc(handlers.UpdateById).ServeHTTP(w, newRequest(userUUID, `{"street": "Jl. Thamrin 10", "is_default": true}`))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, utils.ETag(2), w.Header().Get("ETag"))
+	})
+
+	t.Run("RecipientKeepsCoordinates", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		addressUC.EXPECT().FindById(gomock.Any(), addressUUID).Return(newAddress(), nil)
+		addressUC.EXPECT().UpdateById(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, userAddress *models.UserAddress) (*models.UserAddress, error) {
+			require.Equal(t, "Other", userAddress.Recipient)
+			require.NotNil(t, userAddress.Point())
+			return userAddress, nil
+		})
+
+		http.HandlerFunc(handlers.UpdateById).ServeHTTP(w, newRequest(userUUID, `{"recipient": "Other"}`))
+
+		require.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("NotOwner", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		addressUC.EXPECT().FindById(gomock.Any(), addressUUID).Return(newAddress(), nil)
+
+		http.HandlerFunc(handlers.UpdateById).ServeHTTP(w, newRequest(uuid.New(), `{"recipient": "Other"}`))
+
+		require.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
@@ -0,0 +1,10 @@
+package handlers
+
+func (h *addressHandlersHTTP) AddressMapRoutes() {
+	addresses := h.router.Group("/user/addresses", h.mw.IsLoggedIn)
+	addresses.Post("", h.Create)
+	addresses.Get("", h.FindAll)
+	addresses.Get("/{id}", h.FindById)
+	addresses.Patch("/{id}", h.UpdateById)
+	addresses.Delete("/{id}", h.DeleteById)
+}
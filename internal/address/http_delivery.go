@@ -0,0 +1,14 @@
+package address
+
+import (
+	"net/http"
+)
+
+// Address HTTP Handlers interface
+type AddressHandlers interface {
+	Create(w http.ResponseWriter, r *http.Request)
+	FindAll(w http.ResponseWriter, r *http.Request)
+	FindById(w http.ResponseWriter, r *http.Request)
+	UpdateById(w http.ResponseWriter, r *http.Request)
+	DeleteById(w http.ResponseWriter, r *http.Request)
+}
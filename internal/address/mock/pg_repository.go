@@ -0,0 +1,126 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pg_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/dinorain/kalobranded/internal/models"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockAddressPGRepository is a mock of AddressPGRepository interface.
+type MockAddressPGRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAddressPGRepositoryMockRecorder
+}
+
+// MockAddressPGRepositoryMockRecorder is the mock recorder for MockAddressPGRepository.
+type MockAddressPGRepositoryMockRecorder struct {
+	mock *MockAddressPGRepository
+}
+
+// NewMockAddressPGRepository creates a new mock instance.
+func NewMockAddressPGRepository(ctrl *gomock.Controller) *MockAddressPGRepository {
+	mock := &MockAddressPGRepository{ctrl: ctrl}
+	mock.recorder = &MockAddressPGRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAddressPGRepository) EXPECT() *MockAddressPGRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAddressPGRepository) Create(ctx context.Context, address *models.UserAddress) (*models.UserAddress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, address)
+	ret0, _ := ret[0].(*models.UserAddress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAddressPGRepositoryMockRecorder) Create(ctx, address interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAddressPGRepository)(nil).Create), ctx, address)
+}
+
+// DeleteById mocks base method.
+func (m *MockAddressPGRepository) DeleteById(ctx context.Context, addressID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteById", ctx, addressID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteById indicates an expected call of DeleteById.
+func (mr *MockAddressPGRepositoryMockRecorder) DeleteById(ctx, addressID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockAddressPGRepository)(nil).DeleteById), ctx, addressID)
+}
+
+// FindAllByUserId mocks base method.
+func (m *MockAddressPGRepository) FindAllByUserId(ctx context.Context, userID uuid.UUID) ([]models.UserAddress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByUserId", ctx, userID)
+	ret0, _ := ret[0].([]models.UserAddress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByUserId indicates an expected call of FindAllByUserId.
+func (mr *MockAddressPGRepositoryMockRecorder) FindAllByUserId(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByUserId", reflect.TypeOf((*MockAddressPGRepository)(nil).FindAllByUserId), ctx, userID)
+}
+
+// FindById mocks base method.
+func (m *MockAddressPGRepository) FindById(ctx context.Context, addressID uuid.UUID) (*models.UserAddress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, addressID)
+	ret0, _ := ret[0].(*models.UserAddress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockAddressPGRepositoryMockRecorder) FindById(ctx, addressID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockAddressPGRepository)(nil).FindById), ctx, addressID)
+}
+
+// FindDefaultByUserId mocks base method.
+func (m *MockAddressPGRepository) FindDefaultByUserId(ctx context.Context, userID uuid.UUID) (*models.UserAddress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDefaultByUserId", ctx, userID)
+	ret0, _ := ret[0].(*models.UserAddress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDefaultByUserId indicates an expected call of FindDefaultByUserId.
+func (mr *MockAddressPGRepositoryMockRecorder) FindDefaultByUserId(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDefaultByUserId", reflect.TypeOf((*MockAddressPGRepository)(nil).FindDefaultByUserId), ctx, userID)
+}
+
+// UpdateById mocks base method.
+func (m *MockAddressPGRepository) UpdateById(ctx context.Context, address *models.UserAddress) (*models.UserAddress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateById", ctx, address)
+	ret0, _ := ret[0].(*models.UserAddress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateById indicates an expected call of UpdateById.
+func (mr *MockAddressPGRepositoryMockRecorder) UpdateById(ctx, address interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateById", reflect.TypeOf((*MockAddressPGRepository)(nil).UpdateById), ctx, address)
+}
@@ -0,0 +1,126 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/dinorain/kalobranded/internal/models"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockAddressUseCase is a mock of AddressUseCase interface.
+type MockAddressUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockAddressUseCaseMockRecorder
+}
+
+// MockAddressUseCaseMockRecorder is the mock recorder for MockAddressUseCase.
+type MockAddressUseCaseMockRecorder struct {
+	mock *MockAddressUseCase
+}
+
+// NewMockAddressUseCase creates a new mock instance.
+func NewMockAddressUseCase(ctrl *gomock.Controller) *MockAddressUseCase {
+	mock := &MockAddressUseCase{ctrl: ctrl}
+	mock.recorder = &MockAddressUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAddressUseCase) EXPECT() *MockAddressUseCaseMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAddressUseCase) Create(ctx context.Context, address *models.UserAddress) (*models.UserAddress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, address)
+	ret0, _ := ret[0].(*models.UserAddress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAddressUseCaseMockRecorder) Create(ctx, address interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAddressUseCase)(nil).Create), ctx, address)
+}
+
+// DeleteById mocks base method.
+func (m *MockAddressUseCase) DeleteById(ctx context.Context, addressID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteById", ctx, addressID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteById indicates an expected call of DeleteById.
+func (mr *MockAddressUseCaseMockRecorder) DeleteById(ctx, addressID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockAddressUseCase)(nil).DeleteById), ctx, addressID)
+}
+
+// FindAllByUserId mocks base method.
+func (m *MockAddressUseCase) FindAllByUserId(ctx context.Context, userID uuid.UUID) ([]models.UserAddress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByUserId", ctx, userID)
+	ret0, _ := ret[0].([]models.UserAddress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByUserId indicates an expected call of FindAllByUserId.
+func (mr *MockAddressUseCaseMockRecorder) FindAllByUserId(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByUserId", reflect.TypeOf((*MockAddressUseCase)(nil).FindAllByUserId), ctx, userID)
+}
+
+// FindById mocks base method.
+func (m *MockAddressUseCase) FindById(ctx context.Context, addressID uuid.UUID) (*models.UserAddress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, addressID)
+	ret0, _ := ret[0].(*models.UserAddress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockAddressUseCaseMockRecorder) FindById(ctx, addressID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockAddressUseCase)(nil).FindById), ctx, addressID)
+}
+
+// FindDefaultByUserId mocks base method.
+func (m *MockAddressUseCase) FindDefaultByUserId(ctx context.Context, userID uuid.UUID) (*models.UserAddress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDefaultByUserId", ctx, userID)
+	ret0, _ := ret[0].(*models.UserAddress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDefaultByUserId indicates an expected call of FindDefaultByUserId.
+func (mr *MockAddressUseCaseMockRecorder) FindDefaultByUserId(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDefaultByUserId", reflect.TypeOf((*MockAddressUseCase)(nil).FindDefaultByUserId), ctx, userID)
+}
+
+// UpdateById mocks base method.
+func (m *MockAddressUseCase) UpdateById(ctx context.Context, address *models.UserAddress) (*models.UserAddress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateById", ctx, address)
+	ret0, _ := ret[0].(*models.UserAddress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateById indicates an expected call of UpdateById.
+func (mr *MockAddressUseCaseMockRecorder) UpdateById(ctx, address interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateById", reflect.TypeOf((*MockAddressUseCase)(nil).UpdateById), ctx, address)
+}
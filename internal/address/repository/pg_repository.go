@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"github.com/dinorain/kalobranded/internal/address"
+	"github.com/dinorain/kalobranded/internal/models"
+)
+
+// Address repository
+type AddressRepository struct {
+	db *sqlx.DB
+}
+
+var _ address.AddressPGRepository = (*AddressRepository)(nil)
+
+// Address repository constructor
+func NewAddressPGRepository(db *sqlx.DB) *AddressRepository {
+	return &AddressRepository{db: db}
+}
+
+// Create new address of the user. An address created while the user has no default becomes the default, a new
+// default address takes the flag from the previous one in the same transaction
+func (r *AddressRepository) Create(ctx context.Context, userAddress *models.UserAddress) (*models.UserAddress, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "AddressRepository.Create.BeginTxx")
+	}
+	defer tx.Rollback()
+
+	if userAddress.IsDefault {
+		// the new address has no id yet, so every address of the user is cleared
+		if _, err := tx.ExecContext(ctx, clearDefaultQuery, userAddress.UserID, uuid.Nil); err != nil {
+			return nil, errors.Wrap(err, "AddressRepository.Create.ClearDefault")
+		}
+	}
+
+	createdAddress := &models.UserAddress{}
+	if err := tx.QueryRowxContext(
+		ctx,
+		createAddressQuery,
+		userAddress.UserID,
+		userAddress.Label,
+		userAddress.Recipient,
+		userAddress.Phone,
+		userAddress.Street,
+		userAddress.City,
+		userAddress.PostalCode,
+		userAddress.Region,
+		userAddress.Country,
+		userAddress.Latitude,
+		userAddress.Longitude,
+		userAddress.IsDefault,
+	).StructScan(createdAddress); err != nil {
+		return nil, errors.Wrap(err, "AddressRepository.Create.QueryRowxContext")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "AddressRepository.Create.Commit")
+	}
+
+	return createdAddress, nil
+}
+
+// FindAllByUserId Find addresses of user uuid, the default first then oldest first
+func (r *AddressRepository) FindAllByUserId(ctx context.Context, userID uuid.UUID) ([]models.UserAddress, error) {
+	var addresses []models.UserAddress
+	if err := r.db.SelectContext(ctx, &addresses, findAllByUserIdQuery, userID); err != nil {
+		return nil, errors.Wrap(err, "AddressRepository.FindAllByUserId.SelectContext")
+	}
+
+	return addresses, nil
+}
+
+// FindById Find address by uuid
+func (r *AddressRepository) FindById(ctx context.Context, addressID uuid.UUID) (*models.UserAddress, error) {
+	userAddress := &models.UserAddress{}
+	if err := r.db.GetContext(ctx, userAddress, findByIdQuery, addressID); err != nil {
+		return nil, errors.Wrap(err, "AddressRepository.FindById.GetContext")
+	}
+
+	return userAddress, nil
+}
+
+// FindDefaultByUserId Find default address of user uuid, sql.ErrNoRows when the user has none
+func (r *AddressRepository) FindDefaultByUserId(ctx context.Context, userID uuid.UUID) (*models.UserAddress, error) {
+	userAddress := &models.UserAddress{}
+	if err := r.db.GetContext(ctx, userAddress, findDefaultByUserIdQuery, userID); err != nil {
+		return nil, errors.Wrap(err, "AddressRepository.FindDefaultByUserId.GetContext")
+	}
+
+	return userAddress, nil
+}
+
+// UpdateById update existing address when its version is unchanged, an address made default takes the flag from
+// the previous one in the same transaction
+func (r *AddressRepository) UpdateById(ctx context.Context, userAddress *models.UserAddress) (*models.UserAddress, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "AddressRepository.UpdateById.BeginTxx")
+	}
+	defer tx.Rollback()
+
+	if userAddress.IsDefault {
+		if _, err := tx.ExecContext(ctx, clearDefaultQuery, userAddress.UserID, userAddress.AddressID); err != nil {
+			return nil, errors.Wrap(err, "AddressRepository.UpdateById.ClearDefault")
+		}
+	}
+
+	updatedAddress := &models.UserAddress{}
+	if err := tx.QueryRowxContext(
+		ctx,
+		updateByIdQuery,
+		userAddress.AddressID,
+		userAddress.Label,
+		userAddress.Recipient,
+		userAddress.Phone,
+		userAddress.Street,
+		userAddress.City,
+		userAddress.PostalCode,
+		userAddress.Region,
+		userAddress.Country,
+		userAddress.Latitude,
+		userAddress.Longitude,
+		userAddress.IsDefault,
+		userAddress.Version,
+	).StructScan(updatedAddress); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, models.ErrVersionConflict
+		}
+		return nil, errors.Wrap(err, "AddressRepository.UpdateById.QueryRowxContext")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "AddressRepository.UpdateById.Commit")
+	}
+
+	return updatedAddress, nil
+}
+
+// DeleteById delete address by uuid, orders keep their snapshot of it
+func (r *AddressRepository) DeleteById(ctx context.Context, addressID uuid.UUID) error {
+	if res, err := r.db.ExecContext(ctx, deleteByIdQuery, addressID); err != nil {
+		return errors.Wrap(err, "AddressRepository.DeleteById.ExecContext")
+	} else {
+		cnt, err := res.RowsAffected()
+		if err != nil {
+			return errors.Wrap(err, "AddressRepository.DeleteById.RowsAffected")
+		} else if cnt == 0 {
+			return sql.ErrNoRows
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,205 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/kalobranded/internal/models"
+)
+
+var addressColumns = []string{"address_id", "user_id", "label", "recipient", "phone", "street", "city", "postal_code", "country", "is_default", "version"}
+
+func TestAddressRepository_Create(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	addressPGRepository := NewAddressPGRepository(sqlxDB)
+
+	userUUID := uuid.New()
+	addressUUID := uuid.New()
+	mockAddress := &models.UserAddress{
+		UserID:     userUUID,
+		Label:      "Home",
+		Recipient:  "Recipient",
+		Phone:      "08123456789",
+		Street:     "Jl. Sudirman 1",
+		City:       "Jakarta",
+		PostalCode: "10220",
+		Country:    "ID",
+		IsDefault:  true,
+	}
+
+	rows := sqlmock.NewRows(addressColumns).AddRow(
+		addressUUID,
+		userUUID,
+		mockAddress.Label,
+		mockAddress.Recipient,
+		mockAddress.Phone,
+		mockAddress.Street,
+		mockAddress.City,
+		mockAddress.PostalCode,
+		mockAddress.Country,
+		true,
+		1,
+	)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(clearDefaultQuery).WithArgs(userUUID, uuid.Nil).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(createAddressQuery).WithArgs(
+		mockAddress.UserID,
+		mockAddress.Label,
+		mockAddress.Recipient,
+		mockAddress.Phone,
+		mockAddress.Street,
+		mockAddress.City,
+		mockAddress.PostalCode,
+		mockAddress.Region,
+		mockAddress.Country,
+		mockAddress.Latitude,
+		mockAddress.Longitude,
+		mockAddress.IsDefault,
+	).WillReturnRows(rows)
+	mock.ExpectCommit()
+
+	createdAddress, err := addressPGRepository.Create(context.Background(), mockAddress)
+	require.NoError(t, err)
+	require.Equal(t, addressUUID, createdAddress.AddressID)
+	require.True(t, createdAddress.IsDefault)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAddressRepository_FindDefaultByUserId(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	addressPGRepository := NewAddressPGRepository(sqlxDB)
+
+	userUUID := uuid.New()
+	addressUUID := uuid.New()
+	rows := sqlmock.NewRows(addressColumns).
+		AddRow(addressUUID, userUUID, "Home", "Recipient", "08123456789", "Jl. Sudirman 1", "Jakarta", "10220", "ID", true, 1)
+
+	mock.ExpectQuery(findDefaultByUserIdQuery).WithArgs(userUUID).WillReturnRows(rows)
+
+	foundAddress, err := addressPGRepository.FindDefaultByUserId(context.Background(), userUUID)
+	require.NoError(t, err)
+	require.Equal(t, addressUUID, foundAddress.AddressID)
+
+	t.Run("None", func(t *testing.T) {
+		mock.ExpectQuery(findDefaultByUserIdQuery).WithArgs(userUUID).WillReturnRows(sqlmock.NewRows(addressColumns))
+
+		_, err := addressPGRepository.FindDefaultByUserId(context.Background(), userUUID)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
+}
+
+func TestAddressRepository_UpdateById(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	addressPGRepository := NewAddressPGRepository(sqlxDB)
+
+	lat, lng := -6.2146, 106.8223
+	mockAddress := &models.UserAddress{
+		AddressID: uuid.New(),
+		UserID:    uuid.New(),
+		Recipient: "Recipient",
+		Phone:     "08123456789",
+		Street:    "Jl. Sudirman 1",
+		City:      "Jakarta",
+		Country:   "ID",
+		Latitude:  &lat,
+		Longitude: &lng,
+		IsDefault: true,
+		Version:   1,
+	}
+	args := []driver.Value{
+		mockAddress.AddressID,
+		mockAddress.Label,
+		mockAddress.Recipient,
+		mockAddress.Phone,
+		mockAddress.Street,
+		mockAddress.City,
+		mockAddress.PostalCode,
+		mockAddress.Region,
+		mockAddress.Country,
+		mockAddress.Latitude,
+		mockAddress.Longitude,
+		mockAddress.IsDefault,
+		mockAddress.Version,
+	}
+
+	rows := sqlmock.NewRows(addressColumns).AddRow(
+		mockAddress.AddressID, mockAddress.UserID, "", mockAddress.Recipient, mockAddress.Phone, mockAddress.Street, mockAddress.City, "", mockAddress.Country, true, 2,
+	)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(clearDefaultQuery).WithArgs(mockAddress.UserID, mockAddress.AddressID).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(updateByIdQuery).WithArgs(args...).WillReturnRows(rows)
+	mock.ExpectCommit()
+
+	updatedAddress, err := addressPGRepository.UpdateById(context.Background(), mockAddress)
+	require.NoError(t, err)
+	require.Equal(t, 2, updatedAddress.Version)
+
+	t.Run("VersionConflict", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(clearDefaultQuery).WithArgs(mockAddress.UserID, mockAddress.AddressID).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(updateByIdQuery).WithArgs(args...).WillReturnRows(sqlmock.NewRows(addressColumns))
+		mock.ExpectRollback()
+
+		_, err := addressPGRepository.UpdateById(context.Background(), mockAddress)
+		require.ErrorIs(t, err, models.ErrVersionConflict)
+	})
+}
+
+func TestAddressRepository_DeleteById(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	addressPGRepository := NewAddressPGRepository(sqlxDB)
+
+	addressUUID := uuid.New()
+	mock.ExpectExec(deleteByIdQuery).WithArgs(addressUUID).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = addressPGRepository.DeleteById(context.Background(), addressUUID)
+	require.NoError(t, err)
+
+	t.Run("NotFound", func(t *testing.T) {
+		mock.ExpectExec(deleteByIdQuery).WithArgs(addressUUID).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := addressPGRepository.DeleteById(context.Background(), addressUUID)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
+}
@@ -0,0 +1,20 @@
+package repository
+
+const (
+	clearDefaultQuery = `UPDATE user_addresses SET is_default = FALSE, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE user_id = $1 AND address_id <> $2 AND is_default`
+
+	createAddressQuery = `INSERT INTO user_addresses (user_id, label, recipient, phone, street, city, postal_code, region, country, latitude, longitude, is_default) 
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12 OR NOT EXISTS (SELECT 1 FROM user_addresses WHERE user_id = $1 AND is_default))
+		RETURNING address_id, user_id, label, recipient, phone, street, city, postal_code, region, country, latitude, longitude, is_default, version, created_at, updated_at`
+
+	findAllByUserIdQuery = `SELECT address_id, user_id, label, recipient, phone, street, city, postal_code, region, country, latitude, longitude, is_default, version, created_at, updated_at FROM user_addresses WHERE user_id = $1 ORDER BY is_default DESC, created_at`
+
+	findByIdQuery = `SELECT address_id, user_id, label, recipient, phone, street, city, postal_code, region, country, latitude, longitude, is_default, version, created_at, updated_at FROM user_addresses WHERE address_id = $1`
+
+	findDefaultByUserIdQuery = `SELECT address_id, user_id, label, recipient, phone, street, city, postal_code, region, country, latitude, longitude, is_default, version, created_at, updated_at FROM user_addresses WHERE user_id = $1 AND is_default`
+
+	updateByIdQuery = `UPDATE user_addresses SET label = $2, recipient = $3, phone = $4, street = $5, city = $6, postal_code = $7, region = $8, country = $9, latitude = $10, longitude = $11, is_default = $12, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE address_id = $1 AND version = $13
+		RETURNING address_id, user_id, label, recipient, phone, street, city, postal_code, region, country, latitude, longitude, is_default, version, created_at, updated_at`
+
+	deleteByIdQuery = `DELETE FROM user_addresses WHERE address_id = $1`
+)
@@ -0,0 +1,20 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase.go -package mock
+package address
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/internal/models"
+)
+
+// Address UseCase interface
+type AddressUseCase interface {
+	Create(ctx context.Context, address *models.UserAddress) (*models.UserAddress, error)
+	FindAllByUserId(ctx context.Context, userID uuid.UUID) ([]models.UserAddress, error)
+	FindById(ctx context.Context, addressID uuid.UUID) (*models.UserAddress, error)
+	FindDefaultByUserId(ctx context.Context, userID uuid.UUID) (*models.UserAddress, error)
+	UpdateById(ctx context.Context, address *models.UserAddress) (*models.UserAddress, error)
+	DeleteById(ctx context.Context, addressID uuid.UUID) error
+}
@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/address"
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/pkg/logger"
+)
+
+// Address UseCase
+type addressUseCase struct {
+	cfg           *config.Config
+	logger        logger.Logger
+	addressPgRepo address.AddressPGRepository
+}
+
+var _ address.AddressUseCase = (*addressUseCase)(nil)
+
+// New Address UseCase
+func NewAddressUseCase(cfg *config.Config, logger logger.Logger, addressRepo address.AddressPGRepository) *addressUseCase {
+	return &addressUseCase{cfg: cfg, logger: logger, addressPgRepo: addressRepo}
+}
+
+// Create new address
+func (u *addressUseCase) Create(ctx context.Context, userAddress *models.UserAddress) (*models.UserAddress, error) {
+	createdAddress, err := u.addressPgRepo.Create(ctx, userAddress)
+	if err != nil {
+		return nil, errors.Wrap(err, "addressPgRepo.Create")
+	}
+
+	return createdAddress, nil
+}
+
+// FindAllByUserId find addresses of user
+func (u *addressUseCase) FindAllByUserId(ctx context.Context, userID uuid.UUID) ([]models.UserAddress, error) {
+	addresses, err := u.addressPgRepo.FindAllByUserId(ctx, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "addressPgRepo.FindAllByUserId")
+	}
+
+	return addresses, nil
+}
+
+// FindById find address by uuid
+func (u *addressUseCase) FindById(ctx context.Context, addressID uuid.UUID) (*models.UserAddress, error) {
+	foundAddress, err := u.addressPgRepo.FindById(ctx, addressID)
+	if err != nil {
+		return nil, errors.Wrap(err, "addressPgRepo.FindById")
+	}
+
+	return foundAddress, nil
+}
+
+// FindDefaultByUserId find default address of user, sql.ErrNoRows when the user has none
+func (u *addressUseCase) FindDefaultByUserId(ctx context.Context, userID uuid.UUID) (*models.UserAddress, error) {
+	foundAddress, err := u.addressPgRepo.FindDefaultByUserId(ctx, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "addressPgRepo.FindDefaultByUserId")
+	}
+
+	return foundAddress, nil
+}
+
+// UpdateById update existing address
+func (u *addressUseCase) UpdateById(ctx context.Context, userAddress *models.UserAddress) (*models.UserAddress, error) {
+	updatedAddress, err := u.addressPgRepo.UpdateById(ctx, userAddress)
+	if err != nil {
+		return nil, errors.Wrap(err, "addressPgRepo.UpdateById")
+	}
+
+	return updatedAddress, nil
+}
+
+// DeleteById delete address by uuid
+func (u *addressUseCase) DeleteById(ctx context.Context, addressID uuid.UUID) error {
+	if err := u.addressPgRepo.DeleteById(ctx, addressID); err != nil {
+		return errors.Wrap(err, "addressPgRepo.DeleteById")
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/address/mock"
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/pkg/logger"
+)
+
+func TestAddressUseCase_Create(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	addressPGRepository := mock.NewMockAddressPGRepository(ctrl)
+	apiLogger := logger.NewAppLogger(nil)
+
+	cfg := &config.Config{}
+	addressUC := NewAddressUseCase(cfg, apiLogger, addressPGRepository)
+
+	ctx := context.Background()
+	mockAddress := &models.UserAddress{UserID: uuid.New(), Recipient: "Recipient", Street: "Jl. Sudirman 1", City: "Jakarta", Country: "ID"}
+
+	addressPGRepository.EXPECT().Create(gomock.Any(), mockAddress).Return(&models.UserAddress{AddressID: uuid.New(), IsDefault: true}, nil)
+
+	createdAddress, err := addressUC.Create(ctx, mockAddress)
+	require.NoError(t, err)
+	require.True(t, createdAddress.IsDefault)
+}
+
+func TestAddressUseCase_FindDefaultByUserId(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	addressPGRepository := mock.NewMockAddressPGRepository(ctrl)
+	apiLogger := logger.NewAppLogger(nil)
+
+	cfg := &config.Config{}
+	addressUC := NewAddressUseCase(cfg, apiLogger, addressPGRepository)
+
+	ctx := context.Background()
+	userUUID := uuid.New()
+
+	addressPGRepository.EXPECT().FindDefaultByUserId(gomock.Any(), userUUID).Return(nil, sql.ErrNoRows)
+
+	_, err := addressUC.FindDefaultByUserId(ctx, userUUID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
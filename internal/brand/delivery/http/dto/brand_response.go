@@ -9,21 +9,31 @@ import (
 )
 
 type BrandResponseDto struct {
-	BrandID       uuid.UUID `json:"brand_id"`
-	BrandName     string    `json:"brand_name"`
-	Logo          *string   `json:"logo"`
-	PickupAddress string    `json:"pickup_address"`
-	CreatedAt     time.Time `json:"created_at"`
-	UpdatedAt     time.Time `json:"updated_at"`
+	BrandID          uuid.UUID  `json:"brand_id"`
+	BrandName        string     `json:"brand_name"`
+	Logo             *string    `json:"logo"`
+	PickupAddress    string     `json:"pickup_address"`
+	PickupLatitude   *float64   `json:"pickup_latitude,omitempty"`
+	PickupLongitude  *float64   `json:"pickup_longitude,omitempty"`
+	ReturnWindowDays int        `json:"return_window_days"`
+	Version          int        `json:"version"`
+	DeletedAt        *time.Time `json:"deleted_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
 }
 
 func BrandResponseFromModel(brand *models.Brand) *BrandResponseDto {
 	return &BrandResponseDto{
-		BrandID:       brand.BrandID,
-		BrandName:     brand.BrandName,
-		Logo:          brand.Logo,
-		PickupAddress: brand.PickupAddress,
-		CreatedAt:     brand.CreatedAt,
-		UpdatedAt:     brand.UpdatedAt,
+		BrandID:          brand.BrandID,
+		BrandName:        brand.BrandName,
+		Logo:             brand.Logo,
+		PickupAddress:    brand.PickupAddress,
+		PickupLatitude:   brand.PickupLatitude,
+		PickupLongitude:  brand.PickupLongitude,
+		ReturnWindowDays: brand.ReturnWindowDays,
+		Version:          brand.Version,
+		DeletedAt:        brand.DeletedAt,
+		CreatedAt:        brand.CreatedAt,
+		UpdatedAt:        brand.UpdatedAt,
 	}
 }
@@ -5,9 +5,12 @@ import (
 )
 
 type BrandRegisterRequestDto struct {
-	BrandName     string  `json:"brand_name" validate:"required,lte=30"`
-	PickupAddress string  `json:"pickup_address" validate:"required"`
-	Logo          *string `json:"logo"`
+	BrandName        string   `json:"brand_name" validate:"required,lte=30"`
+	PickupAddress    string   `json:"pickup_address" validate:"required"`
+	PickupLatitude   *float64 `json:"pickup_latitude" validate:"omitempty,gte=-90,lte=90"`
+	PickupLongitude  *float64 `json:"pickup_longitude" validate:"omitempty,gte=-180,lte=180"`
+	Logo             *string  `json:"logo"`
+	ReturnWindowDays *int     `json:"return_window_days" validate:"omitempty,gte=0"`
 }
 
 type BrandRegisterResponseDto struct {
@@ -1,7 +1,10 @@
 package dto
 
 type BrandUpdateRequestDto struct {
-	BrandName     *string `json:"brand_name" validate:"omitempty,lte=30"`
-	Logo          *string `json:"logo" validate:"omitempty"`
-	PickupAddress *string `json:"pickup_address" validate:"omitempty"`
+	BrandName        *string  `json:"brand_name" validate:"omitempty,lte=30"`
+	Logo             *string  `json:"logo" validate:"omitempty"`
+	PickupAddress    *string  `json:"pickup_address" validate:"omitempty"`
+	PickupLatitude   *float64 `json:"pickup_latitude" validate:"omitempty,gte=-90,lte=90"`
+	PickupLongitude  *float64 `json:"pickup_longitude" validate:"omitempty,gte=-180,lte=180"`
+	ReturnWindowDays *int     `json:"return_window_days" validate:"omitempty,gte=0"`
 }
@@ -3,6 +3,7 @@ package handlers
 import (
 	"encoding/json"
 	"net/http"
+	"strings"
 
 	"github.com/go-playground/validator"
 	"github.com/google/uuid"
@@ -12,15 +13,17 @@ import (
 	"github.com/dinorain/kalobranded/internal/brand/delivery/http/dto"
 	"github.com/dinorain/kalobranded/internal/middlewares"
 	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/internal/server/router"
 	"github.com/dinorain/kalobranded/internal/session"
 	"github.com/dinorain/kalobranded/pkg/constants"
+	"github.com/dinorain/kalobranded/pkg/geo"
 	httpErrors "github.com/dinorain/kalobranded/pkg/http_errors"
 	"github.com/dinorain/kalobranded/pkg/logger"
 	"github.com/dinorain/kalobranded/pkg/utils"
 )
 
 type brandHandlersHTTP struct {
-	mux     *http.ServeMux
+	router  *router.Router
 	logger  logger.Logger
 	cfg     *config.Config
 	mw      middlewares.MiddlewareManager
@@ -32,7 +35,7 @@ type brandHandlersHTTP struct {
 var _ brand.BrandHandlers = (*brandHandlersHTTP)(nil)
 
 func NewBrandHandlersHTTP(
-	mux *http.ServeMux,
+	router *router.Router,
 	logger logger.Logger,
 	cfg *config.Config,
 	mw middlewares.MiddlewareManager,
@@ -40,7 +43,7 @@ func NewBrandHandlersHTTP(
 	brandUC brand.BrandUseCase,
 	sessUC session.SessUseCase,
 ) *brandHandlersHTTP {
-	return &brandHandlersHTTP{mux: mux, logger: logger, cfg: cfg, mw: mw, v: v, brandUC: brandUC, sessUC: sessUC}
+	return &brandHandlersHTTP{router: router, logger: logger, cfg: cfg, mw: mw, v: v, brandUC: brandUC, sessUC: sessUC}
 }
 
 // Create
@@ -52,7 +55,7 @@ func NewBrandHandlersHTTP(
 // @Security ApiKeyAuth
 // @Param payload body dto.BrandRegisterRequestDto true "Payload"
 // @Success 200 {object} dto.BrandRegisterResponseDto
-// @Router /brand/create [post]
+// @Router /brands [post]
 func (h *brandHandlersHTTP) Create(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -70,6 +73,11 @@ func (h *brandHandlersHTTP) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := geo.CheckCoordinates(createDto.PickupLatitude, createDto.PickupLongitude); err != nil {
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
 	brand, err := h.registerReqToBrandModel(createDto)
 	if err != nil {
 		h.logger.Errorf("registerReqToBrandModel: %v", err)
@@ -93,43 +101,41 @@ func (h *brandHandlersHTTP) Create(w http.ResponseWriter, r *http.Request) {
 // FindAll
 // @Tags Brands
 // @Summary Find all brands
-// @Description Find all brands
+// @Description Find all brands, newest first unless sorted. sort is one of created_at and brand_name, descending when prefixed with -
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
-// @Param id query string false "brand"
+// @Param name query string false "part of the brand name, case insensitive"
+// @Param sort query string false "sort key, e.g. brand_name"
 // @Param size query string false "pagination size"
 // @Param page query string false "pagination page"
 // @Success 200 {object} dto.BrandFindResponseDto
-// @Router /brand [get]
+// @Param include_deleted query bool false "admin only, include soft deleted brands"
+// @Router /brands [get]
 func (h *brandHandlersHTTP) FindAll(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	queryParam := r.URL.Query()
+	pq := utils.NewPaginationFromQueryParams(queryParam.Get(constants.Size), queryParam.Get(constants.Page))
+	pq.SetOrderBy(queryParam.Get(constants.Sort))
 
-	if queryParam.Get("id") != "" {
-		brandUUID, err := uuid.Parse(r.URL.Query().Get("id"))
-		if err != nil {
-			_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
-			return
-		}
-
-		brand, err := h.brandUC.CachedFindById(ctx, brandUUID)
-		if err != nil {
-			h.logger.Errorf("brandUC.CachedFindById: %v", err)
-			_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
-			return
-		}
+	if err := models.BrandSorts.Validate(pq.GetOrderBy()); err != nil {
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+	filter := &models.BrandFilter{Name: strings.TrimSpace(queryParam.Get(constants.Name))}
 
-		res, _ := json.Marshal(dto.BrandResponseFromModel(brand))
-		w.WriteHeader(http.StatusOK)
-		w.Write(res)
+	includeDeleted, err := h.mw.IncludeDeleted(w, r)
+	if err != nil {
 		return
 	}
 
-	pq := utils.NewPaginationFromQueryParams(queryParam.Get(constants.Size), queryParam.Get(constants.Page))
+	findAll := h.brandUC.FindAll
+	if includeDeleted {
+		findAll = h.brandUC.FindAllWithDeleted
+	}
 
 	var brands []models.Brand
-	if res, err := h.brandUC.FindAll(ctx, pq); err != nil {
+	if res, err := findAll(ctx, filter, pq); err != nil {
 		h.logger.Errorf("brandUC.FindAll: %v", err)
 		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
 		return
@@ -156,30 +162,171 @@ func (h *brandHandlersHTTP) FindAll(w http.ResponseWriter, r *http.Request) {
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
-// @Param id query string true "brand uuid"
+// @Param id path string true "brand uuid"
+// @Param include_deleted query bool false "admin only, find soft deleted brand"
 // @Success 200 {object} dto.BrandResponseDto
-// @Router /brand [get]
+// @Header 200 {string} ETag "resource version"
+// @Router /brands/{id} [get]
 func (h *brandHandlersHTTP) FindById(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	queryParam := r.URL.Query()
+	brandUUID, err := uuid.Parse(router.Param(r, constants.ID))
+	if err != nil {
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	includeDeleted, err := h.mw.IncludeDeleted(w, r)
+	if err != nil {
+		return
+	}
+
+	findById := h.brandUC.CachedFindById
+	if includeDeleted {
+		findById = h.brandUC.FindByIdWithDeleted
+	}
 
-	if queryParam.Get("id") == "" {
-		_ = httpErrors.NewBadRequestError(w, nil, h.cfg.Http.DebugErrorsResponse)
+	brand, err := findById(ctx, brandUUID)
+	if err != nil {
+		h.logger.Errorf("brandUC.FindById: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
 		return
 	}
-	brandUUID, err := uuid.Parse(r.URL.Query().Get("id"))
+
+	w.Header().Set(constants.ETag, utils.ETag(brand.Version))
+	res, _ := json.Marshal(dto.BrandResponseFromModel(brand))
+	w.WriteHeader(http.StatusOK)
+	w.Write(res)
+	return
+}
+
+// UpdateById
+// @Tags Brands
+// @Summary Update brand
+// @Description Admin update brand, only provided fields are changed
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "brand uuid"
+// @Param If-Match header string false "ETag of the version being changed"
+// @Param payload body dto.BrandUpdateRequestDto true "Payload"
+// @Success 200 {object} dto.BrandResponseDto
+// @Header 200 {string} ETag "resource version"
+// @Router /brands/{id} [patch]
+func (h *brandHandlersHTTP) UpdateById(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	brandUUID, err := uuid.Parse(router.Param(r, constants.ID))
 	if err != nil {
 		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
 		return
 	}
 
-	brand, err := h.brandUC.CachedFindById(ctx, brandUUID)
+	updateDto := &dto.BrandUpdateRequestDto{}
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&updateDto); err != nil {
+		h.logger.Errorf("decoder.Decode: %v", err)
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	if err := h.v.Struct(updateDto); err != nil {
+		h.logger.Errorf("h.v.Struct: %v", err)
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	if err := geo.CheckCoordinates(updateDto.PickupLatitude, updateDto.PickupLongitude); err != nil {
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	brand, err := h.brandUC.FindById(ctx, brandUUID)
 	if err != nil {
-		h.logger.Errorf("brandUC.CachedFindById: %v", err)
+		h.logger.Errorf("brandUC.FindById: %v", err)
 		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
 		return
 	}
 
+	if !utils.IfMatch(r.Header.Get(constants.IfMatch), brand.Version) {
+		_ = httpErrors.ErrorCtxResponse(w, httpErrors.PreconditionFailed, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	if err := h.updateReqToBrandModel(brand, updateDto); err != nil {
+		h.logger.Errorf("updateReqToBrandModel: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	updatedBrand, err := h.brandUC.UpdateById(ctx, brand)
+	if err != nil {
+		h.logger.Errorf("brandUC.UpdateById: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	w.Header().Set(constants.ETag, utils.ETag(updatedBrand.Version))
+	res, _ := json.Marshal(dto.BrandResponseFromModel(updatedBrand))
+	w.WriteHeader(http.StatusOK)
+	w.Write(res)
+	return
+}
+
+// DeleteById
+// @Tags Brands
+// @Summary Delete brand
+// @Description Admin delete brand
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "brand uuid"
+// @Param If-Match header string false "ETag of the version being changed"
+// @Success 204 {object} nil
+// @Router /brands/{id} [delete]
+func (h *brandHandlersHTTP) DeleteById(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	brandUUID, err := uuid.Parse(router.Param(r, constants.ID))
+	if err != nil {
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	if err := h.brandUC.DeleteById(ctx, brandUUID, utils.IfMatchVersions(r.Header.Get(constants.IfMatch))); err != nil {
+		h.logger.Errorf("brandUC.DeleteById: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return
+}
+
+// RestoreById
+// @Tags Brands
+// @Summary Restore brand
+// @Description Admin restore soft deleted brand
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "brand uuid"
+// @Success 200 {object} dto.BrandResponseDto
+// @Header 200 {string} ETag "resource version"
+// @Router /brands/{id}/restore [post]
+func (h *brandHandlersHTTP) RestoreById(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	brandUUID, err := uuid.Parse(router.Param(r, constants.ID))
+	if err != nil {
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	brand, err := h.brandUC.RestoreById(ctx, brandUUID)
+	if err != nil {
+		h.logger.Errorf("brandUC.RestoreById: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	w.Header().Set(constants.ETag, utils.ETag(brand.Version))
 	res, _ := json.Marshal(dto.BrandResponseFromModel(brand))
 	w.WriteHeader(http.StatusOK)
 	w.Write(res)
@@ -188,9 +335,15 @@ func (h *brandHandlersHTTP) FindById(w http.ResponseWriter, r *http.Request) {
 
 func (h *brandHandlersHTTP) registerReqToBrandModel(r *dto.BrandRegisterRequestDto) (*models.Brand, error) {
 	brandCandidate := &models.Brand{
-		BrandName:     r.BrandName,
-		PickupAddress: r.PickupAddress,
-		Logo:          r.Logo,
+		BrandName:        r.BrandName,
+		PickupAddress:    r.PickupAddress,
+		PickupLatitude:   r.PickupLatitude,
+		PickupLongitude:  r.PickupLongitude,
+		Logo:             r.Logo,
+		ReturnWindowDays: models.DefaultReturnWindowDays,
+	}
+	if r.ReturnWindowDays != nil {
+		brandCandidate.ReturnWindowDays = *r.ReturnWindowDays
 	}
 
 	if err := brandCandidate.PrepareCreate(); err != nil {
@@ -199,3 +352,27 @@ func (h *brandHandlersHTTP) registerReqToBrandModel(r *dto.BrandRegisterRequestD
 
 	return brandCandidate, nil
 }
+
+func (h *brandHandlersHTTP) updateReqToBrandModel(brand *models.Brand, r *dto.BrandUpdateRequestDto) error {
+	if r.BrandName != nil {
+		brand.BrandName = *r.BrandName
+	}
+	if r.PickupAddress != nil {
+		if *r.PickupAddress != brand.PickupAddress {
+			// coordinates of the previous address would misplace the new one, it gets geocoded instead
+			brand.PickupLatitude, brand.PickupLongitude = nil, nil
+		}
+		brand.PickupAddress = *r.PickupAddress
+	}
+	if r.PickupLatitude != nil {
+		brand.PickupLatitude, brand.PickupLongitude = r.PickupLatitude, r.PickupLongitude
+	}
+	if r.Logo != nil {
+		brand.Logo = r.Logo
+	}
+	if r.ReturnWindowDays != nil {
+		brand.ReturnWindowDays = *r.ReturnWindowDays
+	}
+
+	return brand.PrepareCreate()
+}
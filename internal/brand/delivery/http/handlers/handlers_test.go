@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"bytes"
+	"database/sql"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -9,10 +10,13 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-playground/validator"
+	"github.com/golang-jwt/jwt"
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 
 	"github.com/dinorain/kalobranded/config"
@@ -20,6 +24,7 @@ import (
 	"github.com/dinorain/kalobranded/internal/brand/mock"
 	"github.com/dinorain/kalobranded/internal/middlewares"
 	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/internal/server/router"
 	mockSessUC "github.com/dinorain/kalobranded/internal/session/mock"
 	"github.com/dinorain/kalobranded/pkg/converter"
 	"github.com/dinorain/kalobranded/pkg/logger"
@@ -40,8 +45,8 @@ func TestBrandsHandler_Create(t *testing.T) {
 
 	v := validator.New()
 
-	mux := http.NewServeMux()
-	handlers := NewBrandHandlersHTTP(mux, appLogger, cfg, mw, v, brandUC, sessUC)
+	rt := router.NewRouter(false)
+	handlers := NewBrandHandlersHTTP(rt, appLogger, cfg, mw, v, brandUC, sessUC)
 
 	reqDto := &dto.BrandRegisterRequestDto{
 		BrandName:     "BrandName",
@@ -55,7 +60,7 @@ func TestBrandsHandler_Create(t *testing.T) {
 	brandUUID := uuid.New()
 	sessUUID := uuid.New()
 
-	req := httptest.NewRequest(http.MethodPost, "/brand/create", buf)
+	req := httptest.NewRequest(http.MethodPost, "/brands", buf)
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 
@@ -97,8 +102,8 @@ func TestBrandsHandler_Find(t *testing.T) {
 
 	v := validator.New()
 
-	mux := http.NewServeMux()
-	handlers := NewBrandHandlersHTTP(mux, appLogger, cfg, mw, v, brandUC, sessUC)
+	rt := router.NewRouter(false)
+	handlers := NewBrandHandlersHTTP(rt, appLogger, cfg, mw, v, brandUC, sessUC)
 
 	brandUUID := uuid.New()
 
@@ -118,11 +123,11 @@ func TestBrandsHandler_Find(t *testing.T) {
 	})
 
 	t.Run("FindAll", func(t *testing.T) {
-		req := httptest.NewRequest(http.MethodGet, "/brand", nil)
+		req := httptest.NewRequest(http.MethodGet, "/brands?name=%20kalo&sort=brand_name", nil)
 		req.Header.Set("Content-Type", "application/json")
 		w := httptest.NewRecorder()
 
-		brandUC.EXPECT().FindAll(gomock.Any(), gomock.Any()).AnyTimes().Return(brands, nil)
+		brandUC.EXPECT().FindAll(gomock.Any(), &models.BrandFilter{Name: "kalo"}, gomock.Any()).AnyTimes().Return(brands, nil)
 
 		handler := http.HandlerFunc(handlers.FindAll)
 		handler.ServeHTTP(w, req)
@@ -143,13 +148,13 @@ func TestBrandsHandler_Find(t *testing.T) {
 	})
 
 	t.Run("FindById", func(t *testing.T) {
-		req := httptest.NewRequest(http.MethodGet, "/brand?id="+m.BrandID.String(), nil)
+		req := router.WithParams(httptest.NewRequest(http.MethodGet, "/brands/"+m.BrandID.String(), nil), map[string]string{"id": m.BrandID.String()})
 		req.Header.Set("Content-Type", "application/json")
 		w := httptest.NewRecorder()
 
 		brandUC.EXPECT().CachedFindById(gomock.Any(), gomock.Any()).AnyTimes().Return(&m, nil)
 
-		handler := http.HandlerFunc(handlers.FindAll)
+		handler := http.HandlerFunc(handlers.FindById)
 		handler.ServeHTTP(w, req)
 
 		res := w.Result()
@@ -168,5 +173,290 @@ func TestBrandsHandler_Find(t *testing.T) {
 		}
 
 		require.Equal(t, m.BrandID.String(), resDto.BrandID.String())
+		require.Equal(t, `"0"`, res.Header.Get("ETag"))
+	})
+
+	signedToken := func(role string) string {
+		token := jwt.New(jwt.SigningMethodHS256)
+		claims := token.Claims.(jwt.MapClaims)
+		claims["session_id"] = uuid.New().String()
+		claims["user_id"] = uuid.New().String()
+		claims["role"] = role
+		claims["exp"] = time.Now().Add(time.Minute * 15).Unix()
+		validToken, _ := token.SignedString([]byte(cfg.Server.JwtSecretKey))
+		return validToken
+	}
+
+	t.Run("FindAllIncludeDeleted", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/brands?include_deleted=true", nil)
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", signedToken(models.UserRoleAdmin)))
+		w := httptest.NewRecorder()
+
+		brandUC.EXPECT().FindAllWithDeleted(gomock.Any(), gomock.Any(), gomock.Any()).Return(brands, nil)
+
+		http.HandlerFunc(handlers.FindAll).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("FindByIdIncludeDeleted", func(t *testing.T) {
+		deletedAt := time.Now()
+		deleted := models.Brand{BrandID: brandUUID, BrandName: "BrandName", DeletedAt: &deletedAt}
+
+		req := router.WithParams(httptest.NewRequest(http.MethodGet, "/brands/"+brandUUID.String()+"?include_deleted=true", nil), map[string]string{"id": brandUUID.String()})
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", signedToken(models.UserRoleAdmin)))
+		w := httptest.NewRecorder()
+
+		brandUC.EXPECT().FindByIdWithDeleted(gomock.Any(), brandUUID).Return(&deleted, nil)
+
+		http.HandlerFunc(handlers.FindById).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		resDto := &dto.BrandResponseDto{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), resDto))
+		require.NotNil(t, resDto.DeletedAt)
+	})
+
+	t.Run("IncludeDeletedForbidden", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/brands?include_deleted=true", nil)
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", signedToken(models.UserRoleUser)))
+		w := httptest.NewRecorder()
+
+		http.HandlerFunc(handlers.FindAll).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("IncludeDeletedUnauthorized", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/brands?include_deleted=true", nil)
+		w := httptest.NewRecorder()
+
+		http.HandlerFunc(handlers.FindAll).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
+
+func TestBrandsHandler_UpdateById(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	brandUC := mock.NewMockBrandUseCase(ctrl)
+	sessUC := mockSessUC.NewMockSessUseCase(ctrl)
+
+	cfg := &config.Config{Session: config.Session{Expire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	appLogger := logger.NewAppLogger(cfg)
+	appLogger.InitLogger()
+	mw := middlewares.NewMiddlewareManager(appLogger, cfg)
+
+	v := validator.New()
+
+	rt := router.NewRouter(false)
+	handlers := NewBrandHandlersHTTP(rt, appLogger, cfg, mw, v, brandUC, sessUC)
+	handlers.BrandMapRoutes()
+
+	brandUUID := uuid.New()
+	brandName := "NewBrandName"
+
+	t.Run("PartialUpdate", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		_ = json.NewEncoder(buf).Encode(&dto.BrandUpdateRequestDto{BrandName: &brandName})
+
+		req := router.WithParams(httptest.NewRequest(http.MethodPatch, "/brands/"+brandUUID.String(), buf), map[string]string{"id": brandUUID.String()})
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		brandUC.EXPECT().FindById(gomock.Any(), brandUUID).Return(&models.Brand{BrandID: brandUUID, BrandName: "BrandName", PickupAddress: "PickupAddress"}, nil)
+		brandUC.EXPECT().UpdateById(gomock.Any(), &models.Brand{BrandID: brandUUID, BrandName: brandName, PickupAddress: "PickupAddress"}).DoAndReturn(func(_ interface{}, b *models.Brand) (*models.Brand, error) {
+			return b, nil
+		})
+
+		http.HandlerFunc(handlers.UpdateById).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		resDto := &dto.BrandResponseDto{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), resDto))
+		require.Equal(t, brandName, resDto.BrandName)
+		require.Equal(t, "PickupAddress", resDto.PickupAddress)
+	})
+
+	t.Run("IfMatch", func(t *testing.T) {
+		req := router.WithParams(httptest.NewRequest(http.MethodPatch, "/brands/"+brandUUID.String(), strings.NewReader(`{"brand_name": "NewBrandName"}`)), map[string]string{"id": brandUUID.String()})
+		req.Header.Set("If-Match", `"3"`)
+		w := httptest.NewRecorder()
+
+		brandUC.EXPECT().FindById(gomock.Any(), brandUUID).Return(&models.Brand{BrandID: brandUUID, BrandName: "BrandName", PickupAddress: "PickupAddress", Version: 3}, nil)
+		brandUC.EXPECT().UpdateById(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, b *models.Brand) (*models.Brand, error) {
+			b.Version++
+			return b, nil
+		})
+
+		http.HandlerFunc(handlers.UpdateById).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, `"4"`, w.Header().Get("ETag"))
+	})
+
+	t.Run("PreconditionFailed", func(t *testing.T) {
+		req := router.WithParams(httptest.NewRequest(http.MethodPatch, "/brands/"+brandUUID.String(), strings.NewReader(`{"brand_name": "NewBrandName"}`)), map[string]string{"id": brandUUID.String()})
+		req.Header.Set("If-Match", `"2"`)
+		w := httptest.NewRecorder()
+
+		brandUC.EXPECT().FindById(gomock.Any(), brandUUID).Return(&models.Brand{BrandID: brandUUID, BrandName: "BrandName", PickupAddress: "PickupAddress", Version: 3}, nil)
+
+		http.HandlerFunc(handlers.UpdateById).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusPreconditionFailed, w.Code)
+	})
+
+	t.Run("Conflict", func(t *testing.T) {
+		req := router.WithParams(httptest.NewRequest(http.MethodPatch, "/brands/"+brandUUID.String(), strings.NewReader(`{"brand_name": "NewBrandName"}`)), map[string]string{"id": brandUUID.String()})
+		w := httptest.NewRecorder()
+
+		brandUC.EXPECT().FindById(gomock.Any(), brandUUID).Return(&models.Brand{BrandID: brandUUID, BrandName: "BrandName", PickupAddress: "PickupAddress", Version: 3}, nil)
+		brandUC.EXPECT().UpdateById(gomock.Any(), gomock.Any()).Return(nil, errors.Wrap(models.ErrVersionConflict, "brandPgRepo.UpdateById"))
+
+		http.HandlerFunc(handlers.UpdateById).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		req := router.WithParams(httptest.NewRequest(http.MethodPatch, "/brands/"+brandUUID.String(), strings.NewReader(`{}`)), map[string]string{"id": brandUUID.String()})
+		w := httptest.NewRecorder()
+
+		brandUC.EXPECT().FindById(gomock.Any(), brandUUID).Return(nil, sql.ErrNoRows)
+
+		http.HandlerFunc(handlers.UpdateById).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Forbidden", func(t *testing.T) {
+		token := jwt.New(jwt.SigningMethodHS256)
+		claims := token.Claims.(jwt.MapClaims)
+		claims["session_id"] = uuid.New().String()
+		claims["user_id"] = uuid.New().String()
+		claims["role"] = models.UserRoleUser
+		claims["exp"] = time.Now().Add(time.Minute * 15).Unix()
+		validToken, _ := token.SignedString([]byte(cfg.Server.JwtSecretKey))
+
+		req := httptest.NewRequest(http.MethodPatch, "/brands/"+brandUUID.String(), strings.NewReader(`{}`))
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", validToken))
+		w := httptest.NewRecorder()
+
+		rt.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
+
+func TestBrandsHandler_DeleteById(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	brandUC := mock.NewMockBrandUseCase(ctrl)
+	sessUC := mockSessUC.NewMockSessUseCase(ctrl)
+
+	cfg := &config.Config{Session: config.Session{Expire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	appLogger := logger.NewAppLogger(cfg)
+	appLogger.InitLogger()
+	mw := middlewares.NewMiddlewareManager(appLogger, cfg)
+
+	v := validator.New()
+
+	rt := router.NewRouter(false)
+	handlers := NewBrandHandlersHTTP(rt, appLogger, cfg, mw, v, brandUC, sessUC)
+
+	brandUUID := uuid.New()
+
+	req := router.WithParams(httptest.NewRequest(http.MethodDelete, "/brands/"+brandUUID.String(), nil), map[string]string{"id": brandUUID.String()})
+	w := httptest.NewRecorder()
+
+	brandUC.EXPECT().DeleteById(gomock.Any(), brandUUID, nil).Return(nil)
+
+	http.HandlerFunc(handlers.DeleteById).ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusNoContent, w.Code)
+
+	t.Run("NotFound", func(t *testing.T) {
+		req := router.WithParams(httptest.NewRequest(http.MethodDelete, "/brands/"+brandUUID.String(), nil), map[string]string{"id": brandUUID.String()})
+		w := httptest.NewRecorder()
+
+		brandUC.EXPECT().DeleteById(gomock.Any(), brandUUID, nil).Return(sql.ErrNoRows)
+
+		http.HandlerFunc(handlers.DeleteById).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("PreconditionFailed", func(t *testing.T) {
+		req := router.WithParams(httptest.NewRequest(http.MethodDelete, "/brands/"+brandUUID.String(), nil), map[string]string{"id": brandUUID.String()})
+		req.Header.Set("If-Match", `"1"`)
+		w := httptest.NewRecorder()
+
+		brandUC.EXPECT().DeleteById(gomock.Any(), brandUUID, []int{1}).Return(models.ErrPreconditionFailed)
+
+		http.HandlerFunc(handlers.DeleteById).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusPreconditionFailed, w.Code)
+	})
+}
+
+func TestBrandsHandler_RestoreById(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	brandUC := mock.NewMockBrandUseCase(ctrl)
+	sessUC := mockSessUC.NewMockSessUseCase(ctrl)
+
+	cfg := &config.Config{Session: config.Session{Expire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	appLogger := logger.NewAppLogger(cfg)
+	appLogger.InitLogger()
+	mw := middlewares.NewMiddlewareManager(appLogger, cfg)
+
+	v := validator.New()
+
+	rt := router.NewRouter(false)
+	handlers := NewBrandHandlersHTTP(rt, appLogger, cfg, mw, v, brandUC, sessUC)
+
+	brandUUID := uuid.New()
+
+	req := router.WithParams(httptest.NewRequest(http.MethodPost, "/brands/"+brandUUID.String()+"/restore", nil), map[string]string{"id": brandUUID.String()})
+	w := httptest.NewRecorder()
+
+	brandUC.EXPECT().RestoreById(gomock.Any(), brandUUID).Return(&models.Brand{BrandID: brandUUID, Version: 3}, nil)
+
+	http.HandlerFunc(handlers.RestoreById).ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, `"3"`, w.Header().Get("ETag"))
+
+	t.Run("NotDeleted", func(t *testing.T) {
+		req := router.WithParams(httptest.NewRequest(http.MethodPost, "/brands/"+brandUUID.String()+"/restore", nil), map[string]string{"id": brandUUID.String()})
+		w := httptest.NewRecorder()
+
+		brandUC.EXPECT().RestoreById(gomock.Any(), brandUUID).Return(nil, errors.Wrap(sql.ErrNoRows, "brandPgRepo.RestoreById"))
+
+		http.HandlerFunc(handlers.RestoreById).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		handlers.BrandMapRoutes()
+
+		req := httptest.NewRequest(http.MethodPost, "/brands/"+brandUUID.String()+"/restore", nil)
+		w := httptest.NewRecorder()
+
+		rt.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusUnauthorized, w.Code)
 	})
 }
@@ -1,9 +1,11 @@
 package handlers
 
-import "net/http"
-
 func (h *brandHandlersHTTP) BrandMapRoutes() {
-	h.mux.Handle("/brand/create", h.mw.IsAdmin(http.HandlerFunc(h.Create)))
-	h.mux.Handle("/brand", h.mw.GetHandler(http.HandlerFunc(h.FindAll)))
-	h.mux.Handle("/brand?id=", h.mw.GetHandler(http.HandlerFunc(h.FindById)))
+	brands := h.router.Group("/brands")
+	brands.Get("", h.FindAll)
+	brands.Get("/{id}", h.FindById)
+	brands.Post("", h.Create, h.mw.IsAdmin)
+	brands.Patch("/{id}", h.UpdateById, h.mw.IsAdmin)
+	brands.Delete("/{id}", h.DeleteById, h.mw.IsAdmin)
+	brands.Post("/{id}/restore", h.RestoreById, h.mw.IsAdmin)
 }
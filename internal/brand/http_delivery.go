@@ -9,4 +9,7 @@ type BrandHandlers interface {
 	Create(w http.ResponseWriter, r *http.Request)
 	FindAll(w http.ResponseWriter, r *http.Request)
 	FindById(w http.ResponseWriter, r *http.Request)
+	UpdateById(w http.ResponseWriter, r *http.Request)
+	DeleteById(w http.ResponseWriter, r *http.Request)
+	RestoreById(w http.ResponseWriter, r *http.Request)
 }
@@ -7,6 +7,7 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	models "github.com/dinorain/kalobranded/internal/models"
 	utils "github.com/dinorain/kalobranded/pkg/utils"
@@ -53,32 +54,47 @@ func (mr *MockBrandPGRepositoryMockRecorder) Create(ctx, user interface{}) *gomo
 }
 
 // DeleteById mocks base method.
-func (m *MockBrandPGRepository) DeleteById(ctx context.Context, userID uuid.UUID) error {
+func (m *MockBrandPGRepository) DeleteById(ctx context.Context, brandID uuid.UUID, versions []int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteById", ctx, userID)
+	ret := m.ctrl.Call(m, "DeleteById", ctx, brandID, versions)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteById indicates an expected call of DeleteById.
-func (mr *MockBrandPGRepositoryMockRecorder) DeleteById(ctx, userID interface{}) *gomock.Call {
+func (mr *MockBrandPGRepositoryMockRecorder) DeleteById(ctx, brandID, versions interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockBrandPGRepository)(nil).DeleteById), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockBrandPGRepository)(nil).DeleteById), ctx, brandID, versions)
 }
 
 // FindAll mocks base method.
-func (m *MockBrandPGRepository) FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.Brand, error) {
+func (m *MockBrandPGRepository) FindAll(ctx context.Context, filter *models.BrandFilter, pagination *utils.Pagination) ([]models.Brand, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAll", ctx, pagination)
+	ret := m.ctrl.Call(m, "FindAll", ctx, filter, pagination)
 	ret0, _ := ret[0].([]models.Brand)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindAll indicates an expected call of FindAll.
-func (mr *MockBrandPGRepositoryMockRecorder) FindAll(ctx, pagination interface{}) *gomock.Call {
+func (mr *MockBrandPGRepositoryMockRecorder) FindAll(ctx, filter, pagination interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockBrandPGRepository)(nil).FindAll), ctx, pagination)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockBrandPGRepository)(nil).FindAll), ctx, filter, pagination)
+}
+
+// FindAllWithDeleted mocks base method.
+func (m *MockBrandPGRepository) FindAllWithDeleted(ctx context.Context, filter *models.BrandFilter, pagination *utils.Pagination) ([]models.Brand, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllWithDeleted", ctx, filter, pagination)
+	ret0, _ := ret[0].([]models.Brand)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllWithDeleted indicates an expected call of FindAllWithDeleted.
+func (mr *MockBrandPGRepositoryMockRecorder) FindAllWithDeleted(ctx, filter, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllWithDeleted", reflect.TypeOf((*MockBrandPGRepository)(nil).FindAllWithDeleted), ctx, filter, pagination)
 }
 
 // FindById mocks base method.
@@ -96,6 +112,51 @@ func (mr *MockBrandPGRepositoryMockRecorder) FindById(ctx, userID interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockBrandPGRepository)(nil).FindById), ctx, userID)
 }
 
+// FindByIdWithDeleted mocks base method.
+func (m *MockBrandPGRepository) FindByIdWithDeleted(ctx context.Context, brandID uuid.UUID) (*models.Brand, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIdWithDeleted", ctx, brandID)
+	ret0, _ := ret[0].(*models.Brand)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIdWithDeleted indicates an expected call of FindByIdWithDeleted.
+func (mr *MockBrandPGRepositoryMockRecorder) FindByIdWithDeleted(ctx, brandID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIdWithDeleted", reflect.TypeOf((*MockBrandPGRepository)(nil).FindByIdWithDeleted), ctx, brandID)
+}
+
+// PurgeDeleted mocks base method.
+func (m *MockBrandPGRepository) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeleted", ctx, deletedBefore)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeleted indicates an expected call of PurgeDeleted.
+func (mr *MockBrandPGRepositoryMockRecorder) PurgeDeleted(ctx, deletedBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeleted", reflect.TypeOf((*MockBrandPGRepository)(nil).PurgeDeleted), ctx, deletedBefore)
+}
+
+// RestoreById mocks base method.
+func (m *MockBrandPGRepository) RestoreById(ctx context.Context, brandID uuid.UUID) (*models.Brand, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreById", ctx, brandID)
+	ret0, _ := ret[0].(*models.Brand)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreById indicates an expected call of RestoreById.
+func (mr *MockBrandPGRepositoryMockRecorder) RestoreById(ctx, brandID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreById", reflect.TypeOf((*MockBrandPGRepository)(nil).RestoreById), ctx, brandID)
+}
+
 // UpdateById mocks base method.
 func (m *MockBrandPGRepository) UpdateById(ctx context.Context, user *models.Brand) (*models.Brand, error) {
 	m.ctrl.T.Helper()
@@ -7,6 +7,7 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	models "github.com/dinorain/kalobranded/internal/models"
 	utils "github.com/dinorain/kalobranded/pkg/utils"
@@ -53,32 +54,47 @@ func (mr *MockBrandUseCaseMockRecorder) CachedFindById(ctx, brandID interface{})
 }
 
 // DeleteById mocks base method.
-func (m *MockBrandUseCase) DeleteById(ctx context.Context, brandID uuid.UUID) error {
+func (m *MockBrandUseCase) DeleteById(ctx context.Context, brandID uuid.UUID, versions []int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteById", ctx, brandID)
+	ret := m.ctrl.Call(m, "DeleteById", ctx, brandID, versions)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteById indicates an expected call of DeleteById.
-func (mr *MockBrandUseCaseMockRecorder) DeleteById(ctx, brandID interface{}) *gomock.Call {
+func (mr *MockBrandUseCaseMockRecorder) DeleteById(ctx, brandID, versions interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockBrandUseCase)(nil).DeleteById), ctx, brandID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockBrandUseCase)(nil).DeleteById), ctx, brandID, versions)
 }
 
 // FindAll mocks base method.
-func (m *MockBrandUseCase) FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.Brand, error) {
+func (m *MockBrandUseCase) FindAll(ctx context.Context, filter *models.BrandFilter, pagination *utils.Pagination) ([]models.Brand, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAll", ctx, pagination)
+	ret := m.ctrl.Call(m, "FindAll", ctx, filter, pagination)
 	ret0, _ := ret[0].([]models.Brand)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindAll indicates an expected call of FindAll.
-func (mr *MockBrandUseCaseMockRecorder) FindAll(ctx, pagination interface{}) *gomock.Call {
+func (mr *MockBrandUseCaseMockRecorder) FindAll(ctx, filter, pagination interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockBrandUseCase)(nil).FindAll), ctx, pagination)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockBrandUseCase)(nil).FindAll), ctx, filter, pagination)
+}
+
+// FindAllWithDeleted mocks base method.
+func (m *MockBrandUseCase) FindAllWithDeleted(ctx context.Context, filter *models.BrandFilter, pagination *utils.Pagination) ([]models.Brand, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllWithDeleted", ctx, filter, pagination)
+	ret0, _ := ret[0].([]models.Brand)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllWithDeleted indicates an expected call of FindAllWithDeleted.
+func (mr *MockBrandUseCaseMockRecorder) FindAllWithDeleted(ctx, filter, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllWithDeleted", reflect.TypeOf((*MockBrandUseCase)(nil).FindAllWithDeleted), ctx, filter, pagination)
 }
 
 // FindById mocks base method.
@@ -96,6 +112,36 @@ func (mr *MockBrandUseCaseMockRecorder) FindById(ctx, brandID interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockBrandUseCase)(nil).FindById), ctx, brandID)
 }
 
+// FindByIdWithDeleted mocks base method.
+func (m *MockBrandUseCase) FindByIdWithDeleted(ctx context.Context, brandID uuid.UUID) (*models.Brand, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIdWithDeleted", ctx, brandID)
+	ret0, _ := ret[0].(*models.Brand)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIdWithDeleted indicates an expected call of FindByIdWithDeleted.
+func (mr *MockBrandUseCaseMockRecorder) FindByIdWithDeleted(ctx, brandID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIdWithDeleted", reflect.TypeOf((*MockBrandUseCase)(nil).FindByIdWithDeleted), ctx, brandID)
+}
+
+// PurgeDeleted mocks base method.
+func (m *MockBrandUseCase) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeleted", ctx, deletedBefore)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeleted indicates an expected call of PurgeDeleted.
+func (mr *MockBrandUseCaseMockRecorder) PurgeDeleted(ctx, deletedBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeleted", reflect.TypeOf((*MockBrandUseCase)(nil).PurgeDeleted), ctx, deletedBefore)
+}
+
 // Register mocks base method.
 func (m *MockBrandUseCase) Register(ctx context.Context, brand *models.Brand) (*models.Brand, error) {
 	m.ctrl.T.Helper()
@@ -111,6 +157,21 @@ func (mr *MockBrandUseCaseMockRecorder) Register(ctx, brand interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockBrandUseCase)(nil).Register), ctx, brand)
 }
 
+// RestoreById mocks base method.
+func (m *MockBrandUseCase) RestoreById(ctx context.Context, brandID uuid.UUID) (*models.Brand, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreById", ctx, brandID)
+	ret0, _ := ret[0].(*models.Brand)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreById indicates an expected call of RestoreById.
+func (mr *MockBrandUseCaseMockRecorder) RestoreById(ctx, brandID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreById", reflect.TypeOf((*MockBrandUseCase)(nil).RestoreById), ctx, brandID)
+}
+
 // UpdateById mocks base method.
 func (m *MockBrandUseCase) UpdateById(ctx context.Context, brand *models.Brand) (*models.Brand, error) {
 	m.ctrl.T.Helper()
@@ -3,6 +3,7 @@ package brand
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -13,8 +14,12 @@ import (
 // Brand pg repository
 type BrandPGRepository interface {
 	Create(ctx context.Context, user *models.Brand) (*models.Brand, error)
-	FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.Brand, error)
+	FindAll(ctx context.Context, filter *models.BrandFilter, pagination *utils.Pagination) ([]models.Brand, error)
 	FindById(ctx context.Context, userID uuid.UUID) (*models.Brand, error)
 	UpdateById(ctx context.Context, user *models.Brand) (*models.Brand, error)
-	DeleteById(ctx context.Context, userID uuid.UUID) error
+	DeleteById(ctx context.Context, brandID uuid.UUID, versions []int) error
+	FindAllWithDeleted(ctx context.Context, filter *models.BrandFilter, pagination *utils.Pagination) ([]models.Brand, error)
+	FindByIdWithDeleted(ctx context.Context, brandID uuid.UUID) (*models.Brand, error)
+	RestoreById(ctx context.Context, brandID uuid.UUID) (*models.Brand, error)
+	PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error)
 }
@@ -3,9 +3,11 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/pkg/errors"
 
 	"github.com/dinorain/kalobranded/internal/brand"
@@ -34,6 +36,9 @@ func (r *BrandRepository) Create(ctx context.Context, brand *models.Brand) (*mod
 		brand.BrandName,
 		brand.Logo,
 		brand.PickupAddress,
+		brand.ReturnWindowDays,
+		brand.PickupLatitude,
+		brand.PickupLongitude,
 	).StructScan(createdBrand); err != nil {
 		return nil, errors.Wrap(err, "BrandRepository.Create.QueryRowxContext")
 	}
@@ -41,7 +46,7 @@ func (r *BrandRepository) Create(ctx context.Context, brand *models.Brand) (*mod
 	return createdBrand, nil
 }
 
-// UpdateById update existing brand
+// UpdateById update existing brand when its version is unchanged, bumping the version
 func (r *BrandRepository) UpdateById(ctx context.Context, brand *models.Brand) (*models.Brand, error) {
 	if res, err := r.db.ExecContext(
 		ctx,
@@ -50,23 +55,36 @@ func (r *BrandRepository) UpdateById(ctx context.Context, brand *models.Brand) (
 		brand.BrandName,
 		brand.Logo,
 		brand.PickupAddress,
+		brand.ReturnWindowDays,
+		brand.PickupLatitude,
+		brand.PickupLongitude,
+		brand.Version,
 	); err != nil {
 		return nil, errors.Wrap(err, "UpdateById.Update.ExecContext")
 	} else {
-		_, err := res.RowsAffected()
+		cnt, err := res.RowsAffected()
 		if err != nil {
 			return nil, errors.Wrap(err, "UpdateById.Update.RowsAffected")
+		} else if cnt == 0 {
+			return nil, models.ErrVersionConflict
 		}
 	}
 
+	brand.Version++
+
 	return brand, nil
 }
 
-// FindAll Find brands
-func (r *BrandRepository) FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.Brand, error) {
+// FindAll Find brands matching filter
+func (r *BrandRepository) FindAll(ctx context.Context, filter *models.BrandFilter, pagination *utils.Pagination) ([]models.Brand, error) {
+	query, args, err := brandListQuery(filter, false).Paginate(models.BrandSorts, pagination).Build()
+	if err != nil {
+		return nil, errors.Wrap(err, "BrandRepository.FindAll.Build")
+	}
+
 	var brands []models.Brand
-	if err := r.db.SelectContext(ctx, &brands, findAllQuery, pagination.GetLimit(), pagination.GetOffset()); err != nil {
-		return nil, errors.Wrap(err, "BrandRepository.FindById.SelectContext")
+	if err := r.db.SelectContext(ctx, &brands, query, args...); err != nil {
+		return nil, errors.Wrap(err, "BrandRepository.FindAll.SelectContext")
 	}
 
 	return brands, nil
@@ -82,14 +100,16 @@ func (r *BrandRepository) FindById(ctx context.Context, brandID uuid.UUID) (*mod
 	return brand, nil
 }
 
-// DeleteById Find brand by uuid
-func (r *BrandRepository) DeleteById(ctx context.Context, brandID uuid.UUID) error {
-	if res, err := r.db.ExecContext(ctx, deleteByIdQuery, brandID); err != nil {
+// DeleteById soft delete brand by uuid, at one of versions unless nil
+func (r *BrandRepository) DeleteById(ctx context.Context, brandID uuid.UUID, versions []int) error {
+	if res, err := r.db.ExecContext(ctx, deleteByIdQuery, brandID, pq.Array(versions)); err != nil {
 		return errors.Wrap(err, "BrandRepository.DeleteById.ExecContext")
 	} else {
 		cnt, err := res.RowsAffected()
 		if err != nil {
 			return errors.Wrap(err, "BrandRepository.DeleteById.RowsAffected")
+		} else if cnt == 0 && versions != nil {
+			return models.ErrPreconditionFailed
 		} else if cnt == 0 {
 			return sql.ErrNoRows
 		}
@@ -97,3 +117,70 @@ func (r *BrandRepository) DeleteById(ctx context.Context, brandID uuid.UUID) err
 
 	return nil
 }
+
+// FindAllWithDeleted Find brands matching filter including soft deleted ones
+func (r *BrandRepository) FindAllWithDeleted(ctx context.Context, filter *models.BrandFilter, pagination *utils.Pagination) ([]models.Brand, error) {
+	query, args, err := brandListQuery(filter, true).Paginate(models.BrandSorts, pagination).Build()
+	if err != nil {
+		return nil, errors.Wrap(err, "BrandRepository.FindAllWithDeleted.Build")
+	}
+
+	var brands []models.Brand
+	if err := r.db.SelectContext(ctx, &brands, query, args...); err != nil {
+		return nil, errors.Wrap(err, "BrandRepository.FindAllWithDeleted.SelectContext")
+	}
+
+	return brands, nil
+}
+
+// FindByIdWithDeleted Find brand by uuid including soft deleted ones
+func (r *BrandRepository) FindByIdWithDeleted(ctx context.Context, brandID uuid.UUID) (*models.Brand, error) {
+	brand := &models.Brand{}
+	if err := r.db.GetContext(ctx, brand, findByIdWithDeletedQuery, brandID); err != nil {
+		return nil, errors.Wrap(err, "BrandRepository.FindByIdWithDeleted.GetContext")
+	}
+
+	return brand, nil
+}
+
+// RestoreById restore soft deleted brand by uuid
+func (r *BrandRepository) RestoreById(ctx context.Context, brandID uuid.UUID) (*models.Brand, error) {
+	brand := &models.Brand{}
+	if err := r.db.QueryRowxContext(ctx, restoreByIdQuery, brandID).StructScan(brand); err != nil {
+		return nil, errors.Wrap(err, "BrandRepository.RestoreById.QueryRowxContext")
+	}
+
+	return brand, nil
+}
+
+// PurgeDeleted hard delete brands soft deleted before deletedBefore, returns purged count
+func (r *BrandRepository) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	res, err := r.db.ExecContext(ctx, purgeDeletedQuery, deletedBefore)
+	if err != nil {
+		return 0, errors.Wrap(err, "BrandRepository.PurgeDeleted.ExecContext")
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "BrandRepository.PurgeDeleted.RowsAffected")
+	}
+
+	return cnt, nil
+}
+
+// brandListQuery list of the brands matching filter
+func brandListQuery(filter *models.BrandFilter, withDeleted bool) *utils.QueryBuilder {
+	qb := utils.NewQueryBuilder(findAllQuery)
+	if !withDeleted {
+		qb.Where("deleted_at IS NULL")
+	}
+	if filter == nil {
+		return qb
+	}
+
+	if filter.Name != "" {
+		qb.Where("brand_name ILIKE ?", utils.ContainsPattern(filter.Name))
+	}
+
+	return qb
+}
@@ -2,12 +2,14 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/require"
 
 	"github.com/dinorain/kalobranded/internal/models"
@@ -48,6 +50,9 @@ func TestBrandRepository_Create(t *testing.T) {
 		mockBrand.BrandName,
 		mockBrand.Logo,
 		mockBrand.PickupAddress,
+		mockBrand.ReturnWindowDays,
+		mockBrand.PickupLatitude,
+		mockBrand.PickupLongitude,
 	).WillReturnRows(rows)
 
 	createdBrand, err := brandPGRepository.Create(context.Background(), mockBrand)
@@ -86,16 +91,27 @@ func TestBrandRepository_FindAll(t *testing.T) {
 	)
 
 	size := 10
-	mock.ExpectQuery(findAllQuery).WithArgs(size, 0).WillReturnRows(rows)
-	foundBrands, err := brandPGRepository.FindAll(context.Background(), utils.NewPaginationQuery(size, 1))
+	query := findAllQuery + " WHERE deleted_at IS NULL ORDER BY created_at DESC, brand_id DESC LIMIT $1 OFFSET $2"
+	mock.ExpectQuery(query).WithArgs(size, 0).WillReturnRows(rows)
+	foundBrands, err := brandPGRepository.FindAll(context.Background(), &models.BrandFilter{}, utils.NewPaginationQuery(size, 1))
 	require.NoError(t, err)
 	require.NotNil(t, foundBrands)
 	require.Equal(t, len(foundBrands), 1)
 
-	mock.ExpectQuery(findAllQuery).WithArgs(size, 10).WillReturnRows(rows)
-	foundBrands, err = brandPGRepository.FindAll(context.Background(), utils.NewPaginationQuery(size, 2))
+	mock.ExpectQuery(query).WithArgs(size, 10).WillReturnRows(rows)
+	foundBrands, err = brandPGRepository.FindAll(context.Background(), nil, utils.NewPaginationQuery(size, 2))
 	require.NoError(t, err)
 	require.Nil(t, foundBrands)
+
+	t.Run("ByName", func(t *testing.T) {
+		pagination := utils.NewPaginationQuery(size, 1)
+		pagination.SetOrderBy("brand_name")
+		mock.ExpectQuery(findAllQuery+" WHERE deleted_at IS NULL AND brand_name ILIKE $1 ORDER BY brand_name ASC, brand_id ASC LIMIT $2 OFFSET $3").
+			WithArgs(`%50\%\_off%`, size, 0).WillReturnRows(sqlmock.NewRows(columns))
+		foundBrands, err := brandPGRepository.FindAll(context.Background(), &models.BrandFilter{Name: "50%_off"}, pagination)
+		require.NoError(t, err)
+		require.Empty(t, foundBrands)
+	})
 }
 
 func TestBrandRepository_FindById(t *testing.T) {
@@ -172,6 +188,10 @@ func TestBrandRepository_UpdateById(t *testing.T) {
 		mockBrand.BrandName,
 		mockBrand.Logo,
 		mockBrand.PickupAddress,
+		mockBrand.ReturnWindowDays,
+		mockBrand.PickupLatitude,
+		mockBrand.PickupLongitude,
+		mockBrand.Version,
 	).WillReturnResult(sqlmock.NewResult(0, 1))
 
 	updatedBrand, err := brandPGRepository.UpdateById(context.Background(), mockBrand)
@@ -179,6 +199,14 @@ func TestBrandRepository_UpdateById(t *testing.T) {
 	require.NotNil(t, mockBrand)
 	require.Equal(t, updatedBrand.BrandName, mockBrand.BrandName)
 	require.Equal(t, updatedBrand.BrandID, mockBrand.BrandID)
+	require.Equal(t, 1, updatedBrand.Version)
+
+	t.Run("VersionConflict", func(t *testing.T) {
+		mock.ExpectExec(updateByIdQuery).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		_, err := brandPGRepository.UpdateById(context.Background(), mockBrand)
+		require.ErrorIs(t, err, models.ErrVersionConflict)
+	})
 }
 
 func TestBrandRepository_DeleteById(t *testing.T) {
@@ -211,9 +239,78 @@ func TestBrandRepository_DeleteById(t *testing.T) {
 		time.Now(),
 	)
 
-	mock.ExpectExec(deleteByIdQuery).WithArgs(mockBrand.BrandID).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(deleteByIdQuery).WithArgs(mockBrand.BrandID, pq.Array([]int(nil))).WillReturnResult(sqlmock.NewResult(0, 1))
 
-	err = brandPGRepository.DeleteById(context.Background(), mockBrand.BrandID)
+	err = brandPGRepository.DeleteById(context.Background(), mockBrand.BrandID, nil)
 	require.NoError(t, err)
+
+	t.Run("PreconditionFailed", func(t *testing.T) {
+		mock.ExpectExec(deleteByIdQuery).WithArgs(mockBrand.BrandID, pq.Array([]int{1})).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := brandPGRepository.DeleteById(context.Background(), mockBrand.BrandID, []int{1})
+		require.ErrorIs(t, err, models.ErrPreconditionFailed)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mock.ExpectExec(deleteByIdQuery).WithArgs(mockBrand.BrandID, pq.Array([]int(nil))).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := brandPGRepository.DeleteById(context.Background(), mockBrand.BrandID, nil)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
 	require.NotNil(t, mockBrand)
 }
+
+func TestBrandRepository_RestoreById(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	brandPGRepository := NewBrandPGRepository(sqlxDB)
+
+	columns := []string{"brand_id", "version", "deleted_at"}
+	brandUUID := uuid.New()
+
+	t.Run("Restored", func(t *testing.T) {
+		rows := sqlmock.NewRows(columns).AddRow(brandUUID, 3, nil)
+		mock.ExpectQuery(restoreByIdQuery).WithArgs(brandUUID).WillReturnRows(rows)
+
+		restoredBrand, err := brandPGRepository.RestoreById(context.Background(), brandUUID)
+		require.NoError(t, err)
+		require.Equal(t, brandUUID, restoredBrand.BrandID)
+		require.Equal(t, 3, restoredBrand.Version)
+		require.Nil(t, restoredBrand.DeletedAt)
+	})
+
+	t.Run("NotDeleted", func(t *testing.T) {
+		mock.ExpectQuery(restoreByIdQuery).WithArgs(brandUUID).WillReturnRows(sqlmock.NewRows(columns))
+
+		_, err := brandPGRepository.RestoreById(context.Background(), brandUUID)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
+}
+
+func TestBrandRepository_PurgeDeleted(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	brandPGRepository := NewBrandPGRepository(sqlxDB)
+
+	deletedBefore := time.Now().AddDate(0, 0, -30)
+	mock.ExpectExec(purgeDeletedQuery).WithArgs(deletedBefore).WillReturnResult(sqlmock.NewResult(0, 2))
+
+	purged, err := brandPGRepository.PurgeDeleted(context.Background(), deletedBefore)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), purged)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
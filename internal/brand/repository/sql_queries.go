@@ -1,16 +1,28 @@
 package repository
 
 const (
-	createBrandQuery = `INSERT INTO brands (brand_name, logo, pickup_address) 
-		VALUES ($1, COALESCE(NULLIF($2, ''), null), $3) 
-		RETURNING brand_id, brand_name, logo, pickup_address, created_at, updated_at`
+	createBrandQuery = `INSERT INTO brands (brand_name, logo, pickup_address, return_window_days, pickup_latitude, pickup_longitude) 
+		VALUES ($1, COALESCE(NULLIF($2, ''), null), $3, $4, $5, $6) 
+		RETURNING brand_id, brand_name, logo, pickup_address, pickup_latitude, pickup_longitude, return_window_days, created_at, updated_at, version, deleted_at`
 
-	findByIdQuery = `SELECT brand_id, brand_name, logo, pickup_address, created_at, updated_at FROM brands WHERE brand_id = $1`
+	findByIdQuery = `SELECT brand_id, brand_name, logo, pickup_address, pickup_latitude, pickup_longitude, return_window_days, created_at, updated_at, version, deleted_at FROM brands WHERE brand_id = $1 AND deleted_at IS NULL`
 
-	findAllQuery = `SELECT brand_id, brand_name, logo, pickup_address, created_at, updated_at FROM brands LIMIT $1 OFFSET $2`
+	findByIdWithDeletedQuery = `SELECT brand_id, brand_name, logo, pickup_address, pickup_latitude, pickup_longitude, return_window_days, created_at, updated_at, version, deleted_at FROM brands WHERE brand_id = $1`
 
-	updateByIdQuery = `UPDATE brands SET brand_name = $2, logo = $3, pickup_address = $4 WHERE brand_id = $1
-		RETURNING brand_id, brand_name, logo, pickup_address, created_at, updated_at`
+	// findAllQuery base of brand lists, conditions, sort and pagination are added by utils.QueryBuilder
+	findAllQuery = `SELECT brand_id, brand_name, logo, pickup_address, pickup_latitude, pickup_longitude, return_window_days, created_at, updated_at, version, deleted_at FROM brands`
 
-	deleteByIdQuery = `DELETE FROM brands WHERE brand_id = $1`
+	updateByIdQuery = `UPDATE brands SET brand_name = $2, logo = $3, pickup_address = $4, return_window_days = $5, pickup_latitude = $6, pickup_longitude = $7, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE brand_id = $1 AND version = $8 AND deleted_at IS NULL
+		RETURNING brand_id, brand_name, logo, pickup_address, pickup_latitude, pickup_longitude, return_window_days, created_at, updated_at, version, deleted_at`
+
+	deleteByIdQuery = `UPDATE brands SET deleted_at = CURRENT_TIMESTAMP, version = version + 1 WHERE brand_id = $1 AND deleted_at IS NULL AND ($2::int[] IS NULL OR version = ANY($2))`
+
+	restoreByIdQuery = `UPDATE brands SET deleted_at = NULL, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE brand_id = $1 AND deleted_at IS NOT NULL
+		RETURNING brand_id, brand_name, logo, pickup_address, pickup_latitude, pickup_longitude, return_window_days, created_at, updated_at, version, deleted_at`
+
+	purgeDeletedQuery = `DELETE FROM brands b WHERE b.deleted_at < $1
+		AND NOT EXISTS (SELECT 1 FROM products p WHERE p.brand_id = b.brand_id)
+		AND NOT EXISTS (SELECT 1 FROM orders o WHERE o.brand_id = b.brand_id)
+		AND NOT EXISTS (SELECT 1 FROM users u WHERE u.brand_id = b.brand_id)
+		AND NOT EXISTS (SELECT 1 FROM promotions pr WHERE pr.brand_id = b.brand_id)`
 )
@@ -3,6 +3,7 @@ package brand
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -13,9 +14,13 @@ import (
 //  Brand UseCase interface
 type BrandUseCase interface {
 	Register(ctx context.Context, brand *models.Brand) (*models.Brand, error)
-	FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.Brand, error)
+	FindAll(ctx context.Context, filter *models.BrandFilter, pagination *utils.Pagination) ([]models.Brand, error)
 	FindById(ctx context.Context, brandID uuid.UUID) (*models.Brand, error)
 	CachedFindById(ctx context.Context, brandID uuid.UUID) (*models.Brand, error)
 	UpdateById(ctx context.Context, brand *models.Brand) (*models.Brand, error)
-	DeleteById(ctx context.Context, brandID uuid.UUID) error
+	DeleteById(ctx context.Context, brandID uuid.UUID, versions []int) error
+	FindAllWithDeleted(ctx context.Context, filter *models.BrandFilter, pagination *utils.Pagination) ([]models.Brand, error)
+	FindByIdWithDeleted(ctx context.Context, brandID uuid.UUID) (*models.Brand, error)
+	RestoreById(ctx context.Context, brandID uuid.UUID) (*models.Brand, error)
+	PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error)
 }
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-redis/redis/v8"
 	"github.com/google/uuid"
@@ -38,9 +39,9 @@ func (u *brandUseCase) Register(ctx context.Context, brand *models.Brand) (*mode
 	return u.brandPgRepo.Create(ctx, brand)
 }
 
-// FindAll find brands
-func (u *brandUseCase) FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.Brand, error) {
-	brands, err := u.brandPgRepo.FindAll(ctx, pagination)
+// FindAll find brands matching filter
+func (u *brandUseCase) FindAll(ctx context.Context, filter *models.BrandFilter, pagination *utils.Pagination) ([]models.Brand, error) {
+	brands, err := u.brandPgRepo.FindAll(ctx, filter, pagination)
 	if err != nil {
 		return nil, errors.Wrap(err, "brandPgRepo.FindAll")
 	}
@@ -94,9 +95,9 @@ func (u *brandUseCase) UpdateById(ctx context.Context, brand *models.Brand) (*mo
 	return updatedBrand, nil
 }
 
-// DeleteById delete brand by uuid
-func (u *brandUseCase) DeleteById(ctx context.Context, brandID uuid.UUID) error {
-	err := u.brandPgRepo.DeleteById(ctx, brandID)
+// DeleteById delete brand by uuid, at one of versions unless nil
+func (u *brandUseCase) DeleteById(ctx context.Context, brandID uuid.UUID, versions []int) error {
+	err := u.brandPgRepo.DeleteById(ctx, brandID, versions)
 	if err != nil {
 		return errors.Wrap(err, "brandPgRepo.DeleteById")
 	}
@@ -107,3 +108,47 @@ func (u *brandUseCase) DeleteById(ctx context.Context, brandID uuid.UUID) error
 
 	return nil
 }
+
+// FindAllWithDeleted find brands matching filter including soft deleted ones
+func (u *brandUseCase) FindAllWithDeleted(ctx context.Context, filter *models.BrandFilter, pagination *utils.Pagination) ([]models.Brand, error) {
+	brands, err := u.brandPgRepo.FindAllWithDeleted(ctx, filter, pagination)
+	if err != nil {
+		return nil, errors.Wrap(err, "brandPgRepo.FindAllWithDeleted")
+	}
+
+	return brands, nil
+}
+
+// FindByIdWithDeleted find brand by uuid including soft deleted ones
+func (u *brandUseCase) FindByIdWithDeleted(ctx context.Context, brandID uuid.UUID) (*models.Brand, error) {
+	foundBrand, err := u.brandPgRepo.FindByIdWithDeleted(ctx, brandID)
+	if err != nil {
+		return nil, errors.Wrap(err, "brandPgRepo.FindByIdWithDeleted")
+	}
+
+	return foundBrand, nil
+}
+
+// RestoreById restore soft deleted brand by uuid
+func (u *brandUseCase) RestoreById(ctx context.Context, brandID uuid.UUID) (*models.Brand, error) {
+	restoredBrand, err := u.brandPgRepo.RestoreById(ctx, brandID)
+	if err != nil {
+		return nil, errors.Wrap(err, "brandPgRepo.RestoreById")
+	}
+
+	if err := u.redisRepo.SetBrandCtx(ctx, restoredBrand.BrandID.String(), brandByIdCacheDuration, restoredBrand); err != nil {
+		u.logger.Errorf("redisRepo.SetBrandCtx", err)
+	}
+
+	return restoredBrand, nil
+}
+
+// PurgeDeleted hard delete brands soft deleted before deletedBefore
+func (u *brandUseCase) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	purged, err := u.brandPgRepo.PurgeDeleted(ctx, deletedBefore)
+	if err != nil {
+		return 0, errors.Wrap(err, "brandPgRepo.PurgeDeleted")
+	}
+
+	return purged, nil
+}
@@ -73,9 +73,10 @@ func TestBrandUseCase_FindAll(t *testing.T) {
 
 	ctx := context.Background()
 
-	brandPGRepository.EXPECT().FindAll(gomock.Any(), nil).AnyTimes().Return(append([]models.Brand{}, *mockBrand), nil)
+	filter := &models.BrandFilter{Name: "Brand"}
+	brandPGRepository.EXPECT().FindAll(gomock.Any(), filter, nil).AnyTimes().Return(append([]models.Brand{}, *mockBrand), nil)
 
-	brands, err := brandUC.FindAll(ctx, nil)
+	brands, err := brandUC.FindAll(ctx, filter, nil)
 	require.NoError(t, err)
 	require.NotNil(t, brands)
 	require.Equal(t, len(brands), 1)
@@ -203,10 +204,10 @@ func TestBrandUseCase_DeleteById(t *testing.T) {
 
 	ctx := context.Background()
 
-	brandPGRepository.EXPECT().DeleteById(gomock.Any(), mockBrand.BrandID).Return(nil)
+	brandPGRepository.EXPECT().DeleteById(gomock.Any(), mockBrand.BrandID, []int{1}).Return(nil)
 	brandRedisRepository.EXPECT().DeleteBrandCtx(gomock.Any(), mockBrand.BrandID.String()).AnyTimes().Return(nil)
 
-	err := brandUC.DeleteById(ctx, mockBrand.BrandID)
+	err := brandUC.DeleteById(ctx, mockBrand.BrandID, []int{1})
 	require.NoError(t, err)
 
 	brandPGRepository.EXPECT().FindById(gomock.Any(), mockBrand.BrandID).AnyTimes().Return(nil, nil)
@@ -0,0 +1,52 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/dinorain/kalobranded/internal/models"
+	geo "github.com/dinorain/kalobranded/pkg/geo"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockDeliveryFeeUseCase is a mock of DeliveryFeeUseCase interface.
+type MockDeliveryFeeUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeliveryFeeUseCaseMockRecorder
+}
+
+// MockDeliveryFeeUseCaseMockRecorder is the mock recorder for MockDeliveryFeeUseCase.
+type MockDeliveryFeeUseCaseMockRecorder struct {
+	mock *MockDeliveryFeeUseCase
+}
+
+// NewMockDeliveryFeeUseCase creates a new mock instance.
+func NewMockDeliveryFeeUseCase(ctrl *gomock.Controller) *MockDeliveryFeeUseCase {
+	mock := &MockDeliveryFeeUseCase{ctrl: ctrl}
+	mock.recorder = &MockDeliveryFeeUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeliveryFeeUseCase) EXPECT() *MockDeliveryFeeUseCaseMockRecorder {
+	return m.recorder
+}
+
+// Apply mocks base method.
+func (m *MockDeliveryFeeUseCase) Apply(ctx context.Context, order *models.Order, pickup, dropoff *geo.Point) (*models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Apply", ctx, order, pickup, dropoff)
+	ret0, _ := ret[0].(*models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Apply indicates an expected call of Apply.
+func (mr *MockDeliveryFeeUseCaseMockRecorder) Apply(ctx, order, pickup, dropoff interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Apply", reflect.TypeOf((*MockDeliveryFeeUseCase)(nil).Apply), ctx, order, pickup, dropoff)
+}
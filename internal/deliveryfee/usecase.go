@@ -0,0 +1,14 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase.go -package mock
+package deliveryfee
+
+import (
+	"context"
+
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/pkg/geo"
+)
+
+// DeliveryFee UseCase interface
+type DeliveryFeeUseCase interface {
+	Apply(ctx context.Context, order *models.Order, pickup, dropoff *geo.Point) (*models.Order, error)
+}
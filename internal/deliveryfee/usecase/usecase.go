@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"context"
+	"math"
+
+	"github.com/pkg/errors"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/deliveryfee"
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/pkg/geo"
+	"github.com/dinorain/kalobranded/pkg/logger"
+	"github.com/dinorain/kalobranded/pkg/shipping"
+)
+
+// DeliveryFee UseCase
+type deliveryFeeUseCase struct {
+	cfg      *config.Config
+	logger   logger.Logger
+	geocoder geo.Geocoder
+	feeTable *shipping.FeeTable
+}
+
+var _ deliveryfee.DeliveryFeeUseCase = (*deliveryFeeUseCase)(nil)
+
+// New DeliveryFee UseCase
+func NewDeliveryFeeUseCase(cfg *config.Config, logger logger.Logger, geocoder geo.Geocoder) *deliveryFeeUseCase {
+	return &deliveryFeeUseCase{cfg: cfg, logger: logger, geocoder: geocoder, feeTable: shipping.NewFeeTable(cfg.Delivery)}
+}
+
+// Apply charge order the delivery fee of its distance and weight. Missing pickup or dropoff coordinates are geocoded
+// from the order delivery addresses, addresses unknown to the geocoder are charged without distance. Orders with free
+// shipping keep their distance but are not charged
+func (u *deliveryFeeUseCase) Apply(ctx context.Context, order *models.Order, pickup, dropoff *geo.Point) (*models.Order, error) {
+	distance, err := u.distance(ctx, order, pickup, dropoff)
+	if err != nil {
+		return nil, err
+	}
+
+	fee, err := u.feeTable.Fee(distance, order.Item.Weight*order.Quantity)
+	if err != nil {
+		return nil, errors.Wrap(err, "feeTable.Fee")
+	}
+	if order.FreeShipping {
+		fee = 0
+	}
+
+	chargedOrder := *order
+	chargedOrder.DeliveryDistance = distance
+	chargedOrder.DeliveryFee = fee
+	chargedOrder.TotalPrice = models.RoundAmount(order.TotalPrice + fee)
+
+	return &chargedOrder, nil
+}
+
+// distance between pickup and dropoff in kilometers, zero when either address could not be located
+func (u *deliveryFeeUseCase) distance(ctx context.Context, order *models.Order, pickup, dropoff *geo.Point) (float64, error) {
+	from, err := geo.Locate(ctx, u.geocoder, pickup, order.DeliverySourceAddress)
+	if err != nil {
+		if errors.Is(err, geo.ErrAddressNotFound) {
+			u.logger.Warnf("geo.Locate pickup: %v, charging without distance", err)
+			return 0, nil
+		}
+		return 0, errors.Wrap(err, "geo.Locate pickup")
+	}
+	to, err := geo.Locate(ctx, u.geocoder, dropoff, order.DeliveryDestinationAddress)
+	if err != nil {
+		if errors.Is(err, geo.ErrAddressNotFound) {
+			u.logger.Warnf("geo.Locate dropoff: %v, charging without distance", err)
+			return 0, nil
+		}
+		return 0, errors.Wrap(err, "geo.Locate dropoff")
+	}
+
+	return math.Round(geo.Haversine(from, to)*100) / 100, nil
+}
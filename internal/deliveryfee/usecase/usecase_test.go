@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/pkg/geo"
+	"github.com/dinorain/kalobranded/pkg/logger"
+	"github.com/dinorain/kalobranded/pkg/shipping"
+)
+
+func TestDeliveryFeeUseCase_Apply(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.Config{Delivery: config.Delivery{
+		BaseFee: 8000,
+		PerKm:   1500,
+		WeightTiers: []config.DeliveryWeightTier{
+			{MaxWeight: 1000, Fee: 0},
+			{MaxWeight: 5000, Fee: 5000},
+		},
+	}}
+	apiLogger := logger.NewAppLogger(cfg)
+	apiLogger.InitLogger()
+	jakarta := geo.Point{Latitude: -6.2088, Longitude: 106.8456}
+	bandung := geo.Point{Latitude: -6.9175, Longitude: 107.6191}
+	geocoder := geo.NewStaticGeocoder(map[string]geo.Point{"Jakarta, ID": jakarta, "Bandung, ID": bandung})
+	deliveryFeeUC := NewDeliveryFeeUseCase(cfg, apiLogger, geocoder)
+
+	ctx := context.Background()
+	mockOrder := &models.Order{
+		Item:                       models.OrderItem{Price: 50000, Weight: 800},
+		Quantity:                   2,
+		TotalPrice:                 100000,
+		DeliverySourceAddress:      "Jl. Sudirman 1, Jakarta, ID",
+		DeliveryDestinationAddress: "Jl. Braga 5, Bandung, ID",
+	}
+
+	chargedOrder, err := deliveryFeeUC.Apply(ctx, mockOrder, nil, nil)
+	require.NoError(t, err)
+	require.InDelta(t, 116.0, chargedOrder.DeliveryDistance, 1)
+	// 8000 base + 117 started km * 1500 + 5000 for 1600 grams
+	require.Equal(t, 188500.0, chargedOrder.DeliveryFee)
+	require.Equal(t, 288500.0, chargedOrder.TotalPrice)
+	require.Equal(t, 100000.0, mockOrder.TotalPrice)
+
+	t.Run("Coordinates", func(t *testing.T) {
+		chargedOrder, err := deliveryFeeUC.Apply(ctx, mockOrder, &jakarta, &jakarta)
+		require.NoError(t, err)
+		require.Zero(t, chargedOrder.DeliveryDistance)
+		require.Equal(t, 13000.0, chargedOrder.DeliveryFee)
+	})
+
+	t.Run("FreeShipping", func(t *testing.T) {
+		freeOrder := *mockOrder
+		freeOrder.FreeShipping = true
+
+		chargedOrder, err := deliveryFeeUC.Apply(ctx, &freeOrder, nil, nil)
+		require.NoError(t, err)
+		require.NotZero(t, chargedOrder.DeliveryDistance)
+		require.Zero(t, chargedOrder.DeliveryFee)
+		require.Equal(t, 100000.0, chargedOrder.TotalPrice)
+	})
+
+	t.Run("AddressNotFound", func(t *testing.T) {
+		unknownOrder := *mockOrder
+		unknownOrder.DeliveryDestinationAddress = "Somewhere, XX"
+
+		chargedOrder, err := deliveryFeeUC.Apply(ctx, &unknownOrder, nil, nil)
+		require.NoError(t, err)
+		require.Zero(t, chargedOrder.DeliveryDistance)
+		require.Equal(t, 13000.0, chargedOrder.DeliveryFee)
+		require.Equal(t, 113000.0, chargedOrder.TotalPrice)
+	})
+
+	t.Run("TooHeavy", func(t *testing.T) {
+		heavyOrder := *mockOrder
+		heavyOrder.Quantity = 10
+
+		_, err := deliveryFeeUC.Apply(ctx, &heavyOrder, &jakarta, &bandung)
+		require.ErrorIs(t, err, shipping.ErrTooHeavy)
+	})
+}
@@ -0,0 +1,37 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/internal/models"
+)
+
+type ExportResponseDto struct {
+	ExportID   uuid.UUID  `json:"export_id"`
+	UserID     uuid.UUID  `json:"user_id"`
+	Resource   string     `json:"resource"`
+	Format     string     `json:"format"`
+	Columns    []string   `json:"columns"`
+	Status     string     `json:"status"`
+	Rows       int64      `json:"rows"`
+	Error      *string    `json:"error,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+func ExportResponseFromModel(export *models.Export) *ExportResponseDto {
+	return &ExportResponseDto{
+		ExportID:   export.ExportID,
+		UserID:     export.UserID,
+		Resource:   export.Resource,
+		Format:     export.Format,
+		Columns:    export.Columns,
+		Status:     export.Status,
+		Rows:       export.Rows,
+		Error:      export.Error,
+		CreatedAt:  export.CreatedAt,
+		FinishedAt: export.FinishedAt,
+	}
+}
@@ -0,0 +1,327 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/export"
+	"github.com/dinorain/kalobranded/internal/export/delivery/http/dto"
+	"github.com/dinorain/kalobranded/internal/middlewares"
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/internal/server/router"
+	"github.com/dinorain/kalobranded/pkg/constants"
+	exportFile "github.com/dinorain/kalobranded/pkg/export"
+	httpErrors "github.com/dinorain/kalobranded/pkg/http_errors"
+	"github.com/dinorain/kalobranded/pkg/logger"
+	"github.com/dinorain/kalobranded/pkg/utils"
+)
+
+// fileTimeLayout time in the names of downloaded files
+const fileTimeLayout = "20060102-150405"
+
+// exportSorts sort keys of the rows of each resource, the same as its list
+var exportSorts = map[string]utils.Sorts{
+	models.ExportResourceOrders:   models.OrderSorts,
+	models.ExportResourceProducts: models.ProductSorts,
+	models.ExportResourceUsers:    models.UserSorts,
+}
+
+type exportHandlersHTTP struct {
+	router   *router.Router
+	logger   logger.Logger
+	cfg      *config.Config
+	mw       middlewares.MiddlewareManager
+	exportUC export.ExportUseCase
+}
+
+var _ export.ExportHandlers = (*exportHandlersHTTP)(nil)
+
+func NewExportHandlersHTTP(
+	router *router.Router,
+	logger logger.Logger,
+	cfg *config.Config,
+	mw middlewares.MiddlewareManager,
+	exportUC export.ExportUseCase,
+) *exportHandlersHTTP {
+	return &exportHandlersHTTP{router: router, logger: logger, cfg: cfg, mw: mw, exportUC: exportUC}
+}
+
+// Stream
+// @Tags Exports
+// @Summary Export rows
+// @Description Download orders, products or users as a CSV or XLSX spreadsheet written as they are read. Rows are filtered and sorted with the query parameters of the resource list. Admins export every row, sellers the orders and products of their brand and users their own orders, users are exported by admins only and never with their password. columns takes a comma separated list of columns, all of them by default. The download ends at the server write timeout, large exports are run in the background with a post instead
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Security ApiKeyAuth
+// @Param resource path string true "orders, products or users"
+// @Param format query string false "csv or xlsx, csv by default"
+// @Param columns query string false "columns, comma separated"
+// @Param sort query string false "sort key of the resource list, e.g. -created_at"
+// @Success 200 {file} file
+// @Router /exports/{resource} [get]
+func (h *exportHandlersHTTP) Stream(w http.ResponseWriter, r *http.Request) {
+	e, err := h.newExport(w, r)
+	if err != nil {
+		return
+	}
+
+	sw := &streamWriter{ResponseWriter: w, export: e}
+	if _, err := h.exportUC.Write(r.Context(), e, sw); err != nil {
+		h.logger.Errorf("exportUC.Write: %v", err)
+		if !sw.started {
+			_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		}
+		return
+	}
+	return
+}
+
+// Create
+// @Tags Exports
+// @Summary Export rows in the background
+// @Description Export rows as for the download, in a background job writing the file for a later download. The export is polled until done
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param resource path string true "orders, products or users"
+// @Param format query string false "csv or xlsx, csv by default"
+// @Param columns query string false "columns, comma separated"
+// @Param sort query string false "sort key of the resource list, e.g. -created_at"
+// @Success 202 {object} dto.ExportResponseDto
+// @Router /exports/{resource} [post]
+func (h *exportHandlersHTTP) Create(w http.ResponseWriter, r *http.Request) {
+	e, err := h.newExport(w, r)
+	if err != nil {
+		return
+	}
+
+	createdExport, err := h.exportUC.Create(r.Context(), e)
+	if err != nil {
+		h.logger.Errorf("exportUC.Create: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	res, _ := json.Marshal(dto.ExportResponseFromModel(createdExport))
+	w.WriteHeader(http.StatusAccepted)
+	w.Write(res)
+	return
+}
+
+// FindById
+// @Tags Exports
+// @Summary Find export
+// @Description Find a background export of the user, or any one for admins. status is pending, running, done or failed
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "export uuid"
+// @Success 200 {object} dto.ExportResponseDto
+// @Router /exports/jobs/{id} [get]
+func (h *exportHandlersHTTP) FindById(w http.ResponseWriter, r *http.Request) {
+	foundExport, err := h.findExport(w, r)
+	if err != nil {
+		return
+	}
+
+	res, _ := json.Marshal(dto.ExportResponseFromModel(foundExport))
+	w.WriteHeader(http.StatusOK)
+	w.Write(res)
+	return
+}
+
+// Download
+// @Tags Exports
+// @Summary Download export
+// @Description Download the file of a done background export, conflict while it is not done
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Security ApiKeyAuth
+// @Param id path string true "export uuid"
+// @Success 200 {file} file
+// @Router /exports/jobs/{id}/download [get]
+func (h *exportHandlersHTTP) Download(w http.ResponseWriter, r *http.Request) {
+	foundExport, err := h.findExport(w, r)
+	if err != nil {
+		return
+	}
+
+	f, err := h.exportUC.Open(r.Context(), foundExport)
+	if err != nil {
+		h.logger.Errorf("exportUC.Open: %v", err)
+		switch {
+		case errors.Is(err, models.ErrExportNotDone):
+			_ = httpErrors.NewConflictError(w, models.ErrExportNotDone.Error(), h.cfg.Http.DebugErrorsResponse)
+		case errors.Is(err, exportFile.ErrNotFound):
+			_ = httpErrors.NewNotFoundError(w, nil, h.cfg.Http.DebugErrorsResponse)
+		default:
+			_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		}
+		return
+	}
+	defer f.Close()
+
+	setFileHeaders(w, foundExport, foundExport.CreatedAt)
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(w, f); err != nil {
+		h.logger.Errorf("io.Copy: %v", err)
+	}
+	return
+}
+
+// newExport export of the resource of the path, with the format, columns and rows asked by the query parameters.
+// Admins export every row, sellers the orders and products of their brand and users their own orders. Error
+// response is already written when err is not nil
+func (h *exportHandlersHTTP) newExport(w http.ResponseWriter, r *http.Request) (*models.Export, error) {
+	jwtClaims, err := h.mw.GetJWTClaims(w, r)
+	if err != nil {
+		return nil, err
+	}
+	claims := *jwtClaims
+	role, _ := claims["role"].(string)
+	userID, _ := claims["user_id"].(string)
+	brandID, _ := claims["brand_id"].(string)
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, httpErrors.NewUnauthorizedError(w, nil, h.cfg.Http.DebugErrorsResponse)
+	}
+
+	resource := router.Param(r, constants.Resource)
+	queryParam := r.URL.Query()
+	columns, err := models.ParseExportColumns(resource, queryParam.Get(constants.Columns))
+	if err != nil {
+		return nil, httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+	}
+
+	format := queryParam.Get(constants.Format)
+	if format == "" {
+		format = exportFile.FormatCSV
+	}
+	if !exportFile.IsFormat(format) {
+		return nil, httpErrors.NewBadRequestError(w, fmt.Sprintf("invalid format: %q", format), h.cfg.Http.DebugErrorsResponse)
+	}
+
+	// the visible rows are narrowed to the caller's own with the filters of the list
+	switch {
+	case role == models.UserRoleAdmin:
+	case role == models.UserRoleSeller && brandID != "" && resource != models.ExportResourceUsers:
+		if err := restrict(queryParam, constants.BrandID, brandID); err != nil {
+			return nil, httpErrors.NewForbiddenError(w, nil, h.cfg.Http.DebugErrorsResponse)
+		}
+	case role == models.UserRoleUser && resource == models.ExportResourceOrders:
+		if err := restrict(queryParam, constants.UserID, userID); err != nil {
+			return nil, httpErrors.NewForbiddenError(w, nil, h.cfg.Http.DebugErrorsResponse)
+		}
+	default:
+		return nil, httpErrors.NewForbiddenError(w, nil, h.cfg.Http.DebugErrorsResponse)
+	}
+
+	if err := validateQuery(resource, queryParam); err != nil {
+		return nil, httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+	}
+	for _, param := range []string{constants.Format, constants.Columns, constants.Page, constants.Size, constants.Cursor, constants.Count} {
+		queryParam.Del(param)
+	}
+
+	return &models.Export{
+		UserID:   userUUID,
+		Resource: resource,
+		Format:   format,
+		Columns:  columns,
+		Query:    queryParam.Encode(),
+	}, nil
+}
+
+// findExport find export by id of the path, admins find every export and users their own only. Error response is
+// already written when err is not nil
+func (h *exportHandlersHTTP) findExport(w http.ResponseWriter, r *http.Request) (*models.Export, error) {
+	exportUUID, err := uuid.Parse(router.Param(r, constants.ID))
+	if err != nil {
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return nil, err
+	}
+
+	jwtClaims, err := h.mw.GetJWTClaims(w, r)
+	if err != nil {
+		return nil, err
+	}
+	claims := *jwtClaims
+	role, _ := claims["role"].(string)
+	userID, _ := claims["user_id"].(string)
+
+	foundExport, err := h.exportUC.FindById(r.Context(), exportUUID)
+	if err != nil {
+		h.logger.Errorf("exportUC.FindById: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return nil, err
+	}
+
+	if role != models.UserRoleAdmin && foundExport.UserID.String() != userID {
+		_ = httpErrors.NewNotFoundError(w, nil, h.cfg.Http.DebugErrorsResponse)
+		return nil, errors.New("not found")
+	}
+
+	return foundExport, nil
+}
+
+// restrict set the filter param to value, an error when it already filters on another value
+func restrict(queryParam url.Values, param, value string) error {
+	if v := queryParam.Get(param); v != "" && v != value {
+		return fmt.Errorf("%s %s not allowed", param, v)
+	}
+	queryParam.Set(param, value)
+	return nil
+}
+
+// validateQuery check the sort and filters of the rows of resource
+func validateQuery(resource string, queryParam url.Values) error {
+	if err := exportSorts[resource].Validate(queryParam.Get(constants.Sort)); err != nil {
+		return err
+	}
+
+	var err error
+	switch resource {
+	case models.ExportResourceOrders:
+		_, err = models.ParseOrderFilter(queryParam)
+	case models.ExportResourceProducts:
+		_, err = models.ParseProductFilter(queryParam)
+	case models.ExportResourceUsers:
+		_, err = models.ParseUserFilter(queryParam)
+	}
+	return err
+}
+
+// setFileHeaders headers of the file of export, named after its resource and the time it was made at
+func setFileHeaders(w http.ResponseWriter, e *models.Export, at time.Time) {
+	name := fmt.Sprintf("%s-%s.%s", e.Resource, at.UTC().Format(fileTimeLayout), e.Format)
+	w.Header().Set("Content-Type", exportFile.ContentType(e.Format))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": name}))
+	w.Header().Set("Cache-Control", "no-store")
+}
+
+// streamWriter response of a streamed export, started by the first write so that errors before any row are still
+// answered with an error response
+type streamWriter struct {
+	http.ResponseWriter
+	export  *models.Export
+	started bool
+}
+
+func (s *streamWriter) Write(p []byte) (int, error) {
+	if !s.started {
+		s.started = true
+		setFileHeaders(s.ResponseWriter, s.export, time.Now())
+		s.ResponseWriter.WriteHeader(http.StatusOK)
+	}
+	return s.ResponseWriter.Write(p)
+}
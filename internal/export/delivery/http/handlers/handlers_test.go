@@ -0,0 +1,264 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/export/delivery/http/dto"
+	"github.com/dinorain/kalobranded/internal/export/mock"
+	"github.com/dinorain/kalobranded/internal/middlewares"
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/internal/server/router"
+	exportFile "github.com/dinorain/kalobranded/pkg/export"
+	"github.com/dinorain/kalobranded/pkg/logger"
+)
+
+func signedToken(t *testing.T, cfg *config.Config, userUUID uuid.UUID, role string, brandUUID *uuid.UUID) string {
+	token := jwt.New(jwt.SigningMethodHS256)
+	claims := token.Claims.(jwt.MapClaims)
+	claims["session_id"] = uuid.New().String()
+	claims["user_id"] = userUUID.String()
+	claims["role"] = role
+	if brandUUID != nil {
+		claims["brand_id"] = brandUUID.String()
+	}
+	claims["exp"] = time.Now().Add(time.Minute * 15).Unix()
+	validToken, err := token.SignedString([]byte(cfg.Server.JwtSecretKey))
+	require.NoError(t, err)
+	return validToken
+}
+
+func newTestHandlers(t *testing.T) (*exportHandlersHTTP, *mock.MockExportUseCase, *config.Config) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	exportUC := mock.NewMockExportUseCase(ctrl)
+
+	cfg := &config.Config{Session: config.Session{Expire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	appLogger := logger.NewAppLogger(cfg)
+	appLogger.InitLogger()
+	mw := middlewares.NewMiddlewareManager(appLogger, cfg)
+
+	return NewExportHandlersHTTP(router.NewRouter(false), appLogger, cfg, mw, exportUC), exportUC, cfg
+}
+
+func exportRequest(method, resource, query, token string) *http.Request {
+	req := httptest.NewRequest(method, "/exports/"+resource+query, nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", token))
+	return router.WithParams(req, map[string]string{"resource": resource})
+}
+
+func TestExportHandler_Stream(t *testing.T) {
+	t.Parallel()
+
+	handlers, exportUC, cfg := newTestHandlers(t)
+
+	userUUID := uuid.New()
+	brandUUID := uuid.New()
+	userToken := signedToken(t, cfg, userUUID, models.UserRoleUser, nil)
+	sellerToken := signedToken(t, cfg, uuid.New(), models.UserRoleSeller, &brandUUID)
+
+	t.Run("UserOrders", func(t *testing.T) {
+		req := exportRequest(http.MethodGet, models.ExportResourceOrders, "?columns=order_id,status&status=paid&sort=-created_at&size=10", userToken)
+		w := httptest.NewRecorder()
+
+		exportUC.EXPECT().Write(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, e *models.Export, w io.Writer) (int64, error) {
+			require.Equal(t, userUUID, e.UserID)
+			require.Equal(t, exportFile.FormatCSV, e.Format)
+			require.Equal(t, []string{"order_id", "status"}, []string(e.Columns))
+
+			query, err := url.ParseQuery(e.Query)
+			require.NoError(t, err)
+			require.Equal(t, url.Values{"status": {"paid"}, "sort": {"-created_at"}, "user_id": {userUUID.String()}}, query)
+
+			_, err = io.WriteString(w, "order_id,status\n")
+			return 0, err
+		})
+
+		http.HandlerFunc(handlers.Stream).ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+		require.True(t, strings.HasPrefix(w.Header().Get("Content-Disposition"), "attachment; filename=orders-"))
+		require.Equal(t, "order_id,status\n", w.Body.String())
+	})
+
+	t.Run("SellerProducts", func(t *testing.T) {
+		req := exportRequest(http.MethodGet, models.ExportResourceProducts, "?format=xlsx&brand_id="+brandUUID.String(), sellerToken)
+		w := httptest.NewRecorder()
+
+		exportUC.EXPECT().Write(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, e *models.Export, w io.Writer) (int64, error) {
+			require.Equal(t, exportFile.FormatXLSX, e.Format)
+			require.Equal(t, models.ExportColumns[models.ExportResourceProducts], []string(e.Columns))
+			require.Equal(t, "brand_id="+brandUUID.String(), e.Query)
+			return 0, nil
+		})
+
+		http.HandlerFunc(handlers.Stream).ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("WriteFailed", func(t *testing.T) {
+		req := exportRequest(http.MethodGet, models.ExportResourceOrders, "", userToken)
+		w := httptest.NewRecorder()
+
+		exportUC.EXPECT().Write(gomock.Any(), gomock.Any(), gomock.Any()).Return(int64(0), errors.New("connection refused"))
+
+		http.HandlerFunc(handlers.Stream).ServeHTTP(w, req)
+		require.Equal(t, http.StatusInternalServerError, w.Code)
+		require.Empty(t, w.Header().Get("Content-Disposition"))
+	})
+
+	for name, tc := range map[string]struct {
+		resource string
+		query    string
+		token    string
+		code     int
+	}{
+		"SellerOtherBrand":  {models.ExportResourceOrders, "?brand_id=" + uuid.New().String(), sellerToken, http.StatusForbidden},
+		"SellerUsers":       {models.ExportResourceUsers, "", sellerToken, http.StatusForbidden},
+		"UserProducts":      {models.ExportResourceProducts, "", userToken, http.StatusForbidden},
+		"UserOtherUser":     {models.ExportResourceOrders, "?user_id=" + uuid.New().String(), userToken, http.StatusForbidden},
+		"InvalidResource":   {"sessions", "", userToken, http.StatusBadRequest},
+		"PasswordColumn":    {models.ExportResourceOrders, "?columns=order_id,password", userToken, http.StatusBadRequest},
+		"InvalidFormat":     {models.ExportResourceOrders, "?format=pdf", userToken, http.StatusBadRequest},
+		"InvalidSort":       {models.ExportResourceOrders, "?sort=password", userToken, http.StatusBadRequest},
+		"InvalidFilter":     {models.ExportResourceOrders, "?status=lost", userToken, http.StatusBadRequest},
+		"MissingAuthHeader": {models.ExportResourceOrders, "", "", http.StatusUnauthorized},
+	} {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			req := exportRequest(http.MethodGet, tc.resource, tc.query, tc.token)
+			if tc.token == "" {
+				req.Header.Del("Authorization")
+			}
+			w := httptest.NewRecorder()
+
+			http.HandlerFunc(handlers.Stream).ServeHTTP(w, req)
+			require.Equal(t, tc.code, w.Code)
+		})
+	}
+}
+
+func TestExportHandler_Create(t *testing.T) {
+	t.Parallel()
+
+	handlers, exportUC, cfg := newTestHandlers(t)
+
+	adminUUID := uuid.New()
+	adminToken := signedToken(t, cfg, adminUUID, models.UserRoleAdmin, nil)
+	exportUUID := uuid.New()
+
+	req := exportRequest(http.MethodPost, models.ExportResourceUsers, "?columns=email,role&role=seller&format=xlsx", adminToken)
+	w := httptest.NewRecorder()
+
+	exportUC.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, e *models.Export) (*models.Export, error) {
+		require.Equal(t, adminUUID, e.UserID)
+		require.Equal(t, models.ExportResourceUsers, e.Resource)
+		require.Equal(t, "role=seller", e.Query)
+		createdExport := *e
+		createdExport.ExportID, createdExport.Status = exportUUID, models.ExportStatusPending
+		return &createdExport, nil
+	})
+
+	http.HandlerFunc(handlers.Create).ServeHTTP(w, req)
+	require.Equal(t, http.StatusAccepted, w.Code)
+
+	resDto := &dto.ExportResponseDto{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), resDto))
+	require.Equal(t, exportUUID, resDto.ExportID)
+	require.Equal(t, models.ExportStatusPending, resDto.Status)
+	require.Equal(t, []string{"email", "role"}, resDto.Columns)
+}
+
+func TestExportHandler_FindById(t *testing.T) {
+	t.Parallel()
+
+	handlers, exportUC, cfg := newTestHandlers(t)
+
+	userUUID := uuid.New()
+	userToken := signedToken(t, cfg, userUUID, models.UserRoleUser, nil)
+	adminToken := signedToken(t, cfg, uuid.New(), models.UserRoleAdmin, nil)
+
+	fileName := "export.csv"
+	foundExport := &models.Export{ExportID: uuid.New(), UserID: userUUID, Resource: models.ExportResourceOrders, Status: models.ExportStatusDone, FileName: &fileName}
+	otherExport := &models.Export{ExportID: uuid.New(), UserID: uuid.New(), Status: models.ExportStatusDone}
+
+	findRequest := func(exportID uuid.UUID, token string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/exports/jobs/"+exportID.String(), nil)
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", token))
+		return router.WithParams(req, map[string]string{"id": exportID.String()})
+	}
+
+	t.Run("Owner", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		exportUC.EXPECT().FindById(gomock.Any(), foundExport.ExportID).Return(foundExport, nil)
+
+		http.HandlerFunc(handlers.FindById).ServeHTTP(w, findRequest(foundExport.ExportID, userToken))
+		require.Equal(t, http.StatusOK, w.Code)
+		require.NotContains(t, w.Body.String(), fileName)
+	})
+
+	t.Run("Admin", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		exportUC.EXPECT().FindById(gomock.Any(), otherExport.ExportID).Return(otherExport, nil)
+
+		http.HandlerFunc(handlers.FindById).ServeHTTP(w, findRequest(otherExport.ExportID, adminToken))
+		require.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("OtherUser", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		exportUC.EXPECT().FindById(gomock.Any(), otherExport.ExportID).Return(otherExport, nil)
+
+		http.HandlerFunc(handlers.FindById).ServeHTTP(w, findRequest(otherExport.ExportID, userToken))
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Download", func(t *testing.T) {
+		req := findRequest(foundExport.ExportID, userToken)
+		w := httptest.NewRecorder()
+		exportUC.EXPECT().FindById(gomock.Any(), foundExport.ExportID).Return(foundExport, nil)
+		exportUC.EXPECT().Open(gomock.Any(), foundExport).Return(ioutil.NopCloser(strings.NewReader("order_id\n")), nil)
+
+		http.HandlerFunc(handlers.Download).ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+		require.Equal(t, "order_id\n", w.Body.String())
+	})
+
+	t.Run("DownloadPending", func(t *testing.T) {
+		req := findRequest(foundExport.ExportID, userToken)
+		w := httptest.NewRecorder()
+		exportUC.EXPECT().FindById(gomock.Any(), foundExport.ExportID).Return(foundExport, nil)
+		exportUC.EXPECT().Open(gomock.Any(), foundExport).Return(nil, errors.Wrap(models.ErrExportNotDone, "export is running"))
+
+		http.HandlerFunc(handlers.Download).ServeHTTP(w, req)
+		require.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("DownloadMissingFile", func(t *testing.T) {
+		req := findRequest(foundExport.ExportID, userToken)
+		w := httptest.NewRecorder()
+		exportUC.EXPECT().FindById(gomock.Any(), foundExport.ExportID).Return(foundExport, nil)
+		exportUC.EXPECT().Open(gomock.Any(), foundExport).Return(nil, errors.Wrap(exportFile.ErrNotFound, "store.Open"))
+
+		http.HandlerFunc(handlers.Download).ServeHTTP(w, req)
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
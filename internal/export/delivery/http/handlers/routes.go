@@ -0,0 +1,9 @@
+package handlers
+
+func (h *exportHandlersHTTP) ExportMapRoutes() {
+	exports := h.router.Group("/exports", h.mw.IsLoggedIn)
+	exports.Get("/{resource}", h.Stream)
+	exports.Post("/{resource}", h.Create)
+	exports.Get("/jobs/{id}", h.FindById)
+	exports.Get("/jobs/{id}/download", h.Download)
+}
@@ -0,0 +1,13 @@
+package export
+
+import (
+	"net/http"
+)
+
+// Export HTTP Handlers interface
+type ExportHandlers interface {
+	Stream(w http.ResponseWriter, r *http.Request)
+	Create(w http.ResponseWriter, r *http.Request)
+	FindById(w http.ResponseWriter, r *http.Request)
+	Download(w http.ResponseWriter, r *http.Request)
+}
@@ -0,0 +1,82 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pg_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/dinorain/kalobranded/internal/models"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockExportPGRepository is a mock of ExportPGRepository interface.
+type MockExportPGRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockExportPGRepositoryMockRecorder
+}
+
+// MockExportPGRepositoryMockRecorder is the mock recorder for MockExportPGRepository.
+type MockExportPGRepositoryMockRecorder struct {
+	mock *MockExportPGRepository
+}
+
+// NewMockExportPGRepository creates a new mock instance.
+func NewMockExportPGRepository(ctrl *gomock.Controller) *MockExportPGRepository {
+	mock := &MockExportPGRepository{ctrl: ctrl}
+	mock.recorder = &MockExportPGRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExportPGRepository) EXPECT() *MockExportPGRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockExportPGRepository) Create(ctx context.Context, export *models.Export) (*models.Export, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, export)
+	ret0, _ := ret[0].(*models.Export)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockExportPGRepositoryMockRecorder) Create(ctx, export interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockExportPGRepository)(nil).Create), ctx, export)
+}
+
+// FindById mocks base method.
+func (m *MockExportPGRepository) FindById(ctx context.Context, exportID uuid.UUID) (*models.Export, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, exportID)
+	ret0, _ := ret[0].(*models.Export)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockExportPGRepositoryMockRecorder) FindById(ctx, exportID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockExportPGRepository)(nil).FindById), ctx, exportID)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockExportPGRepository) UpdateStatus(ctx context.Context, export *models.Export) (*models.Export, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, export)
+	ret0, _ := ret[0].(*models.Export)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockExportPGRepositoryMockRecorder) UpdateStatus(ctx, export interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockExportPGRepository)(nil).UpdateStatus), ctx, export)
+}
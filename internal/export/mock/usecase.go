@@ -0,0 +1,156 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	models "github.com/dinorain/kalobranded/internal/models"
+	jobs "github.com/dinorain/kalobranded/pkg/jobs"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockExportUseCase is a mock of ExportUseCase interface.
+type MockExportUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockExportUseCaseMockRecorder
+}
+
+// MockExportUseCaseMockRecorder is the mock recorder for MockExportUseCase.
+type MockExportUseCaseMockRecorder struct {
+	mock *MockExportUseCase
+}
+
+// NewMockExportUseCase creates a new mock instance.
+func NewMockExportUseCase(ctrl *gomock.Controller) *MockExportUseCase {
+	mock := &MockExportUseCase{ctrl: ctrl}
+	mock.recorder = &MockExportUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExportUseCase) EXPECT() *MockExportUseCaseMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockExportUseCase) Create(ctx context.Context, export *models.Export) (*models.Export, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, export)
+	ret0, _ := ret[0].(*models.Export)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockExportUseCaseMockRecorder) Create(ctx, export interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockExportUseCase)(nil).Create), ctx, export)
+}
+
+// FindById mocks base method.
+func (m *MockExportUseCase) FindById(ctx context.Context, exportID uuid.UUID) (*models.Export, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, exportID)
+	ret0, _ := ret[0].(*models.Export)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockExportUseCaseMockRecorder) FindById(ctx, exportID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockExportUseCase)(nil).FindById), ctx, exportID)
+}
+
+// Open mocks base method.
+func (m *MockExportUseCase) Open(ctx context.Context, export *models.Export) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Open", ctx, export)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Open indicates an expected call of Open.
+func (mr *MockExportUseCaseMockRecorder) Open(ctx, export interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Open", reflect.TypeOf((*MockExportUseCase)(nil).Open), ctx, export)
+}
+
+// Run mocks base method.
+func (m *MockExportUseCase) Run(ctx context.Context, job *models.ExportJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Run", ctx, job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockExportUseCaseMockRecorder) Run(ctx, job interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockExportUseCase)(nil).Run), ctx, job)
+}
+
+// Write mocks base method.
+func (m *MockExportUseCase) Write(ctx context.Context, export *models.Export, w io.Writer) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Write", ctx, export, w)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Write indicates an expected call of Write.
+func (mr *MockExportUseCaseMockRecorder) Write(ctx, export, w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Write", reflect.TypeOf((*MockExportUseCase)(nil).Write), ctx, export, w)
+}
+
+// MockJobQueue is a mock of JobQueue interface.
+type MockJobQueue struct {
+	ctrl     *gomock.Controller
+	recorder *MockJobQueueMockRecorder
+}
+
+// MockJobQueueMockRecorder is the mock recorder for MockJobQueue.
+type MockJobQueueMockRecorder struct {
+	mock *MockJobQueue
+}
+
+// NewMockJobQueue creates a new mock instance.
+func NewMockJobQueue(ctrl *gomock.Controller) *MockJobQueue {
+	mock := &MockJobQueue{ctrl: ctrl}
+	mock.recorder = &MockJobQueueMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockJobQueue) EXPECT() *MockJobQueueMockRecorder {
+	return m.recorder
+}
+
+// Enqueue mocks base method.
+func (m *MockJobQueue) Enqueue(ctx context.Context, kind string, payload interface{}, opts ...jobs.EnqueueOption) (*jobs.Job, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, kind, payload}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Enqueue", varargs...)
+	ret0, _ := ret[0].(*jobs.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Enqueue indicates an expected call of Enqueue.
+func (mr *MockJobQueueMockRecorder) Enqueue(ctx, kind, payload interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, kind, payload}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enqueue", reflect.TypeOf((*MockJobQueue)(nil).Enqueue), varargs...)
+}
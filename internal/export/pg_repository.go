@@ -0,0 +1,17 @@
+//go:generate mockgen -source pg_repository.go -destination mock/pg_repository.go -package mock
+package export
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/internal/models"
+)
+
+// Export pg repository
+type ExportPGRepository interface {
+	Create(ctx context.Context, export *models.Export) (*models.Export, error)
+	FindById(ctx context.Context, exportID uuid.UUID) (*models.Export, error)
+	UpdateStatus(ctx context.Context, export *models.Export) (*models.Export, error)
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"github.com/dinorain/kalobranded/internal/export"
+	"github.com/dinorain/kalobranded/internal/models"
+)
+
+// Export repository
+type ExportRepository struct {
+	db *sqlx.DB
+}
+
+var _ export.ExportPGRepository = (*ExportRepository)(nil)
+
+// Export repository constructor
+func NewExportPGRepository(db *sqlx.DB) *ExportRepository {
+	return &ExportRepository{db: db}
+}
+
+// Create new pending export
+func (r *ExportRepository) Create(ctx context.Context, export *models.Export) (*models.Export, error) {
+	createdExport := &models.Export{}
+	if err := r.db.QueryRowxContext(
+		ctx,
+		createExportQuery,
+		export.UserID,
+		export.Resource,
+		export.Format,
+		export.Columns,
+		export.Query,
+	).StructScan(createdExport); err != nil {
+		return nil, errors.Wrap(err, "ExportRepository.Create.QueryRowxContext")
+	}
+
+	return createdExport, nil
+}
+
+// FindById Find export by uuid
+func (r *ExportRepository) FindById(ctx context.Context, exportID uuid.UUID) (*models.Export, error) {
+	foundExport := &models.Export{}
+	if err := r.db.GetContext(ctx, foundExport, findByIdQuery, exportID); err != nil {
+		return nil, errors.Wrap(err, "ExportRepository.FindById.GetContext")
+	}
+
+	return foundExport, nil
+}
+
+// UpdateStatus update status, file, row count, error and finish time of export
+func (r *ExportRepository) UpdateStatus(ctx context.Context, export *models.Export) (*models.Export, error) {
+	updatedExport := &models.Export{}
+	if err := r.db.QueryRowxContext(
+		ctx,
+		updateStatusQuery,
+		export.ExportID,
+		export.Status,
+		export.FileName,
+		export.Rows,
+		export.Error,
+		export.FinishedAt,
+	).StructScan(updatedExport); err != nil {
+		return nil, errors.Wrap(err, "ExportRepository.UpdateStatus.QueryRowxContext")
+	}
+
+	return updatedExport, nil
+}
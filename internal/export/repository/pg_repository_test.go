@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/kalobranded/internal/models"
+)
+
+var exportColumns = []string{"export_id", "user_id", "resource", "format", "columns", "query", "status", "file_name", "row_count", "error", "created_at", "finished_at"}
+
+func newTestRepository(t *testing.T) (*ExportRepository, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	return NewExportPGRepository(sqlx.NewDb(db, "sqlmock")), mock
+}
+
+func TestExportRepository_Create(t *testing.T) {
+	t.Parallel()
+
+	exportPGRepository, mock := newTestRepository(t)
+
+	userUUID, exportUUID := uuid.New(), uuid.New()
+	export := &models.Export{
+		UserID:   userUUID,
+		Resource: models.ExportResourceOrders,
+		Format:   "xlsx",
+		Columns:  pq.StringArray{"order_id", "total_price"},
+		Query:    "status=paid",
+	}
+
+	mock.ExpectQuery(createExportQuery).
+		WithArgs(userUUID, export.Resource, export.Format, export.Columns, export.Query).
+		WillReturnRows(sqlmock.NewRows(exportColumns).AddRow(
+			exportUUID, userUUID, export.Resource, export.Format, `{order_id,total_price}`, export.Query, models.ExportStatusPending, nil, 0, nil, time.Now(), nil,
+		))
+
+	createdExport, err := exportPGRepository.Create(context.Background(), export)
+	require.NoError(t, err)
+	require.Equal(t, exportUUID, createdExport.ExportID)
+	require.Equal(t, models.ExportStatusPending, createdExport.Status)
+	require.Equal(t, pq.StringArray{"order_id", "total_price"}, createdExport.Columns)
+	require.Nil(t, createdExport.FileName)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExportRepository_FindById(t *testing.T) {
+	t.Parallel()
+
+	exportPGRepository, mock := newTestRepository(t)
+
+	exportUUID := uuid.New()
+	mock.ExpectQuery(findByIdQuery).WithArgs(exportUUID).
+		WillReturnRows(sqlmock.NewRows(exportColumns).AddRow(
+			exportUUID, uuid.New(), models.ExportResourceUsers, "csv", `{email}`, "", models.ExportStatusDone, exportUUID.String()+".csv", 42, nil, time.Now(), time.Now(),
+		))
+
+	foundExport, err := exportPGRepository.FindById(context.Background(), exportUUID)
+	require.NoError(t, err)
+	require.Equal(t, int64(42), foundExport.Rows)
+	require.Equal(t, exportUUID.String()+".csv", *foundExport.FileName)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExportRepository_UpdateStatus(t *testing.T) {
+	t.Parallel()
+
+	exportPGRepository, mock := newTestRepository(t)
+
+	exportUUID := uuid.New()
+	message := "export failed"
+	finishedAt := time.Now()
+	export := &models.Export{ExportID: exportUUID, Status: models.ExportStatusFailed, Rows: 7, Error: &message, FinishedAt: &finishedAt}
+
+	mock.ExpectQuery(updateStatusQuery).
+		WithArgs(exportUUID, models.ExportStatusFailed, export.FileName, int64(7), export.Error, export.FinishedAt).
+		WillReturnRows(sqlmock.NewRows(exportColumns).AddRow(
+			exportUUID, uuid.New(), models.ExportResourceProducts, "csv", `{name}`, "", models.ExportStatusFailed, nil, 7, message, time.Now(), finishedAt,
+		))
+
+	updatedExport, err := exportPGRepository.UpdateStatus(context.Background(), export)
+	require.NoError(t, err)
+	require.Equal(t, models.ExportStatusFailed, updatedExport.Status)
+	require.Equal(t, message, *updatedExport.Error)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
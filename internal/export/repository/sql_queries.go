@@ -0,0 +1,11 @@
+package repository
+
+const (
+	createExportQuery = `INSERT INTO exports (user_id, resource, format, columns, query) VALUES ($1, $2, $3, $4, $5)
+		RETURNING export_id, user_id, resource, format, columns, query, status, file_name, row_count, error, created_at, finished_at`
+
+	findByIdQuery = `SELECT export_id, user_id, resource, format, columns, query, status, file_name, row_count, error, created_at, finished_at FROM exports WHERE export_id = $1`
+
+	updateStatusQuery = `UPDATE exports SET status = $2, file_name = $3, row_count = $4, error = $5, finished_at = $6 WHERE export_id = $1
+		RETURNING export_id, user_id, resource, format, columns, query, status, file_name, row_count, error, created_at, finished_at`
+)
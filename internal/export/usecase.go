@@ -0,0 +1,31 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase.go -package mock
+package export
+
+import (
+	"context"
+	"io"
+
+	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/pkg/jobs"
+)
+
+// JobKindRun kind of the jobs running an export in the background
+const JobKindRun = "exports.run"
+
+// Export UseCase interface
+type ExportUseCase interface {
+	Write(ctx context.Context, export *models.Export, w io.Writer) (int64, error)
+	Create(ctx context.Context, export *models.Export) (*models.Export, error)
+	FindById(ctx context.Context, exportID uuid.UUID) (*models.Export, error)
+	Open(ctx context.Context, export *models.Export) (io.ReadCloser, error)
+	Run(ctx context.Context, job *models.ExportJob) error
+}
+
+// JobQueue queue the export jobs are enqueued to, *jobs.Queue
+type JobQueue interface {
+	Enqueue(ctx context.Context, kind string, payload interface{}, opts ...jobs.EnqueueOption) (*jobs.Job, error)
+}
+
+var _ JobQueue = (*jobs.Queue)(nil)
@@ -0,0 +1,238 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/export"
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/internal/order"
+	"github.com/dinorain/kalobranded/internal/product"
+	"github.com/dinorain/kalobranded/internal/user"
+	"github.com/dinorain/kalobranded/pkg/constants"
+	exportFile "github.com/dinorain/kalobranded/pkg/export"
+	"github.com/dinorain/kalobranded/pkg/jobs"
+	"github.com/dinorain/kalobranded/pkg/logger"
+)
+
+// errExportFailed error shown for exports failed other than by having too many rows, the cause is logged only
+const errExportFailed = "export failed"
+
+// Export UseCase
+type exportUseCase struct {
+	cfg          *config.Config
+	logger       logger.Logger
+	exportPgRepo export.ExportPGRepository
+	orderUC      order.OrderUseCase
+	productUC    product.ProductUseCase
+	userUC       user.UserUseCase
+	queue        export.JobQueue
+	store        exportFile.Store
+}
+
+var _ export.ExportUseCase = (*exportUseCase)(nil)
+
+// New Export UseCase
+func NewExportUseCase(
+	cfg *config.Config,
+	logger logger.Logger,
+	exportRepo export.ExportPGRepository,
+	orderUC order.OrderUseCase,
+	productUC product.ProductUseCase,
+	userUC user.UserUseCase,
+	queue export.JobQueue,
+	store exportFile.Store,
+) *exportUseCase {
+	return &exportUseCase{
+		cfg:          cfg,
+		logger:       logger,
+		exportPgRepo: exportRepo,
+		orderUC:      orderUC,
+		productUC:    productUC,
+		userUC:       userUC,
+		queue:        queue,
+		store:        store,
+	}
+}
+
+// Write write the columns of the rows of export to w in its format, a header row first, as the rows are read. Returns
+// how many rows were written, the header left out
+func (u *exportUseCase) Write(ctx context.Context, e *models.Export, w io.Writer) (int64, error) {
+	queryParam, err := url.ParseQuery(e.Query)
+	if err != nil {
+		return 0, errors.Wrap(err, "url.ParseQuery")
+	}
+	sort := queryParam.Get(constants.Sort)
+
+	writer, err := exportFile.NewWriter(e.Format, w)
+	if err != nil {
+		return 0, errors.Wrap(err, "export.NewWriter")
+	}
+
+	values := make([]interface{}, len(e.Columns))
+	for i, column := range e.Columns {
+		values[i] = column
+	}
+	if err := writer.Write(values); err != nil {
+		return 0, errors.Wrap(err, "writer.Write")
+	}
+
+	var rows int64
+	write := func(row models.Exportable) error {
+		for i, column := range e.Columns {
+			values[i] = row.ExportValue(column)
+		}
+		if err := writer.Write(values); err != nil {
+			return errors.Wrap(err, "writer.Write")
+		}
+		rows++
+		return nil
+	}
+
+	switch e.Resource {
+	case models.ExportResourceOrders:
+		filter, err := models.ParseOrderFilter(queryParam)
+		if err != nil {
+			return 0, err
+		}
+		err = u.orderUC.Export(ctx, filter, sort, func(order *models.Order) error { return write(order) })
+		if err != nil {
+			return rows, errors.Wrap(err, "orderUC.Export")
+		}
+	case models.ExportResourceProducts:
+		filter, err := models.ParseProductFilter(queryParam)
+		if err != nil {
+			return 0, err
+		}
+		err = u.productUC.Export(ctx, filter, sort, func(product *models.Product) error { return write(product) })
+		if err != nil {
+			return rows, errors.Wrap(err, "productUC.Export")
+		}
+	case models.ExportResourceUsers:
+		filter, err := models.ParseUserFilter(queryParam)
+		if err != nil {
+			return 0, err
+		}
+		err = u.userUC.Export(ctx, filter, sort, func(user *models.User) error { return write(user) })
+		if err != nil {
+			return rows, errors.Wrap(err, "userUC.Export")
+		}
+	default:
+		return 0, errors.Errorf("invalid export resource %q", e.Resource)
+	}
+
+	if err := writer.Close(); err != nil {
+		return rows, errors.Wrap(err, "writer.Close")
+	}
+
+	return rows, nil
+}
+
+// Create save a pending export and enqueue the job running it
+func (u *exportUseCase) Create(ctx context.Context, e *models.Export) (*models.Export, error) {
+	createdExport, err := u.exportPgRepo.Create(ctx, e)
+	if err != nil {
+		return nil, errors.Wrap(err, "exportPgRepo.Create")
+	}
+
+	job := &models.ExportJob{ExportID: createdExport.ExportID}
+	if _, err := u.queue.Enqueue(ctx, export.JobKindRun, job, jobs.UniqueKey(createdExport.ExportID.String())); err != nil {
+		u.fail(ctx, createdExport, err)
+		return nil, errors.Wrap(err, "queue.Enqueue")
+	}
+
+	return createdExport, nil
+}
+
+// FindById find export by uuid
+func (u *exportUseCase) FindById(ctx context.Context, exportID uuid.UUID) (*models.Export, error) {
+	foundExport, err := u.exportPgRepo.FindById(ctx, exportID)
+	if err != nil {
+		return nil, errors.Wrap(err, "exportPgRepo.FindById")
+	}
+
+	return foundExport, nil
+}
+
+// Open file of a done export
+func (u *exportUseCase) Open(ctx context.Context, e *models.Export) (io.ReadCloser, error) {
+	if e.Status != models.ExportStatusDone || e.FileName == nil {
+		return nil, errors.Wrapf(models.ErrExportNotDone, "export %s is %s", e.ExportID, e.Status)
+	}
+
+	f, err := u.store.Open(ctx, *e.FileName)
+	if err != nil {
+		return nil, errors.Wrap(err, "store.Open")
+	}
+
+	return f, nil
+}
+
+// Run write the file of an export to the store, the export is done once the file is stored. A failed attempt marks
+// it failed, a retry that succeeds done
+func (u *exportUseCase) Run(ctx context.Context, job *models.ExportJob) error {
+	foundExport, err := u.exportPgRepo.FindById(ctx, job.ExportID)
+	if err != nil {
+		return errors.Wrap(err, "exportPgRepo.FindById")
+	}
+	if foundExport.Status == models.ExportStatusDone {
+		return nil
+	}
+
+	foundExport.Status, foundExport.Error, foundExport.FinishedAt = models.ExportStatusRunning, nil, nil
+	if foundExport, err = u.exportPgRepo.UpdateStatus(ctx, foundExport); err != nil {
+		return errors.Wrap(err, "exportPgRepo.UpdateStatus")
+	}
+
+	name := fmt.Sprintf("%s.%s", foundExport.ExportID, foundExport.Format)
+	upload, err := u.store.Create(ctx, name)
+	if err != nil {
+		u.fail(ctx, foundExport, err)
+		return errors.Wrap(err, "store.Create")
+	}
+
+	rows, err := u.Write(ctx, foundExport, upload)
+	if err == nil {
+		err = upload.Commit()
+	} else {
+		upload.Abort()
+	}
+	if err != nil {
+		u.fail(ctx, foundExport, err)
+		if errors.Is(err, exportFile.ErrTooManyRows) {
+			return jobs.Permanent(err)
+		}
+		return errors.Wrap(err, "Write")
+	}
+
+	finishedAt := time.Now()
+	foundExport.Status, foundExport.FileName, foundExport.Rows, foundExport.FinishedAt = models.ExportStatusDone, &name, rows, &finishedAt
+	if _, err := u.exportPgRepo.UpdateStatus(ctx, foundExport); err != nil {
+		return errors.Wrap(err, "exportPgRepo.UpdateStatus")
+	}
+	u.logger.Infof("export %s of %s done, %d rows", foundExport.ExportID, foundExport.Resource, rows)
+
+	return nil
+}
+
+// fail mark export failed by err, logged in full and shown only when the rows don't fit the format
+func (u *exportUseCase) fail(ctx context.Context, e *models.Export, err error) {
+	u.logger.Errorf("export %s failed: %v", e.ExportID, err)
+
+	message := errExportFailed
+	if errors.Is(err, exportFile.ErrTooManyRows) {
+		message = exportFile.ErrTooManyRows.Error()
+	}
+	finishedAt := time.Now()
+	e.Status, e.Error, e.FinishedAt = models.ExportStatusFailed, &message, &finishedAt
+	if _, err := u.exportPgRepo.UpdateStatus(ctx, e); err != nil {
+		u.logger.Errorf("exportPgRepo.UpdateStatus: %v", err)
+	}
+}
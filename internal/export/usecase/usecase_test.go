@@ -0,0 +1,223 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/export"
+	"github.com/dinorain/kalobranded/internal/export/mock"
+	"github.com/dinorain/kalobranded/internal/models"
+	mockOrderUC "github.com/dinorain/kalobranded/internal/order/mock"
+	mockProductUC "github.com/dinorain/kalobranded/internal/product/mock"
+	mockUserUC "github.com/dinorain/kalobranded/internal/user/mock"
+	exportFile "github.com/dinorain/kalobranded/pkg/export"
+	"github.com/dinorain/kalobranded/pkg/jobs"
+	"github.com/dinorain/kalobranded/pkg/logger"
+)
+
+type testDeps struct {
+	exportPGRepository *mock.MockExportPGRepository
+	orderUC            *mockOrderUC.MockOrderUseCase
+	productUC          *mockProductUC.MockProductUseCase
+	userUC             *mockUserUC.MockUserUseCase
+	queue              *mock.MockJobQueue
+	store              *exportFile.FileStore
+}
+
+func newTestUseCase(t *testing.T) (*exportUseCase, *testDeps) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	deps := &testDeps{
+		exportPGRepository: mock.NewMockExportPGRepository(ctrl),
+		orderUC:            mockOrderUC.NewMockOrderUseCase(ctrl),
+		productUC:          mockProductUC.NewMockProductUseCase(ctrl),
+		userUC:             mockUserUC.NewMockUserUseCase(ctrl),
+		queue:              mock.NewMockJobQueue(ctrl),
+		store:              exportFile.NewFileStore(t.TempDir()),
+	}
+
+	cfg := &config.Config{}
+	apiLogger := logger.NewAppLogger(cfg)
+	apiLogger.InitLogger()
+
+	return NewExportUseCase(cfg, apiLogger, deps.exportPGRepository, deps.orderUC, deps.productUC, deps.userUC, deps.queue, deps.store), deps
+}
+
+// exportOrders expect an order export filtered by status paid, sorted by sort, calling fn with orders
+func exportOrders(deps *testDeps, sort string, orders ...*models.Order) *gomock.Call {
+	return deps.orderUC.EXPECT().Export(gomock.Any(), &models.OrderFilter{Statuses: []string{models.OrderStatusPaid}}, sort, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *models.OrderFilter, _ string, fn func(*models.Order) error) error {
+			for _, order := range orders {
+				if err := fn(order); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+}
+
+func TestExportUseCase_Write(t *testing.T) {
+	t.Parallel()
+
+	exportUC, deps := newTestUseCase(t)
+
+	orderUUID := uuid.New()
+	createdAt := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	exportOrders(deps, "-total_price", &models.Order{
+		OrderID:    orderUUID,
+		Item:       models.OrderItem{Name: "Kaos"},
+		Quantity:   2,
+		TotalPrice: 198000,
+		Status:     models.OrderStatusPaid,
+		CreatedAt:  createdAt,
+	})
+
+	var buf bytes.Buffer
+	rows, err := exportUC.Write(context.Background(), &models.Export{
+		Resource: models.ExportResourceOrders,
+		Format:   exportFile.FormatCSV,
+		Columns:  pq.StringArray{"order_id", "product_name", "quantity", "total_price", "created_at"},
+		Query:    "sort=-total_price&status=paid",
+	}, &buf)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rows)
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Equal(t, [][]string{
+		{"order_id", "product_name", "quantity", "total_price", "created_at"},
+		{orderUUID.String(), "Kaos", "2", "198000", "2026-03-01T10:00:00Z"},
+	}, records)
+
+	t.Run("Users", func(t *testing.T) {
+		deps.userUC.EXPECT().Export(gomock.Any(), &models.UserFilter{Role: models.UserRoleSeller}, "", gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *models.UserFilter, _ string, fn func(*models.User) error) error {
+				return fn(&models.User{Email: "seller@kalobranded.local", Password: "hash"})
+			})
+
+		var buf bytes.Buffer
+		_, err := exportUC.Write(context.Background(), &models.Export{
+			Resource: models.ExportResourceUsers,
+			Format:   exportFile.FormatCSV,
+			Columns:  pq.StringArray{"email", "brand_id"},
+			Query:    "role=seller",
+		}, &buf)
+		require.NoError(t, err)
+		require.Equal(t, "email,brand_id\nseller@kalobranded.local,\n", buf.String())
+	})
+
+	t.Run("InvalidResource", func(t *testing.T) {
+		_, err := exportUC.Write(context.Background(), &models.Export{Resource: "sessions", Format: exportFile.FormatCSV}, &bytes.Buffer{})
+		require.Error(t, err)
+	})
+}
+
+func TestExportUseCase_Create(t *testing.T) {
+	t.Parallel()
+
+	exportUC, deps := newTestUseCase(t)
+
+	pendingExport := &models.Export{ExportID: uuid.New(), Resource: models.ExportResourceOrders, Format: exportFile.FormatXLSX, Status: models.ExportStatusPending}
+	deps.exportPGRepository.EXPECT().Create(gomock.Any(), pendingExport).Return(pendingExport, nil)
+	deps.queue.EXPECT().Enqueue(gomock.Any(), export.JobKindRun, &models.ExportJob{ExportID: pendingExport.ExportID}, gomock.Any()).Return(&jobs.Job{}, nil)
+
+	createdExport, err := exportUC.Create(context.Background(), pendingExport)
+	require.NoError(t, err)
+	require.Equal(t, models.ExportStatusPending, createdExport.Status)
+
+	t.Run("EnqueueFailed", func(t *testing.T) {
+		pendingExport := &models.Export{ExportID: uuid.New(), Status: models.ExportStatusPending}
+		deps.exportPGRepository.EXPECT().Create(gomock.Any(), pendingExport).Return(pendingExport, nil)
+		deps.queue.EXPECT().Enqueue(gomock.Any(), export.JobKindRun, gomock.Any(), gomock.Any()).Return(nil, errors.New("connection refused"))
+		deps.exportPGRepository.EXPECT().UpdateStatus(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, e *models.Export) (*models.Export, error) {
+			require.Equal(t, models.ExportStatusFailed, e.Status)
+			return e, nil
+		})
+
+		_, err := exportUC.Create(context.Background(), pendingExport)
+		require.Error(t, err)
+	})
+}
+
+func TestExportUseCase_Run(t *testing.T) {
+	t.Parallel()
+
+	exportUC, deps := newTestUseCase(t)
+
+	pendingExport := &models.Export{
+		ExportID: uuid.New(),
+		Resource: models.ExportResourceOrders,
+		Format:   exportFile.FormatCSV,
+		Columns:  pq.StringArray{"status"},
+		Query:    "status=paid",
+		Status:   models.ExportStatusPending,
+	}
+
+	var (
+		statuses []string
+		updated  models.Export
+	)
+	updateStatus := func(_ context.Context, e *models.Export) (*models.Export, error) {
+		statuses = append(statuses, e.Status)
+		updated = *e
+		return &updated, nil
+	}
+
+	deps.exportPGRepository.EXPECT().FindById(gomock.Any(), pendingExport.ExportID).Return(pendingExport, nil)
+	deps.exportPGRepository.EXPECT().UpdateStatus(gomock.Any(), gomock.Any()).DoAndReturn(updateStatus).Times(2)
+	exportOrders(deps, "", &models.Order{Status: models.OrderStatusPaid}, &models.Order{Status: models.OrderStatusPaid})
+
+	require.NoError(t, exportUC.Run(context.Background(), &models.ExportJob{ExportID: pendingExport.ExportID}))
+	require.Equal(t, []string{models.ExportStatusRunning, models.ExportStatusDone}, statuses)
+	require.Equal(t, int64(2), updated.Rows)
+
+	done := &models.Export{ExportID: pendingExport.ExportID, Format: exportFile.FormatCSV, Status: models.ExportStatusDone, Rows: 2}
+	name := pendingExport.ExportID.String() + ".csv"
+	done.FileName = &name
+	f, err := exportUC.Open(context.Background(), done)
+	require.NoError(t, err)
+	data, err := ioutil.ReadAll(f)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	require.Equal(t, "status\npaid\npaid\n", string(data))
+
+	t.Run("Failed", func(t *testing.T) {
+		failing := &models.Export{ExportID: uuid.New(), Resource: models.ExportResourceOrders, Format: exportFile.FormatCSV, Columns: pq.StringArray{"status"}, Query: "status=paid"}
+		statuses = nil
+
+		deps.exportPGRepository.EXPECT().FindById(gomock.Any(), failing.ExportID).Return(failing, nil)
+		deps.exportPGRepository.EXPECT().UpdateStatus(gomock.Any(), gomock.Any()).DoAndReturn(updateStatus).Times(2)
+		deps.orderUC.EXPECT().Export(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("connection reset"))
+
+		require.Error(t, exportUC.Run(context.Background(), &models.ExportJob{ExportID: failing.ExportID}))
+		require.Equal(t, []string{models.ExportStatusRunning, models.ExportStatusFailed}, statuses)
+		require.Equal(t, errExportFailed, *updated.Error)
+
+		// nothing is left in the store
+		_, err := deps.store.Open(context.Background(), failing.ExportID.String()+".csv")
+		require.True(t, errors.Is(err, exportFile.ErrNotFound))
+	})
+
+	t.Run("Done", func(t *testing.T) {
+		deps.exportPGRepository.EXPECT().FindById(gomock.Any(), done.ExportID).Return(done, nil)
+
+		require.NoError(t, exportUC.Run(context.Background(), &models.ExportJob{ExportID: done.ExportID}))
+	})
+
+	t.Run("OpenPending", func(t *testing.T) {
+		_, err := exportUC.Open(context.Background(), &models.Export{Status: models.ExportStatusRunning})
+		require.True(t, errors.Is(err, models.ErrExportNotDone))
+	})
+}
@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: redis_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/dinorain/kalobranded/internal/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIdempotencyRedisRepository is a mock of IdempotencyRedisRepository interface.
+type MockIdempotencyRedisRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdempotencyRedisRepositoryMockRecorder
+}
+
+// MockIdempotencyRedisRepositoryMockRecorder is the mock recorder for MockIdempotencyRedisRepository.
+type MockIdempotencyRedisRepositoryMockRecorder struct {
+	mock *MockIdempotencyRedisRepository
+}
+
+// NewMockIdempotencyRedisRepository creates a new mock instance.
+func NewMockIdempotencyRedisRepository(ctrl *gomock.Controller) *MockIdempotencyRedisRepository {
+	mock := &MockIdempotencyRedisRepository{ctrl: ctrl}
+	mock.recorder = &MockIdempotencyRedisRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdempotencyRedisRepository) EXPECT() *MockIdempotencyRedisRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetResponseCtx mocks base method.
+func (m *MockIdempotencyRedisRepository) GetResponseCtx(ctx context.Context, key string) (*models.IdempotentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetResponseCtx", ctx, key)
+	ret0, _ := ret[0].(*models.IdempotentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetResponseCtx indicates an expected call of GetResponseCtx.
+func (mr *MockIdempotencyRedisRepositoryMockRecorder) GetResponseCtx(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResponseCtx", reflect.TypeOf((*MockIdempotencyRedisRepository)(nil).GetResponseCtx), ctx, key)
+}
+
+// LockCtx mocks base method.
+func (m *MockIdempotencyRedisRepository) LockCtx(ctx context.Context, key, token string, seconds int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockCtx", ctx, key, token, seconds)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockCtx indicates an expected call of LockCtx.
+func (mr *MockIdempotencyRedisRepositoryMockRecorder) LockCtx(ctx, key, token, seconds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockCtx", reflect.TypeOf((*MockIdempotencyRedisRepository)(nil).LockCtx), ctx, key, token, seconds)
+}
+
+// RefreshLockCtx mocks base method.
+func (m *MockIdempotencyRedisRepository) RefreshLockCtx(ctx context.Context, key, token string, seconds int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshLockCtx", ctx, key, token, seconds)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefreshLockCtx indicates an expected call of RefreshLockCtx.
+func (mr *MockIdempotencyRedisRepositoryMockRecorder) RefreshLockCtx(ctx, key, token, seconds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshLockCtx", reflect.TypeOf((*MockIdempotencyRedisRepository)(nil).RefreshLockCtx), ctx, key, token, seconds)
+}
+
+// SetResponseCtx mocks base method.
+func (m *MockIdempotencyRedisRepository) SetResponseCtx(ctx context.Context, key string, seconds int, res *models.IdempotentResponse) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetResponseCtx", ctx, key, seconds, res)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetResponseCtx indicates an expected call of SetResponseCtx.
+func (mr *MockIdempotencyRedisRepositoryMockRecorder) SetResponseCtx(ctx, key, seconds, res interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetResponseCtx", reflect.TypeOf((*MockIdempotencyRedisRepository)(nil).SetResponseCtx), ctx, key, seconds, res)
+}
+
+// UnlockCtx mocks base method.
+func (m *MockIdempotencyRedisRepository) UnlockCtx(ctx context.Context, key, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockCtx", ctx, key, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlockCtx indicates an expected call of UnlockCtx.
+func (mr *MockIdempotencyRedisRepositoryMockRecorder) UnlockCtx(ctx, key, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockCtx", reflect.TypeOf((*MockIdempotencyRedisRepository)(nil).UnlockCtx), ctx, key, token)
+}
@@ -0,0 +1,17 @@
+//go:generate mockgen -source redis_repository.go -destination mock/redis_repository.go -package mock
+package idempotency
+
+import (
+	"context"
+
+	"github.com/dinorain/kalobranded/internal/models"
+)
+
+// Idempotency Redis repository interface
+type IdempotencyRedisRepository interface {
+	GetResponseCtx(ctx context.Context, key string) (*models.IdempotentResponse, error)
+	SetResponseCtx(ctx context.Context, key string, seconds int, res *models.IdempotentResponse) error
+	LockCtx(ctx context.Context, key string, token string, seconds int) (bool, error)
+	RefreshLockCtx(ctx context.Context, key string, token string, seconds int) (bool, error)
+	UnlockCtx(ctx context.Context, key string, token string) error
+}
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/dinorain/kalobranded/internal/idempotency"
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/pkg/logger"
+)
+
+// unlockScript delete the lock only while it is still held with token, an expired lock taken over by
+// another request is left alone
+var unlockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// refreshScript extend the lock expiry only while it is still held with token
+var refreshScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+// Idempotency redis repository
+type idempotencyRedisRepo struct {
+	redisClient *redis.Client
+	basePrefix  string
+	lockPrefix  string
+	logger      logger.Logger
+}
+
+var _ idempotency.IdempotencyRedisRepository = (*idempotencyRedisRepo)(nil)
+
+// Idempotency redis repository constructor
+func NewIdempotencyRedisRepo(redisClient *redis.Client, logger logger.Logger) *idempotencyRedisRepo {
+	return &idempotencyRedisRepo{redisClient: redisClient, basePrefix: "idempotency:", lockPrefix: "idempotency_lock:", logger: logger}
+}
+
+// Get stored response by key
+func (r *idempotencyRedisRepo) GetResponseCtx(ctx context.Context, key string) (*models.IdempotentResponse, error) {
+	resBytes, err := r.redisClient.Get(ctx, r.createKey(r.basePrefix, key)).Bytes()
+	if err != nil {
+		return nil, err
+	}
+	res := &models.IdempotentResponse{}
+	if err = json.Unmarshal(resBytes, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Store response with duration in seconds
+func (r *idempotencyRedisRepo) SetResponseCtx(ctx context.Context, key string, seconds int, res *models.IdempotentResponse) error {
+	resBytes, err := json.Marshal(res)
+	if err != nil {
+		return err
+	}
+
+	return r.redisClient.Set(ctx, r.createKey(r.basePrefix, key), resBytes, time.Second*time.Duration(seconds)).Err()
+}
+
+// Lock key with token for duration in seconds, false when another request holds the lock
+func (r *idempotencyRedisRepo) LockCtx(ctx context.Context, key string, token string, seconds int) (bool, error) {
+	return r.redisClient.SetNX(ctx, r.createKey(r.lockPrefix, key), token, time.Second*time.Duration(seconds)).Result()
+}
+
+// Unlock key locked with token
+func (r *idempotencyRedisRepo) UnlockCtx(ctx context.Context, key string, token string) error {
+	return unlockScript.Run(ctx, r.redisClient, []string{r.createKey(r.lockPrefix, key)}, token).Err()
+}
+
+// Refresh lock of key held with token for duration in seconds, false when the lock is no longer held with token
+func (r *idempotencyRedisRepo) RefreshLockCtx(ctx context.Context, key string, token string, seconds int) (bool, error) {
+	refreshed, err := refreshScript.Run(ctx, r.redisClient, []string{r.createKey(r.lockPrefix, key)}, token, (time.Second * time.Duration(seconds)).Milliseconds()).Int()
+	if err != nil {
+		return false, err
+	}
+	return refreshed == 1, nil
+}
+
+func (r *idempotencyRedisRepo) createKey(prefix string, value string) string {
+	return fmt.Sprintf("%s: %s", prefix, value)
+}
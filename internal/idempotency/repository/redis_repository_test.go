@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/kalobranded/internal/models"
+)
+
+func SetupRedis() *idempotencyRedisRepo {
+	mr, err := miniredis.Run()
+	if err != nil {
+		log.Fatal(err)
+	}
+	client := redis.NewClient(&redis.Options{
+		Addr: mr.Addr(),
+	})
+
+	idempotencyRedisRepository := NewIdempotencyRedisRepo(client, nil)
+	return idempotencyRedisRepository
+}
+
+func TestIdempotencyRedisRepo_GetResponseCtx(t *testing.T) {
+	t.Parallel()
+
+	redisRepo := SetupRedis()
+
+	t.Run("GetResponseCtx", func(t *testing.T) {
+		res := &models.IdempotentResponse{
+			Fingerprint: "fingerprint",
+			StatusCode:  http.StatusCreated,
+			Header:      http.Header{"Content-Type": []string{"application/json"}},
+			Body:        []byte(`{"order_id":"id"}`),
+		}
+
+		err := redisRepo.SetResponseCtx(context.Background(), "key", 10, res)
+		require.NoError(t, err)
+
+		found, err := redisRepo.GetResponseCtx(context.Background(), "key")
+		require.NoError(t, err)
+		require.Equal(t, res, found)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := redisRepo.GetResponseCtx(context.Background(), "unknown")
+		require.ErrorIs(t, err, redis.Nil)
+	})
+}
+
+func TestIdempotencyRedisRepo_LockCtx(t *testing.T) {
+	t.Parallel()
+
+	redisRepo := SetupRedis()
+
+	t.Run("LockCtx", func(t *testing.T) {
+		locked, err := redisRepo.LockCtx(context.Background(), "key", "first", 10)
+		require.NoError(t, err)
+		require.True(t, locked)
+
+		locked, err = redisRepo.LockCtx(context.Background(), "key", "second", 10)
+		require.NoError(t, err)
+		require.False(t, locked)
+	})
+
+	t.Run("UnlockCtx", func(t *testing.T) {
+		err := redisRepo.UnlockCtx(context.Background(), "key", "second")
+		require.NoError(t, err)
+
+		locked, err := redisRepo.LockCtx(context.Background(), "key", "second", 10)
+		require.NoError(t, err)
+		require.False(t, locked)
+
+		err = redisRepo.UnlockCtx(context.Background(), "key", "first")
+		require.NoError(t, err)
+
+		locked, err = redisRepo.LockCtx(context.Background(), "key", "second", 10)
+		require.NoError(t, err)
+		require.True(t, locked)
+	})
+}
+
+func TestIdempotencyRedisRepo_RefreshLockCtx(t *testing.T) {
+	t.Parallel()
+
+	redisRepo := SetupRedis()
+
+	locked, err := redisRepo.LockCtx(context.Background(), "key", "first", 10)
+	require.NoError(t, err)
+	require.True(t, locked)
+
+	t.Run("RefreshLockCtx", func(t *testing.T) {
+		refreshed, err := redisRepo.RefreshLockCtx(context.Background(), "key", "first", 60)
+		require.NoError(t, err)
+		require.True(t, refreshed)
+
+		ttl, err := redisRepo.redisClient.TTL(context.Background(), redisRepo.createKey(redisRepo.lockPrefix, "key")).Result()
+		require.NoError(t, err)
+		require.Equal(t, 60*time.Second, ttl)
+	})
+
+	t.Run("OtherToken", func(t *testing.T) {
+		refreshed, err := redisRepo.RefreshLockCtx(context.Background(), "key", "second", 60)
+		require.NoError(t, err)
+		require.False(t, refreshed)
+	})
+
+	t.Run("NotLocked", func(t *testing.T) {
+		refreshed, err := redisRepo.RefreshLockCtx(context.Background(), "unknown", "first", 60)
+		require.NoError(t, err)
+		require.False(t, refreshed)
+	})
+}
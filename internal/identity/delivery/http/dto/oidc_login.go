@@ -0,0 +1,15 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+)
+
+type OidcLoginResponseDto struct {
+	UserID uuid.UUID                 `json:"user_id" validate:"required"`
+	Tokens *OidcTokenPairResponseDto `json:"tokens" validate:"required"`
+}
+
+type OidcTokenPairResponseDto struct {
+	AccessToken  string `json:"access_token" validate:"required"`
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
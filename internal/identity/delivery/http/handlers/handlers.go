@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-playground/validator"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/identity"
+	"github.com/dinorain/kalobranded/internal/identity/delivery/http/dto"
+	"github.com/dinorain/kalobranded/internal/middlewares"
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/internal/server/router"
+	"github.com/dinorain/kalobranded/internal/session"
+	"github.com/dinorain/kalobranded/internal/user"
+	httpErrors "github.com/dinorain/kalobranded/pkg/http_errors"
+	"github.com/dinorain/kalobranded/pkg/logger"
+)
+
+type identityHandlersHTTP struct {
+	router     *router.Router
+	logger     logger.Logger
+	cfg        *config.Config
+	mw         middlewares.MiddlewareManager
+	v          *validator.Validate
+	identityUC identity.IdentityUseCase
+	userUC     user.UserUseCase
+	sessUC     session.SessUseCase
+}
+
+var _ identity.IdentityHandlers = (*identityHandlersHTTP)(nil)
+
+func NewIdentityHandlersHTTP(
+	router *router.Router,
+	logger logger.Logger,
+	cfg *config.Config,
+	mw middlewares.MiddlewareManager,
+	v *validator.Validate,
+	identityUC identity.IdentityUseCase,
+	userUC user.UserUseCase,
+	sessUC session.SessUseCase,
+) *identityHandlersHTTP {
+	return &identityHandlersHTTP{router: router, logger: logger, cfg: cfg, mw: mw, v: v, identityUC: identityUC, userUC: userUC, sessUC: sessUC}
+}
+
+// Login
+// @Tags Users
+// @Summary OIDC login
+// @Description Redirect to the external identity provider authorization endpoint
+// @Accept json
+// @Produce json
+// @Param provider query string true "configured provider name"
+// @Success 302 {object} nil
+// @Router /users/oidc/login [get]
+func (h *identityHandlersHTTP) Login(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	provider := r.URL.Query().Get("provider")
+	if provider == "" {
+		_ = httpErrors.NewBadRequestError(w, "provider is required", h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	authURL, err := h.identityUC.AuthCodeURL(ctx, provider)
+	if err != nil {
+		h.logger.Errorf("identityUC.AuthCodeURL: %v", err)
+		if errors.Is(err, identity.ErrProviderNotFound) {
+			_ = httpErrors.NewNotFoundError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+			return
+		}
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	http.Redirect(w, r, authURL, http.StatusFound)
+}
+
+// Callback
+// @Tags Users
+// @Summary OIDC callback
+// @Description Exchange authorization code, link or create the user and issue a token pair
+// @Accept json
+// @Produce json
+// @Param state query string true "state"
+// @Param code query string true "authorization code"
+// @Success 200 {object} dto.OidcLoginResponseDto
+// @Router /users/oidc/callback [get]
+func (h *identityHandlersHTTP) Callback(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	queryParam := r.URL.Query()
+
+	if providerErr := queryParam.Get("error"); providerErr != "" {
+		h.logger.Warnf("oidc provider error: %s", providerErr)
+		_ = httpErrors.NewUnauthorizedError(w, providerErr, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	provider, claims, err := h.identityUC.Exchange(ctx, queryParam.Get("state"), queryParam.Get("code"))
+	if err != nil {
+		h.logger.Errorf("identityUC.Exchange: %v", err)
+		_ = httpErrors.NewUnauthorizedError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	user, err := h.identityUC.FindOrCreateUser(ctx, provider, claims)
+	if err != nil {
+		h.logger.Errorf("identityUC.FindOrCreateUser: %v", err)
+		switch {
+		case errors.Is(err, identity.ErrEmailRequired):
+			_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		case errors.Is(err, identity.ErrEmailExists):
+			_ = httpErrors.NewConflictError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		default:
+			_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		}
+		return
+	}
+
+	session, err := h.sessUC.CreateSession(ctx, &models.Session{
+		UserID: user.UserID,
+	}, h.cfg.Session.Expire)
+	if err != nil {
+		h.logger.Errorf("sessUC.CreateSession: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	accessToken, refreshToken, err := h.userUC.GenerateTokenPair(user, session)
+	if err != nil {
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	res, _ := json.Marshal(dto.OidcLoginResponseDto{UserID: user.UserID, Tokens: &dto.OidcTokenPairResponseDto{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}})
+	w.WriteHeader(http.StatusOK)
+	w.Write(res)
+	return
+}
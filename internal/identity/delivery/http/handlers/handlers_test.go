@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-playground/validator"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/identity"
+	"github.com/dinorain/kalobranded/internal/identity/delivery/http/dto"
+	"github.com/dinorain/kalobranded/internal/identity/mock"
+	"github.com/dinorain/kalobranded/internal/middlewares"
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/internal/server/router"
+	mockSessUC "github.com/dinorain/kalobranded/internal/session/mock"
+	mockUserUC "github.com/dinorain/kalobranded/internal/user/mock"
+	"github.com/dinorain/kalobranded/pkg/logger"
+	"github.com/dinorain/kalobranded/pkg/oidc"
+)
+
+func TestIdentityHandler_Login(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	identityUC := mock.NewMockIdentityUseCase(ctrl)
+	userUC := mockUserUC.NewMockUserUseCase(ctrl)
+	sessUC := mockSessUC.NewMockSessUseCase(ctrl)
+
+	cfg := &config.Config{Session: config.Session{Expire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	appLogger := logger.NewAppLogger(cfg)
+	appLogger.InitLogger()
+	mw := middlewares.NewMiddlewareManager(appLogger, cfg)
+	v := validator.New()
+	rt := router.NewRouter(false)
+	handlers := NewIdentityHandlersHTTP(rt, appLogger, cfg, mw, v, identityUC, userUC, sessUC)
+
+	t.Run("Redirect", func(t *testing.T) {
+		identityUC.EXPECT().AuthCodeURL(gomock.Any(), "google").Return("https://accounts.google.com/o/oauth2/auth?state=state", nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/users/oidc/login?provider=google", nil)
+		w := httptest.NewRecorder()
+		http.HandlerFunc(handlers.Login).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusFound, w.Code)
+		require.Equal(t, "https://accounts.google.com/o/oauth2/auth?state=state", w.Header().Get("Location"))
+	})
+
+	t.Run("UnknownProvider", func(t *testing.T) {
+		identityUC.EXPECT().AuthCodeURL(gomock.Any(), "unknown").Return("", identity.ErrProviderNotFound)
+
+		req := httptest.NewRequest(http.MethodGet, "/users/oidc/login?provider=unknown", nil)
+		w := httptest.NewRecorder()
+		http.HandlerFunc(handlers.Login).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestIdentityHandler_Callback(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	identityUC := mock.NewMockIdentityUseCase(ctrl)
+	userUC := mockUserUC.NewMockUserUseCase(ctrl)
+	sessUC := mockSessUC.NewMockSessUseCase(ctrl)
+
+	cfg := &config.Config{Session: config.Session{Expire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	appLogger := logger.NewAppLogger(cfg)
+	appLogger.InitLogger()
+	mw := middlewares.NewMiddlewareManager(appLogger, cfg)
+	v := validator.New()
+	rt := router.NewRouter(false)
+	handlers := NewIdentityHandlersHTTP(rt, appLogger, cfg, mw, v, identityUC, userUC, sessUC)
+
+	userUUID := uuid.New()
+	sessUUID := uuid.New().String()
+	claims := &oidc.Claims{Subject: "subject", Email: "Email@gmail.com", EmailVerified: true, GivenName: "FirstName"}
+
+	t.Run("SignIn", func(t *testing.T) {
+		identityUC.EXPECT().Exchange(gomock.Any(), "state", "code").Return("google", claims, nil)
+		identityUC.EXPECT().FindOrCreateUser(gomock.Any(), "google", claims).Return(&models.User{UserID: userUUID}, nil)
+		sessUC.EXPECT().CreateSession(gomock.Any(), &models.Session{UserID: userUUID}, 1234).Return(sessUUID, nil)
+		userUC.EXPECT().GenerateTokenPair(gomock.Any(), sessUUID).Return("access", "refresh", nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/users/oidc/callback?state=state&code=code", nil)
+		w := httptest.NewRecorder()
+		http.HandlerFunc(handlers.Callback).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		resDto := &dto.OidcLoginResponseDto{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), resDto))
+		require.Equal(t, userUUID, resDto.UserID)
+		require.Equal(t, "access", resDto.Tokens.AccessToken)
+	})
+
+	t.Run("EmailExists", func(t *testing.T) {
+		identityUC.EXPECT().Exchange(gomock.Any(), "state", "code").Return("google", claims, nil)
+		identityUC.EXPECT().FindOrCreateUser(gomock.Any(), "google", claims).Return(nil, identity.ErrEmailExists)
+
+		req := httptest.NewRequest(http.MethodGet, "/users/oidc/callback?state=state&code=code", nil)
+		w := httptest.NewRecorder()
+		http.HandlerFunc(handlers.Callback).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("InvalidState", func(t *testing.T) {
+		identityUC.EXPECT().Exchange(gomock.Any(), "replayed", "code").Return("", nil, identity.ErrInvalidState)
+
+		req := httptest.NewRequest(http.MethodGet, "/users/oidc/callback?state=replayed&code=code", nil)
+		w := httptest.NewRecorder()
+		http.HandlerFunc(handlers.Callback).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
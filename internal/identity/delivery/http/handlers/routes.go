@@ -0,0 +1,7 @@
+package handlers
+
+func (h *identityHandlersHTTP) IdentityMapRoutes() {
+	oidc := h.router.Group("/users/oidc")
+	oidc.Get("/login", h.Login)
+	oidc.Get("/callback", h.Callback)
+}
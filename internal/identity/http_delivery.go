@@ -0,0 +1,11 @@
+package identity
+
+import (
+	"net/http"
+)
+
+// Identity HTTP Handlers interface
+type IdentityHandlers interface {
+	Login(w http.ResponseWriter, r *http.Request)
+	Callback(w http.ResponseWriter, r *http.Request)
+}
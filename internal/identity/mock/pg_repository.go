@@ -0,0 +1,112 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pg_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/dinorain/kalobranded/internal/models"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockIdentityPGRepository is a mock of IdentityPGRepository interface.
+type MockIdentityPGRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdentityPGRepositoryMockRecorder
+}
+
+// MockIdentityPGRepositoryMockRecorder is the mock recorder for MockIdentityPGRepository.
+type MockIdentityPGRepositoryMockRecorder struct {
+	mock *MockIdentityPGRepository
+}
+
+// NewMockIdentityPGRepository creates a new mock instance.
+func NewMockIdentityPGRepository(ctrl *gomock.Controller) *MockIdentityPGRepository {
+	mock := &MockIdentityPGRepository{ctrl: ctrl}
+	mock.recorder = &MockIdentityPGRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdentityPGRepository) EXPECT() *MockIdentityPGRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIdentityPGRepository) Create(ctx context.Context, identity *models.UserIdentity) (*models.UserIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, identity)
+	ret0, _ := ret[0].(*models.UserIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIdentityPGRepositoryMockRecorder) Create(ctx, identity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIdentityPGRepository)(nil).Create), ctx, identity)
+}
+
+// FindAllByUserId mocks base method.
+func (m *MockIdentityPGRepository) FindAllByUserId(ctx context.Context, userID uuid.UUID) ([]models.UserIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByUserId", ctx, userID)
+	ret0, _ := ret[0].([]models.UserIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByUserId indicates an expected call of FindAllByUserId.
+func (mr *MockIdentityPGRepositoryMockRecorder) FindAllByUserId(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByUserId", reflect.TypeOf((*MockIdentityPGRepository)(nil).FindAllByUserId), ctx, userID)
+}
+
+// FindByProviderSubject mocks base method.
+func (m *MockIdentityPGRepository) FindByProviderSubject(ctx context.Context, provider, subject string) (*models.UserIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByProviderSubject", ctx, provider, subject)
+	ret0, _ := ret[0].(*models.UserIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByProviderSubject indicates an expected call of FindByProviderSubject.
+func (mr *MockIdentityPGRepositoryMockRecorder) FindByProviderSubject(ctx, provider, subject interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByProviderSubject", reflect.TypeOf((*MockIdentityPGRepository)(nil).FindByProviderSubject), ctx, provider, subject)
+}
+
+// FindUserByProviderSubject mocks base method.
+func (m *MockIdentityPGRepository) FindUserByProviderSubject(ctx context.Context, provider, subject string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindUserByProviderSubject", ctx, provider, subject)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindUserByProviderSubject indicates an expected call of FindUserByProviderSubject.
+func (mr *MockIdentityPGRepositoryMockRecorder) FindUserByProviderSubject(ctx, provider, subject interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUserByProviderSubject", reflect.TypeOf((*MockIdentityPGRepository)(nil).FindUserByProviderSubject), ctx, provider, subject)
+}
+
+// LinkUser mocks base method.
+func (m *MockIdentityPGRepository) LinkUser(ctx context.Context, identity *models.UserIdentity, user *models.User, linkByEmail bool) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkUser", ctx, identity, user, linkByEmail)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LinkUser indicates an expected call of LinkUser.
+func (mr *MockIdentityPGRepositoryMockRecorder) LinkUser(ctx, identity, user, linkByEmail interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkUser", reflect.TypeOf((*MockIdentityPGRepository)(nil).LinkUser), ctx, identity, user, linkByEmail)
+}
@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: redis_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/dinorain/kalobranded/internal/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIdentityRedisRepository is a mock of IdentityRedisRepository interface.
+type MockIdentityRedisRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdentityRedisRepositoryMockRecorder
+}
+
+// MockIdentityRedisRepositoryMockRecorder is the mock recorder for MockIdentityRedisRepository.
+type MockIdentityRedisRepositoryMockRecorder struct {
+	mock *MockIdentityRedisRepository
+}
+
+// NewMockIdentityRedisRepository creates a new mock instance.
+func NewMockIdentityRedisRepository(ctrl *gomock.Controller) *MockIdentityRedisRepository {
+	mock := &MockIdentityRedisRepository{ctrl: ctrl}
+	mock.recorder = &MockIdentityRedisRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdentityRedisRepository) EXPECT() *MockIdentityRedisRepositoryMockRecorder {
+	return m.recorder
+}
+
+// SetAuthStateCtx mocks base method.
+func (m *MockIdentityRedisRepository) SetAuthStateCtx(ctx context.Context, key string, seconds int, state *models.OidcAuthState) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAuthStateCtx", ctx, key, seconds, state)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAuthStateCtx indicates an expected call of SetAuthStateCtx.
+func (mr *MockIdentityRedisRepositoryMockRecorder) SetAuthStateCtx(ctx, key, seconds, state interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAuthStateCtx", reflect.TypeOf((*MockIdentityRedisRepository)(nil).SetAuthStateCtx), ctx, key, seconds, state)
+}
+
+// TakeAuthStateCtx mocks base method.
+func (m *MockIdentityRedisRepository) TakeAuthStateCtx(ctx context.Context, key string) (*models.OidcAuthState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakeAuthStateCtx", ctx, key)
+	ret0, _ := ret[0].(*models.OidcAuthState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TakeAuthStateCtx indicates an expected call of TakeAuthStateCtx.
+func (mr *MockIdentityRedisRepositoryMockRecorder) TakeAuthStateCtx(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakeAuthStateCtx", reflect.TypeOf((*MockIdentityRedisRepository)(nil).TakeAuthStateCtx), ctx, key)
+}
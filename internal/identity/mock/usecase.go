@@ -0,0 +1,83 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/dinorain/kalobranded/internal/models"
+	oidc "github.com/dinorain/kalobranded/pkg/oidc"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIdentityUseCase is a mock of IdentityUseCase interface.
+type MockIdentityUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdentityUseCaseMockRecorder
+}
+
+// MockIdentityUseCaseMockRecorder is the mock recorder for MockIdentityUseCase.
+type MockIdentityUseCaseMockRecorder struct {
+	mock *MockIdentityUseCase
+}
+
+// NewMockIdentityUseCase creates a new mock instance.
+func NewMockIdentityUseCase(ctrl *gomock.Controller) *MockIdentityUseCase {
+	mock := &MockIdentityUseCase{ctrl: ctrl}
+	mock.recorder = &MockIdentityUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdentityUseCase) EXPECT() *MockIdentityUseCaseMockRecorder {
+	return m.recorder
+}
+
+// AuthCodeURL mocks base method.
+func (m *MockIdentityUseCase) AuthCodeURL(ctx context.Context, provider string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthCodeURL", ctx, provider)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthCodeURL indicates an expected call of AuthCodeURL.
+func (mr *MockIdentityUseCaseMockRecorder) AuthCodeURL(ctx, provider interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthCodeURL", reflect.TypeOf((*MockIdentityUseCase)(nil).AuthCodeURL), ctx, provider)
+}
+
+// Exchange mocks base method.
+func (m *MockIdentityUseCase) Exchange(ctx context.Context, state, code string) (string, *oidc.Claims, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exchange", ctx, state, code)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(*oidc.Claims)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Exchange indicates an expected call of Exchange.
+func (mr *MockIdentityUseCaseMockRecorder) Exchange(ctx, state, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exchange", reflect.TypeOf((*MockIdentityUseCase)(nil).Exchange), ctx, state, code)
+}
+
+// FindOrCreateUser mocks base method.
+func (m *MockIdentityUseCase) FindOrCreateUser(ctx context.Context, provider string, claims *oidc.Claims) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOrCreateUser", ctx, provider, claims)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOrCreateUser indicates an expected call of FindOrCreateUser.
+func (mr *MockIdentityUseCaseMockRecorder) FindOrCreateUser(ctx, provider, claims interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOrCreateUser", reflect.TypeOf((*MockIdentityUseCase)(nil).FindOrCreateUser), ctx, provider, claims)
+}
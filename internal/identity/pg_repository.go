@@ -0,0 +1,19 @@
+//go:generate mockgen -source pg_repository.go -destination mock/pg_repository.go -package mock
+package identity
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/internal/models"
+)
+
+// Identity pg repository
+type IdentityPGRepository interface {
+	Create(ctx context.Context, identity *models.UserIdentity) (*models.UserIdentity, error)
+	FindByProviderSubject(ctx context.Context, provider string, subject string) (*models.UserIdentity, error)
+	FindAllByUserId(ctx context.Context, userID uuid.UUID) ([]models.UserIdentity, error)
+	FindUserByProviderSubject(ctx context.Context, provider string, subject string) (*models.User, error)
+	LinkUser(ctx context.Context, identity *models.UserIdentity, user *models.User, linkByEmail bool) (*models.User, error)
+}
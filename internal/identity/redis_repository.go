@@ -0,0 +1,14 @@
+//go:generate mockgen -source redis_repository.go -destination mock/redis_repository.go -package mock
+package identity
+
+import (
+	"context"
+
+	"github.com/dinorain/kalobranded/internal/models"
+)
+
+// Identity Redis repository interface
+type IdentityRedisRepository interface {
+	TakeAuthStateCtx(ctx context.Context, key string) (*models.OidcAuthState, error)
+	SetAuthStateCtx(ctx context.Context, key string, seconds int, state *models.OidcAuthState) error
+}
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"github.com/dinorain/kalobranded/internal/identity"
+	"github.com/dinorain/kalobranded/internal/models"
+	userRepository "github.com/dinorain/kalobranded/internal/user/repository"
+)
+
+// Identity repository
+type IdentityRepository struct {
+	db *sqlx.DB
+}
+
+var _ identity.IdentityPGRepository = (*IdentityRepository)(nil)
+
+// Identity repository constructor
+func NewIdentityPGRepository(db *sqlx.DB) *IdentityRepository {
+	return &IdentityRepository{db: db}
+}
+
+// Create link a provider subject to a user
+func (r *IdentityRepository) Create(ctx context.Context, identity *models.UserIdentity) (*models.UserIdentity, error) {
+	createdIdentity := &models.UserIdentity{}
+	if err := r.db.QueryRowxContext(
+		ctx,
+		createIdentityQuery,
+		identity.UserID,
+		identity.Provider,
+		identity.Subject,
+		identity.Email,
+	).StructScan(createdIdentity); err != nil {
+		return nil, errors.Wrap(err, "IdentityRepository.Create.QueryRowxContext")
+	}
+
+	return createdIdentity, nil
+}
+
+// FindByProviderSubject Find identity by provider name and subject
+func (r *IdentityRepository) FindByProviderSubject(ctx context.Context, provider string, subject string) (*models.UserIdentity, error) {
+	identity := &models.UserIdentity{}
+	if err := r.db.GetContext(ctx, identity, findByProviderSubjectQuery, provider, subject); err != nil {
+		return nil, errors.Wrap(err, "IdentityRepository.FindByProviderSubject.GetContext")
+	}
+
+	return identity, nil
+}
+
+// FindAllByUserId Find identities linked to user uuid
+func (r *IdentityRepository) FindAllByUserId(ctx context.Context, userID uuid.UUID) ([]models.UserIdentity, error) {
+	var identities []models.UserIdentity
+	if err := r.db.SelectContext(ctx, &identities, findAllByUserIdQuery, userID); err != nil {
+		return nil, errors.Wrap(err, "IdentityRepository.FindAllByUserId.SelectContext")
+	}
+
+	return identities, nil
+}
+
+// FindUserByProviderSubject Find the user linked to provider name and subject
+func (r *IdentityRepository) FindUserByProviderSubject(ctx context.Context, provider string, subject string) (*models.User, error) {
+	user := &models.User{}
+	if err := r.db.GetContext(ctx, user, findUserByProviderSubjectQuery, provider, subject); err != nil {
+		return nil, errors.Wrap(err, "IdentityRepository.FindUserByProviderSubject.GetContext")
+	}
+
+	return user, nil
+}
+
+// LinkUser link the provider subject of userIdentity to a user in one transaction. With linkByEmail it is linked to
+// the user registered with the email of user if there is one, otherwise user is registered. When the email is taken
+// and linkByEmail is unset nothing changes. A subject linked by a concurrent sign in is answered with its user
+func (r *IdentityRepository) LinkUser(ctx context.Context, userIdentity *models.UserIdentity, user *models.User, linkByEmail bool) (*models.User, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "IdentityRepository.LinkUser.BeginTxx")
+	}
+	defer tx.Rollback()
+
+	linkedUser := &models.User{}
+	err = tx.GetContext(ctx, linkedUser, lockUserByEmailQuery, user.Email)
+	switch {
+	case err == nil && !linkByEmail:
+		return nil, identity.ErrEmailExists
+	case errors.Is(err, sql.ErrNoRows):
+		if linkedUser, err = userRepository.CreateUser(ctx, tx, user); err != nil {
+			return nil, errors.Wrap(err, "IdentityRepository.LinkUser.CreateUser")
+		}
+	case err != nil:
+		return nil, errors.Wrap(err, "IdentityRepository.LinkUser.GetContext")
+	}
+
+	res, err := tx.ExecContext(ctx, linkIdentityQuery, linkedUser.UserID, userIdentity.Provider, userIdentity.Subject, userIdentity.Email)
+	if err != nil {
+		return nil, errors.Wrap(err, "IdentityRepository.LinkUser.ExecContext")
+	}
+	linked, err := res.RowsAffected()
+	if err != nil {
+		return nil, errors.Wrap(err, "IdentityRepository.LinkUser.RowsAffected")
+	}
+	if linked == 0 {
+		if err := tx.Rollback(); err != nil {
+			return nil, errors.Wrap(err, "IdentityRepository.LinkUser.Rollback")
+		}
+		return r.FindUserByProviderSubject(ctx, userIdentity.Provider, userIdentity.Subject)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "IdentityRepository.LinkUser.Commit")
+	}
+
+	return linkedUser, nil
+}
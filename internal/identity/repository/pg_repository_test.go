@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/kalobranded/internal/identity"
+	"github.com/dinorain/kalobranded/internal/models"
+	outboxRepository "github.com/dinorain/kalobranded/internal/outbox/repository"
+	userRepository "github.com/dinorain/kalobranded/internal/user/repository"
+)
+
+func TestIdentityRepository_Create(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	identityPGRepository := NewIdentityPGRepository(sqlxDB)
+
+	columns := []string{"identity_id", "user_id", "provider", "subject", "email", "created_at", "updated_at"}
+	identityUUID := uuid.New()
+	mockIdentity := &models.UserIdentity{
+		UserID:   uuid.New(),
+		Provider: "google",
+		Subject:  "subject",
+		Email:    "email@gmail.com",
+	}
+
+	rows := sqlmock.NewRows(columns).AddRow(
+		identityUUID,
+		mockIdentity.UserID,
+		mockIdentity.Provider,
+		mockIdentity.Subject,
+		mockIdentity.Email,
+		time.Now(),
+		time.Now(),
+	)
+
+	mock.ExpectQuery(createIdentityQuery).WithArgs(
+		mockIdentity.UserID,
+		mockIdentity.Provider,
+		mockIdentity.Subject,
+		mockIdentity.Email,
+	).WillReturnRows(rows)
+
+	createdIdentity, err := identityPGRepository.Create(context.Background(), mockIdentity)
+	require.NoError(t, err)
+	require.NotNil(t, createdIdentity)
+	require.Equal(t, identityUUID, createdIdentity.IdentityID)
+}
+
+func TestIdentityRepository_FindByProviderSubject(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	identityPGRepository := NewIdentityPGRepository(sqlxDB)
+
+	columns := []string{"identity_id", "user_id", "provider", "subject", "email", "created_at", "updated_at"}
+	userUUID := uuid.New()
+
+	rows := sqlmock.NewRows(columns).AddRow(
+		uuid.New(),
+		userUUID,
+		"google",
+		"subject",
+		"email@gmail.com",
+		time.Now(),
+		time.Now(),
+	)
+
+	mock.ExpectQuery(findByProviderSubjectQuery).WithArgs("google", "subject").WillReturnRows(rows)
+
+	foundIdentity, err := identityPGRepository.FindByProviderSubject(context.Background(), "google", "subject")
+	require.NoError(t, err)
+	require.NotNil(t, foundIdentity)
+	require.Equal(t, userUUID, foundIdentity.UserID)
+}
+
+func TestIdentityRepository_FindAllByUserId(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	identityPGRepository := NewIdentityPGRepository(sqlxDB)
+
+	columns := []string{"identity_id", "user_id", "provider", "subject", "email", "created_at", "updated_at"}
+	userUUID := uuid.New()
+
+	rows := sqlmock.NewRows(columns).
+		AddRow(uuid.New(), userUUID, "google", "subject", "email@gmail.com", time.Now(), time.Now()).
+		AddRow(uuid.New(), userUUID, "github", "subject", "email@gmail.com", time.Now(), time.Now())
+
+	mock.ExpectQuery(findAllByUserIdQuery).WithArgs(userUUID).WillReturnRows(rows)
+
+	identities, err := identityPGRepository.FindAllByUserId(context.Background(), userUUID)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(identities))
+}
+
+func TestIdentityRepository_LinkUser(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	identityPGRepository := NewIdentityPGRepository(sqlxDB)
+
+	columns := []string{"user_id", "email", "first_name", "last_name", "role"}
+	userUUID := uuid.New()
+	userIdentity := &models.UserIdentity{Provider: "google", Subject: "subject", Email: "email@gmail.com"}
+	candidate := &models.User{Email: "email@gmail.com", FirstName: "FirstName", LastName: "LastName", Password: "hash", Role: models.UserRoleUser}
+
+	t.Run("NewUser", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockUserByEmailQuery).WithArgs(candidate.Email).WillReturnRows(sqlmock.NewRows(columns))
+		mock.ExpectQuery(userRepository.CreateUserQuery).WillReturnRows(sqlmock.NewRows(columns).AddRow(userUUID, candidate.Email, "FirstName", "LastName", models.UserRoleUser))
+		mock.ExpectExec(outboxRepository.AddEventQuery).WithArgs(sqlmock.AnyArg(), models.AggregateUser, userUUID, models.EventUserRegistered, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(linkIdentityQuery).WithArgs(userUUID, "google", "subject", "email@gmail.com").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		user, err := identityPGRepository.LinkUser(context.Background(), userIdentity, candidate, false)
+		require.NoError(t, err)
+		require.Equal(t, userUUID, user.UserID)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("LinkByEmail", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockUserByEmailQuery).WithArgs(candidate.Email).WillReturnRows(sqlmock.NewRows(columns).AddRow(userUUID, candidate.Email, "FirstName", "LastName", models.UserRoleUser))
+		mock.ExpectExec(linkIdentityQuery).WithArgs(userUUID, "google", "subject", "email@gmail.com").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		user, err := identityPGRepository.LinkUser(context.Background(), userIdentity, candidate, true)
+		require.NoError(t, err)
+		require.Equal(t, userUUID, user.UserID)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("EmailExists", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockUserByEmailQuery).WithArgs(candidate.Email).WillReturnRows(sqlmock.NewRows(columns).AddRow(userUUID, candidate.Email, "FirstName", "LastName", models.UserRoleUser))
+		mock.ExpectRollback()
+
+		_, err := identityPGRepository.LinkUser(context.Background(), userIdentity, candidate, false)
+		require.ErrorIs(t, err, identity.ErrEmailExists)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("LinkedConcurrently", func(t *testing.T) {
+		otherUUID := uuid.New()
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockUserByEmailQuery).WithArgs(candidate.Email).WillReturnRows(sqlmock.NewRows(columns))
+		mock.ExpectQuery(userRepository.CreateUserQuery).WillReturnRows(sqlmock.NewRows(columns).AddRow(userUUID, candidate.Email, "FirstName", "LastName", models.UserRoleUser))
+		mock.ExpectExec(outboxRepository.AddEventQuery).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(linkIdentityQuery).WithArgs(userUUID, "google", "subject", "email@gmail.com").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+		mock.ExpectQuery(findUserByProviderSubjectQuery).WithArgs("google", "subject").WillReturnRows(sqlmock.NewRows(columns).AddRow(otherUUID, candidate.Email, "FirstName", "LastName", models.UserRoleUser))
+
+		user, err := identityPGRepository.LinkUser(context.Background(), userIdentity, candidate, false)
+		require.NoError(t, err)
+		require.Equal(t, otherUUID, user.UserID)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
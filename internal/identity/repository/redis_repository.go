@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/dinorain/kalobranded/internal/identity"
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/pkg/logger"
+)
+
+// takeScript get and delete the key at once, so only one caller ever reads it
+var takeScript = redis.NewScript(`
+local value = redis.call("GET", KEYS[1])
+if value then
+	redis.call("DEL", KEYS[1])
+end
+return value
+`)
+
+// Identity redis repository
+type identityRedisRepo struct {
+	redisClient *redis.Client
+	basePrefix  string
+	logger      logger.Logger
+}
+
+var _ identity.IdentityRedisRepository = (*identityRedisRepo)(nil)
+
+// Identity redis repository constructor
+func NewIdentityRedisRepo(redisClient *redis.Client, logger logger.Logger) *identityRedisRepo {
+	return &identityRedisRepo{redisClient: redisClient, basePrefix: "oidc_state:", logger: logger}
+}
+
+// Take pending authorization state, deleting it so a replayed callback finds none
+func (r *identityRedisRepo) TakeAuthStateCtx(ctx context.Context, key string) (*models.OidcAuthState, error) {
+	stateString, err := takeScript.Run(ctx, r.redisClient, []string{r.createKey(key)}).Text()
+	if err != nil {
+		return nil, err
+	}
+	state := &models.OidcAuthState{}
+	if err = json.Unmarshal([]byte(stateString), state); err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+// Store authorization state with duration in seconds
+func (r *identityRedisRepo) SetAuthStateCtx(ctx context.Context, key string, seconds int, state *models.OidcAuthState) error {
+	stateBytes, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	return r.redisClient.Set(ctx, r.createKey(key), stateBytes, time.Second*time.Duration(seconds)).Err()
+}
+
+func (r *identityRedisRepo) createKey(value string) string {
+	return fmt.Sprintf("%s: %s", r.basePrefix, value)
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"testing"
+
+	"github.com/alicebob/miniredis"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/kalobranded/internal/models"
+)
+
+func SetupRedis() *identityRedisRepo {
+	mr, err := miniredis.Run()
+	if err != nil {
+		log.Fatal(err)
+	}
+	client := redis.NewClient(&redis.Options{
+		Addr: mr.Addr(),
+	})
+
+	identityRedisRepository := NewIdentityRedisRepo(client, nil)
+	return identityRedisRepository
+}
+
+func TestIdentityRedisRepo_SetAuthStateCtx(t *testing.T) {
+	t.Parallel()
+
+	redisRepo := SetupRedis()
+
+	t.Run("SetAuthStateCtx", func(t *testing.T) {
+		state := &models.OidcAuthState{State: "state", Provider: "google", Nonce: "nonce", CodeVerifier: "verifier"}
+
+		err := redisRepo.SetAuthStateCtx(context.Background(), state.State, 10, state)
+		require.NoError(t, err)
+	})
+}
+
+func TestIdentityRedisRepo_TakeAuthStateCtx(t *testing.T) {
+	t.Parallel()
+
+	redisRepo := SetupRedis()
+
+	t.Run("TakeAuthStateCtx", func(t *testing.T) {
+		state := &models.OidcAuthState{State: "state", Provider: "google", Nonce: "nonce", CodeVerifier: "verifier"}
+
+		err := redisRepo.SetAuthStateCtx(context.Background(), state.State, 10, state)
+		require.NoError(t, err)
+
+		found, err := redisRepo.TakeAuthStateCtx(context.Background(), state.State)
+		require.NoError(t, err)
+		require.Equal(t, state, found)
+
+		_, err = redisRepo.TakeAuthStateCtx(context.Background(), state.State)
+		require.ErrorIs(t, err, redis.Nil)
+	})
+}
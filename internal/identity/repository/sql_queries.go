@@ -0,0 +1,24 @@
+package repository
+
+const (
+	createIdentityQuery = `INSERT INTO user_identities (user_id, provider, subject, email) 
+		VALUES ($1, $2, $3, $4)
+		RETURNING identity_id, user_id, provider, subject, email, created_at, updated_at`
+
+	findByProviderSubjectQuery = `SELECT identity_id, user_id, provider, subject, email, created_at, updated_at FROM user_identities WHERE provider = $1 AND subject = $2`
+
+	findAllByUserIdQuery = `SELECT identity_id, user_id, provider, subject, email, created_at, updated_at FROM user_identities WHERE user_id = $1`
+
+	findUserByProviderSubjectQuery = `SELECT u.user_id, u.email, u.first_name, u.last_name, u.role, u.avatar, u.brand_id, u.password, u.delivery_address, u.delivery_latitude, u.delivery_longitude, u.created_at, u.updated_at, u.version, u.deleted_at 
+		FROM users u JOIN user_identities ui ON ui.user_id = u.user_id 
+		WHERE ui.provider = $1 AND ui.subject = $2 AND u.deleted_at IS NULL`
+
+	// lockUserByEmailQuery user registered with the email, locked until the identity is linked to it
+	lockUserByEmailQuery = `SELECT user_id, email, first_name, last_name, role, avatar, brand_id, password, delivery_address, delivery_latitude, delivery_longitude, created_at, updated_at, version, deleted_at 
+		FROM users WHERE email = $1 AND deleted_at IS NULL FOR UPDATE`
+
+	// linkIdentityQuery link the provider subject unless a concurrent sign in linked it first
+	linkIdentityQuery = `INSERT INTO user_identities (user_id, provider, subject, email) 
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (provider, subject) DO NOTHING`
+)
@@ -0,0 +1,24 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase.go -package mock
+package identity
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/pkg/oidc"
+)
+
+var (
+	ErrProviderNotFound = errors.New("oidc provider not found")
+	ErrInvalidState     = errors.New("invalid oidc state token")
+	ErrEmailRequired    = errors.New("oidc email claim is required")
+	ErrEmailExists      = errors.New("email already registered, sign in with password")
+)
+
+// Identity UseCase interface
+type IdentityUseCase interface {
+	AuthCodeURL(ctx context.Context, provider string) (string, error)
+	Exchange(ctx context.Context, state string, code string) (string, *oidc.Claims, error)
+	FindOrCreateUser(ctx context.Context, provider string, claims *oidc.Claims) (*models.User, error)
+}
@@ -0,0 +1,188 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/pkg/errors"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/identity"
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/pkg/logger"
+	"github.com/dinorain/kalobranded/pkg/oidc"
+)
+
+const (
+	authStateDefaultDuration = 600
+	maxNameLength            = 32
+)
+
+// Identity UseCase
+type identityUseCase struct {
+	cfg            *config.Config
+	logger         logger.Logger
+	identityPgRepo identity.IdentityPGRepository
+	redisRepo      identity.IdentityRedisRepository
+	providers      map[string]oidc.Provider
+}
+
+var _ identity.IdentityUseCase = (*identityUseCase)(nil)
+
+// New Identity UseCase
+func NewIdentityUseCase(
+	cfg *config.Config,
+	logger logger.Logger,
+	identityRepo identity.IdentityPGRepository,
+	redisRepo identity.IdentityRedisRepository,
+	providers map[string]oidc.Provider,
+) *identityUseCase {
+	return &identityUseCase{cfg: cfg, logger: logger, identityPgRepo: identityRepo, redisRepo: redisRepo, providers: providers}
+}
+
+// AuthCodeURL start authorization code flow, state, nonce and PKCE verifier are kept in redis until callback
+func (u *identityUseCase) AuthCodeURL(ctx context.Context, provider string) (string, error) {
+	p, ok := u.providers[provider]
+	if !ok {
+		return "", identity.ErrProviderNotFound
+	}
+
+	authState := &models.OidcAuthState{Provider: provider}
+	for _, s := range []*string{&authState.State, &authState.Nonce, &authState.CodeVerifier} {
+		v, err := oidc.RandomString()
+		if err != nil {
+			return "", errors.Wrap(err, "oidc.RandomString")
+		}
+		*s = v
+	}
+
+	if err := u.redisRepo.SetAuthStateCtx(ctx, authState.State, u.getStateDuration(), authState); err != nil {
+		return "", errors.Wrap(err, "redisRepo.SetAuthStateCtx")
+	}
+
+	authURL, err := p.AuthCodeURL(ctx, authState.State, authState.Nonce, oidc.CodeChallengeS256(authState.CodeVerifier))
+	if err != nil {
+		return "", errors.Wrap(err, "provider.AuthCodeURL")
+	}
+
+	return authURL, nil
+}
+
+// Exchange consume state, exchange code and return provider name with verified ID token claims
+func (u *identityUseCase) Exchange(ctx context.Context, state string, code string) (string, *oidc.Claims, error) {
+	if state == "" || code == "" {
+		return "", nil, identity.ErrInvalidState
+	}
+
+	// State is single use, taking it deletes it so a replayed callback does not succeed
+	authState, err := u.redisRepo.TakeAuthStateCtx(ctx, state)
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return "", nil, identity.ErrInvalidState
+		}
+		return "", nil, errors.Wrap(err, "redisRepo.TakeAuthStateCtx")
+	}
+
+	p, ok := u.providers[authState.Provider]
+	if !ok {
+		return "", nil, identity.ErrProviderNotFound
+	}
+
+	token, err := p.Exchange(ctx, code, authState.CodeVerifier)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "provider.Exchange")
+	}
+
+	claims, err := p.VerifyIDToken(ctx, token.IDToken, authState.Nonce)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "provider.VerifyIDToken")
+	}
+
+	return authState.Provider, claims, nil
+}
+
+// FindOrCreateUser resolve the user signing in with claims of provider. A subject seen before signs in its linked
+// user. Otherwise a verified email links the user registered with it, for providers trusted with emails only, or a
+// new user is registered
+func (u *identityUseCase) FindOrCreateUser(ctx context.Context, provider string, claims *oidc.Claims) (*models.User, error) {
+	user, err := u.identityPgRepo.FindUserByProviderSubject(ctx, provider, claims.Subject)
+	if err == nil {
+		user.SanitizePassword()
+		return user, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.Wrap(err, "identityPgRepo.FindUserByProviderSubject")
+	}
+
+	if claims.Email == "" {
+		return nil, identity.ErrEmailRequired
+	}
+
+	candidate, err := oidcClaimsToUserModel(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	linkByEmail := claims.EmailVerified && u.cfg.Oidc.Providers[provider].TrustEmail
+	user, err = u.identityPgRepo.LinkUser(ctx, &models.UserIdentity{
+		Provider: provider,
+		Subject:  claims.Subject,
+		Email:    claims.Email,
+	}, candidate, linkByEmail)
+	if err != nil {
+		return nil, errors.Wrap(err, "identityPgRepo.LinkUser")
+	}
+
+	user.SanitizePassword()
+
+	return user, nil
+}
+
+func oidcClaimsToUserModel(claims *oidc.Claims) (*models.User, error) {
+	// Users signing in through a provider never use the password login, keep an unguessable one
+	password, err := oidc.RandomString()
+	if err != nil {
+		return nil, errors.Wrap(err, "oidc.RandomString")
+	}
+
+	userCandidate := &models.User{
+		Email:     claims.Email,
+		FirstName: claims.GivenName,
+		LastName:  claims.FamilyName,
+		Role:      models.UserRoleUser,
+		Password:  password,
+	}
+	if userCandidate.FirstName == "" {
+		userCandidate.FirstName = strings.Split(claims.Email, "@")[0]
+	}
+	if userCandidate.LastName == "" {
+		userCandidate.LastName = "-"
+	}
+	userCandidate.FirstName = truncate(userCandidate.FirstName, maxNameLength)
+	userCandidate.LastName = truncate(userCandidate.LastName, maxNameLength)
+	if claims.Picture != "" {
+		userCandidate.Avatar = &claims.Picture
+	}
+
+	if err := userCandidate.PrepareCreate(); err != nil {
+		return nil, err
+	}
+
+	return userCandidate, nil
+}
+
+func truncate(s string, n int) string {
+	if r := []rune(s); len(r) > n {
+		return string(r[:n])
+	}
+	return s
+}
+
+func (u *identityUseCase) getStateDuration() int {
+	if u.cfg.Oidc.StateExpire > 0 {
+		return u.cfg.Oidc.StateExpire
+	}
+	return authStateDefaultDuration
+}
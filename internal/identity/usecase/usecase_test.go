@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/identity"
+	"github.com/dinorain/kalobranded/internal/identity/mock"
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/pkg/http_client"
+	"github.com/dinorain/kalobranded/pkg/logger"
+	"github.com/dinorain/kalobranded/pkg/oidc"
+	"github.com/dinorain/kalobranded/pkg/oidc/oidctest"
+)
+
+func TestIdentityUseCase_AuthCodeURLExchange(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	identityPGRepository := mock.NewMockIdentityPGRepository(ctrl)
+	identityRedisRepository := mock.NewMockIdentityRedisRepository(ctrl)
+	apiLogger := logger.NewAppLogger(nil)
+
+	stub := oidctest.NewServer("client-id", "client-secret")
+	defer stub.Close()
+
+	cfg := &config.Config{Oidc: config.Oidc{StateExpire: 60}}
+	providers := map[string]oidc.Provider{
+		"stub": oidc.NewProvider("stub", stub.ProviderConfig("http://localhost/user/oidc/callback"), http_client.NewHttpClient(false)),
+	}
+	identityUC := NewIdentityUseCase(cfg, apiLogger, identityPGRepository, identityRedisRepository, providers)
+
+	ctx := context.Background()
+
+	var stored *models.OidcAuthState
+	identityRedisRepository.EXPECT().SetAuthStateCtx(gomock.Any(), gomock.Any(), 60, gomock.Any()).
+		DoAndReturn(func(_ context.Context, key string, _ int, state *models.OidcAuthState) error {
+			require.Equal(t, key, state.State)
+			stored = state
+			return nil
+		})
+
+	authURL, err := identityUC.AuthCodeURL(ctx, "stub")
+	require.NoError(t, err)
+	require.NotNil(t, stored)
+	require.Equal(t, "stub", stored.Provider)
+
+	code, state, err := stub.Authorize(authURL)
+	require.NoError(t, err)
+	require.Equal(t, stored.State, state)
+
+	identityRedisRepository.EXPECT().TakeAuthStateCtx(gomock.Any(), state).Return(stored, nil)
+
+	provider, claims, err := identityUC.Exchange(ctx, state, code)
+	require.NoError(t, err)
+	require.Equal(t, "stub", provider)
+	require.Equal(t, stub.Identity.Subject, claims.Subject)
+
+	t.Run("ReplayedState", func(t *testing.T) {
+		identityRedisRepository.EXPECT().TakeAuthStateCtx(gomock.Any(), state).Return(nil, redis.Nil)
+
+		_, _, err := identityUC.Exchange(ctx, state, code)
+		require.ErrorIs(t, err, identity.ErrInvalidState)
+	})
+
+	t.Run("UnknownProvider", func(t *testing.T) {
+		_, err := identityUC.AuthCodeURL(ctx, "unknown")
+		require.ErrorIs(t, err, identity.ErrProviderNotFound)
+	})
+}
+
+func TestIdentityUseCase_FindOrCreateUser(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	identityPGRepository := mock.NewMockIdentityPGRepository(ctrl)
+	identityRedisRepository := mock.NewMockIdentityRedisRepository(ctrl)
+	apiLogger := logger.NewAppLogger(nil)
+
+	cfg := &config.Config{Oidc: config.Oidc{Providers: map[string]config.OidcProvider{
+		"google": {TrustEmail: true},
+		"other":  {},
+	}}}
+	identityUC := NewIdentityUseCase(cfg, apiLogger, identityPGRepository, identityRedisRepository, nil)
+
+	userUUID := uuid.New()
+	claims := &oidc.Claims{Subject: "subject", Email: "Email@gmail.com", EmailVerified: true, GivenName: "FirstName"}
+
+	ctx := context.Background()
+
+	t.Run("LinkedIdentity", func(t *testing.T) {
+		identityPGRepository.EXPECT().FindUserByProviderSubject(gomock.Any(), "google", "subject").Return(&models.User{UserID: userUUID, Password: "hash"}, nil)
+
+		user, err := identityUC.FindOrCreateUser(ctx, "google", claims)
+		require.NoError(t, err)
+		require.Equal(t, userUUID, user.UserID)
+		require.Empty(t, user.Password)
+	})
+
+	t.Run("TrustedProvider", func(t *testing.T) {
+		identityPGRepository.EXPECT().FindUserByProviderSubject(gomock.Any(), "google", "subject").Return(nil, sql.ErrNoRows)
+		identityPGRepository.EXPECT().LinkUser(gomock.Any(), &models.UserIdentity{Provider: "google", Subject: "subject", Email: claims.Email}, gomock.Any(), true).
+			DoAndReturn(func(_ context.Context, _ *models.UserIdentity, u *models.User, _ bool) (*models.User, error) {
+				require.Equal(t, "email@gmail.com", u.Email)
+				require.Equal(t, "FirstName", u.FirstName)
+				require.Equal(t, models.UserRoleUser, u.Role)
+				require.NotEmpty(t, u.Password)
+				return &models.User{UserID: userUUID}, nil
+			})
+
+		user, err := identityUC.FindOrCreateUser(ctx, "google", claims)
+		require.NoError(t, err)
+		require.Equal(t, userUUID, user.UserID)
+	})
+
+	t.Run("UntrustedProvider", func(t *testing.T) {
+		identityPGRepository.EXPECT().FindUserByProviderSubject(gomock.Any(), "other", "subject").Return(nil, sql.ErrNoRows)
+		identityPGRepository.EXPECT().LinkUser(gomock.Any(), gomock.Any(), gomock.Any(), false).Return(nil, identity.ErrEmailExists)
+
+		_, err := identityUC.FindOrCreateUser(ctx, "other", claims)
+		require.ErrorIs(t, err, identity.ErrEmailExists)
+	})
+
+	t.Run("UnverifiedEmail", func(t *testing.T) {
+		unverified := &oidc.Claims{Subject: "unverified", Email: "email@gmail.com"}
+		identityPGRepository.EXPECT().FindUserByProviderSubject(gomock.Any(), "google", "unverified").Return(nil, sql.ErrNoRows)
+		identityPGRepository.EXPECT().LinkUser(gomock.Any(), gomock.Any(), gomock.Any(), false).Return(&models.User{UserID: userUUID}, nil)
+
+		_, err := identityUC.FindOrCreateUser(ctx, "google", unverified)
+		require.NoError(t, err)
+	})
+
+	t.Run("NoEmail", func(t *testing.T) {
+		identityPGRepository.EXPECT().FindUserByProviderSubject(gomock.Any(), "google", "anonymous").Return(nil, sql.ErrNoRows)
+
+		_, err := identityUC.FindOrCreateUser(ctx, "google", &oidc.Claims{Subject: "anonymous"})
+		require.ErrorIs(t, err, identity.ErrEmailRequired)
+	})
+}
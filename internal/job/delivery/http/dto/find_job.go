@@ -0,0 +1,8 @@
+package dto
+
+import "github.com/dinorain/kalobranded/pkg/utils"
+
+type JobFindResponseDto struct {
+	Meta utils.PaginationMetaDto `json:"meta"`
+	Data []*JobResponseDto       `json:"data"`
+}
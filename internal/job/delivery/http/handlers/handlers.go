@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/job"
+	"github.com/dinorain/kalobranded/internal/job/delivery/http/dto"
+	"github.com/dinorain/kalobranded/internal/middlewares"
+	"github.com/dinorain/kalobranded/internal/server/router"
+	"github.com/dinorain/kalobranded/pkg/constants"
+	httpErrors "github.com/dinorain/kalobranded/pkg/http_errors"
+	"github.com/dinorain/kalobranded/pkg/jobs"
+	"github.com/dinorain/kalobranded/pkg/logger"
+	"github.com/dinorain/kalobranded/pkg/utils"
+)
+
+type jobHandlersHTTP struct {
+	router *router.Router
+	logger logger.Logger
+	cfg    *config.Config
+	mw     middlewares.MiddlewareManager
+	jobUC  job.JobUseCase
+}
+
+var _ job.JobHandlers = (*jobHandlersHTTP)(nil)
+
+func NewJobHandlersHTTP(
+	router *router.Router,
+	logger logger.Logger,
+	cfg *config.Config,
+	mw middlewares.MiddlewareManager,
+	jobUC job.JobUseCase,
+) *jobHandlersHTTP {
+	return &jobHandlersHTTP{router: router, logger: logger, cfg: cfg, mw: mw, jobUC: jobUC}
+}
+
+// FindAll
+// @Tags Jobs
+// @Summary Find background jobs
+// @Description Admin find background jobs, latest first, optionally of a status and kind
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param status query string false "queued, running, succeeded or failed"
+// @Param kind query string false "job kind"
+// @Param size query string false "pagination size"
+// @Param page query string false "pagination page"
+// @Success 200 {object} dto.JobFindResponseDto
+// @Router /jobs [get]
+func (h *jobHandlersHTTP) FindAll(w http.ResponseWriter, r *http.Request) {
+	queryParam := r.URL.Query()
+	pq := utils.NewPaginationFromQueryParams(queryParam.Get(constants.Size), queryParam.Get(constants.Page))
+
+	filter := jobs.Filter{Status: queryParam.Get(constants.Status), Kind: queryParam.Get(constants.Kind)}
+	switch filter.Status {
+	case "", jobs.StatusQueued, jobs.StatusRunning, jobs.StatusSucceeded, jobs.StatusFailed:
+	default:
+		_ = httpErrors.NewBadRequestError(w, "invalid job status", h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	foundJobs, err := h.jobUC.FindAll(r.Context(), filter, pq)
+	if err != nil {
+		h.logger.Errorf("jobUC.FindAll: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	resDto := dto.JobFindResponseDto{
+		Data: make([]*dto.JobResponseDto, 0, len(foundJobs)),
+		Meta: utils.PaginationMetaDto{
+			Limit:  pq.GetLimit(),
+			Offset: pq.GetOffset(),
+			Page:   pq.GetPage(),
+		},
+	}
+	for i := range foundJobs {
+		resDto.Data = append(resDto.Data, dto.JobResponseFromModel(&foundJobs[i]))
+	}
+
+	res, _ := json.Marshal(resDto)
+	w.WriteHeader(http.StatusOK)
+	w.Write(res)
+	return
+}
+
+// FindById
+// @Tags Jobs
+// @Summary Find background job by id
+// @Description Admin find background job by id, last_error holds the error of the last failed attempt
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "job uuid"
+// @Success 200 {object} dto.JobResponseDto
+// @Router /jobs/{id} [get]
+func (h *jobHandlersHTTP) FindById(w http.ResponseWriter, r *http.Request) {
+	jobUUID, err := uuid.Parse(router.Param(r, constants.ID))
+	if err != nil {
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	foundJob, err := h.jobUC.FindById(r.Context(), jobUUID)
+	if err != nil {
+		h.logger.Errorf("jobUC.FindById: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	res, _ := json.Marshal(dto.JobResponseFromModel(foundJob))
+	w.WriteHeader(http.StatusOK)
+	w.Write(res)
+	return
+}
+
+// Retry
+// @Tags Jobs
+// @Summary Retry failed background job
+// @Description Admin queue a failed job again with a fresh set of attempts. Only failed jobs can be retried, and not while another job with the same unique key is queued or running
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "job uuid"
+// @Success 202 {object} dto.JobResponseDto
+// @Router /jobs/{id}/retry [post]
+func (h *jobHandlersHTTP) Retry(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	jobUUID, err := uuid.Parse(router.Param(r, constants.ID))
+	if err != nil {
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	if _, err := h.jobUC.FindById(ctx, jobUUID); err != nil {
+		h.logger.Errorf("jobUC.FindById: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	retriedJob, err := h.jobUC.Retry(ctx, jobUUID)
+	if err != nil {
+		h.logger.Errorf("jobUC.Retry: %v", err)
+		if errors.Is(err, jobs.ErrNotFailed) || errors.Is(err, jobs.ErrDuplicate) {
+			_ = httpErrors.NewConflictError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+			return
+		}
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	res, _ := json.Marshal(dto.JobResponseFromModel(retriedJob))
+	w.WriteHeader(http.StatusAccepted)
+	w.Write(res)
+	return
+}
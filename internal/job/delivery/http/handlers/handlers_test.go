@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/job/delivery/http/dto"
+	"github.com/dinorain/kalobranded/internal/job/mock"
+	"github.com/dinorain/kalobranded/internal/middlewares"
+	"github.com/dinorain/kalobranded/internal/server/router"
+	"github.com/dinorain/kalobranded/pkg/constants"
+	"github.com/dinorain/kalobranded/pkg/jobs"
+	"github.com/dinorain/kalobranded/pkg/logger"
+	"github.com/dinorain/kalobranded/pkg/utils"
+)
+
+func newTestHandlers(t *testing.T) (*jobHandlersHTTP, *mock.MockJobUseCase) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	jobUC := mock.NewMockJobUseCase(ctrl)
+
+	cfg := &config.Config{Session: config.Session{Expire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	appLogger := logger.NewAppLogger(cfg)
+	appLogger.InitLogger()
+	mw := middlewares.NewMiddlewareManager(appLogger, cfg)
+
+	return NewJobHandlersHTTP(router.NewRouter(false), appLogger, cfg, mw, jobUC), jobUC
+}
+
+func TestJobsHandler_FindAll(t *testing.T) {
+	t.Parallel()
+
+	handlers, jobUC := newTestHandlers(t)
+
+	t.Run("Filtered", func(t *testing.T) {
+		jobUUID := uuid.New()
+		jobUC.EXPECT().FindAll(gomock.Any(), jobs.Filter{Status: jobs.StatusFailed, Kind: "jobs.purge"}, gomock.Any()).
+			DoAndReturn(func(_ interface{}, _ jobs.Filter, pq *utils.Pagination) ([]jobs.Job, error) {
+				require.Equal(t, 2, pq.GetPage())
+				return []jobs.Job{{JobID: jobUUID, Kind: "jobs.purge", Payload: jobs.Payload(`{"keep":"168h"}`), Status: jobs.StatusFailed, LastError: "boom"}}, nil
+			})
+
+		w := httptest.NewRecorder()
+		http.HandlerFunc(handlers.FindAll).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/jobs?status=failed&kind=jobs.purge&page=2", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var res dto.JobFindResponseDto
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+		require.Len(t, res.Data, 1)
+		require.Equal(t, jobUUID, res.Data[0].JobID)
+		require.Equal(t, "boom", res.Data[0].LastError)
+		require.JSONEq(t, `{"keep":"168h"}`, string(res.Data[0].Payload))
+	})
+
+	t.Run("InvalidStatus", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		http.HandlerFunc(handlers.FindAll).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/jobs?status=done", nil))
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestJobsHandler_Retry(t *testing.T) {
+	t.Parallel()
+
+	handlers, jobUC := newTestHandlers(t)
+
+	jobUUID := uuid.New()
+	newRequest := func() *http.Request {
+		return router.WithParams(httptest.NewRequest(http.MethodPost, "/jobs/"+jobUUID.String()+"/retry", nil), map[string]string{constants.ID: jobUUID.String()})
+	}
+
+	t.Run("Failed", func(t *testing.T) {
+		jobUC.EXPECT().FindById(gomock.Any(), jobUUID).Return(&jobs.Job{JobID: jobUUID, Status: jobs.StatusFailed}, nil)
+		jobUC.EXPECT().Retry(gomock.Any(), jobUUID).Return(&jobs.Job{JobID: jobUUID, Status: jobs.StatusQueued, RunAt: time.Now()}, nil)
+
+		w := httptest.NewRecorder()
+		http.HandlerFunc(handlers.Retry).ServeHTTP(w, newRequest())
+		require.Equal(t, http.StatusAccepted, w.Code)
+
+		var res dto.JobResponseDto
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+		require.Equal(t, jobs.StatusQueued, res.Status)
+	})
+
+	t.Run("NotFailed", func(t *testing.T) {
+		jobUC.EXPECT().FindById(gomock.Any(), jobUUID).Return(&jobs.Job{JobID: jobUUID, Status: jobs.StatusSucceeded}, nil)
+		jobUC.EXPECT().Retry(gomock.Any(), jobUUID).Return(nil, jobs.ErrNotFailed)
+
+		w := httptest.NewRecorder()
+		http.HandlerFunc(handlers.Retry).ServeHTTP(w, newRequest())
+		require.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		jobUC.EXPECT().FindById(gomock.Any(), jobUUID).Return(nil, errors.Wrap(sql.ErrNoRows, "Queue.FindById.GetContext"))
+
+		w := httptest.NewRecorder()
+		http.HandlerFunc(handlers.Retry).ServeHTTP(w, newRequest())
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -0,0 +1,8 @@
+package handlers
+
+func (h *jobHandlersHTTP) JobMapRoutes() {
+	jobs := h.router.Group("/jobs")
+	jobs.Get("", h.FindAll, h.mw.IsAdmin)
+	jobs.Get("/{id}", h.FindById, h.mw.IsAdmin)
+	jobs.Post("/{id}/retry", h.Retry, h.mw.IsAdmin)
+}
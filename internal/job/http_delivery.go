@@ -0,0 +1,12 @@
+package job
+
+import (
+	"net/http"
+)
+
+// Job HTTP Handlers interface
+type JobHandlers interface {
+	FindAll(w http.ResponseWriter, r *http.Request)
+	FindById(w http.ResponseWriter, r *http.Request)
+	Retry(w http.ResponseWriter, r *http.Request)
+}
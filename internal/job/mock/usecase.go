@@ -0,0 +1,83 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	jobs "github.com/dinorain/kalobranded/pkg/jobs"
+	utils "github.com/dinorain/kalobranded/pkg/utils"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockJobUseCase is a mock of JobUseCase interface.
+type MockJobUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockJobUseCaseMockRecorder
+}
+
+// MockJobUseCaseMockRecorder is the mock recorder for MockJobUseCase.
+type MockJobUseCaseMockRecorder struct {
+	mock *MockJobUseCase
+}
+
+// NewMockJobUseCase creates a new mock instance.
+func NewMockJobUseCase(ctrl *gomock.Controller) *MockJobUseCase {
+	mock := &MockJobUseCase{ctrl: ctrl}
+	mock.recorder = &MockJobUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockJobUseCase) EXPECT() *MockJobUseCaseMockRecorder {
+	return m.recorder
+}
+
+// FindAll mocks base method.
+func (m *MockJobUseCase) FindAll(ctx context.Context, filter jobs.Filter, pagination *utils.Pagination) ([]jobs.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, filter, pagination)
+	ret0, _ := ret[0].([]jobs.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockJobUseCaseMockRecorder) FindAll(ctx, filter, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockJobUseCase)(nil).FindAll), ctx, filter, pagination)
+}
+
+// FindById mocks base method.
+func (m *MockJobUseCase) FindById(ctx context.Context, jobID uuid.UUID) (*jobs.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, jobID)
+	ret0, _ := ret[0].(*jobs.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockJobUseCaseMockRecorder) FindById(ctx, jobID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockJobUseCase)(nil).FindById), ctx, jobID)
+}
+
+// Retry mocks base method.
+func (m *MockJobUseCase) Retry(ctx context.Context, jobID uuid.UUID) (*jobs.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Retry", ctx, jobID)
+	ret0, _ := ret[0].(*jobs.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Retry indicates an expected call of Retry.
+func (mr *MockJobUseCaseMockRecorder) Retry(ctx, jobID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Retry", reflect.TypeOf((*MockJobUseCase)(nil).Retry), ctx, jobID)
+}
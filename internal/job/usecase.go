@@ -0,0 +1,20 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase.go -package mock
+package job
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/pkg/jobs"
+	"github.com/dinorain/kalobranded/pkg/utils"
+)
+
+// Job UseCase interface
+type JobUseCase interface {
+	FindAll(ctx context.Context, filter jobs.Filter, pagination *utils.Pagination) ([]jobs.Job, error)
+	FindById(ctx context.Context, jobID uuid.UUID) (*jobs.Job, error)
+	Retry(ctx context.Context, jobID uuid.UUID) (*jobs.Job, error)
+}
+
+var _ JobUseCase = (*jobs.Queue)(nil)
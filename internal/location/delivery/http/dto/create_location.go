@@ -0,0 +1,20 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/internal/models"
+)
+
+type LocationCreateRequestDto struct {
+	Name           string                `json:"name" validate:"required,lte=60"`
+	Address        string                `json:"address" validate:"required,lte=250"`
+	Latitude       *float64              `json:"latitude" validate:"omitempty,gte=-90,lte=90"`
+	Longitude      *float64              `json:"longitude" validate:"omitempty,gte=-180,lte=180"`
+	OperatingHours models.OperatingHours `json:"operating_hours"`
+	Active         *bool                 `json:"active"`
+}
+
+type LocationCreateResponseDto struct {
+	LocationID uuid.UUID `json:"location_id" validate:"required"`
+}
@@ -0,0 +1,8 @@
+package dto
+
+import "github.com/dinorain/kalobranded/pkg/utils"
+
+type LocationFindResponseDto struct {
+	Meta utils.PaginationMetaDto `json:"meta"`
+	Data []*LocationResponseDto  `json:"data"`
+}
@@ -0,0 +1,39 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/internal/models"
+)
+
+type LocationResponseDto struct {
+	LocationID     uuid.UUID             `json:"location_id"`
+	BrandID        uuid.UUID             `json:"brand_id"`
+	Name           string                `json:"name"`
+	Address        string                `json:"address"`
+	Latitude       *float64              `json:"latitude,omitempty"`
+	Longitude      *float64              `json:"longitude,omitempty"`
+	OperatingHours models.OperatingHours `json:"operating_hours"`
+	Active         bool                  `json:"active"`
+	Version        int                   `json:"version"`
+	CreatedAt      time.Time             `json:"created_at"`
+	UpdatedAt      time.Time             `json:"updated_at"`
+}
+
+func LocationResponseFromModel(location *models.BrandLocation) *LocationResponseDto {
+	return &LocationResponseDto{
+		LocationID:     location.LocationID,
+		BrandID:        location.BrandID,
+		Name:           location.Name,
+		Address:        location.Address,
+		Latitude:       location.Latitude,
+		Longitude:      location.Longitude,
+		OperatingHours: location.OperatingHours,
+		Active:         location.Active,
+		Version:        location.Version,
+		CreatedAt:      location.CreatedAt,
+		UpdatedAt:      location.UpdatedAt,
+	}
+}
@@ -0,0 +1,33 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/internal/models"
+)
+
+type LocationStockSetRequestDto struct {
+	Stock *uint64 `json:"stock" validate:"required"`
+}
+
+type LocationStockResponseDto struct {
+	LocationID uuid.UUID `json:"location_id"`
+	ProductID  uuid.UUID `json:"product_id"`
+	Stock      uint64    `json:"stock"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type LocationStockFindResponseDto struct {
+	Data []*LocationStockResponseDto `json:"data"`
+}
+
+func LocationStockResponseFromModel(stock *models.LocationStock) *LocationStockResponseDto {
+	return &LocationStockResponseDto{
+		LocationID: stock.LocationID,
+		ProductID:  stock.ProductID,
+		Stock:      stock.Stock,
+		UpdatedAt:  stock.UpdatedAt,
+	}
+}
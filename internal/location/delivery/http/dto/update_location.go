@@ -0,0 +1,12 @@
+package dto
+
+import "github.com/dinorain/kalobranded/internal/models"
+
+type LocationUpdateRequestDto struct {
+	Name           *string                `json:"name" validate:"omitempty,min=1,lte=60"`
+	Address        *string                `json:"address" validate:"omitempty,min=1,lte=250"`
+	Latitude       *float64               `json:"latitude" validate:"omitempty,gte=-90,lte=90"`
+	Longitude      *float64               `json:"longitude" validate:"omitempty,gte=-180,lte=180"`
+	OperatingHours *models.OperatingHours `json:"operating_hours"`
+	Active         *bool                  `json:"active"`
+}
@@ -0,0 +1,446 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/brand"
+	"github.com/dinorain/kalobranded/internal/location"
+	"github.com/dinorain/kalobranded/internal/location/delivery/http/dto"
+	"github.com/dinorain/kalobranded/internal/middlewares"
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/internal/product"
+	"github.com/dinorain/kalobranded/internal/server/router"
+	"github.com/dinorain/kalobranded/pkg/constants"
+	"github.com/dinorain/kalobranded/pkg/geo"
+	httpErrors "github.com/dinorain/kalobranded/pkg/http_errors"
+	"github.com/dinorain/kalobranded/pkg/logger"
+	"github.com/dinorain/kalobranded/pkg/utils"
+)
+
+type locationHandlersHTTP struct {
+	router     *router.Router
+	logger     logger.Logger
+	cfg        *config.Config
+	mw         middlewares.MiddlewareManager
+	v          *validator.Validate
+	locationUC location.LocationUseCase
+	brandUC    brand.BrandUseCase
+	productUC  product.ProductUseCase
+}
+
+var _ location.LocationHandlers = (*locationHandlersHTTP)(nil)
+
+func NewLocationHandlersHTTP(
+	router *router.Router,
+	logger logger.Logger,
+	cfg *config.Config,
+	mw middlewares.MiddlewareManager,
+	v *validator.Validate,
+	locationUC location.LocationUseCase,
+	brandUC brand.BrandUseCase,
+	productUC product.ProductUseCase,
+) *locationHandlersHTTP {
+	return &locationHandlersHTTP{router: router, logger: logger, cfg: cfg, mw: mw, v: v, locationUC: locationUC, brandUC: brandUC, productUC: productUC}
+}
+
+// Create
+// @Tags Locations
+// @Summary Create brand location
+// @Description Admin or seller of the brand add a warehouse or store the brand ships from. Once a brand has active locations its orders ship from the nearest one holding the ordered quantity instead of the brand pickup address
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "brand uuid"
+// @Param payload body dto.LocationCreateRequestDto true "Payload"
+// @Success 201 {object} dto.LocationCreateResponseDto
+// @Router /brands/{id}/locations [post]
+func (h *locationHandlersHTTP) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	brandUUID, err := uuid.Parse(router.Param(r, constants.ID))
+	if err != nil {
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	createDto := &dto.LocationCreateRequestDto{}
+	if err := json.NewDecoder(r.Body).Decode(createDto); err != nil {
+		h.logger.Errorf("decoder.Decode: %v", err)
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	if err := h.v.Struct(createDto); err != nil {
+		h.logger.Errorf("h.v.Struct: %v", err)
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	if err := h.mw.CheckBrand(w, r, brandUUID); err != nil {
+		return
+	}
+
+	if _, err := h.brandUC.CachedFindById(ctx, brandUUID); err != nil {
+		h.logger.Errorf("brandUC.CachedFindById: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	location := &models.BrandLocation{
+		BrandID:        brandUUID,
+		Name:           createDto.Name,
+		Address:        createDto.Address,
+		Latitude:       createDto.Latitude,
+		Longitude:      createDto.Longitude,
+		OperatingHours: createDto.OperatingHours,
+		Active:         true,
+	}
+	if createDto.Active != nil {
+		location.Active = *createDto.Active
+	}
+	if err := location.PrepareCreate(); err != nil {
+		h.logger.Errorf("location.PrepareCreate: %v", err)
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	createdLocation, err := h.locationUC.Create(ctx, location)
+	if err != nil {
+		h.logger.Errorf("locationUC.Create: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	res, _ := json.Marshal(dto.LocationCreateResponseDto{LocationID: createdLocation.LocationID})
+	w.WriteHeader(http.StatusCreated)
+	w.Write(res)
+	return
+}
+
+// FindAllByBrandId
+// @Tags Locations
+// @Summary Find brand locations
+// @Description Find the locations of a brand, oldest first
+// @Accept json
+// @Produce json
+// @Param id path string true "brand uuid"
+// @Param size query string false "pagination size"
+// @Param page query string false "pagination page"
+// @Success 200 {object} dto.LocationFindResponseDto
+// @Router /brands/{id}/locations [get]
+func (h *locationHandlersHTTP) FindAllByBrandId(w http.ResponseWriter, r *http.Request) {
+	queryParam := r.URL.Query()
+	pq := utils.NewPaginationFromQueryParams(queryParam.Get(constants.Size), queryParam.Get(constants.Page))
+
+	brandUUID, err := uuid.Parse(router.Param(r, constants.ID))
+	if err != nil {
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	locations, err := h.locationUC.FindAllByBrandId(r.Context(), brandUUID, pq)
+	if err != nil {
+		h.logger.Errorf("locationUC.FindAllByBrandId: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	resDto := dto.LocationFindResponseDto{
+		Data: make([]*dto.LocationResponseDto, 0, len(locations)),
+		Meta: utils.PaginationMetaDto{
+			Limit:  pq.GetLimit(),
+			Offset: pq.GetOffset(),
+			Page:   pq.GetPage(),
+		},
+	}
+	for i := range locations {
+		resDto.Data = append(resDto.Data, dto.LocationResponseFromModel(&locations[i]))
+	}
+
+	res, _ := json.Marshal(resDto)
+	w.WriteHeader(http.StatusOK)
+	w.Write(res)
+	return
+}
+
+// FindById
+// @Tags Locations
+// @Summary Find location by id
+// @Description Find brand location by id
+// @Accept json
+// @Produce json
+// @Param id path string true "location uuid"
+// @Success 200 {object} dto.LocationResponseDto
+// @Header 200 {string} ETag "resource version"
+// @Router /locations/{id} [get]
+func (h *locationHandlersHTTP) FindById(w http.ResponseWriter, r *http.Request) {
+	locationUUID, err := uuid.Parse(router.Param(r, constants.ID))
+	if err != nil {
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	location, err := h.locationUC.FindById(r.Context(), locationUUID)
+	if err != nil {
+		h.logger.Errorf("locationUC.FindById: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	w.Header().Set(constants.ETag, utils.ETag(location.Version))
+	res, _ := json.Marshal(dto.LocationResponseFromModel(location))
+	w.WriteHeader(http.StatusOK)
+	w.Write(res)
+	return
+}
+
+// UpdateById
+// @Tags Locations
+// @Summary Update location
+// @Description Admin or seller of the location brand update location, only provided fields are changed. Coordinates are cleared when the address changes without new ones. Inactive locations don't fulfil orders
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "location uuid"
+// @Param If-Match header string false "ETag of the version being changed"
+// @Param payload body dto.LocationUpdateRequestDto true "Payload"
+// @Success 200 {object} dto.LocationResponseDto
+// @Header 200 {string} ETag "resource version"
+// @Router /locations/{id} [patch]
+func (h *locationHandlersHTTP) UpdateById(w http.ResponseWriter, r *http.Request) {
+	updateDto := &dto.LocationUpdateRequestDto{}
+	if err := json.NewDecoder(r.Body).Decode(updateDto); err != nil {
+		h.logger.Errorf("decoder.Decode: %v", err)
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	if err := h.v.Struct(updateDto); err != nil {
+		h.logger.Errorf("h.v.Struct: %v", err)
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	location, err := h.findLocation(w, r)
+	if err != nil {
+		return
+	}
+
+	if !utils.IfMatch(r.Header.Get(constants.IfMatch), location.Version) {
+		_ = httpErrors.ErrorCtxResponse(w, httpErrors.PreconditionFailed, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	if err := updateReqToLocationModel(location, updateDto); err != nil {
+		h.logger.Errorf("updateReqToLocationModel: %v", err)
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	updatedLocation, err := h.locationUC.UpdateById(r.Context(), location)
+	if err != nil {
+		h.logger.Errorf("locationUC.UpdateById: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	w.Header().Set(constants.ETag, utils.ETag(updatedLocation.Version))
+	res, _ := json.Marshal(dto.LocationResponseFromModel(updatedLocation))
+	w.WriteHeader(http.StatusOK)
+	w.Write(res)
+	return
+}
+
+// DeleteById
+// @Tags Locations
+// @Summary Delete location
+// @Description Admin or seller of the location brand delete location, orders keep the location they shipped from
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "location uuid"
+// @Param If-Match header string false "ETag of the version being changed"
+// @Success 204 {object} nil
+// @Router /locations/{id} [delete]
+func (h *locationHandlersHTTP) DeleteById(w http.ResponseWriter, r *http.Request) {
+	location, err := h.findLocation(w, r)
+	if err != nil {
+		return
+	}
+
+	if ifMatch := r.Header.Get(constants.IfMatch); ifMatch != "" && !utils.IfMatch(ifMatch, location.Version) {
+		_ = httpErrors.ErrorCtxResponse(w, httpErrors.PreconditionFailed, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	if err := h.locationUC.DeleteById(r.Context(), location.LocationID); err != nil {
+		h.logger.Errorf("locationUC.DeleteById: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return
+}
+
+// FindAllStocks
+// @Tags Locations
+// @Summary Find location stocks
+// @Description Admin or seller of the location brand find the product stocks of the location, latest changed first
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "location uuid"
+// @Success 200 {object} dto.LocationStockFindResponseDto
+// @Router /locations/{id}/stocks [get]
+func (h *locationHandlersHTTP) FindAllStocks(w http.ResponseWriter, r *http.Request) {
+	location, err := h.findLocation(w, r)
+	if err != nil {
+		return
+	}
+
+	stocks, err := h.locationUC.FindAllStocksByLocationId(r.Context(), location.LocationID)
+	if err != nil {
+		h.logger.Errorf("locationUC.FindAllStocksByLocationId: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	resDto := dto.LocationStockFindResponseDto{Data: make([]*dto.LocationStockResponseDto, 0, len(stocks))}
+	for i := range stocks {
+		resDto.Data = append(resDto.Data, dto.LocationStockResponseFromModel(&stocks[i]))
+	}
+
+	res, _ := json.Marshal(resDto)
+	w.WriteHeader(http.StatusOK)
+	w.Write(res)
+	return
+}
+
+// SetStock
+// @Tags Locations
+// @Summary Set location stock
+// @Description Admin or seller of the location brand set the stock of a product of the brand at the location. Orders take their quantity off the stock of the location they ship from
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "location uuid"
+// @Param product_id path string true "product uuid"
+// @Param payload body dto.LocationStockSetRequestDto true "Payload"
+// @Success 200 {object} dto.LocationStockResponseDto
+// @Router /locations/{id}/stocks/{product_id} [put]
+func (h *locationHandlersHTTP) SetStock(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	productUUID, err := uuid.Parse(router.Param(r, constants.ProductID))
+	if err != nil {
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	setDto := &dto.LocationStockSetRequestDto{}
+	if err := json.NewDecoder(r.Body).Decode(setDto); err != nil {
+		h.logger.Errorf("decoder.Decode: %v", err)
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	if err := h.v.Struct(setDto); err != nil {
+		h.logger.Errorf("h.v.Struct: %v", err)
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	location, err := h.findLocation(w, r)
+	if err != nil {
+		return
+	}
+
+	product, err := h.productUC.CachedFindById(ctx, productUUID)
+	if err != nil {
+		h.logger.Errorf("productUC.CachedFindById: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	if product.BrandID != location.BrandID {
+		err := fmt.Sprintf("product %s is not of brand %s", product.ProductID, location.BrandID)
+		_ = httpErrors.NewBadRequestError(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	stock, err := h.locationUC.SetStock(ctx, &models.LocationStock{LocationID: location.LocationID, ProductID: product.ProductID, Stock: *setDto.Stock})
+	if err != nil {
+		h.logger.Errorf("locationUC.SetStock: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	res, _ := json.Marshal(dto.LocationStockResponseFromModel(stock))
+	w.WriteHeader(http.StatusOK)
+	w.Write(res)
+	return
+}
+
+func updateReqToLocationModel(location *models.BrandLocation, r *dto.LocationUpdateRequestDto) error {
+	address := location.Address
+
+	if r.Name != nil {
+		location.Name = *r.Name
+	}
+	if r.Address != nil {
+		location.Address = *r.Address
+	}
+	if r.OperatingHours != nil {
+		location.OperatingHours = *r.OperatingHours
+	}
+	if r.Active != nil {
+		location.Active = *r.Active
+	}
+
+	if err := location.PrepareCreate(); err != nil {
+		return err
+	}
+
+	if location.Address != address {
+		// coordinates of the previous address would misplace the new one, it gets geocoded instead
+		location.Latitude, location.Longitude = nil, nil
+	}
+	if r.Latitude != nil || r.Longitude != nil {
+		if err := geo.CheckCoordinates(r.Latitude, r.Longitude); err != nil {
+			return err
+		}
+		location.Latitude, location.Longitude = r.Latitude, r.Longitude
+	}
+
+	return nil
+}
+
+// findLocation find location by id, sellers can only act on locations of their brand, error response is already
+// written when err is not nil
+func (h *locationHandlersHTTP) findLocation(w http.ResponseWriter, r *http.Request) (*models.BrandLocation, error) {
+	locationUUID, err := uuid.Parse(router.Param(r, constants.ID))
+	if err != nil {
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return nil, err
+	}
+
+	location, err := h.locationUC.FindById(r.Context(), locationUUID)
+	if err != nil {
+		h.logger.Errorf("locationUC.FindById: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return nil, err
+	}
+
+	if err := h.mw.CheckBrand(w, r, location.BrandID); err != nil {
+		return nil, err
+	}
+
+	return location, nil
+}
@@ -0,0 +1,181 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/golang-jwt/jwt"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/kalobranded/config"
+	mockBrandUC "github.com/dinorain/kalobranded/internal/brand/mock"
+	"github.com/dinorain/kalobranded/internal/location/delivery/http/dto"
+	"github.com/dinorain/kalobranded/internal/location/mock"
+	"github.com/dinorain/kalobranded/internal/middlewares"
+	"github.com/dinorain/kalobranded/internal/models"
+	mockProductUC "github.com/dinorain/kalobranded/internal/product/mock"
+	"github.com/dinorain/kalobranded/internal/server/router"
+	"github.com/dinorain/kalobranded/pkg/constants"
+	"github.com/dinorain/kalobranded/pkg/logger"
+)
+
+func signedToken(t *testing.T, cfg *config.Config, role string, brandUUID *uuid.UUID) string {
+	token := jwt.New(jwt.SigningMethodHS256)
+	claims := token.Claims.(jwt.MapClaims)
+	claims["session_id"] = uuid.New().String()
+	claims["user_id"] = uuid.New().String()
+	claims["role"] = role
+	if brandUUID != nil {
+		claims["brand_id"] = brandUUID.String()
+	}
+	claims["exp"] = time.Now().Add(time.Minute * 15).Unix()
+	validToken, err := token.SignedString([]byte(cfg.Server.JwtSecretKey))
+	require.NoError(t, err)
+	return validToken
+}
+
+func TestLocationsHandler_Create(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	locationUC := mock.NewMockLocationUseCase(ctrl)
+	brandUC := mockBrandUC.NewMockBrandUseCase(ctrl)
+	productUC := mockProductUC.NewMockProductUseCase(ctrl)
+
+	cfg := &config.Config{Session: config.Session{Expire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	appLogger := logger.NewAppLogger(cfg)
+	appLogger.InitLogger()
+	mw := middlewares.NewMiddlewareManager(appLogger, cfg)
+
+	v := validator.New()
+
+	rt := router.NewRouter(false)
+	handlers := NewLocationHandlersHTTP(rt, appLogger, cfg, mw, v, locationUC, brandUC, productUC)
+
+	brandUUID := uuid.New()
+	newRequest := func(token, body string) *http.Request {
+		req := router.WithParams(httptest.NewRequest(http.MethodPost, "/brands/"+brandUUID.String()+"/locations", strings.NewReader(body)), map[string]string{constants.ID: brandUUID.String()})
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", token))
+		return req
+	}
+
+	t.Run("Seller", func(t *testing.T) {
+		body := `{"name": " Jakarta ", "address": "Jl. Sudirman 1, Jakarta, ID", "operating_hours": [{"day": "mon", "opens": "09:00", "closes": "17:00"}]}`
+		w := httptest.NewRecorder()
+
+		locationUUID := uuid.New()
+		brandUC.EXPECT().CachedFindById(gomock.Any(), brandUUID).Return(&models.Brand{BrandID: brandUUID}, nil)
+		locationUC.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, l *models.BrandLocation) (*models.BrandLocation, error) {
+			require.Equal(t, brandUUID, l.BrandID)
+			require.Equal(t, "Jakarta", l.Name)
+			require.True(t, l.Active)
+			require.Len(t, l.OperatingHours, 1)
+			l.LocationID = locationUUID
+			return l, nil
+		})
+
+		http.HandlerFunc(handlers.Create).ServeHTTP(w, newRequest(signedToken(t, cfg, models.UserRoleSeller, &brandUUID), body))
+
+		require.Equal(t, http.StatusCreated, w.Code)
+		resDto := &dto.LocationCreateResponseDto{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), resDto))
+		require.Equal(t, locationUUID, resDto.LocationID)
+	})
+
+	t.Run("OtherBrandSeller", func(t *testing.T) {
+		otherBrandUUID := uuid.New()
+		w := httptest.NewRecorder()
+
+		http.HandlerFunc(handlers.Create).ServeHTTP(w, newRequest(signedToken(t, cfg, models.UserRoleSeller, &otherBrandUUID), `{"name": "Jakarta", "address": "Jakarta, ID"}`))
+
+		require.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("InvalidOperatingHours", func(t *testing.T) {
+		body := `{"name": "Jakarta", "address": "Jakarta, ID", "operating_hours": [{"day": "mon", "opens": "17:00", "closes": "09:00"}]}`
+		w := httptest.NewRecorder()
+
+		brandUC.EXPECT().CachedFindById(gomock.Any(), brandUUID).Return(&models.Brand{BrandID: brandUUID}, nil)
+
+		http.HandlerFunc(handlers.Create).ServeHTTP(w, newRequest(signedToken(t, cfg, models.UserRoleAdmin, nil), body))
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestLocationsHandler_SetStock(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	locationUC := mock.NewMockLocationUseCase(ctrl)
+	brandUC := mockBrandUC.NewMockBrandUseCase(ctrl)
+	productUC := mockProductUC.NewMockProductUseCase(ctrl)
+
+	cfg := &config.Config{Session: config.Session{Expire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	appLogger := logger.NewAppLogger(cfg)
+	appLogger.InitLogger()
+	mw := middlewares.NewMiddlewareManager(appLogger, cfg)
+
+	v := validator.New()
+
+	rt := router.NewRouter(false)
+	handlers := NewLocationHandlersHTTP(rt, appLogger, cfg, mw, v, locationUC, brandUC, productUC)
+
+	brandUUID := uuid.New()
+	location := &models.BrandLocation{LocationID: uuid.New(), BrandID: brandUUID, Name: "Jakarta", Address: "Jakarta, ID", Active: true, Version: 1}
+	newRequest := func(productUUID uuid.UUID, body string) *http.Request {
+		req := router.WithParams(
+			httptest.NewRequest(http.MethodPut, "/locations/"+location.LocationID.String()+"/stocks/"+productUUID.String(), strings.NewReader(body)),
+			map[string]string{constants.ID: location.LocationID.String(), constants.ProductID: productUUID.String()},
+		)
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", signedToken(t, cfg, models.UserRoleSeller, &brandUUID)))
+		return req
+	}
+
+	t.Run("Set", func(t *testing.T) {
+		productUUID := uuid.New()
+		w := httptest.NewRecorder()
+
+		locationUC.EXPECT().FindById(gomock.Any(), location.LocationID).Return(location, nil)
+		productUC.EXPECT().CachedFindById(gomock.Any(), productUUID).Return(&models.Product{ProductID: productUUID, BrandID: brandUUID}, nil)
+		locationUC.EXPECT().SetStock(gomock.Any(), &models.LocationStock{LocationID: location.LocationID, ProductID: productUUID, Stock: 0}).DoAndReturn(func(_ interface{}, s *models.LocationStock) (*models.LocationStock, error) {
+			return s, nil
+		})
+
+		http.HandlerFunc(handlers.SetStock).ServeHTTP(w, newRequest(productUUID, `{"stock": 0}`))
+
+		require.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("OtherBrandProduct", func(t *testing.T) {
+		productUUID := uuid.New()
+		w := httptest.NewRecorder()
+
+		locationUC.EXPECT().FindById(gomock.Any(), location.LocationID).Return(location, nil)
+		productUC.EXPECT().CachedFindById(gomock.Any(), productUUID).Return(&models.Product{ProductID: productUUID, BrandID: uuid.New()}, nil)
+
+		http.HandlerFunc(handlers.SetStock).ServeHTTP(w, newRequest(productUUID, `{"stock": 5}`))
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("MissingStock", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		http.HandlerFunc(handlers.SetStock).ServeHTTP(w, newRequest(uuid.New(), `{}`))
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
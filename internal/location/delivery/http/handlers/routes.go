@@ -0,0 +1,13 @@
+package handlers
+
+func (h *locationHandlersHTTP) LocationMapRoutes() {
+	locations := h.router.Group("/locations")
+	locations.Get("/{id}", h.FindById)
+	locations.Patch("/{id}", h.UpdateById, h.mw.IsAdminOrSeller)
+	locations.Delete("/{id}", h.DeleteById, h.mw.IsAdminOrSeller)
+	locations.Get("/{id}/stocks", h.FindAllStocks, h.mw.IsAdminOrSeller)
+	locations.Put("/{id}/stocks/{product_id}", h.SetStock, h.mw.IsAdminOrSeller)
+
+	h.router.Get("/brands/{id}/locations", h.FindAllByBrandId)
+	h.router.Post("/brands/{id}/locations", h.Create, h.mw.IsAdminOrSeller)
+}
@@ -0,0 +1,16 @@
+package location
+
+import (
+	"net/http"
+)
+
+// Location HTTP Handlers interface
+type LocationHandlers interface {
+	Create(w http.ResponseWriter, r *http.Request)
+	FindAllByBrandId(w http.ResponseWriter, r *http.Request)
+	FindById(w http.ResponseWriter, r *http.Request)
+	UpdateById(w http.ResponseWriter, r *http.Request)
+	DeleteById(w http.ResponseWriter, r *http.Request)
+	FindAllStocks(w http.ResponseWriter, r *http.Request)
+	SetStock(w http.ResponseWriter, r *http.Request)
+}
@@ -0,0 +1,157 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pg_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/dinorain/kalobranded/internal/models"
+	utils "github.com/dinorain/kalobranded/pkg/utils"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockLocationPGRepository is a mock of LocationPGRepository interface.
+type MockLocationPGRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLocationPGRepositoryMockRecorder
+}
+
+// MockLocationPGRepositoryMockRecorder is the mock recorder for MockLocationPGRepository.
+type MockLocationPGRepositoryMockRecorder struct {
+	mock *MockLocationPGRepository
+}
+
+// NewMockLocationPGRepository creates a new mock instance.
+func NewMockLocationPGRepository(ctrl *gomock.Controller) *MockLocationPGRepository {
+	mock := &MockLocationPGRepository{ctrl: ctrl}
+	mock.recorder = &MockLocationPGRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLocationPGRepository) EXPECT() *MockLocationPGRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockLocationPGRepository) Create(ctx context.Context, location *models.BrandLocation) (*models.BrandLocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, location)
+	ret0, _ := ret[0].(*models.BrandLocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockLocationPGRepositoryMockRecorder) Create(ctx, location interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockLocationPGRepository)(nil).Create), ctx, location)
+}
+
+// DeleteById mocks base method.
+func (m *MockLocationPGRepository) DeleteById(ctx context.Context, locationID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteById", ctx, locationID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteById indicates an expected call of DeleteById.
+func (mr *MockLocationPGRepositoryMockRecorder) DeleteById(ctx, locationID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockLocationPGRepository)(nil).DeleteById), ctx, locationID)
+}
+
+// FindAllActiveByProductId mocks base method.
+func (m *MockLocationPGRepository) FindAllActiveByProductId(ctx context.Context, brandID, productID uuid.UUID) ([]models.StockedLocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllActiveByProductId", ctx, brandID, productID)
+	ret0, _ := ret[0].([]models.StockedLocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllActiveByProductId indicates an expected call of FindAllActiveByProductId.
+func (mr *MockLocationPGRepositoryMockRecorder) FindAllActiveByProductId(ctx, brandID, productID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllActiveByProductId", reflect.TypeOf((*MockLocationPGRepository)(nil).FindAllActiveByProductId), ctx, brandID, productID)
+}
+
+// FindAllByBrandId mocks base method.
+func (m *MockLocationPGRepository) FindAllByBrandId(ctx context.Context, brandID uuid.UUID, pagination *utils.Pagination) ([]models.BrandLocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByBrandId", ctx, brandID, pagination)
+	ret0, _ := ret[0].([]models.BrandLocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByBrandId indicates an expected call of FindAllByBrandId.
+func (mr *MockLocationPGRepositoryMockRecorder) FindAllByBrandId(ctx, brandID, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByBrandId", reflect.TypeOf((*MockLocationPGRepository)(nil).FindAllByBrandId), ctx, brandID, pagination)
+}
+
+// FindAllStocksByLocationId mocks base method.
+func (m *MockLocationPGRepository) FindAllStocksByLocationId(ctx context.Context, locationID uuid.UUID) ([]models.LocationStock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllStocksByLocationId", ctx, locationID)
+	ret0, _ := ret[0].([]models.LocationStock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllStocksByLocationId indicates an expected call of FindAllStocksByLocationId.
+func (mr *MockLocationPGRepositoryMockRecorder) FindAllStocksByLocationId(ctx, locationID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllStocksByLocationId", reflect.TypeOf((*MockLocationPGRepository)(nil).FindAllStocksByLocationId), ctx, locationID)
+}
+
+// FindById mocks base method.
+func (m *MockLocationPGRepository) FindById(ctx context.Context, locationID uuid.UUID) (*models.BrandLocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, locationID)
+	ret0, _ := ret[0].(*models.BrandLocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockLocationPGRepositoryMockRecorder) FindById(ctx, locationID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockLocationPGRepository)(nil).FindById), ctx, locationID)
+}
+
+// SetStock mocks base method.
+func (m *MockLocationPGRepository) SetStock(ctx context.Context, stock *models.LocationStock) (*models.LocationStock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetStock", ctx, stock)
+	ret0, _ := ret[0].(*models.LocationStock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetStock indicates an expected call of SetStock.
+func (mr *MockLocationPGRepositoryMockRecorder) SetStock(ctx, stock interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStock", reflect.TypeOf((*MockLocationPGRepository)(nil).SetStock), ctx, stock)
+}
+
+// UpdateById mocks base method.
+func (m *MockLocationPGRepository) UpdateById(ctx context.Context, location *models.BrandLocation) (*models.BrandLocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateById", ctx, location)
+	ret0, _ := ret[0].(*models.BrandLocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateById indicates an expected call of UpdateById.
+func (mr *MockLocationPGRepositoryMockRecorder) UpdateById(ctx, location interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateById", reflect.TypeOf((*MockLocationPGRepository)(nil).UpdateById), ctx, location)
+}
@@ -0,0 +1,158 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/dinorain/kalobranded/internal/models"
+	geo "github.com/dinorain/kalobranded/pkg/geo"
+	utils "github.com/dinorain/kalobranded/pkg/utils"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockLocationUseCase is a mock of LocationUseCase interface.
+type MockLocationUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockLocationUseCaseMockRecorder
+}
+
+// MockLocationUseCaseMockRecorder is the mock recorder for MockLocationUseCase.
+type MockLocationUseCaseMockRecorder struct {
+	mock *MockLocationUseCase
+}
+
+// NewMockLocationUseCase creates a new mock instance.
+func NewMockLocationUseCase(ctrl *gomock.Controller) *MockLocationUseCase {
+	mock := &MockLocationUseCase{ctrl: ctrl}
+	mock.recorder = &MockLocationUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLocationUseCase) EXPECT() *MockLocationUseCaseMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockLocationUseCase) Create(ctx context.Context, location *models.BrandLocation) (*models.BrandLocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, location)
+	ret0, _ := ret[0].(*models.BrandLocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockLocationUseCaseMockRecorder) Create(ctx, location interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockLocationUseCase)(nil).Create), ctx, location)
+}
+
+// DeleteById mocks base method.
+func (m *MockLocationUseCase) DeleteById(ctx context.Context, locationID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteById", ctx, locationID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteById indicates an expected call of DeleteById.
+func (mr *MockLocationUseCaseMockRecorder) DeleteById(ctx, locationID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockLocationUseCase)(nil).DeleteById), ctx, locationID)
+}
+
+// FindAllByBrandId mocks base method.
+func (m *MockLocationUseCase) FindAllByBrandId(ctx context.Context, brandID uuid.UUID, pagination *utils.Pagination) ([]models.BrandLocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByBrandId", ctx, brandID, pagination)
+	ret0, _ := ret[0].([]models.BrandLocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByBrandId indicates an expected call of FindAllByBrandId.
+func (mr *MockLocationUseCaseMockRecorder) FindAllByBrandId(ctx, brandID, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByBrandId", reflect.TypeOf((*MockLocationUseCase)(nil).FindAllByBrandId), ctx, brandID, pagination)
+}
+
+// FindAllStocksByLocationId mocks base method.
+func (m *MockLocationUseCase) FindAllStocksByLocationId(ctx context.Context, locationID uuid.UUID) ([]models.LocationStock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllStocksByLocationId", ctx, locationID)
+	ret0, _ := ret[0].([]models.LocationStock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllStocksByLocationId indicates an expected call of FindAllStocksByLocationId.
+func (mr *MockLocationUseCaseMockRecorder) FindAllStocksByLocationId(ctx, locationID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllStocksByLocationId", reflect.TypeOf((*MockLocationUseCase)(nil).FindAllStocksByLocationId), ctx, locationID)
+}
+
+// FindById mocks base method.
+func (m *MockLocationUseCase) FindById(ctx context.Context, locationID uuid.UUID) (*models.BrandLocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, locationID)
+	ret0, _ := ret[0].(*models.BrandLocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockLocationUseCaseMockRecorder) FindById(ctx, locationID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockLocationUseCase)(nil).FindById), ctx, locationID)
+}
+
+// FindNearestInStock mocks base method.
+func (m *MockLocationUseCase) FindNearestInStock(ctx context.Context, brandID, productID uuid.UUID, quantity uint64, dropoff *geo.Point, destination string) (*models.BrandLocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindNearestInStock", ctx, brandID, productID, quantity, dropoff, destination)
+	ret0, _ := ret[0].(*models.BrandLocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindNearestInStock indicates an expected call of FindNearestInStock.
+func (mr *MockLocationUseCaseMockRecorder) FindNearestInStock(ctx, brandID, productID, quantity, dropoff, destination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindNearestInStock", reflect.TypeOf((*MockLocationUseCase)(nil).FindNearestInStock), ctx, brandID, productID, quantity, dropoff, destination)
+}
+
+// SetStock mocks base method.
+func (m *MockLocationUseCase) SetStock(ctx context.Context, stock *models.LocationStock) (*models.LocationStock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetStock", ctx, stock)
+	ret0, _ := ret[0].(*models.LocationStock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetStock indicates an expected call of SetStock.
+func (mr *MockLocationUseCaseMockRecorder) SetStock(ctx, stock interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStock", reflect.TypeOf((*MockLocationUseCase)(nil).SetStock), ctx, stock)
+}
+
+// UpdateById mocks base method.
+func (m *MockLocationUseCase) UpdateById(ctx context.Context, location *models.BrandLocation) (*models.BrandLocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateById", ctx, location)
+	ret0, _ := ret[0].(*models.BrandLocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateById indicates an expected call of UpdateById.
+func (mr *MockLocationUseCaseMockRecorder) UpdateById(ctx, location interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateById", reflect.TypeOf((*MockLocationUseCase)(nil).UpdateById), ctx, location)
+}
@@ -0,0 +1,23 @@
+//go:generate mockgen -source pg_repository.go -destination mock/pg_repository.go -package mock
+package location
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/pkg/utils"
+)
+
+// Location pg repository
+type LocationPGRepository interface {
+	Create(ctx context.Context, location *models.BrandLocation) (*models.BrandLocation, error)
+	FindAllByBrandId(ctx context.Context, brandID uuid.UUID, pagination *utils.Pagination) ([]models.BrandLocation, error)
+	FindAllActiveByProductId(ctx context.Context, brandID uuid.UUID, productID uuid.UUID) ([]models.StockedLocation, error)
+	FindById(ctx context.Context, locationID uuid.UUID) (*models.BrandLocation, error)
+	UpdateById(ctx context.Context, location *models.BrandLocation) (*models.BrandLocation, error)
+	DeleteById(ctx context.Context, locationID uuid.UUID) error
+	FindAllStocksByLocationId(ctx context.Context, locationID uuid.UUID) ([]models.LocationStock, error)
+	SetStock(ctx context.Context, stock *models.LocationStock) (*models.LocationStock, error)
+}
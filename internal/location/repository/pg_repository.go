@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"github.com/dinorain/kalobranded/internal/location"
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/pkg/utils"
+)
+
+// Location repository
+type LocationRepository struct {
+	db *sqlx.DB
+}
+
+var _ location.LocationPGRepository = (*LocationRepository)(nil)
+
+// Location repository constructor
+func NewLocationPGRepository(db *sqlx.DB) *LocationRepository {
+	return &LocationRepository{db: db}
+}
+
+// Create new brand location
+func (r *LocationRepository) Create(ctx context.Context, location *models.BrandLocation) (*models.BrandLocation, error) {
+	createdLocation := &models.BrandLocation{}
+	if err := r.db.QueryRowxContext(
+		ctx,
+		createLocationQuery,
+		location.BrandID,
+		location.Name,
+		location.Address,
+		location.Latitude,
+		location.Longitude,
+		location.OperatingHours,
+		location.Active,
+	).StructScan(createdLocation); err != nil {
+		return nil, errors.Wrap(err, "LocationRepository.Create.QueryRowxContext")
+	}
+
+	return createdLocation, nil
+}
+
+// FindAllByBrandId Find locations of brand uuid, oldest first
+func (r *LocationRepository) FindAllByBrandId(ctx context.Context, brandID uuid.UUID, pagination *utils.Pagination) ([]models.BrandLocation, error) {
+	var locations []models.BrandLocation
+	if err := r.db.SelectContext(ctx, &locations, findAllByBrandIdQuery, brandID, pagination.GetLimit(), pagination.GetOffset()); err != nil {
+		return nil, errors.Wrap(err, "LocationRepository.FindAllByBrandId.SelectContext")
+	}
+
+	return locations, nil
+}
+
+// FindAllActiveByProductId Find active locations of brand uuid with their stock of product uuid, none when unstocked
+func (r *LocationRepository) FindAllActiveByProductId(ctx context.Context, brandID uuid.UUID, productID uuid.UUID) ([]models.StockedLocation, error) {
+	var locations []models.StockedLocation
+	if err := r.db.SelectContext(ctx, &locations, findAllActiveByProductIdQuery, brandID, productID); err != nil {
+		return nil, errors.Wrap(err, "LocationRepository.FindAllActiveByProductId.SelectContext")
+	}
+
+	return locations, nil
+}
+
+// FindById Find location by uuid
+func (r *LocationRepository) FindById(ctx context.Context, locationID uuid.UUID) (*models.BrandLocation, error) {
+	location := &models.BrandLocation{}
+	if err := r.db.GetContext(ctx, location, findByIdQuery, locationID); err != nil {
+		return nil, errors.Wrap(err, "LocationRepository.FindById.GetContext")
+	}
+
+	return location, nil
+}
+
+// UpdateById update existing location when its version is unchanged, brand is kept
+func (r *LocationRepository) UpdateById(ctx context.Context, location *models.BrandLocation) (*models.BrandLocation, error) {
+	updatedLocation := &models.BrandLocation{}
+	if err := r.db.QueryRowxContext(
+		ctx,
+		updateByIdQuery,
+		location.LocationID,
+		location.Name,
+		location.Address,
+		location.Latitude,
+		location.Longitude,
+		location.OperatingHours,
+		location.Active,
+		location.Version,
+	).StructScan(updatedLocation); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, models.ErrVersionConflict
+		}
+		return nil, errors.Wrap(err, "LocationRepository.UpdateById.QueryRowxContext")
+	}
+
+	return updatedLocation, nil
+}
+
+// DeleteById soft delete location by uuid, orders keep the location they shipped from
+func (r *LocationRepository) DeleteById(ctx context.Context, locationID uuid.UUID) error {
+	if res, err := r.db.ExecContext(ctx, deleteByIdQuery, locationID); err != nil {
+		return errors.Wrap(err, "LocationRepository.DeleteById.ExecContext")
+	} else {
+		cnt, err := res.RowsAffected()
+		if err != nil {
+			return errors.Wrap(err, "LocationRepository.DeleteById.RowsAffected")
+		} else if cnt == 0 {
+			return sql.ErrNoRows
+		}
+	}
+
+	return nil
+}
+
+// FindAllStocksByLocationId Find product stocks of location uuid, latest changed first
+func (r *LocationRepository) FindAllStocksByLocationId(ctx context.Context, locationID uuid.UUID) ([]models.LocationStock, error) {
+	var stocks []models.LocationStock
+	if err := r.db.SelectContext(ctx, &stocks, findAllStocksByLocationIdQuery, locationID); err != nil {
+		return nil, errors.Wrap(err, "LocationRepository.FindAllStocksByLocationId.SelectContext")
+	}
+
+	return stocks, nil
+}
+
+// SetStock set the stock of a product at a location, creating it when the location had none
+func (r *LocationRepository) SetStock(ctx context.Context, stock *models.LocationStock) (*models.LocationStock, error) {
+	setStock := &models.LocationStock{}
+	if err := r.db.QueryRowxContext(ctx, setStockQuery, stock.LocationID, stock.ProductID, stock.Stock).StructScan(setStock); err != nil {
+		return nil, errors.Wrap(err, "LocationRepository.SetStock.QueryRowxContext")
+	}
+
+	return setStock, nil
+}
@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/kalobranded/internal/models"
+)
+
+func TestLocationRepository_Create(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	locationPGRepository := NewLocationPGRepository(sqlxDB)
+
+	lat, lng := -6.2088, 106.8456
+	mockLocation := &models.BrandLocation{
+		BrandID:        uuid.New(),
+		Name:           "Jakarta",
+		Address:        "Jl. Sudirman 1, Jakarta, ID",
+		Latitude:       &lat,
+		Longitude:      &lng,
+		OperatingHours: models.OperatingHours{{Day: "mon", Opens: "09:00", Closes: "17:00"}},
+		Active:         true,
+	}
+	hours, _ := mockLocation.OperatingHours.Value()
+
+	locationUUID := uuid.New()
+	rows := sqlmock.NewRows([]string{"location_id", "brand_id", "name", "address", "latitude", "longitude", "operating_hours", "active", "version"}).AddRow(
+		locationUUID,
+		mockLocation.BrandID,
+		mockLocation.Name,
+		mockLocation.Address,
+		lat,
+		lng,
+		hours,
+		true,
+		1,
+	)
+
+	mock.ExpectQuery(createLocationQuery).WithArgs(
+		mockLocation.BrandID,
+		mockLocation.Name,
+		mockLocation.Address,
+		mockLocation.Latitude,
+		mockLocation.Longitude,
+		hours,
+		mockLocation.Active,
+	).WillReturnRows(rows)
+
+	createdLocation, err := locationPGRepository.Create(context.Background(), mockLocation)
+	require.NoError(t, err)
+	require.Equal(t, locationUUID, createdLocation.LocationID)
+	require.Equal(t, mockLocation.OperatingHours, createdLocation.OperatingHours)
+	require.NotNil(t, createdLocation.Point())
+}
+
+func TestLocationRepository_FindAllActiveByProductId(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	locationPGRepository := NewLocationPGRepository(sqlxDB)
+
+	brandUUID := uuid.New()
+	productUUID := uuid.New()
+	rows := sqlmock.NewRows([]string{"location_id", "brand_id", "name", "address", "operating_hours", "active", "stock"}).
+		AddRow(uuid.New(), brandUUID, "Jakarta", "Jakarta, ID", []byte(`[]`), true, 5).
+		AddRow(uuid.New(), brandUUID, "Bandung", "Bandung, ID", []byte(`[]`), true, 0)
+
+	mock.ExpectQuery(findAllActiveByProductIdQuery).WithArgs(brandUUID, productUUID).WillReturnRows(rows)
+
+	locations, err := locationPGRepository.FindAllActiveByProductId(context.Background(), brandUUID, productUUID)
+	require.NoError(t, err)
+	require.Len(t, locations, 2)
+	require.Equal(t, "Jakarta", locations[0].Name)
+	require.Equal(t, uint64(5), locations[0].Stock)
+	require.Zero(t, locations[1].Stock)
+}
+
+func TestLocationRepository_UpdateById(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	locationPGRepository := NewLocationPGRepository(sqlxDB)
+
+	mockLocation := &models.BrandLocation{
+		LocationID:     uuid.New(),
+		BrandID:        uuid.New(),
+		Name:           "Jakarta",
+		Address:        "Jl. Sudirman 1, Jakarta, ID",
+		OperatingHours: models.OperatingHours{},
+		Active:         false,
+		Version:        1,
+	}
+	hours, _ := mockLocation.OperatingHours.Value()
+
+	mock.ExpectQuery(updateByIdQuery).WithArgs(
+		mockLocation.LocationID,
+		mockLocation.Name,
+		mockLocation.Address,
+		mockLocation.Latitude,
+		mockLocation.Longitude,
+		hours,
+		mockLocation.Active,
+		mockLocation.Version,
+	).WillReturnRows(sqlmock.NewRows([]string{"location_id", "active", "version"}).AddRow(mockLocation.LocationID, false, 2))
+
+	updatedLocation, err := locationPGRepository.UpdateById(context.Background(), mockLocation)
+	require.NoError(t, err)
+	require.Equal(t, 2, updatedLocation.Version)
+	require.False(t, updatedLocation.Active)
+
+	t.Run("VersionConflict", func(t *testing.T) {
+		mock.ExpectQuery(updateByIdQuery).WillReturnError(sql.ErrNoRows)
+
+		_, err := locationPGRepository.UpdateById(context.Background(), mockLocation)
+		require.ErrorIs(t, err, models.ErrVersionConflict)
+	})
+}
+
+func TestLocationRepository_SetStock(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	locationPGRepository := NewLocationPGRepository(sqlxDB)
+
+	mockStock := &models.LocationStock{LocationID: uuid.New(), ProductID: uuid.New(), Stock: 12}
+
+	mock.ExpectQuery(setStockQuery).WithArgs(mockStock.LocationID, mockStock.ProductID, mockStock.Stock).WillReturnRows(
+		sqlmock.NewRows([]string{"location_id", "product_id", "stock", "updated_at"}).AddRow(mockStock.LocationID, mockStock.ProductID, 12, time.Now()),
+	)
+
+	setStock, err := locationPGRepository.SetStock(context.Background(), mockStock)
+	require.NoError(t, err)
+	require.Equal(t, uint64(12), setStock.Stock)
+}
+
+func TestLocationRepository_DeleteById(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	locationPGRepository := NewLocationPGRepository(sqlxDB)
+
+	locationUUID := uuid.New()
+	mock.ExpectExec(deleteByIdQuery).WithArgs(locationUUID).WillReturnResult(sqlmock.NewResult(0, 1))
+	require.NoError(t, locationPGRepository.DeleteById(context.Background(), locationUUID))
+
+	mock.ExpectExec(deleteByIdQuery).WithArgs(locationUUID).WillReturnResult(sqlmock.NewResult(0, 0))
+	require.ErrorIs(t, locationPGRepository.DeleteById(context.Background(), locationUUID), sql.ErrNoRows)
+}
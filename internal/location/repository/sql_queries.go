@@ -0,0 +1,26 @@
+package repository
+
+const (
+	createLocationQuery = `INSERT INTO brand_locations (brand_id, name, address, latitude, longitude, operating_hours, active) 
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING location_id, brand_id, name, address, latitude, longitude, operating_hours, active, created_at, updated_at, version, deleted_at`
+
+	findByIdQuery = `SELECT location_id, brand_id, name, address, latitude, longitude, operating_hours, active, created_at, updated_at, version, deleted_at FROM brand_locations WHERE location_id = $1 AND deleted_at IS NULL`
+
+	findAllByBrandIdQuery = `SELECT location_id, brand_id, name, address, latitude, longitude, operating_hours, active, created_at, updated_at, version, deleted_at FROM brand_locations WHERE brand_id = $1 AND deleted_at IS NULL ORDER BY created_at LIMIT $2 OFFSET $3`
+
+	findAllActiveByProductIdQuery = `SELECT l.location_id, l.brand_id, l.name, l.address, l.latitude, l.longitude, l.operating_hours, l.active, l.created_at, l.updated_at, l.version, l.deleted_at, COALESCE(s.stock, 0) AS stock FROM brand_locations l
+		LEFT JOIN location_stocks s ON s.location_id = l.location_id AND s.product_id = $2
+		WHERE l.brand_id = $1 AND l.active AND l.deleted_at IS NULL ORDER BY l.created_at`
+
+	updateByIdQuery = `UPDATE brand_locations SET name = $2, address = $3, latitude = $4, longitude = $5, operating_hours = $6, active = $7, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE location_id = $1 AND version = $8 AND deleted_at IS NULL
+		RETURNING location_id, brand_id, name, address, latitude, longitude, operating_hours, active, created_at, updated_at, version, deleted_at`
+
+	deleteByIdQuery = `UPDATE brand_locations SET deleted_at = CURRENT_TIMESTAMP, version = version + 1 WHERE location_id = $1 AND deleted_at IS NULL`
+
+	findAllStocksByLocationIdQuery = `SELECT location_id, product_id, stock, updated_at FROM location_stocks WHERE location_id = $1 ORDER BY updated_at DESC`
+
+	setStockQuery = `INSERT INTO location_stocks (location_id, product_id, stock) VALUES ($1, $2, $3)
+		ON CONFLICT (location_id, product_id) DO UPDATE SET stock = EXCLUDED.stock, updated_at = CURRENT_TIMESTAMP
+		RETURNING location_id, product_id, stock, updated_at`
+)
@@ -0,0 +1,24 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase.go -package mock
+package location
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/pkg/geo"
+	"github.com/dinorain/kalobranded/pkg/utils"
+)
+
+// Location UseCase interface
+type LocationUseCase interface {
+	Create(ctx context.Context, location *models.BrandLocation) (*models.BrandLocation, error)
+	FindAllByBrandId(ctx context.Context, brandID uuid.UUID, pagination *utils.Pagination) ([]models.BrandLocation, error)
+	FindById(ctx context.Context, locationID uuid.UUID) (*models.BrandLocation, error)
+	UpdateById(ctx context.Context, location *models.BrandLocation) (*models.BrandLocation, error)
+	DeleteById(ctx context.Context, locationID uuid.UUID) error
+	FindAllStocksByLocationId(ctx context.Context, locationID uuid.UUID) ([]models.LocationStock, error)
+	SetStock(ctx context.Context, stock *models.LocationStock) (*models.LocationStock, error)
+	FindNearestInStock(ctx context.Context, brandID uuid.UUID, productID uuid.UUID, quantity uint64, dropoff *geo.Point, destination string) (*models.BrandLocation, error)
+}
@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/location"
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/pkg/geo"
+	"github.com/dinorain/kalobranded/pkg/logger"
+	"github.com/dinorain/kalobranded/pkg/utils"
+)
+
+// Location UseCase
+type locationUseCase struct {
+	cfg            *config.Config
+	logger         logger.Logger
+	locationPgRepo location.LocationPGRepository
+	geocoder       geo.Geocoder
+}
+
+var _ location.LocationUseCase = (*locationUseCase)(nil)
+
+// New Location UseCase
+func NewLocationUseCase(cfg *config.Config, logger logger.Logger, locationRepo location.LocationPGRepository, geocoder geo.Geocoder) *locationUseCase {
+	return &locationUseCase{cfg: cfg, logger: logger, locationPgRepo: locationRepo, geocoder: geocoder}
+}
+
+// Create new brand location
+func (u *locationUseCase) Create(ctx context.Context, location *models.BrandLocation) (*models.BrandLocation, error) {
+	createdLocation, err := u.locationPgRepo.Create(ctx, location)
+	if err != nil {
+		return nil, errors.Wrap(err, "locationPgRepo.Create")
+	}
+
+	return createdLocation, nil
+}
+
+// FindAllByBrandId find locations of brand
+func (u *locationUseCase) FindAllByBrandId(ctx context.Context, brandID uuid.UUID, pagination *utils.Pagination) ([]models.BrandLocation, error) {
+	locations, err := u.locationPgRepo.FindAllByBrandId(ctx, brandID, pagination)
+	if err != nil {
+		return nil, errors.Wrap(err, "locationPgRepo.FindAllByBrandId")
+	}
+
+	return locations, nil
+}
+
+// FindById find location by uuid
+func (u *locationUseCase) FindById(ctx context.Context, locationID uuid.UUID) (*models.BrandLocation, error) {
+	foundLocation, err := u.locationPgRepo.FindById(ctx, locationID)
+	if err != nil {
+		return nil, errors.Wrap(err, "locationPgRepo.FindById")
+	}
+
+	return foundLocation, nil
+}
+
+// UpdateById update existing location
+func (u *locationUseCase) UpdateById(ctx context.Context, location *models.BrandLocation) (*models.BrandLocation, error) {
+	updatedLocation, err := u.locationPgRepo.UpdateById(ctx, location)
+	if err != nil {
+		return nil, errors.Wrap(err, "locationPgRepo.UpdateById")
+	}
+
+	return updatedLocation, nil
+}
+
+// DeleteById soft delete location by uuid
+func (u *locationUseCase) DeleteById(ctx context.Context, locationID uuid.UUID) error {
+	if err := u.locationPgRepo.DeleteById(ctx, locationID); err != nil {
+		return errors.Wrap(err, "locationPgRepo.DeleteById")
+	}
+
+	return nil
+}
+
+// FindAllStocksByLocationId find product stocks of location
+func (u *locationUseCase) FindAllStocksByLocationId(ctx context.Context, locationID uuid.UUID) ([]models.LocationStock, error) {
+	stocks, err := u.locationPgRepo.FindAllStocksByLocationId(ctx, locationID)
+	if err != nil {
+		return nil, errors.Wrap(err, "locationPgRepo.FindAllStocksByLocationId")
+	}
+
+	return stocks, nil
+}
+
+// SetStock set the stock of a product at a location
+func (u *locationUseCase) SetStock(ctx context.Context, stock *models.LocationStock) (*models.LocationStock, error) {
+	setStock, err := u.locationPgRepo.SetStock(ctx, stock)
+	if err != nil {
+		return nil, errors.Wrap(err, "locationPgRepo.SetStock")
+	}
+
+	return setStock, nil
+}
+
+// FindNearestInStock location of brand fulfilling quantity of product, the nearest to the dropoff among the active
+// ones holding the whole quantity. Missing coordinates are geocoded from the addresses, locations that can't be
+// located are passed over. Nil when the brand has no active location, its orders ship from the brand pickup
+// address, models.ErrOutOfStock when none of them holds the quantity
+func (u *locationUseCase) FindNearestInStock(ctx context.Context, brandID uuid.UUID, productID uuid.UUID, quantity uint64, dropoff *geo.Point, destination string) (*models.BrandLocation, error) {
+	locations, err := u.locationPgRepo.FindAllActiveByProductId(ctx, brandID, productID)
+	if err != nil {
+		return nil, errors.Wrap(err, "locationPgRepo.FindAllActiveByProductId")
+	}
+	if len(locations) == 0 {
+		return nil, nil
+	}
+
+	to, err := geo.Locate(ctx, u.geocoder, dropoff, destination)
+	if err != nil {
+		return nil, errors.Wrap(err, "geo.Locate dropoff")
+	}
+
+	var (
+		nearest  *models.BrandLocation
+		distance float64
+	)
+	for i := range locations {
+		if locations[i].Stock < quantity {
+			continue
+		}
+
+		from, err := geo.Locate(ctx, u.geocoder, locations[i].Point(), locations[i].Address)
+		if err != nil {
+			u.logger.Warnf("geo.Locate location %s: %v", locations[i].LocationID, err)
+			continue
+		}
+
+		if d := geo.Haversine(from, to); nearest == nil || d < distance {
+			nearest, distance = &locations[i].BrandLocation, d
+		}
+	}
+	if nearest == nil {
+		return nil, errors.Wrapf(models.ErrOutOfStock, "product %s", productID)
+	}
+
+	return nearest, nil
+}
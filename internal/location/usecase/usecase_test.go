@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/location/mock"
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/pkg/geo"
+	"github.com/dinorain/kalobranded/pkg/logger"
+)
+
+func TestLocationUseCase_FindNearestInStock(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	locationPGRepository := mock.NewMockLocationPGRepository(ctrl)
+	cfg := &config.Config{}
+	apiLogger := logger.NewAppLogger(cfg)
+	apiLogger.InitLogger()
+
+	jakarta := geo.Point{Latitude: -6.2088, Longitude: 106.8456}
+	bandung := geo.Point{Latitude: -6.9175, Longitude: 107.6191}
+	surabaya := geo.Point{Latitude: -7.2575, Longitude: 112.7521}
+	geocoder := geo.NewStaticGeocoder(map[string]geo.Point{"Jakarta, ID": jakarta, "Bandung, ID": bandung})
+	locationUC := NewLocationUseCase(cfg, apiLogger, locationPGRepository, geocoder)
+
+	ctx := context.Background()
+	brandUUID := uuid.New()
+	productUUID := uuid.New()
+	stocked := func(name, address string, point *geo.Point, stock uint64) models.StockedLocation {
+		location := models.StockedLocation{
+			BrandLocation: models.BrandLocation{LocationID: uuid.New(), BrandID: brandUUID, Name: name, Address: address, Active: true},
+			Stock:         stock,
+		}
+		if point != nil {
+			location.Latitude, location.Longitude = &point.Latitude, &point.Longitude
+		}
+		return location
+	}
+
+	t.Run("Nearest", func(t *testing.T) {
+		far := stocked("Surabaya", "Jl. Tunjungan 1, Surabaya, ID", &surabaya, 10)
+		near := stocked("Bandung", "Jl. Braga 5, Bandung, ID", nil, 10)
+		locationPGRepository.EXPECT().FindAllActiveByProductId(gomock.Any(), brandUUID, productUUID).Return([]models.StockedLocation{far, near}, nil)
+
+		found, err := locationUC.FindNearestInStock(ctx, brandUUID, productUUID, 2, nil, "Jl. Sudirman 1, Jakarta, ID")
+		require.NoError(t, err)
+		require.Equal(t, near.LocationID, found.LocationID)
+	})
+
+	t.Run("SkipsShortAndUnlocatable", func(t *testing.T) {
+		short := stocked("Jakarta", "Jl. Thamrin 10, Jakarta, ID", &jakarta, 1)
+		unknown := stocked("Unknown", "Nowhere", nil, 10)
+		far := stocked("Surabaya", "Jl. Tunjungan 1, Surabaya, ID", &surabaya, 10)
+		locationPGRepository.EXPECT().FindAllActiveByProductId(gomock.Any(), brandUUID, productUUID).Return([]models.StockedLocation{short, unknown, far}, nil)
+
+		found, err := locationUC.FindNearestInStock(ctx, brandUUID, productUUID, 2, &jakarta, "")
+		require.NoError(t, err)
+		require.Equal(t, far.LocationID, found.LocationID)
+	})
+
+	t.Run("OutOfStock", func(t *testing.T) {
+		short := stocked("Jakarta", "Jl. Thamrin 10, Jakarta, ID", &jakarta, 1)
+		locationPGRepository.EXPECT().FindAllActiveByProductId(gomock.Any(), brandUUID, productUUID).Return([]models.StockedLocation{short}, nil)
+
+		_, err := locationUC.FindNearestInStock(ctx, brandUUID, productUUID, 2, &jakarta, "")
+		require.ErrorIs(t, err, models.ErrOutOfStock)
+	})
+
+	t.Run("NoLocations", func(t *testing.T) {
+		locationPGRepository.EXPECT().FindAllActiveByProductId(gomock.Any(), brandUUID, productUUID).Return(nil, nil)
+
+		found, err := locationUC.FindNearestInStock(ctx, brandUUID, productUUID, 2, nil, "Nowhere")
+		require.NoError(t, err)
+		require.Nil(t, found)
+	})
+}
@@ -0,0 +1,271 @@
+package middlewares
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/idempotency"
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/pkg/constants"
+	httpErrors "github.com/dinorain/kalobranded/pkg/http_errors"
+	"github.com/dinorain/kalobranded/pkg/logger"
+)
+
+const (
+	defaultIdempotencyExpire     = 24 * 60 * 60
+	defaultIdempotencyLockExpire = 30
+	maxIdempotencyKeyLength      = 255
+	maxIdempotentBodyBytes       = 1 << 20
+	idempotencyLockRetryInterval = 50 * time.Millisecond
+)
+
+// IdempotencyMiddleware replay the stored response of POST requests sent again with the same Idempotency-Key
+type IdempotencyMiddleware struct {
+	logger logger.Logger
+	cfg    *config.Config
+	repo   idempotency.IdempotencyRedisRepository
+}
+
+func NewIdempotencyMiddleware(logger logger.Logger, cfg *config.Config, repo idempotency.IdempotencyRedisRepository) *IdempotencyMiddleware {
+	return &IdempotencyMiddleware{logger: logger, cfg: cfg, repo: repo}
+}
+
+// Idempotent handle POST requests carrying an Idempotency-Key header once per caller and key, a replay gets the
+// stored response, the same key with a different payload gets 422 and concurrent duplicates wait on a redis lock
+func (m *IdempotencyMiddleware) Idempotent(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		idempotencyKey := r.Header.Get(constants.IdempotencyKey)
+		if r.Method != http.MethodPost || idempotencyKey == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if len(idempotencyKey) > maxIdempotencyKeyLength {
+			_ = httpErrors.NewBadRequestError(w, fmt.Sprintf("%s longer than %d characters", constants.IdempotencyKey, maxIdempotencyKeyLength), m.cfg.Http.DebugErrorsResponse)
+			return
+		}
+
+		body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxIdempotentBodyBytes))
+		if err != nil {
+			m.logger.Errorf("ioutil.ReadAll: %v", err)
+			_ = httpErrors.NewBadRequestError(w, err.Error(), m.cfg.Http.DebugErrorsResponse)
+			return
+		}
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+		ctx := r.Context()
+		key := fmt.Sprintf("%s:%s", m.callerID(r), idempotencyKey)
+		fingerprint := m.fingerprint(r, body)
+
+		if replayed := m.replay(ctx, w, key, fingerprint); replayed {
+			return
+		}
+
+		token := uuid.New().String()
+		if err := m.lock(ctx, key, token); err != nil {
+			m.logger.Errorf("IdempotencyMiddleware.lock: %v", err)
+			_ = httpErrors.NewConflictError(w, err.Error(), m.cfg.Http.DebugErrorsResponse)
+			return
+		}
+		defer func() {
+			if err := m.repo.UnlockCtx(context.Background(), key, token); err != nil {
+				m.logger.Errorf("repo.UnlockCtx: %v", err)
+			}
+		}()
+
+		// a concurrent duplicate holding the lock before may have finished the request meanwhile
+		if replayed := m.replay(ctx, w, key, fingerprint); replayed {
+			return
+		}
+
+		stopRefresh := m.keepLock(key, token)
+		defer stopRefresh()
+
+		rec := &responseRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+		next.ServeHTTP(rec, r)
+
+		// server errors are not stored so the client can retry them, responses setting cookies carry credentials
+		if rec.statusCode >= http.StatusInternalServerError || len(rec.Header().Values("Set-Cookie")) > 0 {
+			return
+		}
+
+		if err := m.repo.SetResponseCtx(context.Background(), key, m.getExpire(), &models.IdempotentResponse{
+			Fingerprint: fingerprint,
+			StatusCode:  rec.statusCode,
+			Header:      storedHeader(rec.Header()),
+			Body:        rec.body.Bytes(),
+		}); err != nil {
+			m.logger.Errorf("repo.SetResponseCtx: %v", err)
+		}
+	})
+}
+
+// replay write the stored response of key, reports whether a response has been written
+func (m *IdempotencyMiddleware) replay(ctx context.Context, w http.ResponseWriter, key string, fingerprint string) bool {
+	stored, err := m.repo.GetResponseCtx(ctx, key)
+	if err != nil {
+		if !errors.Is(err, redis.Nil) {
+			m.logger.Errorf("repo.GetResponseCtx: %v", err)
+			_ = httpErrors.NewInternalServerError(w, err.Error(), m.cfg.Http.DebugErrorsResponse)
+			return true
+		}
+		return false
+	}
+
+	if stored.Fingerprint != fingerprint {
+		_ = httpErrors.NewUnprocessableEntityError(w, fmt.Sprintf("%s already used with a different request", constants.IdempotencyKey), m.cfg.Http.DebugErrorsResponse)
+		return true
+	}
+
+	for name, values := range stored.Header {
+		w.Header()[name] = values
+	}
+	w.Header().Set(constants.IdempotentReplay, "true")
+	w.WriteHeader(stored.StatusCode)
+	w.Write(stored.Body)
+	return true
+}
+
+// lock wait until key is locked with token, giving up when the lock is not released within its expiry
+func (m *IdempotencyMiddleware) lock(ctx context.Context, key string, token string) error {
+	deadline := time.Now().Add(time.Second * time.Duration(m.getLockExpire()))
+	for {
+		locked, err := m.repo.LockCtx(ctx, key, token, m.getLockExpire())
+		if err != nil {
+			return err
+		}
+		if locked {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("request with the same %s is still in progress", constants.IdempotencyKey)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(idempotencyLockRetryInterval):
+		}
+	}
+}
+
+// keepLock refresh the lock of key every third of its expiry until the returned stop is called, so a request
+// running longer than idempotency.LockExpire does not let a concurrent duplicate take the lock over
+func (m *IdempotencyMiddleware) keepLock(key string, token string) (stop func()) {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(time.Second * time.Duration(m.getLockExpire()) / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				refreshed, err := m.repo.RefreshLockCtx(context.Background(), key, token, m.getLockExpire())
+				if err != nil {
+					m.logger.Errorf("repo.RefreshLockCtx: %v", err)
+					continue
+				}
+				if !refreshed {
+					m.logger.Warnf("IdempotencyMiddleware.keepLock: lock of %s lost", key)
+					return
+				}
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
+
+// callerID scope keys to the authenticated user, requests without a valid token share the anonymous scope
+func (m *IdempotencyMiddleware) callerID(r *http.Request) string {
+	authHeader := strings.Split(r.Header.Get("Authorization"), "Bearer ")
+	if len(authHeader) != 2 {
+		return "anonymous"
+	}
+
+	token, err := jwt.Parse(authHeader[1], func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return []byte(m.cfg.Server.JwtSecretKey), nil
+	})
+	if err != nil || !token.Valid {
+		return "anonymous"
+	}
+
+	claims, _ := token.Claims.(jwt.MapClaims)
+	if userID, ok := claims["user_id"].(string); ok && userID != "" {
+		return userID
+	}
+	return "anonymous"
+}
+
+func (m *IdempotencyMiddleware) fingerprint(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(r.Method + " " + r.URL.RequestURI() + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func (m *IdempotencyMiddleware) getExpire() int {
+	if m.cfg.Idempotency.Expire > 0 {
+		return m.cfg.Idempotency.Expire
+	}
+	return defaultIdempotencyExpire
+}
+
+func (m *IdempotencyMiddleware) getLockExpire() int {
+	if m.cfg.Idempotency.LockExpire > 0 {
+		return m.cfg.Idempotency.LockExpire
+	}
+	return defaultIdempotencyLockExpire
+}
+
+// storedHeader copy of the response header without credentials
+func storedHeader(header http.Header) http.Header {
+	stored := header.Clone()
+	for _, name := range []string{"Set-Cookie", "Authorization", "WWW-Authenticate"} {
+		stored.Del(name)
+	}
+	return stored
+}
+
+// responseRecorder write through to the client while keeping status and body for storing
+type responseRecorder struct {
+	http.ResponseWriter
+	statusCode  int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (rec *responseRecorder) WriteHeader(statusCode int) {
+	if !rec.wroteHeader {
+		rec.statusCode = statusCode
+		rec.wroteHeader = true
+	}
+	rec.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (rec *responseRecorder) Write(b []byte) (int, error) {
+	rec.wroteHeader = true
+	rec.body.Write(b)
+	return rec.ResponseWriter.Write(b)
+}
@@ -0,0 +1,210 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/kalobranded/config"
+	idempotencyRepository "github.com/dinorain/kalobranded/internal/idempotency/repository"
+	"github.com/dinorain/kalobranded/pkg/constants"
+	"github.com/dinorain/kalobranded/pkg/logger"
+)
+
+func setupIdempotencyMiddleware(t *testing.T) *IdempotencyMiddleware {
+	m, _ := setupIdempotencyMiddlewareWithRedis(t)
+	return m
+}
+
+func setupIdempotencyMiddlewareWithRedis(t *testing.T) (*IdempotencyMiddleware, *miniredis.Miniredis) {
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	t.Cleanup(mr.Close)
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+
+	cfg := &config.Config{Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	appLogger := logger.NewAppLogger(cfg)
+	appLogger.InitLogger()
+
+	return NewIdempotencyMiddleware(appLogger, cfg, idempotencyRepository.NewIdempotencyRedisRepo(client, appLogger)), mr
+}
+
+func countingHandler(calls *int32, statusCode int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(calls, 1)
+		time.Sleep(10 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(statusCode)
+		w.Write([]byte(`{"call":` + strconv.Itoa(int(n)) + `}`))
+	})
+}
+
+func idempotentRequest(key string, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set(constants.IdempotencyKey, key)
+	}
+	return req
+}
+
+func TestIdempotencyMiddleware_Idempotent(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Replay", func(t *testing.T) {
+		var calls int32
+		handler := setupIdempotencyMiddleware(t).Idempotent(countingHandler(&calls, http.StatusCreated))
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, idempotentRequest("key", `{"quantity": 1}`))
+		require.Equal(t, http.StatusCreated, w.Code)
+		require.Empty(t, w.Header().Get(constants.IdempotentReplay))
+
+		replay := httptest.NewRecorder()
+		handler.ServeHTTP(replay, idempotentRequest("key", `{"quantity": 1}`))
+		require.Equal(t, http.StatusCreated, replay.Code)
+		require.Equal(t, "true", replay.Header().Get(constants.IdempotentReplay))
+		require.Equal(t, "application/json", replay.Header().Get("Content-Type"))
+		require.Equal(t, w.Body.String(), replay.Body.String())
+		require.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("DifferentPayload", func(t *testing.T) {
+		var calls int32
+		handler := setupIdempotencyMiddleware(t).Idempotent(countingHandler(&calls, http.StatusCreated))
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, idempotentRequest("key", `{"quantity": 1}`))
+		require.Equal(t, http.StatusCreated, w.Code)
+
+		w = httptest.NewRecorder()
+		handler.ServeHTTP(w, idempotentRequest("key", `{"quantity": 2}`))
+		require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		require.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("Concurrent", func(t *testing.T) {
+		var calls int32
+		handler := setupIdempotencyMiddleware(t).Idempotent(countingHandler(&calls, http.StatusCreated))
+
+		var wg sync.WaitGroup
+		recorders := make([]*httptest.ResponseRecorder, 5)
+		for i := range recorders {
+			recorders[i] = httptest.NewRecorder()
+			wg.Add(1)
+			go func(w *httptest.ResponseRecorder) {
+				defer wg.Done()
+				handler.ServeHTTP(w, idempotentRequest("key", `{"quantity": 1}`))
+			}(recorders[i])
+		}
+		wg.Wait()
+
+		require.Equal(t, int32(1), atomic.LoadInt32(&calls))
+		for _, w := range recorders {
+			require.Equal(t, http.StatusCreated, w.Code)
+			require.Equal(t, recorders[0].Body.String(), w.Body.String())
+		}
+	})
+
+	t.Run("LockRefreshed", func(t *testing.T) {
+		m, mr := setupIdempotencyMiddlewareWithRedis(t)
+		m.cfg.Idempotency.LockExpire = 1
+
+		// miniredis only expires keys on FastForward, 1.2s in total would expire a lock never refreshed
+		var lockHeld bool
+		handler := m.Idempotent(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for i := 0; i < 2; i++ {
+				time.Sleep(400 * time.Millisecond)
+				mr.FastForward(600 * time.Millisecond)
+			}
+			for _, key := range mr.Keys() {
+				lockHeld = lockHeld || strings.HasPrefix(key, "idempotency_lock:")
+			}
+			w.WriteHeader(http.StatusCreated)
+		}))
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, idempotentRequest("key", `{"quantity": 1}`))
+		require.Equal(t, http.StatusCreated, w.Code)
+		require.True(t, lockHeld)
+	})
+
+	t.Run("ServerErrorNotStored", func(t *testing.T) {
+		var calls int32
+		handler := setupIdempotencyMiddleware(t).Idempotent(countingHandler(&calls, http.StatusInternalServerError))
+
+		for i := 0; i < 2; i++ {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, idempotentRequest("key", `{"quantity": 1}`))
+			require.Equal(t, http.StatusInternalServerError, w.Code)
+		}
+		require.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("WithoutKey", func(t *testing.T) {
+		var calls int32
+		handler := setupIdempotencyMiddleware(t).Idempotent(countingHandler(&calls, http.StatusCreated))
+
+		for i := 0; i < 2; i++ {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, idempotentRequest("", `{"quantity": 1}`))
+			require.Equal(t, http.StatusCreated, w.Code)
+		}
+		require.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("CookieResponseNotStored", func(t *testing.T) {
+		var calls int32
+		handler := setupIdempotencyMiddleware(t).Idempotent(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			http.SetCookie(w, &http.Cookie{Name: "session_id", Value: "secret"})
+			w.WriteHeader(http.StatusCreated)
+		}))
+
+		for i := 0; i < 2; i++ {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, idempotentRequest("key", `{"quantity": 1}`))
+			require.Equal(t, http.StatusCreated, w.Code)
+			require.Empty(t, w.Header().Get(constants.IdempotentReplay))
+		}
+		require.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	})
+}
+
+func TestMiddlewareManager_Idempotent(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.Config{}
+	appLogger := logger.NewAppLogger(cfg)
+	appLogger.InitLogger()
+
+	var calls int32
+	handler := NewMiddlewareManager(appLogger, cfg).Idempotent(countingHandler(&calls, http.StatusCreated))
+
+	for i := 0; i < 2; i++ {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, idempotentRequest("key", `{"quantity": 1}`))
+		require.Equal(t, http.StatusCreated, w.Code)
+	}
+	require.Equal(t, int32(2), atomic.LoadInt32(&calls))
+
+	calls = 0
+	handler = NewMiddlewareManager(appLogger, cfg, WithIdempotency(setupIdempotencyMiddleware(t))).Idempotent(countingHandler(&calls, http.StatusCreated))
+
+	for i := 0; i < 2; i++ {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, idempotentRequest("key", `{"quantity": 1}`))
+		require.Equal(t, http.StatusCreated, w.Code)
+	}
+	require.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
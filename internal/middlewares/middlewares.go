@@ -6,52 +6,59 @@ import (
 	"strings"
 
 	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
 
 	"github.com/dinorain/kalobranded/config"
 	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/pkg/constants"
 	httpErrors "github.com/dinorain/kalobranded/pkg/http_errors"
 	"github.com/dinorain/kalobranded/pkg/logger"
 )
 
 type MiddlewareManager interface {
 	RequestLoggerMiddleware(next http.Handler) http.Handler
-	PostHandler(next http.Handler) http.Handler
-	GetHandler(next http.Handler) http.Handler
 	IsLoggedIn(next http.Handler) http.Handler
 	IsUser(next http.Handler) http.Handler
 	IsAdmin(next http.Handler) http.Handler
+	IsAdminOrSeller(next http.Handler) http.Handler
+	Idempotent(next http.Handler) http.Handler
 	GetJWTClaims(w http.ResponseWriter, r *http.Request) (*jwt.MapClaims, error)
+	IncludeDeleted(w http.ResponseWriter, r *http.Request) (bool, error)
+	CheckBrand(w http.ResponseWriter, r *http.Request, brandID uuid.UUID) error
 }
 
 type middlewareManager struct {
-	logger logger.Logger
-	cfg    *config.Config
+	logger      logger.Logger
+	cfg         *config.Config
+	idempotency *IdempotencyMiddleware
 }
 
 var _ MiddlewareManager = (*middlewareManager)(nil)
 
-func NewMiddlewareManager(logger logger.Logger, cfg *config.Config) *middlewareManager {
-	return &middlewareManager{logger: logger, cfg: cfg}
+// Option optional dependency of the middleware manager
+type Option func(*middlewareManager)
+
+// WithIdempotency back Idempotent with the given middleware, without it Idempotent passes requests through
+func WithIdempotency(idempotency *IdempotencyMiddleware) Option {
+	return func(mw *middlewareManager) {
+		mw.idempotency = idempotency
+	}
 }
 
-func (mw *middlewareManager) PostHandler(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodPost {
-			httpErrors.NewStatusMethodNotAllowedError(w, nil, mw.cfg.Http.DebugErrorsResponse)
-			return
-		}
-		next.ServeHTTP(w, r)
-	})
+func NewMiddlewareManager(logger logger.Logger, cfg *config.Config, opts ...Option) *middlewareManager {
+	mw := &middlewareManager{logger: logger, cfg: cfg}
+	for _, opt := range opts {
+		opt(mw)
+	}
+	return mw
 }
 
-func (mw *middlewareManager) GetHandler(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
-			httpErrors.NewStatusMethodNotAllowedError(w, nil, mw.cfg.Http.DebugErrorsResponse)
-			return
-		}
-		next.ServeHTTP(w, r)
-	})
+// Idempotent replay responses of retried requests carrying an Idempotency-Key, only meant for routes creating resources
+func (mw *middlewareManager) Idempotent(next http.Handler) http.Handler {
+	if mw.idempotency == nil {
+		return next
+	}
+	return mw.idempotency.Idempotent(next)
 }
 
 func (mw *middlewareManager) GetJWTClaims(w http.ResponseWriter, r *http.Request) (*jwt.MapClaims, error) {
@@ -77,8 +84,6 @@ func (mw *middlewareManager) GetJWTClaims(w http.ResponseWriter, r *http.Request
 			return nil, httpErrors.NewUnauthorizedError(w, nil, mw.cfg.Http.DebugErrorsResponse)
 		}
 	}
-
-	return nil, httpErrors.NewUnauthorizedError(w, nil, mw.cfg.Http.DebugErrorsResponse)
 }
 
 func (mw *middlewareManager) IsLoggedIn(next http.Handler) http.Handler {
@@ -110,6 +115,26 @@ func (mw *middlewareManager) IsAdmin(next http.Handler) http.Handler {
 	})
 }
 
+func (mw *middlewareManager) IsAdminOrSeller(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jwtClaims, err := mw.GetJWTClaims(w, r)
+		if err != nil {
+			return
+		}
+		claims := *jwtClaims
+		role, ok := claims["role"].(string)
+		if !ok {
+			mw.logger.Warnf("role: %+v", claims)
+		}
+
+		if role != models.UserRoleAdmin && role != models.UserRoleSeller {
+			_ = httpErrors.NewForbiddenError(w, nil, mw.cfg.Http.DebugErrorsResponse)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
 func (mw *middlewareManager) IsUser(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		jwtClaims, err := mw.GetJWTClaims(w, r)
@@ -130,6 +155,43 @@ func (mw *middlewareManager) IsUser(next http.Handler) http.Handler {
 	})
 }
 
+// IncludeDeleted reports whether soft deleted rows were requested with include_deleted=true,
+// which only admins may do, error response is already written when err is not nil
+func (mw *middlewareManager) IncludeDeleted(w http.ResponseWriter, r *http.Request) (bool, error) {
+	if r.URL.Query().Get(constants.IncludeDeleted) != "true" {
+		return false, nil
+	}
+
+	jwtClaims, err := mw.GetJWTClaims(w, r)
+	if err != nil {
+		return false, err
+	}
+	claims := *jwtClaims
+	if role, _ := claims["role"].(string); role != models.UserRoleAdmin {
+		return false, httpErrors.NewForbiddenError(w, nil, mw.cfg.Http.DebugErrorsResponse)
+	}
+
+	return true, nil
+}
+
+// CheckBrand admins act on every brand, sellers on their own only, so uuid.Nil passes admins only. Error response is
+// already written when err is not nil
+func (mw *middlewareManager) CheckBrand(w http.ResponseWriter, r *http.Request, brandID uuid.UUID) error {
+	jwtClaims, err := mw.GetJWTClaims(w, r)
+	if err != nil {
+		return err
+	}
+	claims := *jwtClaims
+	role, _ := claims["role"].(string)
+	sellerBrandID, _ := claims["brand_id"].(string)
+
+	if role != models.UserRoleAdmin && (role != models.UserRoleSeller || brandID == uuid.Nil || sellerBrandID != brandID.String()) {
+		return httpErrors.NewForbiddenError(w, nil, mw.cfg.Http.DebugErrorsResponse)
+	}
+
+	return nil
+}
+
 func (mw *middlewareManager) RequestLoggerMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if !mw.checkIgnoredURI(r.RequestURI, mw.cfg.Http.IgnoreLogUrls) {
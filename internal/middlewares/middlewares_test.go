@@ -96,7 +96,7 @@ func TestMiddlewares_IsAdmin(t *testing.T) {
 	require.Equal(t, http.StatusOK, w.Code)
 }
 
-func TestMiddlewares_IsUser(t *testing.T) {
+func TestMiddlewares_IsAdminOrSeller(t *testing.T) {
 	t.Parallel()
 
 	ctrl := gomock.NewController(t)
@@ -106,59 +106,83 @@ func TestMiddlewares_IsUser(t *testing.T) {
 	appLogger := logger.NewAppLogger(cfg)
 	mw := NewMiddlewareManager(appLogger, cfg)
 
-	userUUID := uuid.New()
-	sessUUID := uuid.New()
-	token := jwt.New(jwt.SigningMethodHS256)
-	claims := token.Claims.(jwt.MapClaims)
-	claims["session_id"] = sessUUID.String()
-	claims["user_id"] = userUUID.String()
-	claims["role"] = models.UserRoleUser
-	claims["exp"] = time.Now().Add(time.Minute * 15).Unix()
-	validToken, _ := token.SignedString([]byte(cfg.Server.JwtSecretKey))
+	for role, code := range map[string]int{
+		models.UserRoleAdmin:  http.StatusOK,
+		models.UserRoleSeller: http.StatusOK,
+		models.UserRoleUser:   http.StatusForbidden,
+	} {
+		token := jwt.New(jwt.SigningMethodHS256)
+		claims := token.Claims.(jwt.MapClaims)
+		claims["session_id"] = uuid.New().String()
+		claims["user_id"] = uuid.New().String()
+		claims["role"] = role
+		claims["exp"] = time.Now().Add(time.Minute * 15).Unix()
+		validToken, _ := token.SignedString([]byte(cfg.Server.JwtSecretKey))
 
-	req := httptest.NewRequest(http.MethodGet, "/", nil)
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", validToken))
-	w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", validToken))
+		w := httptest.NewRecorder()
 
-	handler := mw.IsUser(http.HandlerFunc(testHandler))
-	handler.ServeHTTP(w, req)
+		handler := mw.IsAdminOrSeller(http.HandlerFunc(testHandler))
+		handler.ServeHTTP(w, req)
 
-	require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, code, w.Code, role)
+	}
 }
 
-func TestMiddlewares_PostHandler(t *testing.T) {
+func TestMiddlewares_CheckBrand(t *testing.T) {
 	t.Parallel()
 
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-
 	cfg := &config.Config{Session: config.Session{Expire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
 	appLogger := logger.NewAppLogger(cfg)
 	mw := NewMiddlewareManager(appLogger, cfg)
 
-	t.Run("Fail", func(t *testing.T) {
-		req := httptest.NewRequest(http.MethodGet, "/", nil)
-		req.Header.Set("Content-Type", "application/json")
-		w := httptest.NewRecorder()
-
-		handler := mw.PostHandler(http.HandlerFunc(testHandler))
-		handler.ServeHTTP(w, req)
-		require.Equal(t, http.StatusMethodNotAllowed, w.Code)
-	})
-
-	t.Run("Success", func(t *testing.T) {
-		req := httptest.NewRequest(http.MethodPost, "/", nil)
-		req.Header.Set("Content-Type", "application/json")
+	brandUUID := uuid.New()
+	for _, tc := range []struct {
+		name    string
+		role    string
+		brandID uuid.UUID
+		code    int
+	}{
+		{name: "Admin", role: models.UserRoleAdmin, brandID: uuid.New(), code: http.StatusOK},
+		{name: "AdminNoBrand", role: models.UserRoleAdmin, brandID: uuid.Nil, code: http.StatusOK},
+		{name: "OwnBrand", role: models.UserRoleSeller, brandID: brandUUID, code: http.StatusOK},
+		{name: "OtherBrand", role: models.UserRoleSeller, brandID: uuid.New(), code: http.StatusForbidden},
+		{name: "SellerNoBrand", role: models.UserRoleSeller, brandID: uuid.Nil, code: http.StatusForbidden},
+		{name: "User", role: models.UserRoleUser, brandID: brandUUID, code: http.StatusForbidden},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			token := jwt.New(jwt.SigningMethodHS256)
+			claims := token.Claims.(jwt.MapClaims)
+			claims["session_id"] = uuid.New().String()
+			claims["user_id"] = uuid.New().String()
+			claims["role"] = tc.role
+			claims["brand_id"] = brandUUID.String()
+			claims["exp"] = time.Now().Add(time.Minute * 15).Unix()
+			validToken, _ := token.SignedString([]byte(cfg.Server.JwtSecretKey))
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", validToken))
+			w := httptest.NewRecorder()
+
+			if err := mw.CheckBrand(w, req, tc.brandID); err == nil {
+				testHandler(w, req)
+			}
+
+			require.Equal(t, tc.code, w.Code)
+		})
+	}
+
+	t.Run("Unauthorized", func(t *testing.T) {
 		w := httptest.NewRecorder()
 
-		handler := mw.PostHandler(http.HandlerFunc(testHandler))
-		handler.ServeHTTP(w, req)
-		require.Equal(t, http.StatusOK, w.Code)
+		require.Error(t, mw.CheckBrand(w, httptest.NewRequest(http.MethodGet, "/", nil), brandUUID))
+		require.Equal(t, http.StatusUnauthorized, w.Code)
 	})
 }
 
-func TestMiddlewares_GetHandler(t *testing.T) {
+func TestMiddlewares_IsUser(t *testing.T) {
 	t.Parallel()
 
 	ctrl := gomock.NewController(t)
@@ -168,24 +192,23 @@ func TestMiddlewares_GetHandler(t *testing.T) {
 	appLogger := logger.NewAppLogger(cfg)
 	mw := NewMiddlewareManager(appLogger, cfg)
 
-	t.Run("Fail", func(t *testing.T) {
-		req := httptest.NewRequest(http.MethodPost, "/", nil)
-		req.Header.Set("Content-Type", "application/json")
-		w := httptest.NewRecorder()
-
-		handler := mw.GetHandler(http.HandlerFunc(testHandler))
-		handler.ServeHTTP(w, req)
-		require.Equal(t, http.StatusMethodNotAllowed, w.Code)
-	})
+	userUUID := uuid.New()
+	sessUUID := uuid.New()
+	token := jwt.New(jwt.SigningMethodHS256)
+	claims := token.Claims.(jwt.MapClaims)
+	claims["session_id"] = sessUUID.String()
+	claims["user_id"] = userUUID.String()
+	claims["role"] = models.UserRoleUser
+	claims["exp"] = time.Now().Add(time.Minute * 15).Unix()
+	validToken, _ := token.SignedString([]byte(cfg.Server.JwtSecretKey))
 
-	t.Run("Success", func(t *testing.T) {
-		req := httptest.NewRequest(http.MethodGet, "/", nil)
-		req.Header.Set("Content-Type", "application/json")
-		w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", validToken))
+	w := httptest.NewRecorder()
 
-		handler := mw.GetHandler(http.HandlerFunc(testHandler))
-		handler.ServeHTTP(w, req)
-		require.Equal(t, http.StatusOK, w.Code)
-	})
+	handler := mw.IsUser(http.HandlerFunc(testHandler))
+	handler.ServeHTTP(w, req)
 
+	require.Equal(t, http.StatusOK, w.Code)
 }
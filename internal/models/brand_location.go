@@ -0,0 +1,133 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/pkg/geo"
+)
+
+var (
+	// ErrInvalidOperatingHours unknown day or opening time not before the closing one
+	ErrInvalidOperatingHours = errors.New("invalid operating hours")
+	// ErrOutOfStock no location of the brand holds the ordered quantity
+	ErrOutOfStock = errors.New("out of stock")
+)
+
+// Weekdays of operating hours, monday first
+var Weekdays = []string{"mon", "tue", "wed", "thu", "fri", "sat", "sun"}
+
+// BrandLocation model, a warehouse or store of the brand orders ship from
+type BrandLocation struct {
+	LocationID     uuid.UUID      `json:"location_id" db:"location_id"`
+	BrandID        uuid.UUID      `json:"brand_id" db:"brand_id"`
+	Name           string         `json:"name" db:"name"`
+	Address        string         `json:"address" db:"address"`
+	Latitude       *float64       `json:"latitude,omitempty" db:"latitude"`
+	Longitude      *float64       `json:"longitude,omitempty" db:"longitude"`
+	OperatingHours OperatingHours `json:"operating_hours" db:"operating_hours"`
+	Active         bool           `json:"active" db:"active"`
+	Version        int            `json:"version" db:"version"`
+	DeletedAt      *time.Time     `json:"deleted_at,omitempty" db:"deleted_at"`
+	CreatedAt      time.Time      `json:"created_at,omitempty" db:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at,omitempty" db:"updated_at"`
+}
+
+func (l *BrandLocation) PrepareCreate() error {
+	l.Name = strings.TrimSpace(l.Name)
+	l.Address = strings.TrimSpace(l.Address)
+	if l.OperatingHours == nil {
+		l.OperatingHours = OperatingHours{}
+	}
+
+	if err := geo.CheckCoordinates(l.Latitude, l.Longitude); err != nil {
+		return err
+	}
+	return l.OperatingHours.Validate()
+}
+
+// Point coordinates of the location, nil when they are not set
+func (l *BrandLocation) Point() *geo.Point {
+	return geo.NewPoint(l.Latitude, l.Longitude)
+}
+
+// StockedLocation brand location with its stock of a product
+type StockedLocation struct {
+	BrandLocation
+	Stock uint64 `json:"stock" db:"stock"`
+}
+
+// LocationStock stock of a product at a brand location
+type LocationStock struct {
+	LocationID uuid.UUID `json:"location_id" db:"location_id"`
+	ProductID  uuid.UUID `json:"product_id" db:"product_id"`
+	Stock      uint64    `json:"stock" db:"stock"`
+	UpdatedAt  time.Time `json:"updated_at,omitempty" db:"updated_at"`
+}
+
+// OpeningHours opening and closing time of a weekday, "HH:MM" in the local time of the location
+type OpeningHours struct {
+	Day    string `json:"day"`
+	Opens  string `json:"opens"`
+	Closes string `json:"closes"`
+}
+
+// OperatingHours weekly opening hours of a location, a day may open more than once
+type OperatingHours []OpeningHours
+
+// Validate days must be weekdays and every opening before its closing
+func (o OperatingHours) Validate() error {
+	for _, hours := range o {
+		if !isWeekday(hours.Day) {
+			return fmt.Errorf("%w: day %q", ErrInvalidOperatingHours, hours.Day)
+		}
+		opens, err := time.Parse("15:04", hours.Opens)
+		if err != nil {
+			return fmt.Errorf("%w: opens %q", ErrInvalidOperatingHours, hours.Opens)
+		}
+		closes, err := time.Parse("15:04", hours.Closes)
+		if err != nil {
+			return fmt.Errorf("%w: closes %q", ErrInvalidOperatingHours, hours.Closes)
+		}
+		if !opens.Before(closes) {
+			return fmt.Errorf("%w: %s opens at %s after closing at %s", ErrInvalidOperatingHours, hours.Day, hours.Opens, hours.Closes)
+		}
+	}
+	return nil
+}
+
+func isWeekday(day string) bool {
+	for _, weekday := range Weekdays {
+		if day == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+func (o *OperatingHours) Scan(value interface{}) error {
+	val, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("unable to scan")
+	}
+	var hours OperatingHours
+	if err := json.Unmarshal(val, &hours); err != nil {
+		return fmt.Errorf("json.Unmarshal %v", value)
+	}
+	*o = hours
+	return nil
+}
+
+func (o OperatingHours) Value() (driver.Value, error) {
+	if o == nil {
+		o = OperatingHours{}
+	}
+	valueJson, _ := json.Marshal(o)
+	return valueJson, nil
+}
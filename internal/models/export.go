@@ -0,0 +1,211 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const (
+	ExportResourceOrders   = "orders"
+	ExportResourceProducts = "products"
+	ExportResourceUsers    = "users"
+)
+
+const (
+	ExportStatusPending = "pending"
+	ExportStatusRunning = "running"
+	ExportStatusDone    = "done"
+	ExportStatusFailed  = "failed"
+)
+
+// ErrExportNotDone the file of an export is not written yet, or failed to be
+var ErrExportNotDone = errors.New("export is not done")
+
+// ExportColumns columns each resource exports, in the order exports have them by default. The password of users is
+// not among them and cannot be exported
+var ExportColumns = map[string][]string{
+	ExportResourceOrders: {
+		"order_id", "user_id", "brand_id", "product_id", "product_name", "quantity", "total_price", "discount_total",
+		"tax_total", "delivery_fee", "refunded_quantity", "refunded_amount", "status", "delivery_destination_address",
+		"created_at", "updated_at",
+	},
+	ExportResourceProducts: {
+		"product_id", "brand_id", "name", "description", "category", "price", "stock", "weight", "created_at",
+		"updated_at",
+	},
+	ExportResourceUsers: {
+		"user_id", "email", "first_name", "last_name", "role", "brand_id", "delivery_address", "created_at",
+		"updated_at",
+	},
+}
+
+// ParseExportColumns columns of a comma separated list of resource columns, all of them when columns is empty
+func ParseExportColumns(resource, columns string) ([]string, error) {
+	allowed, ok := ExportColumns[resource]
+	if !ok {
+		return nil, fmt.Errorf("invalid resource: %q", resource)
+	}
+	if columns == "" {
+		return append([]string{}, allowed...), nil
+	}
+
+	var parsed []string
+	seen := map[string]bool{}
+	for _, column := range strings.Split(columns, ",") {
+		column = strings.ToLower(strings.TrimSpace(column))
+		if !isExportColumn(allowed, column) {
+			return nil, fmt.Errorf("invalid column: %q", column)
+		}
+		if !seen[column] {
+			seen[column] = true
+			parsed = append(parsed, column)
+		}
+	}
+
+	return parsed, nil
+}
+
+func isExportColumn(allowed []string, column string) bool {
+	for _, c := range allowed {
+		if c == column {
+			return true
+		}
+	}
+	return false
+}
+
+// Exportable row of an export
+type Exportable interface {
+	// ExportValue value of a column of ExportColumns, nil for an empty cell
+	ExportValue(column string) interface{}
+}
+
+// Export rows of a resource exported in the background to a file. Query holds the filters and sort of the rows as
+// the query parameters of the resource list, with the visibility of the user who asked already applied
+type Export struct {
+	ExportID   uuid.UUID      `json:"export_id" db:"export_id"`
+	UserID     uuid.UUID      `json:"user_id" db:"user_id"`
+	Resource   string         `json:"resource" db:"resource"`
+	Format     string         `json:"format" db:"format"`
+	Columns    pq.StringArray `json:"columns" db:"columns"`
+	Query      string         `json:"query" db:"query"`
+	Status     string         `json:"status" db:"status"`
+	FileName   *string        `json:"-" db:"file_name"`
+	Rows       int64          `json:"rows" db:"row_count"`
+	Error      *string        `json:"error,omitempty" db:"error"`
+	CreatedAt  time.Time      `json:"created_at" db:"created_at"`
+	FinishedAt *time.Time     `json:"finished_at,omitempty" db:"finished_at"`
+}
+
+// ExportJob payload of the job running an export
+type ExportJob struct {
+	ExportID uuid.UUID `json:"export_id"`
+}
+
+var (
+	_ Exportable = (*Order)(nil)
+	_ Exportable = (*Product)(nil)
+	_ Exportable = (*User)(nil)
+)
+
+// ExportValue value of the order in a column of its ExportColumns
+func (o *Order) ExportValue(column string) interface{} {
+	switch column {
+	case "order_id":
+		return o.OrderID
+	case "user_id":
+		return o.UserID
+	case "brand_id":
+		return o.BrandID
+	case "product_id":
+		return o.Item.ProductID
+	case "product_name":
+		return o.Item.Name
+	case "quantity":
+		return o.Quantity
+	case "total_price":
+		return o.TotalPrice
+	case "discount_total":
+		return o.DiscountTotal
+	case "tax_total":
+		return o.TaxTotal
+	case "delivery_fee":
+		return o.DeliveryFee
+	case "refunded_quantity":
+		return o.RefundedQuantity
+	case "refunded_amount":
+		return o.RefundedAmount
+	case "status":
+		return o.Status
+	case "delivery_destination_address":
+		return o.DeliveryDestinationAddress
+	case "created_at":
+		return o.CreatedAt
+	case "updated_at":
+		return o.UpdatedAt
+	default:
+		return nil
+	}
+}
+
+// ExportValue value of the product in a column of its ExportColumns
+func (p *Product) ExportValue(column string) interface{} {
+	switch column {
+	case "product_id":
+		return p.ProductID
+	case "brand_id":
+		return p.BrandID
+	case "name":
+		return p.Name
+	case "description":
+		return p.Description
+	case "category":
+		return p.Category
+	case "price":
+		return p.Price
+	case "stock":
+		return p.Stock
+	case "weight":
+		return p.Weight
+	case "created_at":
+		return p.CreatedAt
+	case "updated_at":
+		return p.UpdatedAt
+	default:
+		return nil
+	}
+}
+
+// ExportValue value of the user in a column of its ExportColumns
+func (u *User) ExportValue(column string) interface{} {
+	switch column {
+	case "user_id":
+		return u.UserID
+	case "email":
+		return u.Email
+	case "first_name":
+		return u.FirstName
+	case "last_name":
+		return u.LastName
+	case "role":
+		return u.Role
+	case "brand_id":
+		if u.BrandID == nil {
+			return nil
+		}
+		return *u.BrandID
+	case "delivery_address":
+		return u.DeliveryAddress
+	case "created_at":
+		return u.CreatedAt
+	case "updated_at":
+		return u.UpdatedAt
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,11 @@
+package models
+
+import "net/http"
+
+// IdempotentResponse response stored for an Idempotency-Key, replayed when the same request is sent again
+type IdempotentResponse struct {
+	Fingerprint string      `json:"fingerprint"`
+	StatusCode  int         `json:"status_code"`
+	Header      http.Header `json:"header,omitempty"`
+	Body        []byte      `json:"body,omitempty"`
+}
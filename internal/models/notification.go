@@ -0,0 +1,97 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// NotificationOrderPlaced sent to the buyer of a new order
+	NotificationOrderPlaced = "order_placed"
+	// NotificationOrderReceived sent to the sellers of the brand of a new order
+	NotificationOrderReceived = "order_received"
+	// NotificationOrderAccepted sent to the buyer once the brand accepted the order
+	NotificationOrderAccepted = "order_accepted"
+	// NotificationOrderShipped sent to the buyer once the order is on its way
+	NotificationOrderShipped = "order_shipped"
+)
+
+// NotificationLocales locales notification templates are available in
+var NotificationLocales = []string{"en", "id"}
+
+// ErrUnsupportedLocale no notification templates in the locale
+var ErrUnsupportedLocale = errors.New("unsupported locale")
+
+// IsNotificationLocale reports whether notification templates are available in locale
+func IsNotificationLocale(locale string) bool {
+	for _, l := range NotificationLocales {
+		if l == locale {
+			return true
+		}
+	}
+	return false
+}
+
+// NotificationPreferences emails a user opted into, users without preferences get every email in the default locale
+type NotificationPreferences struct {
+	UserID       uuid.UUID `json:"user_id" db:"user_id"`
+	Locale       string    `json:"locale" db:"locale"`
+	OrderUpdates bool      `json:"order_updates" db:"order_updates"`
+	NewOrders    bool      `json:"new_orders" db:"new_orders"`
+	CreatedAt    time.Time `json:"created_at,omitempty" db:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at,omitempty" db:"updated_at"`
+}
+
+// DefaultNotificationPreferences preferences of users who never set theirs
+func DefaultNotificationPreferences(userID uuid.UUID) *NotificationPreferences {
+	return &NotificationPreferences{UserID: userID, OrderUpdates: true, NewOrders: true}
+}
+
+func (p *NotificationPreferences) PrepareCreate() error {
+	p.Locale = strings.ToLower(strings.TrimSpace(p.Locale))
+	if p.Locale != "" && !IsNotificationLocale(p.Locale) {
+		return ErrUnsupportedLocale
+	}
+
+	return nil
+}
+
+// NotificationRecipient user an email may go to, along with their preferences
+type NotificationRecipient struct {
+	UserID       uuid.UUID `json:"user_id" db:"user_id"`
+	Email        string    `json:"email" db:"email"`
+	FirstName    string    `json:"first_name" db:"first_name"`
+	Locale       string    `json:"locale" db:"locale"`
+	OrderUpdates bool      `json:"order_updates" db:"order_updates"`
+	NewOrders    bool      `json:"new_orders" db:"new_orders"`
+}
+
+// Notification email rendered from Template in Locale, the payload of a notification job
+type Notification struct {
+	EventID  uuid.UUID             `json:"event_id"`
+	UserID   uuid.UUID             `json:"user_id"`
+	Template string                `json:"template"`
+	Locale   string                `json:"locale"`
+	To       string                `json:"to"`
+	Data     OrderNotificationData `json:"data"`
+}
+
+// OrderNotificationData order details available to notification templates
+type OrderNotificationData struct {
+	RecipientName   string    `json:"recipient_name"`
+	BrandName       string    `json:"brand_name"`
+	OrderID         uuid.UUID `json:"order_id"`
+	ItemName        string    `json:"item_name"`
+	Quantity        uint64    `json:"quantity"`
+	TotalPrice      float64   `json:"total_price"`
+	Status          string    `json:"status"`
+	DeliveryAddress string    `json:"delivery_address"`
+}
+
+// OrderNumber short form of the order id shown to people
+func (d OrderNotificationData) OrderNumber() string {
+	return strings.ToUpper(d.OrderID.String()[:8])
+}
@@ -3,30 +3,131 @@ package models
 import (
 	"database/sql/driver"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/pkg/tax"
 )
 
 const (
-	OrderStatusPending  = "pending"
-	OrderStatusAccepted = "accepted"
+	OrderStatusPending   = "pending"
+	OrderStatusPaid      = "paid"
+	OrderStatusAccepted  = "accepted"
+	OrderStatusShipped   = "shipped"
+	OrderStatusDelivered = "delivered"
+	OrderStatusRefunded  = "refunded"
+	OrderStatusRejected  = "rejected"
 )
 
+// OrderStatuses every order status, in the order they are reached
+var OrderStatuses = []string{
+	OrderStatusPending,
+	OrderStatusPaid,
+	OrderStatusRejected,
+	OrderStatusAccepted,
+	OrderStatusShipped,
+	OrderStatusDelivered,
+	OrderStatusRefunded,
+}
+
+// IsOrderStatus whether status is one of OrderStatuses
+func IsOrderStatus(status string) bool {
+	for _, s := range OrderStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// ErrInvalidStatusTransition status change not allowed from the current status
+var ErrInvalidStatusTransition = errors.New("invalid status transition")
+
+// orderStatusTransitions allowed next statuses, an order is paid through its payment and accepted or rejected by
+// the seller only once paid, shipped and delivered by its courier, which may skip the shipped scan, and refunded once
+// its whole quantity is refunded
+var orderStatusTransitions = map[string][]string{
+	OrderStatusPending:   {OrderStatusPaid},
+	OrderStatusPaid:      {OrderStatusAccepted, OrderStatusRejected, OrderStatusRefunded},
+	OrderStatusRejected:  {OrderStatusRefunded},
+	OrderStatusAccepted:  {OrderStatusShipped, OrderStatusDelivered, OrderStatusRefunded},
+	OrderStatusShipped:   {OrderStatusDelivered, OrderStatusRefunded},
+	OrderStatusDelivered: {OrderStatusRefunded},
+}
+
 // Order model
 type Order struct {
-	OrderID                    uuid.UUID `json:"order_id" db:"order_id"`
-	UserID                     uuid.UUID `json:"user_id" db:"user_id"`
-	BrandID                    uuid.UUID `json:"brand_id" db:"brand_id"`
-	Item                       OrderItem `json:"item" db:"item"`
-	Quantity                   uint64    `json:"quantity" db:"quantity"`
-	TotalPrice                 float64   `json:"total_price" db:"total_price"`
-	Status                     string    `json:"status" db:"status"`
-	DeliverySourceAddress      string    `json:"delivery_source_address" db:"delivery_source_address"`
-	DeliveryDestinationAddress string    `json:"delivery_destination_address" db:"delivery_destination_address"`
-	CreatedAt                  time.Time `json:"created_at,omitempty" db:"created_at"`
-	UpdatedAt                  time.Time `json:"updated_at,omitempty" db:"updated_at"`
+	OrderID                    uuid.UUID         `json:"order_id" db:"order_id"`
+	UserID                     uuid.UUID         `json:"user_id" db:"user_id"`
+	BrandID                    uuid.UUID         `json:"brand_id" db:"brand_id"`
+	Item                       OrderItem         `json:"item" db:"item"`
+	Quantity                   uint64            `json:"quantity" db:"quantity"`
+	TotalPrice                 float64           `json:"total_price" db:"total_price"`
+	Status                     string            `json:"status" db:"status"`
+	DeliverySourceAddress      string            `json:"delivery_source_address" db:"delivery_source_address"`
+	DeliveryDestinationAddress string            `json:"delivery_destination_address" db:"delivery_destination_address"`
+	RefundedQuantity           uint64            `json:"refunded_quantity" db:"refunded_quantity"`
+	RefundedAmount             float64           `json:"refunded_amount" db:"refunded_amount"`
+	DiscountTotal              float64           `json:"discount_total" db:"discount_total"`
+	AppliedPromotions          AppliedPromotions `json:"applied_promotions" db:"applied_promotions"`
+	FreeShipping               bool              `json:"free_shipping" db:"free_shipping"`
+	TaxTotal                   float64           `json:"tax_total" db:"tax_total"`
+	TaxLines                   TaxLines          `json:"tax_lines" db:"tax_lines"`
+	DeliveryFee                float64           `json:"delivery_fee" db:"delivery_fee"`
+	DeliveryDistance           float64           `json:"delivery_distance" db:"delivery_distance"`
+	DeliveryAddress            *OrderAddress     `json:"delivery_address,omitempty" db:"delivery_address"`
+	LocationID                 *uuid.UUID        `json:"location_id,omitempty" db:"location_id"`
+	DeliveredAt                *time.Time        `json:"delivered_at,omitempty" db:"delivered_at"`
+	Version                    int               `json:"version" db:"version"`
+	DeletedAt                  *time.Time        `json:"deleted_at,omitempty" db:"deleted_at"`
+	CreatedAt                  time.Time         `json:"created_at,omitempty" db:"created_at"`
+	UpdatedAt                  time.Time         `json:"updated_at,omitempty" db:"updated_at"`
+}
+
+// CanTransitionTo reports whether the order may move from its current status to next
+// TaxRegion region code the order is taxed in, the region of its address book address or the text ending its
+// delivery destination address otherwise
+func (o *Order) TaxRegion() string {
+	if o.DeliveryAddress != nil {
+		address := UserAddress(*o.DeliveryAddress)
+		return address.TaxRegion()
+	}
+	return tax.RegionFromAddress(o.DeliveryDestinationAddress)
+}
+
+func (o *Order) CanTransitionTo(next string) bool {
+	for _, s := range orderStatusTransitions[o.Status] {
+		if s == next {
+			return true
+		}
+	}
+	return false
+}
+
+// RefundableQuantity quantity not refunded yet
+func (o *Order) RefundableQuantity() uint64 {
+	return o.Quantity - o.RefundedQuantity
+}
+
+// NetTotal total price less refunded amount
+func (o *Order) NetTotal() float64 {
+	return o.TotalPrice - o.RefundedAmount
+}
+
+// RefundAmount money given back for quantity more units. The total paid for the goods, after discount and tax but
+// without the delivery fee, is shared evenly by the units. Refunding the last units gives back the rest of the net
+// total, delivery fee included, and no refund goes over the net total
+func (o *Order) RefundAmount(quantity uint64) float64 {
+	if o.Quantity == 0 || quantity >= o.RefundableQuantity() {
+		return o.NetTotal()
+	}
+
+	amount := RoundAmount((o.TotalPrice - o.DeliveryFee) * float64(quantity) / float64(o.Quantity))
+	return math.Max(0, math.Min(amount, o.NetTotal()))
 }
 
 type OrderItem Product
@@ -0,0 +1,98 @@
+package models
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/pkg/constants"
+	"github.com/dinorain/kalobranded/pkg/utils"
+)
+
+// OrderSorts sort keys of order lists, newest first by default
+var OrderSorts = utils.Sorts{
+	Columns: map[string]string{
+		"created_at":  "created_at",
+		"updated_at":  "updated_at",
+		"total_price": "total_price",
+		"quantity":    "quantity",
+		"status":      "status",
+	},
+	Default: "-created_at",
+	Unique:  "order_id",
+}
+
+// SortValue value of the order in a column of OrderSorts, or its unique column, for the cursors of order lists
+func (o *Order) SortValue(column string) interface{} {
+	switch column {
+	case "created_at":
+		return o.CreatedAt
+	case "updated_at":
+		return o.UpdatedAt
+	case "total_price":
+		return o.TotalPrice
+	case "quantity":
+		return o.Quantity
+	case "status":
+		return o.Status
+	case "order_id":
+		return o.OrderID
+	default:
+		return nil
+	}
+}
+
+// OrderFilter orders of an order list, unset fields match every order. Orders match when created from CreatedFrom up
+// to, but excluding, CreatedTo and with a total between MinTotal and MaxTotal included
+type OrderFilter struct {
+	UserID      *uuid.UUID
+	BrandID     *uuid.UUID
+	ProductID   *uuid.UUID
+	Statuses    []string
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	MinTotal    *float64
+	MaxTotal    *float64
+}
+
+// ParseOrderFilter order filter of the query parameters of an order list
+func ParseOrderFilter(queryParam url.Values) (*OrderFilter, error) {
+	filter := &OrderFilter{}
+	if statuses := queryParam.Get(constants.Status); statuses != "" {
+		for _, status := range strings.Split(statuses, ",") {
+			status = strings.ToLower(strings.TrimSpace(status))
+			if !IsOrderStatus(status) {
+				return nil, fmt.Errorf("invalid status: %q", status)
+			}
+			filter.Statuses = append(filter.Statuses, status)
+		}
+	}
+
+	var err error
+	if filter.UserID, err = utils.ParseQueryUUID(queryParam, constants.UserID); err != nil {
+		return nil, err
+	}
+	if filter.BrandID, err = utils.ParseQueryUUID(queryParam, constants.BrandID); err != nil {
+		return nil, err
+	}
+	if filter.ProductID, err = utils.ParseQueryUUID(queryParam, constants.ProductID); err != nil {
+		return nil, err
+	}
+	if filter.CreatedFrom, err = utils.ParseQueryTime(queryParam, constants.CreatedFrom, false); err != nil {
+		return nil, err
+	}
+	if filter.CreatedTo, err = utils.ParseQueryTime(queryParam, constants.CreatedTo, true); err != nil {
+		return nil, err
+	}
+	if filter.MinTotal, err = utils.ParseQueryAmount(queryParam, constants.MinTotal); err != nil {
+		return nil, err
+	}
+	if filter.MaxTotal, err = utils.ParseQueryAmount(queryParam, constants.MaxTotal); err != nil {
+		return nil, err
+	}
+
+	return filter, nil
+}
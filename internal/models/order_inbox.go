@@ -0,0 +1,34 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+const (
+	OrderBulkAccept      = "accept"
+	OrderBulkReject      = "reject"
+	OrderBulkMarkShipped = "mark_shipped"
+)
+
+// OrderBulkActionStatuses status each bulk action moves orders to
+var OrderBulkActionStatuses = map[string]string{
+	OrderBulkAccept:      OrderStatusAccepted,
+	OrderBulkReject:      OrderStatusRejected,
+	OrderBulkMarkShipped: OrderStatusShipped,
+}
+
+// OrderBulkItemResult outcome of a bulk action on one order, Error is set on the orders that prevented the action
+type OrderBulkItemResult struct {
+	OrderID        uuid.UUID `json:"order_id"`
+	PreviousStatus string    `json:"previous_status,omitempty"`
+	Status         string    `json:"status,omitempty"`
+	Version        int       `json:"version,omitempty"`
+	Error          string    `json:"error,omitempty"`
+}
+
+// OrderBulkResult outcome of a bulk action, applied to every order or to none of them
+type OrderBulkResult struct {
+	Action  string                `json:"action"`
+	Applied bool                  `json:"applied"`
+	Results []OrderBulkItemResult `json:"results"`
+}
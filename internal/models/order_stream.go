@@ -0,0 +1,73 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OrderUpdate order change pushed to order stream subscribers, ID is the id of the update in the order stream buffer
+type OrderUpdate struct {
+	ID         string    `json:"id"`
+	Event      string    `json:"event"`
+	OrderID    uuid.UUID `json:"order_id"`
+	UserID     uuid.UUID `json:"user_id"`
+	BrandID    uuid.UUID `json:"brand_id"`
+	From       string    `json:"from,omitempty"`
+	Status     string    `json:"status"`
+	Version    int       `json:"version"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// OrderStreamFilter orders a subscriber gets updates of, nil fields match every order
+type OrderStreamFilter struct {
+	UserID  *uuid.UUID
+	BrandID *uuid.UUID
+}
+
+// Matches whether update is about an order of the filter
+func (f OrderStreamFilter) Matches(update *OrderUpdate) bool {
+	if f.UserID != nil && *f.UserID != update.UserID {
+		return false
+	}
+	if f.BrandID != nil && *f.BrandID != update.BrandID {
+		return false
+	}
+	return true
+}
+
+// IsOrderUpdateID whether id is an order stream buffer id, a <milliseconds>-<sequence> Redis stream entry id
+func IsOrderUpdateID(id string) bool {
+	_, _, ok := splitOrderUpdateID(id)
+	return ok
+}
+
+// CompareOrderUpdateIDs compare the order stream buffer ids a and b, -1 when a comes first, 1 when b does and 0 when
+// they are equal. Malformed ids come before every other id
+func CompareOrderUpdateIDs(a string, b string) int {
+	aMs, aSeq, _ := splitOrderUpdateID(a)
+	bMs, bSeq, _ := splitOrderUpdateID(b)
+
+	switch {
+	case aMs < bMs || (aMs == bMs && aSeq < bSeq):
+		return -1
+	case aMs > bMs || aSeq > bSeq:
+		return 1
+	}
+	return 0
+}
+
+func splitOrderUpdateID(id string) (ms uint64, seq uint64, ok bool) {
+	parts := strings.SplitN(id, "-", 2)
+	if len(parts) != 2 {
+		return 0, 0, false
+	}
+	ms, msErr := strconv.ParseUint(parts[0], 10, 64)
+	seq, seqErr := strconv.ParseUint(parts[1], 10, 64)
+	if msErr != nil || seqErr != nil {
+		return 0, 0, false
+	}
+	return ms, seq, true
+}
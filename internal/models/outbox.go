@@ -0,0 +1,98 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	AggregateOrder   = "order"
+	AggregateProduct = "product"
+	AggregateUser    = "user"
+
+	EventOrderCreated       = "order.created"
+	EventOrderStatusChanged = "order.status_changed"
+	EventProductCreated     = "product.created"
+	EventProductUpdated     = "product.updated"
+	EventUserRegistered     = "user.registered"
+)
+
+// OutboxEvent domain event written in the transaction of the change it reports, published by the outbox relay
+type OutboxEvent struct {
+	EventID       uuid.UUID    `json:"event_id" db:"event_id"`
+	AggregateType string       `json:"aggregate_type" db:"aggregate_type"`
+	AggregateID   uuid.UUID    `json:"aggregate_id" db:"aggregate_id"`
+	EventType     string       `json:"event_type" db:"event_type"`
+	Payload       EventPayload `json:"payload" db:"payload"`
+	Attempts      int          `json:"attempts" db:"attempts"`
+	CreatedAt     time.Time    `json:"created_at" db:"created_at"`
+	PublishedAt   *time.Time   `json:"published_at,omitempty" db:"published_at"`
+}
+
+// OrderStatusChange payload of EventOrderStatusChanged
+type OrderStatusChange struct {
+	OrderID uuid.UUID `json:"order_id"`
+	UserID  uuid.UUID `json:"user_id"`
+	BrandID uuid.UUID `json:"brand_id"`
+	From    string    `json:"from"`
+	To      string    `json:"to"`
+	Version int       `json:"version"`
+}
+
+// NewOutboxEvent event of eventType about the aggregate, payload is stored as JSON
+func NewOutboxEvent(aggregateType string, aggregateID uuid.UUID, eventType string, payload interface{}) (*OutboxEvent, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OutboxEvent{
+		EventID:       uuid.New(),
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		EventType:     eventType,
+		Payload:       data,
+	}, nil
+}
+
+// EventPayload JSON document carried by an outbox event
+type EventPayload json.RawMessage
+
+func (p *EventPayload) Scan(value interface{}) error {
+	val, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("unable to scan")
+	}
+	*p = append(EventPayload(nil), val...)
+	return nil
+}
+
+func (p EventPayload) Value() (driver.Value, error) {
+	if len(p) == 0 {
+		return []byte("{}"), nil
+	}
+	return []byte(p), nil
+}
+
+func (p EventPayload) MarshalJSON() ([]byte, error) {
+	if len(p) == 0 {
+		return []byte("null"), nil
+	}
+	return p, nil
+}
+
+func (p *EventPayload) UnmarshalJSON(data []byte) error {
+	*p = append(EventPayload(nil), data...)
+	return nil
+}
+
+// StreamMessage outbox event read from a stream, Deliveries counts how often it was handed to a consumer
+type StreamMessage struct {
+	ID         string
+	Event      OutboxEvent
+	Deliveries int64
+}
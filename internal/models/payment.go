@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	PaymentStatusPending    = "pending"
+	PaymentStatusAuthorized = "authorized"
+	PaymentStatusCaptured   = "captured"
+	PaymentStatusFailed     = "failed"
+)
+
+// Payment model, one attempt to pay an order through a payment provider
+type Payment struct {
+	PaymentID         uuid.UUID `json:"payment_id" db:"payment_id"`
+	OrderID           uuid.UUID `json:"order_id" db:"order_id"`
+	Provider          string    `json:"provider" db:"provider"`
+	ProviderPaymentID string    `json:"provider_payment_id" db:"provider_payment_id"`
+	Amount            float64   `json:"amount" db:"amount"`
+	Currency          string    `json:"currency" db:"currency"`
+	Status            string    `json:"status" db:"status"`
+	ClientSecret      string    `json:"-" db:"-"`
+	CreatedAt         time.Time `json:"created_at,omitempty" db:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at,omitempty" db:"updated_at"`
+}
@@ -1,25 +1,74 @@
 package models
 
 import (
+	"net/url"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/pkg/constants"
+	"github.com/dinorain/kalobranded/pkg/utils"
 )
 
 // Product model
 type Product struct {
-	ProductID   uuid.UUID `json:"product_id" db:"product_id"`
-	Name        string    `json:"name" db:"name"`
-	Description string    `json:"description" db:"description"`
-	Price       float64   `json:"price" db:"price"`
-	BrandID    uuid.UUID `json:"brand_id" db:"brand_id"`
-	CreatedAt   time.Time `json:"created_at,omitempty" db:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at,omitempty" db:"updated_at"`
+	ProductID   uuid.UUID  `json:"product_id" db:"product_id"`
+	Name        string     `json:"name" db:"name"`
+	Description string     `json:"description" db:"description"`
+	Price       float64    `json:"price" db:"price"`
+	BrandID     uuid.UUID  `json:"brand_id" db:"brand_id"`
+	Stock       uint64     `json:"stock,omitempty" db:"stock"`
+	Category    string     `json:"category,omitempty" db:"category"`
+	Weight      uint64     `json:"weight" db:"weight"`
+	Version     int        `json:"version" db:"version"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+	CreatedAt   time.Time  `json:"created_at,omitempty" db:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at,omitempty" db:"updated_at"`
+}
+
+// ProductSorts sort keys of product lists, newest first by default
+var ProductSorts = utils.Sorts{
+	Columns: map[string]string{
+		"created_at": "created_at",
+		"name":       "name",
+		"price":      "price",
+		"stock":      "stock",
+	},
+	Default: "-created_at",
+	Unique:  "product_id",
+}
+
+// ProductFilter products of a product list, unset fields match every product. Prices between MinPrice and MaxPrice
+// included match
+type ProductFilter struct {
+	BrandID  *uuid.UUID
+	Category string
+	MinPrice *float64
+	MaxPrice *float64
+}
+
+// ParseProductFilter product filter of the query parameters of a product list
+func ParseProductFilter(queryParam url.Values) (*ProductFilter, error) {
+	filter := &ProductFilter{Category: strings.ToLower(strings.TrimSpace(queryParam.Get(constants.Category)))}
+
+	var err error
+	if filter.BrandID, err = utils.ParseQueryUUID(queryParam, constants.BrandID); err != nil {
+		return nil, err
+	}
+	if filter.MinPrice, err = utils.ParseQueryAmount(queryParam, constants.MinPrice); err != nil {
+		return nil, err
+	}
+	if filter.MaxPrice, err = utils.ParseQueryAmount(queryParam, constants.MaxPrice); err != nil {
+		return nil, err
+	}
+
+	return filter, nil
 }
 
 func (p *Product) PrepareCreate() error {
 	p.Name = strings.TrimSpace(p.Name)
 	p.Description = strings.TrimSpace(p.Description)
+	p.Category = strings.ToLower(strings.TrimSpace(p.Category))
 	return nil
 }
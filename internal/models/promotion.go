@@ -0,0 +1,188 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	PromotionTypePercentage   = "percentage"
+	PromotionTypeFixedAmount  = "fixed_amount"
+	PromotionTypeBuyXGetY     = "buy_x_get_y"
+	PromotionTypeFreeShipping = "free_shipping"
+)
+
+var (
+	// ErrInvalidPromotion promotion rule missing or out of range values for its type
+	ErrInvalidPromotion = errors.New("invalid promotion")
+	// ErrPromotionNotApplicable coupon code unknown, expired or not covering the ordered product
+	ErrPromotionNotApplicable = errors.New("promotion not applicable")
+	// ErrPromotionUsageLimitReached promotion used up globally or by the ordering user
+	ErrPromotionUsageLimitReached = errors.New("promotion usage limit reached")
+)
+
+// Promotion model, a discount rule applied automatically or with its coupon code when set. A rule covers
+// order items matching every scope set among brand, product and category, all items when none is set
+type Promotion struct {
+	PromotionID  uuid.UUID  `json:"promotion_id" db:"promotion_id"`
+	Code         *string    `json:"code,omitempty" db:"code"`
+	Name         string     `json:"name" db:"name"`
+	Type         string     `json:"type" db:"type"`
+	Value        float64    `json:"value" db:"value"`
+	BuyQuantity  uint64     `json:"buy_quantity" db:"buy_quantity"`
+	GetQuantity  uint64     `json:"get_quantity" db:"get_quantity"`
+	BrandID      *uuid.UUID `json:"brand_id,omitempty" db:"brand_id"`
+	ProductID    *uuid.UUID `json:"product_id,omitempty" db:"product_id"`
+	Category     *string    `json:"category,omitempty" db:"category"`
+	StartsAt     time.Time  `json:"starts_at" db:"starts_at"`
+	EndsAt       *time.Time `json:"ends_at,omitempty" db:"ends_at"`
+	UsageLimit   *int       `json:"usage_limit,omitempty" db:"usage_limit"`
+	PerUserLimit *int       `json:"per_user_limit,omitempty" db:"per_user_limit"`
+	UsageCount   int        `json:"usage_count" db:"usage_count"`
+	Stackable    bool       `json:"stackable" db:"stackable"`
+	Active       bool       `json:"active" db:"active"`
+	Version      int        `json:"version" db:"version"`
+	DeletedAt    *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+	CreatedAt    time.Time  `json:"created_at,omitempty" db:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at,omitempty" db:"updated_at"`
+}
+
+// PrepareCreate normalize code and category and check the rule values of the promotion type
+func (p *Promotion) PrepareCreate() error {
+	p.Name = strings.TrimSpace(p.Name)
+	if p.Code != nil {
+		code := strings.ToUpper(strings.TrimSpace(*p.Code))
+		p.Code = &code
+		if code == "" {
+			p.Code = nil
+		}
+	}
+	if p.Category != nil {
+		category := strings.ToLower(strings.TrimSpace(*p.Category))
+		p.Category = &category
+		if category == "" {
+			p.Category = nil
+		}
+	}
+	if p.StartsAt.IsZero() {
+		p.StartsAt = time.Now().UTC()
+	}
+
+	switch p.Type {
+	case PromotionTypePercentage:
+		if p.Value <= 0 || p.Value > 100 {
+			return fmt.Errorf("%w: percentage value must be within (0, 100]", ErrInvalidPromotion)
+		}
+	case PromotionTypeFixedAmount:
+		if p.Value <= 0 {
+			return fmt.Errorf("%w: fixed amount value must be positive", ErrInvalidPromotion)
+		}
+	case PromotionTypeBuyXGetY:
+		if p.BuyQuantity == 0 || p.GetQuantity == 0 {
+			return fmt.Errorf("%w: buy_x_get_y needs buy_quantity and get_quantity", ErrInvalidPromotion)
+		}
+	case PromotionTypeFreeShipping:
+	default:
+		return fmt.Errorf("%w: unknown type %q", ErrInvalidPromotion, p.Type)
+	}
+
+	if p.EndsAt != nil && !p.EndsAt.After(p.StartsAt) {
+		return fmt.Errorf("%w: ends_at must be after starts_at", ErrInvalidPromotion)
+	}
+
+	return nil
+}
+
+// IsCoupon reports whether the promotion only applies when its code is given
+func (p *Promotion) IsCoupon() bool {
+	return p.Code != nil
+}
+
+// IsRunningAt reports whether the promotion is active and within its validity window at t
+func (p *Promotion) IsRunningAt(t time.Time) bool {
+	if !p.Active || p.DeletedAt != nil || t.Before(p.StartsAt) {
+		return false
+	}
+	return p.EndsAt == nil || t.Before(*p.EndsAt)
+}
+
+// Covers reports whether item falls within the promotion scope
+func (p *Promotion) Covers(item *OrderItem) bool {
+	if p.BrandID != nil && *p.BrandID != item.BrandID {
+		return false
+	}
+	if p.ProductID != nil && *p.ProductID != item.ProductID {
+		return false
+	}
+	if p.Category != nil && *p.Category != item.Category {
+		return false
+	}
+	return true
+}
+
+// IsUsedUp reports whether the global usage limit is reached
+func (p *Promotion) IsUsedUp() bool {
+	return p.UsageLimit != nil && p.UsageCount >= *p.UsageLimit
+}
+
+// Discount amount taken off quantity units at price, never more than their subtotal
+func (p *Promotion) Discount(price float64, quantity uint64) float64 {
+	subtotal := price * float64(quantity)
+
+	var discount float64
+	switch p.Type {
+	case PromotionTypePercentage:
+		discount = subtotal * p.Value / 100
+	case PromotionTypeFixedAmount:
+		discount = p.Value
+	case PromotionTypeBuyXGetY:
+		free := quantity / (p.BuyQuantity + p.GetQuantity) * p.GetQuantity
+		discount = price * float64(free)
+	}
+
+	return RoundAmount(math.Min(discount, subtotal))
+}
+
+// AppliedPromotion promotion applied to an order with the discount it gave
+type AppliedPromotion struct {
+	PromotionID uuid.UUID `json:"promotion_id"`
+	Code        string    `json:"code,omitempty"`
+	Name        string    `json:"name"`
+	Type        string    `json:"type"`
+	Discount    float64   `json:"discount"`
+}
+
+type AppliedPromotions []AppliedPromotion
+
+func (a *AppliedPromotions) Scan(value interface{}) error {
+	val, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("unable to scan")
+	}
+	var applied AppliedPromotions
+	if err := json.Unmarshal(val, &applied); err != nil {
+		return fmt.Errorf("json.Unmarshal %v", value)
+	}
+	*a = applied
+	return nil
+}
+
+func (a AppliedPromotions) Value() (driver.Value, error) {
+	if a == nil {
+		a = AppliedPromotions{}
+	}
+	valueJson, _ := json.Marshal(a)
+	return valueJson, nil
+}
+
+// RoundAmount round amount to cents
+func RoundAmount(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
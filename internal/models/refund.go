@@ -0,0 +1,51 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	RefundReasonDamaged         = "damaged"
+	RefundReasonWrongItem       = "wrong_item"
+	RefundReasonNotReceived     = "not_received"
+	RefundReasonCustomerRequest = "customer_request"
+	RefundReasonOther           = "other"
+)
+
+const (
+	RefundStatusPending   = "pending"
+	RefundStatusSucceeded = "succeeded"
+	RefundStatusFailed    = "failed"
+)
+
+// Refund model, money given back for part or all of an order quantity, or for the goods of the return ReturnID. A
+// refund is pending from the moment its quantity is taken off the order until the provider has made it
+type Refund struct {
+	RefundID         uuid.UUID  `json:"refund_id" db:"refund_id"`
+	OrderID          uuid.UUID  `json:"order_id" db:"order_id"`
+	Quantity         uint64     `json:"quantity" db:"quantity"`
+	Amount           float64    `json:"amount" db:"amount"`
+	Reason           string     `json:"reason" db:"reason"`
+	Note             string     `json:"note" db:"note"`
+	Restock          bool       `json:"restock" db:"restock"`
+	RefundedBy       uuid.UUID  `json:"refunded_by" db:"refunded_by"`
+	ProviderRefundID string     `json:"provider_refund_id" db:"provider_refund_id"`
+	Status           string     `json:"status" db:"status"`
+	ReturnID         *uuid.UUID `json:"return_id,omitempty" db:"return_id"`
+	CreatedAt        time.Time  `json:"created_at,omitempty" db:"created_at"`
+}
+
+var (
+	// ErrRefundExceedsQuantity refund asks for more than the order quantity not refunded yet
+	ErrRefundExceedsQuantity = errors.New("refund exceeds refundable quantity")
+	// ErrRefundNotPending refund was already completed or failed
+	ErrRefundNotPending = errors.New("refund is not pending")
+)
+
+// RefundJob payload of the job completing a pending refund
+type RefundJob struct {
+	RefundID uuid.UUID `json:"refund_id"`
+}
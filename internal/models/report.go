@@ -0,0 +1,88 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/pkg/utils"
+)
+
+const (
+	SalesIntervalDay   = "day"
+	SalesIntervalWeek  = "week"
+	SalesIntervalMonth = "month"
+)
+
+// SalesIntervals periods sales are reported by
+var SalesIntervals = []string{SalesIntervalDay, SalesIntervalWeek, SalesIntervalMonth}
+
+// IsSalesInterval whether interval is one of SalesIntervals
+func IsSalesInterval(interval string) bool {
+	for _, i := range SalesIntervals {
+		if i == interval {
+			return true
+		}
+	}
+	return false
+}
+
+// SalesStatuses statuses of the orders counted as sales, pending orders are not paid yet and rejected or refunded
+// ones are given back
+var SalesStatuses = []string{OrderStatusPaid, OrderStatusAccepted, OrderStatusShipped, OrderStatusDelivered}
+
+// ProductSalesSorts sort keys of the products of a sales report, most revenue first by default
+var ProductSalesSorts = utils.Sorts{
+	Columns: map[string]string{
+		"revenue": "revenue",
+		"units":   "units",
+		"orders":  "orders",
+	},
+	Default: "-revenue",
+	Unique:  "product_id",
+}
+
+// SalesFilter sales of the brand, of every brand when BrandID is nil, on the UTC days from From up to, but
+// excluding, To
+type SalesFilter struct {
+	BrandID *uuid.UUID
+	From    time.Time
+	To      time.Time
+}
+
+// SalesPeriod sales of the day, week or month starting on Period
+type SalesPeriod struct {
+	Period            time.Time `json:"period" db:"period"`
+	Orders            int64     `json:"orders" db:"orders"`
+	Units             int64     `json:"units" db:"units"`
+	Revenue           float64   `json:"revenue" db:"revenue"`
+	AverageOrderValue float64   `json:"average_order_value" db:"-"`
+}
+
+// ProductSales sales of a product
+type ProductSales struct {
+	ProductID   uuid.UUID `json:"product_id" db:"product_id"`
+	ProductName string    `json:"product_name" db:"product_name"`
+	Orders      int64     `json:"orders" db:"orders"`
+	Units       int64     `json:"units" db:"units"`
+	Revenue     float64   `json:"revenue" db:"revenue"`
+}
+
+// SalesSummary sales totals, repeat buyers being the buyers with more than one order
+type SalesSummary struct {
+	Orders            int64   `json:"orders" db:"orders"`
+	Units             int64   `json:"units" db:"units"`
+	Revenue           float64 `json:"revenue" db:"revenue"`
+	AverageOrderValue float64 `json:"average_order_value" db:"-"`
+	Buyers            int64   `json:"buyers" db:"buyers"`
+	RepeatBuyers      int64   `json:"repeat_buyers" db:"repeat_buyers"`
+	RepeatBuyerRate   float64 `json:"repeat_buyer_rate" db:"-"`
+}
+
+// AverageOrderValue revenue per order, 0 without orders
+func AverageOrderValue(revenue float64, orders int64) float64 {
+	if orders == 0 {
+		return 0
+	}
+	return revenue / float64(orders)
+}
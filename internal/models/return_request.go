@@ -0,0 +1,90 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ReturnStatusRequested = "requested"
+	ReturnStatusApproved  = "approved"
+	ReturnStatusReceived  = "received"
+	ReturnStatusRefunded  = "refunded"
+	ReturnStatusRejected  = "rejected"
+)
+
+var (
+	// ErrReturnWindowClosed order is past the return window of its brand
+	ErrReturnWindowClosed = errors.New("return window closed")
+	// ErrReturnNotReturnable order is not at a status goods can be returned from
+	ErrReturnNotReturnable = errors.New("order is not returnable")
+)
+
+// returnStatusTransitions allowed next statuses, goods are refunded once back at the brand
+var returnStatusTransitions = map[string][]string{
+	ReturnStatusRequested: {ReturnStatusApproved, ReturnStatusRejected},
+	ReturnStatusApproved:  {ReturnStatusReceived, ReturnStatusRejected},
+	ReturnStatusReceived:  {ReturnStatusRefunded, ReturnStatusRejected},
+}
+
+// ReturnRequest model, buyer request to send back part or all of an order quantity
+type ReturnRequest struct {
+	ReturnID        uuid.UUID    `json:"return_id" db:"return_id"`
+	OrderID         uuid.UUID    `json:"order_id" db:"order_id"`
+	UserID          uuid.UUID    `json:"user_id" db:"user_id"`
+	BrandID         uuid.UUID    `json:"brand_id" db:"brand_id"`
+	Quantity        uint64       `json:"quantity" db:"quantity"`
+	Reason          string       `json:"reason" db:"reason"`
+	Note            string       `json:"note" db:"note"`
+	PhotoURLs       ReturnPhotos `json:"photo_urls" db:"photo_urls"`
+	Status          string       `json:"status" db:"status"`
+	LabelAddress    string       `json:"label_address" db:"label_address"`
+	RejectionReason string       `json:"rejection_reason" db:"rejection_reason"`
+	RefundID        *uuid.UUID   `json:"refund_id,omitempty" db:"refund_id"`
+	Version         int          `json:"version" db:"version"`
+	CreatedAt       time.Time    `json:"created_at,omitempty" db:"created_at"`
+	UpdatedAt       time.Time    `json:"updated_at,omitempty" db:"updated_at"`
+}
+
+// CanTransitionTo reports whether the return may move from its current status to next
+func (r *ReturnRequest) CanTransitionTo(next string) bool {
+	for _, s := range returnStatusTransitions[r.Status] {
+		if s == next {
+			return true
+		}
+	}
+	return false
+}
+
+// IsOpen reports whether the return still holds part of the order quantity
+func (r *ReturnRequest) IsOpen() bool {
+	return r.Status != ReturnStatusRefunded && r.Status != ReturnStatusRejected
+}
+
+type ReturnPhotos []string
+
+func (p *ReturnPhotos) Scan(value interface{}) error {
+	val, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("unable to scan")
+	}
+	var photos ReturnPhotos
+	if err := json.Unmarshal(val, &photos); err != nil {
+		return fmt.Errorf("json.Unmarshal %v", value)
+	}
+	*p = photos
+	return nil
+}
+
+func (p ReturnPhotos) Value() (driver.Value, error) {
+	if p == nil {
+		p = ReturnPhotos{}
+	}
+	valueJson, _ := json.Marshal(p)
+	return valueJson, nil
+}
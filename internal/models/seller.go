@@ -5,16 +5,42 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/pkg/geo"
+	"github.com/dinorain/kalobranded/pkg/utils"
 )
 
+// DefaultReturnWindowDays return window of brands registered without one
+const DefaultReturnWindowDays = 30
+
 // Brand model
 type Brand struct {
-	BrandID       uuid.UUID `json:"brand_id" db:"brand_id"`
-	BrandName     string    `json:"brand_name" db:"brand_name"`
-	PickupAddress string    `json:"pickup_address" db:"pickup_address"`
-	Logo          *string   `json:"logo" db:"logo"`
-	CreatedAt     time.Time `json:"created_at,omitempty" db:"created_at"`
-	UpdatedAt     time.Time `json:"updated_at,omitempty" db:"updated_at"`
+	BrandID          uuid.UUID  `json:"brand_id" db:"brand_id"`
+	BrandName        string     `json:"brand_name" db:"brand_name"`
+	PickupAddress    string     `json:"pickup_address" db:"pickup_address"`
+	PickupLatitude   *float64   `json:"pickup_latitude,omitempty" db:"pickup_latitude"`
+	PickupLongitude  *float64   `json:"pickup_longitude,omitempty" db:"pickup_longitude"`
+	Logo             *string    `json:"logo" db:"logo"`
+	ReturnWindowDays int        `json:"return_window_days" db:"return_window_days"`
+	Version          int        `json:"version" db:"version"`
+	DeletedAt        *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+	CreatedAt        time.Time  `json:"created_at,omitempty" db:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at,omitempty" db:"updated_at"`
+}
+
+// BrandSorts sort keys of brand lists, newest first by default
+var BrandSorts = utils.Sorts{
+	Columns: map[string]string{
+		"created_at": "created_at",
+		"brand_name": "brand_name",
+	},
+	Default: "-created_at",
+	Unique:  "brand_id",
+}
+
+// BrandFilter brands of a brand list, Name matches brand names containing it regardless of case
+type BrandFilter struct {
+	Name string
 }
 
 func (s *Brand) PrepareCreate() error {
@@ -23,3 +49,8 @@ func (s *Brand) PrepareCreate() error {
 
 	return nil
 }
+
+// PickupPoint coordinates of the pickup address, nil when they are not set
+func (s *Brand) PickupPoint() *geo.Point {
+	return geo.NewPoint(s.PickupLatitude, s.PickupLongitude)
+}
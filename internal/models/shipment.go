@@ -0,0 +1,123 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ShipmentStatusLabelCreated   = "label_created"
+	ShipmentStatusPickedUp       = "picked_up"
+	ShipmentStatusInTransit      = "in_transit"
+	ShipmentStatusOutForDelivery = "out_for_delivery"
+	ShipmentStatusDelivered      = "delivered"
+	ShipmentStatusFailed         = "failed"
+)
+
+var (
+	// ErrShipmentExists order already has a shipment that has not failed
+	ErrShipmentExists = errors.New("order already has an open shipment")
+	// ErrNotShippable order is not at a status it can be shipped from
+	ErrNotShippable = errors.New("order is not shippable")
+)
+
+// shipmentStatusRanks progress of each status, a shipment only moves forward and stops once delivered or failed
+var shipmentStatusRanks = map[string]int{
+	ShipmentStatusLabelCreated:   1,
+	ShipmentStatusPickedUp:       2,
+	ShipmentStatusInTransit:      3,
+	ShipmentStatusOutForDelivery: 4,
+	ShipmentStatusDelivered:      5,
+	ShipmentStatusFailed:         5,
+}
+
+// Shipment model, parcel of an order handed to a courier
+type Shipment struct {
+	ShipmentID     uuid.UUID      `json:"shipment_id" db:"shipment_id"`
+	OrderID        uuid.UUID      `json:"order_id" db:"order_id"`
+	Carrier        string         `json:"carrier" db:"carrier"`
+	Service        string         `json:"service" db:"service"`
+	TrackingNumber string         `json:"tracking_number" db:"tracking_number"`
+	LabelURL       string         `json:"label_url" db:"label_url"`
+	Rate           float64        `json:"rate" db:"rate"`
+	Status         string         `json:"status" db:"status"`
+	Events         TrackingEvents `json:"events" db:"events"`
+	Version        int            `json:"version" db:"version"`
+	CreatedAt      time.Time      `json:"created_at,omitempty" db:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at,omitempty" db:"updated_at"`
+}
+
+// IsOpen reports whether the shipment has not failed
+func (s *Shipment) IsOpen() bool {
+	return s.Status != ShipmentStatusFailed
+}
+
+// HasEvent reports whether the courier event of id was already recorded
+func (s *Shipment) HasEvent(id string) bool {
+	for _, e := range s.Events {
+		if e.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// CanAdvanceTo reports whether next moves the shipment forward, repeated scans at the same status such as
+// several in transit hubs are allowed
+func (s *Shipment) CanAdvanceTo(next string) bool {
+	from, to := shipmentStatusRanks[s.Status], shipmentStatusRanks[next]
+	if to == 0 || s.Status == ShipmentStatusDelivered || s.Status == ShipmentStatusFailed {
+		return false
+	}
+	return to >= from
+}
+
+// ShipmentOrderStatus order status reached when its shipment gets to status, empty when the order is left as is
+func ShipmentOrderStatus(status string) string {
+	switch status {
+	case ShipmentStatusPickedUp, ShipmentStatusInTransit, ShipmentStatusOutForDelivery:
+		return OrderStatusShipped
+	case ShipmentStatusDelivered:
+		return OrderStatusDelivered
+	default:
+		return ""
+	}
+}
+
+// TrackingEvent courier scan of a shipment
+type TrackingEvent struct {
+	ID          string    `json:"id"`
+	Status      string    `json:"status"`
+	Description string    `json:"description"`
+	Location    string    `json:"location"`
+	OccurredAt  time.Time `json:"occurred_at"`
+}
+
+// TrackingEvents scans of a shipment, oldest first
+type TrackingEvents []TrackingEvent
+
+func (e *TrackingEvents) Scan(value interface{}) error {
+	val, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("unable to scan")
+	}
+	var events TrackingEvents
+	if err := json.Unmarshal(val, &events); err != nil {
+		return fmt.Errorf("json.Unmarshal %v", value)
+	}
+	*e = events
+	return nil
+}
+
+func (e TrackingEvents) Value() (driver.Value, error) {
+	if e == nil {
+		e = TrackingEvents{}
+	}
+	valueJson, _ := json.Marshal(e)
+	return valueJson, nil
+}
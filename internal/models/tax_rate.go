@@ -0,0 +1,104 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/pkg/tax"
+)
+
+// ErrTaxRateExists region has a rate table already
+var ErrTaxRateExists = errors.New("tax rate of region already exists")
+
+// TaxRate model, the rate table of a tax jurisdiction. Orders are taxed with the table of their delivery region
+type TaxRate struct {
+	TaxRateID        uuid.UUID        `json:"tax_rate_id" db:"tax_rate_id"`
+	Region           string           `json:"region" db:"region"`
+	Name             string           `json:"name" db:"name"`
+	Rate             float64          `json:"rate" db:"rate"`
+	PricesIncludeTax bool             `json:"prices_include_tax" db:"prices_include_tax"`
+	CategoryRates    TaxCategoryRates `json:"category_rates" db:"category_rates"`
+	Version          int              `json:"version" db:"version"`
+	CreatedAt        time.Time        `json:"created_at,omitempty" db:"created_at"`
+	UpdatedAt        time.Time        `json:"updated_at,omitempty" db:"updated_at"`
+}
+
+// PrepareCreate normalize region and categories, then check the rates
+func (t *TaxRate) PrepareCreate() error {
+	t.Region = tax.NormalizeRegion(t.Region)
+	t.Name = strings.TrimSpace(t.Name)
+
+	categoryRates := make(TaxCategoryRates, len(t.CategoryRates))
+	for category, rate := range t.CategoryRates {
+		categoryRates[strings.ToLower(strings.TrimSpace(category))] = rate
+	}
+	t.CategoryRates = categoryRates
+
+	jurisdiction := t.Jurisdiction()
+	return jurisdiction.Validate()
+}
+
+// Jurisdiction tax calculator jurisdiction of the rate table
+func (t *TaxRate) Jurisdiction() tax.Jurisdiction {
+	return tax.Jurisdiction{
+		Region:           t.Region,
+		Name:             t.Name,
+		Rate:             t.Rate,
+		PricesIncludeTax: t.PricesIncludeTax,
+		CategoryRates:    t.CategoryRates,
+	}
+}
+
+// TaxCategoryRates rate overrides keyed by product category
+type TaxCategoryRates map[string]float64
+
+func (c *TaxCategoryRates) Scan(value interface{}) error {
+	val, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("unable to scan")
+	}
+	var rates TaxCategoryRates
+	if err := json.Unmarshal(val, &rates); err != nil {
+		return fmt.Errorf("json.Unmarshal %v", value)
+	}
+	*c = rates
+	return nil
+}
+
+func (c TaxCategoryRates) Value() (driver.Value, error) {
+	if c == nil {
+		c = TaxCategoryRates{}
+	}
+	valueJson, _ := json.Marshal(c)
+	return valueJson, nil
+}
+
+// TaxLines taxes charged on an order
+type TaxLines []tax.Line
+
+func (l *TaxLines) Scan(value interface{}) error {
+	val, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("unable to scan")
+	}
+	var lines TaxLines
+	if err := json.Unmarshal(val, &lines); err != nil {
+		return fmt.Errorf("json.Unmarshal %v", value)
+	}
+	*l = lines
+	return nil
+}
+
+func (l TaxLines) Value() (driver.Value, error) {
+	if l == nil {
+		l = TaxLines{}
+	}
+	valueJson, _ := json.Marshal(l)
+	return valueJson, nil
+}
@@ -1,31 +1,86 @@
 package models
 
 import (
+	"errors"
 	"fmt"
+	"net/url"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
+
+	"github.com/dinorain/kalobranded/pkg/constants"
+	"github.com/dinorain/kalobranded/pkg/geo"
+	"github.com/dinorain/kalobranded/pkg/utils"
 )
 
 const (
-	UserRoleAdmin = "admin"
-	UserRoleUser  = "user"
+	UserRoleAdmin  = "admin"
+	UserRoleUser   = "user"
+	UserRoleSeller = "seller"
 )
 
+// ErrDeliveryAddressRequired user without a delivery address, OIDC users are created without one
+var ErrDeliveryAddressRequired = errors.New("delivery address is required")
+
 // User model
 type User struct {
-	UserID          uuid.UUID `json:"user_id" db:"user_id"`
-	Email           string    `json:"email" db:"email"`
-	FirstName       string    `json:"first_name" db:"first_name"`
-	LastName        string    `json:"last_name" db:"last_name"`
-	DeliveryAddress string    `json:"delivery_address" db:"delivery_address"`
-	Role            string    `json:"role" db:"role"`
-	Avatar          *string   `json:"avatar" db:"avatar"`
-	Password        string    `json:"-" db:"password"`
-	CreatedAt       time.Time `json:"created_at,omitempty" db:"created_at"`
-	UpdatedAt       time.Time `json:"updated_at,omitempty" db:"updated_at"`
+	UserID            uuid.UUID  `json:"user_id" db:"user_id"`
+	Email             string     `json:"email" db:"email"`
+	FirstName         string     `json:"first_name" db:"first_name"`
+	LastName          string     `json:"last_name" db:"last_name"`
+	DeliveryAddress   string     `json:"delivery_address" db:"delivery_address"`
+	DeliveryLatitude  *float64   `json:"delivery_latitude,omitempty" db:"delivery_latitude"`
+	DeliveryLongitude *float64   `json:"delivery_longitude,omitempty" db:"delivery_longitude"`
+	Role              string     `json:"role" db:"role"`
+	Avatar            *string    `json:"avatar" db:"avatar"`
+	BrandID           *uuid.UUID `json:"brand_id,omitempty" db:"brand_id"`
+	Password          string     `json:"-" db:"password"`
+	Version           int        `json:"version" db:"version"`
+	DeletedAt         *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+	CreatedAt         time.Time  `json:"created_at,omitempty" db:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at,omitempty" db:"updated_at"`
+}
+
+// UserSorts sort keys of user lists, newest first by default
+var UserSorts = utils.Sorts{
+	Columns: map[string]string{
+		"created_at": "created_at",
+		"email":      "email",
+		"first_name": "first_name",
+		"last_name":  "last_name",
+	},
+	Default: "-created_at",
+	Unique:  "user_id",
+}
+
+// UserFilter users of a user list, unset fields match every user. Email matches emails containing it regardless of
+// case
+type UserFilter struct {
+	Role    string
+	BrandID *uuid.UUID
+	Email   string
+}
+
+// ParseUserFilter user filter of the query parameters of a user list
+func ParseUserFilter(queryParam url.Values) (*UserFilter, error) {
+	filter := &UserFilter{
+		Role:  queryParam.Get(constants.Role),
+		Email: strings.TrimSpace(queryParam.Get(constants.Email)),
+	}
+	switch filter.Role {
+	case "", UserRoleAdmin, UserRoleUser, UserRoleSeller:
+	default:
+		return nil, fmt.Errorf("invalid %s: %q", constants.Role, filter.Role)
+	}
+
+	var err error
+	if filter.BrandID, err = utils.ParseQueryUUID(queryParam, constants.BrandID); err != nil {
+		return nil, err
+	}
+
+	return filter, nil
 }
 
 func (u *User) SanitizePassword() {
@@ -55,15 +110,28 @@ func (u *User) PrepareCreate() error {
 	}
 
 	if u.Role != "" {
-		u.Role = strings.ToLower(strings.TrimSpace(u.Role))
-		if u.Role != UserRoleAdmin && u.Role != UserRoleUser {
-			return fmt.Errorf("role invalid: %v", u.Role)
-		}
+		return u.SetRole(u.Role, u.BrandID)
 	}
 
 	return nil
 }
 
+// SetRole change the role of the user, sellers need the brand they sell for and other roles have none
+func (u *User) SetRole(role string, brandID *uuid.UUID) error {
+	role = strings.ToLower(strings.TrimSpace(role))
+	if role != UserRoleAdmin && role != UserRoleUser && role != UserRoleSeller {
+		return fmt.Errorf("role invalid: %v", role)
+	}
+
+	if (role == UserRoleSeller) != (brandID != nil) {
+		return fmt.Errorf("brand_id must be set for sellers only")
+	}
+
+	u.Role = role
+	u.BrandID = brandID
+	return nil
+}
+
 // Get avatar string
 func (u *User) GetAvatar() string {
 	if u.Avatar == nil {
@@ -71,3 +139,8 @@ func (u *User) GetAvatar() string {
 	}
 	return *u.Avatar
 }
+
+// DeliveryPoint coordinates of the delivery address, nil when they are not set
+func (u *User) DeliveryPoint() *geo.Point {
+	return geo.NewPoint(u.DeliveryLatitude, u.DeliveryLongitude)
+}
@@ -0,0 +1,93 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/pkg/geo"
+)
+
+// UserAddress model, an entry of the user address book. At most one address per user is the default
+type UserAddress struct {
+	AddressID  uuid.UUID `json:"address_id" db:"address_id"`
+	UserID     uuid.UUID `json:"user_id" db:"user_id"`
+	Label      string    `json:"label" db:"label"`
+	Recipient  string    `json:"recipient" db:"recipient"`
+	Phone      string    `json:"phone" db:"phone"`
+	Street     string    `json:"street" db:"street"`
+	City       string    `json:"city" db:"city"`
+	PostalCode string    `json:"postal_code" db:"postal_code"`
+	Region     string    `json:"region" db:"region"`
+	Country    string    `json:"country" db:"country"`
+	Latitude   *float64  `json:"latitude,omitempty" db:"latitude"`
+	Longitude  *float64  `json:"longitude,omitempty" db:"longitude"`
+	IsDefault  bool      `json:"is_default" db:"is_default"`
+	Version    int       `json:"version" db:"version"`
+	CreatedAt  time.Time `json:"created_at,omitempty" db:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at,omitempty" db:"updated_at"`
+}
+
+func (a *UserAddress) PrepareCreate() error {
+	a.Label = strings.TrimSpace(a.Label)
+	a.Recipient = strings.TrimSpace(a.Recipient)
+	a.Phone = strings.TrimSpace(a.Phone)
+	a.Street = strings.TrimSpace(a.Street)
+	a.City = strings.TrimSpace(a.City)
+	a.PostalCode = strings.TrimSpace(a.PostalCode)
+	a.Region = strings.ToUpper(strings.TrimSpace(a.Region))
+	a.Country = strings.ToUpper(strings.TrimSpace(a.Country))
+
+	return geo.CheckCoordinates(a.Latitude, a.Longitude)
+}
+
+// Point coordinates of the address, nil when they are not set
+func (a *UserAddress) Point() *geo.Point {
+	return geo.NewPoint(a.Latitude, a.Longitude)
+}
+
+// TaxRegion region code the address is taxed in, the country code followed by the region, e.g. "US-CA", or the
+// country code alone when no region is set
+func (a *UserAddress) TaxRegion() string {
+	if a.Region == "" {
+		return a.Country
+	}
+	return a.Country + "-" + a.Region
+}
+
+// Format single line address, comma separated from the most to the least specific part and ending with the country
+// code, so it falls back to the city when geocoded
+func (a *UserAddress) Format() string {
+	parts := make([]string, 0, 4)
+	for _, part := range []string{a.Street, a.PostalCode, a.City, a.Country} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// OrderAddress snapshot of the user address an order is delivered to
+type OrderAddress UserAddress
+
+func (o *OrderAddress) Scan(value interface{}) error {
+	val, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("unable to scan")
+	}
+	var address OrderAddress
+	if err := json.Unmarshal(val, &address); err != nil {
+		return fmt.Errorf("json.Unmarshal %v", value)
+	}
+	*o = address
+	return nil
+}
+
+func (o OrderAddress) Value() (driver.Value, error) {
+	valueJson, _ := json.Marshal(o)
+	return valueJson, nil
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserIdentity model, links an external identity provider subject to a user
+type UserIdentity struct {
+	IdentityID uuid.UUID `json:"identity_id" db:"identity_id"`
+	UserID     uuid.UUID `json:"user_id" db:"user_id"`
+	Provider   string    `json:"provider" db:"provider"`
+	Subject    string    `json:"subject" db:"subject"`
+	Email      string    `json:"email" db:"email"`
+	CreatedAt  time.Time `json:"created_at,omitempty" db:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at,omitempty" db:"updated_at"`
+}
+
+// OidcAuthState pending authorization request, kept until the provider calls back
+type OidcAuthState struct {
+	State        string `json:"state"`
+	Provider     string `json:"provider"`
+	Nonce        string `json:"nonce"`
+	CodeVerifier string `json:"code_verifier"`
+}
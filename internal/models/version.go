@@ -0,0 +1,9 @@
+package models
+
+import "errors"
+
+// ErrVersionConflict conditional update found the row at another version, it was changed concurrently
+var ErrVersionConflict = errors.New("version conflict")
+
+// ErrPreconditionFailed conditional change found no row at the versions it was asked for
+var ErrPreconditionFailed = errors.New("precondition failed")
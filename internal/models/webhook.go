@@ -0,0 +1,116 @@
+package models
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const (
+	WebhookDeliveryStatusPending   = "pending"
+	WebhookDeliveryStatusSucceeded = "succeeded"
+	WebhookDeliveryStatusFailed    = "failed"
+)
+
+var (
+	// ErrInvalidWebhookURL webhook url is not an absolute http or https url
+	ErrInvalidWebhookURL = errors.New("webhook url must be an absolute http or https url")
+	// ErrNonPublicWebhookURL webhook url host does not resolve, or resolves to a loopback, private or link-local address
+	ErrNonPublicWebhookURL = errors.New("webhook url must resolve to public addresses")
+	// ErrUnsupportedWebhookEvent event type brands can't subscribe to
+	ErrUnsupportedWebhookEvent = errors.New("unsupported webhook event type")
+	// ErrDeliveryPending delivery is still being attempted, it can only be redelivered once it succeeded or failed
+	ErrDeliveryPending = errors.New("webhook delivery is pending")
+)
+
+// WebhookEventTypes domain events brands can subscribe to, their payloads carry the brand_id they concern
+var WebhookEventTypes = []string{EventOrderCreated, EventOrderStatusChanged}
+
+// WebhookSubscription model, url of a brand system receiving the events of EventTypes signed with Secret
+type WebhookSubscription struct {
+	SubscriptionID uuid.UUID      `json:"subscription_id" db:"subscription_id"`
+	BrandID        uuid.UUID      `json:"brand_id" db:"brand_id"`
+	URL            string         `json:"url" db:"url"`
+	EventTypes     pq.StringArray `json:"event_types" db:"event_types"`
+	Secret         string         `json:"-" db:"secret"`
+	Active         bool           `json:"active" db:"active"`
+	Version        int            `json:"version" db:"version"`
+	CreatedAt      time.Time      `json:"created_at,omitempty" db:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at,omitempty" db:"updated_at"`
+}
+
+// PrepareCreate check url and event types, event types are deduplicated
+func (s *WebhookSubscription) PrepareCreate() error {
+	s.URL = strings.TrimSpace(s.URL)
+	u, err := url.Parse(s.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrInvalidWebhookURL
+	}
+
+	eventTypes := make(pq.StringArray, 0, len(s.EventTypes))
+	seen := make(map[string]bool, len(s.EventTypes))
+	for _, t := range s.EventTypes {
+		if !IsWebhookEventType(t) {
+			return ErrUnsupportedWebhookEvent
+		}
+		if !seen[t] {
+			seen[t] = true
+			eventTypes = append(eventTypes, t)
+		}
+	}
+	if len(eventTypes) == 0 {
+		return ErrUnsupportedWebhookEvent
+	}
+	s.EventTypes = eventTypes
+
+	return nil
+}
+
+// IsWebhookEventType reports whether brands can subscribe to eventType
+func IsWebhookEventType(eventType string) bool {
+	for _, t := range WebhookEventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDelivery model, attempts at sending an event to a webhook subscription
+type WebhookDelivery struct {
+	DeliveryID     uuid.UUID    `json:"delivery_id" db:"delivery_id"`
+	SubscriptionID uuid.UUID    `json:"subscription_id" db:"subscription_id"`
+	EventID        uuid.UUID    `json:"event_id" db:"event_id"`
+	EventType      string       `json:"event_type" db:"event_type"`
+	Payload        EventPayload `json:"payload" db:"payload"`
+	Status         string       `json:"status" db:"status"`
+	Attempts       int          `json:"attempts" db:"attempts"`
+	NextAttemptAt  *time.Time   `json:"next_attempt_at,omitempty" db:"next_attempt_at"`
+	ResponseStatus int          `json:"response_status" db:"response_status"`
+	ResponseBody   string       `json:"response_body" db:"response_body"`
+	Error          string       `json:"error" db:"error"`
+	DeliveredAt    *time.Time   `json:"delivered_at,omitempty" db:"delivered_at"`
+	CreatedAt      time.Time    `json:"created_at,omitempty" db:"created_at"`
+	UpdatedAt      time.Time    `json:"updated_at,omitempty" db:"updated_at"`
+}
+
+// DueWebhookDelivery webhook delivery with the url and secret of its subscription, claimed for an attempt
+type DueWebhookDelivery struct {
+	WebhookDelivery
+	URL    string `json:"url" db:"url"`
+	Secret string `json:"-" db:"secret"`
+}
+
+// WebhookEnvelope body posted to webhook urls
+type WebhookEnvelope struct {
+	DeliveryID uuid.UUID    `json:"delivery_id"`
+	EventID    uuid.UUID    `json:"event_id"`
+	EventType  string       `json:"event_type"`
+	Attempt    int          `json:"attempt"`
+	SentAt     time.Time    `json:"sent_at"`
+	Data       EventPayload `json:"data"`
+}
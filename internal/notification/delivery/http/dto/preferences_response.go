@@ -0,0 +1,23 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/internal/models"
+)
+
+type NotificationPreferencesResponseDto struct {
+	UserID       uuid.UUID `json:"user_id"`
+	Locale       string    `json:"locale"`
+	OrderUpdates bool      `json:"order_updates"`
+	NewOrders    bool      `json:"new_orders"`
+}
+
+func NotificationPreferencesResponseFromModel(preferences *models.NotificationPreferences) *NotificationPreferencesResponseDto {
+	return &NotificationPreferencesResponseDto{
+		UserID:       preferences.UserID,
+		Locale:       preferences.Locale,
+		OrderUpdates: preferences.OrderUpdates,
+		NewOrders:    preferences.NewOrders,
+	}
+}
@@ -0,0 +1,7 @@
+package dto
+
+type NotificationPreferencesUpdateRequestDto struct {
+	Locale       *string `json:"locale" validate:"omitempty,lte=10"`
+	OrderUpdates *bool   `json:"order_updates"`
+	NewOrders    *bool   `json:"new_orders"`
+}
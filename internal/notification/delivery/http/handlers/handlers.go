@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/middlewares"
+	"github.com/dinorain/kalobranded/internal/notification"
+	"github.com/dinorain/kalobranded/internal/notification/delivery/http/dto"
+	"github.com/dinorain/kalobranded/internal/server/router"
+	httpErrors "github.com/dinorain/kalobranded/pkg/http_errors"
+	"github.com/dinorain/kalobranded/pkg/logger"
+)
+
+type notificationHandlersHTTP struct {
+	router         *router.Router
+	logger         logger.Logger
+	cfg            *config.Config
+	mw             middlewares.MiddlewareManager
+	v              *validator.Validate
+	notificationUC notification.NotificationUseCase
+}
+
+var _ notification.NotificationHandlers = (*notificationHandlersHTTP)(nil)
+
+func NewNotificationHandlersHTTP(
+	router *router.Router,
+	logger logger.Logger,
+	cfg *config.Config,
+	mw middlewares.MiddlewareManager,
+	v *validator.Validate,
+	notificationUC notification.NotificationUseCase,
+) *notificationHandlersHTTP {
+	return &notificationHandlersHTTP{router: router, logger: logger, cfg: cfg, mw: mw, v: v, notificationUC: notificationUC}
+}
+
+// FindPreferences
+// @Tags Notifications
+// @Summary Find notification preferences
+// @Description Find the order emails the caller opted into. Users who never set their preferences get every email in the default locale, shown as an empty locale
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} dto.NotificationPreferencesResponseDto
+// @Router /user/notification-preferences [get]
+func (h *notificationHandlersHTTP) FindPreferences(w http.ResponseWriter, r *http.Request) {
+	userUUID, err := h.getCaller(w, r)
+	if err != nil {
+		return
+	}
+
+	preferences, err := h.notificationUC.FindPreferences(r.Context(), userUUID)
+	if err != nil {
+		h.logger.Errorf("notificationUC.FindPreferences: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	res, _ := json.Marshal(dto.NotificationPreferencesResponseFromModel(preferences))
+	w.WriteHeader(http.StatusOK)
+	w.Write(res)
+	return
+}
+
+// UpdatePreferences
+// @Tags Notifications
+// @Summary Update notification preferences
+// @Description Update the order emails the caller opted into, only provided fields are changed. order_updates covers the emails on the caller's own orders being placed, accepted and shipped, new_orders the emails sellers get on new orders of their brand. Emails are sent in locale, en or id, the default locale when empty
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param payload body dto.NotificationPreferencesUpdateRequestDto true "Payload"
+// @Success 200 {object} dto.NotificationPreferencesResponseDto
+// @Router /user/notification-preferences [patch]
+func (h *notificationHandlersHTTP) UpdatePreferences(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	updateDto := &dto.NotificationPreferencesUpdateRequestDto{}
+	if err := json.NewDecoder(r.Body).Decode(updateDto); err != nil {
+		h.logger.Errorf("decoder.Decode: %v", err)
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	if err := h.v.Struct(updateDto); err != nil {
+		h.logger.Errorf("h.v.Struct: %v", err)
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	userUUID, err := h.getCaller(w, r)
+	if err != nil {
+		return
+	}
+
+	preferences, err := h.notificationUC.FindPreferences(ctx, userUUID)
+	if err != nil {
+		h.logger.Errorf("notificationUC.FindPreferences: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	if updateDto.Locale != nil {
+		preferences.Locale = *updateDto.Locale
+	}
+	if updateDto.OrderUpdates != nil {
+		preferences.OrderUpdates = *updateDto.OrderUpdates
+	}
+	if updateDto.NewOrders != nil {
+		preferences.NewOrders = *updateDto.NewOrders
+	}
+	if err := preferences.PrepareCreate(); err != nil {
+		h.logger.Errorf("preferences.PrepareCreate: %v", err)
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	updatedPreferences, err := h.notificationUC.UpdatePreferences(ctx, preferences)
+	if err != nil {
+		h.logger.Errorf("notificationUC.UpdatePreferences: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	res, _ := json.Marshal(dto.NotificationPreferencesResponseFromModel(updatedPreferences))
+	w.WriteHeader(http.StatusOK)
+	w.Write(res)
+	return
+}
+
+// getCaller user uuid of the token, error response is already written when err is not nil
+func (h *notificationHandlersHTTP) getCaller(w http.ResponseWriter, r *http.Request) (userUUID uuid.UUID, err error) {
+	jwtClaims, err := h.mw.GetJWTClaims(w, r)
+	if err != nil {
+		return
+	}
+	claims := *jwtClaims
+	userID, _ := claims["user_id"].(string)
+
+	userUUID, err = uuid.Parse(userID)
+	if err != nil {
+		_ = httpErrors.NewUnauthorizedError(w, nil, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+	return
+}
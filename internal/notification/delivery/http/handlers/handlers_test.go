@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/golang-jwt/jwt"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/middlewares"
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/internal/notification/delivery/http/dto"
+	"github.com/dinorain/kalobranded/internal/notification/mock"
+	"github.com/dinorain/kalobranded/internal/server/router"
+	"github.com/dinorain/kalobranded/pkg/logger"
+)
+
+func signedToken(t *testing.T, cfg *config.Config, userUUID uuid.UUID) string {
+	token := jwt.New(jwt.SigningMethodHS256)
+	claims := token.Claims.(jwt.MapClaims)
+	claims["session_id"] = uuid.New().String()
+	claims["user_id"] = userUUID.String()
+	claims["role"] = models.UserRoleUser
+	claims["exp"] = time.Now().Add(time.Minute * 15).Unix()
+	validToken, err := token.SignedString([]byte(cfg.Server.JwtSecretKey))
+	require.NoError(t, err)
+	return validToken
+}
+
+func TestNotificationsHandler_UpdatePreferences(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	notificationUC := mock.NewMockNotificationUseCase(ctrl)
+
+	cfg := &config.Config{Session: config.Session{Expire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	appLogger := logger.NewAppLogger(cfg)
+	appLogger.InitLogger()
+	mw := middlewares.NewMiddlewareManager(appLogger, cfg)
+
+	handlers := NewNotificationHandlersHTTP(router.NewRouter(false), appLogger, cfg, mw, validator.New(), notificationUC)
+
+	userUUID := uuid.New()
+	newRequest := func(body string) *http.Request {
+		req := httptest.NewRequest(http.MethodPatch, "/user/notification-preferences", strings.NewReader(body))
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", signedToken(t, cfg, userUUID)))
+		return req
+	}
+
+	t.Run("PartialUpdate", func(t *testing.T) {
+		notificationUC.EXPECT().FindPreferences(gomock.Any(), userUUID).Return(models.DefaultNotificationPreferences(userUUID), nil)
+		notificationUC.EXPECT().UpdatePreferences(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, p *models.NotificationPreferences) (*models.NotificationPreferences, error) {
+			require.Equal(t, userUUID, p.UserID)
+			require.Equal(t, "id", p.Locale)
+			require.False(t, p.OrderUpdates)
+			require.True(t, p.NewOrders)
+			return p, nil
+		})
+
+		w := httptest.NewRecorder()
+		http.HandlerFunc(handlers.UpdatePreferences).ServeHTTP(w, newRequest(`{"locale": " ID ", "order_updates": false}`))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var res dto.NotificationPreferencesResponseDto
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+		require.Equal(t, "id", res.Locale)
+		require.False(t, res.OrderUpdates)
+	})
+
+	t.Run("UnsupportedLocale", func(t *testing.T) {
+		notificationUC.EXPECT().FindPreferences(gomock.Any(), userUUID).Return(models.DefaultNotificationPreferences(userUUID), nil)
+
+		w := httptest.NewRecorder()
+		http.HandlerFunc(handlers.UpdatePreferences).ServeHTTP(w, newRequest(`{"locale": "fr"}`))
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
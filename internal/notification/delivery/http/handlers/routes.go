@@ -0,0 +1,7 @@
+package handlers
+
+func (h *notificationHandlersHTTP) NotificationMapRoutes() {
+	preferences := h.router.Group("/user/notification-preferences", h.mw.IsLoggedIn)
+	preferences.Get("", h.FindPreferences)
+	preferences.Patch("", h.UpdatePreferences)
+}
@@ -0,0 +1,11 @@
+package notification
+
+import (
+	"net/http"
+)
+
+// Notification HTTP Handlers interface
+type NotificationHandlers interface {
+	FindPreferences(w http.ResponseWriter, r *http.Request)
+	UpdatePreferences(w http.ResponseWriter, r *http.Request)
+}
@@ -0,0 +1,126 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pg_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/dinorain/kalobranded/internal/models"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockNotificationPGRepository is a mock of NotificationPGRepository interface.
+type MockNotificationPGRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationPGRepositoryMockRecorder
+}
+
+// MockNotificationPGRepositoryMockRecorder is the mock recorder for MockNotificationPGRepository.
+type MockNotificationPGRepositoryMockRecorder struct {
+	mock *MockNotificationPGRepository
+}
+
+// NewMockNotificationPGRepository creates a new mock instance.
+func NewMockNotificationPGRepository(ctrl *gomock.Controller) *MockNotificationPGRepository {
+	mock := &MockNotificationPGRepository{ctrl: ctrl}
+	mock.recorder = &MockNotificationPGRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationPGRepository) EXPECT() *MockNotificationPGRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindAllRecipientsByBrandId mocks base method.
+func (m *MockNotificationPGRepository) FindAllRecipientsByBrandId(ctx context.Context, brandID uuid.UUID) ([]models.NotificationRecipient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllRecipientsByBrandId", ctx, brandID)
+	ret0, _ := ret[0].([]models.NotificationRecipient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllRecipientsByBrandId indicates an expected call of FindAllRecipientsByBrandId.
+func (mr *MockNotificationPGRepositoryMockRecorder) FindAllRecipientsByBrandId(ctx, brandID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllRecipientsByBrandId", reflect.TypeOf((*MockNotificationPGRepository)(nil).FindAllRecipientsByBrandId), ctx, brandID)
+}
+
+// FindPreferencesByUserId mocks base method.
+func (m *MockNotificationPGRepository) FindPreferencesByUserId(ctx context.Context, userID uuid.UUID) (*models.NotificationPreferences, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPreferencesByUserId", ctx, userID)
+	ret0, _ := ret[0].(*models.NotificationPreferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPreferencesByUserId indicates an expected call of FindPreferencesByUserId.
+func (mr *MockNotificationPGRepositoryMockRecorder) FindPreferencesByUserId(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPreferencesByUserId", reflect.TypeOf((*MockNotificationPGRepository)(nil).FindPreferencesByUserId), ctx, userID)
+}
+
+// FindRecipientById mocks base method.
+func (m *MockNotificationPGRepository) FindRecipientById(ctx context.Context, userID uuid.UUID) (*models.NotificationRecipient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRecipientById", ctx, userID)
+	ret0, _ := ret[0].(*models.NotificationRecipient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRecipientById indicates an expected call of FindRecipientById.
+func (mr *MockNotificationPGRepositoryMockRecorder) FindRecipientById(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRecipientById", reflect.TypeOf((*MockNotificationPGRepository)(nil).FindRecipientById), ctx, userID)
+}
+
+// IsSent mocks base method.
+func (m *MockNotificationPGRepository) IsSent(ctx context.Context, eventID, userID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsSent", ctx, eventID, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsSent indicates an expected call of IsSent.
+func (mr *MockNotificationPGRepositoryMockRecorder) IsSent(ctx, eventID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSent", reflect.TypeOf((*MockNotificationPGRepository)(nil).IsSent), ctx, eventID, userID)
+}
+
+// MarkSent mocks base method.
+func (m *MockNotificationPGRepository) MarkSent(ctx context.Context, n *models.Notification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkSent", ctx, n)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkSent indicates an expected call of MarkSent.
+func (mr *MockNotificationPGRepositoryMockRecorder) MarkSent(ctx, n interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkSent", reflect.TypeOf((*MockNotificationPGRepository)(nil).MarkSent), ctx, n)
+}
+
+// UpsertPreferences mocks base method.
+func (m *MockNotificationPGRepository) UpsertPreferences(ctx context.Context, preferences *models.NotificationPreferences) (*models.NotificationPreferences, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertPreferences", ctx, preferences)
+	ret0, _ := ret[0].(*models.NotificationPreferences)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertPreferences indicates an expected call of UpsertPreferences.
+func (mr *MockNotificationPGRepositoryMockRecorder) UpsertPreferences(ctx, preferences interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertPreferences", reflect.TypeOf((*MockNotificationPGRepository)(nil).UpsertPreferences), ctx, preferences)
+}
@@ -0,0 +1,20 @@
+//go:generate mockgen -source pg_repository.go -destination mock/pg_repository.go -package mock
+package notification
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/internal/models"
+)
+
+// Notification pg repository
+type NotificationPGRepository interface {
+	FindPreferencesByUserId(ctx context.Context, userID uuid.UUID) (*models.NotificationPreferences, error)
+	UpsertPreferences(ctx context.Context, preferences *models.NotificationPreferences) (*models.NotificationPreferences, error)
+	FindRecipientById(ctx context.Context, userID uuid.UUID) (*models.NotificationRecipient, error)
+	FindAllRecipientsByBrandId(ctx context.Context, brandID uuid.UUID) ([]models.NotificationRecipient, error)
+	IsSent(ctx context.Context, eventID uuid.UUID, userID uuid.UUID) (bool, error)
+	MarkSent(ctx context.Context, n *models.Notification) error
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/internal/notification"
+)
+
+// Notification repository
+type NotificationRepository struct {
+	db *sqlx.DB
+}
+
+var _ notification.NotificationPGRepository = (*NotificationRepository)(nil)
+
+// Notification repository constructor
+func NewNotificationPGRepository(db *sqlx.DB) *NotificationRepository {
+	return &NotificationRepository{db: db}
+}
+
+// FindPreferencesByUserId Find notification preferences of user uuid
+func (r *NotificationRepository) FindPreferencesByUserId(ctx context.Context, userID uuid.UUID) (*models.NotificationPreferences, error) {
+	preferences := &models.NotificationPreferences{}
+	if err := r.db.GetContext(ctx, preferences, findPreferencesByUserIdQuery, userID); err != nil {
+		return nil, errors.Wrap(err, "NotificationRepository.FindPreferencesByUserId.GetContext")
+	}
+
+	return preferences, nil
+}
+
+// UpsertPreferences Create or replace notification preferences of user
+func (r *NotificationRepository) UpsertPreferences(ctx context.Context, preferences *models.NotificationPreferences) (*models.NotificationPreferences, error) {
+	upsertedPreferences := &models.NotificationPreferences{}
+	if err := r.db.QueryRowxContext(
+		ctx,
+		upsertPreferencesQuery,
+		preferences.UserID,
+		preferences.Locale,
+		preferences.OrderUpdates,
+		preferences.NewOrders,
+	).StructScan(upsertedPreferences); err != nil {
+		return nil, errors.Wrap(err, "NotificationRepository.UpsertPreferences.QueryRowxContext")
+	}
+
+	return upsertedPreferences, nil
+}
+
+// FindRecipientById Find user uuid along with their notification preferences, defaults when they set none
+func (r *NotificationRepository) FindRecipientById(ctx context.Context, userID uuid.UUID) (*models.NotificationRecipient, error) {
+	recipient := &models.NotificationRecipient{}
+	if err := r.db.GetContext(ctx, recipient, findRecipientByIdQuery, userID); err != nil {
+		return nil, errors.Wrap(err, "NotificationRepository.FindRecipientById.GetContext")
+	}
+
+	return recipient, nil
+}
+
+// FindAllRecipientsByBrandId Find sellers of brand uuid along with their notification preferences
+func (r *NotificationRepository) FindAllRecipientsByBrandId(ctx context.Context, brandID uuid.UUID) ([]models.NotificationRecipient, error) {
+	var recipients []models.NotificationRecipient
+	if err := r.db.SelectContext(ctx, &recipients, findAllRecipientsByBrandIdQuery, brandID); err != nil {
+		return nil, errors.Wrap(err, "NotificationRepository.FindAllRecipientsByBrandId.SelectContext")
+	}
+
+	return recipients, nil
+}
+
+// IsSent reports whether the notification of event was already mailed to user uuid
+func (r *NotificationRepository) IsSent(ctx context.Context, eventID uuid.UUID, userID uuid.UUID) (bool, error) {
+	var sent bool
+	if err := r.db.GetContext(ctx, &sent, isSentQuery, eventID, userID); err != nil {
+		return false, errors.Wrap(err, "NotificationRepository.IsSent.GetContext")
+	}
+
+	return sent, nil
+}
+
+// MarkSent record the notification as mailed to its recipient
+func (r *NotificationRepository) MarkSent(ctx context.Context, n *models.Notification) error {
+	if _, err := r.db.ExecContext(ctx, markSentQuery, n.EventID, n.UserID, n.Template); err != nil {
+		return errors.Wrap(err, "NotificationRepository.MarkSent.ExecContext")
+	}
+
+	return nil
+}
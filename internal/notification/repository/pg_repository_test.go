@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/kalobranded/internal/models"
+)
+
+func TestNotificationRepository_UpsertPreferences(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	notificationPGRepository := NewNotificationPGRepository(sqlxDB)
+
+	userUUID := uuid.New()
+	rows := sqlmock.NewRows([]string{"user_id", "locale", "order_updates", "new_orders", "created_at", "updated_at"}).
+		AddRow(userUUID, "id", false, true, time.Now(), time.Now())
+
+	mock.ExpectQuery(upsertPreferencesQuery).WithArgs(userUUID, "id", false, true).WillReturnRows(rows)
+
+	preferences, err := notificationPGRepository.UpsertPreferences(context.Background(), &models.NotificationPreferences{UserID: userUUID, Locale: "id", NewOrders: true})
+	require.NoError(t, err)
+	require.Equal(t, "id", preferences.Locale)
+	require.False(t, preferences.OrderUpdates)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNotificationRepository_FindAllRecipientsByBrandId(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	notificationPGRepository := NewNotificationPGRepository(sqlxDB)
+
+	brandUUID := uuid.New()
+	rows := sqlmock.NewRows([]string{"user_id", "email", "first_name", "locale", "order_updates", "new_orders"}).
+		AddRow(uuid.New(), "seller@kalo.example", "Sari", "", true, true).
+		AddRow(uuid.New(), "owner@kalo.example", "Oki", "id", true, false)
+
+	mock.ExpectQuery(findAllRecipientsByBrandIdQuery).WithArgs(brandUUID).WillReturnRows(rows)
+
+	recipients, err := notificationPGRepository.FindAllRecipientsByBrandId(context.Background(), brandUUID)
+	require.NoError(t, err)
+	require.Len(t, recipients, 2)
+	require.Equal(t, "seller@kalo.example", recipients[0].Email)
+	require.False(t, recipients[1].NewOrders)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNotificationRepository_MarkSent(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	notificationPGRepository := NewNotificationPGRepository(sqlxDB)
+
+	n := &models.Notification{EventID: uuid.New(), UserID: uuid.New(), Template: models.NotificationOrderShipped}
+
+	mock.ExpectQuery(isSentQuery).WithArgs(n.EventID, n.UserID).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectExec(markSentQuery).WithArgs(n.EventID, n.UserID, n.Template).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(isSentQuery).WithArgs(n.EventID, n.UserID).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	sent, err := notificationPGRepository.IsSent(context.Background(), n.EventID, n.UserID)
+	require.NoError(t, err)
+	require.False(t, sent)
+
+	require.NoError(t, notificationPGRepository.MarkSent(context.Background(), n))
+
+	sent, err = notificationPGRepository.IsSent(context.Background(), n.EventID, n.UserID)
+	require.NoError(t, err)
+	require.True(t, sent)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
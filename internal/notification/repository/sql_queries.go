@@ -0,0 +1,19 @@
+package repository
+
+const (
+	findPreferencesByUserIdQuery = `SELECT user_id, locale, order_updates, new_orders, created_at, updated_at FROM notification_preferences WHERE user_id = $1`
+
+	upsertPreferencesQuery = `INSERT INTO notification_preferences (user_id, locale, order_updates, new_orders) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id) DO UPDATE SET locale = EXCLUDED.locale, order_updates = EXCLUDED.order_updates, new_orders = EXCLUDED.new_orders, updated_at = CURRENT_TIMESTAMP
+		RETURNING user_id, locale, order_updates, new_orders, created_at, updated_at`
+
+	findRecipientByIdQuery = `SELECT u.user_id, u.email, u.first_name, COALESCE(p.locale, '') AS locale, COALESCE(p.order_updates, TRUE) AS order_updates, COALESCE(p.new_orders, TRUE) AS new_orders
+		FROM users u LEFT JOIN notification_preferences p ON p.user_id = u.user_id WHERE u.user_id = $1 AND u.deleted_at IS NULL`
+
+	findAllRecipientsByBrandIdQuery = `SELECT u.user_id, u.email, u.first_name, COALESCE(p.locale, '') AS locale, COALESCE(p.order_updates, TRUE) AS order_updates, COALESCE(p.new_orders, TRUE) AS new_orders
+		FROM users u LEFT JOIN notification_preferences p ON p.user_id = u.user_id WHERE u.brand_id = $1 AND u.role = 'seller' AND u.deleted_at IS NULL ORDER BY u.created_at`
+
+	isSentQuery = `SELECT EXISTS (SELECT 1 FROM sent_notifications WHERE event_id = $1 AND user_id = $2)`
+
+	markSentQuery = `INSERT INTO sent_notifications (event_id, user_id, template) VALUES ($1, $2, $3) ON CONFLICT (event_id, user_id) DO NOTHING`
+)
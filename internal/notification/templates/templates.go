@@ -0,0 +1,149 @@
+// Package templates renders notification emails from the templates embedded per locale. Each template has a
+// <name>.txt text/template, defining the "subject" template ahead of the plain text body, and a <name>.html
+// html/template holding the HTML body
+package templates
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmlTemplate "html/template"
+	"io/fs"
+	"math"
+	"path"
+	"strconv"
+	"strings"
+	textTemplate "text/template"
+
+	"github.com/dinorain/kalobranded/internal/models"
+)
+
+//go:embed en id
+var files embed.FS
+
+// separators thousands and decimal separators of prices per locale
+var separators = map[string][2]string{
+	"en": {",", "."},
+	"id": {".", ","},
+}
+
+// Rendered notification email
+type Rendered struct {
+	Subject string
+	Text    string
+	HTML    string
+}
+
+type localeTemplates struct {
+	subject map[string]*textTemplate.Template
+	text    map[string]*textTemplate.Template
+	html    map[string]*htmlTemplate.Template
+}
+
+// Renderer renders notification templates, falling back to the default locale for unknown locales
+type Renderer struct {
+	defaultLocale string
+	locales       map[string]*localeTemplates
+}
+
+// NewRenderer parse the embedded templates of every notification locale, prices are shown in currency. An empty
+// defaultLocale stands for the first notification locale
+func NewRenderer(defaultLocale string, currency string) (*Renderer, error) {
+	if defaultLocale == "" {
+		defaultLocale = models.NotificationLocales[0]
+	}
+	if !models.IsNotificationLocale(defaultLocale) {
+		return nil, fmt.Errorf("%w: %q", models.ErrUnsupportedLocale, defaultLocale)
+	}
+
+	r := &Renderer{defaultLocale: defaultLocale, locales: make(map[string]*localeTemplates)}
+	for _, locale := range models.NotificationLocales {
+		funcs := map[string]interface{}{"price": priceFunc(locale, currency)}
+
+		names, err := fs.Glob(files, path.Join(locale, "*.txt"))
+		if err != nil {
+			return nil, err
+		}
+
+		lt := &localeTemplates{
+			subject: make(map[string]*textTemplate.Template),
+			text:    make(map[string]*textTemplate.Template),
+			html:    make(map[string]*htmlTemplate.Template),
+		}
+		for _, textFile := range names {
+			name := strings.TrimSuffix(path.Base(textFile), ".txt")
+
+			text, err := textTemplate.New(name).Funcs(funcs).ParseFS(files, textFile)
+			if err != nil {
+				return nil, err
+			}
+			subject := text.Lookup("subject")
+			if subject == nil {
+				return nil, fmt.Errorf("template %s has no subject", textFile)
+			}
+			html, err := htmlTemplate.New(name).Funcs(funcs).ParseFS(files, path.Join(locale, name+".html"))
+			if err != nil {
+				return nil, err
+			}
+
+			lt.text[name] = text.Lookup(path.Base(textFile))
+			lt.subject[name] = subject
+			lt.html[name] = html.Lookup(name + ".html")
+		}
+		r.locales[locale] = lt
+	}
+
+	return r, nil
+}
+
+// Render template name in locale with data
+func (r *Renderer) Render(locale string, name string, data interface{}) (*Rendered, error) {
+	lt, ok := r.locales[locale]
+	if !ok {
+		lt = r.locales[r.defaultLocale]
+	}
+	text, ok := lt.text[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown notification template %q", name)
+	}
+
+	var subject, body, html bytes.Buffer
+	if err := lt.subject[name].Execute(&subject, data); err != nil {
+		return nil, err
+	}
+	if err := text.Execute(&body, data); err != nil {
+		return nil, err
+	}
+	if err := lt.html[name].Execute(&html, data); err != nil {
+		return nil, err
+	}
+
+	return &Rendered{Subject: strings.TrimSpace(subject.String()), Text: body.String(), HTML: html.String()}, nil
+}
+
+// priceFunc formats prices with the separators of locale, decimals are only shown for fractional prices
+func priceFunc(locale string, currency string) func(float64) string {
+	sep := separators[locale]
+	return func(price float64) string {
+		cents := int64(math.Round(math.Abs(price) * 100))
+		digits := strconv.FormatInt(cents/100, 10)
+
+		var b strings.Builder
+		if price < 0 {
+			b.WriteString("-")
+		}
+		if currency != "" {
+			b.WriteString(currency + " ")
+		}
+		for i, d := range digits {
+			if i > 0 && (len(digits)-i)%3 == 0 {
+				b.WriteString(sep[0])
+			}
+			b.WriteRune(d)
+		}
+		if cents%100 != 0 {
+			fmt.Fprintf(&b, "%s%02d", sep[1], cents%100)
+		}
+		return b.String()
+	}
+}
@@ -0,0 +1,75 @@
+package templates
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/kalobranded/internal/models"
+)
+
+func TestRenderer_Render(t *testing.T) {
+	t.Parallel()
+
+	renderer, err := NewRenderer("en", "IDR")
+	require.NoError(t, err)
+
+	data := models.OrderNotificationData{
+		RecipientName:   "Budi <script>",
+		BrandName:       "Kalo",
+		OrderID:         uuid.MustParse("3f2a9c1e-0000-4000-8000-000000000000"),
+		ItemName:        "Tote bag",
+		Quantity:        2,
+		TotalPrice:      1250000.5,
+		DeliveryAddress: "Jl. Sudirman 1",
+	}
+
+	t.Run("English", func(t *testing.T) {
+		rendered, err := renderer.Render("en", models.NotificationOrderPlaced, data)
+		require.NoError(t, err)
+		require.Equal(t, "Your order 3F2A9C1E is placed", rendered.Subject)
+		require.Contains(t, rendered.Text, "Hi Budi <script>,")
+		require.Contains(t, rendered.Text, "Total: IDR 1,250,000.50")
+		require.Contains(t, rendered.HTML, "Hi Budi &lt;script&gt;,")
+		require.NotContains(t, rendered.Text, "subject")
+	})
+
+	t.Run("Indonesian", func(t *testing.T) {
+		rendered, err := renderer.Render("id", models.NotificationOrderPlaced, data)
+		require.NoError(t, err)
+		require.Equal(t, "Pesanan 3F2A9C1E Anda telah dibuat", rendered.Subject)
+		require.Contains(t, rendered.Text, "Total: IDR 1.250.000,50")
+	})
+
+	t.Run("UnknownLocaleFallsBack", func(t *testing.T) {
+		rendered, err := renderer.Render("fr", models.NotificationOrderShipped, data)
+		require.NoError(t, err)
+		require.Equal(t, "Your order 3F2A9C1E is on its way", rendered.Subject)
+	})
+
+	t.Run("EveryTemplateInEveryLocale", func(t *testing.T) {
+		for _, locale := range models.NotificationLocales {
+			for _, name := range []string{models.NotificationOrderPlaced, models.NotificationOrderReceived, models.NotificationOrderAccepted, models.NotificationOrderShipped} {
+				rendered, err := renderer.Render(locale, name, data)
+				require.NoError(t, err, locale+"/"+name)
+				require.NotEmpty(t, rendered.Subject, locale+"/"+name)
+			}
+		}
+	})
+
+	t.Run("UnknownTemplate", func(t *testing.T) {
+		_, err := renderer.Render("en", "order_lost", data)
+		require.Error(t, err)
+	})
+}
+
+func TestPriceFunc(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "IDR 0", priceFunc("en", "IDR")(0))
+	require.Equal(t, "IDR 999", priceFunc("en", "IDR")(999))
+	require.Equal(t, "IDR 150,000", priceFunc("en", "IDR")(150000))
+	require.Equal(t, "IDR 150.000", priceFunc("id", "IDR")(150000))
+	require.Equal(t, "-1,000.25", priceFunc("en", "")(-1000.25))
+}
@@ -0,0 +1,29 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase.go -package mock
+package notification
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/pkg/jobs"
+)
+
+// JobKindSend kind of the jobs sending a notification email
+const JobKindSend = "notifications.send"
+
+// Notification UseCase interface
+type NotificationUseCase interface {
+	FindPreferences(ctx context.Context, userID uuid.UUID) (*models.NotificationPreferences, error)
+	UpdatePreferences(ctx context.Context, preferences *models.NotificationPreferences) (*models.NotificationPreferences, error)
+	HandleEvent(ctx context.Context, event *models.OutboxEvent) error
+	Send(ctx context.Context, notification *models.Notification) error
+}
+
+// JobQueue queue the notification jobs are enqueued to, *jobs.Queue
+type JobQueue interface {
+	Enqueue(ctx context.Context, kind string, payload interface{}, opts ...jobs.EnqueueOption) (*jobs.Job, error)
+}
+
+var _ JobQueue = (*jobs.Queue)(nil)
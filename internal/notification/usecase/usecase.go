@@ -0,0 +1,246 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/mail"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/brand"
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/internal/notification"
+	"github.com/dinorain/kalobranded/internal/notification/templates"
+	"github.com/dinorain/kalobranded/internal/order"
+	"github.com/dinorain/kalobranded/pkg/jobs"
+	"github.com/dinorain/kalobranded/pkg/logger"
+	"github.com/dinorain/kalobranded/pkg/mailer"
+)
+
+// Notification UseCase
+type notificationUseCase struct {
+	cfg                *config.Config
+	logger             logger.Logger
+	notificationPgRepo notification.NotificationPGRepository
+	orderUC            order.OrderUseCase
+	brandUC            brand.BrandUseCase
+	queue              notification.JobQueue
+	renderer           *templates.Renderer
+	mailer             mailer.Mailer
+}
+
+var _ notification.NotificationUseCase = (*notificationUseCase)(nil)
+
+// New Notification UseCase
+func NewNotificationUseCase(
+	cfg *config.Config,
+	logger logger.Logger,
+	notificationRepo notification.NotificationPGRepository,
+	orderUC order.OrderUseCase,
+	brandUC brand.BrandUseCase,
+	queue notification.JobQueue,
+	renderer *templates.Renderer,
+	mailer mailer.Mailer,
+) *notificationUseCase {
+	return &notificationUseCase{
+		cfg:                cfg,
+		logger:             logger,
+		notificationPgRepo: notificationRepo,
+		orderUC:            orderUC,
+		brandUC:            brandUC,
+		queue:              queue,
+		renderer:           renderer,
+		mailer:             mailer,
+	}
+}
+
+// FindPreferences find notification preferences of user, the defaults when they set none
+func (u *notificationUseCase) FindPreferences(ctx context.Context, userID uuid.UUID) (*models.NotificationPreferences, error) {
+	preferences, err := u.notificationPgRepo.FindPreferencesByUserId(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.DefaultNotificationPreferences(userID), nil
+		}
+		return nil, errors.Wrap(err, "notificationPgRepo.FindPreferencesByUserId")
+	}
+
+	return preferences, nil
+}
+
+// UpdatePreferences replace notification preferences of user
+func (u *notificationUseCase) UpdatePreferences(ctx context.Context, preferences *models.NotificationPreferences) (*models.NotificationPreferences, error) {
+	updatedPreferences, err := u.notificationPgRepo.UpsertPreferences(ctx, preferences)
+	if err != nil {
+		return nil, errors.Wrap(err, "notificationPgRepo.UpsertPreferences")
+	}
+
+	return updatedPreferences, nil
+}
+
+// HandleEvent enqueue a notification job per recipient of an order event. New orders go to the buyer and the brand
+// sellers, accepted and shipped orders to the buyer, each recipient only gets the emails they opted into
+func (u *notificationUseCase) HandleEvent(ctx context.Context, event *models.OutboxEvent) error {
+	var (
+		foundOrder *models.Order
+		toBuyer    string
+		toSellers  string
+	)
+
+	switch event.EventType {
+	case models.EventOrderCreated:
+		foundOrder = &models.Order{}
+		if err := json.Unmarshal(event.Payload, foundOrder); err != nil {
+			return errors.Wrap(err, "json.Unmarshal")
+		}
+		toBuyer, toSellers = models.NotificationOrderPlaced, models.NotificationOrderReceived
+	case models.EventOrderStatusChanged:
+		var change models.OrderStatusChange
+		if err := json.Unmarshal(event.Payload, &change); err != nil {
+			return errors.Wrap(err, "json.Unmarshal")
+		}
+		switch change.To {
+		case models.OrderStatusAccepted:
+			toBuyer = models.NotificationOrderAccepted
+		case models.OrderStatusShipped:
+			toBuyer = models.NotificationOrderShipped
+		default:
+			return nil
+		}
+
+		var err error
+		foundOrder, err = u.orderUC.FindById(ctx, change.OrderID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil
+			}
+			return errors.Wrap(err, "orderUC.FindById")
+		}
+		foundOrder.Status = change.To
+	default:
+		return nil
+	}
+
+	foundBrand, err := u.brandUC.CachedFindById(ctx, foundOrder.BrandID)
+	if err != nil {
+		return errors.Wrap(err, "brandUC.CachedFindById")
+	}
+
+	buyer, err := u.notificationPgRepo.FindRecipientById(ctx, foundOrder.UserID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return errors.Wrap(err, "notificationPgRepo.FindRecipientById")
+	}
+	if buyer != nil && buyer.OrderUpdates {
+		if err := u.enqueue(ctx, event, toBuyer, buyer, foundOrder, foundBrand); err != nil {
+			return err
+		}
+	}
+
+	if toSellers == "" {
+		return nil
+	}
+	sellers, err := u.notificationPgRepo.FindAllRecipientsByBrandId(ctx, foundOrder.BrandID)
+	if err != nil {
+		return errors.Wrap(err, "notificationPgRepo.FindAllRecipientsByBrandId")
+	}
+	for i := range sellers {
+		if !sellers[i].NewOrders {
+			continue
+		}
+		if err := u.enqueue(ctx, event, toSellers, &sellers[i], foundOrder, foundBrand); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// enqueue the notification job of template for recipient, once per event and recipient. The unique key covers jobs
+// still queued or running, the sent record the ones that went out already
+func (u *notificationUseCase) enqueue(
+	ctx context.Context,
+	event *models.OutboxEvent,
+	template string,
+	recipient *models.NotificationRecipient,
+	foundOrder *models.Order,
+	foundBrand *models.Brand,
+) error {
+	sent, err := u.notificationPgRepo.IsSent(ctx, event.EventID, recipient.UserID)
+	if err != nil {
+		return errors.Wrap(err, "notificationPgRepo.IsSent")
+	}
+	if sent {
+		return nil
+	}
+
+	locale := recipient.Locale
+	if locale == "" {
+		locale = u.cfg.Notification.DefaultLocale
+	}
+
+	n := &models.Notification{
+		EventID:  event.EventID,
+		UserID:   recipient.UserID,
+		Template: template,
+		Locale:   locale,
+		To:       (&mail.Address{Name: recipient.FirstName, Address: recipient.Email}).String(),
+		Data: models.OrderNotificationData{
+			RecipientName:   recipient.FirstName,
+			BrandName:       foundBrand.BrandName,
+			OrderID:         foundOrder.OrderID,
+			ItemName:        foundOrder.Item.Name,
+			Quantity:        foundOrder.Quantity,
+			TotalPrice:      foundOrder.TotalPrice,
+			Status:          foundOrder.Status,
+			DeliveryAddress: foundOrder.DeliveryDestinationAddress,
+		},
+	}
+
+	uniqueKey := fmt.Sprintf("notification:%s:%s", event.EventID, recipient.UserID)
+	if _, err := u.queue.Enqueue(ctx, notification.JobKindSend, n, jobs.UniqueKey(uniqueKey)); err != nil && !errors.Is(err, jobs.ErrDuplicate) {
+		return errors.Wrap(err, "queue.Enqueue")
+	}
+
+	return nil
+}
+
+// Send render the notification and mail it, a notification that does not render fails without retries. A
+// notification already mailed for its event is skipped, and a sent one is recorded so that it is never mailed again
+func (u *notificationUseCase) Send(ctx context.Context, n *models.Notification) error {
+	if n.EventID != uuid.Nil {
+		sent, err := u.notificationPgRepo.IsSent(ctx, n.EventID, n.UserID)
+		if err != nil {
+			return errors.Wrap(err, "notificationPgRepo.IsSent")
+		}
+		if sent {
+			return nil
+		}
+	}
+
+	rendered, err := u.renderer.Render(n.Locale, n.Template, n.Data)
+	if err != nil {
+		return jobs.Permanent(errors.Wrap(err, "renderer.Render"))
+	}
+
+	if err := u.mailer.Send(ctx, &mailer.Message{
+		From:    u.cfg.Notification.From,
+		To:      []string{n.To},
+		Subject: rendered.Subject,
+		Text:    rendered.Text,
+		HTML:    rendered.HTML,
+	}); err != nil {
+		return errors.Wrap(err, "mailer.Send")
+	}
+
+	if n.EventID != uuid.Nil {
+		// the mail is out, retrying the job would send it twice
+		if err := u.notificationPgRepo.MarkSent(ctx, n); err != nil {
+			u.logger.Errorf("notificationPgRepo.MarkSent: %v", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,240 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/kalobranded/config"
+	mockBrandUC "github.com/dinorain/kalobranded/internal/brand/mock"
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/internal/notification"
+	"github.com/dinorain/kalobranded/internal/notification/mock"
+	"github.com/dinorain/kalobranded/internal/notification/templates"
+	mockOrderUC "github.com/dinorain/kalobranded/internal/order/mock"
+	"github.com/dinorain/kalobranded/pkg/jobs"
+	"github.com/dinorain/kalobranded/pkg/logger"
+	"github.com/dinorain/kalobranded/pkg/mailer"
+)
+
+type testDeps struct {
+	notificationPGRepository *mock.MockNotificationPGRepository
+	orderUC                  *mockOrderUC.MockOrderUseCase
+	brandUC                  *mockBrandUC.MockBrandUseCase
+	queue                    *mock.MockJobQueue
+	mailDir                  string
+}
+
+func newTestUseCase(t *testing.T) (*notificationUseCase, *testDeps) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	deps := &testDeps{
+		notificationPGRepository: mock.NewMockNotificationPGRepository(ctrl),
+		orderUC:                  mockOrderUC.NewMockOrderUseCase(ctrl),
+		brandUC:                  mockBrandUC.NewMockBrandUseCase(ctrl),
+		queue:                    mock.NewMockJobQueue(ctrl),
+		mailDir:                  t.TempDir(),
+	}
+
+	cfg := &config.Config{Notification: config.Notification{From: "Kalobranded <no-reply@kalobranded.local>", DefaultLocale: "en"}}
+	apiLogger := logger.NewAppLogger(cfg)
+	apiLogger.InitLogger()
+
+	renderer, err := templates.NewRenderer("en", "IDR")
+	require.NoError(t, err)
+
+	return NewNotificationUseCase(cfg, apiLogger, deps.notificationPGRepository, deps.orderUC, deps.brandUC, deps.queue, renderer, mailer.NewFileMailer(deps.mailDir)), deps
+}
+
+func TestNotificationUseCase_HandleEvent(t *testing.T) {
+	t.Parallel()
+
+	brandUUID, buyerUUID := uuid.New(), uuid.New()
+	createdOrder := &models.Order{
+		OrderID:                    uuid.New(),
+		UserID:                     buyerUUID,
+		BrandID:                    brandUUID,
+		Item:                       models.OrderItem{Name: "Tote bag"},
+		Quantity:                   2,
+		TotalPrice:                 300000,
+		Status:                     models.OrderStatusPending,
+		DeliveryDestinationAddress: "Jl. Sudirman 1",
+	}
+	buyer := &models.NotificationRecipient{UserID: buyerUUID, Email: "budi@example.com", FirstName: "Budi", Locale: "id", OrderUpdates: true, NewOrders: true}
+
+	t.Run("OrderCreated", func(t *testing.T) {
+		notificationUC, deps := newTestUseCase(t)
+
+		event, err := models.NewOutboxEvent(models.AggregateOrder, createdOrder.OrderID, models.EventOrderCreated, createdOrder)
+		require.NoError(t, err)
+
+		optedIn, optedOut := uuid.New(), uuid.New()
+		deps.brandUC.EXPECT().CachedFindById(gomock.Any(), brandUUID).Return(&models.Brand{BrandID: brandUUID, BrandName: "Kalo"}, nil)
+		deps.notificationPGRepository.EXPECT().FindRecipientById(gomock.Any(), buyerUUID).Return(buyer, nil)
+		deps.notificationPGRepository.EXPECT().IsSent(gomock.Any(), event.EventID, gomock.Any()).Times(2).Return(false, nil)
+		deps.notificationPGRepository.EXPECT().FindAllRecipientsByBrandId(gomock.Any(), brandUUID).Return([]models.NotificationRecipient{
+			{UserID: optedIn, Email: "seller@kalo.example", FirstName: "Sari", OrderUpdates: true, NewOrders: true},
+			{UserID: optedOut, Email: "owner@kalo.example", FirstName: "Oki", OrderUpdates: true, NewOrders: false},
+		}, nil)
+
+		var enqueued []*models.Notification
+		deps.queue.EXPECT().Enqueue(gomock.Any(), notification.JobKindSend, gomock.Any(), gomock.Any()).Times(2).
+			DoAndReturn(func(_ context.Context, _ string, payload interface{}, _ ...jobs.EnqueueOption) (*jobs.Job, error) {
+				enqueued = append(enqueued, payload.(*models.Notification))
+				return &jobs.Job{}, nil
+			})
+
+		require.NoError(t, notificationUC.HandleEvent(context.Background(), event))
+		require.Len(t, enqueued, 2)
+
+		require.Equal(t, event.EventID, enqueued[0].EventID)
+		require.Equal(t, buyerUUID, enqueued[0].UserID)
+		require.Equal(t, models.NotificationOrderPlaced, enqueued[0].Template)
+		require.Equal(t, "id", enqueued[0].Locale)
+		require.Equal(t, `"Budi" <budi@example.com>`, enqueued[0].To)
+		require.Equal(t, "Kalo", enqueued[0].Data.BrandName)
+		require.Equal(t, "Tote bag", enqueued[0].Data.ItemName)
+
+		require.Equal(t, models.NotificationOrderReceived, enqueued[1].Template)
+		require.Equal(t, "en", enqueued[1].Locale)
+		require.Equal(t, "Sari", enqueued[1].Data.RecipientName)
+	})
+
+	t.Run("OrderShipped", func(t *testing.T) {
+		notificationUC, deps := newTestUseCase(t)
+
+		event, err := models.NewOutboxEvent(models.AggregateOrder, createdOrder.OrderID, models.EventOrderStatusChanged, &models.OrderStatusChange{
+			OrderID: createdOrder.OrderID, UserID: buyerUUID, BrandID: brandUUID, From: models.OrderStatusAccepted, To: models.OrderStatusShipped,
+		})
+		require.NoError(t, err)
+
+		deps.orderUC.EXPECT().FindById(gomock.Any(), createdOrder.OrderID).Return(createdOrder, nil)
+		deps.brandUC.EXPECT().CachedFindById(gomock.Any(), brandUUID).Return(&models.Brand{BrandID: brandUUID, BrandName: "Kalo"}, nil)
+		deps.notificationPGRepository.EXPECT().FindRecipientById(gomock.Any(), buyerUUID).Return(buyer, nil)
+		deps.notificationPGRepository.EXPECT().IsSent(gomock.Any(), event.EventID, buyerUUID).Return(false, nil)
+		deps.queue.EXPECT().Enqueue(gomock.Any(), notification.JobKindSend, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, payload interface{}, _ ...jobs.EnqueueOption) (*jobs.Job, error) {
+				n := payload.(*models.Notification)
+				require.Equal(t, models.NotificationOrderShipped, n.Template)
+				require.Equal(t, models.OrderStatusShipped, n.Data.Status)
+				return nil, jobs.ErrDuplicate
+			})
+
+		require.NoError(t, notificationUC.HandleEvent(context.Background(), event))
+	})
+
+	t.Run("BuyerOptedOut", func(t *testing.T) {
+		notificationUC, deps := newTestUseCase(t)
+
+		event, err := models.NewOutboxEvent(models.AggregateOrder, createdOrder.OrderID, models.EventOrderStatusChanged, &models.OrderStatusChange{
+			OrderID: createdOrder.OrderID, UserID: buyerUUID, BrandID: brandUUID, From: models.OrderStatusPaid, To: models.OrderStatusAccepted,
+		})
+		require.NoError(t, err)
+
+		deps.orderUC.EXPECT().FindById(gomock.Any(), createdOrder.OrderID).Return(createdOrder, nil)
+		deps.brandUC.EXPECT().CachedFindById(gomock.Any(), brandUUID).Return(&models.Brand{BrandID: brandUUID, BrandName: "Kalo"}, nil)
+		deps.notificationPGRepository.EXPECT().FindRecipientById(gomock.Any(), buyerUUID).
+			Return(&models.NotificationRecipient{UserID: buyerUUID, Email: "budi@example.com", OrderUpdates: false}, nil)
+
+		require.NoError(t, notificationUC.HandleEvent(context.Background(), event))
+	})
+
+	t.Run("AlreadySent", func(t *testing.T) {
+		// the event is delivered again after the job that mailed the buyer finished
+		notificationUC, deps := newTestUseCase(t)
+
+		event, err := models.NewOutboxEvent(models.AggregateOrder, createdOrder.OrderID, models.EventOrderStatusChanged, &models.OrderStatusChange{
+			OrderID: createdOrder.OrderID, UserID: buyerUUID, BrandID: brandUUID, From: models.OrderStatusAccepted, To: models.OrderStatusShipped,
+		})
+		require.NoError(t, err)
+
+		deps.orderUC.EXPECT().FindById(gomock.Any(), createdOrder.OrderID).Return(createdOrder, nil)
+		deps.brandUC.EXPECT().CachedFindById(gomock.Any(), brandUUID).Return(&models.Brand{BrandID: brandUUID, BrandName: "Kalo"}, nil)
+		deps.notificationPGRepository.EXPECT().FindRecipientById(gomock.Any(), buyerUUID).Return(buyer, nil)
+		deps.notificationPGRepository.EXPECT().IsSent(gomock.Any(), event.EventID, buyerUUID).Return(true, nil)
+
+		require.NoError(t, notificationUC.HandleEvent(context.Background(), event))
+	})
+
+	t.Run("OtherStatus", func(t *testing.T) {
+		notificationUC, _ := newTestUseCase(t)
+
+		event, err := models.NewOutboxEvent(models.AggregateOrder, createdOrder.OrderID, models.EventOrderStatusChanged, &models.OrderStatusChange{
+			OrderID: createdOrder.OrderID, From: models.OrderStatusPending, To: models.OrderStatusPaid,
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, notificationUC.HandleEvent(context.Background(), event))
+	})
+
+	t.Run("QueueDown", func(t *testing.T) {
+		notificationUC, deps := newTestUseCase(t)
+
+		event, err := models.NewOutboxEvent(models.AggregateOrder, createdOrder.OrderID, models.EventOrderCreated, createdOrder)
+		require.NoError(t, err)
+
+		deps.brandUC.EXPECT().CachedFindById(gomock.Any(), brandUUID).Return(&models.Brand{BrandID: brandUUID, BrandName: "Kalo"}, nil)
+		deps.notificationPGRepository.EXPECT().FindRecipientById(gomock.Any(), buyerUUID).Return(buyer, nil)
+		deps.notificationPGRepository.EXPECT().IsSent(gomock.Any(), event.EventID, buyerUUID).Return(false, nil)
+		deps.queue.EXPECT().Enqueue(gomock.Any(), notification.JobKindSend, gomock.Any(), gomock.Any()).Return(nil, errors.New("connection refused"))
+
+		require.Error(t, notificationUC.HandleEvent(context.Background(), event))
+	})
+}
+
+func TestNotificationUseCase_FindPreferences(t *testing.T) {
+	t.Parallel()
+
+	notificationUC, deps := newTestUseCase(t)
+
+	userUUID := uuid.New()
+	deps.notificationPGRepository.EXPECT().FindPreferencesByUserId(gomock.Any(), userUUID).Return(nil, sql.ErrNoRows)
+
+	preferences, err := notificationUC.FindPreferences(context.Background(), userUUID)
+	require.NoError(t, err)
+	require.Equal(t, models.DefaultNotificationPreferences(userUUID), preferences)
+}
+
+func TestNotificationUseCase_Send(t *testing.T) {
+	t.Parallel()
+
+	notificationUC, deps := newTestUseCase(t)
+
+	n := &models.Notification{
+		EventID:  uuid.New(),
+		UserID:   uuid.New(),
+		Template: models.NotificationOrderAccepted,
+		Locale:   "en",
+		To:       `"Budi" <budi@example.com>`,
+		Data:     models.OrderNotificationData{RecipientName: "Budi", BrandName: "Kalo", OrderID: uuid.New(), ItemName: "Tote bag", Quantity: 1},
+	}
+	deps.notificationPGRepository.EXPECT().IsSent(gomock.Any(), n.EventID, n.UserID).Return(false, nil)
+	deps.notificationPGRepository.EXPECT().MarkSent(gomock.Any(), n).Return(nil)
+	require.NoError(t, notificationUC.Send(context.Background(), n))
+
+	// a job run again after the mail went out does not send it twice
+	deps.notificationPGRepository.EXPECT().IsSent(gomock.Any(), n.EventID, n.UserID).Return(true, nil)
+	require.NoError(t, notificationUC.Send(context.Background(), n))
+
+	files, err := filepath.Glob(filepath.Join(deps.mailDir, "*.eml"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	data, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+	require.True(t, strings.Contains(string(data), "Subject: Your order "+n.Data.OrderNumber()+" is accepted"))
+
+	t.Run("UnknownTemplate", func(t *testing.T) {
+		err := notificationUC.Send(context.Background(), &models.Notification{Template: "order_lost", Locale: "en", To: "budi@example.com"})
+		require.Error(t, err)
+		require.True(t, jobs.IsPermanent(err))
+	})
+}
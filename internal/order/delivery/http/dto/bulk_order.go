@@ -0,0 +1,8 @@
+package dto
+
+import "github.com/google/uuid"
+
+type OrderBulkRequestDto struct {
+	Action   string      `json:"action" validate:"required,oneof=accept reject mark_shipped"`
+	OrderIDs []uuid.UUID `json:"order_ids" validate:"required,min=1,max=100,unique"`
+}
@@ -5,8 +5,10 @@ import (
 )
 
 type OrderCreateRequestDto struct {
-	ProductID uuid.UUID `json:"product_id" validate:"required"`
-	Quantity  uint64    `json:"quantity" validate:"required"`
+	ProductID   uuid.UUID  `json:"product_id" validate:"required"`
+	Quantity    uint64     `json:"quantity" validate:"required"`
+	CouponCodes []string   `json:"coupon_codes" validate:"lte=5,dive,required,lte=64"`
+	AddressID   *uuid.UUID `json:"address_id"`
 }
 
 type OrderCreateResponseDto struct {
@@ -9,30 +9,60 @@ import (
 )
 
 type OrderResponseDto struct {
-	OrderID                    uuid.UUID        `json:"order_id"`
-	UserID                     uuid.UUID        `json:"user_id"`
-	BrandID                   uuid.UUID        `json:"brand_id"`
-	Item                       models.OrderItem `json:"item"`
-	Quantity                   uint64           `json:"quantity"`
-	TotalPrice                 float64          `json:"total_price"`
-	Status                     string           `json:"status"`
-	DeliverySourceAddress      string           `json:"delivery_source_address"`
-	DeliveryDestinationAddress string           `json:"delivery_destination_address"`
-	CreatedAt                  time.Time        `json:"created_at,omitempty"`
-	UpdatedAt                  time.Time        `json:"updated_at,omitempty"`
+	OrderID                    uuid.UUID                `json:"order_id"`
+	UserID                     uuid.UUID                `json:"user_id"`
+	BrandID                    uuid.UUID                `json:"brand_id"`
+	Item                       models.OrderItem         `json:"item"`
+	Quantity                   uint64                   `json:"quantity"`
+	TotalPrice                 float64                  `json:"total_price"`
+	Status                     string                   `json:"status"`
+	DeliverySourceAddress      string                   `json:"delivery_source_address"`
+	DeliveryDestinationAddress string                   `json:"delivery_destination_address"`
+	RefundedQuantity           uint64                   `json:"refunded_quantity"`
+	RefundedAmount             float64                  `json:"refunded_amount"`
+	NetTotal                   float64                  `json:"net_total"`
+	DiscountTotal              float64                  `json:"discount_total"`
+	AppliedPromotions          models.AppliedPromotions `json:"applied_promotions"`
+	FreeShipping               bool                     `json:"free_shipping"`
+	TaxTotal                   float64                  `json:"tax_total"`
+	TaxLines                   models.TaxLines          `json:"tax_lines"`
+	DeliveryFee                float64                  `json:"delivery_fee"`
+	DeliveryDistance           float64                  `json:"delivery_distance"`
+	DeliveryAddress            *models.OrderAddress     `json:"delivery_address,omitempty"`
+	LocationID                 *uuid.UUID               `json:"location_id,omitempty"`
+	DeliveredAt                *time.Time               `json:"delivered_at,omitempty"`
+	Version                    int                      `json:"version"`
+	DeletedAt                  *time.Time               `json:"deleted_at,omitempty"`
+	CreatedAt                  time.Time                `json:"created_at,omitempty"`
+	UpdatedAt                  time.Time                `json:"updated_at,omitempty"`
 }
 
 func OrderResponseFromModel(order *models.Order) *OrderResponseDto {
 	return &OrderResponseDto{
 		OrderID:                    order.OrderID,
 		UserID:                     order.UserID,
-		BrandID:                   order.BrandID,
+		BrandID:                    order.BrandID,
 		Item:                       order.Item,
 		Quantity:                   order.Quantity,
 		TotalPrice:                 order.TotalPrice,
 		Status:                     order.Status,
 		DeliverySourceAddress:      order.DeliverySourceAddress,
 		DeliveryDestinationAddress: order.DeliveryDestinationAddress,
+		RefundedQuantity:           order.RefundedQuantity,
+		RefundedAmount:             order.RefundedAmount,
+		NetTotal:                   order.NetTotal(),
+		DiscountTotal:              order.DiscountTotal,
+		AppliedPromotions:          order.AppliedPromotions,
+		FreeShipping:               order.FreeShipping,
+		TaxTotal:                   order.TaxTotal,
+		TaxLines:                   order.TaxLines,
+		DeliveryFee:                order.DeliveryFee,
+		DeliveryDistance:           order.DeliveryDistance,
+		DeliveryAddress:            order.DeliveryAddress,
+		LocationID:                 order.LocationID,
+		DeliveredAt:                order.DeliveredAt,
+		Version:                    order.Version,
+		DeletedAt:                  order.DeletedAt,
 		CreatedAt:                  order.CreatedAt,
 		UpdatedAt:                  order.UpdatedAt,
 	}
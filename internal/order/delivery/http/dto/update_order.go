@@ -1,5 +1,5 @@
 package dto
 
 type OrderUpdateRequestDto struct {
-	Status string `json:"status"`
+	Status *string `json:"status" validate:"omitempty,oneof=pending accepted"`
 }
@@ -1,69 +1,90 @@
 package handlers
 
 import (
+	"context"
+	"database/sql"
 	"encoding/json"
 	"errors"
 	"net/http"
+	"net/url"
 
 	"github.com/go-playground/validator"
 	"github.com/go-redis/redis/v8"
 	"github.com/google/uuid"
 
 	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/address"
 	"github.com/dinorain/kalobranded/internal/brand"
+	"github.com/dinorain/kalobranded/internal/deliveryfee"
+	"github.com/dinorain/kalobranded/internal/location"
 	"github.com/dinorain/kalobranded/internal/middlewares"
 	"github.com/dinorain/kalobranded/internal/models"
 	"github.com/dinorain/kalobranded/internal/order"
 	"github.com/dinorain/kalobranded/internal/order/delivery/http/dto"
 	"github.com/dinorain/kalobranded/internal/product"
+	"github.com/dinorain/kalobranded/internal/promotion"
+	"github.com/dinorain/kalobranded/internal/server/router"
 	"github.com/dinorain/kalobranded/internal/session"
+	"github.com/dinorain/kalobranded/internal/taxrate"
 	"github.com/dinorain/kalobranded/internal/user"
 	"github.com/dinorain/kalobranded/pkg/constants"
+	"github.com/dinorain/kalobranded/pkg/geo"
 	httpErrors "github.com/dinorain/kalobranded/pkg/http_errors"
 	"github.com/dinorain/kalobranded/pkg/logger"
+	"github.com/dinorain/kalobranded/pkg/shipping"
 	"github.com/dinorain/kalobranded/pkg/utils"
 )
 
 type orderHandlersHTTP struct {
-	mux       *http.ServeMux
-	logger    logger.Logger
-	cfg       *config.Config
-	mw        middlewares.MiddlewareManager
-	v         *validator.Validate
-	orderUC   order.OrderUseCase
-	userUC    user.UserUseCase
-	brandUC   brand.BrandUseCase
-	productUC product.ProductUseCase
-	sessUC    session.SessUseCase
+	router        *router.Router
+	logger        logger.Logger
+	cfg           *config.Config
+	mw            middlewares.MiddlewareManager
+	v             *validator.Validate
+	orderUC       order.OrderUseCase
+	userUC        user.UserUseCase
+	addressUC     address.AddressUseCase
+	brandUC       brand.BrandUseCase
+	productUC     product.ProductUseCase
+	promotionUC   promotion.PromotionUseCase
+	taxRateUC     taxrate.TaxRateUseCase
+	deliveryFeeUC deliveryfee.DeliveryFeeUseCase
+	locationUC    location.LocationUseCase
+	sessUC        session.SessUseCase
 }
 
 var _ order.OrderHandlers = (*orderHandlersHTTP)(nil)
 
 func NewOrderHandlersHTTP(
-	mux *http.ServeMux,
+	router *router.Router,
 	logger logger.Logger,
 	cfg *config.Config,
 	mw middlewares.MiddlewareManager,
 	v *validator.Validate,
 	orderUC order.OrderUseCase,
 	userUC user.UserUseCase,
+	addressUC address.AddressUseCase,
 	brandUC brand.BrandUseCase,
 	productUC product.ProductUseCase,
+	promotionUC promotion.PromotionUseCase,
+	taxRateUC taxrate.TaxRateUseCase,
+	deliveryFeeUC deliveryfee.DeliveryFeeUseCase,
+	locationUC location.LocationUseCase,
 	sessUC session.SessUseCase,
 ) *orderHandlersHTTP {
-	return &orderHandlersHTTP{mux: mux, logger: logger, cfg: cfg, mw: mw, v: v, orderUC: orderUC, userUC: userUC, brandUC: brandUC, productUC: productUC, sessUC: sessUC}
+	return &orderHandlersHTTP{router: router, logger: logger, cfg: cfg, mw: mw, v: v, orderUC: orderUC, userUC: userUC, addressUC: addressUC, brandUC: brandUC, productUC: productUC, promotionUC: promotionUC, taxRateUC: taxRateUC, deliveryFeeUC: deliveryFeeUC, locationUC: locationUC, sessUC: sessUC}
 }
 
 // Create
 // @Tags Orders
 // @Summary To create order
-// @Description Order create order
+// @Description Order create order delivered to the given address of the user address book, else to the default one, else to the profile delivery address. The address is kept on the order as it was when ordering. Priced with the running promotions of the product and the given coupon codes, then taxed with the rate table of the delivery region. Brands with locations ship from the nearest active one holding the ordered quantity, taking it off its stock, others from their pickup address. The delivery fee of the distance between the shipping origin and the user delivery coordinates, geocoded from the addresses when unset, and of the item weight is added untaxed
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
 // @Param payload body dto.OrderCreateRequestDto true "Payload"
 // @Success 200 {object} dto.OrderCreateResponseDto
-// @Router /order/create [post]
+// @Router /orders [post]
 func (h *orderHandlersHTTP) Create(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -105,6 +126,15 @@ func (h *orderHandlersHTTP) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	deliveryAddress, err := h.findDeliveryAddress(w, r, user.UserID, createDto.AddressID)
+	if err != nil {
+		return
+	}
+	if deliveryAddress == nil && user.DeliveryAddress == "" {
+		_ = httpErrors.NewBadRequestError(w, models.ErrDeliveryAddressRequired.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
 	product, err := h.productUC.CachedFindById(ctx, createDto.ProductID)
 	if err != nil {
 		h.logger.Errorf("productUC.CachedFindById: %v", err)
@@ -119,16 +149,74 @@ func (h *orderHandlersHTTP) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	order, err := h.registerReqToOrderModel(createDto, user, brand, product)
+	dropoff, destination := user.DeliveryPoint(), user.DeliveryAddress
+	if deliveryAddress != nil {
+		dropoff, destination = deliveryAddress.Point(), deliveryAddress.Format()
+	}
+
+	location, err := h.locationUC.FindNearestInStock(ctx, brand.BrandID, product.ProductID, createDto.Quantity, dropoff, destination)
+	if err != nil {
+		h.logger.Errorf("locationUC.FindNearestInStock: %v", err)
+		if errors.Is(err, models.ErrOutOfStock) {
+			_ = httpErrors.NewConflictError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+			return
+		}
+		if errors.Is(err, geo.ErrAddressNotFound) {
+			_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+			return
+		}
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	order, err := h.registerReqToOrderModel(createDto, user, deliveryAddress, brand, location, product)
 	if err != nil {
 		h.logger.Errorf("orderHandlersHTTP.registerReqToOrderModel: %v", err)
 		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
 		return
 	}
 
+	order, err = h.promotionUC.Apply(ctx, order, createDto.CouponCodes)
+	if err != nil {
+		h.logger.Errorf("promotionUC.Apply: %v", err)
+		if errors.Is(err, models.ErrPromotionNotApplicable) || errors.Is(err, models.ErrPromotionUsageLimitReached) {
+			_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+			return
+		}
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	order, err = h.taxRateUC.Apply(ctx, order)
+	if err != nil {
+		h.logger.Errorf("taxRateUC.Apply: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	pickup := brand.PickupPoint()
+	if location != nil {
+		pickup = location.Point()
+	}
+
+	order, err = h.deliveryFeeUC.Apply(ctx, order, pickup, dropoff)
+	if err != nil {
+		h.logger.Errorf("deliveryFeeUC.Apply: %v", err)
+		if errors.Is(err, shipping.ErrTooHeavy) {
+			_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+			return
+		}
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
 	createdOrder, err := h.orderUC.Create(ctx, order)
 	if err != nil {
 		h.logger.Errorf("orderUC.Create: %v", err)
+		if errors.Is(err, models.ErrPromotionUsageLimitReached) || errors.Is(err, models.ErrOutOfStock) {
+			_ = httpErrors.NewConflictError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+			return
+		}
 		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
 		return
 	}
@@ -142,20 +230,43 @@ func (h *orderHandlersHTTP) Create(w http.ResponseWriter, r *http.Request) {
 // FindAll
 // @Tags Orders
 // @Summary Find all orders
-// @Description Find all orders, will be filtered by user_id when accessed by user role
+// @Description Find all orders, users and sellers find their own orders only. Orders are newest first unless sorted, sort is one of created_at, updated_at, total_price, quantity and status, descending when prefixed with -. status takes a comma separated list of statuses, created_from and created_to an RFC 3339 time or a date, created_to excluded for times and included for dates. Without page the meta links the next and prev pages by cursor, read after or before a row instead of skipping rows, with page it links them by page number. count=exact counts the matching orders, count=estimate takes the planner estimate for large lists
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
+// @Param status query string false "order statuses, comma separated"
+// @Param brand_id query string false "brand uuid"
+// @Param product_id query string false "product uuid"
+// @Param user_id query string false "buyer uuid, admin only"
+// @Param created_from query string false "created at or after"
+// @Param created_to query string false "created before, or on the date"
+// @Param min_total query number false "minimum total price"
+// @Param max_total query number false "maximum total price"
+// @Param sort query string false "sort key, e.g. -total_price"
 // @Param size query string false "pagination size"
 // @Param page query string false "pagination page"
+// @Param cursor query string false "cursor of the next or prev link of meta"
+// @Param count query string false "total count, exact or estimate"
 // @Success 200 {object} dto.OrderFindResponseDto
-// @Router /order [get]
+// @Param include_deleted query bool false "admin only, include soft deleted orders"
+// @Router /orders [get]
 func (h *orderHandlersHTTP) FindAll(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	queryParam := r.URL.Query()
 	pq := utils.NewPaginationFromQueryParams(queryParam.Get(constants.Size), queryParam.Get(constants.Page))
+	pq.SetOrderBy(queryParam.Get(constants.Sort))
 
-	var orders []models.Order
+	filter, err := parseOrderFilter(queryParam, pq)
+	if err != nil {
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	count, err := utils.ParseQueryCount(queryParam, constants.Count)
+	if err != nil {
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
 
 	sessID, _, role, err := h.getSessionIDFromCtx(w, r)
 	if err != nil {
@@ -163,6 +274,11 @@ func (h *orderHandlersHTTP) FindAll(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	includeDeleted, err := h.mw.IncludeDeleted(w, r)
+	if err != nil {
+		return
+	}
+
 	session, err := h.sessUC.GetSessionById(ctx, sessID)
 	if err != nil {
 		h.logger.Errorf("sessUC.GetSessionById: %v", err)
@@ -174,34 +290,28 @@ func (h *orderHandlersHTTP) FindAll(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if role == models.UserRoleAdmin {
-		if res, err := h.orderUC.FindAll(ctx, pq); err != nil {
-			h.logger.Errorf("orderUC.FindAll: %v", err)
-			_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
-			return
-		} else {
-			orders = res
-		}
+	findAll, countAll := h.orderUC.FindAll, h.orderUC.Count
+	if role != models.UserRoleAdmin {
+		filter.UserID = &session.UserID
+	} else if includeDeleted {
+		findAll, countAll = h.orderUC.FindAllWithDeleted, h.orderUC.CountWithDeleted
+	}
 
-	} else {
-		userUUID := session.UserID
-		if res, err := h.orderUC.FindAllByUserId(ctx, userUUID, pq); err != nil {
-			h.logger.Errorf("orderUC.FindAll: %v", err)
-			_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
-			return
-		} else {
-			orders = res
-		}
+	orders, err := findAll(ctx, filter, pq)
+	if err != nil {
+		h.logger.Errorf("orderUC.FindAll: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
 
+	meta, err := h.paginationMeta(r, pq, filter, orders, count, countAll)
+	if err != nil {
+		h.logger.Errorf("paginationMeta: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
 	}
 
-	res, _ := json.Marshal(dto.OrderFindResponseDto{
-		Data: orders,
-		Meta: utils.PaginationMetaDto{
-			Limit:  pq.GetLimit(),
-			Offset: pq.GetOffset(),
-			Page:   pq.GetPage(),
-		}})
+	res, _ := json.Marshal(dto.OrderFindResponseDto{Data: orders, Meta: meta})
 	w.WriteHeader(http.StatusOK)
 	w.Write(res)
 	return
@@ -210,41 +320,190 @@ func (h *orderHandlersHTTP) FindAll(w http.ResponseWriter, r *http.Request) {
 // FindById
 // @Tags Orders
 // @Summary Find order by id
-// @Description Find order by id
+// @Description Find order by id, users can only find their own orders
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
-// @Param id query string false "order uuid"
+// @Param id path string true "order uuid"
+// @Param include_deleted query bool false "admin only, find soft deleted order"
 // @Success 200 {object} dto.OrderResponseDto
-// @Router /order [get]
+// @Header 200 {string} ETag "resource version"
+// @Router /orders/{id} [get]
 func (h *orderHandlersHTTP) FindById(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	queryParam := r.URL.Query()
+	orderUUID, err := uuid.Parse(router.Param(r, constants.ID))
+	if err != nil {
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
 
-	if queryParam.Get("id") == "" {
-		_ = httpErrors.NewBadRequestError(w, nil, h.cfg.Http.DebugErrorsResponse)
+	_, userID, role, err := h.getSessionIDFromCtx(w, r)
+	if err != nil {
+		h.logger.Errorf("getSessionIDFromCtx: %v", err)
 		return
 	}
-	orderUUID, err := uuid.Parse(r.URL.Query().Get("id"))
+
+	includeDeleted, err := h.mw.IncludeDeleted(w, r)
 	if err != nil {
+		return
+	}
+
+	findById := h.orderUC.CachedFindById
+	if includeDeleted {
+		findById = h.orderUC.FindByIdWithDeleted
+	}
+
+	order, err := findById(ctx, orderUUID)
+	if err != nil {
+		h.logger.Errorf("orderUC.FindById: %v", err)
 		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
 		return
 	}
 
-	order, err := h.orderUC.CachedFindById(ctx, orderUUID)
+	if role != models.UserRoleAdmin && order.UserID.String() != userID {
+		_ = httpErrors.NewForbiddenError(w, nil, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	w.Header().Set(constants.ETag, utils.ETag(order.Version))
+	res, _ := json.Marshal(dto.OrderResponseFromModel(order))
+	w.WriteHeader(http.StatusOK)
+	w.Write(res)
+	return
+}
+
+// UpdateById
+// @Tags Orders
+// @Summary Update order
+// @Description Admin update order, only provided fields are changed, an order can be accepted once paid
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "order uuid"
+// @Param If-Match header string false "ETag of the version being changed"
+// @Param payload body dto.OrderUpdateRequestDto true "Payload"
+// @Success 200 {object} dto.OrderResponseDto
+// @Header 200 {string} ETag "resource version"
+// @Router /orders/{id} [patch]
+func (h *orderHandlersHTTP) UpdateById(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	orderUUID, err := uuid.Parse(router.Param(r, constants.ID))
 	if err != nil {
-		h.logger.Errorf("orderUC.CachedFindById: %v", err)
 		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
 		return
 	}
 
+	updateDto := &dto.OrderUpdateRequestDto{}
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&updateDto); err != nil {
+		h.logger.Errorf("decoder.Decode: %v", err)
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	if err := h.v.Struct(updateDto); err != nil {
+		h.logger.Errorf("h.v.Struct: %v", err)
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	order, err := h.orderUC.FindById(ctx, orderUUID)
+	if err != nil {
+		h.logger.Errorf("orderUC.FindById: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	if !utils.IfMatch(r.Header.Get(constants.IfMatch), order.Version) {
+		_ = httpErrors.ErrorCtxResponse(w, httpErrors.PreconditionFailed, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	if updateDto.Status != nil && *updateDto.Status != order.Status {
+		if !order.CanTransitionTo(*updateDto.Status) {
+			_ = httpErrors.ErrorCtxResponse(w, models.ErrInvalidStatusTransition, h.cfg.Http.DebugErrorsResponse)
+			return
+		}
+		order.Status = *updateDto.Status
+	}
+
+	updatedOrder, err := h.orderUC.UpdateById(ctx, order)
+	if err != nil {
+		h.logger.Errorf("orderUC.UpdateById: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	w.Header().Set(constants.ETag, utils.ETag(updatedOrder.Version))
+	res, _ := json.Marshal(dto.OrderResponseFromModel(updatedOrder))
+	w.WriteHeader(http.StatusOK)
+	w.Write(res)
+	return
+}
+
+// DeleteById
+// @Tags Orders
+// @Summary Delete order
+// @Description Admin delete order
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "order uuid"
+// @Param If-Match header string false "ETag of the version being changed"
+// @Success 204 {object} nil
+// @Router /orders/{id} [delete]
+func (h *orderHandlersHTTP) DeleteById(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	orderUUID, err := uuid.Parse(router.Param(r, constants.ID))
+	if err != nil {
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	if err := h.orderUC.DeleteById(ctx, orderUUID, utils.IfMatchVersions(r.Header.Get(constants.IfMatch))); err != nil {
+		h.logger.Errorf("orderUC.DeleteById: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return
+}
+
+// RestoreById
+// @Tags Orders
+// @Summary Restore order
+// @Description Admin restore soft deleted order
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "order uuid"
+// @Success 200 {object} dto.OrderResponseDto
+// @Header 200 {string} ETag "resource version"
+// @Router /orders/{id}/restore [post]
+func (h *orderHandlersHTTP) RestoreById(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	orderUUID, err := uuid.Parse(router.Param(r, constants.ID))
+	if err != nil {
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	order, err := h.orderUC.RestoreById(ctx, orderUUID)
+	if err != nil {
+		h.logger.Errorf("orderUC.RestoreById: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	w.Header().Set(constants.ETag, utils.ETag(order.Version))
 	res, _ := json.Marshal(dto.OrderResponseFromModel(order))
 	w.WriteHeader(http.StatusOK)
 	w.Write(res)
 	return
 }
 
-func (h *orderHandlersHTTP) registerReqToOrderModel(r *dto.OrderCreateRequestDto, user *models.User, brand *models.Brand, product *models.Product) (*models.Order, error) {
+func (h *orderHandlersHTTP) registerReqToOrderModel(r *dto.OrderCreateRequestDto, user *models.User, deliveryAddress *models.UserAddress, brand *models.Brand, location *models.BrandLocation, product *models.Product) (*models.Order, error) {
 	orderCandidate := &models.Order{
 		UserID:  user.UserID,
 		BrandID: brand.BrandID,
@@ -254,6 +513,8 @@ func (h *orderHandlersHTTP) registerReqToOrderModel(r *dto.OrderCreateRequestDto
 			Description: product.Description,
 			Price:       product.Price,
 			BrandID:     product.BrandID,
+			Category:    product.Category,
+			Weight:      product.Weight,
 			CreatedAt:   product.CreatedAt,
 			UpdatedAt:   product.UpdatedAt,
 		},
@@ -263,10 +524,217 @@ func (h *orderHandlersHTTP) registerReqToOrderModel(r *dto.OrderCreateRequestDto
 		DeliverySourceAddress:      brand.PickupAddress,
 		DeliveryDestinationAddress: user.DeliveryAddress,
 	}
+	if deliveryAddress != nil {
+		orderCandidate.DeliveryDestinationAddress = deliveryAddress.Format()
+		orderCandidate.DeliveryAddress = (*models.OrderAddress)(deliveryAddress)
+	}
+	if location != nil {
+		orderCandidate.DeliverySourceAddress = location.Address
+		orderCandidate.LocationID = &location.LocationID
+	}
 
 	return orderCandidate, nil
 }
 
+// findDeliveryAddress address book entry the order goes to, the one of addressID or else the default of the user.
+// Nil when the user has no default address. Error response is already written when err is not nil
+func (h *orderHandlersHTTP) findDeliveryAddress(w http.ResponseWriter, r *http.Request, userID uuid.UUID, addressID *uuid.UUID) (*models.UserAddress, error) {
+	ctx := r.Context()
+
+	if addressID == nil {
+		defaultAddress, err := h.addressUC.FindDefaultByUserId(ctx, userID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, nil
+			}
+			h.logger.Errorf("addressUC.FindDefaultByUserId: %v", err)
+			_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+			return nil, err
+		}
+		return defaultAddress, nil
+	}
+
+	foundAddress, err := h.addressUC.FindById(ctx, *addressID)
+	if err != nil {
+		h.logger.Errorf("addressUC.FindById: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return nil, err
+	}
+
+	if foundAddress.UserID != userID {
+		return nil, httpErrors.NewForbiddenError(w, nil, h.cfg.Http.DebugErrorsResponse)
+	}
+
+	return foundAddress, nil
+}
+
+// FindInbox
+// @Tags Orders
+// @Summary Find brand order inbox
+// @Description Admin or seller of the brand find the orders of the brand, filtered, sorted, paged and counted as orders are
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "brand uuid"
+// @Param status query string false "order statuses, comma separated"
+// @Param created_from query string false "created at or after"
+// @Param created_to query string false "created before, or on the date"
+// @Param user_id query string false "buyer uuid"
+// @Param product_id query string false "product uuid"
+// @Param min_total query number false "minimum total price"
+// @Param max_total query number false "maximum total price"
+// @Param sort query string false "sort key, e.g. -total_price"
+// @Param size query string false "pagination size"
+// @Param page query string false "pagination page"
+// @Param cursor query string false "cursor of the next or prev link of meta"
+// @Param count query string false "total count, exact or estimate"
+// @Success 200 {object} dto.OrderFindResponseDto
+// @Router /brands/{id}/orders [get]
+func (h *orderHandlersHTTP) FindInbox(w http.ResponseWriter, r *http.Request) {
+	queryParam := r.URL.Query()
+	pq := utils.NewPaginationFromQueryParams(queryParam.Get(constants.Size), queryParam.Get(constants.Page))
+	pq.SetOrderBy(queryParam.Get(constants.Sort))
+
+	brandUUID, err := uuid.Parse(router.Param(r, constants.ID))
+	if err != nil {
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	if err := h.mw.CheckBrand(w, r, brandUUID); err != nil {
+		return
+	}
+
+	filter, err := parseOrderFilter(queryParam, pq)
+	if err != nil {
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+	filter.BrandID = &brandUUID
+
+	count, err := utils.ParseQueryCount(queryParam, constants.Count)
+	if err != nil {
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	orders, err := h.orderUC.FindAll(r.Context(), filter, pq)
+	if err != nil {
+		h.logger.Errorf("orderUC.FindAll: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	meta, err := h.paginationMeta(r, pq, filter, orders, count, h.orderUC.Count)
+	if err != nil {
+		h.logger.Errorf("paginationMeta: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	data := make([]*dto.OrderResponseDto, 0, len(orders))
+	for i := range orders {
+		data = append(data, dto.OrderResponseFromModel(&orders[i]))
+	}
+
+	res, _ := json.Marshal(dto.OrderFindResponseDto{Data: data, Meta: meta})
+	w.WriteHeader(http.StatusOK)
+	w.Write(res)
+	return
+}
+
+// BulkAction
+// @Tags Orders
+// @Summary Bulk update brand orders
+// @Description Admin or seller of the brand accept, reject or mark shipped up to 100 orders of the brand at once. The action applies to every order or, when one of them is not found or cannot take it, to none. Paid orders can be accepted or rejected and accepted orders marked shipped, rejected orders are then refunded. The report gives the outcome per order, the orders preventing the action have an error
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "brand uuid"
+// @Param payload body dto.OrderBulkRequestDto true "Payload"
+// @Success 200 {object} models.OrderBulkResult
+// @Failure 409 {object} models.OrderBulkResult
+// @Router /brands/{id}/orders/bulk [post]
+func (h *orderHandlersHTTP) BulkAction(w http.ResponseWriter, r *http.Request) {
+	brandUUID, err := uuid.Parse(router.Param(r, constants.ID))
+	if err != nil {
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	if err := h.mw.CheckBrand(w, r, brandUUID); err != nil {
+		return
+	}
+
+	bulkDto := &dto.OrderBulkRequestDto{}
+	if err := json.NewDecoder(r.Body).Decode(bulkDto); err != nil {
+		h.logger.Errorf("decoder.Decode: %v", err)
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	if err := h.v.Struct(bulkDto); err != nil {
+		h.logger.Errorf("h.v.Struct: %v", err)
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	result, err := h.orderUC.BulkAction(r.Context(), brandUUID, bulkDto.Action, bulkDto.OrderIDs)
+	if err != nil {
+		h.logger.Errorf("orderUC.BulkAction: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	status := http.StatusOK
+	if !result.Applied {
+		status = http.StatusConflict
+	}
+
+	res, _ := json.Marshal(result)
+	w.WriteHeader(status)
+	w.Write(res)
+	return
+}
+
+// paginationMeta meta of a page of orders, with the total of the list when count asks for it
+func (h *orderHandlersHTTP) paginationMeta(
+	r *http.Request,
+	pagination *utils.Pagination,
+	filter *models.OrderFilter,
+	orders []models.Order,
+	count string,
+	countAll func(ctx context.Context, filter *models.OrderFilter, estimate bool) (int, error),
+) (utils.PaginationMetaDto, error) {
+	var total *int
+	if count != "" {
+		n, err := countAll(r.Context(), filter, count == utils.CountEstimate)
+		if err != nil {
+			return utils.PaginationMetaDto{}, err
+		}
+		total = &n
+	}
+
+	var first, last utils.CursorRow
+	if len(orders) > 0 {
+		first, last = &orders[0], &orders[len(orders)-1]
+	}
+
+	return utils.NewPaginationMetaDto(r.URL, pagination, models.OrderSorts, len(orders), first, last, total)
+}
+
+// parseOrderFilter order filter of the query parameters, checking the cursor and sort of pagination too
+func parseOrderFilter(queryParam url.Values, pagination *utils.Pagination) (*models.OrderFilter, error) {
+	if err := pagination.SetCursor(queryParam.Get(constants.Cursor)); err != nil {
+		return nil, err
+	}
+	if err := models.OrderSorts.Validate(pagination.GetOrderBy()); err != nil {
+		return nil, err
+	}
+
+	return models.ParseOrderFilter(queryParam)
+}
+
 func (h *orderHandlersHTTP) getSessionIDFromCtx(w http.ResponseWriter, r *http.Request) (sessionID string, userID string, role string, err error) {
 	jwtClaims, err := h.mw.GetJWTClaims(w, r)
 	if err != nil {
@@ -2,6 +2,8 @@ package handlers
 
 import (
 	"bytes"
+	"context"
+	"database/sql"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -18,16 +20,24 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/dinorain/kalobranded/config"
+	mockAddressUC "github.com/dinorain/kalobranded/internal/address/mock"
 	mockBrandUC "github.com/dinorain/kalobranded/internal/brand/mock"
+	mockDeliveryFeeUC "github.com/dinorain/kalobranded/internal/deliveryfee/mock"
+	mockLocationUC "github.com/dinorain/kalobranded/internal/location/mock"
 	"github.com/dinorain/kalobranded/internal/middlewares"
 	"github.com/dinorain/kalobranded/internal/models"
 	"github.com/dinorain/kalobranded/internal/order/delivery/http/dto"
 	"github.com/dinorain/kalobranded/internal/order/mock"
 	mockProductUC "github.com/dinorain/kalobranded/internal/product/mock"
+	mockPromotionUC "github.com/dinorain/kalobranded/internal/promotion/mock"
+	"github.com/dinorain/kalobranded/internal/server/router"
 	mockSessUC "github.com/dinorain/kalobranded/internal/session/mock"
+	mockTaxRateUC "github.com/dinorain/kalobranded/internal/taxrate/mock"
 	mockUserUC "github.com/dinorain/kalobranded/internal/user/mock"
 	"github.com/dinorain/kalobranded/pkg/converter"
+	"github.com/dinorain/kalobranded/pkg/geo"
 	"github.com/dinorain/kalobranded/pkg/logger"
+	"github.com/dinorain/kalobranded/pkg/utils"
 )
 
 func TestOrdersHandler_Create(t *testing.T) {
@@ -39,8 +49,13 @@ func TestOrdersHandler_Create(t *testing.T) {
 	orderUC := mock.NewMockOrderUseCase(ctrl)
 	sessUC := mockSessUC.NewMockSessUseCase(ctrl)
 	userUC := mockUserUC.NewMockUserUseCase(ctrl)
+	addressUC := mockAddressUC.NewMockAddressUseCase(ctrl)
 	brandUC := mockBrandUC.NewMockBrandUseCase(ctrl)
 	productUC := mockProductUC.NewMockProductUseCase(ctrl)
+	promotionUC := mockPromotionUC.NewMockPromotionUseCase(ctrl)
+	taxRateUC := mockTaxRateUC.NewMockTaxRateUseCase(ctrl)
+	deliveryFeeUC := mockDeliveryFeeUC.NewMockDeliveryFeeUseCase(ctrl)
+	locationUC := mockLocationUC.NewMockLocationUseCase(ctrl)
 
 	cfg := &config.Config{Session: config.Session{Expire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
 	appLogger := logger.NewAppLogger(cfg)
@@ -48,8 +63,8 @@ func TestOrdersHandler_Create(t *testing.T) {
 
 	v := validator.New()
 
-	mux := http.NewServeMux()
-	handlers := NewOrderHandlersHTTP(mux, appLogger, cfg, mw, v, orderUC, userUC, brandUC, productUC, sessUC)
+	rt := router.NewRouter(false)
+	handlers := NewOrderHandlersHTTP(rt, appLogger, cfg, mw, v, orderUC, userUC, addressUC, brandUC, productUC, promotionUC, taxRateUC, deliveryFeeUC, locationUC, sessUC)
 
 	userUUID := uuid.New()
 	brandUUID := uuid.New()
@@ -73,7 +88,7 @@ func TestOrdersHandler_Create(t *testing.T) {
 	claims["exp"] = time.Now().Add(time.Minute * 15).Unix()
 	validToken, _ := token.SignedString([]byte(cfg.Server.JwtSecretKey))
 
-	req := httptest.NewRequest(http.MethodPost, "/order/create", buf)
+	req := httptest.NewRequest(http.MethodPost, "/orders", buf)
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", validToken))
 	w := httptest.NewRecorder()
@@ -85,9 +100,20 @@ func TestOrdersHandler_Create(t *testing.T) {
 	buf, _ = converter.AnyToBytesBuffer(wDto)
 
 	sessUC.EXPECT().GetSessionById(gomock.Any(), sessUUID.String()).AnyTimes().Return(&models.Session{UserID: userUUID, SessionID: sessUUID.String()}, nil)
-	userUC.EXPECT().CachedFindById(gomock.Any(), userUUID).AnyTimes().Return(&models.User{UserID: userUUID}, nil)
+	userUC.EXPECT().CachedFindById(gomock.Any(), userUUID).AnyTimes().Return(&models.User{UserID: userUUID, DeliveryAddress: "Home, Bandung, ID"}, nil)
+	addressUC.EXPECT().FindDefaultByUserId(gomock.Any(), userUUID).AnyTimes().Return(nil, sql.ErrNoRows)
 	productUC.EXPECT().CachedFindById(gomock.Any(), productUUID).AnyTimes().Return(&models.Product{ProductID: productUUID, BrandID: brandUUID}, nil)
 	brandUC.EXPECT().CachedFindById(gomock.Any(), brandUUID).AnyTimes().Return(&models.Brand{BrandID: brandUUID}, nil)
+	promotionUC.EXPECT().Apply(gomock.Any(), gomock.Any(), reqDto.CouponCodes).AnyTimes().DoAndReturn(func(_ context.Context, order *models.Order, _ []string) (*models.Order, error) {
+		return order, nil
+	})
+	taxRateUC.EXPECT().Apply(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(_ context.Context, order *models.Order) (*models.Order, error) {
+		return order, nil
+	})
+	locationUC.EXPECT().FindNearestInStock(gomock.Any(), brandUUID, productUUID, reqDto.Quantity, nil, "Home, Bandung, ID").AnyTimes().Return(nil, nil)
+	deliveryFeeUC.EXPECT().Apply(gomock.Any(), gomock.Any(), nil, nil).AnyTimes().DoAndReturn(func(_ context.Context, order *models.Order, _, _ *geo.Point) (*models.Order, error) {
+		return order, nil
+	})
 	orderUC.EXPECT().Create(gomock.Any(), gomock.Any()).AnyTimes().Return(&models.Order{OrderID: orderUUID}, nil)
 
 	handler := http.HandlerFunc(handlers.Create)
@@ -104,6 +130,239 @@ func TestOrdersHandler_Create(t *testing.T) {
 	require.Equal(t, strings.Trim(buf.String(), "\n"), string(data))
 }
 
+func TestOrdersHandler_CreateWithAddress(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orderUC := mock.NewMockOrderUseCase(ctrl)
+	sessUC := mockSessUC.NewMockSessUseCase(ctrl)
+	userUC := mockUserUC.NewMockUserUseCase(ctrl)
+	addressUC := mockAddressUC.NewMockAddressUseCase(ctrl)
+	brandUC := mockBrandUC.NewMockBrandUseCase(ctrl)
+	productUC := mockProductUC.NewMockProductUseCase(ctrl)
+	promotionUC := mockPromotionUC.NewMockPromotionUseCase(ctrl)
+	taxRateUC := mockTaxRateUC.NewMockTaxRateUseCase(ctrl)
+	deliveryFeeUC := mockDeliveryFeeUC.NewMockDeliveryFeeUseCase(ctrl)
+	locationUC := mockLocationUC.NewMockLocationUseCase(ctrl)
+
+	cfg := &config.Config{Session: config.Session{Expire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	appLogger := logger.NewAppLogger(cfg)
+	appLogger.InitLogger()
+	mw := middlewares.NewMiddlewareManager(appLogger, cfg)
+
+	v := validator.New()
+
+	rt := router.NewRouter(false)
+	handlers := NewOrderHandlersHTTP(rt, appLogger, cfg, mw, v, orderUC, userUC, addressUC, brandUC, productUC, promotionUC, taxRateUC, deliveryFeeUC, locationUC, sessUC)
+
+	userUUID := uuid.New()
+	brandUUID := uuid.New()
+	sessUUID := uuid.New()
+	productUUID := uuid.New()
+	addressUUID := uuid.New()
+
+	token := jwt.New(jwt.SigningMethodHS256)
+	claims := token.Claims.(jwt.MapClaims)
+	claims["session_id"] = sessUUID.String()
+	claims["user_id"] = userUUID.String()
+	claims["role"] = models.UserRoleUser
+	claims["exp"] = time.Now().Add(time.Minute * 15).Unix()
+	validToken, _ := token.SignedString([]byte(cfg.Server.JwtSecretKey))
+
+	newRequest := func() *http.Request {
+		buf := &bytes.Buffer{}
+		_ = json.NewEncoder(buf).Encode(&dto.OrderCreateRequestDto{ProductID: productUUID, Quantity: 1, AddressID: &addressUUID})
+		req := httptest.NewRequest(http.MethodPost, "/orders", buf)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", validToken))
+		return req
+	}
+
+	lat, lng := -6.2146, 106.8223
+	officeAddress := &models.UserAddress{
+		AddressID:  addressUUID,
+		UserID:     userUUID,
+		Recipient:  "Recipient",
+		Street:     "Jl. Sudirman 1",
+		City:       "Jakarta",
+		PostalCode: "10220",
+		Country:    "ID",
+		Latitude:   &lat,
+		Longitude:  &lng,
+	}
+
+	sessUC.EXPECT().GetSessionById(gomock.Any(), sessUUID.String()).AnyTimes().Return(&models.Session{UserID: userUUID, SessionID: sessUUID.String()}, nil)
+	profileAddress := "Home, Bandung, ID"
+	userUC.EXPECT().CachedFindById(gomock.Any(), userUUID).AnyTimes().DoAndReturn(func(_ context.Context, _ uuid.UUID) (*models.User, error) {
+		return &models.User{UserID: userUUID, DeliveryAddress: profileAddress}, nil
+	})
+	productUC.EXPECT().CachedFindById(gomock.Any(), productUUID).AnyTimes().Return(&models.Product{ProductID: productUUID, BrandID: brandUUID}, nil)
+	brandUC.EXPECT().CachedFindById(gomock.Any(), brandUUID).AnyTimes().Return(&models.Brand{BrandID: brandUUID}, nil)
+	promotionUC.EXPECT().Apply(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(_ context.Context, order *models.Order, _ []string) (*models.Order, error) {
+		return order, nil
+	})
+	taxRateUC.EXPECT().Apply(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(_ context.Context, order *models.Order) (*models.Order, error) {
+		return order, nil
+	})
+	locationUC.EXPECT().FindNearestInStock(gomock.Any(), brandUUID, productUUID, uint64(1), officeAddress.Point(), officeAddress.Format()).AnyTimes().Return(nil, nil)
+	deliveryFeeUC.EXPECT().Apply(gomock.Any(), gomock.Any(), nil, officeAddress.Point()).AnyTimes().DoAndReturn(func(_ context.Context, order *models.Order, _, _ *geo.Point) (*models.Order, error) {
+		return order, nil
+	})
+
+	t.Run("Snapshot", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		addressUC.EXPECT().FindById(gomock.Any(), addressUUID).Return(officeAddress, nil)
+		orderUC.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, order *models.Order) (*models.Order, error) {
+			require.Equal(t, "Jl. Sudirman 1, 10220, Jakarta, ID", order.DeliveryDestinationAddress)
+			require.NotNil(t, order.DeliveryAddress)
+			require.Equal(t, "Recipient", order.DeliveryAddress.Recipient)
+			return &models.Order{OrderID: uuid.New()}, nil
+		})
+
+		http.HandlerFunc(handlers.Create).ServeHTTP(w, newRequest())
+
+		require.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("NotOwner", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		otherAddress := *officeAddress
+		otherAddress.UserID = uuid.New()
+		addressUC.EXPECT().FindById(gomock.Any(), addressUUID).Return(&otherAddress, nil)
+
+		http.HandlerFunc(handlers.Create).ServeHTTP(w, newRequest())
+
+		require.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("WithoutDeliveryAddress", func(t *testing.T) {
+		profileAddress = ""
+		w := httptest.NewRecorder()
+
+		buf := &bytes.Buffer{}
+		_ = json.NewEncoder(buf).Encode(&dto.OrderCreateRequestDto{ProductID: productUUID, Quantity: 1})
+		req := httptest.NewRequest(http.MethodPost, "/orders", buf)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", validToken))
+		addressUC.EXPECT().FindDefaultByUserId(gomock.Any(), userUUID).Return(nil, sql.ErrNoRows)
+
+		http.HandlerFunc(handlers.Create).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestOrdersHandler_CreateFromLocation(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orderUC := mock.NewMockOrderUseCase(ctrl)
+	sessUC := mockSessUC.NewMockSessUseCase(ctrl)
+	userUC := mockUserUC.NewMockUserUseCase(ctrl)
+	addressUC := mockAddressUC.NewMockAddressUseCase(ctrl)
+	brandUC := mockBrandUC.NewMockBrandUseCase(ctrl)
+	productUC := mockProductUC.NewMockProductUseCase(ctrl)
+	promotionUC := mockPromotionUC.NewMockPromotionUseCase(ctrl)
+	taxRateUC := mockTaxRateUC.NewMockTaxRateUseCase(ctrl)
+	deliveryFeeUC := mockDeliveryFeeUC.NewMockDeliveryFeeUseCase(ctrl)
+	locationUC := mockLocationUC.NewMockLocationUseCase(ctrl)
+
+	cfg := &config.Config{Session: config.Session{Expire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	appLogger := logger.NewAppLogger(cfg)
+	appLogger.InitLogger()
+	mw := middlewares.NewMiddlewareManager(appLogger, cfg)
+
+	v := validator.New()
+
+	rt := router.NewRouter(false)
+	handlers := NewOrderHandlersHTTP(rt, appLogger, cfg, mw, v, orderUC, userUC, addressUC, brandUC, productUC, promotionUC, taxRateUC, deliveryFeeUC, locationUC, sessUC)
+
+	userUUID := uuid.New()
+	brandUUID := uuid.New()
+	sessUUID := uuid.New()
+	productUUID := uuid.New()
+
+	token := jwt.New(jwt.SigningMethodHS256)
+	claims := token.Claims.(jwt.MapClaims)
+	claims["session_id"] = sessUUID.String()
+	claims["user_id"] = userUUID.String()
+	claims["role"] = models.UserRoleUser
+	claims["exp"] = time.Now().Add(time.Minute * 15).Unix()
+	validToken, _ := token.SignedString([]byte(cfg.Server.JwtSecretKey))
+
+	newRequest := func() *http.Request {
+		buf := &bytes.Buffer{}
+		_ = json.NewEncoder(buf).Encode(&dto.OrderCreateRequestDto{ProductID: productUUID, Quantity: 2})
+		req := httptest.NewRequest(http.MethodPost, "/orders", buf)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", validToken))
+		return req
+	}
+
+	lat, lng := -6.9175, 107.6191
+	warehouse := &models.BrandLocation{LocationID: uuid.New(), BrandID: brandUUID, Name: "Bandung", Address: "Jl. Soekarno Hatta 1, Bandung, ID", Latitude: &lat, Longitude: &lng, Active: true}
+
+	sessUC.EXPECT().GetSessionById(gomock.Any(), sessUUID.String()).AnyTimes().Return(&models.Session{UserID: userUUID, SessionID: sessUUID.String()}, nil)
+	userUC.EXPECT().CachedFindById(gomock.Any(), userUUID).AnyTimes().Return(&models.User{UserID: userUUID, DeliveryAddress: "Jl. Braga 5, Bandung, ID"}, nil)
+	addressUC.EXPECT().FindDefaultByUserId(gomock.Any(), userUUID).AnyTimes().Return(nil, sql.ErrNoRows)
+	productUC.EXPECT().CachedFindById(gomock.Any(), productUUID).AnyTimes().Return(&models.Product{ProductID: productUUID, BrandID: brandUUID}, nil)
+	brandUC.EXPECT().CachedFindById(gomock.Any(), brandUUID).AnyTimes().Return(&models.Brand{BrandID: brandUUID, PickupAddress: "Jl. Sudirman 1, Jakarta, ID"}, nil)
+	promotionUC.EXPECT().Apply(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(_ context.Context, order *models.Order, _ []string) (*models.Order, error) {
+		return order, nil
+	})
+	taxRateUC.EXPECT().Apply(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(_ context.Context, order *models.Order) (*models.Order, error) {
+		return order, nil
+	})
+
+	t.Run("Nearest", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		locationUC.EXPECT().FindNearestInStock(gomock.Any(), brandUUID, productUUID, uint64(2), nil, "Jl. Braga 5, Bandung, ID").Return(warehouse, nil)
+		deliveryFeeUC.EXPECT().Apply(gomock.Any(), gomock.Any(), warehouse.Point(), nil).DoAndReturn(func(_ context.Context, order *models.Order, _, _ *geo.Point) (*models.Order, error) {
+			return order, nil
+		})
+		orderUC.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, order *models.Order) (*models.Order, error) {
+			require.Equal(t, warehouse.Address, order.DeliverySourceAddress)
+			require.Equal(t, warehouse.LocationID, *order.LocationID)
+			return &models.Order{OrderID: uuid.New()}, nil
+		})
+
+		http.HandlerFunc(handlers.Create).ServeHTTP(w, newRequest())
+
+		require.Equal(t, http.StatusCreated, w.Code)
+	})
+
+	t.Run("OutOfStock", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		locationUC.EXPECT().FindNearestInStock(gomock.Any(), brandUUID, productUUID, uint64(2), nil, "Jl. Braga 5, Bandung, ID").Return(nil, models.ErrOutOfStock)
+
+		http.HandlerFunc(handlers.Create).ServeHTTP(w, newRequest())
+
+		require.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("SoldOutMeanwhile", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		locationUC.EXPECT().FindNearestInStock(gomock.Any(), brandUUID, productUUID, uint64(2), nil, "Jl. Braga 5, Bandung, ID").Return(warehouse, nil)
+		deliveryFeeUC.EXPECT().Apply(gomock.Any(), gomock.Any(), warehouse.Point(), nil).DoAndReturn(func(_ context.Context, order *models.Order, _, _ *geo.Point) (*models.Order, error) {
+			return order, nil
+		})
+		orderUC.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, models.ErrOutOfStock)
+
+		http.HandlerFunc(handlers.Create).ServeHTTP(w, newRequest())
+
+		require.Equal(t, http.StatusConflict, w.Code)
+	})
+}
+
 func TestOrdersHandler_Find(t *testing.T) {
 	t.Parallel()
 
@@ -113,8 +372,13 @@ func TestOrdersHandler_Find(t *testing.T) {
 	orderUC := mock.NewMockOrderUseCase(ctrl)
 	sessUC := mockSessUC.NewMockSessUseCase(ctrl)
 	userUC := mockUserUC.NewMockUserUseCase(ctrl)
+	addressUC := mockAddressUC.NewMockAddressUseCase(ctrl)
 	brandUC := mockBrandUC.NewMockBrandUseCase(ctrl)
 	productUC := mockProductUC.NewMockProductUseCase(ctrl)
+	promotionUC := mockPromotionUC.NewMockPromotionUseCase(ctrl)
+	taxRateUC := mockTaxRateUC.NewMockTaxRateUseCase(ctrl)
+	deliveryFeeUC := mockDeliveryFeeUC.NewMockDeliveryFeeUseCase(ctrl)
+	locationUC := mockLocationUC.NewMockLocationUseCase(ctrl)
 
 	cfg := &config.Config{Session: config.Session{Expire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
 	appLogger := logger.NewAppLogger(cfg)
@@ -122,8 +386,8 @@ func TestOrdersHandler_Find(t *testing.T) {
 
 	v := validator.New()
 
-	mux := http.NewServeMux()
-	handlers := NewOrderHandlersHTTP(mux, appLogger, cfg, mw, v, orderUC, userUC, brandUC, productUC, sessUC)
+	rt := router.NewRouter(false)
+	handlers := NewOrderHandlersHTTP(rt, appLogger, cfg, mw, v, orderUC, userUC, addressUC, brandUC, productUC, promotionUC, taxRateUC, deliveryFeeUC, locationUC, sessUC)
 
 	userUUID := uuid.New()
 	brandUUID := uuid.New()
@@ -163,12 +427,19 @@ func TestOrdersHandler_Find(t *testing.T) {
 		claims["exp"] = time.Now().Add(time.Minute * 15).Unix()
 		validToken, _ := token.SignedString([]byte(cfg.Server.JwtSecretKey))
 
-		req := httptest.NewRequest(http.MethodGet, "/order", nil)
+		req := httptest.NewRequest(http.MethodGet, "/orders?user_id="+uuid.New().String()+"&status=paid&min_total=5000&sort=-total_price", nil)
 		req.Header.Set("Content-Type", "application/json")
 		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", validToken))
 		w := httptest.NewRecorder()
 
-		orderUC.EXPECT().FindAllByUserId(gomock.Any(), userUUID, gomock.Any()).AnyTimes().Return(oneOnly, nil)
+		orderUC.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, filter *models.OrderFilter, pq *utils.Pagination) ([]models.Order, error) {
+			require.Equal(t, userUUID, *filter.UserID)
+			require.Equal(t, []string{models.OrderStatusPaid}, filter.Statuses)
+			require.Equal(t, 5000.0, *filter.MinTotal)
+			require.Nil(t, filter.MaxTotal)
+			require.Equal(t, "-total_price", pq.GetOrderBy())
+			return oneOnly, nil
+		})
 		sessUC.EXPECT().GetSessionById(gomock.Any(), sessUUID.String()).AnyTimes().Return(&models.Session{UserID: userUUID, SessionID: sessUUID.String()}, nil)
 		userUC.EXPECT().CachedFindById(gomock.Any(), userUUID).AnyTimes().Return(&models.User{UserID: userUUID}, nil)
 
@@ -199,12 +470,12 @@ func TestOrdersHandler_Find(t *testing.T) {
 		claims["exp"] = time.Now().Add(time.Minute * 15).Unix()
 		validToken, _ := token.SignedString([]byte(cfg.Server.JwtSecretKey))
 
-		req := httptest.NewRequest(http.MethodGet, "/order", nil)
+		req := httptest.NewRequest(http.MethodGet, "/orders", nil)
 		req.Header.Set("Content-Type", "application/json")
 		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", validToken))
 		w := httptest.NewRecorder()
 
-		orderUC.EXPECT().FindAll(gomock.Any(), gomock.Any()).AnyTimes().Return(orders, nil)
+		orderUC.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(orders, nil)
 		sessUC.EXPECT().GetSessionById(gomock.Any(), sessUUID.String()).AnyTimes().Return(&models.Session{UserID: userUUID, SessionID: sessUUID.String()}, nil)
 		userUC.EXPECT().CachedFindById(gomock.Any(), userUUID).AnyTimes().Return(&models.User{UserID: userUUID}, nil)
 
@@ -235,7 +506,7 @@ func TestOrdersHandler_Find(t *testing.T) {
 		claims["exp"] = time.Now().Add(time.Minute * 15).Unix()
 		validToken, _ := token.SignedString([]byte(cfg.Server.JwtSecretKey))
 
-		req := httptest.NewRequest(http.MethodGet, "/order?id="+m.OrderID.String(), nil)
+		req := router.WithParams(httptest.NewRequest(http.MethodGet, "/orders/"+m.OrderID.String(), nil), map[string]string{"id": m.OrderID.String()})
 		req.Header.Set("Content-Type", "application/json")
 		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", validToken))
 		w := httptest.NewRecorder()
@@ -262,4 +533,457 @@ func TestOrdersHandler_Find(t *testing.T) {
 
 		require.Equal(t, m.OrderID.String(), resDto.OrderID.String())
 	})
+
+	t.Run("FindByIdOtherUser", func(t *testing.T) {
+		token := jwt.New(jwt.SigningMethodHS256)
+		claims := token.Claims.(jwt.MapClaims)
+		claims["session_id"] = sessUUID.String()
+		claims["user_id"] = uuid.New().String()
+		claims["role"] = models.UserRoleUser
+		claims["exp"] = time.Now().Add(time.Minute * 15).Unix()
+		validToken, _ := token.SignedString([]byte(cfg.Server.JwtSecretKey))
+
+		req := router.WithParams(httptest.NewRequest(http.MethodGet, "/orders/"+m.OrderID.String(), nil), map[string]string{"id": m.OrderID.String()})
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", validToken))
+		w := httptest.NewRecorder()
+
+		orderUC.EXPECT().CachedFindById(gomock.Any(), gomock.Any()).AnyTimes().Return(&m, nil)
+
+		handler := http.HandlerFunc(handlers.FindById)
+		handler.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
+
+func TestOrdersHandler_UpdateById(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orderUC := mock.NewMockOrderUseCase(ctrl)
+	userUC := mockUserUC.NewMockUserUseCase(ctrl)
+	addressUC := mockAddressUC.NewMockAddressUseCase(ctrl)
+	brandUC := mockBrandUC.NewMockBrandUseCase(ctrl)
+	productUC := mockProductUC.NewMockProductUseCase(ctrl)
+	promotionUC := mockPromotionUC.NewMockPromotionUseCase(ctrl)
+	taxRateUC := mockTaxRateUC.NewMockTaxRateUseCase(ctrl)
+	deliveryFeeUC := mockDeliveryFeeUC.NewMockDeliveryFeeUseCase(ctrl)
+	locationUC := mockLocationUC.NewMockLocationUseCase(ctrl)
+	sessUC := mockSessUC.NewMockSessUseCase(ctrl)
+
+	cfg := &config.Config{Session: config.Session{Expire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	appLogger := logger.NewAppLogger(cfg)
+	appLogger.InitLogger()
+	mw := middlewares.NewMiddlewareManager(appLogger, cfg)
+
+	v := validator.New()
+
+	rt := router.NewRouter(false)
+	handlers := NewOrderHandlersHTTP(rt, appLogger, cfg, mw, v, orderUC, userUC, addressUC, brandUC, productUC, promotionUC, taxRateUC, deliveryFeeUC, locationUC, sessUC)
+
+	orderUUID := uuid.New()
+
+	t.Run("Status", func(t *testing.T) {
+		req := router.WithParams(httptest.NewRequest(http.MethodPatch, "/orders/"+orderUUID.String(), strings.NewReader(`{"status": "accepted"}`)), map[string]string{"id": orderUUID.String()})
+		w := httptest.NewRecorder()
+
+		orderUC.EXPECT().FindById(gomock.Any(), orderUUID).Return(&models.Order{OrderID: orderUUID, Quantity: 2, Status: models.OrderStatusPaid}, nil)
+		orderUC.EXPECT().UpdateById(gomock.Any(), &models.Order{OrderID: orderUUID, Quantity: 2, Status: models.OrderStatusAccepted}).DoAndReturn(func(_ interface{}, o *models.Order) (*models.Order, error) {
+			return o, nil
+		})
+
+		http.HandlerFunc(handlers.UpdateById).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		resDto := &dto.OrderResponseDto{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), resDto))
+		require.Equal(t, models.OrderStatusAccepted, resDto.Status)
+	})
+
+	t.Run("NotPaid", func(t *testing.T) {
+		req := router.WithParams(httptest.NewRequest(http.MethodPatch, "/orders/"+orderUUID.String(), strings.NewReader(`{"status": "accepted"}`)), map[string]string{"id": orderUUID.String()})
+		w := httptest.NewRecorder()
+
+		orderUC.EXPECT().FindById(gomock.Any(), orderUUID).Return(&models.Order{OrderID: orderUUID, Quantity: 2, Status: models.OrderStatusPending}, nil)
+
+		http.HandlerFunc(handlers.UpdateById).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("InvalidStatus", func(t *testing.T) {
+		req := router.WithParams(httptest.NewRequest(http.MethodPatch, "/orders/"+orderUUID.String(), strings.NewReader(`{"status": "unknown"}`)), map[string]string{"id": orderUUID.String()})
+		w := httptest.NewRecorder()
+
+		http.HandlerFunc(handlers.UpdateById).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestOrdersHandler_DeleteById(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orderUC := mock.NewMockOrderUseCase(ctrl)
+	userUC := mockUserUC.NewMockUserUseCase(ctrl)
+	addressUC := mockAddressUC.NewMockAddressUseCase(ctrl)
+	brandUC := mockBrandUC.NewMockBrandUseCase(ctrl)
+	productUC := mockProductUC.NewMockProductUseCase(ctrl)
+	promotionUC := mockPromotionUC.NewMockPromotionUseCase(ctrl)
+	taxRateUC := mockTaxRateUC.NewMockTaxRateUseCase(ctrl)
+	deliveryFeeUC := mockDeliveryFeeUC.NewMockDeliveryFeeUseCase(ctrl)
+	locationUC := mockLocationUC.NewMockLocationUseCase(ctrl)
+	sessUC := mockSessUC.NewMockSessUseCase(ctrl)
+
+	cfg := &config.Config{Session: config.Session{Expire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	appLogger := logger.NewAppLogger(cfg)
+	mw := middlewares.NewMiddlewareManager(appLogger, cfg)
+
+	v := validator.New()
+
+	rt := router.NewRouter(false)
+	handlers := NewOrderHandlersHTTP(rt, appLogger, cfg, mw, v, orderUC, userUC, addressUC, brandUC, productUC, promotionUC, taxRateUC, deliveryFeeUC, locationUC, sessUC)
+
+	orderUUID := uuid.New()
+
+	req := router.WithParams(httptest.NewRequest(http.MethodDelete, "/orders/"+orderUUID.String(), nil), map[string]string{"id": orderUUID.String()})
+	w := httptest.NewRecorder()
+
+	orderUC.EXPECT().DeleteById(gomock.Any(), orderUUID, nil).Return(nil)
+
+	http.HandlerFunc(handlers.DeleteById).ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusNoContent, w.Code)
+}
+
+func TestOrdersHandler_RestoreById(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orderUC := mock.NewMockOrderUseCase(ctrl)
+	userUC := mockUserUC.NewMockUserUseCase(ctrl)
+	addressUC := mockAddressUC.NewMockAddressUseCase(ctrl)
+	brandUC := mockBrandUC.NewMockBrandUseCase(ctrl)
+	productUC := mockProductUC.NewMockProductUseCase(ctrl)
+	promotionUC := mockPromotionUC.NewMockPromotionUseCase(ctrl)
+	taxRateUC := mockTaxRateUC.NewMockTaxRateUseCase(ctrl)
+	deliveryFeeUC := mockDeliveryFeeUC.NewMockDeliveryFeeUseCase(ctrl)
+	locationUC := mockLocationUC.NewMockLocationUseCase(ctrl)
+	sessUC := mockSessUC.NewMockSessUseCase(ctrl)
+
+	cfg := &config.Config{Session: config.Session{Expire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	appLogger := logger.NewAppLogger(cfg)
+	mw := middlewares.NewMiddlewareManager(appLogger, cfg)
+
+	v := validator.New()
+
+	rt := router.NewRouter(false)
+	handlers := NewOrderHandlersHTTP(rt, appLogger, cfg, mw, v, orderUC, userUC, addressUC, brandUC, productUC, promotionUC, taxRateUC, deliveryFeeUC, locationUC, sessUC)
+
+	orderUUID := uuid.New()
+
+	req := router.WithParams(httptest.NewRequest(http.MethodPost, "/orders/"+orderUUID.String()+"/restore", nil), map[string]string{"id": orderUUID.String()})
+	w := httptest.NewRecorder()
+
+	orderUC.EXPECT().RestoreById(gomock.Any(), orderUUID).Return(&models.Order{OrderID: orderUUID, Version: 2}, nil)
+
+	http.HandlerFunc(handlers.RestoreById).ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, `"2"`, w.Header().Get("ETag"))
+}
+
+func TestOrdersHandler_FindInbox(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orderUC := mock.NewMockOrderUseCase(ctrl)
+	userUC := mockUserUC.NewMockUserUseCase(ctrl)
+	addressUC := mockAddressUC.NewMockAddressUseCase(ctrl)
+	brandUC := mockBrandUC.NewMockBrandUseCase(ctrl)
+	productUC := mockProductUC.NewMockProductUseCase(ctrl)
+	promotionUC := mockPromotionUC.NewMockPromotionUseCase(ctrl)
+	taxRateUC := mockTaxRateUC.NewMockTaxRateUseCase(ctrl)
+	deliveryFeeUC := mockDeliveryFeeUC.NewMockDeliveryFeeUseCase(ctrl)
+	locationUC := mockLocationUC.NewMockLocationUseCase(ctrl)
+	sessUC := mockSessUC.NewMockSessUseCase(ctrl)
+
+	cfg := &config.Config{Session: config.Session{Expire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	appLogger := logger.NewAppLogger(cfg)
+	appLogger.InitLogger()
+	mw := middlewares.NewMiddlewareManager(appLogger, cfg)
+
+	v := validator.New()
+
+	rt := router.NewRouter(false)
+	handlers := NewOrderHandlersHTTP(rt, appLogger, cfg, mw, v, orderUC, userUC, addressUC, brandUC, productUC, promotionUC, taxRateUC, deliveryFeeUC, locationUC, sessUC)
+
+	brandUUID, userUUID, productUUID := uuid.New(), uuid.New(), uuid.New()
+	sellerToken := signedToken(t, cfg, uuid.New(), models.UserRoleSeller, &brandUUID)
+
+	t.Run("Filters", func(t *testing.T) {
+		query := "?status=paid,%20Accepted&created_from=2024-01-01&created_to=2024-01-31&user_id=" + userUUID.String() + "&product_id=" + productUUID.String() + "&brand_id=" + uuid.New().String() + "&max_total=250000&sort=-total_price&size=5&page=2"
+		req := router.WithParams(httptest.NewRequest(http.MethodGet, "/brands/"+brandUUID.String()+"/orders"+query, nil), map[string]string{"id": brandUUID.String()})
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", sellerToken))
+		w := httptest.NewRecorder()
+
+		orderUC.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, filter *models.OrderFilter, pq *utils.Pagination) ([]models.Order, error) {
+			require.Equal(t, brandUUID, *filter.BrandID)
+			require.Equal(t, []string{models.OrderStatusPaid, models.OrderStatusAccepted}, filter.Statuses)
+			require.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), *filter.CreatedFrom)
+			require.Equal(t, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), *filter.CreatedTo)
+			require.Equal(t, userUUID, *filter.UserID)
+			require.Equal(t, productUUID, *filter.ProductID)
+			require.Equal(t, 250000.0, *filter.MaxTotal)
+			require.Equal(t, "-total_price", pq.GetOrderBy())
+			require.Equal(t, 5, pq.GetLimit())
+			require.Equal(t, 5, pq.GetOffset())
+			return []models.Order{{OrderID: uuid.New(), BrandID: brandUUID}}, nil
+		})
+
+		http.HandlerFunc(handlers.FindInbox).ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		resDto := &dto.OrderFindResponseDto{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), resDto))
+		require.Len(t, resDto.Data.([]interface{}), 1)
+	})
+
+	for name, query := range map[string]string{
+		"InvalidStatus": "?status=paid,lost",
+		"InvalidSort":   "?sort=name",
+		"InvalidDate":   "?created_from=yesterday",
+		"InvalidTotal":  "?min_total=-1",
+		"InvalidUser":   "?user_id=42",
+		"InvalidCursor": "?cursor=page-2",
+		"InvalidCount":  "?count=all",
+	} {
+		query := query
+		t.Run(name, func(t *testing.T) {
+			req := router.WithParams(httptest.NewRequest(http.MethodGet, "/brands/"+brandUUID.String()+"/orders"+query, nil), map[string]string{"id": brandUUID.String()})
+			req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", sellerToken))
+			w := httptest.NewRecorder()
+
+			http.HandlerFunc(handlers.FindInbox).ServeHTTP(w, req)
+			require.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+
+	t.Run("Cursor", func(t *testing.T) {
+		newest := models.Order{OrderID: uuid.New(), BrandID: brandUUID, CreatedAt: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)}
+		req := router.WithParams(httptest.NewRequest(http.MethodGet, "/brands/"+brandUUID.String()+"/orders?status=paid&size=1", nil), map[string]string{"id": brandUUID.String()})
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", sellerToken))
+		w := httptest.NewRecorder()
+
+		orderUC.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any()).Return([]models.Order{newest}, nil)
+
+		http.HandlerFunc(handlers.FindInbox).ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		resDto := &dto.OrderFindResponseDto{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), resDto))
+		require.Empty(t, resDto.Meta.Prev)
+		require.Nil(t, resDto.Meta.TotalCount)
+		require.Contains(t, resDto.Meta.Next, "/brands/"+brandUUID.String()+"/orders?cursor=")
+
+		req = router.WithParams(httptest.NewRequest(http.MethodGet, resDto.Meta.Next+"&count=exact", nil), map[string]string{"id": brandUUID.String()})
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", sellerToken))
+		w = httptest.NewRecorder()
+
+		orderUC.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, filter *models.OrderFilter, pq *utils.Pagination) ([]models.Order, error) {
+			require.Equal(t, []string{models.OrderStatusPaid}, filter.Statuses)
+			require.Equal(t, "-created_at", pq.GetOrderBy())
+			require.Equal(t, []string{"2024-05-01T10:00:00Z", newest.OrderID.String()}, pq.Cursor.Values)
+			return nil, nil
+		})
+		orderUC.EXPECT().Count(gomock.Any(), gomock.Any(), false).Return(1, nil)
+
+		http.HandlerFunc(handlers.FindInbox).ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		resDto = &dto.OrderFindResponseDto{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), resDto))
+		require.Equal(t, 1, *resDto.Meta.TotalCount)
+		require.Empty(t, resDto.Meta.Next)
+		require.Empty(t, resDto.Meta.Prev)
+	})
+
+	t.Run("OtherBrand", func(t *testing.T) {
+		otherBrandUUID := uuid.New()
+		req := router.WithParams(httptest.NewRequest(http.MethodGet, "/brands/"+otherBrandUUID.String()+"/orders", nil), map[string]string{"id": otherBrandUUID.String()})
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", sellerToken))
+		w := httptest.NewRecorder()
+
+		http.HandlerFunc(handlers.FindInbox).ServeHTTP(w, req)
+		require.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
+
+func TestOrdersHandler_BulkAction(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orderUC := mock.NewMockOrderUseCase(ctrl)
+	userUC := mockUserUC.NewMockUserUseCase(ctrl)
+	addressUC := mockAddressUC.NewMockAddressUseCase(ctrl)
+	brandUC := mockBrandUC.NewMockBrandUseCase(ctrl)
+	productUC := mockProductUC.NewMockProductUseCase(ctrl)
+	promotionUC := mockPromotionUC.NewMockPromotionUseCase(ctrl)
+	taxRateUC := mockTaxRateUC.NewMockTaxRateUseCase(ctrl)
+	deliveryFeeUC := mockDeliveryFeeUC.NewMockDeliveryFeeUseCase(ctrl)
+	locationUC := mockLocationUC.NewMockLocationUseCase(ctrl)
+	sessUC := mockSessUC.NewMockSessUseCase(ctrl)
+
+	cfg := &config.Config{Session: config.Session{Expire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	appLogger := logger.NewAppLogger(cfg)
+	appLogger.InitLogger()
+	mw := middlewares.NewMiddlewareManager(appLogger, cfg)
+
+	v := validator.New()
+
+	rt := router.NewRouter(false)
+	handlers := NewOrderHandlersHTTP(rt, appLogger, cfg, mw, v, orderUC, userUC, addressUC, brandUC, productUC, promotionUC, taxRateUC, deliveryFeeUC, locationUC, sessUC)
+
+	brandUUID := uuid.New()
+	orderIDs := []uuid.UUID{uuid.New(), uuid.New()}
+	sellerToken := signedToken(t, cfg, uuid.New(), models.UserRoleSeller, &brandUUID)
+
+	newRequest := func(brandID uuid.UUID, token string, body interface{}) *http.Request {
+		buf := &bytes.Buffer{}
+		_ = json.NewEncoder(buf).Encode(body)
+		req := router.WithParams(httptest.NewRequest(http.MethodPost, "/brands/"+brandID.String()+"/orders/bulk", buf), map[string]string{"id": brandID.String()})
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", token))
+		return req
+	}
+
+	t.Run("Applied", func(t *testing.T) {
+		orderUC.EXPECT().BulkAction(gomock.Any(), brandUUID, models.OrderBulkAccept, orderIDs).Return(&models.OrderBulkResult{
+			Action:  models.OrderBulkAccept,
+			Applied: true,
+			Results: []models.OrderBulkItemResult{
+				{OrderID: orderIDs[0], PreviousStatus: models.OrderStatusPaid, Status: models.OrderStatusAccepted, Version: 2},
+				{OrderID: orderIDs[1], PreviousStatus: models.OrderStatusPaid, Status: models.OrderStatusAccepted, Version: 2},
+			},
+		}, nil)
+
+		w := httptest.NewRecorder()
+		http.HandlerFunc(handlers.BulkAction).ServeHTTP(w, newRequest(brandUUID, sellerToken, dto.OrderBulkRequestDto{Action: models.OrderBulkAccept, OrderIDs: orderIDs}))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		result := &models.OrderBulkResult{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), result))
+		require.True(t, result.Applied)
+		require.Len(t, result.Results, 2)
+	})
+
+	t.Run("NotApplied", func(t *testing.T) {
+		orderUC.EXPECT().BulkAction(gomock.Any(), brandUUID, models.OrderBulkReject, orderIDs).Return(&models.OrderBulkResult{
+			Action: models.OrderBulkReject,
+			Results: []models.OrderBulkItemResult{
+				{OrderID: orderIDs[0], PreviousStatus: models.OrderStatusPaid, Status: models.OrderStatusRejected},
+				{OrderID: orderIDs[1], Error: sql.ErrNoRows.Error()},
+			},
+		}, nil)
+
+		w := httptest.NewRecorder()
+		http.HandlerFunc(handlers.BulkAction).ServeHTTP(w, newRequest(brandUUID, sellerToken, dto.OrderBulkRequestDto{Action: models.OrderBulkReject, OrderIDs: orderIDs}))
+		require.Equal(t, http.StatusConflict, w.Code)
+		require.Contains(t, w.Body.String(), `"applied":false`)
+	})
+
+	t.Run("InvalidPayload", func(t *testing.T) {
+		for _, body := range []dto.OrderBulkRequestDto{
+			{Action: "cancel", OrderIDs: orderIDs},
+			{Action: models.OrderBulkAccept},
+			{Action: models.OrderBulkAccept, OrderIDs: []uuid.UUID{orderIDs[0], orderIDs[0]}},
+		} {
+			w := httptest.NewRecorder()
+			http.HandlerFunc(handlers.BulkAction).ServeHTTP(w, newRequest(brandUUID, sellerToken, body))
+			require.Equal(t, http.StatusBadRequest, w.Code)
+		}
+	})
+
+	t.Run("Cursor", func(t *testing.T) {
+		newest := models.Order{OrderID: uuid.New(), BrandID: brandUUID, CreatedAt: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)}
+		req := router.WithParams(httptest.NewRequest(http.MethodGet, "/brands/"+brandUUID.String()+"/orders?status=paid&size=1", nil), map[string]string{"id": brandUUID.String()})
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", sellerToken))
+		w := httptest.NewRecorder()
+
+		orderUC.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any()).Return([]models.Order{newest}, nil)
+
+		http.HandlerFunc(handlers.FindInbox).ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		resDto := &dto.OrderFindResponseDto{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), resDto))
+		require.Empty(t, resDto.Meta.Prev)
+		require.Nil(t, resDto.Meta.TotalCount)
+		require.Contains(t, resDto.Meta.Next, "/brands/"+brandUUID.String()+"/orders?cursor=")
+
+		req = router.WithParams(httptest.NewRequest(http.MethodGet, resDto.Meta.Next+"&count=exact", nil), map[string]string{"id": brandUUID.String()})
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", sellerToken))
+		w = httptest.NewRecorder()
+
+		orderUC.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, filter *models.OrderFilter, pq *utils.Pagination) ([]models.Order, error) {
+			require.Equal(t, []string{models.OrderStatusPaid}, filter.Statuses)
+			require.Equal(t, "-created_at", pq.GetOrderBy())
+			require.Equal(t, []string{"2024-05-01T10:00:00Z", newest.OrderID.String()}, pq.Cursor.Values)
+			return nil, nil
+		})
+		orderUC.EXPECT().Count(gomock.Any(), gomock.Any(), false).Return(1, nil)
+
+		http.HandlerFunc(handlers.FindInbox).ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		resDto = &dto.OrderFindResponseDto{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), resDto))
+		require.Equal(t, 1, *resDto.Meta.TotalCount)
+		require.Empty(t, resDto.Meta.Next)
+		require.Empty(t, resDto.Meta.Prev)
+	})
+
+	t.Run("OtherBrand", func(t *testing.T) {
+		otherBrandUUID := uuid.New()
+		w := httptest.NewRecorder()
+		http.HandlerFunc(handlers.BulkAction).ServeHTTP(w, newRequest(otherBrandUUID, sellerToken, dto.OrderBulkRequestDto{Action: models.OrderBulkAccept, OrderIDs: orderIDs}))
+		require.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("Admin", func(t *testing.T) {
+		otherBrandUUID := uuid.New()
+		orderUC.EXPECT().BulkAction(gomock.Any(), otherBrandUUID, models.OrderBulkMarkShipped, orderIDs).Return(&models.OrderBulkResult{Action: models.OrderBulkMarkShipped, Applied: true}, nil)
+
+		adminToken := signedToken(t, cfg, uuid.New(), models.UserRoleAdmin, nil)
+		w := httptest.NewRecorder()
+		http.HandlerFunc(handlers.BulkAction).ServeHTTP(w, newRequest(otherBrandUUID, adminToken, dto.OrderBulkRequestDto{Action: models.OrderBulkMarkShipped, OrderIDs: orderIDs}))
+		require.Equal(t, http.StatusOK, w.Code)
+	})
+}
+
+func signedToken(t *testing.T, cfg *config.Config, userUUID uuid.UUID, role string, brandUUID *uuid.UUID) string {
+	token := jwt.New(jwt.SigningMethodHS256)
+	claims := token.Claims.(jwt.MapClaims)
+	claims["session_id"] = uuid.New().String()
+	claims["user_id"] = userUUID.String()
+	claims["role"] = role
+	if brandUUID != nil {
+		claims["brand_id"] = brandUUID.String()
+	}
+	claims["exp"] = time.Now().Add(time.Minute * 15).Unix()
+	validToken, err := token.SignedString([]byte(cfg.Server.JwtSecretKey))
+	require.NoError(t, err)
+	return validToken
 }
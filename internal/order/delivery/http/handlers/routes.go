@@ -1,8 +1,15 @@
 package handlers
 
-import "net/http"
-
 func (h *orderHandlersHTTP) OrderMapRoutes() {
-	h.mux.Handle("/order/create", h.mw.IsAdmin(http.HandlerFunc(h.Create)))
-	h.mux.Handle("/order", h.mw.GetHandler(http.HandlerFunc(h.FindAll)))
+	orders := h.router.Group("/orders", h.mw.IsLoggedIn)
+	orders.Get("", h.FindAll)
+	orders.Get("/{id}", h.FindById)
+	orders.Post("", h.Create, h.mw.IsAdmin, h.mw.Idempotent)
+	orders.Patch("/{id}", h.UpdateById, h.mw.IsAdmin)
+	orders.Delete("/{id}", h.DeleteById, h.mw.IsAdmin)
+	orders.Post("/{id}/restore", h.RestoreById, h.mw.IsAdmin)
+
+	brandOrders := h.router.Group("/brands/{id}/orders", h.mw.IsAdminOrSeller)
+	brandOrders.Get("", h.FindInbox)
+	brandOrders.Post("/bulk", h.BulkAction)
 }
@@ -8,4 +8,10 @@ import (
 type OrderHandlers interface {
 	Create(w http.ResponseWriter, r *http.Request)
 	FindAll(w http.ResponseWriter, r *http.Request)
+	FindById(w http.ResponseWriter, r *http.Request)
+	UpdateById(w http.ResponseWriter, r *http.Request)
+	DeleteById(w http.ResponseWriter, r *http.Request)
+	RestoreById(w http.ResponseWriter, r *http.Request)
+	FindInbox(w http.ResponseWriter, r *http.Request)
+	BulkAction(w http.ResponseWriter, r *http.Request)
 }
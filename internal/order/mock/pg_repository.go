@@ -7,6 +7,7 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	models "github.com/dinorain/kalobranded/internal/models"
 	utils "github.com/dinorain/kalobranded/pkg/utils"
@@ -37,6 +38,52 @@ func (m *MockOrderPGRepository) EXPECT() *MockOrderPGRepositoryMockRecorder {
 	return m.recorder
 }
 
+// BulkUpdateStatus mocks base method.
+func (m *MockOrderPGRepository) BulkUpdateStatus(ctx context.Context, brandID uuid.UUID, orderIDs []uuid.UUID, status string) ([]models.OrderBulkItemResult, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkUpdateStatus", ctx, brandID, orderIDs, status)
+	ret0, _ := ret[0].([]models.OrderBulkItemResult)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// BulkUpdateStatus indicates an expected call of BulkUpdateStatus.
+func (mr *MockOrderPGRepositoryMockRecorder) BulkUpdateStatus(ctx, brandID, orderIDs, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkUpdateStatus", reflect.TypeOf((*MockOrderPGRepository)(nil).BulkUpdateStatus), ctx, brandID, orderIDs, status)
+}
+
+// Count mocks base method.
+func (m *MockOrderPGRepository) Count(ctx context.Context, filter *models.OrderFilter, estimate bool) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, filter, estimate)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockOrderPGRepositoryMockRecorder) Count(ctx, filter, estimate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockOrderPGRepository)(nil).Count), ctx, filter, estimate)
+}
+
+// CountWithDeleted mocks base method.
+func (m *MockOrderPGRepository) CountWithDeleted(ctx context.Context, filter *models.OrderFilter, estimate bool) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountWithDeleted", ctx, filter, estimate)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountWithDeleted indicates an expected call of CountWithDeleted.
+func (mr *MockOrderPGRepositoryMockRecorder) CountWithDeleted(ctx, filter, estimate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountWithDeleted", reflect.TypeOf((*MockOrderPGRepository)(nil).CountWithDeleted), ctx, filter, estimate)
+}
+
 // Create mocks base method.
 func (m *MockOrderPGRepository) Create(ctx context.Context, user *models.Order) (*models.Order, error) {
 	m.ctrl.T.Helper()
@@ -53,92 +100,121 @@ func (mr *MockOrderPGRepositoryMockRecorder) Create(ctx, user interface{}) *gomo
 }
 
 // DeleteById mocks base method.
-func (m *MockOrderPGRepository) DeleteById(ctx context.Context, userID uuid.UUID) error {
+func (m *MockOrderPGRepository) DeleteById(ctx context.Context, orderID uuid.UUID, versions []int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteById", ctx, userID)
+	ret := m.ctrl.Call(m, "DeleteById", ctx, orderID, versions)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteById indicates an expected call of DeleteById.
-func (mr *MockOrderPGRepositoryMockRecorder) DeleteById(ctx, userID interface{}) *gomock.Call {
+func (mr *MockOrderPGRepositoryMockRecorder) DeleteById(ctx, orderID, versions interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockOrderPGRepository)(nil).DeleteById), ctx, orderID, versions)
+}
+
+// Export mocks base method.
+func (m *MockOrderPGRepository) Export(ctx context.Context, filter *models.OrderFilter, sort string, fn func(*models.Order) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, filter, sort, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockOrderPGRepositoryMockRecorder) Export(ctx, filter, sort, fn interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockOrderPGRepository)(nil).DeleteById), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockOrderPGRepository)(nil).Export), ctx, filter, sort, fn)
 }
 
 // FindAll mocks base method.
-func (m *MockOrderPGRepository) FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.Order, error) {
+func (m *MockOrderPGRepository) FindAll(ctx context.Context, filter *models.OrderFilter, pagination *utils.Pagination) ([]models.Order, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAll", ctx, pagination)
+	ret := m.ctrl.Call(m, "FindAll", ctx, filter, pagination)
 	ret0, _ := ret[0].([]models.Order)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindAll indicates an expected call of FindAll.
-func (mr *MockOrderPGRepositoryMockRecorder) FindAll(ctx, pagination interface{}) *gomock.Call {
+func (mr *MockOrderPGRepositoryMockRecorder) FindAll(ctx, filter, pagination interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockOrderPGRepository)(nil).FindAll), ctx, pagination)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockOrderPGRepository)(nil).FindAll), ctx, filter, pagination)
 }
 
-// FindAllByBrandId mocks base method.
-func (m *MockOrderPGRepository) FindAllByBrandId(ctx context.Context, brandID uuid.UUID, pagination *utils.Pagination) ([]models.Order, error) {
+// FindAllWithDeleted mocks base method.
+func (m *MockOrderPGRepository) FindAllWithDeleted(ctx context.Context, filter *models.OrderFilter, pagination *utils.Pagination) ([]models.Order, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAllByBrandId", ctx, brandID, pagination)
+	ret := m.ctrl.Call(m, "FindAllWithDeleted", ctx, filter, pagination)
 	ret0, _ := ret[0].([]models.Order)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FindAllByBrandId indicates an expected call of FindAllByBrandId.
-func (mr *MockOrderPGRepositoryMockRecorder) FindAllByBrandId(ctx, brandID, pagination interface{}) *gomock.Call {
+// FindAllWithDeleted indicates an expected call of FindAllWithDeleted.
+func (mr *MockOrderPGRepositoryMockRecorder) FindAllWithDeleted(ctx, filter, pagination interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByBrandId", reflect.TypeOf((*MockOrderPGRepository)(nil).FindAllByBrandId), ctx, brandID, pagination)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllWithDeleted", reflect.TypeOf((*MockOrderPGRepository)(nil).FindAllWithDeleted), ctx, filter, pagination)
 }
 
-// FindAllByUserId mocks base method.
-func (m *MockOrderPGRepository) FindAllByUserId(ctx context.Context, userID uuid.UUID, pagination *utils.Pagination) ([]models.Order, error) {
+// FindById mocks base method.
+func (m *MockOrderPGRepository) FindById(ctx context.Context, userID uuid.UUID) (*models.Order, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAllByUserId", ctx, userID, pagination)
-	ret0, _ := ret[0].([]models.Order)
+	ret := m.ctrl.Call(m, "FindById", ctx, userID)
+	ret0, _ := ret[0].(*models.Order)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FindAllByUserId indicates an expected call of FindAllByUserId.
-func (mr *MockOrderPGRepositoryMockRecorder) FindAllByUserId(ctx, userID, pagination interface{}) *gomock.Call {
+// FindById indicates an expected call of FindById.
+func (mr *MockOrderPGRepositoryMockRecorder) FindById(ctx, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByUserId", reflect.TypeOf((*MockOrderPGRepository)(nil).FindAllByUserId), ctx, userID, pagination)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockOrderPGRepository)(nil).FindById), ctx, userID)
 }
 
-// FindAllByUserIdBrandId mocks base method.
-func (m *MockOrderPGRepository) FindAllByUserIdBrandId(ctx context.Context, userID, brandID uuid.UUID, pagination *utils.Pagination) ([]models.Order, error) {
+// FindByIdWithDeleted mocks base method.
+func (m *MockOrderPGRepository) FindByIdWithDeleted(ctx context.Context, orderID uuid.UUID) (*models.Order, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAllByUserIdBrandId", ctx, userID, brandID, pagination)
-	ret0, _ := ret[0].([]models.Order)
+	ret := m.ctrl.Call(m, "FindByIdWithDeleted", ctx, orderID)
+	ret0, _ := ret[0].(*models.Order)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FindAllByUserIdBrandId indicates an expected call of FindAllByUserIdBrandId.
-func (mr *MockOrderPGRepositoryMockRecorder) FindAllByUserIdBrandId(ctx, userID, brandID, pagination interface{}) *gomock.Call {
+// FindByIdWithDeleted indicates an expected call of FindByIdWithDeleted.
+func (mr *MockOrderPGRepositoryMockRecorder) FindByIdWithDeleted(ctx, orderID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByUserIdBrandId", reflect.TypeOf((*MockOrderPGRepository)(nil).FindAllByUserIdBrandId), ctx, userID, brandID, pagination)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIdWithDeleted", reflect.TypeOf((*MockOrderPGRepository)(nil).FindByIdWithDeleted), ctx, orderID)
 }
 
-// FindById mocks base method.
-func (m *MockOrderPGRepository) FindById(ctx context.Context, userID uuid.UUID) (*models.Order, error) {
+// PurgeDeleted mocks base method.
+func (m *MockOrderPGRepository) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindById", ctx, userID)
+	ret := m.ctrl.Call(m, "PurgeDeleted", ctx, deletedBefore)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeleted indicates an expected call of PurgeDeleted.
+func (mr *MockOrderPGRepositoryMockRecorder) PurgeDeleted(ctx, deletedBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeleted", reflect.TypeOf((*MockOrderPGRepository)(nil).PurgeDeleted), ctx, deletedBefore)
+}
+
+// RestoreById mocks base method.
+func (m *MockOrderPGRepository) RestoreById(ctx context.Context, orderID uuid.UUID) (*models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreById", ctx, orderID)
 	ret0, _ := ret[0].(*models.Order)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FindById indicates an expected call of FindById.
-func (mr *MockOrderPGRepositoryMockRecorder) FindById(ctx, userID interface{}) *gomock.Call {
+// RestoreById indicates an expected call of RestoreById.
+func (mr *MockOrderPGRepositoryMockRecorder) RestoreById(ctx, orderID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockOrderPGRepository)(nil).FindById), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreById", reflect.TypeOf((*MockOrderPGRepository)(nil).RestoreById), ctx, orderID)
 }
 
 // UpdateById mocks base method.
@@ -7,6 +7,7 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	models "github.com/dinorain/kalobranded/internal/models"
 	utils "github.com/dinorain/kalobranded/pkg/utils"
@@ -37,6 +38,21 @@ func (m *MockOrderUseCase) EXPECT() *MockOrderUseCaseMockRecorder {
 	return m.recorder
 }
 
+// BulkAction mocks base method.
+func (m *MockOrderUseCase) BulkAction(ctx context.Context, brandID uuid.UUID, action string, orderIDs []uuid.UUID) (*models.OrderBulkResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkAction", ctx, brandID, action, orderIDs)
+	ret0, _ := ret[0].(*models.OrderBulkResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkAction indicates an expected call of BulkAction.
+func (mr *MockOrderUseCaseMockRecorder) BulkAction(ctx, brandID, action, orderIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkAction", reflect.TypeOf((*MockOrderUseCase)(nil).BulkAction), ctx, brandID, action, orderIDs)
+}
+
 // CachedFindById mocks base method.
 func (m *MockOrderUseCase) CachedFindById(ctx context.Context, orderID uuid.UUID) (*models.Order, error) {
 	m.ctrl.T.Helper()
@@ -52,6 +68,36 @@ func (mr *MockOrderUseCaseMockRecorder) CachedFindById(ctx, orderID interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CachedFindById", reflect.TypeOf((*MockOrderUseCase)(nil).CachedFindById), ctx, orderID)
 }
 
+// Count mocks base method.
+func (m *MockOrderUseCase) Count(ctx context.Context, filter *models.OrderFilter, estimate bool) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, filter, estimate)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockOrderUseCaseMockRecorder) Count(ctx, filter, estimate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockOrderUseCase)(nil).Count), ctx, filter, estimate)
+}
+
+// CountWithDeleted mocks base method.
+func (m *MockOrderUseCase) CountWithDeleted(ctx context.Context, filter *models.OrderFilter, estimate bool) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountWithDeleted", ctx, filter, estimate)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountWithDeleted indicates an expected call of CountWithDeleted.
+func (mr *MockOrderUseCaseMockRecorder) CountWithDeleted(ctx, filter, estimate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountWithDeleted", reflect.TypeOf((*MockOrderUseCase)(nil).CountWithDeleted), ctx, filter, estimate)
+}
+
 // Create mocks base method.
 func (m *MockOrderUseCase) Create(ctx context.Context, order *models.Order) (*models.Order, error) {
 	m.ctrl.T.Helper()
@@ -68,92 +114,121 @@ func (mr *MockOrderUseCaseMockRecorder) Create(ctx, order interface{}) *gomock.C
 }
 
 // DeleteById mocks base method.
-func (m *MockOrderUseCase) DeleteById(ctx context.Context, orderID uuid.UUID) error {
+func (m *MockOrderUseCase) DeleteById(ctx context.Context, orderID uuid.UUID, versions []int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteById", ctx, orderID)
+	ret := m.ctrl.Call(m, "DeleteById", ctx, orderID, versions)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteById indicates an expected call of DeleteById.
-func (mr *MockOrderUseCaseMockRecorder) DeleteById(ctx, orderID interface{}) *gomock.Call {
+func (mr *MockOrderUseCaseMockRecorder) DeleteById(ctx, orderID, versions interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockOrderUseCase)(nil).DeleteById), ctx, orderID, versions)
+}
+
+// Export mocks base method.
+func (m *MockOrderUseCase) Export(ctx context.Context, filter *models.OrderFilter, sort string, fn func(*models.Order) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, filter, sort, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockOrderUseCaseMockRecorder) Export(ctx, filter, sort, fn interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockOrderUseCase)(nil).DeleteById), ctx, orderID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockOrderUseCase)(nil).Export), ctx, filter, sort, fn)
 }
 
 // FindAll mocks base method.
-func (m *MockOrderUseCase) FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.Order, error) {
+func (m *MockOrderUseCase) FindAll(ctx context.Context, filter *models.OrderFilter, pagination *utils.Pagination) ([]models.Order, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAll", ctx, pagination)
+	ret := m.ctrl.Call(m, "FindAll", ctx, filter, pagination)
 	ret0, _ := ret[0].([]models.Order)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindAll indicates an expected call of FindAll.
-func (mr *MockOrderUseCaseMockRecorder) FindAll(ctx, pagination interface{}) *gomock.Call {
+func (mr *MockOrderUseCaseMockRecorder) FindAll(ctx, filter, pagination interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockOrderUseCase)(nil).FindAll), ctx, pagination)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockOrderUseCase)(nil).FindAll), ctx, filter, pagination)
 }
 
-// FindAllByBrandId mocks base method.
-func (m *MockOrderUseCase) FindAllByBrandId(ctx context.Context, brandID uuid.UUID, pagination *utils.Pagination) ([]models.Order, error) {
+// FindAllWithDeleted mocks base method.
+func (m *MockOrderUseCase) FindAllWithDeleted(ctx context.Context, filter *models.OrderFilter, pagination *utils.Pagination) ([]models.Order, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAllByBrandId", ctx, brandID, pagination)
+	ret := m.ctrl.Call(m, "FindAllWithDeleted", ctx, filter, pagination)
 	ret0, _ := ret[0].([]models.Order)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FindAllByBrandId indicates an expected call of FindAllByBrandId.
-func (mr *MockOrderUseCaseMockRecorder) FindAllByBrandId(ctx, brandID, pagination interface{}) *gomock.Call {
+// FindAllWithDeleted indicates an expected call of FindAllWithDeleted.
+func (mr *MockOrderUseCaseMockRecorder) FindAllWithDeleted(ctx, filter, pagination interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByBrandId", reflect.TypeOf((*MockOrderUseCase)(nil).FindAllByBrandId), ctx, brandID, pagination)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllWithDeleted", reflect.TypeOf((*MockOrderUseCase)(nil).FindAllWithDeleted), ctx, filter, pagination)
 }
 
-// FindAllByUserId mocks base method.
-func (m *MockOrderUseCase) FindAllByUserId(ctx context.Context, userID uuid.UUID, pagination *utils.Pagination) ([]models.Order, error) {
+// FindById mocks base method.
+func (m *MockOrderUseCase) FindById(ctx context.Context, orderID uuid.UUID) (*models.Order, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAllByUserId", ctx, userID, pagination)
-	ret0, _ := ret[0].([]models.Order)
+	ret := m.ctrl.Call(m, "FindById", ctx, orderID)
+	ret0, _ := ret[0].(*models.Order)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FindAllByUserId indicates an expected call of FindAllByUserId.
-func (mr *MockOrderUseCaseMockRecorder) FindAllByUserId(ctx, userID, pagination interface{}) *gomock.Call {
+// FindById indicates an expected call of FindById.
+func (mr *MockOrderUseCaseMockRecorder) FindById(ctx, orderID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByUserId", reflect.TypeOf((*MockOrderUseCase)(nil).FindAllByUserId), ctx, userID, pagination)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockOrderUseCase)(nil).FindById), ctx, orderID)
 }
 
-// FindAllByUserIdBrandId mocks base method.
-func (m *MockOrderUseCase) FindAllByUserIdBrandId(ctx context.Context, userID, brandID uuid.UUID, pagination *utils.Pagination) ([]models.Order, error) {
+// FindByIdWithDeleted mocks base method.
+func (m *MockOrderUseCase) FindByIdWithDeleted(ctx context.Context, orderID uuid.UUID) (*models.Order, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAllByUserIdBrandId", ctx, userID, brandID, pagination)
-	ret0, _ := ret[0].([]models.Order)
+	ret := m.ctrl.Call(m, "FindByIdWithDeleted", ctx, orderID)
+	ret0, _ := ret[0].(*models.Order)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FindAllByUserIdBrandId indicates an expected call of FindAllByUserIdBrandId.
-func (mr *MockOrderUseCaseMockRecorder) FindAllByUserIdBrandId(ctx, userID, brandID, pagination interface{}) *gomock.Call {
+// FindByIdWithDeleted indicates an expected call of FindByIdWithDeleted.
+func (mr *MockOrderUseCaseMockRecorder) FindByIdWithDeleted(ctx, orderID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByUserIdBrandId", reflect.TypeOf((*MockOrderUseCase)(nil).FindAllByUserIdBrandId), ctx, userID, brandID, pagination)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIdWithDeleted", reflect.TypeOf((*MockOrderUseCase)(nil).FindByIdWithDeleted), ctx, orderID)
 }
 
-// FindById mocks base method.
-func (m *MockOrderUseCase) FindById(ctx context.Context, orderID uuid.UUID) (*models.Order, error) {
+// PurgeDeleted mocks base method.
+func (m *MockOrderUseCase) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindById", ctx, orderID)
+	ret := m.ctrl.Call(m, "PurgeDeleted", ctx, deletedBefore)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeleted indicates an expected call of PurgeDeleted.
+func (mr *MockOrderUseCaseMockRecorder) PurgeDeleted(ctx, deletedBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeleted", reflect.TypeOf((*MockOrderUseCase)(nil).PurgeDeleted), ctx, deletedBefore)
+}
+
+// RestoreById mocks base method.
+func (m *MockOrderUseCase) RestoreById(ctx context.Context, orderID uuid.UUID) (*models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreById", ctx, orderID)
 	ret0, _ := ret[0].(*models.Order)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FindById indicates an expected call of FindById.
-func (mr *MockOrderUseCaseMockRecorder) FindById(ctx, orderID interface{}) *gomock.Call {
+// RestoreById indicates an expected call of RestoreById.
+func (mr *MockOrderUseCaseMockRecorder) RestoreById(ctx, orderID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockOrderUseCase)(nil).FindById), ctx, orderID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreById", reflect.TypeOf((*MockOrderUseCase)(nil).RestoreById), ctx, orderID)
 }
 
 // UpdateById mocks base method.
@@ -3,6 +3,7 @@ package order
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -13,11 +14,16 @@ import (
 // Order pg repository
 type OrderPGRepository interface {
 	Create(ctx context.Context, user *models.Order) (*models.Order, error)
-	FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.Order, error)
-	FindAllByUserId(ctx context.Context, userID uuid.UUID, pagination *utils.Pagination) ([]models.Order, error)
-	FindAllByBrandId(ctx context.Context, brandID uuid.UUID, pagination *utils.Pagination) ([]models.Order, error)
-	FindAllByUserIdBrandId(ctx context.Context, userID uuid.UUID, brandID uuid.UUID, pagination *utils.Pagination) ([]models.Order, error)
+	FindAll(ctx context.Context, filter *models.OrderFilter, pagination *utils.Pagination) ([]models.Order, error)
+	Export(ctx context.Context, filter *models.OrderFilter, sort string, fn func(order *models.Order) error) error
+	Count(ctx context.Context, filter *models.OrderFilter, estimate bool) (int, error)
+	BulkUpdateStatus(ctx context.Context, brandID uuid.UUID, orderIDs []uuid.UUID, status string) ([]models.OrderBulkItemResult, bool, error)
 	FindById(ctx context.Context, userID uuid.UUID) (*models.Order, error)
 	UpdateById(ctx context.Context, user *models.Order) (*models.Order, error)
-	DeleteById(ctx context.Context, userID uuid.UUID) error
+	DeleteById(ctx context.Context, orderID uuid.UUID, versions []int) error
+	FindAllWithDeleted(ctx context.Context, filter *models.OrderFilter, pagination *utils.Pagination) ([]models.Order, error)
+	CountWithDeleted(ctx context.Context, filter *models.OrderFilter, estimate bool) (int, error)
+	FindByIdWithDeleted(ctx context.Context, orderID uuid.UUID) (*models.Order, error)
+	RestoreById(ctx context.Context, orderID uuid.UUID) (*models.Order, error)
+	PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error)
 }
@@ -3,13 +3,17 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/pkg/errors"
 
 	"github.com/dinorain/kalobranded/internal/models"
 	"github.com/dinorain/kalobranded/internal/order"
+	outboxRepository "github.com/dinorain/kalobranded/internal/outbox/repository"
 	"github.com/dinorain/kalobranded/pkg/utils"
 )
 
@@ -25,10 +29,18 @@ func NewOrderPGRepository(db *sqlx.DB) *OrderRepository {
 	return &OrderRepository{db: db}
 }
 
-// Create new order
+// Create new order, redeeming its applied promotions, taking its quantity off the stock of the location it
+// ships from, or of the product for brands without locations, and writing its order.created event in the same transaction. A promotion used up globally or by the ordering user meanwhile fails the
+// whole order with models.ErrPromotionUsageLimitReached, a location or product short of the quantity with models.ErrOutOfStock
 func (r *OrderRepository) Create(ctx context.Context, order *models.Order) (*models.Order, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "OrderPGRepository.Create.BeginTxx")
+	}
+	defer tx.Rollback()
+
 	createdOrder := &models.Order{}
-	if err := r.db.QueryRowxContext(
+	if err := tx.QueryRowxContext(
 		ctx,
 		createOrderQuery,
 		order.UserID,
@@ -39,17 +51,86 @@ func (r *OrderRepository) Create(ctx context.Context, order *models.Order) (*mod
 		order.Status,
 		order.DeliverySourceAddress,
 		order.DeliveryDestinationAddress,
+		order.DiscountTotal,
+		order.AppliedPromotions,
+		order.FreeShipping,
+		order.TaxTotal,
+		order.TaxLines,
+		order.DeliveryFee,
+		order.DeliveryDistance,
+		order.DeliveryAddress,
+		order.LocationID,
 	).StructScan(createdOrder); err != nil {
 		return nil, errors.Wrap(err, "OrderPGRepository.Create.QueryRowxContext")
 	}
 
+	if order.LocationID != nil {
+		if err := execOne(ctx, tx, models.ErrOutOfStock, takeLocationStockQuery, order.LocationID, order.Item.ProductID, order.Quantity); err != nil {
+			return nil, errors.Wrapf(err, "OrderPGRepository.Create.TakeLocationStock %s", order.LocationID)
+		}
+	} else {
+		if err := execOne(ctx, tx, models.ErrOutOfStock, takeProductStockQuery, order.Item.ProductID, order.Quantity); err != nil {
+			return nil, errors.Wrapf(err, "OrderPGRepository.Create.TakeProductStock %s", order.Item.ProductID)
+		}
+	}
+
+	for _, applied := range order.AppliedPromotions {
+		// the usage count update locks the promotion row, so the per user count below sees concurrent redemptions
+		if err := execOne(ctx, tx, models.ErrPromotionUsageLimitReached, redeemPromotionQuery, applied.PromotionID); err != nil {
+			return nil, errors.Wrapf(err, "OrderPGRepository.Create.RedeemPromotion %s", applied.PromotionID)
+		}
+		if err := execOne(ctx, tx, models.ErrPromotionUsageLimitReached, createPromotionRedemptionQuery, applied.PromotionID, createdOrder.OrderID, createdOrder.UserID, applied.Discount); err != nil {
+			return nil, errors.Wrapf(err, "OrderPGRepository.Create.CreatePromotionRedemption %s", applied.PromotionID)
+		}
+	}
+
+	if err := outboxRepository.AddEvent(ctx, tx, models.AggregateOrder, createdOrder.OrderID, models.EventOrderCreated, createdOrder); err != nil {
+		return nil, errors.Wrap(err, "OrderPGRepository.Create.AddEvent")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "OrderPGRepository.Create.Commit")
+	}
+
 	return createdOrder, nil
 }
 
-// UpdateById update existing order
+// execOne exec query expected to affect a row, errNone when none is
+func execOne(ctx context.Context, tx *sqlx.Tx, errNone error, query string, args ...interface{}) error {
+	res, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return err
+	} else if cnt == 0 {
+		return errNone
+	}
+	return nil
+}
+
+// UpdateById update existing order when its version is unchanged, bumping the version. A status change writes its
+// order.status_changed event in the same transaction
 func (r *OrderRepository) UpdateById(ctx context.Context, order *models.Order) (*models.Order, error) {
-	if res, err := r.db.ExecContext(
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "OrderPGRepository.Update.BeginTxx")
+	}
+	defer tx.Rollback()
+
+	var previousStatus string
+	if err := tx.GetContext(ctx, &previousStatus, lockStatusQuery, order.OrderID, order.Version); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, models.ErrVersionConflict
+		}
+		return nil, errors.Wrap(err, "OrderPGRepository.Update.GetContext")
+	}
+
+	if err := execOne(
 		ctx,
+		tx,
+		models.ErrVersionConflict,
 		updateByIdQuery,
 		order.OrderID,
 		order.UserID,
@@ -60,56 +141,157 @@ func (r *OrderRepository) UpdateById(ctx context.Context, order *models.Order) (
 		order.Status,
 		order.DeliverySourceAddress,
 		order.DeliveryDestinationAddress,
+		order.Version,
 	); err != nil {
+		if errors.Is(err, models.ErrVersionConflict) {
+			return nil, err
+		}
 		return nil, errors.Wrap(err, "OrderPGRepository.Update.ExecContext")
-	} else {
-		_, err := res.RowsAffected()
-		if err != nil {
-			return nil, errors.Wrap(err, "OrderPGRepository.Update.RowsAffected")
+	}
+
+	if previousStatus != order.Status {
+		if err := outboxRepository.AddEvent(ctx, tx, models.AggregateOrder, order.OrderID, models.EventOrderStatusChanged, &models.OrderStatusChange{
+			OrderID: order.OrderID,
+			UserID:  order.UserID,
+			BrandID: order.BrandID,
+			From:    previousStatus,
+			To:      order.Status,
+			Version: order.Version + 1,
+		}); err != nil {
+			return nil, errors.Wrap(err, "OrderPGRepository.Update.AddEvent")
 		}
 	}
 
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "OrderPGRepository.Update.Commit")
+	}
+
+	order.Version++
+
 	return order, nil
 }
 
-// FindAll Find orders
-func (r *OrderRepository) FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.Order, error) {
+// FindAll Find orders matching filter
+func (r *OrderRepository) FindAll(ctx context.Context, filter *models.OrderFilter, pagination *utils.Pagination) ([]models.Order, error) {
+	query, args, err := orderListQuery(filter, false).Paginate(models.OrderSorts, pagination).Build()
+	if err != nil {
+		return nil, errors.Wrap(err, "OrderRepository.FindAll.Build")
+	}
+
 	var orders []models.Order
-	if err := r.db.SelectContext(ctx, &orders, findAllQuery, pagination.GetLimit(), pagination.GetOffset()); err != nil {
-		return nil, errors.Wrap(err, "OrderPGRepository.FindById.SelectContext")
+	if err := r.db.SelectContext(ctx, &orders, query, args...); err != nil {
+		return nil, errors.Wrap(err, "OrderRepository.FindAll.SelectContext")
 	}
 
 	return orders, nil
 }
 
-// FindAllByUserId Find orders by user uuid
-func (r *OrderRepository) FindAllByUserId(ctx context.Context, userID uuid.UUID, pagination *utils.Pagination) ([]models.Order, error) {
-	var orders []models.Order
-	if err := r.db.SelectContext(ctx, &orders, findByUserIdQuery, userID, pagination.GetLimit(), pagination.GetOffset()); err != nil {
-		return nil, errors.Wrap(err, "OrderPGRepository.FindAllByUserId.SelectContext")
+// Export call fn with each order matching filter in turn, sorted by sort out of models.OrderSorts. The orders are
+// read from the database as fn takes them rather than all at once, the first error of fn stops the export
+func (r *OrderRepository) Export(ctx context.Context, filter *models.OrderFilter, sort string, fn func(order *models.Order) error) error {
+	query, args, err := orderListQuery(filter, false).OrderBy(models.OrderSorts, sort).Build()
+	if err != nil {
+		return errors.Wrap(err, "OrderRepository.Export.Build")
 	}
 
-	return orders, nil
+	rows, err := r.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return errors.Wrap(err, "OrderRepository.Export.QueryxContext")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		order := &models.Order{}
+		if err := rows.StructScan(order); err != nil {
+			return errors.Wrap(err, "OrderRepository.Export.StructScan")
+		}
+		if err := fn(order); err != nil {
+			return err
+		}
+	}
+
+	return errors.Wrap(rows.Err(), "OrderRepository.Export.Rows")
 }
 
-// FindAllByBrandId Find orders by brand uuid
-func (r *OrderRepository) FindAllByBrandId(ctx context.Context, brandID uuid.UUID, pagination *utils.Pagination) ([]models.Order, error) {
-	var orders []models.Order
-	if err := r.db.SelectContext(ctx, &orders, findAllByBrandIdQuery, brandID, pagination.GetLimit(), pagination.GetOffset()); err != nil {
-		return nil, errors.Wrap(err, "OrderPGRepository.FindAllByBrandId.SelectContext")
+// Count count orders matching filter, or with estimate take the planner estimate of their count
+func (r *OrderRepository) Count(ctx context.Context, filter *models.OrderFilter, estimate bool) (int, error) {
+	count, err := r.count(ctx, orderListQuery(filter, false), estimate)
+	if err != nil {
+		return 0, errors.Wrap(err, "OrderRepository.Count")
 	}
 
-	return orders, nil
+	return count, nil
 }
 
-// FindAllByUserIdBrandId Find orders by user uuid and brand uuid
-func (r *OrderRepository) FindAllByUserIdBrandId(ctx context.Context, userID uuid.UUID, brandID uuid.UUID, pagination *utils.Pagination) ([]models.Order, error) {
-	var orders []models.Order
-	if err := r.db.SelectContext(ctx, &orders, findAllByUserIdBrandIDQuery, userID, brandID, pagination.GetLimit(), pagination.GetOffset()); err != nil {
-		return nil, errors.Wrap(err, "OrderPGRepository.FindAllByUserIdBrandId.SelectContext")
+// BulkUpdateStatus move the orders of brand to status all together or not at all, writing an order.status_changed
+// event for each. The orders are locked first, when one of them is missing or cannot move to status nothing is
+// updated and the results tell which ones prevented it
+func (r *OrderRepository) BulkUpdateStatus(ctx context.Context, brandID uuid.UUID, orderIDs []uuid.UUID, status string) ([]models.OrderBulkItemResult, bool, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "OrderPGRepository.BulkUpdateStatus.BeginTxx")
 	}
+	defer tx.Rollback()
 
-	return orders, nil
+	ids := make([]string, len(orderIDs))
+	for i, id := range orderIDs {
+		ids[i] = id.String()
+	}
+
+	var lockedOrders []models.Order
+	if err := tx.SelectContext(ctx, &lockedOrders, lockBrandOrdersQuery, pq.Array(ids), brandID); err != nil {
+		return nil, false, errors.Wrap(err, "OrderPGRepository.BulkUpdateStatus.SelectContext")
+	}
+	found := make(map[uuid.UUID]*models.Order, len(lockedOrders))
+	for i := range lockedOrders {
+		found[lockedOrders[i].OrderID] = &lockedOrders[i]
+	}
+
+	results := make([]models.OrderBulkItemResult, len(orderIDs))
+	applicable := true
+	for i, id := range orderIDs {
+		results[i].OrderID = id
+		lockedOrder, ok := found[id]
+		if !ok {
+			results[i].Error = sql.ErrNoRows.Error()
+			applicable = false
+			continue
+		}
+		results[i].PreviousStatus = lockedOrder.Status
+		results[i].Version = lockedOrder.Version
+		if !lockedOrder.CanTransitionTo(status) {
+			results[i].Error = fmt.Sprintf("%s: order is %s", models.ErrInvalidStatusTransition, lockedOrder.Status)
+			applicable = false
+		}
+	}
+	if !applicable {
+		return results, false, nil
+	}
+
+	for i := range results {
+		lockedOrder := found[results[i].OrderID]
+		if err := tx.GetContext(ctx, &results[i].Version, updateStatusQuery, lockedOrder.OrderID, status); err != nil {
+			return nil, false, errors.Wrapf(err, "OrderPGRepository.BulkUpdateStatus.GetContext %s", lockedOrder.OrderID)
+		}
+		results[i].Status = status
+
+		if err := outboxRepository.AddEvent(ctx, tx, models.AggregateOrder, lockedOrder.OrderID, models.EventOrderStatusChanged, &models.OrderStatusChange{
+			OrderID: lockedOrder.OrderID,
+			UserID:  lockedOrder.UserID,
+			BrandID: lockedOrder.BrandID,
+			From:    lockedOrder.Status,
+			To:      status,
+			Version: results[i].Version,
+		}); err != nil {
+			return nil, false, errors.Wrapf(err, "OrderPGRepository.BulkUpdateStatus.AddEvent %s", lockedOrder.OrderID)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, false, errors.Wrap(err, "OrderPGRepository.BulkUpdateStatus.Commit")
+	}
+
+	return results, true, nil
 }
 
 // FindById Find order by uuid
@@ -122,14 +304,16 @@ func (r *OrderRepository) FindById(ctx context.Context, orderID uuid.UUID) (*mod
 	return order, nil
 }
 
-// DeleteById Find order by uuid
-func (r *OrderRepository) DeleteById(ctx context.Context, orderID uuid.UUID) error {
-	if res, err := r.db.ExecContext(ctx, deleteByIdQuery, orderID); err != nil {
+// DeleteById soft delete order by uuid, at one of versions unless nil
+func (r *OrderRepository) DeleteById(ctx context.Context, orderID uuid.UUID, versions []int) error {
+	if res, err := r.db.ExecContext(ctx, deleteByIdQuery, orderID, pq.Array(versions)); err != nil {
 		return errors.Wrap(err, "OrderPGRepository.DeleteById.ExecContext")
 	} else {
 		cnt, err := res.RowsAffected()
 		if err != nil {
 			return errors.Wrap(err, "OrderPGRepository.DeleteById.RowsAffected")
+		} else if cnt == 0 && versions != nil {
+			return models.ErrPreconditionFailed
 		} else if cnt == 0 {
 			return sql.ErrNoRows
 		}
@@ -137,3 +321,124 @@ func (r *OrderRepository) DeleteById(ctx context.Context, orderID uuid.UUID) err
 
 	return nil
 }
+
+// FindAllWithDeleted Find orders matching filter including soft deleted ones
+func (r *OrderRepository) FindAllWithDeleted(ctx context.Context, filter *models.OrderFilter, pagination *utils.Pagination) ([]models.Order, error) {
+	query, args, err := orderListQuery(filter, true).Paginate(models.OrderSorts, pagination).Build()
+	if err != nil {
+		return nil, errors.Wrap(err, "OrderRepository.FindAllWithDeleted.Build")
+	}
+
+	var orders []models.Order
+	if err := r.db.SelectContext(ctx, &orders, query, args...); err != nil {
+		return nil, errors.Wrap(err, "OrderRepository.FindAllWithDeleted.SelectContext")
+	}
+
+	return orders, nil
+}
+
+// CountWithDeleted count orders matching filter including soft deleted ones, or with estimate take the planner
+// estimate of their count
+func (r *OrderRepository) CountWithDeleted(ctx context.Context, filter *models.OrderFilter, estimate bool) (int, error) {
+	count, err := r.count(ctx, orderListQuery(filter, true), estimate)
+	if err != nil {
+		return 0, errors.Wrap(err, "OrderRepository.CountWithDeleted")
+	}
+
+	return count, nil
+}
+
+// FindByIdWithDeleted Find order by uuid including soft deleted ones
+func (r *OrderRepository) FindByIdWithDeleted(ctx context.Context, orderID uuid.UUID) (*models.Order, error) {
+	order := &models.Order{}
+	if err := r.db.GetContext(ctx, order, findByIdWithDeletedQuery, orderID); err != nil {
+		return nil, errors.Wrap(err, "OrderRepository.FindByIdWithDeleted.GetContext")
+	}
+
+	return order, nil
+}
+
+// RestoreById restore soft deleted order by uuid
+func (r *OrderRepository) RestoreById(ctx context.Context, orderID uuid.UUID) (*models.Order, error) {
+	order := &models.Order{}
+	if err := r.db.QueryRowxContext(ctx, restoreByIdQuery, orderID).StructScan(order); err != nil {
+		return nil, errors.Wrap(err, "OrderRepository.RestoreById.QueryRowxContext")
+	}
+
+	return order, nil
+}
+
+// PurgeDeleted hard delete orders soft deleted before deletedBefore, returns purged count
+func (r *OrderRepository) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	res, err := r.db.ExecContext(ctx, purgeDeletedQuery, deletedBefore)
+	if err != nil {
+		return 0, errors.Wrap(err, "OrderRepository.PurgeDeleted.ExecContext")
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "OrderRepository.PurgeDeleted.RowsAffected")
+	}
+
+	return cnt, nil
+}
+
+// orderListQuery list of the orders matching filter
+func orderListQuery(filter *models.OrderFilter, withDeleted bool) *utils.QueryBuilder {
+	qb := utils.NewQueryBuilder(findAllQuery)
+	if !withDeleted {
+		qb.Where("deleted_at IS NULL")
+	}
+	if filter == nil {
+		return qb
+	}
+
+	if filter.UserID != nil {
+		qb.Where("user_id = ?", *filter.UserID)
+	}
+	if filter.BrandID != nil {
+		qb.Where("brand_id = ?", *filter.BrandID)
+	}
+	if filter.ProductID != nil {
+		qb.Where("item->>'product_id' = ?", filter.ProductID.String())
+	}
+	if len(filter.Statuses) > 0 {
+		qb.Where("status::text = ANY(?)", pq.Array(filter.Statuses))
+	}
+	if filter.CreatedFrom != nil {
+		qb.Where("created_at >= ?", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		qb.Where("created_at < ?", *filter.CreatedTo)
+	}
+	if filter.MinTotal != nil {
+		qb.Where("total_price >= ?", *filter.MinTotal)
+	}
+	if filter.MaxTotal != nil {
+		qb.Where("total_price <= ?", *filter.MaxTotal)
+	}
+
+	return qb
+}
+
+func (r *OrderRepository) count(ctx context.Context, builder *utils.QueryBuilder, estimate bool) (int, error) {
+	query, args, err := builder.BuildCount(estimate)
+	if err != nil {
+		return 0, errors.Wrap(err, "BuildCount")
+	}
+
+	if estimate {
+		var plan []byte
+		if err := r.db.QueryRowContext(ctx, query, args...).Scan(&plan); err != nil {
+			return 0, errors.Wrap(err, "QueryRowContext")
+		}
+		return utils.ParseEstimate(plan)
+	}
+
+	var count int
+	if err := r.db.GetContext(ctx, &count, query, args...); err != nil {
+		return 0, errors.Wrap(err, "GetContext")
+	}
+
+	return count, nil
+}
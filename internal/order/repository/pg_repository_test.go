@@ -2,16 +2,21 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/require"
 
 	"github.com/dinorain/kalobranded/internal/models"
+	outboxRepository "github.com/dinorain/kalobranded/internal/outbox/repository"
 	"github.com/dinorain/kalobranded/pkg/utils"
 )
 
@@ -66,6 +71,7 @@ func TestOrderRepository_Create(t *testing.T) {
 		time.Now(),
 	)
 
+	mock.ExpectBegin()
 	mock.ExpectQuery(createOrderQuery).WithArgs(
 		mockOrder.UserID,
 		mockOrder.BrandID,
@@ -75,11 +81,97 @@ func TestOrderRepository_Create(t *testing.T) {
 		mockOrder.Status,
 		mockOrder.DeliverySourceAddress,
 		mockOrder.DeliveryDestinationAddress,
+		mockOrder.DiscountTotal,
+		mockOrder.AppliedPromotions,
+		mockOrder.FreeShipping,
+		mockOrder.TaxTotal,
+		mockOrder.TaxLines,
+		mockOrder.DeliveryFee,
+		mockOrder.DeliveryDistance,
+		mockOrder.DeliveryAddress,
+		mockOrder.LocationID,
 	).WillReturnRows(rows)
+	mock.ExpectExec(takeProductStockQuery).WithArgs(productUUID, mockOrder.Quantity).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(outboxRepository.AddEventQuery).WithArgs(sqlmock.AnyArg(), models.AggregateOrder, sqlmock.AnyArg(), models.EventOrderCreated, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
 
 	createdOrder, err := orderPGRepository.Create(context.Background(), mockOrder)
 	require.NoError(t, err)
 	require.NotNil(t, createdOrder)
+
+	promotionUUID := uuid.New()
+	promotedOrder := *mockOrder
+	promotedOrder.TotalPrice = 9000.0
+	promotedOrder.DiscountTotal = 1000.0
+	promotedOrder.AppliedPromotions = models.AppliedPromotions{{PromotionID: promotionUUID, Name: "Name", Type: models.PromotionTypeFixedAmount, Discount: 1000.0}}
+
+	t.Run("WithPromotions", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(createOrderQuery).WillReturnRows(sqlmock.NewRows([]string{"order_id", "user_id"}).AddRow(orderUUID, userUUID))
+		mock.ExpectExec(takeProductStockQuery).WithArgs(productUUID, promotedOrder.Quantity).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(redeemPromotionQuery).WithArgs(promotionUUID).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(createPromotionRedemptionQuery).WithArgs(promotionUUID, orderUUID, userUUID, 1000.0).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(outboxRepository.AddEventQuery).WithArgs(sqlmock.AnyArg(), models.AggregateOrder, sqlmock.AnyArg(), models.EventOrderCreated, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		createdOrder, err := orderPGRepository.Create(context.Background(), &promotedOrder)
+		require.NoError(t, err)
+		require.Equal(t, orderUUID, createdOrder.OrderID)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("UsageLimitReached", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(createOrderQuery).WillReturnRows(sqlmock.NewRows([]string{"order_id", "user_id"}).AddRow(orderUUID, userUUID))
+		mock.ExpectExec(takeProductStockQuery).WithArgs(productUUID, promotedOrder.Quantity).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(redeemPromotionQuery).WithArgs(promotionUUID).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(createPromotionRedemptionQuery).WithArgs(promotionUUID, orderUUID, userUUID, 1000.0).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		_, err := orderPGRepository.Create(context.Background(), &promotedOrder)
+		require.ErrorIs(t, err, models.ErrPromotionUsageLimitReached)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ProductOutOfStock", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(createOrderQuery).WillReturnRows(sqlmock.NewRows([]string{"order_id", "user_id"}).AddRow(orderUUID, userUUID))
+		mock.ExpectExec(takeProductStockQuery).WithArgs(productUUID, mockOrder.Quantity).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		_, err := orderPGRepository.Create(context.Background(), mockOrder)
+		require.ErrorIs(t, err, models.ErrOutOfStock)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	locationUUID := uuid.New()
+	locatedOrder := *mockOrder
+	locatedOrder.Quantity = 2
+	locatedOrder.LocationID = &locationUUID
+
+	t.Run("WithLocation", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(createOrderQuery).WillReturnRows(sqlmock.NewRows([]string{"order_id", "user_id"}).AddRow(orderUUID, userUUID))
+		mock.ExpectExec(takeLocationStockQuery).WithArgs(locationUUID, productUUID, uint64(2)).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(outboxRepository.AddEventQuery).WithArgs(sqlmock.AnyArg(), models.AggregateOrder, sqlmock.AnyArg(), models.EventOrderCreated, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		createdOrder, err := orderPGRepository.Create(context.Background(), &locatedOrder)
+		require.NoError(t, err)
+		require.Equal(t, orderUUID, createdOrder.OrderID)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("OutOfStock", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(createOrderQuery).WillReturnRows(sqlmock.NewRows([]string{"order_id", "user_id"}).AddRow(orderUUID, userUUID))
+		mock.ExpectExec(takeLocationStockQuery).WithArgs(locationUUID, productUUID, uint64(2)).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		_, err := orderPGRepository.Create(context.Background(), &locatedOrder)
+		require.ErrorIs(t, err, models.ErrOutOfStock)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
 }
 
 func TestOrderRepository_FindAll(t *testing.T) {
@@ -134,14 +226,15 @@ func TestOrderRepository_FindAll(t *testing.T) {
 	)
 
 	size := 10
-	mock.ExpectQuery(findAllQuery).WithArgs(size, 0).WillReturnRows(rows)
-	foundOrders, err := orderPGRepository.FindAll(context.Background(), utils.NewPaginationQuery(size, 1))
+	query := findAllQuery + " WHERE deleted_at IS NULL ORDER BY created_at DESC, order_id DESC LIMIT $1 OFFSET $2"
+	mock.ExpectQuery(query).WithArgs(size, 0).WillReturnRows(rows)
+	foundOrders, err := orderPGRepository.FindAll(context.Background(), &models.OrderFilter{}, utils.NewPaginationQuery(size, 1))
 	require.NoError(t, err)
 	require.NotNil(t, foundOrders)
 	require.Equal(t, len(foundOrders), 1)
 
-	mock.ExpectQuery(findAllQuery).WithArgs(size, 10).WillReturnRows(rows)
-	foundOrders, err = orderPGRepository.FindAll(context.Background(), utils.NewPaginationQuery(size, 2))
+	mock.ExpectQuery(query).WithArgs(size, 10).WillReturnRows(rows)
+	foundOrders, err = orderPGRepository.FindAll(context.Background(), nil, utils.NewPaginationQuery(size, 2))
 	require.NoError(t, err)
 	require.Nil(t, foundOrders)
 }
@@ -233,20 +326,21 @@ func TestOrderRepository_FindAllByBrandId(t *testing.T) {
 	)
 
 	size := 10
+	findAllByBrandIdQuery := findAllQuery + " WHERE deleted_at IS NULL AND brand_id = $1 ORDER BY created_at DESC, order_id DESC LIMIT $2 OFFSET $3"
 	mock.ExpectQuery(findAllByBrandIdQuery).WithArgs(mockOrder.BrandID, size, 0).WillReturnRows(rows)
-	foundOrders, err := orderPGRepository.FindAllByBrandId(context.Background(), mockOrder.BrandID, utils.NewPaginationQuery(size, 1))
+	foundOrders, err := orderPGRepository.FindAll(context.Background(), &models.OrderFilter{BrandID: &mockOrder.BrandID}, utils.NewPaginationQuery(size, 1))
 	require.NoError(t, err)
 	require.NotNil(t, foundOrders)
 	require.Equal(t, len(foundOrders), 1)
 
 	mock.ExpectQuery(findAllByBrandIdQuery).WithArgs(mockOtherOrder.BrandID, size, 0).WillReturnRows(otherRows)
-	foundOrders, err = orderPGRepository.FindAllByBrandId(context.Background(), mockOtherOrder.BrandID, utils.NewPaginationQuery(size, 1))
+	foundOrders, err = orderPGRepository.FindAll(context.Background(), &models.OrderFilter{BrandID: &mockOtherOrder.BrandID}, utils.NewPaginationQuery(size, 1))
 	require.NoError(t, err)
 	require.NotNil(t, foundOrders)
 	require.Equal(t, len(foundOrders), 1)
 
 	mock.ExpectQuery(findAllByBrandIdQuery).WithArgs(mockOtherOrder.BrandID, size, 10).WillReturnRows(otherRows)
-	foundOrders, err = orderPGRepository.FindAllByBrandId(context.Background(), mockOtherOrder.BrandID, utils.NewPaginationQuery(size, 2))
+	foundOrders, err = orderPGRepository.FindAll(context.Background(), &models.OrderFilter{BrandID: &mockOtherOrder.BrandID}, utils.NewPaginationQuery(size, 2))
 	require.NoError(t, err)
 	require.Nil(t, foundOrders)
 }
@@ -338,20 +432,21 @@ func TestOrderRepository_FindAllByUserId(t *testing.T) {
 	)
 
 	size := 10
+	findByUserIdQuery := findAllQuery + " WHERE deleted_at IS NULL AND user_id = $1 ORDER BY created_at DESC, order_id DESC LIMIT $2 OFFSET $3"
 	mock.ExpectQuery(findByUserIdQuery).WithArgs(mockOrder.UserID, size, 0).WillReturnRows(rows)
-	foundOrders, err := orderPGRepository.FindAllByUserId(context.Background(), mockOrder.UserID, utils.NewPaginationQuery(size, 1))
+	foundOrders, err := orderPGRepository.FindAll(context.Background(), &models.OrderFilter{UserID: &mockOrder.UserID}, utils.NewPaginationQuery(size, 1))
 	require.NoError(t, err)
 	require.NotNil(t, foundOrders)
 	require.Equal(t, len(foundOrders), 1)
 
 	mock.ExpectQuery(findByUserIdQuery).WithArgs(mockOtherOrder.UserID, size, 0).WillReturnRows(otherRows)
-	foundOrders, err = orderPGRepository.FindAllByUserId(context.Background(), mockOtherOrder.UserID, utils.NewPaginationQuery(size, 1))
+	foundOrders, err = orderPGRepository.FindAll(context.Background(), &models.OrderFilter{UserID: &mockOtherOrder.UserID}, utils.NewPaginationQuery(size, 1))
 	require.NoError(t, err)
 	require.NotNil(t, foundOrders)
 	require.Equal(t, len(foundOrders), 1)
 
 	mock.ExpectQuery(findByUserIdQuery).WithArgs(mockOtherOrder.UserID, size, 10).WillReturnRows(otherRows)
-	foundOrders, err = orderPGRepository.FindAllByUserId(context.Background(), mockOtherOrder.UserID, utils.NewPaginationQuery(size, 2))
+	foundOrders, err = orderPGRepository.FindAll(context.Background(), &models.OrderFilter{UserID: &mockOtherOrder.UserID}, utils.NewPaginationQuery(size, 2))
 	require.NoError(t, err)
 	require.Nil(t, foundOrders)
 }
@@ -443,20 +538,21 @@ func TestOrderRepository_FindAllByUserIdBrandId(t *testing.T) {
 	)
 
 	size := 10
+	findAllByUserIdBrandIDQuery := findAllQuery + " WHERE deleted_at IS NULL AND user_id = $1 AND brand_id = $2 ORDER BY created_at DESC, order_id DESC LIMIT $3 OFFSET $4"
 	mock.ExpectQuery(findAllByUserIdBrandIDQuery).WithArgs(mockOrder.UserID, mockOrder.BrandID, size, 0).WillReturnRows(rows)
-	foundOrders, err := orderPGRepository.FindAllByUserIdBrandId(context.Background(), mockOrder.UserID, mockOrder.BrandID, utils.NewPaginationQuery(size, 1))
+	foundOrders, err := orderPGRepository.FindAll(context.Background(), &models.OrderFilter{UserID: &mockOrder.UserID, BrandID: &mockOrder.BrandID}, utils.NewPaginationQuery(size, 1))
 	require.NoError(t, err)
 	require.NotNil(t, foundOrders)
 	require.Equal(t, len(foundOrders), 1)
 
 	mock.ExpectQuery(findAllByUserIdBrandIDQuery).WithArgs(mockOtherOrder.UserID, mockOtherOrder.BrandID, size, 0).WillReturnRows(otherRows)
-	foundOrders, err = orderPGRepository.FindAllByUserIdBrandId(context.Background(), mockOtherOrder.UserID, mockOtherOrder.BrandID, utils.NewPaginationQuery(size, 1))
+	foundOrders, err = orderPGRepository.FindAll(context.Background(), &models.OrderFilter{UserID: &mockOtherOrder.UserID, BrandID: &mockOtherOrder.BrandID}, utils.NewPaginationQuery(size, 1))
 	require.NoError(t, err)
 	require.NotNil(t, foundOrders)
 	require.Equal(t, len(foundOrders), 1)
 
 	mock.ExpectQuery(findAllByUserIdBrandIDQuery).WithArgs(mockOtherOrder.UserID, mockOtherOrder.BrandID, size, 10).WillReturnRows(otherRows)
-	foundOrders, err = orderPGRepository.FindAllByUserIdBrandId(context.Background(), mockOtherOrder.UserID, mockOtherOrder.BrandID, utils.NewPaginationQuery(size, 2))
+	foundOrders, err = orderPGRepository.FindAll(context.Background(), &models.OrderFilter{UserID: &mockOtherOrder.UserID, BrandID: &mockOtherOrder.BrandID}, utils.NewPaginationQuery(size, 2))
 	require.NoError(t, err)
 	require.Nil(t, foundOrders)
 }
@@ -572,6 +668,8 @@ func TestOrderRepository_UpdateById(t *testing.T) {
 	)
 
 	mockOrder.Status = models.OrderStatusAccepted
+	mock.ExpectBegin()
+	mock.ExpectQuery(lockStatusQuery).WithArgs(mockOrder.OrderID, mockOrder.Version).WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(models.OrderStatusPaid))
 	mock.ExpectExec(updateByIdQuery).WithArgs(
 		mockOrder.OrderID,
 		mockOrder.UserID,
@@ -582,13 +680,37 @@ func TestOrderRepository_UpdateById(t *testing.T) {
 		mockOrder.Status,
 		mockOrder.DeliverySourceAddress,
 		mockOrder.DeliveryDestinationAddress,
+		mockOrder.Version,
 	).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(outboxRepository.AddEventQuery).WithArgs(sqlmock.AnyArg(), models.AggregateOrder, orderUUID, models.EventOrderStatusChanged, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
 
 	updatedOrder, err := orderPGRepository.UpdateById(context.Background(), mockOrder)
 	require.NoError(t, err)
 	require.NotNil(t, mockOrder)
 	require.Equal(t, updatedOrder.Status, mockOrder.Status)
 	require.Equal(t, updatedOrder.OrderID, mockOrder.OrderID)
+	require.Equal(t, 1, updatedOrder.Version)
+
+	t.Run("StatusUnchanged", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockStatusQuery).WithArgs(mockOrder.OrderID, mockOrder.Version).WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(mockOrder.Status))
+		mock.ExpectExec(updateByIdQuery).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		_, err := orderPGRepository.UpdateById(context.Background(), mockOrder)
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("VersionConflict", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockStatusQuery).WithArgs(mockOrder.OrderID, mockOrder.Version).WillReturnRows(sqlmock.NewRows([]string{"status"}))
+		mock.ExpectRollback()
+
+		_, err := orderPGRepository.UpdateById(context.Background(), mockOrder)
+		require.ErrorIs(t, err, models.ErrVersionConflict)
+	})
 }
 
 func TestOrderRepository_DeleteById(t *testing.T) {
@@ -642,9 +764,321 @@ func TestOrderRepository_DeleteById(t *testing.T) {
 		time.Now(),
 	)
 
-	mock.ExpectExec(deleteByIdQuery).WithArgs(mockOrder.OrderID).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(deleteByIdQuery).WithArgs(mockOrder.OrderID, pq.Array([]int(nil))).WillReturnResult(sqlmock.NewResult(0, 1))
 
-	err = orderPGRepository.DeleteById(context.Background(), mockOrder.OrderID)
+	err = orderPGRepository.DeleteById(context.Background(), mockOrder.OrderID, nil)
 	require.NoError(t, err)
+
+	t.Run("PreconditionFailed", func(t *testing.T) {
+		mock.ExpectExec(deleteByIdQuery).WithArgs(mockOrder.OrderID, pq.Array([]int{1})).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := orderPGRepository.DeleteById(context.Background(), mockOrder.OrderID, []int{1})
+		require.ErrorIs(t, err, models.ErrPreconditionFailed)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mock.ExpectExec(deleteByIdQuery).WithArgs(mockOrder.OrderID, pq.Array([]int(nil))).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := orderPGRepository.DeleteById(context.Background(), mockOrder.OrderID, nil)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
 	require.NotNil(t, mockOrder)
 }
+
+func TestOrderRepository_RestoreById(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	orderPGRepository := NewOrderPGRepository(sqlxDB)
+
+	columns := []string{"order_id", "version", "deleted_at"}
+	orderUUID := uuid.New()
+
+	t.Run("Restored", func(t *testing.T) {
+		rows := sqlmock.NewRows(columns).AddRow(orderUUID, 3, nil)
+		mock.ExpectQuery(restoreByIdQuery).WithArgs(orderUUID).WillReturnRows(rows)
+
+		restoredOrder, err := orderPGRepository.RestoreById(context.Background(), orderUUID)
+		require.NoError(t, err)
+		require.Equal(t, orderUUID, restoredOrder.OrderID)
+		require.Equal(t, 3, restoredOrder.Version)
+		require.Nil(t, restoredOrder.DeletedAt)
+	})
+
+	t.Run("NotDeleted", func(t *testing.T) {
+		mock.ExpectQuery(restoreByIdQuery).WithArgs(orderUUID).WillReturnRows(sqlmock.NewRows(columns))
+
+		_, err := orderPGRepository.RestoreById(context.Background(), orderUUID)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
+}
+
+func TestOrderRepository_PurgeDeleted(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	orderPGRepository := NewOrderPGRepository(sqlxDB)
+
+	deletedBefore := time.Now().AddDate(0, 0, -30)
+	mock.ExpectExec(purgeDeletedQuery).WithArgs(deletedBefore).WillReturnResult(sqlmock.NewResult(0, 2))
+
+	purged, err := orderPGRepository.PurgeDeleted(context.Background(), deletedBefore)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), purged)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOrderRepository_FindAllFiltered(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	orderPGRepository := NewOrderPGRepository(sqlxDB)
+
+	brandUUID, userUUID, productUUID := uuid.New(), uuid.New(), uuid.New()
+	createdFrom := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	createdTo := createdFrom.AddDate(0, 1, 0)
+	minTotal, maxTotal := 5000.0, 250000.0
+	columns := []string{"order_id", "user_id", "brand_id", "status"}
+
+	mock.ExpectQuery(findAllQuery+" WHERE deleted_at IS NULL AND user_id = $1 AND brand_id = $2 AND item->>'product_id' = $3 AND status::text = ANY($4)"+
+		" AND created_at >= $5 AND created_at < $6 AND total_price >= $7 AND total_price <= $8 ORDER BY total_price ASC, order_id ASC LIMIT $9 OFFSET $10").
+		WithArgs(userUUID, brandUUID, productUUID.String(), `{"paid","accepted"}`, createdFrom, createdTo, minTotal, maxTotal, 10, 10).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(uuid.New(), userUUID, brandUUID, models.OrderStatusPaid))
+
+	pagination := utils.NewPaginationQuery(10, 2)
+	pagination.SetOrderBy("total_price")
+	foundOrders, err := orderPGRepository.FindAll(context.Background(), &models.OrderFilter{
+		UserID:      &userUUID,
+		BrandID:     &brandUUID,
+		ProductID:   &productUUID,
+		Statuses:    []string{models.OrderStatusPaid, models.OrderStatusAccepted},
+		CreatedFrom: &createdFrom,
+		CreatedTo:   &createdTo,
+		MinTotal:    &minTotal,
+		MaxTotal:    &maxTotal,
+	}, pagination)
+	require.NoError(t, err)
+	require.Len(t, foundOrders, 1)
+
+	t.Run("WithDeleted", func(t *testing.T) {
+		mock.ExpectQuery(findAllQuery+" WHERE status::text = ANY($1) ORDER BY status DESC, order_id DESC LIMIT $2 OFFSET $3").
+			WithArgs(`{"refunded"}`, 10, 0).
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		pagination := utils.NewPaginationQuery(10, 1)
+		pagination.SetOrderBy("-status")
+		foundOrders, err := orderPGRepository.FindAllWithDeleted(context.Background(), &models.OrderFilter{Statuses: []string{models.OrderStatusRefunded}}, pagination)
+		require.NoError(t, err)
+		require.Empty(t, foundOrders)
+	})
+
+	t.Run("InvalidSort", func(t *testing.T) {
+		pagination := utils.NewPaginationQuery(10, 1)
+		pagination.SetOrderBy("total_price; DROP TABLE orders")
+		_, err := orderPGRepository.FindAll(context.Background(), nil, pagination)
+		require.True(t, errors.Is(err, utils.ErrInvalidSort))
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOrderRepository_FindAllCursor(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	orderPGRepository := NewOrderPGRepository(sqlxDB)
+
+	brandUUID, lastUUID := uuid.New(), uuid.New()
+	last := &models.Order{OrderID: lastUUID, CreatedAt: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)}
+	columns := []string{"order_id", "brand_id", "created_at"}
+
+	mock.ExpectQuery(findAllQuery+" WHERE deleted_at IS NULL AND brand_id = $1 AND (created_at, order_id) < ($2, $3) ORDER BY created_at DESC, order_id DESC LIMIT $4").
+		WithArgs(brandUUID, "2024-05-01T10:00:00Z", lastUUID.String(), 10).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(uuid.New(), brandUUID, last.CreatedAt.Add(-time.Hour)))
+
+	next, err := utils.NewCursor(models.OrderSorts, "", last, false)
+	require.NoError(t, err)
+	pagination := utils.NewPaginationQuery(10, 1)
+	require.NoError(t, pagination.SetCursor(next.Encode()))
+	foundOrders, err := orderPGRepository.FindAll(context.Background(), &models.OrderFilter{BrandID: &brandUUID}, pagination)
+	require.NoError(t, err)
+	require.Len(t, foundOrders, 1)
+
+	t.Run("Prev", func(t *testing.T) {
+		mock.ExpectQuery("SELECT * FROM ("+findAllQuery+" WHERE deleted_at IS NULL AND (total_price, order_id) < ($1, $2) ORDER BY total_price DESC, order_id DESC LIMIT $3) AS page ORDER BY total_price ASC, order_id ASC").
+			WithArgs("1500.5", lastUUID.String(), 10).
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		last.TotalPrice = 1500.5
+		prev, err := utils.NewCursor(models.OrderSorts, "total_price", last, true)
+		require.NoError(t, err)
+		pagination := utils.NewPaginationQuery(10, 1)
+		require.NoError(t, pagination.SetCursor(prev.Encode()))
+		foundOrders, err := orderPGRepository.FindAll(context.Background(), &models.OrderFilter{}, pagination)
+		require.NoError(t, err)
+		require.Empty(t, foundOrders)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOrderRepository_Count(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	orderPGRepository := NewOrderPGRepository(sqlxDB)
+
+	userUUID := uuid.New()
+
+	mock.ExpectQuery("SELECT COUNT(*) FROM ("+findAllQuery+" WHERE deleted_at IS NULL AND user_id = $1 AND status::text = ANY($2)) AS list").
+		WithArgs(userUUID, `{"paid"}`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(42))
+
+	count, err := orderPGRepository.Count(context.Background(), &models.OrderFilter{UserID: &userUUID, Statuses: []string{models.OrderStatusPaid}}, false)
+	require.NoError(t, err)
+	require.Equal(t, 42, count)
+
+	t.Run("Estimate", func(t *testing.T) {
+		mock.ExpectQuery("EXPLAIN (FORMAT JSON) " + findAllQuery).
+			WillReturnRows(sqlmock.NewRows([]string{"QUERY PLAN"}).AddRow(`[{"Plan": {"Node Type": "Seq Scan", "Relation Name": "orders", "Plan Rows": 1250000}}]`))
+
+		count, err := orderPGRepository.CountWithDeleted(context.Background(), &models.OrderFilter{}, true)
+		require.NoError(t, err)
+		require.Equal(t, 1250000, count)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOrderRepository_Export(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	orderPGRepository := NewOrderPGRepository(sqlxDB)
+
+	brandUUID := uuid.New()
+	columns := []string{"order_id", "brand_id", "total_price"}
+	query := findAllQuery + " WHERE deleted_at IS NULL AND brand_id = $1 ORDER BY total_price DESC, order_id DESC"
+
+	mock.ExpectQuery(query).WithArgs(brandUUID).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(uuid.New(), brandUUID, 25000.0).AddRow(uuid.New(), brandUUID, 10000.0))
+
+	var totals []float64
+	err = orderPGRepository.Export(context.Background(), &models.OrderFilter{BrandID: &brandUUID}, "-total_price", func(order *models.Order) error {
+		totals = append(totals, order.TotalPrice)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []float64{25000, 10000}, totals)
+
+	t.Run("Stopped", func(t *testing.T) {
+		mock.ExpectQuery(query).WithArgs(brandUUID).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(uuid.New(), brandUUID, 25000.0).AddRow(uuid.New(), brandUUID, 10000.0))
+
+		calls := 0
+		err := orderPGRepository.Export(context.Background(), &models.OrderFilter{BrandID: &brandUUID}, "-total_price", func(order *models.Order) error {
+			calls++
+			return fmt.Errorf("client gone")
+		})
+		require.EqualError(t, err, "client gone")
+		require.Equal(t, 1, calls)
+	})
+
+	t.Run("InvalidSort", func(t *testing.T) {
+		err := orderPGRepository.Export(context.Background(), nil, "password", func(order *models.Order) error { return nil })
+		require.True(t, errors.Is(err, utils.ErrInvalidSort))
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOrderRepository_BulkUpdateStatus(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	orderPGRepository := NewOrderPGRepository(sqlxDB)
+
+	brandUUID := uuid.New()
+	paidUUID, otherPaidUUID, shippedUUID, missingUUID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	columns := []string{"order_id", "user_id", "brand_id", "status", "version"}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(lockBrandOrdersQuery).WithArgs(fmt.Sprintf("{%q,%q}", paidUUID, otherPaidUUID), brandUUID).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(paidUUID, uuid.New(), brandUUID, models.OrderStatusPaid, 2).
+			AddRow(otherPaidUUID, uuid.New(), brandUUID, models.OrderStatusPaid, 1))
+	mock.ExpectQuery(updateStatusQuery).WithArgs(paidUUID, models.OrderStatusAccepted).WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(3))
+	mock.ExpectExec(outboxRepository.AddEventQuery).WithArgs(sqlmock.AnyArg(), models.AggregateOrder, paidUUID, models.EventOrderStatusChanged, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(updateStatusQuery).WithArgs(otherPaidUUID, models.OrderStatusAccepted).WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(2))
+	mock.ExpectExec(outboxRepository.AddEventQuery).WithArgs(sqlmock.AnyArg(), models.AggregateOrder, otherPaidUUID, models.EventOrderStatusChanged, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	results, applied, err := orderPGRepository.BulkUpdateStatus(context.Background(), brandUUID, []uuid.UUID{paidUUID, otherPaidUUID}, models.OrderStatusAccepted)
+	require.NoError(t, err)
+	require.True(t, applied)
+	require.Equal(t, []models.OrderBulkItemResult{
+		{OrderID: paidUUID, PreviousStatus: models.OrderStatusPaid, Status: models.OrderStatusAccepted, Version: 3},
+		{OrderID: otherPaidUUID, PreviousStatus: models.OrderStatusPaid, Status: models.OrderStatusAccepted, Version: 2},
+	}, results)
+
+	t.Run("NotApplicable", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockBrandOrdersQuery).WithArgs(fmt.Sprintf("{%q,%q,%q}", paidUUID, shippedUUID, missingUUID), brandUUID).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(paidUUID, uuid.New(), brandUUID, models.OrderStatusPaid, 2).
+				AddRow(shippedUUID, uuid.New(), brandUUID, models.OrderStatusShipped, 4))
+		mock.ExpectRollback()
+
+		results, applied, err := orderPGRepository.BulkUpdateStatus(context.Background(), brandUUID, []uuid.UUID{paidUUID, shippedUUID, missingUUID}, models.OrderStatusRejected)
+		require.NoError(t, err)
+		require.False(t, applied)
+		require.Len(t, results, 3)
+		require.Empty(t, results[0].Error)
+		require.Empty(t, results[0].Status)
+		require.Contains(t, results[1].Error, "order is shipped")
+		require.Equal(t, sql.ErrNoRows.Error(), results[2].Error)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
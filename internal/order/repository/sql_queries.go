@@ -1,22 +1,46 @@
 package repository
 
 const (
-	createOrderQuery = `INSERT INTO orders (user_id, brand_id, item, quantity, total_price, status, delivery_source_address, delivery_destination_address) 
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-		RETURNING order_id, user_id, brand_id, item, quantity, total_price, status, delivery_source_address, delivery_destination_address, created_at, updated_at`
+	createOrderQuery = `INSERT INTO orders (user_id, brand_id, item, quantity, total_price, status, delivery_source_address, delivery_destination_address, discount_total, applied_promotions, free_shipping, tax_total, tax_lines, delivery_fee, delivery_distance, delivery_address, location_id) 
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
+		RETURNING order_id, user_id, brand_id, item, quantity, total_price, status, delivery_source_address, delivery_destination_address, refunded_quantity, refunded_amount, discount_total, applied_promotions, free_shipping, tax_total, tax_lines, delivery_fee, delivery_distance, delivery_address, location_id, delivered_at, created_at, updated_at, version, deleted_at`
 
-	findByIdQuery = `SELECT order_id, user_id, brand_id, item, quantity, total_price, status, delivery_source_address, delivery_destination_address, created_at, updated_at FROM orders WHERE order_id = $1`
+	findByIdQuery = `SELECT order_id, user_id, brand_id, item, quantity, total_price, status, delivery_source_address, delivery_destination_address, refunded_quantity, refunded_amount, discount_total, applied_promotions, free_shipping, tax_total, tax_lines, delivery_fee, delivery_distance, delivery_address, location_id, delivered_at, created_at, updated_at, version, deleted_at FROM orders WHERE order_id = $1 AND deleted_at IS NULL`
 
-	findAllQuery = `SELECT order_id, user_id, brand_id, item, quantity, total_price, status, delivery_source_address, delivery_destination_address, created_at, updated_at FROM orders LIMIT $1 OFFSET $2`
+	findByIdWithDeletedQuery = `SELECT order_id, user_id, brand_id, item, quantity, total_price, status, delivery_source_address, delivery_destination_address, refunded_quantity, refunded_amount, discount_total, applied_promotions, free_shipping, tax_total, tax_lines, delivery_fee, delivery_distance, delivery_address, location_id, delivered_at, created_at, updated_at, version, deleted_at FROM orders WHERE order_id = $1`
 
-	findByUserIdQuery = `SELECT order_id, user_id, brand_id, item, quantity, total_price, status, delivery_source_address, delivery_destination_address, created_at, updated_at FROM orders WHERE user_id = $1 LIMIT $2 OFFSET $3`
+	// findAllQuery base of order lists, conditions, sort and pagination are added by utils.QueryBuilder
+	findAllQuery = `SELECT order_id, user_id, brand_id, item, quantity, total_price, status, delivery_source_address, delivery_destination_address, refunded_quantity, refunded_amount, discount_total, applied_promotions, free_shipping, tax_total, tax_lines, delivery_fee, delivery_distance, delivery_address, location_id, delivered_at, created_at, updated_at, version, deleted_at FROM orders`
 
-	findAllByBrandIdQuery = `SELECT order_id, user_id, brand_id, item, quantity, total_price, status, delivery_source_address, delivery_destination_address, created_at, updated_at FROM orders WHERE brand_id = $1 LIMIT $2 OFFSET $3`
+	lockBrandOrdersQuery = `SELECT order_id, user_id, brand_id, item, quantity, total_price, status, delivery_source_address, delivery_destination_address, refunded_quantity, refunded_amount, discount_total, applied_promotions, free_shipping, tax_total, tax_lines, delivery_fee, delivery_distance, delivery_address, location_id, delivered_at, created_at, updated_at, version, deleted_at FROM orders
+		WHERE order_id = ANY($1::uuid[]) AND brand_id = $2 AND deleted_at IS NULL ORDER BY order_id FOR UPDATE`
 
-	findAllByUserIdBrandIDQuery = `SELECT order_id, user_id, brand_id, item, quantity, total_price, status, delivery_source_address, delivery_destination_address, created_at, updated_at FROM orders WHERE user_id = $1 AND brand_id = $2 LIMIT $3 OFFSET $4`
+	updateStatusQuery = `UPDATE orders SET status = $2, delivered_at = CASE WHEN $2 = 'delivered' AND status <> 'delivered' THEN CURRENT_TIMESTAMP ELSE delivered_at END, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE order_id = $1 RETURNING version`
 
-	updateByIdQuery = `UPDATE orders SET user_id = $2, brand_id = $3, item = $4, quantity = $5, total_price = $6, status = $7, delivery_source_address = $8, delivery_destination_address = $9 WHERE order_id = $1
-		RETURNING order_id, user_id, brand_id, item, quantity, total_price, status, delivery_source_address, delivery_destination_address, created_at, updated_at`
+	lockStatusQuery = `SELECT status FROM orders WHERE order_id = $1 AND version = $2 AND deleted_at IS NULL FOR UPDATE`
 
-	deleteByIdQuery = `DELETE FROM orders WHERE order_id = $1`
+	updateByIdQuery = `UPDATE orders SET user_id = $2, brand_id = $3, item = $4, quantity = $5, total_price = $6, status = $7, delivery_source_address = $8, delivery_destination_address = $9, delivered_at = CASE WHEN $7 = 'delivered' AND status <> 'delivered' THEN CURRENT_TIMESTAMP ELSE delivered_at END, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE order_id = $1 AND version = $10 AND deleted_at IS NULL
+		RETURNING order_id, user_id, brand_id, item, quantity, total_price, status, delivery_source_address, delivery_destination_address, refunded_quantity, refunded_amount, discount_total, applied_promotions, free_shipping, tax_total, tax_lines, delivery_fee, delivery_distance, delivery_address, location_id, delivered_at, created_at, updated_at, version, deleted_at`
+
+	deleteByIdQuery = `UPDATE orders SET deleted_at = CURRENT_TIMESTAMP, version = version + 1 WHERE order_id = $1 AND deleted_at IS NULL AND ($2::int[] IS NULL OR version = ANY($2))`
+
+	restoreByIdQuery = `UPDATE orders SET deleted_at = NULL, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE order_id = $1 AND deleted_at IS NOT NULL
+		RETURNING order_id, user_id, brand_id, item, quantity, total_price, status, delivery_source_address, delivery_destination_address, refunded_quantity, refunded_amount, discount_total, applied_promotions, free_shipping, tax_total, tax_lines, delivery_fee, delivery_distance, delivery_address, location_id, delivered_at, created_at, updated_at, version, deleted_at`
+
+	purgeDeletedQuery = `DELETE FROM orders o WHERE o.deleted_at < $1
+		AND NOT EXISTS (SELECT 1 FROM payments pa WHERE pa.order_id = o.order_id)
+		AND NOT EXISTS (SELECT 1 FROM refunds rf WHERE rf.order_id = o.order_id)
+		AND NOT EXISTS (SELECT 1 FROM returns rt WHERE rt.order_id = o.order_id)
+		AND NOT EXISTS (SELECT 1 FROM shipments sh WHERE sh.order_id = o.order_id)
+		AND NOT EXISTS (SELECT 1 FROM promotion_redemptions pr WHERE pr.order_id = o.order_id)`
+
+	redeemPromotionQuery = `UPDATE promotions SET usage_count = usage_count + 1, updated_at = CURRENT_TIMESTAMP WHERE promotion_id = $1 AND deleted_at IS NULL AND (usage_limit IS NULL OR usage_count < usage_limit)`
+
+	takeProductStockQuery = `UPDATE products SET stock = stock - $2, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE product_id = $1 AND stock >= $2`
+
+	takeLocationStockQuery = `UPDATE location_stocks SET stock = stock - $3, updated_at = CURRENT_TIMESTAMP WHERE location_id = $1 AND product_id = $2 AND stock >= $3`
+
+	createPromotionRedemptionQuery = `INSERT INTO promotion_redemptions (promotion_id, order_id, user_id, discount) 
+		SELECT p.promotion_id, $2, $3, $4 FROM promotions p WHERE p.promotion_id = $1
+		AND (p.per_user_limit IS NULL OR (SELECT COUNT(*) FROM promotion_redemptions pr WHERE pr.promotion_id = $1 AND pr.user_id = $3) < p.per_user_limit)`
 )
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-redis/redis/v8"
 	"github.com/google/uuid"
@@ -38,9 +39,9 @@ func (u *orderUseCase) Create(ctx context.Context, order *models.Order) (*models
 	return u.orderPgRepo.Create(ctx, order)
 }
 
-// FindAll find orders
-func (u *orderUseCase) FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.Order, error) {
-	orders, err := u.orderPgRepo.FindAll(ctx, pagination)
+// FindAll find orders matching filter
+func (u *orderUseCase) FindAll(ctx context.Context, filter *models.OrderFilter, pagination *utils.Pagination) ([]models.Order, error) {
+	orders, err := u.orderPgRepo.FindAll(ctx, filter, pagination)
 	if err != nil {
 		return nil, errors.Wrap(err, "orderPgRepo.FindAll")
 	}
@@ -48,34 +49,46 @@ func (u *orderUseCase) FindAll(ctx context.Context, pagination *utils.Pagination
 	return orders, nil
 }
 
-// FindAllByUserId find orders by user id
-func (u *orderUseCase) FindAllByUserId(ctx context.Context, userID uuid.UUID, pagination *utils.Pagination) ([]models.Order, error) {
-	orders, err := u.orderPgRepo.FindAllByUserId(ctx, userID, pagination)
-	if err != nil {
-		return nil, errors.Wrap(err, "orderPgRepo.FindAllByUserId")
+// Export call fn with each order matching filter in turn, sorted by sort
+func (u *orderUseCase) Export(ctx context.Context, filter *models.OrderFilter, sort string, fn func(order *models.Order) error) error {
+	if err := u.orderPgRepo.Export(ctx, filter, sort, fn); err != nil {
+		return errors.Wrap(err, "orderPgRepo.Export")
 	}
 
-	return orders, nil
+	return nil
 }
 
-// FindAllByBrandId find orders by brand id
-func (u *orderUseCase) FindAllByBrandId(ctx context.Context, brandID uuid.UUID, pagination *utils.Pagination) ([]models.Order, error) {
-	orders, err := u.orderPgRepo.FindAllByBrandId(ctx, brandID, pagination)
+// Count count orders matching filter, or estimate their count
+func (u *orderUseCase) Count(ctx context.Context, filter *models.OrderFilter, estimate bool) (int, error) {
+	count, err := u.orderPgRepo.Count(ctx, filter, estimate)
 	if err != nil {
-		return nil, errors.Wrap(err, "orderPgRepo.FindAllByUserId")
+		return 0, errors.Wrap(err, "orderPgRepo.Count")
 	}
 
-	return orders, nil
+	return count, nil
 }
 
-// FindAllByUserIdBrandId find orders by brand id
-func (u *orderUseCase) FindAllByUserIdBrandId(ctx context.Context, userID uuid.UUID, brandID uuid.UUID, pagination *utils.Pagination) ([]models.Order, error) {
-	orders, err := u.orderPgRepo.FindAllByUserIdBrandId(ctx, userID, brandID, pagination)
+// BulkAction apply a models.OrderBulkActionStatuses action to orders of brand, to all of them or to none
+func (u *orderUseCase) BulkAction(ctx context.Context, brandID uuid.UUID, action string, orderIDs []uuid.UUID) (*models.OrderBulkResult, error) {
+	status, ok := models.OrderBulkActionStatuses[action]
+	if !ok {
+		return nil, errors.Errorf("unknown bulk action: %s", action)
+	}
+
+	results, applied, err := u.orderPgRepo.BulkUpdateStatus(ctx, brandID, orderIDs, status)
 	if err != nil {
-		return nil, errors.Wrap(err, "orderPgRepo.FindAllByUserIdBrandId")
+		return nil, errors.Wrap(err, "orderPgRepo.BulkUpdateStatus")
 	}
 
-	return orders, nil
+	if applied {
+		for _, result := range results {
+			if err := u.redisRepo.DeleteOrderCtx(ctx, result.OrderID.String()); err != nil {
+				u.logger.Errorf("redisRepo.DeleteOrderCtx", err)
+			}
+		}
+	}
+
+	return &models.OrderBulkResult{Action: action, Applied: applied, Results: results}, nil
 }
 
 // FindById find order by uuid
@@ -124,9 +137,9 @@ func (u *orderUseCase) UpdateById(ctx context.Context, order *models.Order) (*mo
 	return updatedOrder, nil
 }
 
-// DeleteById delete order by uuid
-func (u *orderUseCase) DeleteById(ctx context.Context, orderID uuid.UUID) error {
-	err := u.orderPgRepo.DeleteById(ctx, orderID)
+// DeleteById delete order by uuid, at one of versions unless nil
+func (u *orderUseCase) DeleteById(ctx context.Context, orderID uuid.UUID, versions []int) error {
+	err := u.orderPgRepo.DeleteById(ctx, orderID, versions)
 	if err != nil {
 		return errors.Wrap(err, "orderPgRepo.DeleteById")
 	}
@@ -137,3 +150,57 @@ func (u *orderUseCase) DeleteById(ctx context.Context, orderID uuid.UUID) error
 
 	return nil
 }
+
+// FindAllWithDeleted find orders matching filter including soft deleted ones
+func (u *orderUseCase) FindAllWithDeleted(ctx context.Context, filter *models.OrderFilter, pagination *utils.Pagination) ([]models.Order, error) {
+	orders, err := u.orderPgRepo.FindAllWithDeleted(ctx, filter, pagination)
+	if err != nil {
+		return nil, errors.Wrap(err, "orderPgRepo.FindAllWithDeleted")
+	}
+
+	return orders, nil
+}
+
+// CountWithDeleted count orders matching filter including soft deleted ones, or estimate their count
+func (u *orderUseCase) CountWithDeleted(ctx context.Context, filter *models.OrderFilter, estimate bool) (int, error) {
+	count, err := u.orderPgRepo.CountWithDeleted(ctx, filter, estimate)
+	if err != nil {
+		return 0, errors.Wrap(err, "orderPgRepo.CountWithDeleted")
+	}
+
+	return count, nil
+}
+
+// FindByIdWithDeleted find order by uuid including soft deleted ones
+func (u *orderUseCase) FindByIdWithDeleted(ctx context.Context, orderID uuid.UUID) (*models.Order, error) {
+	foundOrder, err := u.orderPgRepo.FindByIdWithDeleted(ctx, orderID)
+	if err != nil {
+		return nil, errors.Wrap(err, "orderPgRepo.FindByIdWithDeleted")
+	}
+
+	return foundOrder, nil
+}
+
+// RestoreById restore soft deleted order by uuid
+func (u *orderUseCase) RestoreById(ctx context.Context, orderID uuid.UUID) (*models.Order, error) {
+	restoredOrder, err := u.orderPgRepo.RestoreById(ctx, orderID)
+	if err != nil {
+		return nil, errors.Wrap(err, "orderPgRepo.RestoreById")
+	}
+
+	if err := u.redisRepo.SetOrderCtx(ctx, restoredOrder.OrderID.String(), orderByIdCacheDuration, restoredOrder); err != nil {
+		u.logger.Errorf("redisRepo.SetOrderCtx", err)
+	}
+
+	return restoredOrder, nil
+}
+
+// PurgeDeleted hard delete orders soft deleted before deletedBefore
+func (u *orderUseCase) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	purged, err := u.orderPgRepo.PurgeDeleted(ctx, deletedBefore)
+	if err != nil {
+		return 0, errors.Wrap(err, "orderPgRepo.PurgeDeleted")
+	}
+
+	return purged, nil
+}
@@ -113,14 +113,44 @@ func TestOrderUseCase_FindAll(t *testing.T) {
 
 	ctx := context.Background()
 
-	brandPGRepository.EXPECT().FindAll(gomock.Any(), nil).AnyTimes().Return(append([]models.Order{}, *mockOrder), nil)
+	filter := &models.OrderFilter{}
+	brandPGRepository.EXPECT().FindAll(gomock.Any(), filter, nil).AnyTimes().Return(append([]models.Order{}, *mockOrder), nil)
 
-	brands, err := brandUC.FindAll(ctx, nil)
+	brands, err := brandUC.FindAll(ctx, filter, nil)
 	require.NoError(t, err)
 	require.NotNil(t, brands)
 	require.Equal(t, len(brands), 1)
 }
 
+func TestOrderUseCase_Count(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orderPGRepository := mock.NewMockOrderPGRepository(ctrl)
+	orderRedisRepository := mock.NewMockOrderRedisRepository(ctrl)
+	apiLogger := logger.NewAppLogger(nil)
+
+	cfg := &config.Config{}
+	orderUC := NewOrderUseCase(cfg, apiLogger, orderPGRepository, orderRedisRepository)
+
+	ctx := context.Background()
+
+	userUUID := uuid.New()
+	filter := &models.OrderFilter{UserID: &userUUID}
+	orderPGRepository.EXPECT().Count(gomock.Any(), filter, false).Return(42, nil)
+	orderPGRepository.EXPECT().CountWithDeleted(gomock.Any(), filter, true).Return(1250000, nil)
+
+	count, err := orderUC.Count(ctx, filter, false)
+	require.NoError(t, err)
+	require.Equal(t, 42, count)
+
+	count, err = orderUC.CountWithDeleted(ctx, filter, true)
+	require.NoError(t, err)
+	require.Equal(t, 1250000, count)
+}
+
 func TestOrderUseCase_FindAllByBrandId(t *testing.T) {
 	t.Parallel()
 
@@ -158,9 +188,10 @@ func TestOrderUseCase_FindAllByBrandId(t *testing.T) {
 
 	ctx := context.Background()
 
-	brandPGRepository.EXPECT().FindAllByBrandId(gomock.Any(), mockOrder.BrandID, nil).AnyTimes().Return(append([]models.Order{}, *mockOrder), nil)
+	filter := &models.OrderFilter{BrandID: &mockOrder.BrandID}
+	brandPGRepository.EXPECT().FindAll(gomock.Any(), filter, nil).AnyTimes().Return(append([]models.Order{}, *mockOrder), nil)
 
-	brands, err := brandUC.FindAllByBrandId(ctx, mockOrder.BrandID, nil)
+	brands, err := brandUC.FindAll(ctx, filter, nil)
 	require.NoError(t, err)
 	require.NotNil(t, brands)
 	require.Equal(t, len(brands), 1)
@@ -203,9 +234,10 @@ func TestOrderUseCase_FindAllByUserId(t *testing.T) {
 
 	ctx := context.Background()
 
-	brandPGRepository.EXPECT().FindAllByUserId(gomock.Any(), mockOrder.UserID, nil).AnyTimes().Return(append([]models.Order{}, *mockOrder), nil)
+	filter := &models.OrderFilter{UserID: &mockOrder.UserID}
+	brandPGRepository.EXPECT().FindAll(gomock.Any(), filter, nil).AnyTimes().Return(append([]models.Order{}, *mockOrder), nil)
 
-	brands, err := brandUC.FindAllByUserId(ctx, mockOrder.UserID, nil)
+	brands, err := brandUC.FindAll(ctx, filter, nil)
 	require.NoError(t, err)
 	require.NotNil(t, brands)
 	require.Equal(t, len(brands), 1)
@@ -248,9 +280,10 @@ func TestOrderUseCase_FindAllByUserBrandId(t *testing.T) {
 
 	ctx := context.Background()
 
-	brandPGRepository.EXPECT().FindAllByUserIdBrandId(gomock.Any(), mockOrder.UserID, mockOrder.BrandID, nil).AnyTimes().Return(append([]models.Order{}, *mockOrder), nil)
+	filter := &models.OrderFilter{UserID: &mockOrder.UserID, BrandID: &mockOrder.BrandID}
+	brandPGRepository.EXPECT().FindAll(gomock.Any(), filter, nil).AnyTimes().Return(append([]models.Order{}, *mockOrder), nil)
 
-	brands, err := brandUC.FindAllByUserIdBrandId(ctx, mockOrder.UserID, mockOrder.BrandID, nil)
+	brands, err := brandUC.FindAll(ctx, filter, nil)
 	require.NoError(t, err)
 	require.NotNil(t, brands)
 	require.Equal(t, len(brands), 1)
@@ -434,12 +467,65 @@ func TestOrderUseCase_DeleteById(t *testing.T) {
 
 	ctx := context.Background()
 
-	orderPGRepository.EXPECT().DeleteById(gomock.Any(), mockOrder.OrderID).Return(nil)
+	orderPGRepository.EXPECT().DeleteById(gomock.Any(), mockOrder.OrderID, []int{1}).Return(nil)
 	orderRedisRepository.EXPECT().DeleteOrderCtx(gomock.Any(), mockOrder.OrderID.String()).AnyTimes().Return(nil)
 
-	err := orderUC.DeleteById(ctx, mockOrder.OrderID)
+	err := orderUC.DeleteById(ctx, mockOrder.OrderID, []int{1})
 	require.NoError(t, err)
 
 	orderPGRepository.EXPECT().FindById(gomock.Any(), mockOrder.OrderID).AnyTimes().Return(nil, nil)
 	orderRedisRepository.EXPECT().GetByIdCtx(gomock.Any(), mockOrder.OrderID.String()).AnyTimes().Return(nil, redis.Nil)
 }
+
+func TestOrderUseCase_BulkAction(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orderPGRepository := mock.NewMockOrderPGRepository(ctrl)
+	orderRedisRepository := mock.NewMockOrderRedisRepository(ctrl)
+	apiLogger := logger.NewAppLogger(nil)
+
+	cfg := &config.Config{Server: config.ServerConfig{JwtSecretKey: "secret123"}}
+	orderUC := NewOrderUseCase(cfg, apiLogger, orderPGRepository, orderRedisRepository)
+
+	ctx := context.Background()
+	brandUUID := uuid.New()
+	orderIDs := []uuid.UUID{uuid.New(), uuid.New()}
+
+	t.Run("Applied", func(t *testing.T) {
+		results := []models.OrderBulkItemResult{
+			{OrderID: orderIDs[0], PreviousStatus: models.OrderStatusPaid, Status: models.OrderStatusAccepted, Version: 2},
+			{OrderID: orderIDs[1], PreviousStatus: models.OrderStatusPaid, Status: models.OrderStatusAccepted, Version: 3},
+		}
+		orderPGRepository.EXPECT().BulkUpdateStatus(gomock.Any(), brandUUID, orderIDs, models.OrderStatusAccepted).Return(results, true, nil)
+		orderRedisRepository.EXPECT().DeleteOrderCtx(gomock.Any(), orderIDs[0].String()).Return(nil)
+		orderRedisRepository.EXPECT().DeleteOrderCtx(gomock.Any(), orderIDs[1].String()).Return(nil)
+
+		result, err := orderUC.BulkAction(ctx, brandUUID, models.OrderBulkAccept, orderIDs)
+		require.NoError(t, err)
+		require.True(t, result.Applied)
+		require.Equal(t, models.OrderBulkAccept, result.Action)
+		require.Equal(t, results, result.Results)
+	})
+
+	t.Run("NotApplied", func(t *testing.T) {
+		results := []models.OrderBulkItemResult{
+			{OrderID: orderIDs[0], PreviousStatus: models.OrderStatusAccepted, Status: models.OrderStatusShipped},
+			{OrderID: orderIDs[1], PreviousStatus: models.OrderStatusPaid, Error: "invalid status transition: order is paid"},
+		}
+		orderPGRepository.EXPECT().BulkUpdateStatus(gomock.Any(), brandUUID, orderIDs, models.OrderStatusShipped).Return(results, false, nil)
+
+		result, err := orderUC.BulkAction(ctx, brandUUID, models.OrderBulkMarkShipped, orderIDs)
+		require.NoError(t, err)
+		require.False(t, result.Applied)
+		require.Equal(t, results, result.Results)
+	})
+
+	t.Run("UnknownAction", func(t *testing.T) {
+		result, err := orderUC.BulkAction(ctx, brandUUID, "cancel", orderIDs)
+		require.Error(t, err)
+		require.Nil(t, result)
+	})
+}
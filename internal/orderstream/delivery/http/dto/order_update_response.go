@@ -0,0 +1,31 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/internal/models"
+)
+
+type OrderUpdateResponseDto struct {
+	OrderID    uuid.UUID `json:"order_id"`
+	UserID     uuid.UUID `json:"user_id"`
+	BrandID    uuid.UUID `json:"brand_id"`
+	From       string    `json:"from,omitempty"`
+	Status     string    `json:"status"`
+	Version    int       `json:"version"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+func OrderUpdateResponseFromModel(update *models.OrderUpdate) *OrderUpdateResponseDto {
+	return &OrderUpdateResponseDto{
+		OrderID:    update.OrderID,
+		UserID:     update.UserID,
+		BrandID:    update.BrandID,
+		From:       update.From,
+		Status:     update.Status,
+		Version:    update.Version,
+		OccurredAt: update.OccurredAt,
+	}
+}
@@ -0,0 +1,188 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/middlewares"
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/internal/orderstream"
+	"github.com/dinorain/kalobranded/internal/orderstream/delivery/http/dto"
+	"github.com/dinorain/kalobranded/internal/server/router"
+	"github.com/dinorain/kalobranded/pkg/constants"
+	httpErrors "github.com/dinorain/kalobranded/pkg/http_errors"
+	"github.com/dinorain/kalobranded/pkg/logger"
+)
+
+const (
+	defaultHeartbeat   = 5 * time.Second
+	defaultMaxDuration = 12 * time.Second
+	defaultRetry       = time.Second
+
+	lastEventIDHeader = "Last-Event-ID"
+	lastEventIDQuery  = "last_event_id"
+)
+
+type orderStreamHandlersHTTP struct {
+	router        *router.Router
+	logger        logger.Logger
+	cfg           *config.Config
+	mw            middlewares.MiddlewareManager
+	orderStreamUC orderstream.OrderStreamUseCase
+}
+
+var _ orderstream.OrderStreamHandlers = (*orderStreamHandlersHTTP)(nil)
+
+func NewOrderStreamHandlersHTTP(
+	router *router.Router,
+	logger logger.Logger,
+	cfg *config.Config,
+	mw middlewares.MiddlewareManager,
+	orderStreamUC orderstream.OrderStreamUseCase,
+) *orderStreamHandlersHTTP {
+	return &orderStreamHandlersHTTP{router: router, logger: logger, cfg: cfg, mw: mw, orderStreamUC: orderStreamUC}
+}
+
+// Stream
+// @Tags Orders
+// @Summary Stream order updates
+// @Description Server-Sent Events stream of order updates, new orders and status changes, as "order.created" and "order.status_changed" events with the update as data. Users get updates of their own orders, sellers of their brand's orders and admins of every order, or of brand_id only. Streams send a comment as heartbeat while idle and end after a while, clients reconnect with the Last-Event-ID header, or last_event_id, to resume after the last update they got
+// @Produce text/event-stream
+// @Security ApiKeyAuth
+// @Param brand_id query string false "Brand ID, admins only"
+// @Param last_event_id query string false "Resume after this update, the Last-Event-ID header takes precedence"
+// @Success 200 {object} dto.OrderUpdateResponseDto
+// @Router /orders/stream [get]
+func (h *orderStreamHandlersHTTP) Stream(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	filter, err := h.getFilter(w, r)
+	if err != nil {
+		return
+	}
+
+	lastEventID := r.Header.Get(lastEventIDHeader)
+	if lastEventID == "" {
+		lastEventID = r.URL.Query().Get(lastEventIDQuery)
+	}
+	if lastEventID != "" && !models.IsOrderUpdateID(lastEventID) {
+		_ = httpErrors.NewBadRequestError(w, fmt.Sprintf("invalid last event id: %q", lastEventID), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		h.logger.Errorf("order stream: %T is no http.Flusher", w)
+		_ = httpErrors.NewInternalServerError(w, "streaming unsupported", h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	updates, err := h.orderStreamUC.Subscribe(ctx, filter, lastEventID)
+	if err != nil {
+		h.logger.Errorf("orderStreamUC.Subscribe: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	heartbeat, maxDuration, retry := h.getTimings()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, "retry: %d\n\n", retry.Milliseconds())
+	flusher.Flush()
+
+	ticker := time.NewTicker(heartbeat)
+	defer ticker.Stop()
+	// streams end ahead of the server write timeout, clients reconnect and resume from the buffer
+	deadline := time.NewTimer(maxDuration)
+	defer deadline.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-deadline.C:
+			return
+		case <-ticker.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		case update, ok := <-updates:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(dto.OrderUpdateResponseFromModel(update))
+			if err != nil {
+				h.logger.Errorf("json.Marshal: %v", err)
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", update.ID, update.Event, data); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+// getFilter orders the caller gets updates of, error response is already written when err is not nil
+func (h *orderStreamHandlersHTTP) getFilter(w http.ResponseWriter, r *http.Request) (filter models.OrderStreamFilter, err error) {
+	jwtClaims, err := h.mw.GetJWTClaims(w, r)
+	if err != nil {
+		return
+	}
+	claims := *jwtClaims
+	role, _ := claims["role"].(string)
+
+	switch role {
+	case models.UserRoleAdmin:
+		if brandID := r.URL.Query().Get(constants.BrandID); brandID != "" {
+			brandUUID, err := uuid.Parse(brandID)
+			if err != nil {
+				_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+				return filter, err
+			}
+			filter.BrandID = &brandUUID
+		}
+	case models.UserRoleSeller:
+		brandID, _ := claims["brand_id"].(string)
+		brandUUID, err := uuid.Parse(brandID)
+		if err != nil {
+			_ = httpErrors.NewForbiddenError(w, nil, h.cfg.Http.DebugErrorsResponse)
+			return filter, errors.New("forbidden")
+		}
+		filter.BrandID = &brandUUID
+	default:
+		userID, _ := claims["user_id"].(string)
+		userUUID, err := uuid.Parse(userID)
+		if err != nil {
+			_ = httpErrors.NewUnauthorizedError(w, nil, h.cfg.Http.DebugErrorsResponse)
+			return filter, err
+		}
+		filter.UserID = &userUUID
+	}
+
+	return filter, nil
+}
+
+func (h *orderStreamHandlersHTTP) getTimings() (heartbeat time.Duration, maxDuration time.Duration, retry time.Duration) {
+	heartbeat, maxDuration, retry = h.cfg.OrderStream.Heartbeat, h.cfg.OrderStream.MaxDuration, h.cfg.OrderStream.Retry
+	if heartbeat <= 0 {
+		heartbeat = defaultHeartbeat
+	}
+	if maxDuration <= 0 {
+		maxDuration = defaultMaxDuration
+	}
+	if retry <= 0 {
+		retry = defaultRetry
+	}
+	return heartbeat, maxDuration, retry
+}
@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/middlewares"
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/internal/orderstream/mock"
+	"github.com/dinorain/kalobranded/internal/server/router"
+	"github.com/dinorain/kalobranded/pkg/logger"
+)
+
+func signedToken(t *testing.T, cfg *config.Config, userUUID uuid.UUID, role string, brandUUID *uuid.UUID) string {
+	token := jwt.New(jwt.SigningMethodHS256)
+	claims := token.Claims.(jwt.MapClaims)
+	claims["session_id"] = uuid.New().String()
+	claims["user_id"] = userUUID.String()
+	claims["role"] = role
+	if brandUUID != nil {
+		claims["brand_id"] = brandUUID.String()
+	}
+	claims["exp"] = time.Now().Add(time.Minute * 15).Unix()
+	validToken, err := token.SignedString([]byte(cfg.Server.JwtSecretKey))
+	require.NoError(t, err)
+	return validToken
+}
+
+func TestOrderStreamHandler_Stream(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orderStreamUC := mock.NewMockOrderStreamUseCase(ctrl)
+
+	cfg := &config.Config{
+		Session:     config.Session{Expire: 1234},
+		Server:      config.ServerConfig{JwtSecretKey: "secret"},
+		OrderStream: config.OrderStream{Heartbeat: time.Hour, MaxDuration: time.Hour, Retry: 2 * time.Second},
+	}
+	appLogger := logger.NewAppLogger(cfg)
+	appLogger.InitLogger()
+	mw := middlewares.NewMiddlewareManager(appLogger, cfg)
+
+	handlers := NewOrderStreamHandlersHTTP(router.NewRouter(false), appLogger, cfg, mw, orderStreamUC)
+
+	userUUID, brandUUID := uuid.New(), uuid.New()
+
+	t.Run("Seller", func(t *testing.T) {
+		update := &models.OrderUpdate{
+			ID:      "1700000000000-0",
+			Event:   models.EventOrderStatusChanged,
+			OrderID: uuid.New(),
+			UserID:  uuid.New(),
+			BrandID: brandUUID,
+			From:    models.OrderStatusPaid,
+			Status:  models.OrderStatusAccepted,
+			Version: 2,
+		}
+		orderStreamUC.EXPECT().Subscribe(gomock.Any(), gomock.Any(), "1699999999999-3").
+			DoAndReturn(func(_ interface{}, filter models.OrderStreamFilter, _ string) (<-chan *models.OrderUpdate, error) {
+				require.Nil(t, filter.UserID)
+				require.Equal(t, brandUUID, *filter.BrandID)
+
+				updates := make(chan *models.OrderUpdate, 1)
+				updates <- update
+				close(updates)
+				return updates, nil
+			})
+
+		req := httptest.NewRequest(http.MethodGet, "/orders/stream", nil)
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", signedToken(t, cfg, userUUID, models.UserRoleSeller, &brandUUID)))
+		req.Header.Set("Last-Event-ID", "1699999999999-3")
+
+		w := httptest.NewRecorder()
+		http.HandlerFunc(handlers.Stream).ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+		require.True(t, w.Flushed)
+
+		body := w.Body.String()
+		require.True(t, strings.HasPrefix(body, "retry: 2000\n\n"), body)
+		require.Contains(t, body, "id: 1700000000000-0\nevent: order.status_changed\ndata: {")
+		require.Contains(t, body, `"status":"accepted"`)
+	})
+
+	t.Run("User", func(t *testing.T) {
+		orderStreamUC.EXPECT().Subscribe(gomock.Any(), gomock.Any(), "").
+			DoAndReturn(func(_ interface{}, filter models.OrderStreamFilter, _ string) (<-chan *models.OrderUpdate, error) {
+				require.Equal(t, userUUID, *filter.UserID)
+				require.Nil(t, filter.BrandID)
+
+				updates := make(chan *models.OrderUpdate)
+				close(updates)
+				return updates, nil
+			})
+
+		req := httptest.NewRequest(http.MethodGet, "/orders/stream?brand_id="+brandUUID.String(), nil)
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", signedToken(t, cfg, userUUID, models.UserRoleUser, nil)))
+
+		w := httptest.NewRecorder()
+		http.HandlerFunc(handlers.Stream).ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("InvalidLastEventID", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/orders/stream?last_event_id=latest", nil)
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", signedToken(t, cfg, userUUID, models.UserRoleAdmin, nil)))
+
+		w := httptest.NewRecorder()
+		http.HandlerFunc(handlers.Stream).ServeHTTP(w, req)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
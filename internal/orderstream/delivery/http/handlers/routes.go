@@ -0,0 +1,6 @@
+package handlers
+
+func (h *orderStreamHandlersHTTP) OrderStreamMapRoutes() {
+	orders := h.router.Group("/orders", h.mw.IsLoggedIn)
+	orders.Get("/stream", h.Stream)
+}
@@ -0,0 +1,10 @@
+package orderstream
+
+import (
+	"net/http"
+)
+
+// Order stream HTTP Handlers interface
+type OrderStreamHandlers interface {
+	Stream(w http.ResponseWriter, r *http.Request)
+}
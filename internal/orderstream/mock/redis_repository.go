@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: redis_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/dinorain/kalobranded/internal/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockOrderStreamRedisRepository is a mock of OrderStreamRedisRepository interface.
+type MockOrderStreamRedisRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderStreamRedisRepositoryMockRecorder
+}
+
+// MockOrderStreamRedisRepositoryMockRecorder is the mock recorder for MockOrderStreamRedisRepository.
+type MockOrderStreamRedisRepositoryMockRecorder struct {
+	mock *MockOrderStreamRedisRepository
+}
+
+// NewMockOrderStreamRedisRepository creates a new mock instance.
+func NewMockOrderStreamRedisRepository(ctrl *gomock.Controller) *MockOrderStreamRedisRepository {
+	mock := &MockOrderStreamRedisRepository{ctrl: ctrl}
+	mock.recorder = &MockOrderStreamRedisRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderStreamRedisRepository) EXPECT() *MockOrderStreamRedisRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindAllAfterCtx mocks base method.
+func (m *MockOrderStreamRedisRepository) FindAllAfterCtx(ctx context.Context, lastID string, count int64) ([]models.OrderUpdate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllAfterCtx", ctx, lastID, count)
+	ret0, _ := ret[0].([]models.OrderUpdate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllAfterCtx indicates an expected call of FindAllAfterCtx.
+func (mr *MockOrderStreamRedisRepositoryMockRecorder) FindAllAfterCtx(ctx, lastID, count interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllAfterCtx", reflect.TypeOf((*MockOrderStreamRedisRepository)(nil).FindAllAfterCtx), ctx, lastID, count)
+}
+
+// PublishCtx mocks base method.
+func (m *MockOrderStreamRedisRepository) PublishCtx(ctx context.Context, update *models.OrderUpdate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishCtx", ctx, update)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishCtx indicates an expected call of PublishCtx.
+func (mr *MockOrderStreamRedisRepositoryMockRecorder) PublishCtx(ctx, update interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishCtx", reflect.TypeOf((*MockOrderStreamRedisRepository)(nil).PublishCtx), ctx, update)
+}
+
+// SubscribeCtx mocks base method.
+func (m *MockOrderStreamRedisRepository) SubscribeCtx(ctx context.Context) (<-chan *models.OrderUpdate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeCtx", ctx)
+	ret0, _ := ret[0].(<-chan *models.OrderUpdate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubscribeCtx indicates an expected call of SubscribeCtx.
+func (mr *MockOrderStreamRedisRepositoryMockRecorder) SubscribeCtx(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeCtx", reflect.TypeOf((*MockOrderStreamRedisRepository)(nil).SubscribeCtx), ctx)
+}
@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/dinorain/kalobranded/internal/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockOrderStreamUseCase is a mock of OrderStreamUseCase interface.
+type MockOrderStreamUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderStreamUseCaseMockRecorder
+}
+
+// MockOrderStreamUseCaseMockRecorder is the mock recorder for MockOrderStreamUseCase.
+type MockOrderStreamUseCaseMockRecorder struct {
+	mock *MockOrderStreamUseCase
+}
+
+// NewMockOrderStreamUseCase creates a new mock instance.
+func NewMockOrderStreamUseCase(ctrl *gomock.Controller) *MockOrderStreamUseCase {
+	mock := &MockOrderStreamUseCase{ctrl: ctrl}
+	mock.recorder = &MockOrderStreamUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderStreamUseCase) EXPECT() *MockOrderStreamUseCaseMockRecorder {
+	return m.recorder
+}
+
+// HandleEvent mocks base method.
+func (m *MockOrderStreamUseCase) HandleEvent(ctx context.Context, event *models.OutboxEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleEvent", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleEvent indicates an expected call of HandleEvent.
+func (mr *MockOrderStreamUseCaseMockRecorder) HandleEvent(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleEvent", reflect.TypeOf((*MockOrderStreamUseCase)(nil).HandleEvent), ctx, event)
+}
+
+// Run mocks base method.
+func (m *MockOrderStreamUseCase) Run(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Run", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockOrderStreamUseCaseMockRecorder) Run(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockOrderStreamUseCase)(nil).Run), ctx)
+}
+
+// Subscribe mocks base method.
+func (m *MockOrderStreamUseCase) Subscribe(ctx context.Context, filter models.OrderStreamFilter, lastEventID string) (<-chan *models.OrderUpdate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", ctx, filter, lastEventID)
+	ret0, _ := ret[0].(<-chan *models.OrderUpdate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockOrderStreamUseCaseMockRecorder) Subscribe(ctx, filter, lastEventID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockOrderStreamUseCase)(nil).Subscribe), ctx, filter, lastEventID)
+}
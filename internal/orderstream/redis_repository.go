@@ -0,0 +1,15 @@
+//go:generate mockgen -source redis_repository.go -destination mock/redis_repository.go -package mock
+package orderstream
+
+import (
+	"context"
+
+	"github.com/dinorain/kalobranded/internal/models"
+)
+
+// Order stream redis repository interface
+type OrderStreamRedisRepository interface {
+	PublishCtx(ctx context.Context, update *models.OrderUpdate) error
+	FindAllAfterCtx(ctx context.Context, lastID string, count int64) ([]models.OrderUpdate, error)
+	SubscribeCtx(ctx context.Context) (<-chan *models.OrderUpdate, error)
+}
@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/pkg/errors"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/internal/orderstream"
+	"github.com/dinorain/kalobranded/pkg/logger"
+)
+
+const updateField = "update"
+
+// Order stream redis repository, updates are appended to the buffer stream and then published on the pub/sub channel
+type orderStreamRedisRepo struct {
+	redisClient *redis.Client
+	cfg         *config.Config
+	logger      logger.Logger
+}
+
+var _ orderstream.OrderStreamRedisRepository = (*orderStreamRedisRepo)(nil)
+
+// Order stream redis repository constructor
+func NewOrderStreamRedisRepo(redisClient *redis.Client, cfg *config.Config, logger logger.Logger) *orderStreamRedisRepo {
+	return &orderStreamRedisRepo{redisClient: redisClient, cfg: cfg, logger: logger}
+}
+
+// PublishCtx buffer update, which gets the id of its buffer entry, and publish it to the subscribers of every instance
+func (r *orderStreamRedisRepo) PublishCtx(ctx context.Context, update *models.OrderUpdate) error {
+	updateBytes, err := json.Marshal(update)
+	if err != nil {
+		return err
+	}
+
+	id, err := r.redisClient.XAdd(ctx, &redis.XAddArgs{
+		Stream: r.cfg.OrderStream.Buffer,
+		MaxLen: r.cfg.OrderStream.BufferSize,
+		Approx: r.cfg.OrderStream.BufferSize > 0,
+		Values: map[string]interface{}{updateField: updateBytes},
+	}).Result()
+	if err != nil {
+		return errors.Wrap(err, "XAdd")
+	}
+	update.ID = id
+
+	updateBytes, err = json.Marshal(update)
+	if err != nil {
+		return err
+	}
+
+	return r.redisClient.Publish(ctx, r.cfg.OrderStream.Channel, updateBytes).Err()
+}
+
+// FindAllAfterCtx find up to count buffered updates following the one with lastID, oldest first
+func (r *orderStreamRedisRepo) FindAllAfterCtx(ctx context.Context, lastID string, count int64) ([]models.OrderUpdate, error) {
+	// the range start is inclusive, one more entry is read in case the first one is lastID itself
+	messages, err := r.redisClient.XRangeN(ctx, r.cfg.OrderStream.Buffer, lastID, "+", count+1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	updates := make([]models.OrderUpdate, 0, len(messages))
+	for _, m := range messages {
+		if m.ID == lastID {
+			continue
+		}
+		update, err := updateFromStream(m)
+		if err != nil {
+			r.logger.Warnf("updateFromStream %s: %v", m.ID, err)
+			continue
+		}
+		updates = append(updates, *update)
+	}
+	if int64(len(updates)) > count {
+		updates = updates[:count]
+	}
+
+	return updates, nil
+}
+
+// SubscribeCtx subscribe to the updates published by every instance, the channel is closed once ctx is done or
+// the subscription is lost
+func (r *orderStreamRedisRepo) SubscribeCtx(ctx context.Context) (<-chan *models.OrderUpdate, error) {
+	pubsub := r.redisClient.Subscribe(ctx, r.cfg.OrderStream.Channel)
+	if _, err := pubsub.Receive(ctx); err != nil {
+		_ = pubsub.Close()
+		return nil, errors.Wrap(err, "pubsub.Receive")
+	}
+
+	updates := make(chan *models.OrderUpdate)
+	go func() {
+		defer close(updates)
+		defer pubsub.Close()
+
+		messages := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-messages:
+				if !ok {
+					return
+				}
+				update := &models.OrderUpdate{}
+				if err := json.Unmarshal([]byte(msg.Payload), update); err != nil {
+					r.logger.Warnf("json.Unmarshal order update: %v", err)
+					continue
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case updates <- update:
+				}
+			}
+		}
+	}()
+
+	return updates, nil
+}
+
+func updateFromStream(m redis.XMessage) (*models.OrderUpdate, error) {
+	raw, ok := m.Values[updateField].(string)
+	if !ok {
+		return nil, fmt.Errorf("stream message %s has no %s", m.ID, updateField)
+	}
+
+	update := &models.OrderUpdate{}
+	if err := json.Unmarshal([]byte(raw), update); err != nil {
+		return nil, err
+	}
+	update.ID = m.ID
+
+	return update, nil
+}
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/kalobranded/internal/models"
+)
+
+func TestUpdateFromStream(t *testing.T) {
+	t.Parallel()
+
+	orderUUID := uuid.New()
+	update, err := updateFromStream(redis.XMessage{
+		ID:     "1700000000000-1",
+		Values: map[string]interface{}{updateField: `{"event":"order.created","order_id":"` + orderUUID.String() + `","status":"pending"}`},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "1700000000000-1", update.ID)
+	require.Equal(t, orderUUID, update.OrderID)
+	require.Equal(t, models.OrderStatusPending, update.Status)
+
+	t.Run("NoUpdate", func(t *testing.T) {
+		_, err := updateFromStream(redis.XMessage{ID: "2-0", Values: map[string]interface{}{}})
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,19 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase.go -package mock
+package orderstream
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dinorain/kalobranded/internal/models"
+)
+
+// ErrClosed the order stream is shut down and takes no more subscribers
+var ErrClosed = errors.New("order stream closed")
+
+// Order stream UseCase interface
+type OrderStreamUseCase interface {
+	HandleEvent(ctx context.Context, event *models.OutboxEvent) error
+	Subscribe(ctx context.Context, filter models.OrderStreamFilter, lastEventID string) (<-chan *models.OrderUpdate, error)
+	Run(ctx context.Context) error
+}
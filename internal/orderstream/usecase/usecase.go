@@ -0,0 +1,223 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/internal/orderstream"
+	"github.com/dinorain/kalobranded/pkg/logger"
+)
+
+const (
+	defaultBufferSize  = 1000
+	defaultClientQueue = 32
+)
+
+type subscriber struct {
+	filter  models.OrderStreamFilter
+	updates chan *models.OrderUpdate
+}
+
+// Order stream UseCase, fans the updates published by any instance out to the subscribers of this one
+type orderStreamUseCase struct {
+	cfg             *config.Config
+	logger          logger.Logger
+	orderStreamRepo orderstream.OrderStreamRedisRepository
+
+	mu          sync.Mutex
+	subscribers map[*subscriber]struct{}
+	closed      bool
+}
+
+var _ orderstream.OrderStreamUseCase = (*orderStreamUseCase)(nil)
+
+// New Order stream UseCase
+func NewOrderStreamUseCase(cfg *config.Config, logger logger.Logger, orderStreamRepo orderstream.OrderStreamRedisRepository) *orderStreamUseCase {
+	return &orderStreamUseCase{
+		cfg:             cfg,
+		logger:          logger,
+		orderStreamRepo: orderStreamRepo,
+		subscribers:     make(map[*subscriber]struct{}),
+	}
+}
+
+// HandleEvent publish the order update reported by an order event, other events are ignored
+func (u *orderStreamUseCase) HandleEvent(ctx context.Context, event *models.OutboxEvent) error {
+	update := &models.OrderUpdate{Event: event.EventType, OccurredAt: event.CreatedAt}
+
+	switch event.EventType {
+	case models.EventOrderCreated:
+		var createdOrder models.Order
+		if err := json.Unmarshal(event.Payload, &createdOrder); err != nil {
+			return errors.Wrap(err, "json.Unmarshal")
+		}
+		update.OrderID, update.UserID, update.BrandID = createdOrder.OrderID, createdOrder.UserID, createdOrder.BrandID
+		update.Status, update.Version = createdOrder.Status, createdOrder.Version
+	case models.EventOrderStatusChanged:
+		var change models.OrderStatusChange
+		if err := json.Unmarshal(event.Payload, &change); err != nil {
+			return errors.Wrap(err, "json.Unmarshal")
+		}
+		update.OrderID, update.UserID, update.BrandID = change.OrderID, change.UserID, change.BrandID
+		update.From, update.Status, update.Version = change.From, change.To, change.Version
+	default:
+		return nil
+	}
+	if update.OccurredAt.IsZero() {
+		update.OccurredAt = time.Now().UTC()
+	}
+
+	if err := u.orderStreamRepo.PublishCtx(ctx, update); err != nil {
+		return errors.Wrap(err, "orderStreamRepo.PublishCtx")
+	}
+
+	return nil
+}
+
+// Subscribe updates of the orders matching filter until ctx is done, starting with the buffered updates following
+// lastEventID when it is set. The channel is closed early when the stream shuts down or the subscriber falls too far
+// behind, subscribers resume from the id of the last update they got
+func (u *orderStreamUseCase) Subscribe(ctx context.Context, filter models.OrderStreamFilter, lastEventID string) (<-chan *models.OrderUpdate, error) {
+	clientQueue := u.cfg.OrderStream.ClientQueue
+	if clientQueue <= 0 {
+		clientQueue = defaultClientQueue
+	}
+	sub := &subscriber{filter: filter, updates: make(chan *models.OrderUpdate, clientQueue)}
+
+	u.mu.Lock()
+	if u.closed {
+		u.mu.Unlock()
+		return nil, orderstream.ErrClosed
+	}
+	u.subscribers[sub] = struct{}{}
+	u.mu.Unlock()
+
+	// the subscriber is registered ahead of reading the buffer, updates published in between come both ways and
+	// the live copies are skipped
+	var replay []models.OrderUpdate
+	if lastEventID != "" {
+		bufferSize := u.cfg.OrderStream.BufferSize
+		if bufferSize <= 0 {
+			bufferSize = defaultBufferSize
+		}
+
+		var err error
+		replay, err = u.orderStreamRepo.FindAllAfterCtx(ctx, lastEventID, bufferSize)
+		if err != nil {
+			u.unsubscribe(sub)
+			return nil, errors.Wrap(err, "orderStreamRepo.FindAllAfterCtx")
+		}
+	}
+
+	out := make(chan *models.OrderUpdate)
+	go func() {
+		defer close(out)
+		defer u.unsubscribe(sub)
+
+		lastID := lastEventID
+		send := func(update *models.OrderUpdate) bool {
+			if lastID != "" && models.CompareOrderUpdateIDs(update.ID, lastID) <= 0 {
+				return true
+			}
+			select {
+			case <-ctx.Done():
+				return false
+			case out <- update:
+				lastID = update.ID
+				return true
+			}
+		}
+
+		for i := range replay {
+			if filter.Matches(&replay[i]) && !send(&replay[i]) {
+				return
+			}
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case update, ok := <-sub.updates:
+				if !ok || !send(update) {
+					return
+				}
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// Run hand the published updates to the subscribers until ctx is done or the subscription is lost. Subscribers are
+// closed when Run returns, once ctx is done the stream takes no more subscribers
+func (u *orderStreamUseCase) Run(ctx context.Context) error {
+	defer u.closeSubscribers(ctx)
+
+	updates, err := u.orderStreamRepo.SubscribeCtx(ctx)
+	if err != nil {
+		return errors.Wrap(err, "orderStreamRepo.SubscribeCtx")
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case update, ok := <-updates:
+			if !ok {
+				if ctx.Err() != nil {
+					return nil
+				}
+				return errors.New("order stream subscription lost")
+			}
+			u.dispatch(update)
+		}
+	}
+}
+
+// dispatch update to the subscribers it matches, subscribers with a full queue are dropped
+func (u *orderStreamUseCase) dispatch(update *models.OrderUpdate) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	for sub := range u.subscribers {
+		if !sub.filter.Matches(update) {
+			continue
+		}
+		select {
+		case sub.updates <- update:
+		default:
+			u.logger.Warnf("order stream subscriber too slow, dropped at %s", update.ID)
+			delete(u.subscribers, sub)
+			close(sub.updates)
+		}
+	}
+}
+
+func (u *orderStreamUseCase) unsubscribe(sub *subscriber) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if _, ok := u.subscribers[sub]; ok {
+		delete(u.subscribers, sub)
+		close(sub.updates)
+	}
+}
+
+func (u *orderStreamUseCase) closeSubscribers(ctx context.Context) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	for sub := range u.subscribers {
+		delete(u.subscribers, sub)
+		close(sub.updates)
+	}
+	if ctx.Err() != nil {
+		u.closed = true
+	}
+}
@@ -0,0 +1,175 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/internal/orderstream"
+	"github.com/dinorain/kalobranded/internal/orderstream/mock"
+	"github.com/dinorain/kalobranded/pkg/logger"
+)
+
+func newTestUseCase(t *testing.T, clientQueue int) (*orderStreamUseCase, *mock.MockOrderStreamRedisRepository) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	cfg := &config.Config{OrderStream: config.OrderStream{BufferSize: 100, ClientQueue: clientQueue}}
+	apiLogger := logger.NewAppLogger(cfg)
+	apiLogger.InitLogger()
+
+	orderStreamRedisRepo := mock.NewMockOrderStreamRedisRepository(ctrl)
+	return NewOrderStreamUseCase(cfg, apiLogger, orderStreamRedisRepo), orderStreamRedisRepo
+}
+
+// run the use case on a published channel, the returned func stops it and waits for Run to return
+func run(t *testing.T, orderStreamUC *orderStreamUseCase, repo *mock.MockOrderStreamRedisRepository) (chan<- *models.OrderUpdate, func()) {
+	published := make(chan *models.OrderUpdate)
+	repo.EXPECT().SubscribeCtx(gomock.Any()).Return(published, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- orderStreamUC.Run(ctx) }()
+
+	return published, func() {
+		cancel()
+		require.NoError(t, <-done)
+	}
+}
+
+func receive(t *testing.T, updates <-chan *models.OrderUpdate) *models.OrderUpdate {
+	select {
+	case update, ok := <-updates:
+		require.True(t, ok, "updates closed")
+		return update
+	case <-time.After(time.Second):
+		t.Fatal("no update")
+		return nil
+	}
+}
+
+func requireClosed(t *testing.T, updates <-chan *models.OrderUpdate) {
+	select {
+	case _, ok := <-updates:
+		require.False(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("updates not closed")
+	}
+}
+
+func TestOrderStreamUseCase_HandleEvent(t *testing.T) {
+	t.Parallel()
+
+	orderStreamUC, repo := newTestUseCase(t, 0)
+
+	change := &models.OrderStatusChange{OrderID: uuid.New(), UserID: uuid.New(), BrandID: uuid.New(), From: models.OrderStatusPaid, To: models.OrderStatusAccepted, Version: 3}
+	event, err := models.NewOutboxEvent(models.AggregateOrder, change.OrderID, models.EventOrderStatusChanged, change)
+	require.NoError(t, err)
+
+	repo.EXPECT().PublishCtx(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, update *models.OrderUpdate) error {
+		require.Equal(t, models.EventOrderStatusChanged, update.Event)
+		require.Equal(t, change.OrderID, update.OrderID)
+		require.Equal(t, change.BrandID, update.BrandID)
+		require.Equal(t, models.OrderStatusPaid, update.From)
+		require.Equal(t, models.OrderStatusAccepted, update.Status)
+		require.Equal(t, 3, update.Version)
+		require.False(t, update.OccurredAt.IsZero())
+		return nil
+	})
+	require.NoError(t, orderStreamUC.HandleEvent(context.Background(), event))
+
+	t.Run("OtherEvent", func(t *testing.T) {
+		event, err := models.NewOutboxEvent(models.AggregateUser, uuid.New(), models.EventUserRegistered, json.RawMessage(`{}`))
+		require.NoError(t, err)
+		require.NoError(t, orderStreamUC.HandleEvent(context.Background(), event))
+	})
+}
+
+func TestOrderStreamUseCase_Subscribe(t *testing.T) {
+	t.Parallel()
+
+	buyerUUID, brandUUID := uuid.New(), uuid.New()
+	own := func(id string) *models.OrderUpdate {
+		return &models.OrderUpdate{ID: id, OrderID: uuid.New(), UserID: buyerUUID, BrandID: brandUUID, Status: models.OrderStatusPaid}
+	}
+	other := func(id string) *models.OrderUpdate {
+		return &models.OrderUpdate{ID: id, OrderID: uuid.New(), UserID: uuid.New(), BrandID: brandUUID, Status: models.OrderStatusPaid}
+	}
+
+	t.Run("Filter", func(t *testing.T) {
+		orderStreamUC, repo := newTestUseCase(t, 0)
+		published, stop := run(t, orderStreamUC, repo)
+
+		updates, err := orderStreamUC.Subscribe(context.Background(), models.OrderStreamFilter{UserID: &buyerUUID}, "")
+		require.NoError(t, err)
+
+		published <- other("1-0")
+		published <- own("2-0")
+		require.Equal(t, "2-0", receive(t, updates).ID)
+
+		stop()
+		requireClosed(t, updates)
+
+		_, err = orderStreamUC.Subscribe(context.Background(), models.OrderStreamFilter{}, "")
+		require.True(t, errors.Is(err, orderstream.ErrClosed))
+	})
+
+	t.Run("Resume", func(t *testing.T) {
+		orderStreamUC, repo := newTestUseCase(t, 0)
+		published, stop := run(t, orderStreamUC, repo)
+		defer stop()
+
+		repo.EXPECT().FindAllAfterCtx(gomock.Any(), "1-0", int64(100)).Return([]models.OrderUpdate{*own("2-0"), *other("3-0"), *own("4-0")}, nil)
+
+		updates, err := orderStreamUC.Subscribe(context.Background(), models.OrderStreamFilter{UserID: &buyerUUID}, "1-0")
+		require.NoError(t, err)
+
+		require.Equal(t, "2-0", receive(t, updates).ID)
+		require.Equal(t, "4-0", receive(t, updates).ID)
+
+		// published while the buffer was read, already replayed
+		published <- own("4-0")
+		published <- own("5-0")
+		require.Equal(t, "5-0", receive(t, updates).ID)
+	})
+
+	t.Run("SlowSubscriber", func(t *testing.T) {
+		orderStreamUC, repo := newTestUseCase(t, 1)
+		published, stop := run(t, orderStreamUC, repo)
+		defer stop()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		updates, err := orderStreamUC.Subscribe(ctx, models.OrderStreamFilter{BrandID: &brandUUID}, "")
+		require.NoError(t, err)
+
+		// at most one update is held by the subscriber and one queued, the others do not fit
+		for _, id := range []string{"1-0", "2-0", "3-0", "4-0"} {
+			published <- own(id)
+		}
+
+		var got int
+		for range updates {
+			got++
+		}
+		require.True(t, got >= 1 && got <= 2, "got %d updates", got)
+	})
+
+	t.Run("BufferDown", func(t *testing.T) {
+		orderStreamUC, repo := newTestUseCase(t, 0)
+
+		repo.EXPECT().FindAllAfterCtx(gomock.Any(), "1-0", int64(100)).Return(nil, errors.New("connection refused"))
+
+		_, err := orderStreamUC.Subscribe(context.Background(), models.OrderStreamFilter{}, "1-0")
+		require.Error(t, err)
+		require.Empty(t, orderStreamUC.subscribers)
+	})
+}
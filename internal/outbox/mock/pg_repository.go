@@ -0,0 +1,67 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pg_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/dinorain/kalobranded/internal/models"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockOutboxPGRepository is a mock of OutboxPGRepository interface.
+type MockOutboxPGRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxPGRepositoryMockRecorder
+}
+
+// MockOutboxPGRepositoryMockRecorder is the mock recorder for MockOutboxPGRepository.
+type MockOutboxPGRepositoryMockRecorder struct {
+	mock *MockOutboxPGRepository
+}
+
+// NewMockOutboxPGRepository creates a new mock instance.
+func NewMockOutboxPGRepository(ctrl *gomock.Controller) *MockOutboxPGRepository {
+	mock := &MockOutboxPGRepository{ctrl: ctrl}
+	mock.recorder = &MockOutboxPGRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxPGRepository) EXPECT() *MockOutboxPGRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimPending mocks base method.
+func (m *MockOutboxPGRepository) ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]models.OutboxEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimPending", ctx, limit, lease)
+	ret0, _ := ret[0].([]models.OutboxEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimPending indicates an expected call of ClaimPending.
+func (mr *MockOutboxPGRepositoryMockRecorder) ClaimPending(ctx, limit, lease interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimPending", reflect.TypeOf((*MockOutboxPGRepository)(nil).ClaimPending), ctx, limit, lease)
+}
+
+// MarkPublished mocks base method.
+func (m *MockOutboxPGRepository) MarkPublished(ctx context.Context, eventIDs []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkPublished", ctx, eventIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkPublished indicates an expected call of MarkPublished.
+func (mr *MockOutboxPGRepositoryMockRecorder) MarkPublished(ctx, eventIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkPublished", reflect.TypeOf((*MockOutboxPGRepository)(nil).MarkPublished), ctx, eventIDs)
+}
@@ -0,0 +1,128 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: redis_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/dinorain/kalobranded/internal/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockOutboxRedisRepository is a mock of OutboxRedisRepository interface.
+type MockOutboxRedisRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxRedisRepositoryMockRecorder
+}
+
+// MockOutboxRedisRepositoryMockRecorder is the mock recorder for MockOutboxRedisRepository.
+type MockOutboxRedisRepositoryMockRecorder struct {
+	mock *MockOutboxRedisRepository
+}
+
+// NewMockOutboxRedisRepository creates a new mock instance.
+func NewMockOutboxRedisRepository(ctrl *gomock.Controller) *MockOutboxRedisRepository {
+	mock := &MockOutboxRedisRepository{ctrl: ctrl}
+	mock.recorder = &MockOutboxRedisRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxRedisRepository) EXPECT() *MockOutboxRedisRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AckCtx mocks base method.
+func (m *MockOutboxRedisRepository) AckCtx(ctx context.Context, stream, group string, ids ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, stream, group}
+	for _, a := range ids {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AckCtx", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AckCtx indicates an expected call of AckCtx.
+func (mr *MockOutboxRedisRepositoryMockRecorder) AckCtx(ctx, stream, group interface{}, ids ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, stream, group}, ids...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AckCtx", reflect.TypeOf((*MockOutboxRedisRepository)(nil).AckCtx), varargs...)
+}
+
+// AddCtx mocks base method.
+func (m *MockOutboxRedisRepository) AddCtx(ctx context.Context, stream string, event *models.OutboxEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddCtx", ctx, stream, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddCtx indicates an expected call of AddCtx.
+func (mr *MockOutboxRedisRepositoryMockRecorder) AddCtx(ctx, stream, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCtx", reflect.TypeOf((*MockOutboxRedisRepository)(nil).AddCtx), ctx, stream, event)
+}
+
+// ClaimStaleCtx mocks base method.
+func (m *MockOutboxRedisRepository) ClaimStaleCtx(ctx context.Context, stream, group, consumer string, minIdle time.Duration, count int64) ([]models.StreamMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimStaleCtx", ctx, stream, group, consumer, minIdle, count)
+	ret0, _ := ret[0].([]models.StreamMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimStaleCtx indicates an expected call of ClaimStaleCtx.
+func (mr *MockOutboxRedisRepositoryMockRecorder) ClaimStaleCtx(ctx, stream, group, consumer, minIdle, count interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimStaleCtx", reflect.TypeOf((*MockOutboxRedisRepository)(nil).ClaimStaleCtx), ctx, stream, group, consumer, minIdle, count)
+}
+
+// CreateGroupCtx mocks base method.
+func (m *MockOutboxRedisRepository) CreateGroupCtx(ctx context.Context, stream, group string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGroupCtx", ctx, stream, group)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateGroupCtx indicates an expected call of CreateGroupCtx.
+func (mr *MockOutboxRedisRepositoryMockRecorder) CreateGroupCtx(ctx, stream, group interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGroupCtx", reflect.TypeOf((*MockOutboxRedisRepository)(nil).CreateGroupCtx), ctx, stream, group)
+}
+
+// DeadLetterCtx mocks base method.
+func (m *MockOutboxRedisRepository) DeadLetterCtx(ctx context.Context, stream, group string, msg *models.StreamMessage, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeadLetterCtx", ctx, stream, group, msg, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeadLetterCtx indicates an expected call of DeadLetterCtx.
+func (mr *MockOutboxRedisRepositoryMockRecorder) DeadLetterCtx(ctx, stream, group, msg, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeadLetterCtx", reflect.TypeOf((*MockOutboxRedisRepository)(nil).DeadLetterCtx), ctx, stream, group, msg, reason)
+}
+
+// ReadGroupCtx mocks base method.
+func (m *MockOutboxRedisRepository) ReadGroupCtx(ctx context.Context, stream, group, consumer string, count int64, block time.Duration) ([]models.StreamMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadGroupCtx", ctx, stream, group, consumer, count, block)
+	ret0, _ := ret[0].([]models.StreamMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadGroupCtx indicates an expected call of ReadGroupCtx.
+func (mr *MockOutboxRedisRepositoryMockRecorder) ReadGroupCtx(ctx, stream, group, consumer, count, block interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadGroupCtx", reflect.TypeOf((*MockOutboxRedisRepository)(nil).ReadGroupCtx), ctx, stream, group, consumer, count, block)
+}
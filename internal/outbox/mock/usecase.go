@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	outbox "github.com/dinorain/kalobranded/internal/outbox"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockOutboxUseCase is a mock of OutboxUseCase interface.
+type MockOutboxUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxUseCaseMockRecorder
+}
+
+// MockOutboxUseCaseMockRecorder is the mock recorder for MockOutboxUseCase.
+type MockOutboxUseCaseMockRecorder struct {
+	mock *MockOutboxUseCase
+}
+
+// NewMockOutboxUseCase creates a new mock instance.
+func NewMockOutboxUseCase(ctrl *gomock.Controller) *MockOutboxUseCase {
+	mock := &MockOutboxUseCase{ctrl: ctrl}
+	mock.recorder = &MockOutboxUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxUseCase) EXPECT() *MockOutboxUseCaseMockRecorder {
+	return m.recorder
+}
+
+// Consume mocks base method.
+func (m *MockOutboxUseCase) Consume(ctx context.Context, group, consumer string, handler outbox.Handler) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Consume", ctx, group, consumer, handler)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Consume indicates an expected call of Consume.
+func (mr *MockOutboxUseCaseMockRecorder) Consume(ctx, group, consumer, handler interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Consume", reflect.TypeOf((*MockOutboxUseCase)(nil).Consume), ctx, group, consumer, handler)
+}
+
+// Relay mocks base method.
+func (m *MockOutboxUseCase) Relay(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Relay", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Relay indicates an expected call of Relay.
+func (mr *MockOutboxUseCaseMockRecorder) Relay(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Relay", reflect.TypeOf((*MockOutboxUseCase)(nil).Relay), ctx)
+}
+
+// Subscribe mocks base method.
+func (m *MockOutboxUseCase) Subscribe(ctx context.Context, group string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", ctx, group)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockOutboxUseCaseMockRecorder) Subscribe(ctx, group interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockOutboxUseCase)(nil).Subscribe), ctx, group)
+}
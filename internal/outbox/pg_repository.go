@@ -0,0 +1,17 @@
+//go:generate mockgen -source pg_repository.go -destination mock/pg_repository.go -package mock
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/internal/models"
+)
+
+// Outbox pg repository
+type OutboxPGRepository interface {
+	ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]models.OutboxEvent, error)
+	MarkPublished(ctx context.Context, eventIDs []uuid.UUID) error
+}
@@ -0,0 +1,19 @@
+//go:generate mockgen -source redis_repository.go -destination mock/redis_repository.go -package mock
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/dinorain/kalobranded/internal/models"
+)
+
+// Outbox Redis Streams repository interface
+type OutboxRedisRepository interface {
+	AddCtx(ctx context.Context, stream string, event *models.OutboxEvent) error
+	CreateGroupCtx(ctx context.Context, stream string, group string) error
+	ReadGroupCtx(ctx context.Context, stream string, group string, consumer string, count int64, block time.Duration) ([]models.StreamMessage, error)
+	ClaimStaleCtx(ctx context.Context, stream string, group string, consumer string, minIdle time.Duration, count int64) ([]models.StreamMessage, error)
+	AckCtx(ctx context.Context, stream string, group string, ids ...string) error
+	DeadLetterCtx(ctx context.Context, stream string, group string, msg *models.StreamMessage, reason string) error
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/internal/outbox"
+)
+
+// Outbox repository
+type OutboxRepository struct {
+	db *sqlx.DB
+}
+
+var _ outbox.OutboxPGRepository = (*OutboxRepository)(nil)
+
+// Outbox repository constructor
+func NewOutboxPGRepository(db *sqlx.DB) *OutboxRepository {
+	return &OutboxRepository{db: db}
+}
+
+// AddEvent write an event of eventType about the aggregate to the outbox, other repositories call it with the
+// transaction of the change the event reports so both are committed or neither
+func AddEvent(ctx context.Context, tx sqlx.ExecerContext, aggregateType string, aggregateID uuid.UUID, eventType string, payload interface{}) error {
+	event, err := models.NewOutboxEvent(aggregateType, aggregateID, eventType, payload)
+	if err != nil {
+		return errors.Wrap(err, "models.NewOutboxEvent")
+	}
+
+	if _, err := tx.ExecContext(ctx, AddEventQuery, event.EventID, event.AggregateType, event.AggregateID, event.EventType, event.Payload); err != nil {
+		return errors.Wrap(err, "OutboxRepository.AddEvent.ExecContext")
+	}
+
+	return nil
+}
+
+// ClaimPending lock up to limit unpublished events for lease, oldest first. Events claimed by another relay are
+// skipped, and events of a relay that died before publishing them are claimed again once their lease is over
+func (r *OutboxRepository) ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]models.OutboxEvent, error) {
+	var events []models.OutboxEvent
+	if err := r.db.SelectContext(ctx, &events, claimPendingQuery, limit, lease.Seconds()); err != nil {
+		return nil, errors.Wrap(err, "OutboxRepository.ClaimPending.SelectContext")
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].CreatedAt.Before(events[j].CreatedAt)
+	})
+
+	return events, nil
+}
+
+// MarkPublished mark events published to the stream
+func (r *OutboxRepository) MarkPublished(ctx context.Context, eventIDs []uuid.UUID) error {
+	if len(eventIDs) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(eventIDs))
+	for _, id := range eventIDs {
+		ids = append(ids, id.String())
+	}
+
+	if _, err := r.db.ExecContext(ctx, markPublishedQuery, pq.Array(ids)); err != nil {
+		return errors.Wrap(err, "OutboxRepository.MarkPublished.ExecContext")
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/kalobranded/internal/models"
+)
+
+var outboxColumns = []string{"event_id", "aggregate_type", "aggregate_id", "event_type", "payload", "attempts", "created_at", "published_at"}
+
+func TestOutboxRepository_ClaimPending(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	outboxPGRepository := NewOutboxPGRepository(sqlxDB)
+
+	olderUUID, newerUUID := uuid.New(), uuid.New()
+	now := time.Now()
+	rows := sqlmock.NewRows(outboxColumns).
+		AddRow(newerUUID, models.AggregateOrder, uuid.New(), models.EventOrderStatusChanged, []byte(`{"to":"accepted"}`), 1, now, nil).
+		AddRow(olderUUID, models.AggregateOrder, uuid.New(), models.EventOrderCreated, []byte(`{"status":"pending"}`), 2, now.Add(-time.Second), nil)
+
+	mock.ExpectQuery(claimPendingQuery).WithArgs(10, 30.0).WillReturnRows(rows)
+
+	events, err := outboxPGRepository.ClaimPending(context.Background(), 10, 30*time.Second)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	require.Equal(t, olderUUID, events[0].EventID)
+	require.JSONEq(t, `{"status":"pending"}`, string(events[0].Payload))
+	require.Equal(t, newerUUID, events[1].EventID)
+}
+
+func TestOutboxRepository_MarkPublished(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	outboxPGRepository := NewOutboxPGRepository(sqlxDB)
+
+	eventUUID := uuid.New()
+	mock.ExpectExec(markPublishedQuery).WithArgs(pq.Array([]string{eventUUID.String()})).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = outboxPGRepository.MarkPublished(context.Background(), []uuid.UUID{eventUUID})
+	require.NoError(t, err)
+
+	err = outboxPGRepository.MarkPublished(context.Background(), nil)
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/pkg/errors"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/internal/outbox"
+	"github.com/dinorain/kalobranded/pkg/logger"
+)
+
+const (
+	eventField     = "event"
+	eventTypeField = "event_type"
+)
+
+// Outbox redis streams repository
+type outboxRedisRepo struct {
+	redisClient *redis.Client
+	cfg         *config.Config
+	logger      logger.Logger
+}
+
+var _ outbox.OutboxRedisRepository = (*outboxRedisRepo)(nil)
+
+// Outbox redis streams repository constructor
+func NewOutboxRedisRepo(redisClient *redis.Client, cfg *config.Config, logger logger.Logger) *outboxRedisRepo {
+	return &outboxRedisRepo{redisClient: redisClient, cfg: cfg, logger: logger}
+}
+
+// AddCtx append event to stream, the stream is trimmed to about Outbox.MaxLen entries when it is set
+func (r *outboxRedisRepo) AddCtx(ctx context.Context, stream string, event *models.OutboxEvent) error {
+	eventBytes, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	return r.redisClient.XAdd(ctx, &redis.XAddArgs{
+		Stream: stream,
+		MaxLen: r.cfg.Outbox.MaxLen,
+		Approx: r.cfg.Outbox.MaxLen > 0,
+		Values: map[string]interface{}{eventTypeField: event.EventType, eventField: eventBytes},
+	}).Err()
+}
+
+// CreateGroupCtx create consumer group reading stream from its first entry, along with the stream when missing.
+// An existing group is left as is
+func (r *outboxRedisRepo) CreateGroupCtx(ctx context.Context, stream string, group string) error {
+	err := r.redisClient.XGroupCreateMkStream(ctx, stream, group, "0").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return err
+	}
+	return nil
+}
+
+// ReadGroupCtx read up to count entries never delivered to group, waiting up to block for one to arrive
+func (r *outboxRedisRepo) ReadGroupCtx(ctx context.Context, stream string, group string, consumer string, count int64, block time.Duration) ([]models.StreamMessage, error) {
+	streams, err := r.redisClient.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    group,
+		Consumer: consumer,
+		Streams:  []string{stream, ">"},
+		Count:    count,
+		Block:    block,
+	}).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var msgs []models.StreamMessage
+	for _, s := range streams {
+		for _, m := range s.Messages {
+			msg, err := messageFromStream(m, 1)
+			if err != nil {
+				return nil, err
+			}
+			msgs = append(msgs, msg)
+		}
+	}
+
+	return msgs, nil
+}
+
+// ClaimStaleCtx take over up to count entries delivered to group but left unacknowledged for minIdle, by a consumer
+// that failed them or died. Deliveries counts the delivery to consumer
+func (r *outboxRedisRepo) ClaimStaleCtx(ctx context.Context, stream string, group string, consumer string, minIdle time.Duration, count int64) ([]models.StreamMessage, error) {
+	pending, err := r.redisClient.XPendingExt(ctx, &redis.XPendingExtArgs{
+		Stream: stream,
+		Group:  group,
+		Idle:   minIdle,
+		Start:  "-",
+		End:    "+",
+		Count:  count,
+	}).Result()
+	if err != nil || len(pending) == 0 {
+		return nil, err
+	}
+
+	deliveries := make(map[string]int64, len(pending))
+	ids := make([]string, 0, len(pending))
+	for _, p := range pending {
+		deliveries[p.ID] = p.RetryCount + 1
+		ids = append(ids, p.ID)
+	}
+
+	claimed, err := r.redisClient.XClaim(ctx, &redis.XClaimArgs{
+		Stream:   stream,
+		Group:    group,
+		Consumer: consumer,
+		MinIdle:  minIdle,
+		Messages: ids,
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	msgs := make([]models.StreamMessage, 0, len(claimed))
+	for _, m := range claimed {
+		msg, err := messageFromStream(m, deliveries[m.ID])
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, msg)
+	}
+
+	return msgs, nil
+}
+
+// AckCtx acknowledge entries handled by group
+func (r *outboxRedisRepo) AckCtx(ctx context.Context, stream string, group string, ids ...string) error {
+	return r.redisClient.XAck(ctx, stream, group, ids...).Err()
+}
+
+// DeadLetterCtx copy msg group gave up on to the dead letter stream, with the reason it failed
+func (r *outboxRedisRepo) DeadLetterCtx(ctx context.Context, stream string, group string, msg *models.StreamMessage, reason string) error {
+	eventBytes, err := json.Marshal(msg.Event)
+	if err != nil {
+		return err
+	}
+
+	return r.redisClient.XAdd(ctx, &redis.XAddArgs{
+		Stream: stream,
+		Values: map[string]interface{}{
+			eventTypeField: msg.Event.EventType,
+			eventField:     eventBytes,
+			"group":        group,
+			"message_id":   msg.ID,
+			"deliveries":   msg.Deliveries,
+			"reason":       reason,
+		},
+	}).Err()
+}
+
+// messageFromStream decode the outbox event of a stream entry
+func messageFromStream(m redis.XMessage, deliveries int64) (models.StreamMessage, error) {
+	raw, ok := m.Values[eventField].(string)
+	if !ok {
+		return models.StreamMessage{}, fmt.Errorf("stream entry %s has no %s field", m.ID, eventField)
+	}
+
+	msg := models.StreamMessage{ID: m.ID, Deliveries: deliveries}
+	if err := json.Unmarshal([]byte(raw), &msg.Event); err != nil {
+		return models.StreamMessage{}, errors.Wrapf(err, "stream entry %s", m.ID)
+	}
+
+	return msg, nil
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/kalobranded/internal/models"
+)
+
+func TestMessageFromStream(t *testing.T) {
+	t.Parallel()
+
+	event, err := models.NewOutboxEvent(models.AggregateUser, uuid.New(), models.EventUserRegistered, map[string]string{"email": "buyer@kalobranded.test"})
+	require.NoError(t, err)
+
+	eventBytes, err := json.Marshal(event)
+	require.NoError(t, err)
+
+	msg, err := messageFromStream(redis.XMessage{
+		ID:     "1-0",
+		Values: map[string]interface{}{eventTypeField: event.EventType, eventField: string(eventBytes)},
+	}, 2)
+	require.NoError(t, err)
+	require.Equal(t, "1-0", msg.ID)
+	require.Equal(t, int64(2), msg.Deliveries)
+	require.Equal(t, event.EventID, msg.Event.EventID)
+	require.JSONEq(t, `{"email":"buyer@kalobranded.test"}`, string(msg.Event.Payload))
+
+	t.Run("NoEvent", func(t *testing.T) {
+		_, err := messageFromStream(redis.XMessage{ID: "2-0", Values: map[string]interface{}{}}, 1)
+		require.Error(t, err)
+	})
+}
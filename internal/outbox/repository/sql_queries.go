@@ -0,0 +1,15 @@
+package repository
+
+const (
+	// AddEventQuery is run by AddEvent within the transactions of other repositories
+	AddEventQuery = `INSERT INTO outbox (event_id, aggregate_type, aggregate_id, event_type, payload) VALUES ($1, $2, $3, $4, $5)`
+
+	claimPendingQuery = `UPDATE outbox SET locked_until = CURRENT_TIMESTAMP + $2 * INTERVAL '1 second', attempts = attempts + 1
+		WHERE event_id IN (
+			SELECT event_id FROM outbox WHERE published_at IS NULL AND (locked_until IS NULL OR locked_until < CURRENT_TIMESTAMP)
+			ORDER BY created_at LIMIT $1 FOR UPDATE SKIP LOCKED
+		)
+		RETURNING event_id, aggregate_type, aggregate_id, event_type, payload, attempts, created_at, published_at`
+
+	markPublishedQuery = `UPDATE outbox SET published_at = CURRENT_TIMESTAMP, locked_until = NULL WHERE event_id = ANY($1)`
+)
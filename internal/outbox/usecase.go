@@ -0,0 +1,18 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase.go -package mock
+package outbox
+
+import (
+	"context"
+
+	"github.com/dinorain/kalobranded/internal/models"
+)
+
+// Handler consumer of outbox events, an event it fails is delivered again until it runs out of deliveries
+type Handler func(ctx context.Context, event *models.OutboxEvent) error
+
+// Outbox UseCase interface
+type OutboxUseCase interface {
+	Relay(ctx context.Context) (int, error)
+	Subscribe(ctx context.Context, group string) error
+	Consume(ctx context.Context, group string, consumer string, handler Handler) (int, error)
+}
@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/outbox"
+	"github.com/dinorain/kalobranded/pkg/logger"
+)
+
+const (
+	defaultStream        = "events"
+	defaultBatchSize     = 100
+	defaultLease         = 30 * time.Second
+	defaultBlock         = 5 * time.Second
+	defaultClaimIdle     = time.Minute
+	defaultMaxDeliveries = 5
+)
+
+// Outbox UseCase
+type outboxUseCase struct {
+	cfg             *config.Config
+	logger          logger.Logger
+	outboxPgRepo    outbox.OutboxPGRepository
+	outboxRedisRepo outbox.OutboxRedisRepository
+}
+
+var _ outbox.OutboxUseCase = (*outboxUseCase)(nil)
+
+// New Outbox UseCase
+func NewOutboxUseCase(
+	cfg *config.Config,
+	logger logger.Logger,
+	outboxPgRepo outbox.OutboxPGRepository,
+	outboxRedisRepo outbox.OutboxRedisRepository,
+) *outboxUseCase {
+	return &outboxUseCase{cfg: cfg, logger: logger, outboxPgRepo: outboxPgRepo, outboxRedisRepo: outboxRedisRepo}
+}
+
+// Relay publish a batch of pending outbox events to the stream, oldest first, and report how many were published.
+// An event is marked published only once it is on the stream, so a relay failing in between publishes it again
+func (u *outboxUseCase) Relay(ctx context.Context) (int, error) {
+	events, err := u.outboxPgRepo.ClaimPending(ctx, u.getBatchSize(), u.getLease())
+	if err != nil {
+		return 0, errors.Wrap(err, "outboxPgRepo.ClaimPending")
+	}
+
+	published := make([]uuid.UUID, 0, len(events))
+	var publishErr error
+	for i := range events {
+		if err := u.outboxRedisRepo.AddCtx(ctx, u.getStream(), &events[i]); err != nil {
+			// later events wait for this one, so consumers see the events of an aggregate in order
+			publishErr = errors.Wrapf(err, "outboxRedisRepo.AddCtx %s", events[i].EventID)
+			break
+		}
+		published = append(published, events[i].EventID)
+	}
+
+	if err := u.outboxPgRepo.MarkPublished(ctx, published); err != nil {
+		return 0, errors.Wrap(err, "outboxPgRepo.MarkPublished")
+	}
+
+	return len(published), publishErr
+}
+
+// Subscribe create the consumer group reading the stream
+func (u *outboxUseCase) Subscribe(ctx context.Context, group string) error {
+	if err := u.outboxRedisRepo.CreateGroupCtx(ctx, u.getStream(), group); err != nil {
+		return errors.Wrap(err, "outboxRedisRepo.CreateGroupCtx")
+	}
+	return nil
+}
+
+// Consume hand a batch of events to handler as consumer of group and report how many were handled. Events left
+// unacknowledged for Outbox.ClaimIdle, because their handler failed or their consumer died, are delivered again,
+// up to Outbox.MaxDeliveries times before they are moved to the dead letter stream. Handlers may see an event more
+// than once and should be idempotent on its EventID
+func (u *outboxUseCase) Consume(ctx context.Context, group string, consumer string, handler outbox.Handler) (int, error) {
+	stream := u.getStream()
+
+	msgs, err := u.outboxRedisRepo.ClaimStaleCtx(ctx, stream, group, consumer, u.getClaimIdle(), int64(u.getBatchSize()))
+	if err != nil {
+		return 0, errors.Wrap(err, "outboxRedisRepo.ClaimStaleCtx")
+	}
+	if len(msgs) == 0 {
+		msgs, err = u.outboxRedisRepo.ReadGroupCtx(ctx, stream, group, consumer, int64(u.getBatchSize()), u.getBlock())
+		if err != nil {
+			return 0, errors.Wrap(err, "outboxRedisRepo.ReadGroupCtx")
+		}
+	}
+
+	handled := 0
+	for i := range msgs {
+		msg := &msgs[i]
+
+		if msg.Deliveries > u.getMaxDeliveries() {
+			if err := u.outboxRedisRepo.DeadLetterCtx(ctx, u.getDeadLetterStream(), group, msg, "max deliveries exceeded"); err != nil {
+				return handled, errors.Wrapf(err, "outboxRedisRepo.DeadLetterCtx %s", msg.ID)
+			}
+			u.logger.Warnf("outbox event %s %s dead lettered by %s after %d deliveries", msg.Event.EventType, msg.Event.EventID, group, msg.Deliveries-1)
+		} else {
+			if err := handler(ctx, &msg.Event); err != nil {
+				u.logger.Warnf("outbox event %s %s failed in %s, delivery %d: %v", msg.Event.EventType, msg.Event.EventID, group, msg.Deliveries, err)
+				continue
+			}
+			handled++
+		}
+
+		if err := u.outboxRedisRepo.AckCtx(ctx, stream, group, msg.ID); err != nil {
+			return handled, errors.Wrapf(err, "outboxRedisRepo.AckCtx %s", msg.ID)
+		}
+	}
+
+	return handled, nil
+}
+
+func (u *outboxUseCase) getStream() string {
+	if u.cfg.Outbox.Stream != "" {
+		return u.cfg.Outbox.Stream
+	}
+	return defaultStream
+}
+
+func (u *outboxUseCase) getDeadLetterStream() string {
+	if u.cfg.Outbox.DeadLetterStream != "" {
+		return u.cfg.Outbox.DeadLetterStream
+	}
+	return u.getStream() + ":dead"
+}
+
+func (u *outboxUseCase) getBatchSize() int {
+	if u.cfg.Outbox.BatchSize > 0 {
+		return u.cfg.Outbox.BatchSize
+	}
+	return defaultBatchSize
+}
+
+func (u *outboxUseCase) getLease() time.Duration {
+	if u.cfg.Outbox.Lease > 0 {
+		return u.cfg.Outbox.Lease
+	}
+	return defaultLease
+}
+
+func (u *outboxUseCase) getBlock() time.Duration {
+	if u.cfg.Outbox.Block > 0 {
+		return u.cfg.Outbox.Block
+	}
+	return defaultBlock
+}
+
+func (u *outboxUseCase) getClaimIdle() time.Duration {
+	if u.cfg.Outbox.ClaimIdle > 0 {
+		return u.cfg.Outbox.ClaimIdle
+	}
+	return defaultClaimIdle
+}
+
+func (u *outboxUseCase) getMaxDeliveries() int64 {
+	if u.cfg.Outbox.MaxDeliveries > 0 {
+		return u.cfg.Outbox.MaxDeliveries
+	}
+	return defaultMaxDeliveries
+}
@@ -0,0 +1,135 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/internal/outbox/mock"
+	"github.com/dinorain/kalobranded/pkg/logger"
+)
+
+func TestOutboxUseCase_Relay(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	outboxPGRepository := mock.NewMockOutboxPGRepository(ctrl)
+	outboxRedisRepository := mock.NewMockOutboxRedisRepository(ctrl)
+	apiLogger := logger.NewAppLogger(nil)
+
+	cfg := &config.Config{Outbox: config.Outbox{Stream: "events", BatchSize: 10, Lease: time.Minute}}
+	outboxUC := NewOutboxUseCase(cfg, apiLogger, outboxPGRepository, outboxRedisRepository)
+
+	events := []models.OutboxEvent{
+		{EventID: uuid.New(), AggregateType: models.AggregateOrder, AggregateID: uuid.New(), EventType: models.EventOrderCreated},
+		{EventID: uuid.New(), AggregateType: models.AggregateOrder, AggregateID: uuid.New(), EventType: models.EventOrderStatusChanged},
+	}
+
+	ctx := context.Background()
+
+	outboxPGRepository.EXPECT().ClaimPending(gomock.Any(), 10, time.Minute).Return(events, nil)
+	outboxRedisRepository.EXPECT().AddCtx(gomock.Any(), "events", &events[0]).Return(nil)
+	outboxRedisRepository.EXPECT().AddCtx(gomock.Any(), "events", &events[1]).Return(nil)
+	outboxPGRepository.EXPECT().MarkPublished(gomock.Any(), []uuid.UUID{events[0].EventID, events[1].EventID}).Return(nil)
+
+	published, err := outboxUC.Relay(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, published)
+
+	t.Run("StreamDown", func(t *testing.T) {
+		outboxPGRepository.EXPECT().ClaimPending(gomock.Any(), 10, time.Minute).Return(events, nil)
+		outboxRedisRepository.EXPECT().AddCtx(gomock.Any(), "events", &events[0]).Return(nil)
+		outboxRedisRepository.EXPECT().AddCtx(gomock.Any(), "events", &events[1]).Return(errors.New("connection refused"))
+		outboxPGRepository.EXPECT().MarkPublished(gomock.Any(), []uuid.UUID{events[0].EventID}).Return(nil)
+
+		published, err := outboxUC.Relay(ctx)
+		require.Error(t, err)
+		require.Equal(t, 1, published)
+	})
+}
+
+func TestOutboxUseCase_Consume(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	outboxPGRepository := mock.NewMockOutboxPGRepository(ctrl)
+	outboxRedisRepository := mock.NewMockOutboxRedisRepository(ctrl)
+
+	cfg := &config.Config{Outbox: config.Outbox{Stream: "events", DeadLetterStream: "events:dead", BatchSize: 10, Block: time.Second, ClaimIdle: time.Minute, MaxDeliveries: 3}}
+	apiLogger := logger.NewAppLogger(cfg)
+	apiLogger.InitLogger()
+	outboxUC := NewOutboxUseCase(cfg, apiLogger, outboxPGRepository, outboxRedisRepository)
+
+	msg := models.StreamMessage{
+		ID:         "1-0",
+		Event:      models.OutboxEvent{EventID: uuid.New(), EventType: models.EventOrderCreated},
+		Deliveries: 1,
+	}
+
+	ctx := context.Background()
+
+	var handledEvents []uuid.UUID
+	handler := func(ctx context.Context, event *models.OutboxEvent) error {
+		handledEvents = append(handledEvents, event.EventID)
+		return nil
+	}
+
+	outboxRedisRepository.EXPECT().ClaimStaleCtx(gomock.Any(), "events", "audit", "api-1", time.Minute, int64(10)).Return(nil, nil)
+	outboxRedisRepository.EXPECT().ReadGroupCtx(gomock.Any(), "events", "audit", "api-1", int64(10), time.Second).Return([]models.StreamMessage{msg}, nil)
+	outboxRedisRepository.EXPECT().AckCtx(gomock.Any(), "events", "audit", "1-0").Return(nil)
+
+	handled, err := outboxUC.Consume(ctx, "audit", "api-1", handler)
+	require.NoError(t, err)
+	require.Equal(t, 1, handled)
+	require.Equal(t, []uuid.UUID{msg.Event.EventID}, handledEvents)
+
+	t.Run("HandlerFailed", func(t *testing.T) {
+		outboxRedisRepository.EXPECT().ClaimStaleCtx(gomock.Any(), "events", "audit", "api-1", time.Minute, int64(10)).Return(nil, nil)
+		outboxRedisRepository.EXPECT().ReadGroupCtx(gomock.Any(), "events", "audit", "api-1", int64(10), time.Second).Return([]models.StreamMessage{msg}, nil)
+
+		handled, err := outboxUC.Consume(ctx, "audit", "api-1", func(ctx context.Context, event *models.OutboxEvent) error {
+			return errors.New("audit log unavailable")
+		})
+		require.NoError(t, err)
+		require.Equal(t, 0, handled)
+	})
+
+	t.Run("Redelivered", func(t *testing.T) {
+		staleMsg := msg
+		staleMsg.Deliveries = 3
+
+		outboxRedisRepository.EXPECT().ClaimStaleCtx(gomock.Any(), "events", "audit", "api-1", time.Minute, int64(10)).Return([]models.StreamMessage{staleMsg}, nil)
+		outboxRedisRepository.EXPECT().AckCtx(gomock.Any(), "events", "audit", "1-0").Return(nil)
+
+		handled, err := outboxUC.Consume(ctx, "audit", "api-1", handler)
+		require.NoError(t, err)
+		require.Equal(t, 1, handled)
+	})
+
+	t.Run("DeadLetter", func(t *testing.T) {
+		deadMsg := msg
+		deadMsg.Deliveries = 4
+
+		outboxRedisRepository.EXPECT().ClaimStaleCtx(gomock.Any(), "events", "audit", "api-1", time.Minute, int64(10)).Return([]models.StreamMessage{deadMsg}, nil)
+		outboxRedisRepository.EXPECT().DeadLetterCtx(gomock.Any(), "events:dead", "audit", &deadMsg, gomock.Any()).Return(nil)
+		outboxRedisRepository.EXPECT().AckCtx(gomock.Any(), "events", "audit", "1-0").Return(nil)
+
+		handled, err := outboxUC.Consume(ctx, "audit", "api-1", func(ctx context.Context, event *models.OutboxEvent) error {
+			t.Fatal("dead lettered event handed to handler")
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, 0, handled)
+	})
+}
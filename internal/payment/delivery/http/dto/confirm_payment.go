@@ -0,0 +1,5 @@
+package dto
+
+type PaymentConfirmRequestDto struct {
+	Decline bool `json:"decline"`
+}
@@ -0,0 +1,5 @@
+package dto
+
+type PaymentFindResponseDto struct {
+	Data []*PaymentResponseDto `json:"data"`
+}
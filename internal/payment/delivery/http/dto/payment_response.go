@@ -0,0 +1,37 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/internal/models"
+)
+
+type PaymentResponseDto struct {
+	PaymentID         uuid.UUID `json:"payment_id"`
+	OrderID           uuid.UUID `json:"order_id"`
+	Provider          string    `json:"provider"`
+	ProviderPaymentID string    `json:"provider_payment_id"`
+	Amount            float64   `json:"amount"`
+	Currency          string    `json:"currency"`
+	Status            string    `json:"status"`
+	ClientSecret      string    `json:"client_secret,omitempty"`
+	CreatedAt         time.Time `json:"created_at,omitempty"`
+	UpdatedAt         time.Time `json:"updated_at,omitempty"`
+}
+
+func PaymentResponseFromModel(payment *models.Payment) *PaymentResponseDto {
+	return &PaymentResponseDto{
+		PaymentID:         payment.PaymentID,
+		OrderID:           payment.OrderID,
+		Provider:          payment.Provider,
+		ProviderPaymentID: payment.ProviderPaymentID,
+		Amount:            payment.Amount,
+		Currency:          payment.Currency,
+		Status:            payment.Status,
+		ClientSecret:      payment.ClientSecret,
+		CreatedAt:         payment.CreatedAt,
+		UpdatedAt:         payment.UpdatedAt,
+	}
+}
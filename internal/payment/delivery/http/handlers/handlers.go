@@ -0,0 +1,239 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/middlewares"
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/internal/order"
+	"github.com/dinorain/kalobranded/internal/payment"
+	"github.com/dinorain/kalobranded/internal/payment/delivery/http/dto"
+	"github.com/dinorain/kalobranded/internal/server/router"
+	"github.com/dinorain/kalobranded/pkg/constants"
+	httpErrors "github.com/dinorain/kalobranded/pkg/http_errors"
+	"github.com/dinorain/kalobranded/pkg/logger"
+)
+
+const (
+	maxWebhookBytes = 1 << 20
+)
+
+type paymentHandlersHTTP struct {
+	router    *router.Router
+	logger    logger.Logger
+	cfg       *config.Config
+	mw        middlewares.MiddlewareManager
+	v         *validator.Validate
+	paymentUC payment.PaymentUseCase
+	orderUC   order.OrderUseCase
+}
+
+var _ payment.PaymentHandlers = (*paymentHandlersHTTP)(nil)
+
+func NewPaymentHandlersHTTP(
+	router *router.Router,
+	logger logger.Logger,
+	cfg *config.Config,
+	mw middlewares.MiddlewareManager,
+	v *validator.Validate,
+	paymentUC payment.PaymentUseCase,
+	orderUC order.OrderUseCase,
+) *paymentHandlersHTTP {
+	return &paymentHandlersHTTP{router: router, logger: logger, cfg: cfg, mw: mw, v: v, paymentUC: paymentUC, orderUC: orderUC}
+}
+
+// Create
+// @Tags Payments
+// @Summary Pay order
+// @Description Create a payment for a pending order, the client secret is used to confirm it with the provider
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "order uuid"
+// @Success 201 {object} dto.PaymentResponseDto
+// @Router /orders/{id}/payments [post]
+func (h *paymentHandlersHTTP) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	order, err := h.findOwnOrder(w, r, router.Param(r, constants.ID))
+	if err != nil {
+		return
+	}
+
+	createdPayment, err := h.paymentUC.Create(ctx, order)
+	if err != nil {
+		h.logger.Errorf("paymentUC.Create: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	res, _ := json.Marshal(dto.PaymentResponseFromModel(createdPayment))
+	w.WriteHeader(http.StatusCreated)
+	w.Write(res)
+	return
+}
+
+// FindAllByOrderId
+// @Tags Payments
+// @Summary Find order payments
+// @Description Find payments of an order, users can only find payments of their own orders
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "order uuid"
+// @Success 200 {object} dto.PaymentFindResponseDto
+// @Router /orders/{id}/payments [get]
+func (h *paymentHandlersHTTP) FindAllByOrderId(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	order, err := h.findOwnOrder(w, r, router.Param(r, constants.ID))
+	if err != nil {
+		return
+	}
+
+	payments, err := h.paymentUC.FindAllByOrderId(ctx, order.OrderID)
+	if err != nil {
+		h.logger.Errorf("paymentUC.FindAllByOrderId: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	resDto := dto.PaymentFindResponseDto{Data: make([]*dto.PaymentResponseDto, 0, len(payments))}
+	for i := range payments {
+		resDto.Data = append(resDto.Data, dto.PaymentResponseFromModel(&payments[i]))
+	}
+
+	res, _ := json.Marshal(resDto)
+	w.WriteHeader(http.StatusOK)
+	w.Write(res)
+	return
+}
+
+// Confirm
+// @Tags Payments
+// @Summary Confirm payment
+// @Description Simulate the customer confirming the payment, only registered in payment test mode with test gateways such as the fake provider
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "payment uuid"
+// @Param payload body dto.PaymentConfirmRequestDto false "Payload"
+// @Success 200 {object} dto.PaymentResponseDto
+// @Router /payments/{id}/confirm [post]
+func (h *paymentHandlersHTTP) Confirm(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	paymentUUID, err := uuid.Parse(router.Param(r, constants.ID))
+	if err != nil {
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	confirmDto := &dto.PaymentConfirmRequestDto{}
+	if err := json.NewDecoder(r.Body).Decode(confirmDto); err != nil && !errors.Is(err, io.EOF) {
+		h.logger.Errorf("decoder.Decode: %v", err)
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	foundPayment, err := h.paymentUC.FindById(ctx, paymentUUID)
+	if err != nil {
+		h.logger.Errorf("paymentUC.FindById: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	if _, err := h.findOwnOrder(w, r, foundPayment.OrderID.String()); err != nil {
+		return
+	}
+
+	confirmedPayment, err := h.paymentUC.Confirm(ctx, foundPayment, !confirmDto.Decline)
+	if err != nil {
+		h.logger.Errorf("paymentUC.Confirm: %v", err)
+		if errors.Is(err, payment.ErrConfirmUnsupported) {
+			_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+			return
+		}
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	res, _ := json.Marshal(dto.PaymentResponseFromModel(confirmedPayment))
+	w.WriteHeader(http.StatusOK)
+	w.Write(res)
+	return
+}
+
+// Webhook
+// @Tags Payments
+// @Summary Payment provider webhook
+// @Description Receive payment events signed by the provider, a captured payment marks its order paid
+// @Accept json
+// @Produce json
+// @Param Payment-Signature header string true "hex HMAC-SHA256 of the body"
+// @Success 200 {object} dto.PaymentResponseDto
+// @Router /payments/webhook [post]
+func (h *paymentHandlersHTTP) Webhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	payload, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBytes))
+	if err != nil {
+		h.logger.Errorf("ioutil.ReadAll: %v", err)
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	handledPayment, err := h.paymentUC.HandleWebhook(ctx, payload, r.Header.Get(constants.PaymentSignature))
+	if err != nil {
+		h.logger.Errorf("paymentUC.HandleWebhook: %v", err)
+		if errors.Is(err, payment.ErrInvalidSignature) {
+			_ = httpErrors.NewUnauthorizedError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+			return
+		}
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	res, _ := json.Marshal(dto.PaymentResponseFromModel(handledPayment))
+	w.WriteHeader(http.StatusOK)
+	w.Write(res)
+	return
+}
+
+// findOwnOrder find order by id, users can only act on their own orders, error response is already written when err is not nil
+func (h *paymentHandlersHTTP) findOwnOrder(w http.ResponseWriter, r *http.Request, orderID string) (*models.Order, error) {
+	orderUUID, err := uuid.Parse(orderID)
+	if err != nil {
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return nil, err
+	}
+
+	jwtClaims, err := h.mw.GetJWTClaims(w, r)
+	if err != nil {
+		return nil, err
+	}
+	claims := *jwtClaims
+	userID, _ := claims["user_id"].(string)
+	role, _ := claims["role"].(string)
+
+	order, err := h.orderUC.FindById(r.Context(), orderUUID)
+	if err != nil {
+		h.logger.Errorf("orderUC.FindById: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return nil, err
+	}
+
+	if role != models.UserRoleAdmin && order.UserID.String() != userID {
+		return nil, httpErrors.NewForbiddenError(w, nil, h.cfg.Http.DebugErrorsResponse)
+	}
+
+	return order, nil
+}
@@ -0,0 +1,215 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/golang-jwt/jwt"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/middlewares"
+	"github.com/dinorain/kalobranded/internal/models"
+	mockOrderUC "github.com/dinorain/kalobranded/internal/order/mock"
+	"github.com/dinorain/kalobranded/internal/payment"
+	"github.com/dinorain/kalobranded/internal/payment/delivery/http/dto"
+	"github.com/dinorain/kalobranded/internal/payment/mock"
+	"github.com/dinorain/kalobranded/internal/server/router"
+	"github.com/dinorain/kalobranded/pkg/constants"
+	"github.com/dinorain/kalobranded/pkg/logger"
+)
+
+func signedToken(t *testing.T, cfg *config.Config, userUUID uuid.UUID, role string) string {
+	token := jwt.New(jwt.SigningMethodHS256)
+	claims := token.Claims.(jwt.MapClaims)
+	claims["session_id"] = uuid.New().String()
+	claims["user_id"] = userUUID.String()
+	claims["role"] = role
+	claims["exp"] = time.Now().Add(time.Minute * 15).Unix()
+	validToken, err := token.SignedString([]byte(cfg.Server.JwtSecretKey))
+	require.NoError(t, err)
+	return validToken
+}
+
+func TestPaymentsHandler_Create(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	paymentUC := mock.NewMockPaymentUseCase(ctrl)
+	orderUC := mockOrderUC.NewMockOrderUseCase(ctrl)
+
+	cfg := &config.Config{Session: config.Session{Expire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	appLogger := logger.NewAppLogger(cfg)
+	mw := middlewares.NewMiddlewareManager(appLogger, cfg)
+
+	v := validator.New()
+
+	rt := router.NewRouter(false)
+	handlers := NewPaymentHandlersHTTP(rt, appLogger, cfg, mw, v, paymentUC, orderUC)
+
+	userUUID := uuid.New()
+	orderUUID := uuid.New()
+	paymentUUID := uuid.New()
+	mockOrder := &models.Order{OrderID: orderUUID, UserID: userUUID, TotalPrice: 20000.0, Status: models.OrderStatusPending}
+
+	t.Run("Created", func(t *testing.T) {
+		req := router.WithParams(httptest.NewRequest(http.MethodPost, "/orders/"+orderUUID.String()+"/payments", nil), map[string]string{"id": orderUUID.String()})
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", signedToken(t, cfg, userUUID, models.UserRoleUser)))
+		w := httptest.NewRecorder()
+
+		orderUC.EXPECT().FindById(gomock.Any(), orderUUID).Return(mockOrder, nil)
+		paymentUC.EXPECT().Create(gomock.Any(), mockOrder).Return(&models.Payment{
+			PaymentID:    paymentUUID,
+			OrderID:      orderUUID,
+			Amount:       20000.0,
+			Status:       models.PaymentStatusPending,
+			ClientSecret: "pi_fake_000001_secret",
+		}, nil)
+
+		http.HandlerFunc(handlers.Create).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusCreated, w.Code)
+		resDto := &dto.PaymentResponseDto{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), resDto))
+		require.Equal(t, paymentUUID, resDto.PaymentID)
+		require.Equal(t, "pi_fake_000001_secret", resDto.ClientSecret)
+	})
+
+	t.Run("Forbidden", func(t *testing.T) {
+		req := router.WithParams(httptest.NewRequest(http.MethodPost, "/orders/"+orderUUID.String()+"/payments", nil), map[string]string{"id": orderUUID.String()})
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", signedToken(t, cfg, uuid.New(), models.UserRoleUser)))
+		w := httptest.NewRecorder()
+
+		orderUC.EXPECT().FindById(gomock.Any(), orderUUID).Return(mockOrder, nil)
+
+		http.HandlerFunc(handlers.Create).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
+
+func TestPaymentsHandler_Confirm(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	paymentUC := mock.NewMockPaymentUseCase(ctrl)
+	orderUC := mockOrderUC.NewMockOrderUseCase(ctrl)
+
+	cfg := &config.Config{Session: config.Session{Expire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	appLogger := logger.NewAppLogger(cfg)
+	appLogger.InitLogger()
+	mw := middlewares.NewMiddlewareManager(appLogger, cfg)
+
+	v := validator.New()
+
+	rt := router.NewRouter(false)
+	handlers := NewPaymentHandlersHTTP(rt, appLogger, cfg, mw, v, paymentUC, orderUC)
+
+	userUUID := uuid.New()
+	orderUUID := uuid.New()
+	paymentUUID := uuid.New()
+	mockPayment := &models.Payment{PaymentID: paymentUUID, OrderID: orderUUID, Status: models.PaymentStatusPending}
+
+	t.Run("Captured", func(t *testing.T) {
+		req := router.WithParams(httptest.NewRequest(http.MethodPost, "/payments/"+paymentUUID.String()+"/confirm", nil), map[string]string{"id": paymentUUID.String()})
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", signedToken(t, cfg, userUUID, models.UserRoleUser)))
+		w := httptest.NewRecorder()
+
+		paymentUC.EXPECT().FindById(gomock.Any(), paymentUUID).Return(mockPayment, nil)
+		orderUC.EXPECT().FindById(gomock.Any(), orderUUID).Return(&models.Order{OrderID: orderUUID, UserID: userUUID}, nil)
+		paymentUC.EXPECT().Confirm(gomock.Any(), mockPayment, true).Return(&models.Payment{PaymentID: paymentUUID, OrderID: orderUUID, Status: models.PaymentStatusCaptured}, nil)
+
+		http.HandlerFunc(handlers.Confirm).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		resDto := &dto.PaymentResponseDto{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), resDto))
+		require.Equal(t, models.PaymentStatusCaptured, resDto.Status)
+	})
+
+	t.Run("Declined", func(t *testing.T) {
+		req := router.WithParams(httptest.NewRequest(http.MethodPost, "/payments/"+paymentUUID.String()+"/confirm", strings.NewReader(`{"decline": true}`)), map[string]string{"id": paymentUUID.String()})
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", signedToken(t, cfg, userUUID, models.UserRoleUser)))
+		w := httptest.NewRecorder()
+
+		paymentUC.EXPECT().FindById(gomock.Any(), paymentUUID).Return(mockPayment, nil)
+		orderUC.EXPECT().FindById(gomock.Any(), orderUUID).Return(&models.Order{OrderID: orderUUID, UserID: userUUID}, nil)
+		paymentUC.EXPECT().Confirm(gomock.Any(), mockPayment, false).Return(&models.Payment{PaymentID: paymentUUID, OrderID: orderUUID, Status: models.PaymentStatusFailed}, nil)
+
+		http.HandlerFunc(handlers.Confirm).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Unsupported", func(t *testing.T) {
+		req := router.WithParams(httptest.NewRequest(http.MethodPost, "/payments/"+paymentUUID.String()+"/confirm", nil), map[string]string{"id": paymentUUID.String()})
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", signedToken(t, cfg, userUUID, models.UserRoleAdmin)))
+		w := httptest.NewRecorder()
+
+		paymentUC.EXPECT().FindById(gomock.Any(), paymentUUID).Return(mockPayment, nil)
+		orderUC.EXPECT().FindById(gomock.Any(), orderUUID).Return(&models.Order{OrderID: orderUUID, UserID: uuid.New()}, nil)
+		paymentUC.EXPECT().Confirm(gomock.Any(), mockPayment, true).Return(nil, payment.ErrConfirmUnsupported)
+
+		http.HandlerFunc(handlers.Confirm).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestPaymentsHandler_Webhook(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	paymentUC := mock.NewMockPaymentUseCase(ctrl)
+	orderUC := mockOrderUC.NewMockOrderUseCase(ctrl)
+
+	cfg := &config.Config{Session: config.Session{Expire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	appLogger := logger.NewAppLogger(cfg)
+	appLogger.InitLogger()
+	mw := middlewares.NewMiddlewareManager(appLogger, cfg)
+
+	v := validator.New()
+
+	rt := router.NewRouter(false)
+	handlers := NewPaymentHandlersHTTP(rt, appLogger, cfg, mw, v, paymentUC, orderUC)
+
+	payload := `{"id":"evt_fake_000001","type":"payment.authorized","intent_id":"pi_fake_000001","amount":20000}`
+
+	t.Run("Handled", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/payments/webhook", strings.NewReader(payload))
+		req.Header.Set(constants.PaymentSignature, "signature")
+		w := httptest.NewRecorder()
+
+		paymentUC.EXPECT().HandleWebhook(gomock.Any(), []byte(payload), "signature").Return(&models.Payment{PaymentID: uuid.New(), Status: models.PaymentStatusCaptured}, nil)
+
+		http.HandlerFunc(handlers.Webhook).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("InvalidSignature", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/payments/webhook", strings.NewReader(payload))
+		req.Header.Set(constants.PaymentSignature, "forged")
+		w := httptest.NewRecorder()
+
+		paymentUC.EXPECT().HandleWebhook(gomock.Any(), []byte(payload), "forged").Return(nil, payment.ErrInvalidSignature)
+
+		http.HandlerFunc(handlers.Webhook).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
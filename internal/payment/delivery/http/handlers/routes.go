@@ -0,0 +1,14 @@
+package handlers
+
+func (h *paymentHandlersHTTP) PaymentMapRoutes() {
+	orderPayments := h.router.Group("/orders/{id}/payments", h.mw.IsLoggedIn)
+	orderPayments.Post("", h.Create, h.mw.Idempotent)
+	orderPayments.Get("", h.FindAllByOrderId)
+
+	payments := h.router.Group("/payments")
+	payments.Post("/webhook", h.Webhook)
+	// buyers confirming their own payments is a stand-in for the provider, in test mode only
+	if h.cfg.Payment.TestMode {
+		payments.Post("/{id}/confirm", h.Confirm, h.mw.IsLoggedIn)
+	}
+}
@@ -0,0 +1,160 @@
+package fake
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/internal/payment"
+)
+
+const ProviderName = "fake"
+
+// Provider deterministic in-process payment gateway, ids are sequential and nothing leaves the process
+type Provider struct {
+	secret string
+
+	mu      sync.Mutex
+	seq     int
+	intents map[string]*payment.Intent
+	byOrder map[uuid.UUID]string
+	refunds map[string]*payment.Refund
+}
+
+var (
+	_ payment.PaymentProvider = (*Provider)(nil)
+	_ payment.Confirmer       = (*Provider)(nil)
+)
+
+// NewProvider fake gateway constructor, webhook events are signed with webhookSecret
+func NewProvider(webhookSecret string) *Provider {
+	return &Provider{
+		secret:  webhookSecret,
+		intents: map[string]*payment.Intent{},
+		byOrder: map[uuid.UUID]string{},
+		refunds: map[string]*payment.Refund{},
+	}
+}
+
+// Name provider name stored on payments
+func (f *Provider) Name() string {
+	return ProviderName
+}
+
+// CreateIntent create or return the open intent of the order
+func (f *Provider) CreateIntent(ctx context.Context, orderID uuid.UUID, amount float64, currency string) (*payment.Intent, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if id, ok := f.byOrder[orderID]; ok && f.intents[id].Status != payment.IntentStatusFailed {
+		return f.copyIntent(id), nil
+	}
+
+	id := f.nextID("pi")
+	f.intents[id] = &payment.Intent{
+		ID:           id,
+		OrderID:      orderID,
+		Amount:       amount,
+		Currency:     currency,
+		Status:       payment.IntentStatusRequiresConfirmation,
+		ClientSecret: id + "_secret",
+	}
+	f.byOrder[orderID] = id
+
+	return f.copyIntent(id), nil
+}
+
+// Confirm simulate the customer confirming the intent, succeed false simulates a declined payment
+func (f *Provider) Confirm(ctx context.Context, intentID string, succeed bool) ([]byte, string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	intent, ok := f.intents[intentID]
+	if !ok {
+		return nil, "", payment.ErrIntentNotFound
+	}
+	if intent.Status != payment.IntentStatusRequiresConfirmation {
+		return nil, "", fmt.Errorf("payment intent %s is %s", intentID, intent.Status)
+	}
+
+	event := &payment.Event{ID: f.nextID("evt"), Type: payment.EventPaymentAuthorized, IntentID: intentID, Amount: intent.Amount}
+	intent.Status = payment.IntentStatusRequiresCapture
+	if !succeed {
+		event.Type = payment.EventPaymentFailed
+		intent.Status = payment.IntentStatusFailed
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, "", err
+	}
+	return payload, payment.Sign(f.secret, payload), nil
+}
+
+// Capture capture an authorized intent
+func (f *Provider) Capture(ctx context.Context, intentID string) (*payment.Intent, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	intent, ok := f.intents[intentID]
+	if !ok {
+		return nil, payment.ErrIntentNotFound
+	}
+	switch intent.Status {
+	case payment.IntentStatusSucceeded:
+	case payment.IntentStatusRequiresCapture:
+		intent.Status = payment.IntentStatusSucceeded
+	default:
+		return nil, payment.ErrIntentNotCapturable
+	}
+
+	return f.copyIntent(intentID), nil
+}
+
+// Refund refund part or all of a captured intent, a repeated idempotencyKey returns the first refund
+func (f *Provider) Refund(ctx context.Context, intentID string, amount float64, idempotencyKey string) (*payment.Refund, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if refund, ok := f.refunds[idempotencyKey]; ok {
+		copied := *refund
+		return &copied, nil
+	}
+
+	intent, ok := f.intents[intentID]
+	if !ok {
+		return nil, payment.ErrIntentNotFound
+	}
+	if intent.Status != payment.IntentStatusSucceeded {
+		return nil, payment.ErrIntentNotCapturable
+	}
+	if amount <= 0 || intent.RefundedAmount+amount > intent.Amount {
+		return nil, payment.ErrRefundExceedsAmount
+	}
+	intent.RefundedAmount += amount
+
+	refund := &payment.Refund{ID: f.nextID("re"), IntentID: intentID, Amount: amount}
+	if idempotencyKey != "" {
+		f.refunds[idempotencyKey] = refund
+	}
+	copied := *refund
+	return &copied, nil
+}
+
+// VerifyWebhook verify an event signed by Confirm
+func (f *Provider) VerifyWebhook(payload []byte, signature string) (*payment.Event, error) {
+	return payment.VerifyEvent(f.secret, payload, signature)
+}
+
+func (f *Provider) nextID(prefix string) string {
+	f.seq++
+	return fmt.Sprintf("%s_fake_%06d", prefix, f.seq)
+}
+
+func (f *Provider) copyIntent(id string) *payment.Intent {
+	intent := *f.intents[id]
+	return &intent
+}
@@ -0,0 +1,85 @@
+package fake
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/kalobranded/internal/payment"
+)
+
+func TestProvider_Flow(t *testing.T) {
+	t.Parallel()
+
+	p := NewProvider("secret")
+	ctx := context.Background()
+	orderUUID := uuid.New()
+
+	intent, err := p.CreateIntent(ctx, orderUUID, 20000, "IDR")
+	require.NoError(t, err)
+	require.Equal(t, "pi_fake_000001", intent.ID)
+	require.Equal(t, payment.IntentStatusRequiresConfirmation, intent.Status)
+
+	t.Run("Idempotent", func(t *testing.T) {
+		again, err := p.CreateIntent(ctx, orderUUID, 20000, "IDR")
+		require.NoError(t, err)
+		require.Equal(t, intent.ID, again.ID)
+	})
+
+	_, err = p.Capture(ctx, intent.ID)
+	require.ErrorIs(t, err, payment.ErrIntentNotCapturable)
+
+	payload, signature, err := p.Confirm(ctx, intent.ID, true)
+	require.NoError(t, err)
+
+	event, err := p.VerifyWebhook(payload, signature)
+	require.NoError(t, err)
+	require.Equal(t, payment.EventPaymentAuthorized, event.Type)
+	require.Equal(t, intent.ID, event.IntentID)
+	require.Equal(t, 20000.0, event.Amount)
+
+	t.Run("InvalidSignature", func(t *testing.T) {
+		_, err := p.VerifyWebhook(payload, payment.Sign("other", payload))
+		require.ErrorIs(t, err, payment.ErrInvalidSignature)
+	})
+
+	captured, err := p.Capture(ctx, intent.ID)
+	require.NoError(t, err)
+	require.Equal(t, payment.IntentStatusSucceeded, captured.Status)
+
+	refund, err := p.Refund(ctx, intent.ID, 15000, "refund-1")
+	require.NoError(t, err)
+	require.Equal(t, 15000.0, refund.Amount)
+
+	// a retried refund is made once
+	retried, err := p.Refund(ctx, intent.ID, 15000, "refund-1")
+	require.NoError(t, err)
+	require.Equal(t, refund.ID, retried.ID)
+
+	_, err = p.Refund(ctx, intent.ID, 10000, "refund-2")
+	require.ErrorIs(t, err, payment.ErrRefundExceedsAmount)
+}
+
+func TestProvider_Declined(t *testing.T) {
+	t.Parallel()
+
+	p := NewProvider("secret")
+	ctx := context.Background()
+	orderUUID := uuid.New()
+
+	intent, err := p.CreateIntent(ctx, orderUUID, 20000, "IDR")
+	require.NoError(t, err)
+
+	payload, signature, err := p.Confirm(ctx, intent.ID, false)
+	require.NoError(t, err)
+
+	event, err := p.VerifyWebhook(payload, signature)
+	require.NoError(t, err)
+	require.Equal(t, payment.EventPaymentFailed, event.Type)
+
+	retry, err := p.CreateIntent(ctx, orderUUID, 20000, "IDR")
+	require.NoError(t, err)
+	require.NotEqual(t, intent.ID, retry.ID)
+}
@@ -0,0 +1,13 @@
+package payment
+
+import (
+	"net/http"
+)
+
+// Payment HTTP Handlers interface
+type PaymentHandlers interface {
+	Create(w http.ResponseWriter, r *http.Request)
+	FindAllByOrderId(w http.ResponseWriter, r *http.Request)
+	Confirm(w http.ResponseWriter, r *http.Request)
+	Webhook(w http.ResponseWriter, r *http.Request)
+}
@@ -0,0 +1,112 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pg_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/dinorain/kalobranded/internal/models"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockPaymentPGRepository is a mock of PaymentPGRepository interface.
+type MockPaymentPGRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentPGRepositoryMockRecorder
+}
+
+// MockPaymentPGRepositoryMockRecorder is the mock recorder for MockPaymentPGRepository.
+type MockPaymentPGRepositoryMockRecorder struct {
+	mock *MockPaymentPGRepository
+}
+
+// NewMockPaymentPGRepository creates a new mock instance.
+func NewMockPaymentPGRepository(ctrl *gomock.Controller) *MockPaymentPGRepository {
+	mock := &MockPaymentPGRepository{ctrl: ctrl}
+	mock.recorder = &MockPaymentPGRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaymentPGRepository) EXPECT() *MockPaymentPGRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPaymentPGRepository) Create(ctx context.Context, payment *models.Payment) (*models.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, payment)
+	ret0, _ := ret[0].(*models.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPaymentPGRepositoryMockRecorder) Create(ctx, payment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPaymentPGRepository)(nil).Create), ctx, payment)
+}
+
+// FindAllByOrderId mocks base method.
+func (m *MockPaymentPGRepository) FindAllByOrderId(ctx context.Context, orderID uuid.UUID) ([]models.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByOrderId", ctx, orderID)
+	ret0, _ := ret[0].([]models.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByOrderId indicates an expected call of FindAllByOrderId.
+func (mr *MockPaymentPGRepositoryMockRecorder) FindAllByOrderId(ctx, orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByOrderId", reflect.TypeOf((*MockPaymentPGRepository)(nil).FindAllByOrderId), ctx, orderID)
+}
+
+// FindById mocks base method.
+func (m *MockPaymentPGRepository) FindById(ctx context.Context, paymentID uuid.UUID) (*models.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, paymentID)
+	ret0, _ := ret[0].(*models.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockPaymentPGRepositoryMockRecorder) FindById(ctx, paymentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockPaymentPGRepository)(nil).FindById), ctx, paymentID)
+}
+
+// FindByProviderPaymentId mocks base method.
+func (m *MockPaymentPGRepository) FindByProviderPaymentId(ctx context.Context, provider, providerPaymentID string) (*models.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByProviderPaymentId", ctx, provider, providerPaymentID)
+	ret0, _ := ret[0].(*models.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByProviderPaymentId indicates an expected call of FindByProviderPaymentId.
+func (mr *MockPaymentPGRepositoryMockRecorder) FindByProviderPaymentId(ctx, provider, providerPaymentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByProviderPaymentId", reflect.TypeOf((*MockPaymentPGRepository)(nil).FindByProviderPaymentId), ctx, provider, providerPaymentID)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockPaymentPGRepository) UpdateStatus(ctx context.Context, payment *models.Payment, status string) (*models.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, payment, status)
+	ret0, _ := ret[0].(*models.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockPaymentPGRepositoryMockRecorder) UpdateStatus(ctx, payment, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockPaymentPGRepository)(nil).UpdateStatus), ctx, payment, status)
+}
@@ -0,0 +1,128 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/dinorain/kalobranded/internal/models"
+	payment "github.com/dinorain/kalobranded/internal/payment"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockPaymentUseCase is a mock of PaymentUseCase interface.
+type MockPaymentUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentUseCaseMockRecorder
+}
+
+// MockPaymentUseCaseMockRecorder is the mock recorder for MockPaymentUseCase.
+type MockPaymentUseCaseMockRecorder struct {
+	mock *MockPaymentUseCase
+}
+
+// NewMockPaymentUseCase creates a new mock instance.
+func NewMockPaymentUseCase(ctrl *gomock.Controller) *MockPaymentUseCase {
+	mock := &MockPaymentUseCase{ctrl: ctrl}
+	mock.recorder = &MockPaymentUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaymentUseCase) EXPECT() *MockPaymentUseCaseMockRecorder {
+	return m.recorder
+}
+
+// Confirm mocks base method.
+func (m *MockPaymentUseCase) Confirm(ctx context.Context, payment *models.Payment, succeed bool) (*models.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Confirm", ctx, payment, succeed)
+	ret0, _ := ret[0].(*models.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Confirm indicates an expected call of Confirm.
+func (mr *MockPaymentUseCaseMockRecorder) Confirm(ctx, payment, succeed interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Confirm", reflect.TypeOf((*MockPaymentUseCase)(nil).Confirm), ctx, payment, succeed)
+}
+
+// Create mocks base method.
+func (m *MockPaymentUseCase) Create(ctx context.Context, order *models.Order) (*models.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, order)
+	ret0, _ := ret[0].(*models.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPaymentUseCaseMockRecorder) Create(ctx, order interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPaymentUseCase)(nil).Create), ctx, order)
+}
+
+// FindAllByOrderId mocks base method.
+func (m *MockPaymentUseCase) FindAllByOrderId(ctx context.Context, orderID uuid.UUID) ([]models.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByOrderId", ctx, orderID)
+	ret0, _ := ret[0].([]models.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByOrderId indicates an expected call of FindAllByOrderId.
+func (mr *MockPaymentUseCaseMockRecorder) FindAllByOrderId(ctx, orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByOrderId", reflect.TypeOf((*MockPaymentUseCase)(nil).FindAllByOrderId), ctx, orderID)
+}
+
+// FindById mocks base method.
+func (m *MockPaymentUseCase) FindById(ctx context.Context, paymentID uuid.UUID) (*models.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, paymentID)
+	ret0, _ := ret[0].(*models.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockPaymentUseCaseMockRecorder) FindById(ctx, paymentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockPaymentUseCase)(nil).FindById), ctx, paymentID)
+}
+
+// HandleWebhook mocks base method.
+func (m *MockPaymentUseCase) HandleWebhook(ctx context.Context, payload []byte, signature string) (*models.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleWebhook", ctx, payload, signature)
+	ret0, _ := ret[0].(*models.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleWebhook indicates an expected call of HandleWebhook.
+func (mr *MockPaymentUseCaseMockRecorder) HandleWebhook(ctx, payload, signature interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleWebhook", reflect.TypeOf((*MockPaymentUseCase)(nil).HandleWebhook), ctx, payload, signature)
+}
+
+// Refund mocks base method.
+func (m *MockPaymentUseCase) Refund(ctx context.Context, orderID uuid.UUID, amount float64, idempotencyKey string) (*payment.Refund, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refund", ctx, orderID, amount, idempotencyKey)
+	ret0, _ := ret[0].(*payment.Refund)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Refund indicates an expected call of Refund.
+func (mr *MockPaymentUseCaseMockRecorder) Refund(ctx, orderID, amount, idempotencyKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refund", reflect.TypeOf((*MockPaymentUseCase)(nil).Refund), ctx, orderID, amount, idempotencyKey)
+}
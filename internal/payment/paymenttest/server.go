@@ -0,0 +1,168 @@
+package paymenttest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/payment"
+	"github.com/dinorain/kalobranded/internal/payment/fake"
+	"github.com/dinorain/kalobranded/pkg/constants"
+)
+
+// Server local stub payment gateway for tests, the fake gateway served over the JSON API the HTTP provider speaks
+type Server struct {
+	*httptest.Server
+	ApiKey        string
+	WebhookSecret string
+	// WebhookURL when set, events created by confirm are also delivered there like a real gateway would
+	WebhookURL string
+
+	gateway *fake.Provider
+}
+
+// NewServer Start a stub gateway accepting requests authorized with apiKey
+func NewServer(apiKey string, webhookSecret string) *Server {
+	s := &Server{ApiKey: apiKey, WebhookSecret: webhookSecret, gateway: fake.NewProvider(webhookSecret)}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+// PaymentConfig provider config pointing to the stub
+func (s *Server) PaymentConfig() config.Payment {
+	return config.Payment{Provider: "http", BaseURL: s.URL, ApiKey: s.ApiKey, WebhookSecret: s.WebhookSecret, TestMode: true}
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+		return
+	}
+	if r.Header.Get("Authorization") != "Bearer "+s.ApiKey {
+		writeError(w, http.StatusUnauthorized, errors.New("invalid api key"))
+		return
+	}
+
+	// /v1/intents or /v1/intents/{id}/{action}
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch {
+	case len(segments) == 2 && segments[0] == "v1" && segments[1] == "intents":
+		s.createIntent(w, r)
+	case len(segments) == 4 && segments[0] == "v1" && segments[1] == "intents":
+		switch segments[3] {
+		case "capture":
+			intent, err := s.gateway.Capture(r.Context(), segments[2])
+			writeResult(w, intent, err)
+		case "refunds":
+			s.refund(w, r, segments[2])
+		case "confirm":
+			s.confirm(w, r, segments[2])
+		default:
+			writeError(w, http.StatusNotFound, errors.New("not found"))
+		}
+	default:
+		writeError(w, http.StatusNotFound, errors.New("not found"))
+	}
+}
+
+func (s *Server) createIntent(w http.ResponseWriter, r *http.Request) {
+	req := struct {
+		OrderID  uuid.UUID `json:"order_id"`
+		Amount   float64   `json:"amount"`
+		Currency string    `json:"currency"`
+	}{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	intent, err := s.gateway.CreateIntent(r.Context(), req.OrderID, req.Amount, req.Currency)
+	writeResult(w, intent, err)
+}
+
+func (s *Server) refund(w http.ResponseWriter, r *http.Request, intentID string) {
+	req := struct {
+		Amount float64 `json:"amount"`
+	}{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	refund, err := s.gateway.Refund(r.Context(), intentID, req.Amount, r.Header.Get(constants.IdempotencyKey))
+	writeResult(w, refund, err)
+}
+
+func (s *Server) confirm(w http.ResponseWriter, r *http.Request, intentID string) {
+	req := struct {
+		Succeed bool `json:"succeed"`
+	}{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	payload, signature, err := s.gateway.Confirm(r.Context(), intentID, req.Succeed)
+	if err != nil {
+		writeResult(w, nil, err)
+		return
+	}
+
+	if s.WebhookURL != "" {
+		if err := s.deliver(r.Context(), payload, signature); err != nil {
+			writeError(w, http.StatusBadGateway, err)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set(constants.PaymentSignature, signature)
+	w.WriteHeader(http.StatusOK)
+	w.Write(payload)
+}
+
+func (s *Server) deliver(ctx context.Context, payload []byte, signature string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.WebhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(constants.PaymentSignature, signature)
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode >= http.StatusMultipleChoices {
+		return errors.New("webhook delivery failed: " + res.Status)
+	}
+	return nil
+}
+
+func writeResult(w http.ResponseWriter, result interface{}, err error) {
+	switch {
+	case err == nil:
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(result)
+	case errors.Is(err, payment.ErrIntentNotFound):
+		writeError(w, http.StatusNotFound, err)
+	case errors.Is(err, payment.ErrIntentNotCapturable), errors.Is(err, payment.ErrRefundExceedsAmount):
+		writeError(w, http.StatusConflict, err)
+	default:
+		writeError(w, http.StatusBadRequest, err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
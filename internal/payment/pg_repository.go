@@ -0,0 +1,19 @@
+//go:generate mockgen -source pg_repository.go -destination mock/pg_repository.go -package mock
+package payment
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/internal/models"
+)
+
+// Payment pg repository
+type PaymentPGRepository interface {
+	Create(ctx context.Context, payment *models.Payment) (*models.Payment, error)
+	FindById(ctx context.Context, paymentID uuid.UUID) (*models.Payment, error)
+	FindByProviderPaymentId(ctx context.Context, provider string, providerPaymentID string) (*models.Payment, error)
+	FindAllByOrderId(ctx context.Context, orderID uuid.UUID) ([]models.Payment, error)
+	UpdateStatus(ctx context.Context, payment *models.Payment, status string) (*models.Payment, error)
+}
@@ -0,0 +1,71 @@
+//go:generate mockgen -source provider.go -destination mock/provider.go -package mock
+package payment
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+const (
+	IntentStatusRequiresConfirmation = "requires_confirmation"
+	IntentStatusRequiresCapture      = "requires_capture"
+	IntentStatusSucceeded            = "succeeded"
+	IntentStatusFailed               = "failed"
+
+	EventPaymentAuthorized = "payment.authorized"
+	EventPaymentFailed     = "payment.failed"
+)
+
+var (
+	ErrInvalidSignature    = errors.New("invalid webhook signature")
+	ErrIntentNotFound      = errors.New("payment intent not found")
+	ErrIntentNotCapturable = errors.New("payment intent is not capturable")
+	ErrRefundExceedsAmount = errors.New("refund exceeds captured amount")
+	ErrConfirmUnsupported  = errors.New("payment provider does not support confirmation")
+	ErrPaymentNotCaptured  = errors.New("order has no captured payment")
+)
+
+// Intent provider side payment, created per order and confirmed by the customer with ClientSecret
+type Intent struct {
+	ID             string    `json:"id"`
+	OrderID        uuid.UUID `json:"order_id"`
+	Amount         float64   `json:"amount"`
+	Currency       string    `json:"currency"`
+	Status         string    `json:"status"`
+	ClientSecret   string    `json:"client_secret"`
+	RefundedAmount float64   `json:"refunded_amount"`
+}
+
+// Refund provider side refund of a captured intent
+type Refund struct {
+	ID       string  `json:"id"`
+	IntentID string  `json:"intent_id"`
+	Amount   float64 `json:"amount"`
+}
+
+// Event webhook notification sent by the provider
+type Event struct {
+	ID       string  `json:"id"`
+	Type     string  `json:"type"`
+	IntentID string  `json:"intent_id"`
+	Amount   float64 `json:"amount"`
+}
+
+// PaymentProvider payment service provider gateway
+type PaymentProvider interface {
+	Name() string
+	// CreateIntent is idempotent per order while the previous intent has not failed
+	CreateIntent(ctx context.Context, orderID uuid.UUID, amount float64, currency string) (*Intent, error)
+	Capture(ctx context.Context, intentID string) (*Intent, error)
+	// Refund is idempotent per idempotencyKey, a repeated key returns the refund made the first time
+	Refund(ctx context.Context, intentID string, amount float64, idempotencyKey string) (*Refund, error)
+	VerifyWebhook(payload []byte, signature string) (*Event, error)
+}
+
+// Confirmer implemented by providers able to simulate the customer confirming an intent, it returns
+// the webhook payload and signature the provider would deliver
+type Confirmer interface {
+	Confirm(ctx context.Context, intentID string, succeed bool) (payload []byte, signature string, err error)
+}
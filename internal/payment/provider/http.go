@@ -0,0 +1,151 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/payment"
+	"github.com/dinorain/kalobranded/pkg/constants"
+)
+
+const HTTPProviderName = "http"
+
+// HTTP payment gateway reached over its JSON API, see paymenttest for the stub implementation
+type HTTP struct {
+	cfg    config.Payment
+	client *resty.Client
+}
+
+var (
+	_ payment.PaymentProvider = (*HTTP)(nil)
+	_ payment.Confirmer       = (*HTTP)(nil)
+)
+
+type createIntentRequest struct {
+	OrderID  uuid.UUID `json:"order_id"`
+	Amount   float64   `json:"amount"`
+	Currency string    `json:"currency"`
+}
+
+type refundRequest struct {
+	Amount float64 `json:"amount"`
+}
+
+type confirmRequest struct {
+	Succeed bool `json:"succeed"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// NewHTTP HTTP gateway constructor
+func NewHTTP(cfg config.Payment, client *resty.Client) *HTTP {
+	return &HTTP{cfg: cfg, client: client}
+}
+
+// Name provider name stored on payments
+func (p *HTTP) Name() string {
+	return HTTPProviderName
+}
+
+// CreateIntent create or return the open intent of the order
+func (p *HTTP) CreateIntent(ctx context.Context, orderID uuid.UUID, amount float64, currency string) (*payment.Intent, error) {
+	intent := &payment.Intent{}
+	if err := p.post(ctx, "/v1/intents", &createIntentRequest{OrderID: orderID, Amount: amount, Currency: currency}, intent); err != nil {
+		return nil, errors.Wrap(err, "HTTP.CreateIntent")
+	}
+	return intent, nil
+}
+
+// Capture capture an authorized intent
+func (p *HTTP) Capture(ctx context.Context, intentID string) (*payment.Intent, error) {
+	intent := &payment.Intent{}
+	if err := p.post(ctx, "/v1/intents/"+intentID+"/capture", nil, intent); err != nil {
+		return nil, errors.Wrap(err, "HTTP.Capture")
+	}
+	return intent, nil
+}
+
+// Refund refund part or all of a captured intent, the gateway answers a repeated idempotencyKey with the first refund
+func (p *HTTP) Refund(ctx context.Context, intentID string, amount float64, idempotencyKey string) (*payment.Refund, error) {
+	refund := &payment.Refund{}
+	res, err := p.request(ctx).
+		SetHeader(constants.IdempotencyKey, idempotencyKey).
+		SetBody(&refundRequest{Amount: amount}).
+		SetResult(refund).
+		Post(p.url("/v1/intents/" + intentID + "/refunds"))
+	if err != nil {
+		return nil, errors.Wrap(err, "HTTP.Refund.Post")
+	}
+	if err := responseError(res); err != nil {
+		return nil, errors.Wrap(err, "HTTP.Refund")
+	}
+	return refund, nil
+}
+
+// Confirm ask the gateway to simulate customer confirmation, only test gateways support it
+func (p *HTTP) Confirm(ctx context.Context, intentID string, succeed bool) ([]byte, string, error) {
+	res, err := p.request(ctx).SetBody(&confirmRequest{Succeed: succeed}).Post(p.url("/v1/intents/" + intentID + "/confirm"))
+	if err != nil {
+		return nil, "", errors.Wrap(err, "HTTP.Confirm.Post")
+	}
+	if err := responseError(res); err != nil {
+		return nil, "", errors.Wrap(err, "HTTP.Confirm")
+	}
+	return res.Body(), res.Header().Get(constants.PaymentSignature), nil
+}
+
+// VerifyWebhook verify an event signed with the shared webhook secret
+func (p *HTTP) VerifyWebhook(payload []byte, signature string) (*payment.Event, error) {
+	return payment.VerifyEvent(p.cfg.WebhookSecret, payload, signature)
+}
+
+func (p *HTTP) post(ctx context.Context, path string, body interface{}, result interface{}) error {
+	req := p.request(ctx).SetResult(result)
+	if body != nil {
+		req.SetBody(body)
+	}
+	res, err := req.Post(p.url(path))
+	if err != nil {
+		return err
+	}
+	return responseError(res)
+}
+
+func (p *HTTP) request(ctx context.Context) *resty.Request {
+	return p.client.R().
+		SetContext(ctx).
+		SetHeader("Accept", "application/json").
+		SetAuthToken(p.cfg.ApiKey).
+		SetError(&errorResponse{})
+}
+
+func (p *HTTP) url(path string) string {
+	return strings.TrimSuffix(p.cfg.BaseURL, "/") + path
+}
+
+// responseError map gateway error responses back to payment errors
+func responseError(res *resty.Response) error {
+	if res.StatusCode() == http.StatusOK {
+		return nil
+	}
+
+	msg := ""
+	if e, ok := res.Error().(*errorResponse); ok {
+		msg = e.Error
+	}
+	for _, known := range []error{payment.ErrIntentNotFound, payment.ErrIntentNotCapturable, payment.ErrRefundExceedsAmount} {
+		if msg == known.Error() {
+			return known
+		}
+	}
+	return fmt.Errorf("payment gateway status %d: %s", res.StatusCode(), msg)
+}
@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/kalobranded/internal/payment"
+	"github.com/dinorain/kalobranded/internal/payment/paymenttest"
+	"github.com/dinorain/kalobranded/pkg/constants"
+	"github.com/dinorain/kalobranded/pkg/http_client"
+)
+
+func TestHTTP_Flow(t *testing.T) {
+	t.Parallel()
+
+	stub := paymenttest.NewServer("api-key", "secret")
+	defer stub.Close()
+
+	delivered := make(chan []byte, 1)
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload, _ := ioutil.ReadAll(r.Body)
+		require.Equal(t, payment.Sign("secret", payload), r.Header.Get(constants.PaymentSignature))
+		delivered <- payload
+	}))
+	defer webhook.Close()
+	stub.WebhookURL = webhook.URL
+
+	p := NewHTTP(stub.PaymentConfig(), http_client.NewHttpClient(false))
+	ctx := context.Background()
+
+	intent, err := p.CreateIntent(ctx, uuid.New(), 20000, "IDR")
+	require.NoError(t, err)
+	require.Equal(t, "pi_fake_000001", intent.ID)
+	require.NotEmpty(t, intent.ClientSecret)
+
+	_, err = p.Capture(ctx, intent.ID)
+	require.ErrorIs(t, err, payment.ErrIntentNotCapturable)
+
+	payload, signature, err := p.Confirm(ctx, intent.ID, true)
+	require.NoError(t, err)
+	require.Equal(t, payload, <-delivered)
+
+	event, err := p.VerifyWebhook(payload, signature)
+	require.NoError(t, err)
+	require.Equal(t, payment.EventPaymentAuthorized, event.Type)
+
+	captured, err := p.Capture(ctx, intent.ID)
+	require.NoError(t, err)
+	require.Equal(t, payment.IntentStatusSucceeded, captured.Status)
+
+	refund, err := p.Refund(ctx, intent.ID, 5000, "refund-1")
+	require.NoError(t, err)
+	require.Equal(t, 5000.0, refund.Amount)
+
+	retried, err := p.Refund(ctx, intent.ID, 5000, "refund-1")
+	require.NoError(t, err)
+	require.Equal(t, refund.ID, retried.ID)
+
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := p.Capture(ctx, "pi_unknown")
+		require.ErrorIs(t, err, payment.ErrIntentNotFound)
+	})
+
+	t.Run("WrongApiKey", func(t *testing.T) {
+		cfg := stub.PaymentConfig()
+		cfg.ApiKey = "wrong"
+
+		_, err := NewHTTP(cfg, http_client.NewHttpClient(false)).CreateIntent(ctx, uuid.New(), 20000, "IDR")
+		require.Error(t, err)
+	})
+}
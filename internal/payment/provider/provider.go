@@ -0,0 +1,29 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-resty/resty/v2"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/payment"
+	"github.com/dinorain/kalobranded/internal/payment/fake"
+)
+
+// NewProvider Build the configured payment provider, the fake gateway only in test mode as it takes no money
+func NewProvider(cfg *config.Config, client *resty.Client) (payment.PaymentProvider, error) {
+	switch cfg.Payment.Provider {
+	case "":
+		return nil, errors.New("payment provider is not configured")
+	case fake.ProviderName:
+		if !cfg.Payment.TestMode {
+			return nil, fmt.Errorf("payment provider %q is only allowed in test mode", fake.ProviderName)
+		}
+		return fake.NewProvider(cfg.Payment.WebhookSecret), nil
+	case HTTPProviderName:
+		return NewHTTP(cfg.Payment, client), nil
+	default:
+		return nil, fmt.Errorf("unknown payment provider %q", cfg.Payment.Provider)
+	}
+}
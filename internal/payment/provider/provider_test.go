@@ -0,0 +1,31 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/payment/fake"
+	"github.com/dinorain/kalobranded/pkg/http_client"
+)
+
+func TestNewProvider(t *testing.T) {
+	t.Parallel()
+
+	client := http_client.NewHttpClient(false)
+
+	p, err := NewProvider(&config.Config{Payment: config.Payment{Provider: HTTPProviderName}}, client)
+	require.NoError(t, err)
+	require.Equal(t, HTTPProviderName, p.Name())
+
+	p, err = NewProvider(&config.Config{Payment: config.Payment{Provider: fake.ProviderName, TestMode: true}}, client)
+	require.NoError(t, err)
+	require.Equal(t, fake.ProviderName, p.Name())
+
+	// the fake gateway takes no money, it never stands in for a missing or production provider
+	for _, cfg := range []config.Payment{{}, {Provider: fake.ProviderName}, {Provider: "stripe"}} {
+		_, err := NewProvider(&config.Config{Payment: cfg}, client)
+		require.Error(t, err, cfg.Provider)
+	}
+}
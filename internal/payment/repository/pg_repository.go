@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/internal/payment"
+)
+
+// Payment repository
+type PaymentRepository struct {
+	db *sqlx.DB
+}
+
+var _ payment.PaymentPGRepository = (*PaymentRepository)(nil)
+
+// Payment repository constructor
+func NewPaymentPGRepository(db *sqlx.DB) *PaymentRepository {
+	return &PaymentRepository{db: db}
+}
+
+// Create new payment
+func (r *PaymentRepository) Create(ctx context.Context, payment *models.Payment) (*models.Payment, error) {
+	createdPayment := &models.Payment{}
+	if err := r.db.QueryRowxContext(
+		ctx,
+		createPaymentQuery,
+		payment.OrderID,
+		payment.Provider,
+		payment.ProviderPaymentID,
+		payment.Amount,
+		payment.Currency,
+		payment.Status,
+	).StructScan(createdPayment); err != nil {
+		return nil, errors.Wrap(err, "PaymentRepository.Create.QueryRowxContext")
+	}
+
+	return createdPayment, nil
+}
+
+// FindById Find payment by uuid
+func (r *PaymentRepository) FindById(ctx context.Context, paymentID uuid.UUID) (*models.Payment, error) {
+	payment := &models.Payment{}
+	if err := r.db.GetContext(ctx, payment, findByIdQuery, paymentID); err != nil {
+		return nil, errors.Wrap(err, "PaymentRepository.FindById.GetContext")
+	}
+
+	return payment, nil
+}
+
+// FindByProviderPaymentId Find payment by provider name and provider side id
+func (r *PaymentRepository) FindByProviderPaymentId(ctx context.Context, provider string, providerPaymentID string) (*models.Payment, error) {
+	payment := &models.Payment{}
+	if err := r.db.GetContext(ctx, payment, findByProviderPaymentIdQuery, provider, providerPaymentID); err != nil {
+		return nil, errors.Wrap(err, "PaymentRepository.FindByProviderPaymentId.GetContext")
+	}
+
+	return payment, nil
+}
+
+// FindAllByOrderId Find payments of order uuid, oldest first
+func (r *PaymentRepository) FindAllByOrderId(ctx context.Context, orderID uuid.UUID) ([]models.Payment, error) {
+	var payments []models.Payment
+	if err := r.db.SelectContext(ctx, &payments, findAllByOrderIdQuery, orderID); err != nil {
+		return nil, errors.Wrap(err, "PaymentRepository.FindAllByOrderId.SelectContext")
+	}
+
+	return payments, nil
+}
+
+// UpdateStatus move payment to status, only when it is still at the status it was read with
+func (r *PaymentRepository) UpdateStatus(ctx context.Context, payment *models.Payment, status string) (*models.Payment, error) {
+	updatedPayment := &models.Payment{}
+	if err := r.db.QueryRowxContext(ctx, updateStatusQuery, payment.PaymentID, payment.Status, status).StructScan(updatedPayment); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, models.ErrVersionConflict
+		}
+		return nil, errors.Wrap(err, "PaymentRepository.UpdateStatus.QueryRowxContext")
+	}
+
+	return updatedPayment, nil
+}
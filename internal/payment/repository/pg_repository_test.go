@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/kalobranded/internal/models"
+)
+
+var paymentColumns = []string{"payment_id", "order_id", "provider", "provider_payment_id", "amount", "currency", "status", "created_at", "updated_at"}
+
+func TestPaymentRepository_Create(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	paymentPGRepository := NewPaymentPGRepository(sqlxDB)
+
+	paymentUUID := uuid.New()
+	mockPayment := &models.Payment{
+		OrderID:           uuid.New(),
+		Provider:          "fake",
+		ProviderPaymentID: "pi_fake_000001",
+		Amount:            10000.0,
+		Currency:          "IDR",
+		Status:            models.PaymentStatusPending,
+	}
+
+	rows := sqlmock.NewRows(paymentColumns).AddRow(
+		paymentUUID,
+		mockPayment.OrderID,
+		mockPayment.Provider,
+		mockPayment.ProviderPaymentID,
+		mockPayment.Amount,
+		mockPayment.Currency,
+		mockPayment.Status,
+		time.Now(),
+		time.Now(),
+	)
+
+	mock.ExpectQuery(createPaymentQuery).WithArgs(
+		mockPayment.OrderID,
+		mockPayment.Provider,
+		mockPayment.ProviderPaymentID,
+		mockPayment.Amount,
+		mockPayment.Currency,
+		mockPayment.Status,
+	).WillReturnRows(rows)
+
+	createdPayment, err := paymentPGRepository.Create(context.Background(), mockPayment)
+	require.NoError(t, err)
+	require.NotNil(t, createdPayment)
+	require.Equal(t, paymentUUID, createdPayment.PaymentID)
+}
+
+func TestPaymentRepository_FindByProviderPaymentId(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	paymentPGRepository := NewPaymentPGRepository(sqlxDB)
+
+	paymentUUID := uuid.New()
+	rows := sqlmock.NewRows(paymentColumns).AddRow(
+		paymentUUID,
+		uuid.New(),
+		"fake",
+		"pi_fake_000001",
+		10000.0,
+		"IDR",
+		models.PaymentStatusPending,
+		time.Now(),
+		time.Now(),
+	)
+
+	mock.ExpectQuery(findByProviderPaymentIdQuery).WithArgs("fake", "pi_fake_000001").WillReturnRows(rows)
+
+	foundPayment, err := paymentPGRepository.FindByProviderPaymentId(context.Background(), "fake", "pi_fake_000001")
+	require.NoError(t, err)
+	require.Equal(t, paymentUUID, foundPayment.PaymentID)
+
+	t.Run("NotFound", func(t *testing.T) {
+		mock.ExpectQuery(findByProviderPaymentIdQuery).WithArgs("fake", "pi_fake_000002").WillReturnError(sql.ErrNoRows)
+
+		_, err := paymentPGRepository.FindByProviderPaymentId(context.Background(), "fake", "pi_fake_000002")
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
+}
+
+func TestPaymentRepository_UpdateStatus(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	paymentPGRepository := NewPaymentPGRepository(sqlxDB)
+
+	mockPayment := &models.Payment{
+		PaymentID:         uuid.New(),
+		OrderID:           uuid.New(),
+		Provider:          "fake",
+		ProviderPaymentID: "pi_fake_000001",
+		Amount:            10000.0,
+		Currency:          "IDR",
+		Status:            models.PaymentStatusPending,
+	}
+
+	rows := sqlmock.NewRows(paymentColumns).AddRow(
+		mockPayment.PaymentID,
+		mockPayment.OrderID,
+		mockPayment.Provider,
+		mockPayment.ProviderPaymentID,
+		mockPayment.Amount,
+		mockPayment.Currency,
+		models.PaymentStatusAuthorized,
+		time.Now(),
+		time.Now(),
+	)
+
+	mock.ExpectQuery(updateStatusQuery).WithArgs(
+		mockPayment.PaymentID,
+		models.PaymentStatusPending,
+		models.PaymentStatusAuthorized,
+	).WillReturnRows(rows)
+
+	updatedPayment, err := paymentPGRepository.UpdateStatus(context.Background(), mockPayment, models.PaymentStatusAuthorized)
+	require.NoError(t, err)
+	require.Equal(t, models.PaymentStatusAuthorized, updatedPayment.Status)
+
+	t.Run("VersionConflict", func(t *testing.T) {
+		mock.ExpectQuery(updateStatusQuery).WithArgs(
+			mockPayment.PaymentID,
+			models.PaymentStatusPending,
+			models.PaymentStatusAuthorized,
+		).WillReturnRows(sqlmock.NewRows(paymentColumns))
+
+		_, err := paymentPGRepository.UpdateStatus(context.Background(), mockPayment, models.PaymentStatusAuthorized)
+		require.ErrorIs(t, err, models.ErrVersionConflict)
+	})
+}
@@ -0,0 +1,16 @@
+package repository
+
+const (
+	createPaymentQuery = `INSERT INTO payments (order_id, provider, provider_payment_id, amount, currency, status) 
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING payment_id, order_id, provider, provider_payment_id, amount, currency, status, created_at, updated_at`
+
+	findByIdQuery = `SELECT payment_id, order_id, provider, provider_payment_id, amount, currency, status, created_at, updated_at FROM payments WHERE payment_id = $1`
+
+	findByProviderPaymentIdQuery = `SELECT payment_id, order_id, provider, provider_payment_id, amount, currency, status, created_at, updated_at FROM payments WHERE provider = $1 AND provider_payment_id = $2`
+
+	findAllByOrderIdQuery = `SELECT payment_id, order_id, provider, provider_payment_id, amount, currency, status, created_at, updated_at FROM payments WHERE order_id = $1 ORDER BY created_at`
+
+	updateStatusQuery = `UPDATE payments SET status = $3, updated_at = CURRENT_TIMESTAMP WHERE payment_id = $1 AND status = $2
+		RETURNING payment_id, order_id, provider, provider_payment_id, amount, currency, status, created_at, updated_at`
+)
@@ -0,0 +1,20 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase.go -package mock
+package payment
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/internal/models"
+)
+
+// Payment UseCase interface
+type PaymentUseCase interface {
+	Create(ctx context.Context, order *models.Order) (*models.Payment, error)
+	FindById(ctx context.Context, paymentID uuid.UUID) (*models.Payment, error)
+	FindAllByOrderId(ctx context.Context, orderID uuid.UUID) ([]models.Payment, error)
+	Confirm(ctx context.Context, payment *models.Payment, succeed bool) (*models.Payment, error)
+	HandleWebhook(ctx context.Context, payload []byte, signature string) (*models.Payment, error)
+	Refund(ctx context.Context, orderID uuid.UUID, amount float64, idempotencyKey string) (*Refund, error)
+}
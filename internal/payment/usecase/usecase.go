@@ -0,0 +1,224 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/internal/order"
+	"github.com/dinorain/kalobranded/internal/payment"
+	"github.com/dinorain/kalobranded/pkg/logger"
+)
+
+const (
+	defaultCurrency = "IDR"
+)
+
+// Payment UseCase
+type paymentUseCase struct {
+	cfg           *config.Config
+	logger        logger.Logger
+	paymentPgRepo payment.PaymentPGRepository
+	orderUC       order.OrderUseCase
+	provider      payment.PaymentProvider
+}
+
+var _ payment.PaymentUseCase = (*paymentUseCase)(nil)
+
+// New Payment UseCase
+func NewPaymentUseCase(
+	cfg *config.Config,
+	logger logger.Logger,
+	paymentRepo payment.PaymentPGRepository,
+	orderUC order.OrderUseCase,
+	provider payment.PaymentProvider,
+) *paymentUseCase {
+	return &paymentUseCase{cfg: cfg, logger: logger, paymentPgRepo: paymentRepo, orderUC: orderUC, provider: provider}
+}
+
+// Create start paying a pending order, the open payment is returned again while the provider hands back the same intent
+func (u *paymentUseCase) Create(ctx context.Context, order *models.Order) (*models.Payment, error) {
+	if order.Status != models.OrderStatusPending {
+		return nil, errors.Wrapf(models.ErrInvalidStatusTransition, "order is %s", order.Status)
+	}
+
+	intent, err := u.provider.CreateIntent(ctx, order.OrderID, order.TotalPrice, u.getCurrency())
+	if err != nil {
+		return nil, errors.Wrap(err, "provider.CreateIntent")
+	}
+
+	existingPayment, err := u.paymentPgRepo.FindByProviderPaymentId(ctx, u.provider.Name(), intent.ID)
+	if err == nil {
+		existingPayment.ClientSecret = intent.ClientSecret
+		return existingPayment, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.Wrap(err, "paymentPgRepo.FindByProviderPaymentId")
+	}
+
+	createdPayment, err := u.paymentPgRepo.Create(ctx, &models.Payment{
+		OrderID:           order.OrderID,
+		Provider:          u.provider.Name(),
+		ProviderPaymentID: intent.ID,
+		Amount:            intent.Amount,
+		Currency:          intent.Currency,
+		Status:            models.PaymentStatusPending,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "paymentPgRepo.Create")
+	}
+	createdPayment.ClientSecret = intent.ClientSecret
+
+	return createdPayment, nil
+}
+
+// FindById find payment by uuid
+func (u *paymentUseCase) FindById(ctx context.Context, paymentID uuid.UUID) (*models.Payment, error) {
+	foundPayment, err := u.paymentPgRepo.FindById(ctx, paymentID)
+	if err != nil {
+		return nil, errors.Wrap(err, "paymentPgRepo.FindById")
+	}
+
+	return foundPayment, nil
+}
+
+// FindAllByOrderId find payments of order
+func (u *paymentUseCase) FindAllByOrderId(ctx context.Context, orderID uuid.UUID) ([]models.Payment, error) {
+	payments, err := u.paymentPgRepo.FindAllByOrderId(ctx, orderID)
+	if err != nil {
+		return nil, errors.Wrap(err, "paymentPgRepo.FindAllByOrderId")
+	}
+
+	return payments, nil
+}
+
+// Confirm simulate the customer confirming the payment with providers supporting it, the resulting
+// webhook event is processed as if the provider delivered it
+func (u *paymentUseCase) Confirm(ctx context.Context, p *models.Payment, succeed bool) (*models.Payment, error) {
+	confirmer, ok := u.provider.(payment.Confirmer)
+	if !ok || !u.cfg.Payment.TestMode {
+		return nil, payment.ErrConfirmUnsupported
+	}
+
+	payload, signature, err := confirmer.Confirm(ctx, p.ProviderPaymentID, succeed)
+	if err != nil {
+		return nil, errors.Wrap(err, "provider.Confirm")
+	}
+
+	return u.HandleWebhook(ctx, payload, signature)
+}
+
+// HandleWebhook verify and apply a provider event, redelivered events are applied once
+func (u *paymentUseCase) HandleWebhook(ctx context.Context, payload []byte, signature string) (*models.Payment, error) {
+	event, err := u.provider.VerifyWebhook(payload, signature)
+	if err != nil {
+		return nil, errors.Wrap(err, "provider.VerifyWebhook")
+	}
+
+	foundPayment, err := u.paymentPgRepo.FindByProviderPaymentId(ctx, u.provider.Name(), event.IntentID)
+	if err != nil {
+		return nil, errors.Wrap(err, "paymentPgRepo.FindByProviderPaymentId")
+	}
+
+	switch event.Type {
+	case payment.EventPaymentAuthorized:
+		if event.Amount != foundPayment.Amount {
+			return nil, fmt.Errorf("payment %s authorized amount %v, expected %v", foundPayment.PaymentID, event.Amount, foundPayment.Amount)
+		}
+		return u.capture(ctx, foundPayment)
+	case payment.EventPaymentFailed:
+		if foundPayment.Status != models.PaymentStatusPending {
+			return foundPayment, nil
+		}
+		failedPayment, err := u.paymentPgRepo.UpdateStatus(ctx, foundPayment, models.PaymentStatusFailed)
+		if err != nil {
+			return nil, errors.Wrap(err, "paymentPgRepo.UpdateStatus")
+		}
+		return failedPayment, nil
+	default:
+		u.logger.Infof("payment event %s of %s ignored", event.Type, event.IntentID)
+		return foundPayment, nil
+	}
+}
+
+// Refund give amount of the captured payment of order back through the provider, once per idempotencyKey
+func (u *paymentUseCase) Refund(ctx context.Context, orderID uuid.UUID, amount float64, idempotencyKey string) (*payment.Refund, error) {
+	payments, err := u.paymentPgRepo.FindAllByOrderId(ctx, orderID)
+	if err != nil {
+		return nil, errors.Wrap(err, "paymentPgRepo.FindAllByOrderId")
+	}
+
+	for _, p := range payments {
+		if p.Status != models.PaymentStatusCaptured {
+			continue
+		}
+		refund, err := u.provider.Refund(ctx, p.ProviderPaymentID, amount, idempotencyKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "provider.Refund")
+		}
+		return refund, nil
+	}
+
+	return nil, payment.ErrPaymentNotCaptured
+}
+
+// capture capture an authorized payment and mark its order paid, each step is skipped when a previous delivery already did it
+func (u *paymentUseCase) capture(ctx context.Context, p *models.Payment) (*models.Payment, error) {
+	if p.Status == models.PaymentStatusPending {
+		authorizedPayment, err := u.paymentPgRepo.UpdateStatus(ctx, p, models.PaymentStatusAuthorized)
+		if err != nil {
+			return nil, errors.Wrap(err, "paymentPgRepo.UpdateStatus")
+		}
+		p = authorizedPayment
+	}
+
+	if p.Status == models.PaymentStatusAuthorized {
+		if _, err := u.provider.Capture(ctx, p.ProviderPaymentID); err != nil {
+			return nil, errors.Wrap(err, "provider.Capture")
+		}
+		capturedPayment, err := u.paymentPgRepo.UpdateStatus(ctx, p, models.PaymentStatusCaptured)
+		if err != nil {
+			return nil, errors.Wrap(err, "paymentPgRepo.UpdateStatus")
+		}
+		p = capturedPayment
+	}
+
+	if p.Status != models.PaymentStatusCaptured {
+		return p, nil
+	}
+
+	if err := u.markOrderPaid(ctx, p.OrderID); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+func (u *paymentUseCase) markOrderPaid(ctx context.Context, orderID uuid.UUID) error {
+	foundOrder, err := u.orderUC.FindById(ctx, orderID)
+	if err != nil {
+		return errors.Wrap(err, "orderUC.FindById")
+	}
+	if !foundOrder.CanTransitionTo(models.OrderStatusPaid) {
+		return nil
+	}
+
+	foundOrder.Status = models.OrderStatusPaid
+	if _, err := u.orderUC.UpdateById(ctx, foundOrder); err != nil {
+		return errors.Wrap(err, "orderUC.UpdateById")
+	}
+
+	return nil
+}
+
+func (u *paymentUseCase) getCurrency() string {
+	if u.cfg.Payment.Currency != "" {
+		return u.cfg.Payment.Currency
+	}
+	return defaultCurrency
+}
@@ -0,0 +1,208 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/models"
+	orderMock "github.com/dinorain/kalobranded/internal/order/mock"
+	"github.com/dinorain/kalobranded/internal/payment"
+	"github.com/dinorain/kalobranded/internal/payment/fake"
+	"github.com/dinorain/kalobranded/internal/payment/mock"
+	"github.com/dinorain/kalobranded/pkg/logger"
+)
+
+func TestPaymentUseCase_Create(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	paymentPGRepository := mock.NewMockPaymentPGRepository(ctrl)
+	orderUC := orderMock.NewMockOrderUseCase(ctrl)
+	apiLogger := logger.NewAppLogger(nil)
+
+	cfg := &config.Config{Payment: config.Payment{Currency: "IDR", WebhookSecret: "secret"}}
+	paymentUC := NewPaymentUseCase(cfg, apiLogger, paymentPGRepository, orderUC, fake.NewProvider("secret"))
+
+	mockOrder := &models.Order{
+		OrderID:    uuid.New(),
+		UserID:     uuid.New(),
+		TotalPrice: 20000.0,
+		Status:     models.OrderStatusPending,
+	}
+
+	ctx := context.Background()
+
+	paymentPGRepository.EXPECT().FindByProviderPaymentId(gomock.Any(), fake.ProviderName, "pi_fake_000001").Return(nil, sql.ErrNoRows)
+	paymentPGRepository.EXPECT().Create(gomock.Any(), &models.Payment{
+		OrderID:           mockOrder.OrderID,
+		Provider:          fake.ProviderName,
+		ProviderPaymentID: "pi_fake_000001",
+		Amount:            mockOrder.TotalPrice,
+		Currency:          "IDR",
+		Status:            models.PaymentStatusPending,
+	}).Return(&models.Payment{
+		PaymentID:         uuid.New(),
+		OrderID:           mockOrder.OrderID,
+		Provider:          fake.ProviderName,
+		ProviderPaymentID: "pi_fake_000001",
+		Amount:            mockOrder.TotalPrice,
+		Currency:          "IDR",
+		Status:            models.PaymentStatusPending,
+	}, nil)
+
+	createdPayment, err := paymentUC.Create(ctx, mockOrder)
+	require.NoError(t, err)
+	require.NotNil(t, createdPayment)
+	require.NotEmpty(t, createdPayment.ClientSecret)
+
+	t.Run("OrderNotPending", func(t *testing.T) {
+		_, err := paymentUC.Create(ctx, &models.Order{OrderID: uuid.New(), Status: models.OrderStatusPaid})
+		require.ErrorIs(t, err, models.ErrInvalidStatusTransition)
+	})
+}
+
+func TestPaymentUseCase_HandleWebhook(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	paymentPGRepository := mock.NewMockPaymentPGRepository(ctrl)
+	orderUC := orderMock.NewMockOrderUseCase(ctrl)
+	apiLogger := logger.NewAppLogger(nil)
+
+	provider := fake.NewProvider("secret")
+	cfg := &config.Config{Payment: config.Payment{Currency: "IDR", WebhookSecret: "secret"}}
+	paymentUC := NewPaymentUseCase(cfg, apiLogger, paymentPGRepository, orderUC, provider)
+
+	ctx := context.Background()
+
+	mockOrder := &models.Order{
+		OrderID:    uuid.New(),
+		UserID:     uuid.New(),
+		TotalPrice: 20000.0,
+		Status:     models.OrderStatusPending,
+	}
+	intent, err := provider.CreateIntent(ctx, mockOrder.OrderID, mockOrder.TotalPrice, "IDR")
+	require.NoError(t, err)
+
+	mockPayment := &models.Payment{
+		PaymentID:         uuid.New(),
+		OrderID:           mockOrder.OrderID,
+		Provider:          fake.ProviderName,
+		ProviderPaymentID: intent.ID,
+		Amount:            mockOrder.TotalPrice,
+		Currency:          "IDR",
+		Status:            models.PaymentStatusPending,
+	}
+	authorizedPayment := *mockPayment
+	authorizedPayment.Status = models.PaymentStatusAuthorized
+	capturedPayment := *mockPayment
+	capturedPayment.Status = models.PaymentStatusCaptured
+
+	payload, signature, err := provider.Confirm(ctx, intent.ID, true)
+	require.NoError(t, err)
+
+	t.Run("InvalidSignature", func(t *testing.T) {
+		_, err := paymentUC.HandleWebhook(ctx, payload, payment.Sign("other", payload))
+		require.ErrorIs(t, err, payment.ErrInvalidSignature)
+	})
+
+	gomock.InOrder(
+		paymentPGRepository.EXPECT().FindByProviderPaymentId(gomock.Any(), fake.ProviderName, intent.ID).Return(mockPayment, nil),
+		paymentPGRepository.EXPECT().UpdateStatus(gomock.Any(), mockPayment, models.PaymentStatusAuthorized).Return(&authorizedPayment, nil),
+		paymentPGRepository.EXPECT().UpdateStatus(gomock.Any(), &authorizedPayment, models.PaymentStatusCaptured).Return(&capturedPayment, nil),
+	)
+	orderUC.EXPECT().FindById(gomock.Any(), mockOrder.OrderID).Return(mockOrder, nil)
+	orderUC.EXPECT().UpdateById(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, order *models.Order) (*models.Order, error) {
+		require.Equal(t, models.OrderStatusPaid, order.Status)
+		return order, nil
+	})
+
+	handledPayment, err := paymentUC.HandleWebhook(ctx, payload, signature)
+	require.NoError(t, err)
+	require.Equal(t, models.PaymentStatusCaptured, handledPayment.Status)
+
+	t.Run("Redelivered", func(t *testing.T) {
+		paidOrder := *mockOrder
+		paidOrder.Status = models.OrderStatusPaid
+
+		paymentPGRepository.EXPECT().FindByProviderPaymentId(gomock.Any(), fake.ProviderName, intent.ID).Return(&capturedPayment, nil)
+		orderUC.EXPECT().FindById(gomock.Any(), mockOrder.OrderID).Return(&paidOrder, nil)
+
+		handledPayment, err := paymentUC.HandleWebhook(ctx, payload, signature)
+		require.NoError(t, err)
+		require.Equal(t, models.PaymentStatusCaptured, handledPayment.Status)
+	})
+}
+
+func TestPaymentUseCase_Refund(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	paymentPGRepository := mock.NewMockPaymentPGRepository(ctrl)
+	orderUC := orderMock.NewMockOrderUseCase(ctrl)
+	apiLogger := logger.NewAppLogger(nil)
+
+	provider := fake.NewProvider("secret")
+	cfg := &config.Config{Payment: config.Payment{Currency: "IDR", WebhookSecret: "secret"}}
+	paymentUC := NewPaymentUseCase(cfg, apiLogger, paymentPGRepository, orderUC, provider)
+
+	ctx := context.Background()
+	orderUUID := uuid.New()
+
+	intent, err := provider.CreateIntent(ctx, orderUUID, 20000.0, "IDR")
+	require.NoError(t, err)
+	_, _, err = provider.Confirm(ctx, intent.ID, true)
+	require.NoError(t, err)
+	_, err = provider.Capture(ctx, intent.ID)
+	require.NoError(t, err)
+
+	paymentPGRepository.EXPECT().FindAllByOrderId(gomock.Any(), orderUUID).Return([]models.Payment{
+		{OrderID: orderUUID, ProviderPaymentID: "pi_fake_000000", Status: models.PaymentStatusFailed},
+		{OrderID: orderUUID, ProviderPaymentID: intent.ID, Amount: 20000.0, Status: models.PaymentStatusCaptured},
+	}, nil)
+
+	refund, err := paymentUC.Refund(ctx, orderUUID, 5000.0, "refund-1")
+	require.NoError(t, err)
+	require.Equal(t, intent.ID, refund.IntentID)
+	require.Equal(t, 5000.0, refund.Amount)
+
+	t.Run("NotCaptured", func(t *testing.T) {
+		otherUUID := uuid.New()
+		paymentPGRepository.EXPECT().FindAllByOrderId(gomock.Any(), otherUUID).Return([]models.Payment{
+			{OrderID: otherUUID, ProviderPaymentID: "pi_fake_000009", Status: models.PaymentStatusPending},
+		}, nil)
+
+		_, err := paymentUC.Refund(ctx, otherUUID, 5000.0, "refund-2")
+		require.ErrorIs(t, err, payment.ErrPaymentNotCaptured)
+	})
+}
+
+func TestPaymentUseCase_Confirm(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	paymentPGRepository := mock.NewMockPaymentPGRepository(ctrl)
+	orderUC := orderMock.NewMockOrderUseCase(ctrl)
+	apiLogger := logger.NewAppLogger(nil)
+
+	// outside of test mode buyers cannot pay their own orders by confirming them
+	cfg := &config.Config{Payment: config.Payment{Currency: "IDR", WebhookSecret: "secret"}}
+	paymentUC := NewPaymentUseCase(cfg, apiLogger, paymentPGRepository, orderUC, fake.NewProvider("secret"))
+
+	_, err := paymentUC.Confirm(context.Background(), &models.Payment{ProviderPaymentID: "pi_fake_000001"}, true)
+	require.ErrorIs(t, err, payment.ErrConfirmUnsupported)
+}
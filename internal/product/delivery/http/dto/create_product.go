@@ -9,6 +9,9 @@ type ProductCreateRequestDto struct {
 	Description string    `json:"description" validate:"required,lte=250"`
 	Price       float64   `json:"price" validate:"required"`
 	BrandID     uuid.UUID `json:"brand_id" validate:"required"`
+	Stock       uint64    `json:"stock"`
+	Category    string    `json:"category" validate:"lte=64"`
+	Weight      uint64    `json:"weight"`
 }
 
 type ProductCreateResponseDto struct {
@@ -9,13 +9,18 @@ import (
 )
 
 type ProductResponseDto struct {
-	ProductID   uuid.UUID `json:"product_id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	Price       float64   `json:"price"`
-	BrandID     uuid.UUID `json:"brand_id"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ProductID   uuid.UUID  `json:"product_id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Price       float64    `json:"price"`
+	BrandID     uuid.UUID  `json:"brand_id"`
+	Stock       uint64     `json:"stock"`
+	Category    string     `json:"category"`
+	Weight      uint64     `json:"weight"`
+	Version     int        `json:"version"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
 }
 
 func ProductResponseFromModel(product *models.Product) *ProductResponseDto {
@@ -25,6 +30,11 @@ func ProductResponseFromModel(product *models.Product) *ProductResponseDto {
 		Description: product.Description,
 		Price:       product.Price,
 		BrandID:     product.BrandID,
+		Stock:       product.Stock,
+		Category:    product.Category,
+		Weight:      product.Weight,
+		Version:     product.Version,
+		DeletedAt:   product.DeletedAt,
 		CreatedAt:   product.CreatedAt,
 		UpdatedAt:   product.UpdatedAt,
 	}
@@ -3,5 +3,8 @@ package dto
 type ProductUpdateRequestDto struct {
 	Name        *string  `json:"name" validate:"omitempty,lte=30"`
 	Description *string  `json:"description" validate:"omitempty,lte=250"`
-	Price       *float64 `json:"price" validate:"omitempty"`
+	Price       *float64 `json:"price" validate:"omitempty,gt=0"`
+	Stock       *uint64  `json:"stock"`
+	Category    *string  `json:"category" validate:"omitempty,lte=64"`
+	Weight      *uint64  `json:"weight"`
 }
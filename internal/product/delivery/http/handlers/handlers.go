@@ -3,6 +3,7 @@ package handlers
 import (
 	"encoding/json"
 	"net/http"
+	"net/url"
 
 	"github.com/go-playground/validator"
 	"github.com/google/uuid"
@@ -13,6 +14,7 @@ import (
 	"github.com/dinorain/kalobranded/internal/models"
 	"github.com/dinorain/kalobranded/internal/product"
 	"github.com/dinorain/kalobranded/internal/product/delivery/http/dto"
+	"github.com/dinorain/kalobranded/internal/server/router"
 	"github.com/dinorain/kalobranded/internal/session"
 	"github.com/dinorain/kalobranded/pkg/constants"
 	httpErrors "github.com/dinorain/kalobranded/pkg/http_errors"
@@ -21,7 +23,7 @@ import (
 )
 
 type productHandlersHTTP struct {
-	mux       *http.ServeMux
+	router    *router.Router
 	logger    logger.Logger
 	cfg       *config.Config
 	mw        middlewares.MiddlewareManager
@@ -34,7 +36,7 @@ type productHandlersHTTP struct {
 var _ product.ProductHandlers = (*productHandlersHTTP)(nil)
 
 func NewProductHandlersHTTP(
-	mux *http.ServeMux,
+	router *router.Router,
 	logger logger.Logger,
 	cfg *config.Config,
 	mw middlewares.MiddlewareManager,
@@ -43,7 +45,7 @@ func NewProductHandlersHTTP(
 	productUC product.ProductUseCase,
 	sessUC session.SessUseCase,
 ) *productHandlersHTTP {
-	return &productHandlersHTTP{mux: mux, logger: logger, cfg: cfg, mw: mw, v: v, brandUC: brandUC, productUC: productUC, sessUC: sessUC}
+	return &productHandlersHTTP{router: router, logger: logger, cfg: cfg, mw: mw, v: v, brandUC: brandUC, productUC: productUC, sessUC: sessUC}
 }
 
 // Create
@@ -55,7 +57,7 @@ func NewProductHandlersHTTP(
 // @Security ApiKeyAuth
 // @Param payload body dto.ProductCreateRequestDto true "Payload"
 // @Success 200 {object} dto.ProductCreateResponseDto
-// @Router /product/create [post]
+// @Router /products [post]
 func (h *productHandlersHTTP) Create(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -102,26 +104,44 @@ func (h *productHandlersHTTP) Create(w http.ResponseWriter, r *http.Request) {
 // FindAll
 // @Tags Products
 // @Summary Find all products
-// @Description Find all products
+// @Description Find all products, newest first unless sorted. sort is one of created_at, name, price and stock, descending when prefixed with -
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
+// @Param brand_id query string false "brand uuid"
+// @Param category query string false "product category"
+// @Param min_price query number false "minimum price"
+// @Param max_price query number false "maximum price"
+// @Param sort query string false "sort key, e.g. price"
 // @Param size query string false "pagination size"
 // @Param page query string false "pagination page"
 // @Success 200 {object} dto.ProductFindResponseDto
-// @Router /product [get]
+// @Param include_deleted query bool false "admin only, include soft deleted products"
+// @Router /products [get]
 func (h *productHandlersHTTP) FindAll(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	queryParam := r.URL.Query()
+	pq := utils.NewPaginationFromQueryParams(queryParam.Get(constants.Size), queryParam.Get(constants.Page))
+	pq.SetOrderBy(queryParam.Get(constants.Sort))
 
-	if queryParam.Get("id") != "" {
-		h.FindById(w, r)
+	filter, err := parseProductFilter(queryParam, pq)
+	if err != nil {
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
 	}
 
-	pq := utils.NewPaginationFromQueryParams(queryParam.Get(constants.Size), queryParam.Get(constants.Page))
+	includeDeleted, err := h.mw.IncludeDeleted(w, r)
+	if err != nil {
+		return
+	}
+
+	findAll := h.productUC.FindAll
+	if includeDeleted {
+		findAll = h.productUC.FindAllWithDeleted
+	}
 
 	var products []models.Product
-	if res, err := h.productUC.FindAll(ctx, pq); err != nil {
+	if res, err := findAll(ctx, filter, pq); err != nil {
 		h.logger.Errorf("productUC.FindAll: %v", err)
 		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
 		return
@@ -148,31 +168,37 @@ func (h *productHandlersHTTP) FindAll(w http.ResponseWriter, r *http.Request) {
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
-// @Param id query string true "product id"
-// @Param page query string false "pagination page"
+// @Param id path string true "product uuid"
+// @Param include_deleted query bool false "admin only, find soft deleted product"
 // @Success 200 {object} dto.ProductResponseDto
-// @Router /product [get]
+// @Header 200 {string} ETag "resource version"
+// @Router /products/{id} [get]
 func (h *productHandlersHTTP) FindById(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	queryParam := r.URL.Query()
-
-	if queryParam.Get("id") == "" {
-		_ = httpErrors.NewBadRequestError(w, nil, h.cfg.Http.DebugErrorsResponse)
+	productUUID, err := uuid.Parse(router.Param(r, constants.ID))
+	if err != nil {
+		httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
 		return
 	}
-	productUUID, err := uuid.Parse(r.URL.Query().Get("id"))
+
+	includeDeleted, err := h.mw.IncludeDeleted(w, r)
 	if err != nil {
-		httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
 		return
 	}
 
-	product, err := h.productUC.CachedFindById(ctx, productUUID)
+	findById := h.productUC.CachedFindById
+	if includeDeleted {
+		findById = h.productUC.FindByIdWithDeleted
+	}
+
+	product, err := findById(ctx, productUUID)
 	if err != nil {
-		h.logger.Errorf("productUC.CachedFindById: %v", err)
+		h.logger.Errorf("productUC.FindById: %v", err)
 		httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
 		return
 	}
 
+	w.Header().Set(constants.ETag, utils.ETag(product.Version))
 	res, _ := json.Marshal(dto.ProductResponseFromModel(product))
 	w.WriteHeader(http.StatusOK)
 	w.Write(res)
@@ -182,29 +208,41 @@ func (h *productHandlersHTTP) FindById(w http.ResponseWriter, r *http.Request) {
 // FindAllByBrandId
 // @Tags Products
 // @Summary Find all products by brand
-// @Description Find all products by brand is
+// @Description Find all products by brand id, filtered and sorted as products are
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
-// @Param id query string false "brand uuid"
+// @Param id path string true "brand uuid"
+// @Param category query string false "product category"
+// @Param min_price query number false "minimum price"
+// @Param max_price query number false "maximum price"
+// @Param sort query string false "sort key, e.g. price"
 // @Param size query string false "pagination size"
 // @Param page query string false "pagination page"
 // @Success 200 {object} dto.ProductFindResponseDto
-// @Router /product [get]
+// @Router /brands/{id}/products [get]
 func (h *productHandlersHTTP) FindAllByBrandId(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	queryParam := r.URL.Query()
 	pq := utils.NewPaginationFromQueryParams(queryParam.Get(constants.Size), queryParam.Get(constants.Page))
+	pq.SetOrderBy(queryParam.Get(constants.Sort))
 
 	var products []models.Product
-	id := queryParam.Get(constants.ID)
-	brandUUID, err := uuid.Parse(id)
+	brandUUID, err := uuid.Parse(router.Param(r, constants.ID))
 	if err != nil {
 		_ = httpErrors.NewBadRequestError(w, err, h.cfg.Http.DebugErrorsResponse)
 		return
 	}
-	if res, err := h.productUC.FindAllByBrandId(ctx, brandUUID, pq); err != nil {
-		h.logger.Errorf("productUC.FindAllByBrandId: %v", err)
+
+	filter, err := parseProductFilter(queryParam, pq)
+	if err != nil {
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+	filter.BrandID = &brandUUID
+
+	if res, err := h.productUC.FindAll(ctx, filter, pq); err != nil {
+		h.logger.Errorf("productUC.FindAll: %v", err)
 		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
 		return
 	} else {
@@ -223,12 +261,144 @@ func (h *productHandlersHTTP) FindAllByBrandId(w http.ResponseWriter, r *http.Re
 	return
 }
 
+// UpdateById
+// @Tags Products
+// @Summary Update product
+// @Description Admin update product, only provided fields are changed
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "product uuid"
+// @Param If-Match header string false "ETag of the version being changed"
+// @Param payload body dto.ProductUpdateRequestDto true "Payload"
+// @Success 200 {object} dto.ProductResponseDto
+// @Header 200 {string} ETag "resource version"
+// @Router /products/{id} [patch]
+func (h *productHandlersHTTP) UpdateById(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	productUUID, err := uuid.Parse(router.Param(r, constants.ID))
+	if err != nil {
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	updateDto := &dto.ProductUpdateRequestDto{}
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&updateDto); err != nil {
+		h.logger.Errorf("decoder.Decode: %v", err)
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	if err := h.v.Struct(updateDto); err != nil {
+		h.logger.Errorf("h.v.Struct: %v", err)
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	product, err := h.productUC.FindById(ctx, productUUID)
+	if err != nil {
+		h.logger.Errorf("productUC.FindById: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	if !utils.IfMatch(r.Header.Get(constants.IfMatch), product.Version) {
+		_ = httpErrors.ErrorCtxResponse(w, httpErrors.PreconditionFailed, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	if err := h.updateReqToProductModel(product, updateDto); err != nil {
+		h.logger.Errorf("updateReqToProductModel: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	updatedProduct, err := h.productUC.UpdateById(ctx, product)
+	if err != nil {
+		h.logger.Errorf("productUC.UpdateById: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	w.Header().Set(constants.ETag, utils.ETag(updatedProduct.Version))
+	res, _ := json.Marshal(dto.ProductResponseFromModel(updatedProduct))
+	w.WriteHeader(http.StatusOK)
+	w.Write(res)
+	return
+}
+
+// DeleteById
+// @Tags Products
+// @Summary Delete product
+// @Description Admin delete product
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "product uuid"
+// @Param If-Match header string false "ETag of the version being changed"
+// @Success 204 {object} nil
+// @Router /products/{id} [delete]
+func (h *productHandlersHTTP) DeleteById(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	productUUID, err := uuid.Parse(router.Param(r, constants.ID))
+	if err != nil {
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	if err := h.productUC.DeleteById(ctx, productUUID, utils.IfMatchVersions(r.Header.Get(constants.IfMatch))); err != nil {
+		h.logger.Errorf("productUC.DeleteById: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return
+}
+
+// RestoreById
+// @Tags Products
+// @Summary Restore product
+// @Description Admin restore soft deleted product
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "product uuid"
+// @Success 200 {object} dto.ProductResponseDto
+// @Header 200 {string} ETag "resource version"
+// @Router /products/{id}/restore [post]
+func (h *productHandlersHTTP) RestoreById(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	productUUID, err := uuid.Parse(router.Param(r, constants.ID))
+	if err != nil {
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	product, err := h.productUC.RestoreById(ctx, productUUID)
+	if err != nil {
+		h.logger.Errorf("productUC.RestoreById: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	w.Header().Set(constants.ETag, utils.ETag(product.Version))
+	res, _ := json.Marshal(dto.ProductResponseFromModel(product))
+	w.WriteHeader(http.StatusOK)
+	w.Write(res)
+	return
+}
+
 func (h *productHandlersHTTP) registerReqToProductModel(r *dto.ProductCreateRequestDto) (*models.Product, error) {
 	productCandidate := &models.Product{
 		Name:        r.Name,
 		Description: r.Description,
 		Price:       r.Price,
 		BrandID:     r.BrandID,
+		Stock:       r.Stock,
+		Category:    r.Category,
+		Weight:      r.Weight,
 	}
 
 	if err := productCandidate.PrepareCreate(); err != nil {
@@ -237,3 +407,35 @@ func (h *productHandlersHTTP) registerReqToProductModel(r *dto.ProductCreateRequ
 
 	return productCandidate, nil
 }
+
+func (h *productHandlersHTTP) updateReqToProductModel(product *models.Product, r *dto.ProductUpdateRequestDto) error {
+	if r.Name != nil {
+		product.Name = *r.Name
+	}
+	if r.Description != nil {
+		product.Description = *r.Description
+	}
+	if r.Price != nil {
+		product.Price = *r.Price
+	}
+	if r.Stock != nil {
+		product.Stock = *r.Stock
+	}
+	if r.Category != nil {
+		product.Category = *r.Category
+	}
+	if r.Weight != nil {
+		product.Weight = *r.Weight
+	}
+
+	return product.PrepareCreate()
+}
+
+// parseProductFilter product filter of the query parameters, checking the sort of pagination too
+func parseProductFilter(queryParam url.Values, pagination *utils.Pagination) (*models.ProductFilter, error) {
+	if err := models.ProductSorts.Validate(pagination.GetOrderBy()); err != nil {
+		return nil, err
+	}
+
+	return models.ParseProductFilter(queryParam)
+}
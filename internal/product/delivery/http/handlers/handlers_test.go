@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -21,9 +22,11 @@ import (
 	"github.com/dinorain/kalobranded/internal/models"
 	"github.com/dinorain/kalobranded/internal/product/delivery/http/dto"
 	"github.com/dinorain/kalobranded/internal/product/mock"
+	"github.com/dinorain/kalobranded/internal/server/router"
 	mockSessUC "github.com/dinorain/kalobranded/internal/session/mock"
 	"github.com/dinorain/kalobranded/pkg/converter"
 	"github.com/dinorain/kalobranded/pkg/logger"
+	"github.com/dinorain/kalobranded/pkg/utils"
 )
 
 func TestProductsHandler_Create(t *testing.T) {
@@ -42,8 +45,8 @@ func TestProductsHandler_Create(t *testing.T) {
 
 	v := validator.New()
 
-	mux := http.NewServeMux()
-	handlers := NewProductHandlersHTTP(mux, appLogger, cfg, mw, v, brandUC, productUC, sessUC)
+	rt := router.NewRouter(false)
+	handlers := NewProductHandlersHTTP(rt, appLogger, cfg, mw, v, brandUC, productUC, sessUC)
 
 	userUUID := uuid.New()
 	brandUUID := uuid.New()
@@ -60,7 +63,7 @@ func TestProductsHandler_Create(t *testing.T) {
 	buf := &bytes.Buffer{}
 	_ = json.NewEncoder(buf).Encode(reqDto)
 
-	req := httptest.NewRequest(http.MethodPost, "/product/create", buf)
+	req := httptest.NewRequest(http.MethodPost, "/products", buf)
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 
@@ -104,8 +107,8 @@ func TestProductsHandler_Find(t *testing.T) {
 
 	v := validator.New()
 
-	mux := http.NewServeMux()
-	handlers := NewProductHandlersHTTP(mux, appLogger, cfg, mw, v, brandUC, productUC, sessUC)
+	rt := router.NewRouter(false)
+	handlers := NewProductHandlersHTTP(rt, appLogger, cfg, mw, v, brandUC, productUC, sessUC)
 
 	brandUUID := uuid.New()
 
@@ -129,11 +132,18 @@ func TestProductsHandler_Find(t *testing.T) {
 	})
 
 	t.Run("FindAll", func(t *testing.T) {
-		req := httptest.NewRequest(http.MethodGet, "/product", nil)
+		req := httptest.NewRequest(http.MethodGet, "/products?category=%20Shoes&min_price=100&sort=-price", nil)
 		req.Header.Set("Content-Type", "application/json")
 		w := httptest.NewRecorder()
 
-		productUC.EXPECT().FindAll(gomock.Any(), gomock.Any()).AnyTimes().Return(products, nil)
+		productUC.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, filter *models.ProductFilter, pq *utils.Pagination) ([]models.Product, error) {
+			require.Nil(t, filter.BrandID)
+			require.Equal(t, "shoes", filter.Category)
+			require.Equal(t, 100.0, *filter.MinPrice)
+			require.Nil(t, filter.MaxPrice)
+			require.Equal(t, "-price", pq.GetOrderBy())
+			return products, nil
+		})
 
 		handler := http.HandlerFunc(handlers.FindAll)
 		handler.ServeHTTP(w, req)
@@ -154,11 +164,11 @@ func TestProductsHandler_Find(t *testing.T) {
 	})
 
 	t.Run("FindAllByBrandId", func(t *testing.T) {
-		req := httptest.NewRequest(http.MethodGet, "/product/brand?id="+brandUUID.String(), nil)
+		req := router.WithParams(httptest.NewRequest(http.MethodGet, "/brands/"+brandUUID.String()+"/products", nil), map[string]string{"id": brandUUID.String()})
 		req.Header.Set("Content-Type", "application/json")
 		w := httptest.NewRecorder()
 
-		productUC.EXPECT().FindAllByBrandId(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(oneOnly, nil)
+		productUC.EXPECT().FindAll(gomock.Any(), &models.ProductFilter{BrandID: &brandUUID}, gomock.Any()).Return(oneOnly, nil)
 
 		handler := http.HandlerFunc(handlers.FindAllByBrandId)
 		handler.ServeHTTP(w, req)
@@ -178,14 +188,22 @@ func TestProductsHandler_Find(t *testing.T) {
 		require.Equal(t, len(oneOnly), len(resDto.Data.([]interface{})))
 	})
 
+	t.Run("FindAllInvalidSort", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/products?sort=brand_id", nil)
+		w := httptest.NewRecorder()
+
+		http.HandlerFunc(handlers.FindAll).ServeHTTP(w, req)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
 	t.Run("FindById", func(t *testing.T) {
-		req := httptest.NewRequest(http.MethodGet, "/product?id="+m.ProductID.String(), nil)
+		req := router.WithParams(httptest.NewRequest(http.MethodGet, "/products/"+m.ProductID.String(), nil), map[string]string{"id": m.ProductID.String()})
 		req.Header.Set("Content-Type", "application/json")
 		w := httptest.NewRecorder()
 
 		productUC.EXPECT().CachedFindById(gomock.Any(), gomock.Any()).AnyTimes().Return(&m, nil)
 
-		handler := http.HandlerFunc(handlers.FindAll)
+		handler := http.HandlerFunc(handlers.FindById)
 		handler.ServeHTTP(w, req)
 
 		res := w.Result()
@@ -206,3 +224,122 @@ func TestProductsHandler_Find(t *testing.T) {
 		require.Equal(t, m.ProductID.String(), resDto.ProductID.String())
 	})
 }
+
+func TestProductsHandler_UpdateById(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	productUC := mock.NewMockProductUseCase(ctrl)
+	brandUC := mockBrandUC.NewMockBrandUseCase(ctrl)
+	sessUC := mockSessUC.NewMockSessUseCase(ctrl)
+
+	cfg := &config.Config{Session: config.Session{Expire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	appLogger := logger.NewAppLogger(cfg)
+	appLogger.InitLogger()
+	mw := middlewares.NewMiddlewareManager(appLogger, cfg)
+
+	v := validator.New()
+
+	rt := router.NewRouter(false)
+	handlers := NewProductHandlersHTTP(rt, appLogger, cfg, mw, v, brandUC, productUC, sessUC)
+
+	productUUID := uuid.New()
+	brandUUID := uuid.New()
+	price := 20000.0
+
+	t.Run("PartialUpdate", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		_ = json.NewEncoder(buf).Encode(&dto.ProductUpdateRequestDto{Price: &price})
+
+		req := router.WithParams(httptest.NewRequest(http.MethodPatch, "/products/"+productUUID.String(), buf), map[string]string{"id": productUUID.String()})
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		productUC.EXPECT().FindById(gomock.Any(), productUUID).Return(&models.Product{ProductID: productUUID, Name: "Name", Description: "Description", Price: 10000.0, BrandID: brandUUID}, nil)
+		productUC.EXPECT().UpdateById(gomock.Any(), &models.Product{ProductID: productUUID, Name: "Name", Description: "Description", Price: price, BrandID: brandUUID}).DoAndReturn(func(_ interface{}, p *models.Product) (*models.Product, error) {
+			return p, nil
+		})
+
+		http.HandlerFunc(handlers.UpdateById).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		resDto := &dto.ProductResponseDto{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), resDto))
+		require.Equal(t, price, resDto.Price)
+		require.Equal(t, "Name", resDto.Name)
+	})
+
+	t.Run("InvalidPrice", func(t *testing.T) {
+		req := router.WithParams(httptest.NewRequest(http.MethodPatch, "/products/"+productUUID.String(), strings.NewReader(`{"price": -1}`)), map[string]string{"id": productUUID.String()})
+		w := httptest.NewRecorder()
+
+		http.HandlerFunc(handlers.UpdateById).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestProductsHandler_DeleteById(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	productUC := mock.NewMockProductUseCase(ctrl)
+	brandUC := mockBrandUC.NewMockBrandUseCase(ctrl)
+	sessUC := mockSessUC.NewMockSessUseCase(ctrl)
+
+	cfg := &config.Config{Session: config.Session{Expire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	appLogger := logger.NewAppLogger(cfg)
+	mw := middlewares.NewMiddlewareManager(appLogger, cfg)
+
+	v := validator.New()
+
+	rt := router.NewRouter(false)
+	handlers := NewProductHandlersHTTP(rt, appLogger, cfg, mw, v, brandUC, productUC, sessUC)
+
+	productUUID := uuid.New()
+
+	req := router.WithParams(httptest.NewRequest(http.MethodDelete, "/products/"+productUUID.String(), nil), map[string]string{"id": productUUID.String()})
+	w := httptest.NewRecorder()
+
+	productUC.EXPECT().DeleteById(gomock.Any(), productUUID, nil).Return(nil)
+
+	http.HandlerFunc(handlers.DeleteById).ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusNoContent, w.Code)
+}
+
+func TestProductsHandler_RestoreById(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	productUC := mock.NewMockProductUseCase(ctrl)
+	brandUC := mockBrandUC.NewMockBrandUseCase(ctrl)
+	sessUC := mockSessUC.NewMockSessUseCase(ctrl)
+
+	cfg := &config.Config{Session: config.Session{Expire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	appLogger := logger.NewAppLogger(cfg)
+	mw := middlewares.NewMiddlewareManager(appLogger, cfg)
+
+	v := validator.New()
+
+	rt := router.NewRouter(false)
+	handlers := NewProductHandlersHTTP(rt, appLogger, cfg, mw, v, brandUC, productUC, sessUC)
+
+	productUUID := uuid.New()
+
+	req := router.WithParams(httptest.NewRequest(http.MethodPost, "/products/"+productUUID.String()+"/restore", nil), map[string]string{"id": productUUID.String()})
+	w := httptest.NewRecorder()
+
+	productUC.EXPECT().RestoreById(gomock.Any(), productUUID).Return(&models.Product{ProductID: productUUID, Version: 2}, nil)
+
+	http.HandlerFunc(handlers.RestoreById).ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, `"2"`, w.Header().Get("ETag"))
+}
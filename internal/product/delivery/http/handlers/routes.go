@@ -1,8 +1,13 @@
 package handlers
 
-import "net/http"
-
 func (h *productHandlersHTTP) ProductMapRoutes() {
-	h.mux.Handle("/product/create", h.mw.IsAdmin(http.HandlerFunc(h.Create)))
-	h.mux.Handle("/product/brand", h.mw.GetHandler(http.HandlerFunc(h.FindAllByBrandId)))
+	products := h.router.Group("/products")
+	products.Get("", h.FindAll)
+	products.Get("/{id}", h.FindById)
+	products.Post("", h.Create, h.mw.IsAdmin)
+	products.Patch("/{id}", h.UpdateById, h.mw.IsAdmin)
+	products.Delete("/{id}", h.DeleteById, h.mw.IsAdmin)
+	products.Post("/{id}/restore", h.RestoreById, h.mw.IsAdmin)
+
+	h.router.Get("/brands/{id}/products", h.FindAllByBrandId)
 }
@@ -7,6 +7,7 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	models "github.com/dinorain/kalobranded/internal/models"
 	utils "github.com/dinorain/kalobranded/pkg/utils"
@@ -53,47 +54,61 @@ func (mr *MockProductPGRepositoryMockRecorder) Create(ctx, user interface{}) *go
 }
 
 // DeleteById mocks base method.
-func (m *MockProductPGRepository) DeleteById(ctx context.Context, userID uuid.UUID) error {
+func (m *MockProductPGRepository) DeleteById(ctx context.Context, productID uuid.UUID, versions []int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteById", ctx, userID)
+	ret := m.ctrl.Call(m, "DeleteById", ctx, productID, versions)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteById indicates an expected call of DeleteById.
-func (mr *MockProductPGRepositoryMockRecorder) DeleteById(ctx, userID interface{}) *gomock.Call {
+func (mr *MockProductPGRepositoryMockRecorder) DeleteById(ctx, productID, versions interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockProductPGRepository)(nil).DeleteById), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockProductPGRepository)(nil).DeleteById), ctx, productID, versions)
+}
+
+// Export mocks base method.
+func (m *MockProductPGRepository) Export(ctx context.Context, filter *models.ProductFilter, sort string, fn func(*models.Product) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, filter, sort, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockProductPGRepositoryMockRecorder) Export(ctx, filter, sort, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockProductPGRepository)(nil).Export), ctx, filter, sort, fn)
 }
 
 // FindAll mocks base method.
-func (m *MockProductPGRepository) FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.Product, error) {
+func (m *MockProductPGRepository) FindAll(ctx context.Context, filter *models.ProductFilter, pagination *utils.Pagination) ([]models.Product, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAll", ctx, pagination)
+	ret := m.ctrl.Call(m, "FindAll", ctx, filter, pagination)
 	ret0, _ := ret[0].([]models.Product)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindAll indicates an expected call of FindAll.
-func (mr *MockProductPGRepositoryMockRecorder) FindAll(ctx, pagination interface{}) *gomock.Call {
+func (mr *MockProductPGRepositoryMockRecorder) FindAll(ctx, filter, pagination interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockProductPGRepository)(nil).FindAll), ctx, pagination)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockProductPGRepository)(nil).FindAll), ctx, filter, pagination)
 }
 
-// FindAllByBrandId mocks base method.
-func (m *MockProductPGRepository) FindAllByBrandId(ctx context.Context, brandID uuid.UUID, pagination *utils.Pagination) ([]models.Product, error) {
+// FindAllWithDeleted mocks base method.
+func (m *MockProductPGRepository) FindAllWithDeleted(ctx context.Context, filter *models.ProductFilter, pagination *utils.Pagination) ([]models.Product, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAllByBrandId", ctx, brandID, pagination)
+	ret := m.ctrl.Call(m, "FindAllWithDeleted", ctx, filter, pagination)
 	ret0, _ := ret[0].([]models.Product)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FindAllByBrandId indicates an expected call of FindAllByBrandId.
-func (mr *MockProductPGRepositoryMockRecorder) FindAllByBrandId(ctx, brandID, pagination interface{}) *gomock.Call {
+// FindAllWithDeleted indicates an expected call of FindAllWithDeleted.
+func (mr *MockProductPGRepositoryMockRecorder) FindAllWithDeleted(ctx, filter, pagination interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByBrandId", reflect.TypeOf((*MockProductPGRepository)(nil).FindAllByBrandId), ctx, brandID, pagination)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllWithDeleted", reflect.TypeOf((*MockProductPGRepository)(nil).FindAllWithDeleted), ctx, filter, pagination)
 }
 
 // FindById mocks base method.
@@ -111,6 +126,51 @@ func (mr *MockProductPGRepositoryMockRecorder) FindById(ctx, userID interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockProductPGRepository)(nil).FindById), ctx, userID)
 }
 
+// FindByIdWithDeleted mocks base method.
+func (m *MockProductPGRepository) FindByIdWithDeleted(ctx context.Context, productID uuid.UUID) (*models.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIdWithDeleted", ctx, productID)
+	ret0, _ := ret[0].(*models.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIdWithDeleted indicates an expected call of FindByIdWithDeleted.
+func (mr *MockProductPGRepositoryMockRecorder) FindByIdWithDeleted(ctx, productID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIdWithDeleted", reflect.TypeOf((*MockProductPGRepository)(nil).FindByIdWithDeleted), ctx, productID)
+}
+
+// PurgeDeleted mocks base method.
+func (m *MockProductPGRepository) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeleted", ctx, deletedBefore)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeleted indicates an expected call of PurgeDeleted.
+func (mr *MockProductPGRepositoryMockRecorder) PurgeDeleted(ctx, deletedBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeleted", reflect.TypeOf((*MockProductPGRepository)(nil).PurgeDeleted), ctx, deletedBefore)
+}
+
+// RestoreById mocks base method.
+func (m *MockProductPGRepository) RestoreById(ctx context.Context, productID uuid.UUID) (*models.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreById", ctx, productID)
+	ret0, _ := ret[0].(*models.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreById indicates an expected call of RestoreById.
+func (mr *MockProductPGRepositoryMockRecorder) RestoreById(ctx, productID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreById", reflect.TypeOf((*MockProductPGRepository)(nil).RestoreById), ctx, productID)
+}
+
 // UpdateById mocks base method.
 func (m *MockProductPGRepository) UpdateById(ctx context.Context, user *models.Product) (*models.Product, error) {
 	m.ctrl.T.Helper()
@@ -7,6 +7,7 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	models "github.com/dinorain/kalobranded/internal/models"
 	utils "github.com/dinorain/kalobranded/pkg/utils"
@@ -68,47 +69,61 @@ func (mr *MockProductUseCaseMockRecorder) Create(ctx, product interface{}) *gomo
 }
 
 // DeleteById mocks base method.
-func (m *MockProductUseCase) DeleteById(ctx context.Context, productID uuid.UUID) error {
+func (m *MockProductUseCase) DeleteById(ctx context.Context, productID uuid.UUID, versions []int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteById", ctx, productID)
+	ret := m.ctrl.Call(m, "DeleteById", ctx, productID, versions)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteById indicates an expected call of DeleteById.
-func (mr *MockProductUseCaseMockRecorder) DeleteById(ctx, productID interface{}) *gomock.Call {
+func (mr *MockProductUseCaseMockRecorder) DeleteById(ctx, productID, versions interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockProductUseCase)(nil).DeleteById), ctx, productID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockProductUseCase)(nil).DeleteById), ctx, productID, versions)
+}
+
+// Export mocks base method.
+func (m *MockProductUseCase) Export(ctx context.Context, filter *models.ProductFilter, sort string, fn func(*models.Product) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, filter, sort, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockProductUseCaseMockRecorder) Export(ctx, filter, sort, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockProductUseCase)(nil).Export), ctx, filter, sort, fn)
 }
 
 // FindAll mocks base method.
-func (m *MockProductUseCase) FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.Product, error) {
+func (m *MockProductUseCase) FindAll(ctx context.Context, filter *models.ProductFilter, pagination *utils.Pagination) ([]models.Product, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAll", ctx, pagination)
+	ret := m.ctrl.Call(m, "FindAll", ctx, filter, pagination)
 	ret0, _ := ret[0].([]models.Product)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindAll indicates an expected call of FindAll.
-func (mr *MockProductUseCaseMockRecorder) FindAll(ctx, pagination interface{}) *gomock.Call {
+func (mr *MockProductUseCaseMockRecorder) FindAll(ctx, filter, pagination interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockProductUseCase)(nil).FindAll), ctx, pagination)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockProductUseCase)(nil).FindAll), ctx, filter, pagination)
 }
 
-// FindAllByBrandId mocks base method.
-func (m *MockProductUseCase) FindAllByBrandId(ctx context.Context, brandID uuid.UUID, pagination *utils.Pagination) ([]models.Product, error) {
+// FindAllWithDeleted mocks base method.
+func (m *MockProductUseCase) FindAllWithDeleted(ctx context.Context, filter *models.ProductFilter, pagination *utils.Pagination) ([]models.Product, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAllByBrandId", ctx, brandID, pagination)
+	ret := m.ctrl.Call(m, "FindAllWithDeleted", ctx, filter, pagination)
 	ret0, _ := ret[0].([]models.Product)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FindAllByBrandId indicates an expected call of FindAllByBrandId.
-func (mr *MockProductUseCaseMockRecorder) FindAllByBrandId(ctx, brandID, pagination interface{}) *gomock.Call {
+// FindAllWithDeleted indicates an expected call of FindAllWithDeleted.
+func (mr *MockProductUseCaseMockRecorder) FindAllWithDeleted(ctx, filter, pagination interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByBrandId", reflect.TypeOf((*MockProductUseCase)(nil).FindAllByBrandId), ctx, brandID, pagination)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllWithDeleted", reflect.TypeOf((*MockProductUseCase)(nil).FindAllWithDeleted), ctx, filter, pagination)
 }
 
 // FindById mocks base method.
@@ -126,6 +141,51 @@ func (mr *MockProductUseCaseMockRecorder) FindById(ctx, productID interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockProductUseCase)(nil).FindById), ctx, productID)
 }
 
+// FindByIdWithDeleted mocks base method.
+func (m *MockProductUseCase) FindByIdWithDeleted(ctx context.Context, productID uuid.UUID) (*models.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIdWithDeleted", ctx, productID)
+	ret0, _ := ret[0].(*models.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIdWithDeleted indicates an expected call of FindByIdWithDeleted.
+func (mr *MockProductUseCaseMockRecorder) FindByIdWithDeleted(ctx, productID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIdWithDeleted", reflect.TypeOf((*MockProductUseCase)(nil).FindByIdWithDeleted), ctx, productID)
+}
+
+// PurgeDeleted mocks base method.
+func (m *MockProductUseCase) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeleted", ctx, deletedBefore)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeleted indicates an expected call of PurgeDeleted.
+func (mr *MockProductUseCaseMockRecorder) PurgeDeleted(ctx, deletedBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeleted", reflect.TypeOf((*MockProductUseCase)(nil).PurgeDeleted), ctx, deletedBefore)
+}
+
+// RestoreById mocks base method.
+func (m *MockProductUseCase) RestoreById(ctx context.Context, productID uuid.UUID) (*models.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreById", ctx, productID)
+	ret0, _ := ret[0].(*models.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreById indicates an expected call of RestoreById.
+func (mr *MockProductUseCaseMockRecorder) RestoreById(ctx, productID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreById", reflect.TypeOf((*MockProductUseCase)(nil).RestoreById), ctx, productID)
+}
+
 // UpdateById mocks base method.
 func (m *MockProductUseCase) UpdateById(ctx context.Context, product *models.Product) (*models.Product, error) {
 	m.ctrl.T.Helper()
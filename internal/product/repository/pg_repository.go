@@ -3,12 +3,15 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/pkg/errors"
 
 	"github.com/dinorain/kalobranded/internal/models"
+	outboxRepository "github.com/dinorain/kalobranded/internal/outbox/repository"
 	"github.com/dinorain/kalobranded/internal/product"
 	"github.com/dinorain/kalobranded/pkg/utils"
 )
@@ -25,26 +28,50 @@ func NewProductPGRepository(db *sqlx.DB) *ProductRepository {
 	return &ProductRepository{db: db}
 }
 
-// Create new product
+// Create new product along with its product.created event
 func (r *ProductRepository) Create(ctx context.Context, product *models.Product) (*models.Product, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "ProductRepository.Create.BeginTxx")
+	}
+	defer tx.Rollback()
+
 	createdProduct := &models.Product{}
-	if err := r.db.QueryRowxContext(
+	if err := tx.QueryRowxContext(
 		ctx,
 		createProductQuery,
 		product.Name,
 		product.Description,
 		product.Price,
 		product.BrandID,
+		product.Stock,
+		product.Category,
+		product.Weight,
 	).StructScan(createdProduct); err != nil {
 		return nil, errors.Wrap(err, "ProductRepository.Create.QueryRowxContext")
 	}
 
+	if err := outboxRepository.AddEvent(ctx, tx, models.AggregateProduct, createdProduct.ProductID, models.EventProductCreated, createdProduct); err != nil {
+		return nil, errors.Wrap(err, "ProductRepository.Create.AddEvent")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "ProductRepository.Create.Commit")
+	}
+
 	return createdProduct, nil
 }
 
-// UpdateById update existing product
+// UpdateById update existing product when its version is unchanged, bumping the version, along with its
+// product.updated event
 func (r *ProductRepository) UpdateById(ctx context.Context, product *models.Product) (*models.Product, error) {
-	if res, err := r.db.ExecContext(
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "ProductRepository.Update.BeginTxx")
+	}
+	defer tx.Rollback()
+
+	if res, err := tx.ExecContext(
 		ctx,
 		updateByIdQuery,
 		product.ProductID,
@@ -52,36 +79,77 @@ func (r *ProductRepository) UpdateById(ctx context.Context, product *models.Prod
 		product.Description,
 		product.Price,
 		product.BrandID,
+		product.Stock,
+		product.Category,
+		product.Weight,
+		product.Version,
 	); err != nil {
 		return nil, errors.Wrap(err, "ProductRepository.Update.ExecContext")
 	} else {
-		_, err := res.RowsAffected()
+		cnt, err := res.RowsAffected()
 		if err != nil {
 			return nil, errors.Wrap(err, "Update.RowsAffected")
+		} else if cnt == 0 {
+			return nil, models.ErrVersionConflict
 		}
 	}
 
+	updatedProduct := *product
+	updatedProduct.Version++
+
+	if err := outboxRepository.AddEvent(ctx, tx, models.AggregateProduct, updatedProduct.ProductID, models.EventProductUpdated, &updatedProduct); err != nil {
+		return nil, errors.Wrap(err, "ProductRepository.Update.AddEvent")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "ProductRepository.Update.Commit")
+	}
+
+	product.Version++
+
 	return product, nil
 }
 
-// FindAll Find products
-func (r *ProductRepository) FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.Product, error) {
+// FindAll Find products matching filter
+func (r *ProductRepository) FindAll(ctx context.Context, filter *models.ProductFilter, pagination *utils.Pagination) ([]models.Product, error) {
+	query, args, err := productListQuery(filter, false).Paginate(models.ProductSorts, pagination).Build()
+	if err != nil {
+		return nil, errors.Wrap(err, "ProductRepository.FindAll.Build")
+	}
+
 	var products []models.Product
-	if err := r.db.SelectContext(ctx, &products, findAllQuery, pagination.GetLimit(), pagination.GetOffset()); err != nil {
-		return nil, errors.Wrap(err, "ProductRepository.FindById.SelectContext")
+	if err := r.db.SelectContext(ctx, &products, query, args...); err != nil {
+		return nil, errors.Wrap(err, "ProductRepository.FindAll.SelectContext")
 	}
 
 	return products, nil
 }
 
-// FindAllByBrandId Find products by brand uuid
-func (r *ProductRepository) FindAllByBrandId(ctx context.Context, brandID uuid.UUID, pagination *utils.Pagination) ([]models.Product, error) {
-	var products []models.Product
-	if err := r.db.SelectContext(ctx, &products, findAllByBrandIdQuery, brandID, pagination.GetLimit(), pagination.GetOffset()); err != nil {
-		return nil, errors.Wrap(err, "ProductPGRepository.FindAllByBrandId.SelectContext")
+// Export call fn with each product matching filter in turn, sorted by sort out of models.ProductSorts. The products are
+// read from the database as fn takes them rather than all at once, the first error of fn stops the export
+func (r *ProductRepository) Export(ctx context.Context, filter *models.ProductFilter, sort string, fn func(product *models.Product) error) error {
+	query, args, err := productListQuery(filter, false).OrderBy(models.ProductSorts, sort).Build()
+	if err != nil {
+		return errors.Wrap(err, "ProductRepository.Export.Build")
 	}
 
-	return products, nil
+	rows, err := r.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return errors.Wrap(err, "ProductRepository.Export.QueryxContext")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		product := &models.Product{}
+		if err := rows.StructScan(product); err != nil {
+			return errors.Wrap(err, "ProductRepository.Export.StructScan")
+		}
+		if err := fn(product); err != nil {
+			return err
+		}
+	}
+
+	return errors.Wrap(rows.Err(), "ProductRepository.Export.Rows")
 }
 
 // FindById Find product by uuid
@@ -94,14 +162,16 @@ func (r *ProductRepository) FindById(ctx context.Context, productID uuid.UUID) (
 	return product, nil
 }
 
-// DeleteById Find product by uuid
-func (r *ProductRepository) DeleteById(ctx context.Context, productID uuid.UUID) error {
-	if res, err := r.db.ExecContext(ctx, deleteByIdQuery, productID); err != nil {
+// DeleteById soft delete product by uuid, at one of versions unless nil
+func (r *ProductRepository) DeleteById(ctx context.Context, productID uuid.UUID, versions []int) error {
+	if res, err := r.db.ExecContext(ctx, deleteByIdQuery, productID, pq.Array(versions)); err != nil {
 		return errors.Wrap(err, "ProductRepository.DeleteById.ExecContext")
 	} else {
 		cnt, err := res.RowsAffected()
 		if err != nil {
 			return errors.Wrap(err, "ProductRepository.DeleteById.RowsAffected")
+		} else if cnt == 0 && versions != nil {
+			return models.ErrPreconditionFailed
 		} else if cnt == 0 {
 			return sql.ErrNoRows
 		}
@@ -109,3 +179,79 @@ func (r *ProductRepository) DeleteById(ctx context.Context, productID uuid.UUID)
 
 	return nil
 }
+
+// FindAllWithDeleted Find products matching filter including soft deleted ones
+func (r *ProductRepository) FindAllWithDeleted(ctx context.Context, filter *models.ProductFilter, pagination *utils.Pagination) ([]models.Product, error) {
+	query, args, err := productListQuery(filter, true).Paginate(models.ProductSorts, pagination).Build()
+	if err != nil {
+		return nil, errors.Wrap(err, "ProductRepository.FindAllWithDeleted.Build")
+	}
+
+	var products []models.Product
+	if err := r.db.SelectContext(ctx, &products, query, args...); err != nil {
+		return nil, errors.Wrap(err, "ProductRepository.FindAllWithDeleted.SelectContext")
+	}
+
+	return products, nil
+}
+
+// FindByIdWithDeleted Find product by uuid including soft deleted ones
+func (r *ProductRepository) FindByIdWithDeleted(ctx context.Context, productID uuid.UUID) (*models.Product, error) {
+	product := &models.Product{}
+	if err := r.db.GetContext(ctx, product, findByIdWithDeletedQuery, productID); err != nil {
+		return nil, errors.Wrap(err, "ProductRepository.FindByIdWithDeleted.GetContext")
+	}
+
+	return product, nil
+}
+
+// RestoreById restore soft deleted product by uuid
+func (r *ProductRepository) RestoreById(ctx context.Context, productID uuid.UUID) (*models.Product, error) {
+	product := &models.Product{}
+	if err := r.db.QueryRowxContext(ctx, restoreByIdQuery, productID).StructScan(product); err != nil {
+		return nil, errors.Wrap(err, "ProductRepository.RestoreById.QueryRowxContext")
+	}
+
+	return product, nil
+}
+
+// PurgeDeleted hard delete products soft deleted before deletedBefore, returns purged count
+func (r *ProductRepository) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	res, err := r.db.ExecContext(ctx, purgeDeletedQuery, deletedBefore)
+	if err != nil {
+		return 0, errors.Wrap(err, "ProductRepository.PurgeDeleted.ExecContext")
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "ProductRepository.PurgeDeleted.RowsAffected")
+	}
+
+	return cnt, nil
+}
+
+// productListQuery list of the products matching filter
+func productListQuery(filter *models.ProductFilter, withDeleted bool) *utils.QueryBuilder {
+	qb := utils.NewQueryBuilder(findAllQuery)
+	if !withDeleted {
+		qb.Where("deleted_at IS NULL")
+	}
+	if filter == nil {
+		return qb
+	}
+
+	if filter.BrandID != nil {
+		qb.Where("brand_id = ?", *filter.BrandID)
+	}
+	if filter.Category != "" {
+		qb.Where("category = ?", filter.Category)
+	}
+	if filter.MinPrice != nil {
+		qb.Where("price >= ?", *filter.MinPrice)
+	}
+	if filter.MaxPrice != nil {
+		qb.Where("price <= ?", *filter.MaxPrice)
+	}
+
+	return qb
+}
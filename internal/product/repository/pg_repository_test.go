@@ -2,15 +2,18 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/require"
 
 	"github.com/dinorain/kalobranded/internal/models"
+	outboxRepository "github.com/dinorain/kalobranded/internal/outbox/repository"
 	"github.com/dinorain/kalobranded/pkg/utils"
 )
 
@@ -47,12 +50,18 @@ func TestProductRepository_Create(t *testing.T) {
 		time.Now(),
 	)
 
+	mock.ExpectBegin()
 	mock.ExpectQuery(createProductQuery).WithArgs(
 		mockProduct.Name,
 		mockProduct.Description,
 		mockProduct.Price,
 		mockProduct.BrandID,
+		mockProduct.Stock,
+		mockProduct.Category,
+		mockProduct.Weight,
 	).WillReturnRows(rows)
+	mock.ExpectExec(outboxRepository.AddEventQuery).WithArgs(sqlmock.AnyArg(), models.AggregateProduct, productUUID, models.EventProductCreated, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
 
 	createdProduct, err := productPGRepository.Create(context.Background(), mockProduct)
 	require.NoError(t, err)
@@ -93,14 +102,15 @@ func TestProductRepository_FindAll(t *testing.T) {
 	)
 
 	size := 10
-	mock.ExpectQuery(findAllQuery).WithArgs(size, 0).WillReturnRows(rows)
-	foundProducts, err := productPGRepository.FindAll(context.Background(), utils.NewPaginationQuery(size, 1))
+	query := findAllQuery + " WHERE deleted_at IS NULL ORDER BY created_at DESC, product_id DESC LIMIT $1 OFFSET $2"
+	mock.ExpectQuery(query).WithArgs(size, 0).WillReturnRows(rows)
+	foundProducts, err := productPGRepository.FindAll(context.Background(), &models.ProductFilter{}, utils.NewPaginationQuery(size, 1))
 	require.NoError(t, err)
 	require.NotNil(t, foundProducts)
 	require.Equal(t, len(foundProducts), 1)
 
-	mock.ExpectQuery(findAllQuery).WithArgs(size, 10).WillReturnRows(rows)
-	foundProducts, err = productPGRepository.FindAll(context.Background(), utils.NewPaginationQuery(size, 2))
+	mock.ExpectQuery(query).WithArgs(size, 10).WillReturnRows(rows)
+	foundProducts, err = productPGRepository.FindAll(context.Background(), nil, utils.NewPaginationQuery(size, 2))
 	require.NoError(t, err)
 	require.Nil(t, foundProducts)
 }
@@ -150,29 +160,90 @@ func TestProductRepository_FindAllByBrandId(t *testing.T) {
 	)
 
 	size := 10
+	findAllByBrandIdQuery := findAllQuery + " WHERE deleted_at IS NULL AND brand_id = $1 ORDER BY created_at DESC, product_id DESC LIMIT $2 OFFSET $3"
 	mock.ExpectQuery(findAllByBrandIdQuery).WithArgs(mockProduct.BrandID, size, 0).WillReturnRows(rows)
-	foundProducts, err := productPGRepository.FindAllByBrandId(context.Background(), mockProduct.BrandID, utils.NewPaginationQuery(size, 1))
+	foundProducts, err := productPGRepository.FindAll(context.Background(), &models.ProductFilter{BrandID: &mockProduct.BrandID}, utils.NewPaginationQuery(size, 1))
 	require.NoError(t, err)
 	require.NotNil(t, foundProducts)
 	require.Equal(t, len(foundProducts), 1)
 
 	mock.ExpectQuery(findAllByBrandIdQuery).WithArgs(mockProduct.BrandID, size, 10).WillReturnRows(rows)
-	foundProducts, err = productPGRepository.FindAllByBrandId(context.Background(), mockProduct.BrandID, utils.NewPaginationQuery(size, 2))
+	foundProducts, err = productPGRepository.FindAll(context.Background(), &models.ProductFilter{BrandID: &mockProduct.BrandID}, utils.NewPaginationQuery(size, 2))
 	require.NoError(t, err)
 	require.Nil(t, foundProducts)
 
 	mock.ExpectQuery(findAllByBrandIdQuery).WithArgs(otherUUID, size, 0).WillReturnRows(otherRows)
-	foundProducts, err = productPGRepository.FindAllByBrandId(context.Background(), otherUUID, utils.NewPaginationQuery(size, 1))
+	foundProducts, err = productPGRepository.FindAll(context.Background(), &models.ProductFilter{BrandID: &otherUUID}, utils.NewPaginationQuery(size, 1))
 	require.NoError(t, err)
 	require.NotNil(t, foundProducts)
 	require.Equal(t, len(foundProducts), 1)
 
 	mock.ExpectQuery(findAllByBrandIdQuery).WithArgs(otherUUID, size, 10).WillReturnRows(otherRows)
-	foundProducts, err = productPGRepository.FindAllByBrandId(context.Background(), otherUUID, utils.NewPaginationQuery(size, 2))
+	foundProducts, err = productPGRepository.FindAll(context.Background(), &models.ProductFilter{BrandID: &otherUUID}, utils.NewPaginationQuery(size, 2))
 	require.NoError(t, err)
 	require.Nil(t, foundProducts)
 }
 
+func TestProductRepository_FindAllFiltered(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	productPGRepository := NewProductPGRepository(sqlxDB)
+
+	brandUUID := uuid.New()
+	minPrice, maxPrice := 100.0, 500.0
+	columns := []string{"product_id", "name", "price", "brand_id"}
+
+	mock.ExpectQuery(findAllQuery+" WHERE brand_id = $1 AND category = $2 AND price >= $3 AND price <= $4 ORDER BY price DESC, product_id DESC LIMIT $5 OFFSET $6").
+		WithArgs(brandUUID, "shoes", minPrice, maxPrice, 10, 0).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(uuid.New(), "Name", 250.0, brandUUID))
+
+	pagination := utils.NewPaginationQuery(10, 1)
+	pagination.SetOrderBy("-price")
+	foundProducts, err := productPGRepository.FindAllWithDeleted(context.Background(), &models.ProductFilter{
+		BrandID:  &brandUUID,
+		Category: "shoes",
+		MinPrice: &minPrice,
+		MaxPrice: &maxPrice,
+	}, pagination)
+	require.NoError(t, err)
+	require.Len(t, foundProducts, 1)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductRepository_Export(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	productPGRepository := NewProductPGRepository(sqlxDB)
+
+	minPrice := 5000.0
+	mock.ExpectQuery(findAllQuery+" WHERE deleted_at IS NULL AND category = $1 AND price >= $2 ORDER BY created_at DESC, product_id DESC").
+		WithArgs("shirt", minPrice).
+		WillReturnRows(sqlmock.NewRows([]string{"product_id", "name", "price"}).AddRow(uuid.New(), "Kaos", 99000.0))
+
+	var names []string
+	err = productPGRepository.Export(context.Background(), &models.ProductFilter{Category: "shirt", MinPrice: &minPrice}, "", func(product *models.Product) error {
+		names = append(names, product.Name)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"Kaos"}, names)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestProductRepository_FindById(t *testing.T) {
 	t.Parallel()
 
@@ -248,19 +319,36 @@ func TestProductRepository_UpdateById(t *testing.T) {
 	)
 
 	mockProduct.Name = "NameChanged"
+	mock.ExpectBegin()
 	mock.ExpectExec(updateByIdQuery).WithArgs(
 		mockProduct.ProductID,
 		mockProduct.Name,
 		mockProduct.Description,
 		mockProduct.Price,
 		mockProduct.BrandID,
+		mockProduct.Stock,
+		mockProduct.Category,
+		mockProduct.Weight,
+		mockProduct.Version,
 	).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(outboxRepository.AddEventQuery).WithArgs(sqlmock.AnyArg(), models.AggregateProduct, productUUID, models.EventProductUpdated, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
 
 	updatedProduct, err := productPGRepository.UpdateById(context.Background(), mockProduct)
 	require.NoError(t, err)
 	require.NotNil(t, mockProduct)
 	require.Equal(t, updatedProduct.Name, mockProduct.Name)
 	require.Equal(t, updatedProduct.ProductID, mockProduct.ProductID)
+	require.Equal(t, 1, updatedProduct.Version)
+
+	t.Run("VersionConflict", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(updateByIdQuery).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		_, err := productPGRepository.UpdateById(context.Background(), mockProduct)
+		require.ErrorIs(t, err, models.ErrVersionConflict)
+	})
 }
 
 func TestProductRepository_DeleteById(t *testing.T) {
@@ -296,9 +384,78 @@ func TestProductRepository_DeleteById(t *testing.T) {
 		time.Now(),
 	)
 
-	mock.ExpectExec(deleteByIdQuery).WithArgs(mockProduct.ProductID).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(deleteByIdQuery).WithArgs(mockProduct.ProductID, pq.Array([]int(nil))).WillReturnResult(sqlmock.NewResult(0, 1))
 
-	err = productPGRepository.DeleteById(context.Background(), mockProduct.ProductID)
+	err = productPGRepository.DeleteById(context.Background(), mockProduct.ProductID, nil)
 	require.NoError(t, err)
+
+	t.Run("PreconditionFailed", func(t *testing.T) {
+		mock.ExpectExec(deleteByIdQuery).WithArgs(mockProduct.ProductID, pq.Array([]int{1})).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := productPGRepository.DeleteById(context.Background(), mockProduct.ProductID, []int{1})
+		require.ErrorIs(t, err, models.ErrPreconditionFailed)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mock.ExpectExec(deleteByIdQuery).WithArgs(mockProduct.ProductID, pq.Array([]int(nil))).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := productPGRepository.DeleteById(context.Background(), mockProduct.ProductID, nil)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
 	require.NotNil(t, mockProduct)
 }
+
+func TestProductRepository_RestoreById(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	productPGRepository := NewProductPGRepository(sqlxDB)
+
+	columns := []string{"product_id", "version", "deleted_at"}
+	productUUID := uuid.New()
+
+	t.Run("Restored", func(t *testing.T) {
+		rows := sqlmock.NewRows(columns).AddRow(productUUID, 3, nil)
+		mock.ExpectQuery(restoreByIdQuery).WithArgs(productUUID).WillReturnRows(rows)
+
+		restoredProduct, err := productPGRepository.RestoreById(context.Background(), productUUID)
+		require.NoError(t, err)
+		require.Equal(t, productUUID, restoredProduct.ProductID)
+		require.Equal(t, 3, restoredProduct.Version)
+		require.Nil(t, restoredProduct.DeletedAt)
+	})
+
+	t.Run("NotDeleted", func(t *testing.T) {
+		mock.ExpectQuery(restoreByIdQuery).WithArgs(productUUID).WillReturnRows(sqlmock.NewRows(columns))
+
+		_, err := productPGRepository.RestoreById(context.Background(), productUUID)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
+}
+
+func TestProductRepository_PurgeDeleted(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	productPGRepository := NewProductPGRepository(sqlxDB)
+
+	deletedBefore := time.Now().AddDate(0, 0, -30)
+	mock.ExpectExec(purgeDeletedQuery).WithArgs(deletedBefore).WillReturnResult(sqlmock.NewResult(0, 2))
+
+	purged, err := productPGRepository.PurgeDeleted(context.Background(), deletedBefore)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), purged)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
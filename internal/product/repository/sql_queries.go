@@ -1,18 +1,26 @@
 package repository
 
 const (
-	createProductQuery = `INSERT INTO products (name, description, price, brand_id) 
-		VALUES ($1, $2, $3, $4)
-		RETURNING product_id, name, description, price, brand_id, created_at, updated_at`
+	createProductQuery = `INSERT INTO products (name, description, price, brand_id, stock, category, weight) 
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING product_id, name, description, price, brand_id, stock, category, weight, created_at, updated_at, version, deleted_at`
 
-	findByIdQuery = `SELECT product_id, name, description, price, brand_id, created_at, updated_at FROM products WHERE product_id = $1`
+	findByIdQuery = `SELECT product_id, name, description, price, brand_id, stock, category, weight, created_at, updated_at, version, deleted_at FROM products WHERE product_id = $1 AND deleted_at IS NULL`
 
-	findAllQuery = `SELECT product_id, name, description, price, brand_id, created_at, updated_at FROM products LIMIT $1 OFFSET $2`
+	findByIdWithDeletedQuery = `SELECT product_id, name, description, price, brand_id, stock, category, weight, created_at, updated_at, version, deleted_at FROM products WHERE product_id = $1`
 
-	findAllByBrandIdQuery = `SELECT product_id, name, description, price, brand_id, created_at, updated_at FROM products WHERE brand_id = $1 LIMIT $2 OFFSET $3`
+	// findAllQuery base of product lists, conditions, sort and pagination are added by utils.QueryBuilder
+	findAllQuery = `SELECT product_id, name, description, price, brand_id, stock, category, weight, created_at, updated_at, version, deleted_at FROM products`
 
-	updateByIdQuery = `UPDATE products SET name = $2, description = $3, price = $4, brand_id = $5 WHERE product_id = $1
-		RETURNING product_id, name, description, price, brand_id, created_at, updated_at`
+	updateByIdQuery = `UPDATE products SET name = $2, description = $3, price = $4, brand_id = $5, stock = $6, category = $7, weight = $8, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE product_id = $1 AND version = $9 AND deleted_at IS NULL
+		RETURNING product_id, name, description, price, brand_id, stock, category, weight, created_at, updated_at, version, deleted_at`
 
-	deleteByIdQuery = `DELETE FROM products WHERE product_id = $1`
+	deleteByIdQuery = `UPDATE products SET deleted_at = CURRENT_TIMESTAMP, version = version + 1 WHERE product_id = $1 AND deleted_at IS NULL AND ($2::int[] IS NULL OR version = ANY($2))`
+
+	restoreByIdQuery = `UPDATE products SET deleted_at = NULL, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE product_id = $1 AND deleted_at IS NOT NULL
+		RETURNING product_id, name, description, price, brand_id, stock, category, weight, created_at, updated_at, version, deleted_at`
+
+	purgeDeletedQuery = `DELETE FROM products p WHERE p.deleted_at < $1
+		AND NOT EXISTS (SELECT 1 FROM orders o WHERE o.item->>'product_id' = p.product_id::text)
+		AND NOT EXISTS (SELECT 1 FROM promotions pr WHERE pr.product_id = p.product_id)`
 )
@@ -3,6 +3,7 @@ package product
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -13,10 +14,14 @@ import (
 //  Product UseCase interface
 type ProductUseCase interface {
 	Create(ctx context.Context, product *models.Product) (*models.Product, error)
-	FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.Product, error)
-	FindAllByBrandId(ctx context.Context, brandID uuid.UUID, pagination *utils.Pagination) ([]models.Product, error)
+	FindAll(ctx context.Context, filter *models.ProductFilter, pagination *utils.Pagination) ([]models.Product, error)
+	Export(ctx context.Context, filter *models.ProductFilter, sort string, fn func(product *models.Product) error) error
 	FindById(ctx context.Context, productID uuid.UUID) (*models.Product, error)
 	CachedFindById(ctx context.Context, productID uuid.UUID) (*models.Product, error)
 	UpdateById(ctx context.Context, product *models.Product) (*models.Product, error)
-	DeleteById(ctx context.Context, productID uuid.UUID) error
+	DeleteById(ctx context.Context, productID uuid.UUID, versions []int) error
+	FindAllWithDeleted(ctx context.Context, filter *models.ProductFilter, pagination *utils.Pagination) ([]models.Product, error)
+	FindByIdWithDeleted(ctx context.Context, productID uuid.UUID) (*models.Product, error)
+	RestoreById(ctx context.Context, productID uuid.UUID) (*models.Product, error)
+	PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error)
 }
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-redis/redis/v8"
 	"github.com/google/uuid"
@@ -38,9 +39,9 @@ func (u *productUseCase) Create(ctx context.Context, product *models.Product) (*
 	return u.productPgRepo.Create(ctx, product)
 }
 
-// FindAll find products
-func (u *productUseCase) FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.Product, error) {
-	products, err := u.productPgRepo.FindAll(ctx, pagination)
+// FindAll find products matching filter
+func (u *productUseCase) FindAll(ctx context.Context, filter *models.ProductFilter, pagination *utils.Pagination) ([]models.Product, error) {
+	products, err := u.productPgRepo.FindAll(ctx, filter, pagination)
 	if err != nil {
 		return nil, errors.Wrap(err, "productPgRepo.FindAll")
 	}
@@ -48,14 +49,13 @@ func (u *productUseCase) FindAll(ctx context.Context, pagination *utils.Paginati
 	return products, nil
 }
 
-// FindAllByBrandId find products by brand id
-func (u *productUseCase) FindAllByBrandId(ctx context.Context, brandID uuid.UUID, pagination *utils.Pagination) ([]models.Product, error) {
-	products, err := u.productPgRepo.FindAllByBrandId(ctx, brandID, pagination)
-	if err != nil {
-		return nil, errors.Wrap(err, "productPgRepo.FindAllByBrandId")
+// Export call fn with each product matching filter in turn, sorted by sort
+func (u *productUseCase) Export(ctx context.Context, filter *models.ProductFilter, sort string, fn func(product *models.Product) error) error {
+	if err := u.productPgRepo.Export(ctx, filter, sort, fn); err != nil {
+		return errors.Wrap(err, "productPgRepo.Export")
 	}
 
-	return products, nil
+	return nil
 }
 
 // FindById find product by uuid
@@ -104,9 +104,9 @@ func (u *productUseCase) UpdateById(ctx context.Context, product *models.Product
 	return updatedProduct, nil
 }
 
-// DeleteById delete product by uuid
-func (u *productUseCase) DeleteById(ctx context.Context, productID uuid.UUID) error {
-	err := u.productPgRepo.DeleteById(ctx, productID)
+// DeleteById delete product by uuid, at one of versions unless nil
+func (u *productUseCase) DeleteById(ctx context.Context, productID uuid.UUID, versions []int) error {
+	err := u.productPgRepo.DeleteById(ctx, productID, versions)
 	if err != nil {
 		return errors.Wrap(err, "productPgRepo.DeleteById")
 	}
@@ -117,3 +117,47 @@ func (u *productUseCase) DeleteById(ctx context.Context, productID uuid.UUID) er
 
 	return nil
 }
+
+// FindAllWithDeleted find products matching filter including soft deleted ones
+func (u *productUseCase) FindAllWithDeleted(ctx context.Context, filter *models.ProductFilter, pagination *utils.Pagination) ([]models.Product, error) {
+	products, err := u.productPgRepo.FindAllWithDeleted(ctx, filter, pagination)
+	if err != nil {
+		return nil, errors.Wrap(err, "productPgRepo.FindAllWithDeleted")
+	}
+
+	return products, nil
+}
+
+// FindByIdWithDeleted find product by uuid including soft deleted ones
+func (u *productUseCase) FindByIdWithDeleted(ctx context.Context, productID uuid.UUID) (*models.Product, error) {
+	foundProduct, err := u.productPgRepo.FindByIdWithDeleted(ctx, productID)
+	if err != nil {
+		return nil, errors.Wrap(err, "productPgRepo.FindByIdWithDeleted")
+	}
+
+	return foundProduct, nil
+}
+
+// RestoreById restore soft deleted product by uuid
+func (u *productUseCase) RestoreById(ctx context.Context, productID uuid.UUID) (*models.Product, error) {
+	restoredProduct, err := u.productPgRepo.RestoreById(ctx, productID)
+	if err != nil {
+		return nil, errors.Wrap(err, "productPgRepo.RestoreById")
+	}
+
+	if err := u.redisRepo.SetProductCtx(ctx, restoredProduct.ProductID.String(), productByIdCacheDuration, restoredProduct); err != nil {
+		u.logger.Errorf("redisRepo.SetProductCtx", err)
+	}
+
+	return restoredProduct, nil
+}
+
+// PurgeDeleted hard delete products soft deleted before deletedBefore
+func (u *productUseCase) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	purged, err := u.productPgRepo.PurgeDeleted(ctx, deletedBefore)
+	if err != nil {
+		return 0, errors.Wrap(err, "productPgRepo.PurgeDeleted")
+	}
+
+	return purged, nil
+}
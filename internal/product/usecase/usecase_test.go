@@ -78,9 +78,10 @@ func TestProductUseCase_FindAll(t *testing.T) {
 
 	ctx := context.Background()
 
-	brandPGRepository.EXPECT().FindAll(gomock.Any(), nil).AnyTimes().Return(append([]models.Product{}, *mockProduct), nil)
+	filter := &models.ProductFilter{}
+	brandPGRepository.EXPECT().FindAll(gomock.Any(), filter, nil).AnyTimes().Return(append([]models.Product{}, *mockProduct), nil)
 
-	brands, err := brandUC.FindAll(ctx, nil)
+	brands, err := brandUC.FindAll(ctx, filter, nil)
 	require.NoError(t, err)
 	require.NotNil(t, brands)
 	require.Equal(t, len(brands), 1)
@@ -111,9 +112,10 @@ func TestProductUseCase_FindAllByBrandId(t *testing.T) {
 
 	ctx := context.Background()
 
-	brandPGRepository.EXPECT().FindAllByBrandId(gomock.Any(), brandUUID, nil).AnyTimes().Return(append([]models.Product{}, *mockProduct), nil)
+	filter := &models.ProductFilter{BrandID: &brandUUID}
+	brandPGRepository.EXPECT().FindAll(gomock.Any(), filter, nil).AnyTimes().Return(append([]models.Product{}, *mockProduct), nil)
 
-	brands, err := brandUC.FindAllByBrandId(ctx, brandUUID, nil)
+	brands, err := brandUC.FindAll(ctx, filter, nil)
 	require.NoError(t, err)
 	require.NotNil(t, brands)
 	require.Equal(t, len(brands), 1)
@@ -249,10 +251,10 @@ func TestProductUseCase_DeleteById(t *testing.T) {
 
 	ctx := context.Background()
 
-	productPGRepository.EXPECT().DeleteById(gomock.Any(), mockProduct.ProductID).Return(nil)
+	productPGRepository.EXPECT().DeleteById(gomock.Any(), mockProduct.ProductID, []int{1}).Return(nil)
 	productRedisRepository.EXPECT().DeleteProductCtx(gomock.Any(), mockProduct.ProductID.String()).AnyTimes().Return(nil)
 
-	err := productUC.DeleteById(ctx, mockProduct.ProductID)
+	err := productUC.DeleteById(ctx, mockProduct.ProductID, []int{1})
 	require.NoError(t, err)
 
 	productPGRepository.EXPECT().FindById(gomock.Any(), mockProduct.ProductID).AnyTimes().Return(nil, nil)
@@ -0,0 +1,29 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PromotionCreateRequestDto struct {
+	Code         *string    `json:"code" validate:"omitempty,lte=64"`
+	Name         string     `json:"name" validate:"required,lte=100"`
+	Type         string     `json:"type" validate:"required,oneof=percentage fixed_amount buy_x_get_y free_shipping"`
+	Value        float64    `json:"value" validate:"gte=0"`
+	BuyQuantity  uint64     `json:"buy_quantity"`
+	GetQuantity  uint64     `json:"get_quantity"`
+	BrandID      *uuid.UUID `json:"brand_id"`
+	ProductID    *uuid.UUID `json:"product_id"`
+	Category     *string    `json:"category" validate:"omitempty,lte=64"`
+	StartsAt     *time.Time `json:"starts_at"`
+	EndsAt       *time.Time `json:"ends_at"`
+	UsageLimit   *int       `json:"usage_limit" validate:"omitempty,gt=0"`
+	PerUserLimit *int       `json:"per_user_limit" validate:"omitempty,gt=0"`
+	Stackable    bool       `json:"stackable"`
+	Active       *bool      `json:"active"`
+}
+
+type PromotionCreateResponseDto struct {
+	PromotionID uuid.UUID `json:"promotion_id" validate:"required"`
+}
@@ -0,0 +1,8 @@
+package dto
+
+import "github.com/dinorain/kalobranded/pkg/utils"
+
+type PromotionFindResponseDto struct {
+	Meta utils.PaginationMetaDto `json:"meta"`
+	Data []*PromotionResponseDto `json:"data"`
+}
@@ -0,0 +1,57 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/internal/models"
+)
+
+type PromotionResponseDto struct {
+	PromotionID  uuid.UUID  `json:"promotion_id"`
+	Code         *string    `json:"code,omitempty"`
+	Name         string     `json:"name"`
+	Type         string     `json:"type"`
+	Value        float64    `json:"value"`
+	BuyQuantity  uint64     `json:"buy_quantity,omitempty"`
+	GetQuantity  uint64     `json:"get_quantity,omitempty"`
+	BrandID      *uuid.UUID `json:"brand_id,omitempty"`
+	ProductID    *uuid.UUID `json:"product_id,omitempty"`
+	Category     *string    `json:"category,omitempty"`
+	StartsAt     time.Time  `json:"starts_at"`
+	EndsAt       *time.Time `json:"ends_at,omitempty"`
+	UsageLimit   *int       `json:"usage_limit,omitempty"`
+	PerUserLimit *int       `json:"per_user_limit,omitempty"`
+	UsageCount   int        `json:"usage_count"`
+	Stackable    bool       `json:"stackable"`
+	Active       bool       `json:"active"`
+	Version      int        `json:"version"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+func PromotionResponseFromModel(promotion *models.Promotion) *PromotionResponseDto {
+	return &PromotionResponseDto{
+		PromotionID:  promotion.PromotionID,
+		Code:         promotion.Code,
+		Name:         promotion.Name,
+		Type:         promotion.Type,
+		Value:        promotion.Value,
+		BuyQuantity:  promotion.BuyQuantity,
+		GetQuantity:  promotion.GetQuantity,
+		BrandID:      promotion.BrandID,
+		ProductID:    promotion.ProductID,
+		Category:     promotion.Category,
+		StartsAt:     promotion.StartsAt,
+		EndsAt:       promotion.EndsAt,
+		UsageLimit:   promotion.UsageLimit,
+		PerUserLimit: promotion.PerUserLimit,
+		UsageCount:   promotion.UsageCount,
+		Stackable:    promotion.Stackable,
+		Active:       promotion.Active,
+		Version:      promotion.Version,
+		CreatedAt:    promotion.CreatedAt,
+		UpdatedAt:    promotion.UpdatedAt,
+	}
+}
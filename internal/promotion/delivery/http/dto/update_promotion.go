@@ -0,0 +1,23 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PromotionUpdateRequestDto struct {
+	Code         *string    `json:"code" validate:"omitempty,lte=64"`
+	Name         *string    `json:"name" validate:"omitempty,lte=100"`
+	Value        *float64   `json:"value" validate:"omitempty,gte=0"`
+	BuyQuantity  *uint64    `json:"buy_quantity"`
+	GetQuantity  *uint64    `json:"get_quantity"`
+	ProductID    *uuid.UUID `json:"product_id"`
+	Category     *string    `json:"category" validate:"omitempty,lte=64"`
+	StartsAt     *time.Time `json:"starts_at"`
+	EndsAt       *time.Time `json:"ends_at"`
+	UsageLimit   *int       `json:"usage_limit" validate:"omitempty,gt=0"`
+	PerUserLimit *int       `json:"per_user_limit" validate:"omitempty,gt=0"`
+	Stackable    *bool      `json:"stackable"`
+	Active       *bool      `json:"active"`
+}
@@ -0,0 +1,434 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/brand"
+	"github.com/dinorain/kalobranded/internal/middlewares"
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/internal/product"
+	"github.com/dinorain/kalobranded/internal/promotion"
+	"github.com/dinorain/kalobranded/internal/promotion/delivery/http/dto"
+	"github.com/dinorain/kalobranded/internal/server/router"
+	"github.com/dinorain/kalobranded/pkg/constants"
+	httpErrors "github.com/dinorain/kalobranded/pkg/http_errors"
+	"github.com/dinorain/kalobranded/pkg/logger"
+	"github.com/dinorain/kalobranded/pkg/utils"
+)
+
+type promotionHandlersHTTP struct {
+	router      *router.Router
+	logger      logger.Logger
+	cfg         *config.Config
+	mw          middlewares.MiddlewareManager
+	v           *validator.Validate
+	promotionUC promotion.PromotionUseCase
+	brandUC     brand.BrandUseCase
+	productUC   product.ProductUseCase
+}
+
+var _ promotion.PromotionHandlers = (*promotionHandlersHTTP)(nil)
+
+func NewPromotionHandlersHTTP(
+	router *router.Router,
+	logger logger.Logger,
+	cfg *config.Config,
+	mw middlewares.MiddlewareManager,
+	v *validator.Validate,
+	promotionUC promotion.PromotionUseCase,
+	brandUC brand.BrandUseCase,
+	productUC product.ProductUseCase,
+) *promotionHandlersHTTP {
+	return &promotionHandlersHTTP{router: router, logger: logger, cfg: cfg, mw: mw, v: v, promotionUC: promotionUC, brandUC: brandUC, productUC: productUC}
+}
+
+// Create
+// @Tags Promotions
+// @Summary Create promotion
+// @Description Admin or seller create a promotion, applied automatically or with its coupon code when set. Sellers create promotions of their brand only, admins may leave the brand out for every brand
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param payload body dto.PromotionCreateRequestDto true "Payload"
+// @Success 201 {object} dto.PromotionCreateResponseDto
+// @Router /promotions [post]
+func (h *promotionHandlersHTTP) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	createDto := &dto.PromotionCreateRequestDto{}
+	if err := json.NewDecoder(r.Body).Decode(createDto); err != nil {
+		h.logger.Errorf("decoder.Decode: %v", err)
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	if err := h.v.Struct(createDto); err != nil {
+		h.logger.Errorf("h.v.Struct: %v", err)
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	if createDto.BrandID != nil {
+		if err := h.mw.CheckBrand(w, r, *createDto.BrandID); err != nil {
+			return
+		}
+		if _, err := h.brandUC.CachedFindById(ctx, *createDto.BrandID); err != nil {
+			h.logger.Errorf("brandUC.CachedFindById: %v", err)
+			_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+			return
+		}
+	} else {
+		// Sellers create promotions of their own brand, promotions of every brand are for admins
+		sellerBrandID, err := h.getSellerBrand(w, r)
+		if err != nil {
+			return
+		}
+		createDto.BrandID = sellerBrandID
+	}
+
+	promotion := h.registerReqToPromotionModel(createDto)
+	if err := h.checkProduct(w, r, promotion); err != nil {
+		return
+	}
+
+	if err := promotion.PrepareCreate(); err != nil {
+		h.logger.Errorf("promotion.PrepareCreate: %v", err)
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	createdPromotion, err := h.promotionUC.Create(ctx, promotion)
+	if err != nil {
+		h.logger.Errorf("promotionUC.Create: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	res, _ := json.Marshal(dto.PromotionCreateResponseDto{PromotionID: createdPromotion.PromotionID})
+	w.WriteHeader(http.StatusCreated)
+	w.Write(res)
+	return
+}
+
+// FindAll
+// @Tags Promotions
+// @Summary Find all promotions
+// @Description Admin find all promotions, sellers find the promotions of their brand
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param size query string false "pagination size"
+// @Param page query string false "pagination page"
+// @Success 200 {object} dto.PromotionFindResponseDto
+// @Router /promotions [get]
+func (h *promotionHandlersHTTP) FindAll(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	queryParam := r.URL.Query()
+	pq := utils.NewPaginationFromQueryParams(queryParam.Get(constants.Size), queryParam.Get(constants.Page))
+
+	sellerBrandID, err := h.getSellerBrand(w, r)
+	if err != nil {
+		return
+	}
+
+	var promotions []models.Promotion
+	if sellerBrandID != nil {
+		promotions, err = h.promotionUC.FindAllByBrandId(ctx, *sellerBrandID, pq)
+	} else {
+		promotions, err = h.promotionUC.FindAll(ctx, pq)
+	}
+	if err != nil {
+		h.logger.Errorf("promotionUC.FindAll: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	resDto := dto.PromotionFindResponseDto{
+		Data: make([]*dto.PromotionResponseDto, 0, len(promotions)),
+		Meta: utils.PaginationMetaDto{
+			Limit:  pq.GetLimit(),
+			Offset: pq.GetOffset(),
+			Page:   pq.GetPage(),
+		},
+	}
+	for i := range promotions {
+		resDto.Data = append(resDto.Data, dto.PromotionResponseFromModel(&promotions[i]))
+	}
+
+	res, _ := json.Marshal(resDto)
+	w.WriteHeader(http.StatusOK)
+	w.Write(res)
+	return
+}
+
+// FindById
+// @Tags Promotions
+// @Summary Find promotion by id
+// @Description Admin or seller of the promotion brand find promotion by id
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "promotion uuid"
+// @Success 200 {object} dto.PromotionResponseDto
+// @Header 200 {string} ETag "resource version"
+// @Router /promotions/{id} [get]
+func (h *promotionHandlersHTTP) FindById(w http.ResponseWriter, r *http.Request) {
+	promotion, err := h.findPromotion(w, r)
+	if err != nil {
+		return
+	}
+
+	w.Header().Set(constants.ETag, utils.ETag(promotion.Version))
+	res, _ := json.Marshal(dto.PromotionResponseFromModel(promotion))
+	w.WriteHeader(http.StatusOK)
+	w.Write(res)
+	return
+}
+
+// UpdateById
+// @Tags Promotions
+// @Summary Update promotion
+// @Description Admin or seller of the promotion brand update promotion, only provided fields are changed, type and brand are kept
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "promotion uuid"
+// @Param If-Match header string false "ETag of the version being changed"
+// @Param payload body dto.PromotionUpdateRequestDto true "Payload"
+// @Success 200 {object} dto.PromotionResponseDto
+// @Header 200 {string} ETag "resource version"
+// @Router /promotions/{id} [patch]
+func (h *promotionHandlersHTTP) UpdateById(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	updateDto := &dto.PromotionUpdateRequestDto{}
+	if err := json.NewDecoder(r.Body).Decode(updateDto); err != nil {
+		h.logger.Errorf("decoder.Decode: %v", err)
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	if err := h.v.Struct(updateDto); err != nil {
+		h.logger.Errorf("h.v.Struct: %v", err)
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	promotion, err := h.findPromotion(w, r)
+	if err != nil {
+		return
+	}
+
+	if !utils.IfMatch(r.Header.Get(constants.IfMatch), promotion.Version) {
+		_ = httpErrors.ErrorCtxResponse(w, httpErrors.PreconditionFailed, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	h.updateReqToPromotionModel(promotion, updateDto)
+	if updateDto.ProductID != nil {
+		if err := h.checkProduct(w, r, promotion); err != nil {
+			return
+		}
+	}
+
+	if err := promotion.PrepareCreate(); err != nil {
+		h.logger.Errorf("promotion.PrepareCreate: %v", err)
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	updatedPromotion, err := h.promotionUC.UpdateById(ctx, promotion)
+	if err != nil {
+		h.logger.Errorf("promotionUC.UpdateById: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	w.Header().Set(constants.ETag, utils.ETag(updatedPromotion.Version))
+	res, _ := json.Marshal(dto.PromotionResponseFromModel(updatedPromotion))
+	w.WriteHeader(http.StatusOK)
+	w.Write(res)
+	return
+}
+
+// DeleteById
+// @Tags Promotions
+// @Summary Delete promotion
+// @Description Admin or seller of the promotion brand delete promotion, orders keep the discounts they were given
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "promotion uuid"
+// @Param If-Match header string false "ETag of the version being changed"
+// @Success 204 {object} nil
+// @Router /promotions/{id} [delete]
+func (h *promotionHandlersHTTP) DeleteById(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	promotion, err := h.findPromotion(w, r)
+	if err != nil {
+		return
+	}
+
+	if ifMatch := r.Header.Get(constants.IfMatch); ifMatch != "" && !utils.IfMatch(ifMatch, promotion.Version) {
+		_ = httpErrors.ErrorCtxResponse(w, httpErrors.PreconditionFailed, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	if err := h.promotionUC.DeleteById(ctx, promotion.PromotionID); err != nil {
+		h.logger.Errorf("promotionUC.DeleteById: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return
+}
+
+func (h *promotionHandlersHTTP) registerReqToPromotionModel(r *dto.PromotionCreateRequestDto) *models.Promotion {
+	promotionCandidate := &models.Promotion{
+		Code:         r.Code,
+		Name:         r.Name,
+		Type:         r.Type,
+		Value:        r.Value,
+		BuyQuantity:  r.BuyQuantity,
+		GetQuantity:  r.GetQuantity,
+		BrandID:      r.BrandID,
+		ProductID:    r.ProductID,
+		Category:     r.Category,
+		EndsAt:       r.EndsAt,
+		UsageLimit:   r.UsageLimit,
+		PerUserLimit: r.PerUserLimit,
+		Stackable:    r.Stackable,
+		Active:       true,
+	}
+	if r.StartsAt != nil {
+		promotionCandidate.StartsAt = *r.StartsAt
+	}
+	if r.Active != nil {
+		promotionCandidate.Active = *r.Active
+	}
+
+	return promotionCandidate
+}
+
+func (h *promotionHandlersHTTP) updateReqToPromotionModel(promotion *models.Promotion, r *dto.PromotionUpdateRequestDto) {
+	if r.Code != nil {
+		promotion.Code = r.Code
+	}
+	if r.Name != nil {
+		promotion.Name = *r.Name
+	}
+	if r.Value != nil {
+		promotion.Value = *r.Value
+	}
+	if r.BuyQuantity != nil {
+		promotion.BuyQuantity = *r.BuyQuantity
+	}
+	if r.GetQuantity != nil {
+		promotion.GetQuantity = *r.GetQuantity
+	}
+	if r.ProductID != nil {
+		promotion.ProductID = r.ProductID
+	}
+	if r.Category != nil {
+		promotion.Category = r.Category
+	}
+	if r.StartsAt != nil {
+		promotion.StartsAt = *r.StartsAt
+	}
+	if r.EndsAt != nil {
+		promotion.EndsAt = r.EndsAt
+	}
+	if r.UsageLimit != nil {
+		promotion.UsageLimit = r.UsageLimit
+	}
+	if r.PerUserLimit != nil {
+		promotion.PerUserLimit = r.PerUserLimit
+	}
+	if r.Stackable != nil {
+		promotion.Stackable = *r.Stackable
+	}
+	if r.Active != nil {
+		promotion.Active = *r.Active
+	}
+}
+
+// checkProduct the product scope of promotion must belong to its brand, promotions for every brand take the
+// brand of the product. Error response is already written when err is not nil
+func (h *promotionHandlersHTTP) checkProduct(w http.ResponseWriter, r *http.Request, promotion *models.Promotion) error {
+	if promotion.ProductID == nil {
+		return nil
+	}
+
+	product, err := h.productUC.CachedFindById(r.Context(), *promotion.ProductID)
+	if err != nil {
+		h.logger.Errorf("productUC.CachedFindById: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return err
+	}
+
+	if promotion.BrandID == nil {
+		promotion.BrandID = &product.BrandID
+	} else if *promotion.BrandID != product.BrandID {
+		err := fmt.Errorf("%w: product %s is not of brand %s", models.ErrInvalidPromotion, product.ProductID, promotion.BrandID)
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return err
+	}
+
+	return nil
+}
+
+// findPromotion find promotion by id, sellers can only act on promotions of their brand, error response is already
+// written when err is not nil
+func (h *promotionHandlersHTTP) findPromotion(w http.ResponseWriter, r *http.Request) (*models.Promotion, error) {
+	promotionUUID, err := uuid.Parse(router.Param(r, constants.ID))
+	if err != nil {
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return nil, err
+	}
+
+	promotion, err := h.promotionUC.FindById(r.Context(), promotionUUID)
+	if err != nil {
+		h.logger.Errorf("promotionUC.FindById: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return nil, err
+	}
+
+	// Promotions of every brand are managed by admins only
+	brandID := uuid.Nil
+	if promotion.BrandID != nil {
+		brandID = *promotion.BrandID
+	}
+	if err := h.mw.CheckBrand(w, r, brandID); err != nil {
+		return nil, err
+	}
+
+	return promotion, nil
+}
+
+// getSellerBrand brand of the token of sellers, nil for admins. Error response is already written when err is not nil
+func (h *promotionHandlersHTTP) getSellerBrand(w http.ResponseWriter, r *http.Request) (*uuid.UUID, error) {
+	jwtClaims, err := h.mw.GetJWTClaims(w, r)
+	if err != nil {
+		return nil, err
+	}
+	claims := *jwtClaims
+	if role, _ := claims["role"].(string); role != models.UserRoleSeller {
+		return nil, nil
+	}
+
+	brandID, _ := claims["brand_id"].(string)
+	brandUUID, err := uuid.Parse(brandID)
+	if err != nil {
+		return nil, httpErrors.NewForbiddenError(w, nil, h.cfg.Http.DebugErrorsResponse)
+	}
+
+	return &brandUUID, nil
+}
@@ -0,0 +1,265 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/golang-jwt/jwt"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/kalobranded/config"
+	mockBrandUC "github.com/dinorain/kalobranded/internal/brand/mock"
+	"github.com/dinorain/kalobranded/internal/middlewares"
+	"github.com/dinorain/kalobranded/internal/models"
+	mockProductUC "github.com/dinorain/kalobranded/internal/product/mock"
+	"github.com/dinorain/kalobranded/internal/promotion/delivery/http/dto"
+	"github.com/dinorain/kalobranded/internal/promotion/mock"
+	"github.com/dinorain/kalobranded/internal/server/router"
+	"github.com/dinorain/kalobranded/pkg/logger"
+	"github.com/dinorain/kalobranded/pkg/utils"
+)
+
+func signedToken(t *testing.T, cfg *config.Config, role string, brandUUID *uuid.UUID) string {
+	token := jwt.New(jwt.SigningMethodHS256)
+	claims := token.Claims.(jwt.MapClaims)
+	claims["session_id"] = uuid.New().String()
+	claims["user_id"] = uuid.New().String()
+	claims["role"] = role
+	if brandUUID != nil {
+		claims["brand_id"] = brandUUID.String()
+	}
+	claims["exp"] = time.Now().Add(time.Minute * 15).Unix()
+	validToken, err := token.SignedString([]byte(cfg.Server.JwtSecretKey))
+	require.NoError(t, err)
+	return validToken
+}
+
+func TestPromotionsHandler_Create(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	promotionUC := mock.NewMockPromotionUseCase(ctrl)
+	brandUC := mockBrandUC.NewMockBrandUseCase(ctrl)
+	productUC := mockProductUC.NewMockProductUseCase(ctrl)
+
+	cfg := &config.Config{Session: config.Session{Expire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	appLogger := logger.NewAppLogger(cfg)
+	appLogger.InitLogger()
+	mw := middlewares.NewMiddlewareManager(appLogger, cfg)
+
+	v := validator.New()
+
+	rt := router.NewRouter(false)
+	handlers := NewPromotionHandlersHTTP(rt, appLogger, cfg, mw, v, promotionUC, brandUC, productUC)
+
+	brandUUID := uuid.New()
+
+	t.Run("Seller", func(t *testing.T) {
+		body := `{"code": " save10 ", "name": "Save 10", "type": "percentage", "value": 10}`
+		req := httptest.NewRequest(http.MethodPost, "/promotions", strings.NewReader(body))
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", signedToken(t, cfg, models.UserRoleSeller, &brandUUID)))
+		w := httptest.NewRecorder()
+
+		promotionUUID := uuid.New()
+		promotionUC.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, p *models.Promotion) (*models.Promotion, error) {
+			require.Equal(t, "SAVE10", *p.Code)
+			require.Equal(t, brandUUID, *p.BrandID)
+			require.True(t, p.Active)
+			p.PromotionID = promotionUUID
+			return p, nil
+		})
+
+		http.HandlerFunc(handlers.Create).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusCreated, w.Code)
+		resDto := &dto.PromotionCreateResponseDto{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), resDto))
+		require.Equal(t, promotionUUID, resDto.PromotionID)
+	})
+
+	t.Run("SellerForeignBrand", func(t *testing.T) {
+		body := fmt.Sprintf(`{"name": "Save 10", "type": "percentage", "value": 10, "brand_id": "%s"}`, uuid.New())
+		req := httptest.NewRequest(http.MethodPost, "/promotions", strings.NewReader(body))
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", signedToken(t, cfg, models.UserRoleSeller, &brandUUID)))
+		w := httptest.NewRecorder()
+
+		http.HandlerFunc(handlers.Create).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("ProductOfOtherBrand", func(t *testing.T) {
+		productUUID := uuid.New()
+		body := fmt.Sprintf(`{"name": "Save 10", "type": "percentage", "value": 10, "product_id": "%s"}`, productUUID)
+		req := httptest.NewRequest(http.MethodPost, "/promotions", strings.NewReader(body))
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", signedToken(t, cfg, models.UserRoleSeller, &brandUUID)))
+		w := httptest.NewRecorder()
+
+		productUC.EXPECT().CachedFindById(gomock.Any(), productUUID).Return(&models.Product{ProductID: productUUID, BrandID: uuid.New()}, nil)
+
+		http.HandlerFunc(handlers.Create).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("InvalidRule", func(t *testing.T) {
+		body := `{"name": "Save all", "type": "percentage", "value": 150}`
+		req := httptest.NewRequest(http.MethodPost, "/promotions", strings.NewReader(body))
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", signedToken(t, cfg, models.UserRoleAdmin, nil)))
+		w := httptest.NewRecorder()
+
+		http.HandlerFunc(handlers.Create).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestPromotionsHandler_FindAll(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	promotionUC := mock.NewMockPromotionUseCase(ctrl)
+	brandUC := mockBrandUC.NewMockBrandUseCase(ctrl)
+	productUC := mockProductUC.NewMockProductUseCase(ctrl)
+
+	cfg := &config.Config{Session: config.Session{Expire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	appLogger := logger.NewAppLogger(cfg)
+	mw := middlewares.NewMiddlewareManager(appLogger, cfg)
+
+	v := validator.New()
+
+	rt := router.NewRouter(false)
+	handlers := NewPromotionHandlersHTTP(rt, appLogger, cfg, mw, v, promotionUC, brandUC, productUC)
+
+	brandUUID := uuid.New()
+
+	req := httptest.NewRequest(http.MethodGet, "/promotions?size=10&page=1", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", signedToken(t, cfg, models.UserRoleSeller, &brandUUID)))
+	w := httptest.NewRecorder()
+
+	promotionUC.EXPECT().FindAllByBrandId(gomock.Any(), brandUUID, gomock.Any()).Return([]models.Promotion{{PromotionID: uuid.New(), BrandID: &brandUUID}}, nil)
+
+	http.HandlerFunc(handlers.FindAll).ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	resDto := &dto.PromotionFindResponseDto{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), resDto))
+	require.Len(t, resDto.Data, 1)
+}
+
+func TestPromotionsHandler_UpdateById(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	promotionUC := mock.NewMockPromotionUseCase(ctrl)
+	brandUC := mockBrandUC.NewMockBrandUseCase(ctrl)
+	productUC := mockProductUC.NewMockProductUseCase(ctrl)
+
+	cfg := &config.Config{Session: config.Session{Expire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	appLogger := logger.NewAppLogger(cfg)
+	appLogger.InitLogger()
+	mw := middlewares.NewMiddlewareManager(appLogger, cfg)
+
+	v := validator.New()
+
+	rt := router.NewRouter(false)
+	handlers := NewPromotionHandlersHTTP(rt, appLogger, cfg, mw, v, promotionUC, brandUC, productUC)
+
+	brandUUID := uuid.New()
+	promotionUUID := uuid.New()
+	newPromotion := func() *models.Promotion {
+		return &models.Promotion{PromotionID: promotionUUID, Name: "Save 10", Type: models.PromotionTypePercentage, Value: 10, BrandID: &brandUUID, StartsAt: time.Now(), Active: true, Version: 1}
+	}
+
+	t.Run("Seller", func(t *testing.T) {
+		req := router.WithParams(httptest.NewRequest(http.MethodPatch, "/promotions/"+promotionUUID.String(), strings.NewReader(`{"value": 20}`)), map[string]string{"id": promotionUUID.String()})
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", signedToken(t, cfg, models.UserRoleSeller, &brandUUID)))
+		req.Header.Set("If-Match", utils.ETag(1))
+		w := httptest.NewRecorder()
+
+		promotionUC.EXPECT().FindById(gomock.Any(), promotionUUID).Return(newPromotion(), nil)
+		promotionUC.EXPECT().UpdateById(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, p *models.Promotion) (*models.Promotion, error) {
+			require.Equal(t, 20.0, p.Value)
+			updated := *p
+			updated.Version = 2
+			return &updated, nil
+		})
+
+		http.HandlerFunc(handlers.UpdateById).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, utils.ETag(2), w.Header().Get("ETag"))
+	})
+
+	t.Run("OtherSeller", func(t *testing.T) {
+		otherBrandUUID := uuid.New()
+		req := router.WithParams(httptest.NewRequest(http.MethodPatch, "/promotions/"+promotionUUID.String(), strings.NewReader(`{"value": 20}`)), map[string]string{"id": promotionUUID.String()})
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", signedToken(t, cfg, models.UserRoleSeller, &otherBrandUUID)))
+		w := httptest.NewRecorder()
+
+		promotionUC.EXPECT().FindById(gomock.Any(), promotionUUID).Return(newPromotion(), nil)
+
+		http.HandlerFunc(handlers.UpdateById).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("StaleVersion", func(t *testing.T) {
+		req := router.WithParams(httptest.NewRequest(http.MethodPatch, "/promotions/"+promotionUUID.String(), strings.NewReader(`{"value": 20}`)), map[string]string{"id": promotionUUID.String()})
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", signedToken(t, cfg, models.UserRoleAdmin, nil)))
+		req.Header.Set("If-Match", utils.ETag(0))
+		w := httptest.NewRecorder()
+
+		promotionUC.EXPECT().FindById(gomock.Any(), promotionUUID).Return(newPromotion(), nil)
+
+		http.HandlerFunc(handlers.UpdateById).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusPreconditionFailed, w.Code)
+	})
+}
+
+func TestPromotionsHandler_DeleteById(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	promotionUC := mock.NewMockPromotionUseCase(ctrl)
+	brandUC := mockBrandUC.NewMockBrandUseCase(ctrl)
+	productUC := mockProductUC.NewMockProductUseCase(ctrl)
+
+	cfg := &config.Config{Session: config.Session{Expire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	appLogger := logger.NewAppLogger(cfg)
+	mw := middlewares.NewMiddlewareManager(appLogger, cfg)
+
+	v := validator.New()
+
+	rt := router.NewRouter(false)
+	handlers := NewPromotionHandlersHTTP(rt, appLogger, cfg, mw, v, promotionUC, brandUC, productUC)
+
+	promotionUUID := uuid.New()
+	req := router.WithParams(httptest.NewRequest(http.MethodDelete, "/promotions/"+promotionUUID.String(), nil), map[string]string{"id": promotionUUID.String()})
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", signedToken(t, cfg, models.UserRoleAdmin, nil)))
+	w := httptest.NewRecorder()
+
+	promotionUC.EXPECT().FindById(gomock.Any(), promotionUUID).Return(&models.Promotion{PromotionID: promotionUUID, Version: 1}, nil)
+	promotionUC.EXPECT().DeleteById(gomock.Any(), promotionUUID).Return(nil)
+
+	http.HandlerFunc(handlers.DeleteById).ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusNoContent, w.Code)
+}
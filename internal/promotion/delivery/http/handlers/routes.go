@@ -0,0 +1,10 @@
+package handlers
+
+func (h *promotionHandlersHTTP) PromotionMapRoutes() {
+	promotions := h.router.Group("/promotions", h.mw.IsAdminOrSeller)
+	promotions.Post("", h.Create)
+	promotions.Get("", h.FindAll)
+	promotions.Get("/{id}", h.FindById)
+	promotions.Patch("/{id}", h.UpdateById)
+	promotions.Delete("/{id}", h.DeleteById)
+}
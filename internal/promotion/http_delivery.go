@@ -0,0 +1,14 @@
+package promotion
+
+import (
+	"net/http"
+)
+
+// Promotion HTTP Handlers interface
+type PromotionHandlers interface {
+	Create(w http.ResponseWriter, r *http.Request)
+	FindAll(w http.ResponseWriter, r *http.Request)
+	FindById(w http.ResponseWriter, r *http.Request)
+	UpdateById(w http.ResponseWriter, r *http.Request)
+	DeleteById(w http.ResponseWriter, r *http.Request)
+}
@@ -0,0 +1,158 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pg_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/dinorain/kalobranded/internal/models"
+	utils "github.com/dinorain/kalobranded/pkg/utils"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockPromotionPGRepository is a mock of PromotionPGRepository interface.
+type MockPromotionPGRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPromotionPGRepositoryMockRecorder
+}
+
+// MockPromotionPGRepositoryMockRecorder is the mock recorder for MockPromotionPGRepository.
+type MockPromotionPGRepositoryMockRecorder struct {
+	mock *MockPromotionPGRepository
+}
+
+// NewMockPromotionPGRepository creates a new mock instance.
+func NewMockPromotionPGRepository(ctrl *gomock.Controller) *MockPromotionPGRepository {
+	mock := &MockPromotionPGRepository{ctrl: ctrl}
+	mock.recorder = &MockPromotionPGRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPromotionPGRepository) EXPECT() *MockPromotionPGRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountRedemptionsByUserId mocks base method.
+func (m *MockPromotionPGRepository) CountRedemptionsByUserId(ctx context.Context, promotionID, userID uuid.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountRedemptionsByUserId", ctx, promotionID, userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountRedemptionsByUserId indicates an expected call of CountRedemptionsByUserId.
+func (mr *MockPromotionPGRepositoryMockRecorder) CountRedemptionsByUserId(ctx, promotionID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountRedemptionsByUserId", reflect.TypeOf((*MockPromotionPGRepository)(nil).CountRedemptionsByUserId), ctx, promotionID, userID)
+}
+
+// Create mocks base method.
+func (m *MockPromotionPGRepository) Create(ctx context.Context, promotion *models.Promotion) (*models.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, promotion)
+	ret0, _ := ret[0].(*models.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPromotionPGRepositoryMockRecorder) Create(ctx, promotion interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPromotionPGRepository)(nil).Create), ctx, promotion)
+}
+
+// DeleteById mocks base method.
+func (m *MockPromotionPGRepository) DeleteById(ctx context.Context, promotionID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteById", ctx, promotionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteById indicates an expected call of DeleteById.
+func (mr *MockPromotionPGRepositoryMockRecorder) DeleteById(ctx, promotionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockPromotionPGRepository)(nil).DeleteById), ctx, promotionID)
+}
+
+// FindAll mocks base method.
+func (m *MockPromotionPGRepository) FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, pagination)
+	ret0, _ := ret[0].([]models.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockPromotionPGRepositoryMockRecorder) FindAll(ctx, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockPromotionPGRepository)(nil).FindAll), ctx, pagination)
+}
+
+// FindAllByBrandId mocks base method.
+func (m *MockPromotionPGRepository) FindAllByBrandId(ctx context.Context, brandID uuid.UUID, pagination *utils.Pagination) ([]models.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByBrandId", ctx, brandID, pagination)
+	ret0, _ := ret[0].([]models.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByBrandId indicates an expected call of FindAllByBrandId.
+func (mr *MockPromotionPGRepositoryMockRecorder) FindAllByBrandId(ctx, brandID, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByBrandId", reflect.TypeOf((*MockPromotionPGRepository)(nil).FindAllByBrandId), ctx, brandID, pagination)
+}
+
+// FindApplicable mocks base method.
+func (m *MockPromotionPGRepository) FindApplicable(ctx context.Context, item *models.OrderItem, codes []string, at time.Time) ([]models.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindApplicable", ctx, item, codes, at)
+	ret0, _ := ret[0].([]models.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindApplicable indicates an expected call of FindApplicable.
+func (mr *MockPromotionPGRepositoryMockRecorder) FindApplicable(ctx, item, codes, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindApplicable", reflect.TypeOf((*MockPromotionPGRepository)(nil).FindApplicable), ctx, item, codes, at)
+}
+
+// FindById mocks base method.
+func (m *MockPromotionPGRepository) FindById(ctx context.Context, promotionID uuid.UUID) (*models.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, promotionID)
+	ret0, _ := ret[0].(*models.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockPromotionPGRepositoryMockRecorder) FindById(ctx, promotionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockPromotionPGRepository)(nil).FindById), ctx, promotionID)
+}
+
+// UpdateById mocks base method.
+func (m *MockPromotionPGRepository) UpdateById(ctx context.Context, promotion *models.Promotion) (*models.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateById", ctx, promotion)
+	ret0, _ := ret[0].(*models.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateById indicates an expected call of UpdateById.
+func (mr *MockPromotionPGRepositoryMockRecorder) UpdateById(ctx, promotion interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateById", reflect.TypeOf((*MockPromotionPGRepository)(nil).UpdateById), ctx, promotion)
+}
@@ -0,0 +1,142 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/dinorain/kalobranded/internal/models"
+	utils "github.com/dinorain/kalobranded/pkg/utils"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockPromotionUseCase is a mock of PromotionUseCase interface.
+type MockPromotionUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockPromotionUseCaseMockRecorder
+}
+
+// MockPromotionUseCaseMockRecorder is the mock recorder for MockPromotionUseCase.
+type MockPromotionUseCaseMockRecorder struct {
+	mock *MockPromotionUseCase
+}
+
+// NewMockPromotionUseCase creates a new mock instance.
+func NewMockPromotionUseCase(ctrl *gomock.Controller) *MockPromotionUseCase {
+	mock := &MockPromotionUseCase{ctrl: ctrl}
+	mock.recorder = &MockPromotionUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPromotionUseCase) EXPECT() *MockPromotionUseCaseMockRecorder {
+	return m.recorder
+}
+
+// Apply mocks base method.
+func (m *MockPromotionUseCase) Apply(ctx context.Context, order *models.Order, codes []string) (*models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Apply", ctx, order, codes)
+	ret0, _ := ret[0].(*models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Apply indicates an expected call of Apply.
+func (mr *MockPromotionUseCaseMockRecorder) Apply(ctx, order, codes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Apply", reflect.TypeOf((*MockPromotionUseCase)(nil).Apply), ctx, order, codes)
+}
+
+// Create mocks base method.
+func (m *MockPromotionUseCase) Create(ctx context.Context, promotion *models.Promotion) (*models.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, promotion)
+	ret0, _ := ret[0].(*models.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPromotionUseCaseMockRecorder) Create(ctx, promotion interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPromotionUseCase)(nil).Create), ctx, promotion)
+}
+
+// DeleteById mocks base method.
+func (m *MockPromotionUseCase) DeleteById(ctx context.Context, promotionID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteById", ctx, promotionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteById indicates an expected call of DeleteById.
+func (mr *MockPromotionUseCaseMockRecorder) DeleteById(ctx, promotionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockPromotionUseCase)(nil).DeleteById), ctx, promotionID)
+}
+
+// FindAll mocks base method.
+func (m *MockPromotionUseCase) FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, pagination)
+	ret0, _ := ret[0].([]models.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockPromotionUseCaseMockRecorder) FindAll(ctx, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockPromotionUseCase)(nil).FindAll), ctx, pagination)
+}
+
+// FindAllByBrandId mocks base method.
+func (m *MockPromotionUseCase) FindAllByBrandId(ctx context.Context, brandID uuid.UUID, pagination *utils.Pagination) ([]models.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByBrandId", ctx, brandID, pagination)
+	ret0, _ := ret[0].([]models.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByBrandId indicates an expected call of FindAllByBrandId.
+func (mr *MockPromotionUseCaseMockRecorder) FindAllByBrandId(ctx, brandID, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByBrandId", reflect.TypeOf((*MockPromotionUseCase)(nil).FindAllByBrandId), ctx, brandID, pagination)
+}
+
+// FindById mocks base method.
+func (m *MockPromotionUseCase) FindById(ctx context.Context, promotionID uuid.UUID) (*models.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, promotionID)
+	ret0, _ := ret[0].(*models.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockPromotionUseCaseMockRecorder) FindById(ctx, promotionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockPromotionUseCase)(nil).FindById), ctx, promotionID)
+}
+
+// UpdateById mocks base method.
+func (m *MockPromotionUseCase) UpdateById(ctx context.Context, promotion *models.Promotion) (*models.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateById", ctx, promotion)
+	ret0, _ := ret[0].(*models.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateById indicates an expected call of UpdateById.
+func (mr *MockPromotionUseCaseMockRecorder) UpdateById(ctx, promotion interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateById", reflect.TypeOf((*MockPromotionUseCase)(nil).UpdateById), ctx, promotion)
+}
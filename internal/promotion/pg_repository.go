@@ -0,0 +1,24 @@
+//go:generate mockgen -source pg_repository.go -destination mock/pg_repository.go -package mock
+package promotion
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/pkg/utils"
+)
+
+// Promotion pg repository
+type PromotionPGRepository interface {
+	Create(ctx context.Context, promotion *models.Promotion) (*models.Promotion, error)
+	FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.Promotion, error)
+	FindAllByBrandId(ctx context.Context, brandID uuid.UUID, pagination *utils.Pagination) ([]models.Promotion, error)
+	FindById(ctx context.Context, promotionID uuid.UUID) (*models.Promotion, error)
+	FindApplicable(ctx context.Context, item *models.OrderItem, codes []string, at time.Time) ([]models.Promotion, error)
+	CountRedemptionsByUserId(ctx context.Context, promotionID uuid.UUID, userID uuid.UUID) (int, error)
+	UpdateById(ctx context.Context, promotion *models.Promotion) (*models.Promotion, error)
+	DeleteById(ctx context.Context, promotionID uuid.UUID) error
+}
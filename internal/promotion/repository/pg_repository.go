@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/internal/promotion"
+	"github.com/dinorain/kalobranded/pkg/utils"
+)
+
+// Promotion repository
+type PromotionRepository struct {
+	db *sqlx.DB
+}
+
+var _ promotion.PromotionPGRepository = (*PromotionRepository)(nil)
+
+// Promotion repository constructor
+func NewPromotionPGRepository(db *sqlx.DB) *PromotionRepository {
+	return &PromotionRepository{db: db}
+}
+
+// Create new promotion
+func (r *PromotionRepository) Create(ctx context.Context, promotion *models.Promotion) (*models.Promotion, error) {
+	createdPromotion := &models.Promotion{}
+	if err := r.db.QueryRowxContext(
+		ctx,
+		createPromotionQuery,
+		promotion.Code,
+		promotion.Name,
+		promotion.Type,
+		promotion.Value,
+		promotion.BuyQuantity,
+		promotion.GetQuantity,
+		promotion.BrandID,
+		promotion.ProductID,
+		promotion.Category,
+		promotion.StartsAt,
+		promotion.EndsAt,
+		promotion.UsageLimit,
+		promotion.PerUserLimit,
+		promotion.Stackable,
+		promotion.Active,
+	).StructScan(createdPromotion); err != nil {
+		return nil, errors.Wrap(err, "PromotionRepository.Create.QueryRowxContext")
+	}
+
+	return createdPromotion, nil
+}
+
+// FindAll Find promotions, newest first
+func (r *PromotionRepository) FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.Promotion, error) {
+	var promotions []models.Promotion
+	if err := r.db.SelectContext(ctx, &promotions, findAllQuery, pagination.GetLimit(), pagination.GetOffset()); err != nil {
+		return nil, errors.Wrap(err, "PromotionRepository.FindAll.SelectContext")
+	}
+
+	return promotions, nil
+}
+
+// FindAllByBrandId Find promotions of brand uuid, newest first
+func (r *PromotionRepository) FindAllByBrandId(ctx context.Context, brandID uuid.UUID, pagination *utils.Pagination) ([]models.Promotion, error) {
+	var promotions []models.Promotion
+	if err := r.db.SelectContext(ctx, &promotions, findAllByBrandIdQuery, brandID, pagination.GetLimit(), pagination.GetOffset()); err != nil {
+		return nil, errors.Wrap(err, "PromotionRepository.FindAllByBrandId.SelectContext")
+	}
+
+	return promotions, nil
+}
+
+// FindById Find promotion by uuid
+func (r *PromotionRepository) FindById(ctx context.Context, promotionID uuid.UUID) (*models.Promotion, error) {
+	promotion := &models.Promotion{}
+	if err := r.db.GetContext(ctx, promotion, findByIdQuery, promotionID); err != nil {
+		return nil, errors.Wrap(err, "PromotionRepository.FindById.GetContext")
+	}
+
+	return promotion, nil
+}
+
+// FindApplicable Find promotions running at covering item, automatic ones and coupons among codes
+func (r *PromotionRepository) FindApplicable(ctx context.Context, item *models.OrderItem, codes []string, at time.Time) ([]models.Promotion, error) {
+	var promotions []models.Promotion
+	if err := r.db.SelectContext(ctx, &promotions, findApplicableQuery, item.BrandID, item.ProductID, item.Category, pq.Array(codes), at); err != nil {
+		return nil, errors.Wrap(err, "PromotionRepository.FindApplicable.SelectContext")
+	}
+
+	return promotions, nil
+}
+
+// CountRedemptionsByUserId Count orders of user uuid that redeemed promotion uuid
+func (r *PromotionRepository) CountRedemptionsByUserId(ctx context.Context, promotionID uuid.UUID, userID uuid.UUID) (int, error) {
+	var cnt int
+	if err := r.db.GetContext(ctx, &cnt, countRedemptionsByUserIdQuery, promotionID, userID); err != nil {
+		return 0, errors.Wrap(err, "PromotionRepository.CountRedemptionsByUserId.GetContext")
+	}
+
+	return cnt, nil
+}
+
+// UpdateById update existing promotion when its version is unchanged, type and brand are kept
+func (r *PromotionRepository) UpdateById(ctx context.Context, promotion *models.Promotion) (*models.Promotion, error) {
+	updatedPromotion := &models.Promotion{}
+	if err := r.db.QueryRowxContext(
+		ctx,
+		updateByIdQuery,
+		promotion.PromotionID,
+		promotion.Code,
+		promotion.Name,
+		promotion.Value,
+		promotion.BuyQuantity,
+		promotion.GetQuantity,
+		promotion.ProductID,
+		promotion.Category,
+		promotion.StartsAt,
+		promotion.EndsAt,
+		promotion.UsageLimit,
+		promotion.PerUserLimit,
+		promotion.Stackable,
+		promotion.Active,
+		promotion.Version,
+	).StructScan(updatedPromotion); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, models.ErrVersionConflict
+		}
+		return nil, errors.Wrap(err, "PromotionRepository.UpdateById.QueryRowxContext")
+	}
+
+	return updatedPromotion, nil
+}
+
+// DeleteById soft delete promotion by uuid, its redemptions stay with their orders
+func (r *PromotionRepository) DeleteById(ctx context.Context, promotionID uuid.UUID) error {
+	if res, err := r.db.ExecContext(ctx, deleteByIdQuery, promotionID); err != nil {
+		return errors.Wrap(err, "PromotionRepository.DeleteById.ExecContext")
+	} else {
+		cnt, err := res.RowsAffected()
+		if err != nil {
+			return errors.Wrap(err, "PromotionRepository.DeleteById.RowsAffected")
+		} else if cnt == 0 {
+			return sql.ErrNoRows
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,204 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/kalobranded/internal/models"
+)
+
+func TestPromotionRepository_Create(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	promotionPGRepository := NewPromotionPGRepository(sqlxDB)
+
+	code := "SAVE10"
+	brandUUID := uuid.New()
+	mockPromotion := &models.Promotion{
+		Code:     &code,
+		Name:     "Name",
+		Type:     models.PromotionTypePercentage,
+		Value:    10,
+		BrandID:  &brandUUID,
+		StartsAt: time.Now(),
+		Active:   true,
+	}
+
+	promotionUUID := uuid.New()
+	rows := sqlmock.NewRows([]string{"promotion_id", "code", "name", "type", "value", "brand_id"}).AddRow(
+		promotionUUID,
+		code,
+		mockPromotion.Name,
+		mockPromotion.Type,
+		mockPromotion.Value,
+		brandUUID,
+	)
+
+	mock.ExpectQuery(createPromotionQuery).WithArgs(
+		mockPromotion.Code,
+		mockPromotion.Name,
+		mockPromotion.Type,
+		mockPromotion.Value,
+		mockPromotion.BuyQuantity,
+		mockPromotion.GetQuantity,
+		mockPromotion.BrandID,
+		mockPromotion.ProductID,
+		mockPromotion.Category,
+		mockPromotion.StartsAt,
+		mockPromotion.EndsAt,
+		mockPromotion.UsageLimit,
+		mockPromotion.PerUserLimit,
+		mockPromotion.Stackable,
+		mockPromotion.Active,
+	).WillReturnRows(rows)
+
+	createdPromotion, err := promotionPGRepository.Create(context.Background(), mockPromotion)
+	require.NoError(t, err)
+	require.Equal(t, promotionUUID, createdPromotion.PromotionID)
+	require.Equal(t, code, *createdPromotion.Code)
+	require.Equal(t, brandUUID, *createdPromotion.BrandID)
+}
+
+func TestPromotionRepository_FindApplicable(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	promotionPGRepository := NewPromotionPGRepository(sqlxDB)
+
+	item := &models.OrderItem{ProductID: uuid.New(), BrandID: uuid.New(), Category: "shoes"}
+	codes := []string{"SAVE10"}
+	at := time.Now()
+
+	rows := sqlmock.NewRows([]string{"promotion_id", "code", "name", "type", "value"}).
+		AddRow(uuid.New(), nil, "Automatic", models.PromotionTypeFreeShipping, 0).
+		AddRow(uuid.New(), "SAVE10", "Coupon", models.PromotionTypePercentage, 10)
+
+	mock.ExpectQuery(findApplicableQuery).WithArgs(item.BrandID, item.ProductID, item.Category, pq.Array(codes), at).WillReturnRows(rows)
+
+	promotions, err := promotionPGRepository.FindApplicable(context.Background(), item, codes, at)
+	require.NoError(t, err)
+	require.Len(t, promotions, 2)
+	require.False(t, promotions[0].IsCoupon())
+	require.True(t, promotions[1].IsCoupon())
+}
+
+func TestPromotionRepository_CountRedemptionsByUserId(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	promotionPGRepository := NewPromotionPGRepository(sqlxDB)
+
+	promotionUUID := uuid.New()
+	userUUID := uuid.New()
+	mock.ExpectQuery(countRedemptionsByUserIdQuery).WithArgs(promotionUUID, userUUID).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+	cnt, err := promotionPGRepository.CountRedemptionsByUserId(context.Background(), promotionUUID, userUUID)
+	require.NoError(t, err)
+	require.Equal(t, 2, cnt)
+}
+
+func TestPromotionRepository_UpdateById(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	promotionPGRepository := NewPromotionPGRepository(sqlxDB)
+
+	mockPromotion := &models.Promotion{
+		PromotionID: uuid.New(),
+		Name:        "NameChanged",
+		Type:        models.PromotionTypeFixedAmount,
+		Value:       5000,
+		StartsAt:    time.Now(),
+		Active:      false,
+		Version:     1,
+	}
+
+	mock.ExpectQuery(updateByIdQuery).WithArgs(
+		mockPromotion.PromotionID,
+		mockPromotion.Code,
+		mockPromotion.Name,
+		mockPromotion.Value,
+		mockPromotion.BuyQuantity,
+		mockPromotion.GetQuantity,
+		mockPromotion.ProductID,
+		mockPromotion.Category,
+		mockPromotion.StartsAt,
+		mockPromotion.EndsAt,
+		mockPromotion.UsageLimit,
+		mockPromotion.PerUserLimit,
+		mockPromotion.Stackable,
+		mockPromotion.Active,
+		mockPromotion.Version,
+	).WillReturnRows(sqlmock.NewRows([]string{"promotion_id", "name", "active", "version"}).AddRow(mockPromotion.PromotionID, mockPromotion.Name, false, 2))
+
+	updatedPromotion, err := promotionPGRepository.UpdateById(context.Background(), mockPromotion)
+	require.NoError(t, err)
+	require.Equal(t, "NameChanged", updatedPromotion.Name)
+	require.Equal(t, 2, updatedPromotion.Version)
+
+	t.Run("VersionConflict", func(t *testing.T) {
+		mock.ExpectQuery(updateByIdQuery).WillReturnError(sql.ErrNoRows)
+
+		_, err := promotionPGRepository.UpdateById(context.Background(), mockPromotion)
+		require.ErrorIs(t, err, models.ErrVersionConflict)
+	})
+}
+
+func TestPromotionRepository_DeleteById(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	promotionPGRepository := NewPromotionPGRepository(sqlxDB)
+
+	promotionUUID := uuid.New()
+	mock.ExpectExec(deleteByIdQuery).WithArgs(promotionUUID).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = promotionPGRepository.DeleteById(context.Background(), promotionUUID)
+	require.NoError(t, err)
+
+	t.Run("NotFound", func(t *testing.T) {
+		mock.ExpectExec(deleteByIdQuery).WithArgs(promotionUUID).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := promotionPGRepository.DeleteById(context.Background(), promotionUUID)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
+}
@@ -0,0 +1,25 @@
+package repository
+
+const (
+	createPromotionQuery = `INSERT INTO promotions (code, name, type, value, buy_quantity, get_quantity, brand_id, product_id, category, starts_at, ends_at, usage_limit, per_user_limit, stackable, active) 
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+		RETURNING promotion_id, code, name, type, value, buy_quantity, get_quantity, brand_id, product_id, category, starts_at, ends_at, usage_limit, per_user_limit, usage_count, stackable, active, created_at, updated_at, version, deleted_at`
+
+	findByIdQuery = `SELECT promotion_id, code, name, type, value, buy_quantity, get_quantity, brand_id, product_id, category, starts_at, ends_at, usage_limit, per_user_limit, usage_count, stackable, active, created_at, updated_at, version, deleted_at FROM promotions WHERE promotion_id = $1 AND deleted_at IS NULL`
+
+	findAllQuery = `SELECT promotion_id, code, name, type, value, buy_quantity, get_quantity, brand_id, product_id, category, starts_at, ends_at, usage_limit, per_user_limit, usage_count, stackable, active, created_at, updated_at, version, deleted_at FROM promotions WHERE deleted_at IS NULL ORDER BY created_at DESC LIMIT $1 OFFSET $2`
+
+	findAllByBrandIdQuery = `SELECT promotion_id, code, name, type, value, buy_quantity, get_quantity, brand_id, product_id, category, starts_at, ends_at, usage_limit, per_user_limit, usage_count, stackable, active, created_at, updated_at, version, deleted_at FROM promotions WHERE brand_id = $1 AND deleted_at IS NULL ORDER BY created_at DESC LIMIT $2 OFFSET $3`
+
+	findApplicableQuery = `SELECT promotion_id, code, name, type, value, buy_quantity, get_quantity, brand_id, product_id, category, starts_at, ends_at, usage_limit, per_user_limit, usage_count, stackable, active, created_at, updated_at, version, deleted_at FROM promotions
+		WHERE deleted_at IS NULL AND active AND starts_at <= $5 AND (ends_at IS NULL OR ends_at > $5)
+		AND (brand_id IS NULL OR brand_id = $1) AND (product_id IS NULL OR product_id = $2) AND (category IS NULL OR category = $3)
+		AND (code IS NULL OR code = ANY($4))`
+
+	countRedemptionsByUserIdQuery = `SELECT COUNT(*) FROM promotion_redemptions WHERE promotion_id = $1 AND user_id = $2`
+
+	updateByIdQuery = `UPDATE promotions SET code = $2, name = $3, value = $4, buy_quantity = $5, get_quantity = $6, product_id = $7, category = $8, starts_at = $9, ends_at = $10, usage_limit = $11, per_user_limit = $12, stackable = $13, active = $14, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE promotion_id = $1 AND version = $15 AND deleted_at IS NULL
+		RETURNING promotion_id, code, name, type, value, buy_quantity, get_quantity, brand_id, product_id, category, starts_at, ends_at, usage_limit, per_user_limit, usage_count, stackable, active, created_at, updated_at, version, deleted_at`
+
+	deleteByIdQuery = `UPDATE promotions SET deleted_at = CURRENT_TIMESTAMP, version = version + 1 WHERE promotion_id = $1 AND deleted_at IS NULL`
+)
@@ -0,0 +1,22 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase.go -package mock
+package promotion
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/pkg/utils"
+)
+
+// Promotion UseCase interface
+type PromotionUseCase interface {
+	Create(ctx context.Context, promotion *models.Promotion) (*models.Promotion, error)
+	FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.Promotion, error)
+	FindAllByBrandId(ctx context.Context, brandID uuid.UUID, pagination *utils.Pagination) ([]models.Promotion, error)
+	FindById(ctx context.Context, promotionID uuid.UUID) (*models.Promotion, error)
+	UpdateById(ctx context.Context, promotion *models.Promotion) (*models.Promotion, error)
+	DeleteById(ctx context.Context, promotionID uuid.UUID) error
+	Apply(ctx context.Context, order *models.Order, codes []string) (*models.Order, error)
+}
@@ -0,0 +1,246 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/internal/promotion"
+	"github.com/dinorain/kalobranded/pkg/logger"
+	"github.com/dinorain/kalobranded/pkg/utils"
+)
+
+// Promotion UseCase
+type promotionUseCase struct {
+	cfg             *config.Config
+	logger          logger.Logger
+	promotionPgRepo promotion.PromotionPGRepository
+}
+
+var _ promotion.PromotionUseCase = (*promotionUseCase)(nil)
+
+// New Promotion UseCase
+func NewPromotionUseCase(cfg *config.Config, logger logger.Logger, promotionRepo promotion.PromotionPGRepository) *promotionUseCase {
+	return &promotionUseCase{cfg: cfg, logger: logger, promotionPgRepo: promotionRepo}
+}
+
+// Create new promotion
+func (u *promotionUseCase) Create(ctx context.Context, promotion *models.Promotion) (*models.Promotion, error) {
+	createdPromotion, err := u.promotionPgRepo.Create(ctx, promotion)
+	if err != nil {
+		return nil, errors.Wrap(err, "promotionPgRepo.Create")
+	}
+
+	return createdPromotion, nil
+}
+
+// FindAll find promotions
+func (u *promotionUseCase) FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.Promotion, error) {
+	promotions, err := u.promotionPgRepo.FindAll(ctx, pagination)
+	if err != nil {
+		return nil, errors.Wrap(err, "promotionPgRepo.FindAll")
+	}
+
+	return promotions, nil
+}
+
+// FindAllByBrandId find promotions of brand
+func (u *promotionUseCase) FindAllByBrandId(ctx context.Context, brandID uuid.UUID, pagination *utils.Pagination) ([]models.Promotion, error) {
+	promotions, err := u.promotionPgRepo.FindAllByBrandId(ctx, brandID, pagination)
+	if err != nil {
+		return nil, errors.Wrap(err, "promotionPgRepo.FindAllByBrandId")
+	}
+
+	return promotions, nil
+}
+
+// FindById find promotion by uuid
+func (u *promotionUseCase) FindById(ctx context.Context, promotionID uuid.UUID) (*models.Promotion, error) {
+	foundPromotion, err := u.promotionPgRepo.FindById(ctx, promotionID)
+	if err != nil {
+		return nil, errors.Wrap(err, "promotionPgRepo.FindById")
+	}
+
+	return foundPromotion, nil
+}
+
+// UpdateById update existing promotion
+func (u *promotionUseCase) UpdateById(ctx context.Context, promotion *models.Promotion) (*models.Promotion, error) {
+	updatedPromotion, err := u.promotionPgRepo.UpdateById(ctx, promotion)
+	if err != nil {
+		return nil, errors.Wrap(err, "promotionPgRepo.UpdateById")
+	}
+
+	return updatedPromotion, nil
+}
+
+// DeleteById soft delete promotion by uuid
+func (u *promotionUseCase) DeleteById(ctx context.Context, promotionID uuid.UUID) error {
+	if err := u.promotionPgRepo.DeleteById(ctx, promotionID); err != nil {
+		return errors.Wrap(err, "promotionPgRepo.DeleteById")
+	}
+
+	return nil
+}
+
+// Apply price order with the running promotions covering its item, automatic ones and the coupons of codes.
+// Stackable promotions add up while a non stackable one applies alone, whichever discounts more wins, and
+// free shipping rules always apply since they leave the item price alone. Usage limits are checked here for
+// a meaningful error and enforced again atomically when the order is created
+func (u *promotionUseCase) Apply(ctx context.Context, order *models.Order, codes []string) (*models.Order, error) {
+	codes = normalizeCodes(codes)
+
+	candidates, err := u.promotionPgRepo.FindApplicable(ctx, &order.Item, codes, time.Now().UTC())
+	if err != nil {
+		return nil, errors.Wrap(err, "promotionPgRepo.FindApplicable")
+	}
+
+	foundCodes := make(map[string]bool, len(codes))
+	eligible := make([]models.Promotion, 0, len(candidates))
+	for _, p := range candidates {
+		if p.IsCoupon() {
+			foundCodes[*p.Code] = true
+		}
+
+		usable, err := u.isUsableBy(ctx, &p, order.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if !usable {
+			if p.IsCoupon() {
+				return nil, errors.Wrapf(models.ErrPromotionUsageLimitReached, "coupon %s", *p.Code)
+			}
+			continue
+		}
+		eligible = append(eligible, p)
+	}
+
+	for _, code := range codes {
+		if !foundCodes[code] {
+			return nil, errors.Wrapf(models.ErrPromotionNotApplicable, "coupon %s", code)
+		}
+	}
+
+	subtotal := models.RoundAmount(order.Item.Price * float64(order.Quantity))
+	pricedOrder := *order
+	pricedOrder.AppliedPromotions = selectPromotions(eligible, order.Item.Price, order.Quantity)
+	pricedOrder.DiscountTotal = 0
+	pricedOrder.FreeShipping = false
+	for _, applied := range pricedOrder.AppliedPromotions {
+		pricedOrder.DiscountTotal += applied.Discount
+		if applied.Type == models.PromotionTypeFreeShipping {
+			pricedOrder.FreeShipping = true
+		}
+	}
+	pricedOrder.DiscountTotal = models.RoundAmount(pricedOrder.DiscountTotal)
+	pricedOrder.TotalPrice = models.RoundAmount(subtotal - pricedOrder.DiscountTotal)
+
+	return &pricedOrder, nil
+}
+
+// isUsableBy reports whether promotion is neither used up globally nor by user
+func (u *promotionUseCase) isUsableBy(ctx context.Context, p *models.Promotion, userID uuid.UUID) (bool, error) {
+	if p.IsUsedUp() {
+		return false, nil
+	}
+	if p.PerUserLimit == nil {
+		return true, nil
+	}
+
+	cnt, err := u.promotionPgRepo.CountRedemptionsByUserId(ctx, p.PromotionID, userID)
+	if err != nil {
+		return false, errors.Wrap(err, "promotionPgRepo.CountRedemptionsByUserId")
+	}
+
+	return cnt < *p.PerUserLimit, nil
+}
+
+// selectPromotions pick the promotions giving the largest discount on quantity units at price, the total never
+// exceeding their subtotal
+func selectPromotions(eligible []models.Promotion, price float64, quantity uint64) models.AppliedPromotions {
+	type candidate struct {
+		promotion *models.Promotion
+		discount  float64
+	}
+
+	var (
+		freeShipping *models.Promotion
+		stackable    []candidate
+		exclusive    *candidate
+		stackedTotal float64
+	)
+	for i := range eligible {
+		p := &eligible[i]
+		if p.Type == models.PromotionTypeFreeShipping {
+			if freeShipping == nil {
+				freeShipping = p
+			}
+			continue
+		}
+
+		c := candidate{promotion: p, discount: p.Discount(price, quantity)}
+		if c.discount <= 0 {
+			continue
+		}
+		if p.Stackable {
+			stackable = append(stackable, c)
+			stackedTotal += c.discount
+		} else if exclusive == nil || c.discount > exclusive.discount {
+			exclusive = &c
+		}
+	}
+
+	chosen := stackable
+	if exclusive != nil && exclusive.discount > stackedTotal {
+		chosen = []candidate{*exclusive}
+	}
+	sort.SliceStable(chosen, func(i, j int) bool { return chosen[i].discount > chosen[j].discount })
+
+	applied := models.AppliedPromotions{}
+	remaining := models.RoundAmount(price * float64(quantity))
+	for _, c := range chosen {
+		discount := c.discount
+		if discount > remaining {
+			discount = remaining
+		}
+		if discount <= 0 {
+			break
+		}
+		remaining = models.RoundAmount(remaining - discount)
+		applied = append(applied, toApplied(c.promotion, discount))
+	}
+	if freeShipping != nil {
+		applied = append(applied, toApplied(freeShipping, 0))
+	}
+
+	return applied
+}
+
+func toApplied(p *models.Promotion, discount float64) models.AppliedPromotion {
+	applied := models.AppliedPromotion{PromotionID: p.PromotionID, Name: p.Name, Type: p.Type, Discount: discount}
+	if p.Code != nil {
+		applied.Code = *p.Code
+	}
+	return applied
+}
+
+// normalizeCodes upper case coupon codes, dropping blanks and duplicates
+func normalizeCodes(codes []string) []string {
+	normalized := make([]string, 0, len(codes))
+	seen := make(map[string]bool, len(codes))
+	for _, code := range codes {
+		code = strings.ToUpper(strings.TrimSpace(code))
+		if code == "" || seen[code] {
+			continue
+		}
+		seen[code] = true
+		normalized = append(normalized, code)
+	}
+	return normalized
+}
@@ -0,0 +1,135 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/internal/promotion/mock"
+	"github.com/dinorain/kalobranded/pkg/logger"
+)
+
+func mockPromotion(promotionType string, value float64, stackable bool) models.Promotion {
+	return models.Promotion{PromotionID: uuid.New(), Name: promotionType, Type: promotionType, Value: value, Stackable: stackable, Active: true}
+}
+
+func TestPromotionUseCase_Apply(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	promotionPGRepository := mock.NewMockPromotionPGRepository(ctrl)
+	apiLogger := logger.NewAppLogger(nil)
+
+	cfg := &config.Config{}
+	promotionUC := NewPromotionUseCase(cfg, apiLogger, promotionPGRepository)
+
+	ctx := context.Background()
+	mockOrder := &models.Order{
+		UserID:     uuid.New(),
+		BrandID:    uuid.New(),
+		Item:       models.OrderItem{ProductID: uuid.New(), Price: 10000.0, Category: "shoes"},
+		Quantity:   3,
+		TotalPrice: 30000.0,
+		Status:     models.OrderStatusPending,
+	}
+
+	t.Run("Stackable", func(t *testing.T) {
+		percentage := mockPromotion(models.PromotionTypePercentage, 10, true)
+		fixed := mockPromotion(models.PromotionTypeFixedAmount, 5000, true)
+		exclusive := mockPromotion(models.PromotionTypeFixedAmount, 7000, false)
+		freeShipping := mockPromotion(models.PromotionTypeFreeShipping, 0, false)
+		promotionPGRepository.EXPECT().FindApplicable(gomock.Any(), &mockOrder.Item, []string{}, gomock.Any()).Return([]models.Promotion{percentage, fixed, exclusive, freeShipping}, nil)
+
+		pricedOrder, err := promotionUC.Apply(ctx, mockOrder, nil)
+		require.NoError(t, err)
+		require.Equal(t, 8000.0, pricedOrder.DiscountTotal)
+		require.Equal(t, 22000.0, pricedOrder.TotalPrice)
+		require.True(t, pricedOrder.FreeShipping)
+		require.Len(t, pricedOrder.AppliedPromotions, 3)
+		require.Equal(t, fixed.PromotionID, pricedOrder.AppliedPromotions[0].PromotionID)
+		require.Equal(t, 30000.0, mockOrder.TotalPrice)
+	})
+
+	t.Run("ExclusiveWins", func(t *testing.T) {
+		percentage := mockPromotion(models.PromotionTypePercentage, 10, true)
+		buyTwoGetOne := mockPromotion(models.PromotionTypeBuyXGetY, 0, false)
+		buyTwoGetOne.BuyQuantity = 2
+		buyTwoGetOne.GetQuantity = 1
+		promotionPGRepository.EXPECT().FindApplicable(gomock.Any(), &mockOrder.Item, []string{}, gomock.Any()).Return([]models.Promotion{percentage, buyTwoGetOne}, nil)
+
+		pricedOrder, err := promotionUC.Apply(ctx, mockOrder, nil)
+		require.NoError(t, err)
+		require.Equal(t, 10000.0, pricedOrder.DiscountTotal)
+		require.Equal(t, 20000.0, pricedOrder.TotalPrice)
+		require.False(t, pricedOrder.FreeShipping)
+		require.Len(t, pricedOrder.AppliedPromotions, 1)
+		require.Equal(t, buyTwoGetOne.PromotionID, pricedOrder.AppliedPromotions[0].PromotionID)
+	})
+
+	t.Run("CappedAtSubtotal", func(t *testing.T) {
+		fixed := mockPromotion(models.PromotionTypeFixedAmount, 25000, true)
+		percentage := mockPromotion(models.PromotionTypePercentage, 50, true)
+		promotionPGRepository.EXPECT().FindApplicable(gomock.Any(), &mockOrder.Item, []string{}, gomock.Any()).Return([]models.Promotion{fixed, percentage}, nil)
+
+		pricedOrder, err := promotionUC.Apply(ctx, mockOrder, nil)
+		require.NoError(t, err)
+		require.Equal(t, 30000.0, pricedOrder.DiscountTotal)
+		require.Equal(t, 0.0, pricedOrder.TotalPrice)
+		require.Equal(t, 5000.0, pricedOrder.AppliedPromotions[1].Discount)
+	})
+
+	t.Run("Coupon", func(t *testing.T) {
+		coupon := mockPromotion(models.PromotionTypePercentage, 20, false)
+		code := "SAVE20"
+		coupon.Code = &code
+		promotionPGRepository.EXPECT().FindApplicable(gomock.Any(), &mockOrder.Item, []string{"SAVE20"}, gomock.Any()).Return([]models.Promotion{coupon}, nil)
+
+		pricedOrder, err := promotionUC.Apply(ctx, mockOrder, []string{" save20 ", "SAVE20"})
+		require.NoError(t, err)
+		require.Equal(t, 24000.0, pricedOrder.TotalPrice)
+		require.Equal(t, "SAVE20", pricedOrder.AppliedPromotions[0].Code)
+	})
+
+	t.Run("CouponNotApplicable", func(t *testing.T) {
+		promotionPGRepository.EXPECT().FindApplicable(gomock.Any(), &mockOrder.Item, []string{"UNKNOWN"}, gomock.Any()).Return([]models.Promotion{}, nil)
+
+		_, err := promotionUC.Apply(ctx, mockOrder, []string{"unknown"})
+		require.ErrorIs(t, err, models.ErrPromotionNotApplicable)
+	})
+
+	t.Run("PerUserLimitReached", func(t *testing.T) {
+		limit := 1
+		coupon := mockPromotion(models.PromotionTypePercentage, 20, false)
+		code := "ONCE"
+		coupon.Code = &code
+		coupon.PerUserLimit = &limit
+		automatic := mockPromotion(models.PromotionTypeFixedAmount, 1000, false)
+		automatic.PerUserLimit = &limit
+		promotionPGRepository.EXPECT().FindApplicable(gomock.Any(), &mockOrder.Item, []string{"ONCE"}, gomock.Any()).Return([]models.Promotion{automatic, coupon}, nil)
+		promotionPGRepository.EXPECT().CountRedemptionsByUserId(gomock.Any(), automatic.PromotionID, mockOrder.UserID).Return(1, nil)
+		promotionPGRepository.EXPECT().CountRedemptionsByUserId(gomock.Any(), coupon.PromotionID, mockOrder.UserID).Return(1, nil)
+
+		_, err := promotionUC.Apply(ctx, mockOrder, []string{"ONCE"})
+		require.ErrorIs(t, err, models.ErrPromotionUsageLimitReached)
+	})
+
+	t.Run("UsedUpSkipped", func(t *testing.T) {
+		limit := 5
+		automatic := mockPromotion(models.PromotionTypeFixedAmount, 1000, false)
+		automatic.UsageLimit = &limit
+		automatic.UsageCount = 5
+		promotionPGRepository.EXPECT().FindApplicable(gomock.Any(), &mockOrder.Item, []string{}, gomock.Any()).Return([]models.Promotion{automatic}, nil)
+
+		pricedOrder, err := promotionUC.Apply(ctx, mockOrder, nil)
+		require.NoError(t, err)
+		require.Equal(t, 30000.0, pricedOrder.TotalPrice)
+		require.Empty(t, pricedOrder.AppliedPromotions)
+	})
+}
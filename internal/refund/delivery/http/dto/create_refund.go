@@ -0,0 +1,8 @@
+package dto
+
+type RefundCreateRequestDto struct {
+	Quantity uint64 `json:"quantity"`
+	Reason   string `json:"reason" validate:"required,oneof=damaged wrong_item not_received customer_request other"`
+	Note     string `json:"note" validate:"lte=500"`
+	Restock  bool   `json:"restock"`
+}
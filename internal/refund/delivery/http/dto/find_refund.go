@@ -0,0 +1,5 @@
+package dto
+
+type RefundFindResponseDto struct {
+	Data []*RefundResponseDto `json:"data"`
+}
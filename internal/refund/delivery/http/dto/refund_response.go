@@ -0,0 +1,41 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/internal/models"
+)
+
+type RefundResponseDto struct {
+	RefundID         uuid.UUID  `json:"refund_id"`
+	OrderID          uuid.UUID  `json:"order_id"`
+	Quantity         uint64     `json:"quantity"`
+	Amount           float64    `json:"amount"`
+	Reason           string     `json:"reason"`
+	Note             string     `json:"note"`
+	Restock          bool       `json:"restock"`
+	RefundedBy       uuid.UUID  `json:"refunded_by"`
+	ProviderRefundID string     `json:"provider_refund_id"`
+	Status           string     `json:"status"`
+	ReturnID         *uuid.UUID `json:"return_id,omitempty"`
+	CreatedAt        time.Time  `json:"created_at,omitempty"`
+}
+
+func RefundResponseFromModel(refund *models.Refund) *RefundResponseDto {
+	return &RefundResponseDto{
+		RefundID:         refund.RefundID,
+		OrderID:          refund.OrderID,
+		Quantity:         refund.Quantity,
+		Amount:           refund.Amount,
+		Reason:           refund.Reason,
+		Note:             refund.Note,
+		Restock:          refund.Restock,
+		RefundedBy:       refund.RefundedBy,
+		ProviderRefundID: refund.ProviderRefundID,
+		Status:           refund.Status,
+		ReturnID:         refund.ReturnID,
+		CreatedAt:        refund.CreatedAt,
+	}
+}
@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/middlewares"
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/internal/order"
+	"github.com/dinorain/kalobranded/internal/payment"
+	"github.com/dinorain/kalobranded/internal/refund"
+	"github.com/dinorain/kalobranded/internal/refund/delivery/http/dto"
+	"github.com/dinorain/kalobranded/internal/server/router"
+	"github.com/dinorain/kalobranded/pkg/constants"
+	httpErrors "github.com/dinorain/kalobranded/pkg/http_errors"
+	"github.com/dinorain/kalobranded/pkg/logger"
+)
+
+type refundHandlersHTTP struct {
+	router   *router.Router
+	logger   logger.Logger
+	cfg      *config.Config
+	mw       middlewares.MiddlewareManager
+	v        *validator.Validate
+	refundUC refund.RefundUseCase
+	orderUC  order.OrderUseCase
+}
+
+var _ refund.RefundHandlers = (*refundHandlersHTTP)(nil)
+
+func NewRefundHandlersHTTP(
+	router *router.Router,
+	logger logger.Logger,
+	cfg *config.Config,
+	mw middlewares.MiddlewareManager,
+	v *validator.Validate,
+	refundUC refund.RefundUseCase,
+	orderUC order.OrderUseCase,
+) *refundHandlersHTTP {
+	return &refundHandlersHTTP{router: router, logger: logger, cfg: cfg, mw: mw, v: v, refundUC: refundUC, orderUC: orderUC}
+}
+
+// Create
+// @Tags Refunds
+// @Summary Refund order
+// @Description Admin or seller of the order brand refunds part of the order quantity, or all of it when quantity is left out. The refund is pending, answered with 202, when the payment provider did not answer, it is completed in the background
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "order uuid"
+// @Param payload body dto.RefundCreateRequestDto true "Payload"
+// @Success 201 {object} dto.RefundResponseDto
+// @Success 202 {object} dto.RefundResponseDto
+// @Router /orders/{id}/refunds [post]
+func (h *refundHandlersHTTP) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	foundOrder, userID, err := h.findOrder(w, r, false)
+	if err != nil {
+		return
+	}
+
+	createDto := &dto.RefundCreateRequestDto{}
+	if err := json.NewDecoder(r.Body).Decode(createDto); err != nil {
+		h.logger.Errorf("decoder.Decode: %v", err)
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	if err := h.v.Struct(createDto); err != nil {
+		h.logger.Errorf("h.v.Struct: %v", err)
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	createdRefund, err := h.refundUC.Create(ctx, foundOrder, &models.Refund{
+		Quantity:   createDto.Quantity,
+		Reason:     createDto.Reason,
+		Note:       createDto.Note,
+		Restock:    createDto.Restock,
+		RefundedBy: userID,
+	})
+	if err != nil {
+		h.logger.Errorf("refundUC.Create: %v", err)
+		if errors.Is(err, models.ErrRefundExceedsQuantity) || errors.Is(err, payment.ErrRefundExceedsAmount) || errors.Is(err, payment.ErrPaymentNotCaptured) {
+			_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+			return
+		}
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	status := http.StatusCreated
+	if createdRefund.Status == models.RefundStatusPending {
+		status = http.StatusAccepted
+	}
+	res, _ := json.Marshal(dto.RefundResponseFromModel(createdRefund))
+	w.WriteHeader(status)
+	w.Write(res)
+	return
+}
+
+// FindAllByOrderId
+// @Tags Refunds
+// @Summary Find order refunds
+// @Description Find refunds of an order, users can only find refunds of their own orders and sellers of their brand orders
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "order uuid"
+// @Success 200 {object} dto.RefundFindResponseDto
+// @Router /orders/{id}/refunds [get]
+func (h *refundHandlersHTTP) FindAllByOrderId(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	foundOrder, _, err := h.findOrder(w, r, true)
+	if err != nil {
+		return
+	}
+
+	refunds, err := h.refundUC.FindAllByOrderId(ctx, foundOrder.OrderID)
+	if err != nil {
+		h.logger.Errorf("refundUC.FindAllByOrderId: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	resDto := dto.RefundFindResponseDto{Data: make([]*dto.RefundResponseDto, 0, len(refunds))}
+	for i := range refunds {
+		resDto.Data = append(resDto.Data, dto.RefundResponseFromModel(&refunds[i]))
+	}
+
+	res, _ := json.Marshal(resDto)
+	w.WriteHeader(http.StatusOK)
+	w.Write(res)
+	return
+}
+
+// findOrder find the order of the path id for the caller, admins reach every order, sellers the orders of their brand
+// and, when allowBuyer, users their own orders. Error response is already written when err is not nil
+func (h *refundHandlersHTTP) findOrder(w http.ResponseWriter, r *http.Request, allowBuyer bool) (*models.Order, uuid.UUID, error) {
+	orderUUID, err := uuid.Parse(router.Param(r, constants.ID))
+	if err != nil {
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return nil, uuid.Nil, err
+	}
+
+	jwtClaims, err := h.mw.GetJWTClaims(w, r)
+	if err != nil {
+		return nil, uuid.Nil, err
+	}
+	claims := *jwtClaims
+	userID, _ := claims["user_id"].(string)
+
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, uuid.Nil, httpErrors.NewUnauthorizedError(w, nil, h.cfg.Http.DebugErrorsResponse)
+	}
+
+	foundOrder, err := h.orderUC.FindById(r.Context(), orderUUID)
+	if err != nil {
+		h.logger.Errorf("orderUC.FindById: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return nil, uuid.Nil, err
+	}
+
+	if !allowBuyer || foundOrder.UserID != userUUID {
+		if err := h.mw.CheckBrand(w, r, foundOrder.BrandID); err != nil {
+			return nil, uuid.Nil, err
+		}
+	}
+
+	return foundOrder, userUUID, nil
+}
@@ -0,0 +1,175 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/golang-jwt/jwt"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/middlewares"
+	"github.com/dinorain/kalobranded/internal/models"
+	mockOrderUC "github.com/dinorain/kalobranded/internal/order/mock"
+	"github.com/dinorain/kalobranded/internal/refund/delivery/http/dto"
+	"github.com/dinorain/kalobranded/internal/refund/mock"
+	"github.com/dinorain/kalobranded/internal/server/router"
+	"github.com/dinorain/kalobranded/pkg/logger"
+)
+
+func signedToken(t *testing.T, cfg *config.Config, userUUID uuid.UUID, role string, brandUUID *uuid.UUID) string {
+	token := jwt.New(jwt.SigningMethodHS256)
+	claims := token.Claims.(jwt.MapClaims)
+	claims["session_id"] = uuid.New().String()
+	claims["user_id"] = userUUID.String()
+	claims["role"] = role
+	if brandUUID != nil {
+		claims["brand_id"] = brandUUID.String()
+	}
+	claims["exp"] = time.Now().Add(time.Minute * 15).Unix()
+	validToken, err := token.SignedString([]byte(cfg.Server.JwtSecretKey))
+	require.NoError(t, err)
+	return validToken
+}
+
+func TestRefundsHandler_Create(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	refundUC := mock.NewMockRefundUseCase(ctrl)
+	orderUC := mockOrderUC.NewMockOrderUseCase(ctrl)
+
+	cfg := &config.Config{Session: config.Session{Expire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	appLogger := logger.NewAppLogger(cfg)
+	appLogger.InitLogger()
+	mw := middlewares.NewMiddlewareManager(appLogger, cfg)
+
+	v := validator.New()
+
+	rt := router.NewRouter(false)
+	handlers := NewRefundHandlersHTTP(rt, appLogger, cfg, mw, v, refundUC, orderUC)
+
+	sellerUUID := uuid.New()
+	brandUUID := uuid.New()
+	orderUUID := uuid.New()
+	mockOrder := &models.Order{OrderID: orderUUID, UserID: uuid.New(), BrandID: brandUUID, Quantity: 2, TotalPrice: 20000.0, Status: models.OrderStatusAccepted}
+
+	t.Run("Seller", func(t *testing.T) {
+		req := router.WithParams(httptest.NewRequest(http.MethodPost, "/orders/"+orderUUID.String()+"/refunds", strings.NewReader(`{"quantity": 1, "reason": "damaged", "restock": true}`)), map[string]string{"id": orderUUID.String()})
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", signedToken(t, cfg, sellerUUID, models.UserRoleSeller, &brandUUID)))
+		w := httptest.NewRecorder()
+
+		orderUC.EXPECT().FindById(gomock.Any(), orderUUID).Return(mockOrder, nil)
+		refundUC.EXPECT().Create(gomock.Any(), mockOrder, &models.Refund{
+			Quantity:   1,
+			Reason:     models.RefundReasonDamaged,
+			Restock:    true,
+			RefundedBy: sellerUUID,
+		}).Return(&models.Refund{RefundID: uuid.New(), OrderID: orderUUID, Quantity: 1, Amount: 10000.0, Reason: models.RefundReasonDamaged, Restock: true, RefundedBy: sellerUUID}, nil)
+
+		http.HandlerFunc(handlers.Create).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusCreated, w.Code)
+		resDto := &dto.RefundResponseDto{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), resDto))
+		require.Equal(t, sellerUUID, resDto.RefundedBy)
+		require.Equal(t, 10000.0, resDto.Amount)
+	})
+
+	t.Run("OtherBrandSeller", func(t *testing.T) {
+		otherBrandUUID := uuid.New()
+		req := router.WithParams(httptest.NewRequest(http.MethodPost, "/orders/"+orderUUID.String()+"/refunds", strings.NewReader(`{"reason": "damaged"}`)), map[string]string{"id": orderUUID.String()})
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", signedToken(t, cfg, sellerUUID, models.UserRoleSeller, &otherBrandUUID)))
+		w := httptest.NewRecorder()
+
+		orderUC.EXPECT().FindById(gomock.Any(), orderUUID).Return(mockOrder, nil)
+
+		http.HandlerFunc(handlers.Create).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("Buyer", func(t *testing.T) {
+		req := router.WithParams(httptest.NewRequest(http.MethodPost, "/orders/"+orderUUID.String()+"/refunds", strings.NewReader(`{"reason": "damaged"}`)), map[string]string{"id": orderUUID.String()})
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", signedToken(t, cfg, mockOrder.UserID, models.UserRoleUser, nil)))
+		w := httptest.NewRecorder()
+
+		orderUC.EXPECT().FindById(gomock.Any(), orderUUID).Return(mockOrder, nil)
+
+		http.HandlerFunc(handlers.Create).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("InvalidReason", func(t *testing.T) {
+		req := router.WithParams(httptest.NewRequest(http.MethodPost, "/orders/"+orderUUID.String()+"/refunds", strings.NewReader(`{"reason": "changed_mind"}`)), map[string]string{"id": orderUUID.String()})
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", signedToken(t, cfg, uuid.New(), models.UserRoleAdmin, nil)))
+		w := httptest.NewRecorder()
+
+		orderUC.EXPECT().FindById(gomock.Any(), orderUUID).Return(mockOrder, nil)
+
+		http.HandlerFunc(handlers.Create).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("ExceedsQuantity", func(t *testing.T) {
+		req := router.WithParams(httptest.NewRequest(http.MethodPost, "/orders/"+orderUUID.String()+"/refunds", strings.NewReader(`{"quantity": 3, "reason": "damaged"}`)), map[string]string{"id": orderUUID.String()})
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", signedToken(t, cfg, uuid.New(), models.UserRoleAdmin, nil)))
+		w := httptest.NewRecorder()
+
+		orderUC.EXPECT().FindById(gomock.Any(), orderUUID).Return(mockOrder, nil)
+		refundUC.EXPECT().Create(gomock.Any(), mockOrder, gomock.Any()).Return(nil, models.ErrRefundExceedsQuantity)
+
+		http.HandlerFunc(handlers.Create).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestRefundsHandler_FindAllByOrderId(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	refundUC := mock.NewMockRefundUseCase(ctrl)
+	orderUC := mockOrderUC.NewMockOrderUseCase(ctrl)
+
+	cfg := &config.Config{Session: config.Session{Expire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	appLogger := logger.NewAppLogger(cfg)
+	mw := middlewares.NewMiddlewareManager(appLogger, cfg)
+
+	v := validator.New()
+
+	rt := router.NewRouter(false)
+	handlers := NewRefundHandlersHTTP(rt, appLogger, cfg, mw, v, refundUC, orderUC)
+
+	buyerUUID := uuid.New()
+	orderUUID := uuid.New()
+	mockOrder := &models.Order{OrderID: orderUUID, UserID: buyerUUID, BrandID: uuid.New()}
+
+	req := router.WithParams(httptest.NewRequest(http.MethodGet, "/orders/"+orderUUID.String()+"/refunds", nil), map[string]string{"id": orderUUID.String()})
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", signedToken(t, cfg, buyerUUID, models.UserRoleUser, nil)))
+	w := httptest.NewRecorder()
+
+	orderUC.EXPECT().FindById(gomock.Any(), orderUUID).Return(mockOrder, nil)
+	refundUC.EXPECT().FindAllByOrderId(gomock.Any(), orderUUID).Return([]models.Refund{{RefundID: uuid.New(), OrderID: orderUUID, Quantity: 1}}, nil)
+
+	http.HandlerFunc(handlers.FindAllByOrderId).ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	resDto := &dto.RefundFindResponseDto{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), resDto))
+	require.Len(t, resDto.Data, 1)
+}
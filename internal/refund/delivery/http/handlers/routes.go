@@ -0,0 +1,7 @@
+package handlers
+
+func (h *refundHandlersHTTP) RefundMapRoutes() {
+	refunds := h.router.Group("/orders/{id}/refunds", h.mw.IsLoggedIn)
+	refunds.Post("", h.Create, h.mw.IsAdminOrSeller, h.mw.Idempotent)
+	refunds.Get("", h.FindAllByOrderId)
+}
@@ -0,0 +1,11 @@
+package refund
+
+import (
+	"net/http"
+)
+
+// Refund HTTP Handlers interface
+type RefundHandlers interface {
+	Create(w http.ResponseWriter, r *http.Request)
+	FindAllByOrderId(w http.ResponseWriter, r *http.Request)
+}
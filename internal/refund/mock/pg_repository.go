@@ -0,0 +1,112 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pg_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/dinorain/kalobranded/internal/models"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockRefundPGRepository is a mock of RefundPGRepository interface.
+type MockRefundPGRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRefundPGRepositoryMockRecorder
+}
+
+// MockRefundPGRepositoryMockRecorder is the mock recorder for MockRefundPGRepository.
+type MockRefundPGRepositoryMockRecorder struct {
+	mock *MockRefundPGRepository
+}
+
+// NewMockRefundPGRepository creates a new mock instance.
+func NewMockRefundPGRepository(ctrl *gomock.Controller) *MockRefundPGRepository {
+	mock := &MockRefundPGRepository{ctrl: ctrl}
+	mock.recorder = &MockRefundPGRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRefundPGRepository) EXPECT() *MockRefundPGRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Complete mocks base method.
+func (m *MockRefundPGRepository) Complete(ctx context.Context, refund *models.Refund, providerRefundID string) (*models.Refund, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Complete", ctx, refund, providerRefundID)
+	ret0, _ := ret[0].(*models.Refund)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Complete indicates an expected call of Complete.
+func (mr *MockRefundPGRepositoryMockRecorder) Complete(ctx, refund, providerRefundID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockRefundPGRepository)(nil).Complete), ctx, refund, providerRefundID)
+}
+
+// Create mocks base method.
+func (m *MockRefundPGRepository) Create(ctx context.Context, refund *models.Refund, refundedOrder *models.Order) (*models.Refund, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, refund, refundedOrder)
+	ret0, _ := ret[0].(*models.Refund)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRefundPGRepositoryMockRecorder) Create(ctx, refund, refundedOrder interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRefundPGRepository)(nil).Create), ctx, refund, refundedOrder)
+}
+
+// Fail mocks base method.
+func (m *MockRefundPGRepository) Fail(ctx context.Context, refund *models.Refund) (*models.Refund, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Fail", ctx, refund)
+	ret0, _ := ret[0].(*models.Refund)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Fail indicates an expected call of Fail.
+func (mr *MockRefundPGRepositoryMockRecorder) Fail(ctx, refund interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fail", reflect.TypeOf((*MockRefundPGRepository)(nil).Fail), ctx, refund)
+}
+
+// FindAllByOrderId mocks base method.
+func (m *MockRefundPGRepository) FindAllByOrderId(ctx context.Context, orderID uuid.UUID) ([]models.Refund, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByOrderId", ctx, orderID)
+	ret0, _ := ret[0].([]models.Refund)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByOrderId indicates an expected call of FindAllByOrderId.
+func (mr *MockRefundPGRepositoryMockRecorder) FindAllByOrderId(ctx, orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByOrderId", reflect.TypeOf((*MockRefundPGRepository)(nil).FindAllByOrderId), ctx, orderID)
+}
+
+// FindById mocks base method.
+func (m *MockRefundPGRepository) FindById(ctx context.Context, refundID uuid.UUID) (*models.Refund, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, refundID)
+	ret0, _ := ret[0].(*models.Refund)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockRefundPGRepositoryMockRecorder) FindById(ctx, refundID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockRefundPGRepository)(nil).FindById), ctx, refundID)
+}
@@ -0,0 +1,19 @@
+//go:generate mockgen -source pg_repository.go -destination mock/pg_repository.go -package mock
+package refund
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/internal/models"
+)
+
+// Refund pg repository
+type RefundPGRepository interface {
+	Create(ctx context.Context, refund *models.Refund, refundedOrder *models.Order) (*models.Refund, error)
+	Complete(ctx context.Context, refund *models.Refund, providerRefundID string) (*models.Refund, error)
+	Fail(ctx context.Context, refund *models.Refund) (*models.Refund, error)
+	FindById(ctx context.Context, refundID uuid.UUID) (*models.Refund, error)
+	FindAllByOrderId(ctx context.Context, orderID uuid.UUID) ([]models.Refund, error)
+}
@@ -0,0 +1,218 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"github.com/dinorain/kalobranded/internal/models"
+	outboxRepository "github.com/dinorain/kalobranded/internal/outbox/repository"
+	"github.com/dinorain/kalobranded/internal/refund"
+)
+
+// Refund repository
+type RefundRepository struct {
+	db *sqlx.DB
+}
+
+var _ refund.RefundPGRepository = (*RefundRepository)(nil)
+
+// Refund repository constructor
+func NewRefundPGRepository(db *sqlx.DB) *RefundRepository {
+	return &RefundRepository{db: db}
+}
+
+// Create record refund as pending and take its quantity and amount off refundedOrder, all or nothing, so that no
+// other refund can take them while the provider makes this one. The order must still be at the version
+// refundedOrder was read with. A refund of a return marks the return refunded along, the return must be received
+func (r *RefundRepository) Create(ctx context.Context, refund *models.Refund, refundedOrder *models.Order) (*models.Refund, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "RefundRepository.Create.BeginTxx")
+	}
+	defer tx.Rollback()
+
+	var orderID uuid.UUID
+	if err := tx.GetContext(ctx, &orderID, lockOrderVersionQuery, refundedOrder.OrderID, refundedOrder.Version); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, models.ErrVersionConflict
+		}
+		return nil, errors.Wrap(err, "RefundRepository.Create.GetContext")
+	}
+
+	res, err := tx.ExecContext(
+		ctx,
+		reserveRefundQuery,
+		refundedOrder.OrderID,
+		refundedOrder.RefundedQuantity,
+		refundedOrder.RefundedAmount,
+		refundedOrder.Version,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "RefundRepository.Create.ExecContext")
+	}
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return nil, errors.Wrap(err, "RefundRepository.Create.RowsAffected")
+	} else if cnt == 0 {
+		return nil, models.ErrVersionConflict
+	}
+
+	createdRefund := &models.Refund{}
+	if err := tx.QueryRowxContext(
+		ctx,
+		createRefundQuery,
+		refund.OrderID,
+		refund.Quantity,
+		refund.Amount,
+		refund.Reason,
+		refund.Note,
+		refund.Restock,
+		refund.RefundedBy,
+		refund.ReturnID,
+	).StructScan(createdRefund); err != nil {
+		return nil, errors.Wrap(err, "RefundRepository.Create.QueryRowxContext")
+	}
+
+	if refund.ReturnID != nil {
+		res, err := tx.ExecContext(ctx, refundReturnQuery, refund.ReturnID, refund.OrderID, createdRefund.RefundID)
+		if err != nil {
+			return nil, errors.Wrap(err, "RefundRepository.Create.RefundReturn.ExecContext")
+		}
+		cnt, err := res.RowsAffected()
+		if err != nil {
+			return nil, errors.Wrap(err, "RefundRepository.Create.RefundReturn.RowsAffected")
+		} else if cnt == 0 {
+			return nil, errors.Wrap(models.ErrInvalidStatusTransition, "return is not received")
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "RefundRepository.Create.Commit")
+	}
+
+	refundedOrder.Version++
+	return createdRefund, nil
+}
+
+// Complete mark a pending refund made by the provider as providerRefundID and put restocked goods back, all or
+// nothing. The order becomes refunded, along with its order.status_changed event, once its whole quantity is
+func (r *RefundRepository) Complete(ctx context.Context, refund *models.Refund, providerRefundID string) (*models.Refund, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "RefundRepository.Complete.BeginTxx")
+	}
+	defer tx.Rollback()
+
+	refundedOrder := &models.Order{}
+	if err := tx.QueryRowxContext(ctx, lockRefundedOrderQuery, refund.OrderID).StructScan(refundedOrder); err != nil {
+		return nil, errors.Wrap(err, "RefundRepository.Complete.LockOrder")
+	}
+
+	completedRefund := &models.Refund{}
+	if err := tx.QueryRowxContext(ctx, completeRefundQuery, refund.RefundID, providerRefundID).StructScan(completedRefund); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, models.ErrRefundNotPending
+		}
+		return nil, errors.Wrap(err, "RefundRepository.Complete.QueryRowxContext")
+	}
+
+	if completedRefund.Restock {
+		// goods go back to the location the order shipped from, orders of brands without locations to the product
+		if refundedOrder.LocationID != nil {
+			_, err = tx.ExecContext(ctx, restockLocationQuery, refundedOrder.LocationID, refundedOrder.Item.ProductID, completedRefund.Quantity)
+		} else {
+			_, err = tx.ExecContext(ctx, restockProductQuery, refundedOrder.Item.ProductID, completedRefund.Quantity)
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "RefundRepository.Complete.Restock.ExecContext")
+		}
+	}
+
+	var version int
+	err = tx.GetContext(ctx, &version, refundOrderStatusQuery, refundedOrder.OrderID)
+	switch {
+	case err == nil:
+		if err := outboxRepository.AddEvent(ctx, tx, models.AggregateOrder, refundedOrder.OrderID, models.EventOrderStatusChanged, &models.OrderStatusChange{
+			OrderID: refundedOrder.OrderID,
+			UserID:  refundedOrder.UserID,
+			BrandID: refundedOrder.BrandID,
+			From:    refundedOrder.Status,
+			To:      models.OrderStatusRefunded,
+			Version: version,
+		}); err != nil {
+			return nil, errors.Wrap(err, "RefundRepository.Complete.AddEvent")
+		}
+	case !errors.Is(err, sql.ErrNoRows):
+		return nil, errors.Wrap(err, "RefundRepository.Complete.GetContext")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "RefundRepository.Complete.Commit")
+	}
+
+	return completedRefund, nil
+}
+
+// Fail mark a pending refund the provider turned down as failed and give its quantity and amount back to the order,
+// all or nothing. The return it refunds is received again, so that it can be refunded once more
+func (r *RefundRepository) Fail(ctx context.Context, refund *models.Refund) (*models.Refund, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "RefundRepository.Fail.BeginTxx")
+	}
+	defer tx.Rollback()
+
+	// the order is locked first, as by Complete
+	var orderID uuid.UUID
+	if err := tx.GetContext(ctx, &orderID, lockOrderQuery, refund.OrderID); err != nil {
+		return nil, errors.Wrap(err, "RefundRepository.Fail.GetContext")
+	}
+
+	failedRefund := &models.Refund{}
+	if err := tx.QueryRowxContext(ctx, failRefundQuery, refund.RefundID).StructScan(failedRefund); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, models.ErrRefundNotPending
+		}
+		return nil, errors.Wrap(err, "RefundRepository.Fail.QueryRowxContext")
+	}
+
+	if _, err := tx.ExecContext(ctx, releaseRefundQuery, failedRefund.OrderID, failedRefund.Quantity, failedRefund.Amount); err != nil {
+		return nil, errors.Wrap(err, "RefundRepository.Fail.ExecContext")
+	}
+
+	if failedRefund.ReturnID != nil {
+		if _, err := tx.ExecContext(ctx, reopenReturnQuery, failedRefund.ReturnID, failedRefund.RefundID); err != nil {
+			return nil, errors.Wrap(err, "RefundRepository.Fail.ReopenReturn.ExecContext")
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "RefundRepository.Fail.Commit")
+	}
+
+	return failedRefund, nil
+}
+
+// FindById Find refund by uuid
+func (r *RefundRepository) FindById(ctx context.Context, refundID uuid.UUID) (*models.Refund, error) {
+	foundRefund := &models.Refund{}
+	if err := r.db.GetContext(ctx, foundRefund, findByIdQuery, refundID); err != nil {
+		return nil, errors.Wrap(err, "RefundRepository.FindById.GetContext")
+	}
+
+	return foundRefund, nil
+}
+
+// FindAllByOrderId Find refunds of order uuid, oldest first
+func (r *RefundRepository) FindAllByOrderId(ctx context.Context, orderID uuid.UUID) ([]models.Refund, error) {
+	var refunds []models.Refund
+	if err := r.db.SelectContext(ctx, &refunds, findAllByOrderIdQuery, orderID); err != nil {
+		return nil, errors.Wrap(err, "RefundRepository.FindAllByOrderId.SelectContext")
+	}
+
+	return refunds, nil
+}
@@ -0,0 +1,278 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/kalobranded/internal/models"
+	outboxRepository "github.com/dinorain/kalobranded/internal/outbox/repository"
+)
+
+var refundColumns = []string{"refund_id", "order_id", "quantity", "amount", "reason", "note", "restock", "refunded_by", "provider_refund_id", "status", "return_id", "created_at"}
+
+func TestRefundRepository_Create(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	refundPGRepository := NewRefundPGRepository(sqlxDB)
+
+	refundedOrder := &models.Order{
+		OrderID:          uuid.New(),
+		Item:             models.OrderItem{ProductID: uuid.New(), Price: 10000.0},
+		Quantity:         3,
+		TotalPrice:       30000.0,
+		Status:           models.OrderStatusAccepted,
+		RefundedQuantity: 1,
+		RefundedAmount:   10000.0,
+		Version:          2,
+	}
+	mockRefund := &models.Refund{
+		OrderID:    refundedOrder.OrderID,
+		Quantity:   1,
+		Amount:     10000.0,
+		Reason:     models.RefundReasonDamaged,
+		Restock:    true,
+		RefundedBy: uuid.New(),
+	}
+
+	t.Run("Pending", func(t *testing.T) {
+		refundUUID := uuid.New()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockOrderVersionQuery).WithArgs(refundedOrder.OrderID, refundedOrder.Version).WillReturnRows(sqlmock.NewRows([]string{"order_id"}).AddRow(refundedOrder.OrderID))
+		mock.ExpectExec(reserveRefundQuery).WithArgs(
+			refundedOrder.OrderID,
+			refundedOrder.RefundedQuantity,
+			refundedOrder.RefundedAmount,
+			refundedOrder.Version,
+		).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(createRefundQuery).WithArgs(
+			mockRefund.OrderID,
+			mockRefund.Quantity,
+			mockRefund.Amount,
+			mockRefund.Reason,
+			mockRefund.Note,
+			mockRefund.Restock,
+			mockRefund.RefundedBy,
+			mockRefund.ReturnID,
+		).WillReturnRows(sqlmock.NewRows(refundColumns).AddRow(
+			refundUUID,
+			mockRefund.OrderID,
+			mockRefund.Quantity,
+			mockRefund.Amount,
+			mockRefund.Reason,
+			mockRefund.Note,
+			mockRefund.Restock,
+			mockRefund.RefundedBy,
+			"",
+			models.RefundStatusPending,
+			nil,
+			time.Now(),
+		))
+		mock.ExpectCommit()
+
+		createdRefund, err := refundPGRepository.Create(context.Background(), mockRefund, refundedOrder)
+		require.NoError(t, err)
+		require.Equal(t, refundUUID, createdRefund.RefundID)
+		require.Equal(t, models.RefundStatusPending, createdRefund.Status)
+		require.Equal(t, 3, refundedOrder.Version)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Return", func(t *testing.T) {
+		returnUUID, refundUUID := uuid.New(), uuid.New()
+		returnRefund := *mockRefund
+		returnRefund.ReturnID = &returnUUID
+		createRefund := func() {
+			mock.ExpectBegin()
+			mock.ExpectQuery(lockOrderVersionQuery).WithArgs(refundedOrder.OrderID, refundedOrder.Version).WillReturnRows(sqlmock.NewRows([]string{"order_id"}).AddRow(refundedOrder.OrderID))
+			mock.ExpectExec(reserveRefundQuery).WithArgs(refundedOrder.OrderID, refundedOrder.RefundedQuantity, refundedOrder.RefundedAmount, refundedOrder.Version).WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectQuery(createRefundQuery).WithArgs(
+				returnRefund.OrderID, returnRefund.Quantity, returnRefund.Amount, returnRefund.Reason, returnRefund.Note, returnRefund.Restock, returnRefund.RefundedBy, returnRefund.ReturnID,
+			).WillReturnRows(sqlmock.NewRows(refundColumns).AddRow(
+				refundUUID, returnRefund.OrderID, returnRefund.Quantity, returnRefund.Amount, returnRefund.Reason, "", true, returnRefund.RefundedBy, "", models.RefundStatusPending, returnUUID, time.Now(),
+			))
+		}
+
+		createRefund()
+		mock.ExpectExec(refundReturnQuery).WithArgs(&returnUUID, returnRefund.OrderID, refundUUID).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		createdRefund, err := refundPGRepository.Create(context.Background(), &returnRefund, refundedOrder)
+		require.NoError(t, err)
+		require.Equal(t, returnUUID, *createdRefund.ReturnID)
+		require.NoError(t, mock.ExpectationsWereMet())
+
+		// the return was refunded meanwhile, nothing is reserved
+		createRefund()
+		mock.ExpectExec(refundReturnQuery).WithArgs(&returnUUID, returnRefund.OrderID, refundUUID).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		_, err = refundPGRepository.Create(context.Background(), &returnRefund, refundedOrder)
+		require.ErrorIs(t, err, models.ErrInvalidStatusTransition)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("VersionConflict", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockOrderVersionQuery).WithArgs(refundedOrder.OrderID, refundedOrder.Version).WillReturnRows(sqlmock.NewRows([]string{"order_id"}))
+		mock.ExpectRollback()
+
+		_, err := refundPGRepository.Create(context.Background(), mockRefund, refundedOrder)
+		require.ErrorIs(t, err, models.ErrVersionConflict)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRefundRepository_Complete(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	refundPGRepository := NewRefundPGRepository(sqlxDB)
+
+	productUUID := uuid.New()
+	item := []byte(`{"product_id":"` + productUUID.String() + `","price":10000}`)
+	orderColumns := []string{"order_id", "user_id", "brand_id", "item", "quantity", "refunded_quantity", "location_id", "status"}
+	pendingRefund := &models.Refund{RefundID: uuid.New(), OrderID: uuid.New(), Quantity: 1, Amount: 10000.0, Restock: true, Status: models.RefundStatusPending}
+	refundRow := func() *sqlmock.Rows {
+		return sqlmock.NewRows(refundColumns).AddRow(
+			pendingRefund.RefundID, pendingRefund.OrderID, 1, 10000.0, models.RefundReasonDamaged, "", true, uuid.New(), "re_fake_000001", models.RefundStatusSucceeded, nil, time.Now(),
+		)
+	}
+
+	t.Run("Restock", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockRefundedOrderQuery).WithArgs(pendingRefund.OrderID).WillReturnRows(sqlmock.NewRows(orderColumns).AddRow(
+			pendingRefund.OrderID, uuid.New(), uuid.New(), item, 3, 2, nil, models.OrderStatusAccepted,
+		))
+		mock.ExpectQuery(completeRefundQuery).WithArgs(pendingRefund.RefundID, "re_fake_000001").WillReturnRows(refundRow())
+		mock.ExpectExec(restockProductQuery).WithArgs(productUUID, uint64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(refundOrderStatusQuery).WithArgs(pendingRefund.OrderID).WillReturnRows(sqlmock.NewRows([]string{"version"}))
+		mock.ExpectCommit()
+
+		completedRefund, err := refundPGRepository.Complete(context.Background(), pendingRefund, "re_fake_000001")
+		require.NoError(t, err)
+		require.Equal(t, models.RefundStatusSucceeded, completedRefund.Status)
+		require.Equal(t, "re_fake_000001", completedRefund.ProviderRefundID)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("RestockLocation", func(t *testing.T) {
+		locationUUID := uuid.New()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockRefundedOrderQuery).WithArgs(pendingRefund.OrderID).WillReturnRows(sqlmock.NewRows(orderColumns).AddRow(
+			pendingRefund.OrderID, uuid.New(), uuid.New(), item, 3, 3, locationUUID, models.OrderStatusDelivered,
+		))
+		mock.ExpectQuery(completeRefundQuery).WithArgs(pendingRefund.RefundID, "re_fake_000001").WillReturnRows(refundRow())
+		mock.ExpectExec(restockLocationQuery).WithArgs(locationUUID, productUUID, uint64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(refundOrderStatusQuery).WithArgs(pendingRefund.OrderID).WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(5))
+		mock.ExpectExec(outboxRepository.AddEventQuery).WithArgs(sqlmock.AnyArg(), models.AggregateOrder, pendingRefund.OrderID, models.EventOrderStatusChanged, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		_, err := refundPGRepository.Complete(context.Background(), pendingRefund, "re_fake_000001")
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("NotPending", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockRefundedOrderQuery).WithArgs(pendingRefund.OrderID).WillReturnRows(sqlmock.NewRows(orderColumns).AddRow(
+			pendingRefund.OrderID, uuid.New(), uuid.New(), item, 3, 3, nil, models.OrderStatusRefunded,
+		))
+		mock.ExpectQuery(completeRefundQuery).WithArgs(pendingRefund.RefundID, "re_fake_000001").WillReturnRows(sqlmock.NewRows(refundColumns))
+		mock.ExpectRollback()
+
+		_, err := refundPGRepository.Complete(context.Background(), pendingRefund, "re_fake_000001")
+		require.ErrorIs(t, err, models.ErrRefundNotPending)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRefundRepository_Fail(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	refundPGRepository := NewRefundPGRepository(sqlxDB)
+
+	pendingRefund := &models.Refund{RefundID: uuid.New(), OrderID: uuid.New(), Quantity: 2, Amount: 20000.0, Status: models.RefundStatusPending}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(lockOrderQuery).WithArgs(pendingRefund.OrderID).WillReturnRows(sqlmock.NewRows([]string{"order_id"}).AddRow(pendingRefund.OrderID))
+	mock.ExpectQuery(failRefundQuery).WithArgs(pendingRefund.RefundID).WillReturnRows(sqlmock.NewRows(refundColumns).AddRow(
+		pendingRefund.RefundID, pendingRefund.OrderID, 2, 20000.0, models.RefundReasonDamaged, "", false, uuid.New(), "", models.RefundStatusFailed, nil, time.Now(),
+	))
+	mock.ExpectExec(releaseRefundQuery).WithArgs(pendingRefund.OrderID, uint64(2), 20000.0).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	failedRefund, err := refundPGRepository.Fail(context.Background(), pendingRefund)
+	require.NoError(t, err)
+	require.Equal(t, models.RefundStatusFailed, failedRefund.Status)
+	require.NoError(t, mock.ExpectationsWereMet())
+	t.Run("Return", func(t *testing.T) {
+		returnUUID := uuid.New()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockOrderQuery).WithArgs(pendingRefund.OrderID).WillReturnRows(sqlmock.NewRows([]string{"order_id"}).AddRow(pendingRefund.OrderID))
+		mock.ExpectQuery(failRefundQuery).WithArgs(pendingRefund.RefundID).WillReturnRows(sqlmock.NewRows(refundColumns).AddRow(
+			pendingRefund.RefundID, pendingRefund.OrderID, 2, 20000.0, models.RefundReasonDamaged, "", false, uuid.New(), "", models.RefundStatusFailed, returnUUID, time.Now(),
+		))
+		mock.ExpectExec(releaseRefundQuery).WithArgs(pendingRefund.OrderID, uint64(2), 20000.0).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(reopenReturnQuery).WithArgs(&returnUUID, pendingRefund.RefundID).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		failedRefund, err := refundPGRepository.Fail(context.Background(), pendingRefund)
+		require.NoError(t, err)
+		require.Equal(t, returnUUID, *failedRefund.ReturnID)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRefundRepository_FindAllByOrderId(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	refundPGRepository := NewRefundPGRepository(sqlxDB)
+
+	orderUUID := uuid.New()
+	rows := sqlmock.NewRows(refundColumns).
+		AddRow(uuid.New(), orderUUID, 1, 10000.0, models.RefundReasonDamaged, "", true, uuid.New(), "re_fake_000001", models.RefundStatusSucceeded, nil, time.Now()).
+		AddRow(uuid.New(), orderUUID, 2, 20000.0, models.RefundReasonCustomerRequest, "", false, uuid.New(), "re_fake_000002", models.RefundStatusPending, nil, time.Now())
+
+	mock.ExpectQuery(findAllByOrderIdQuery).WithArgs(orderUUID).WillReturnRows(rows)
+
+	refunds, err := refundPGRepository.FindAllByOrderId(context.Background(), orderUUID)
+	require.NoError(t, err)
+	require.Len(t, refunds, 2)
+	require.Equal(t, uint64(2), refunds[1].Quantity)
+}
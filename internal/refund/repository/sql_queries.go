@@ -0,0 +1,44 @@
+package repository
+
+const (
+	lockOrderVersionQuery = `SELECT order_id FROM orders WHERE order_id = $1 AND version = $2 AND deleted_at IS NULL FOR UPDATE`
+
+	lockOrderQuery = `SELECT order_id FROM orders WHERE order_id = $1 FOR UPDATE`
+
+	reserveRefundQuery = `UPDATE orders SET refunded_quantity = $2, refunded_amount = $3, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE order_id = $1 AND version = $4 AND deleted_at IS NULL`
+
+	createRefundQuery = `INSERT INTO refunds (order_id, quantity, amount, reason, note, restock, refunded_by, return_id, status) 
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, 'pending')
+		RETURNING refund_id, order_id, quantity, amount, reason, note, restock, refunded_by, provider_refund_id, status, return_id, created_at`
+
+	refundReturnQuery = `UPDATE returns SET status = 'refunded', refund_id = $3, version = version + 1, updated_at = CURRENT_TIMESTAMP
+		WHERE return_id = $1 AND order_id = $2 AND status = 'received'`
+
+	reopenReturnQuery = `UPDATE returns SET status = 'received', refund_id = NULL, version = version + 1, updated_at = CURRENT_TIMESTAMP
+		WHERE return_id = $1 AND refund_id = $2`
+
+	lockRefundedOrderQuery = `SELECT order_id, user_id, brand_id, item, quantity, refunded_quantity, location_id, status FROM orders WHERE order_id = $1 FOR UPDATE`
+
+	completeRefundQuery = `UPDATE refunds SET status = 'succeeded', provider_refund_id = $2 WHERE refund_id = $1 AND status = 'pending'
+		RETURNING refund_id, order_id, quantity, amount, reason, note, restock, refunded_by, provider_refund_id, status, return_id, created_at`
+
+	failRefundQuery = `UPDATE refunds SET status = 'failed' WHERE refund_id = $1 AND status = 'pending'
+		RETURNING refund_id, order_id, quantity, amount, reason, note, restock, refunded_by, provider_refund_id, status, return_id, created_at`
+
+	releaseRefundQuery = `UPDATE orders SET refunded_quantity = refunded_quantity - $2, refunded_amount = refunded_amount - $3, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE order_id = $1`
+
+	// the order becomes refunded once its whole quantity is, and no refund of it is still waiting on the provider
+	refundOrderStatusQuery = `UPDATE orders SET status = 'refunded', version = version + 1, updated_at = CURRENT_TIMESTAMP
+		WHERE order_id = $1 AND refunded_quantity = quantity AND status <> 'refunded'
+		AND NOT EXISTS (SELECT 1 FROM refunds WHERE order_id = $1 AND status = 'pending')
+		RETURNING version`
+
+	restockProductQuery = `UPDATE products SET stock = stock + $2, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE product_id = $1`
+
+	restockLocationQuery = `INSERT INTO location_stocks (location_id, product_id, stock) VALUES ($1, $2, $3)
+		ON CONFLICT (location_id, product_id) DO UPDATE SET stock = location_stocks.stock + EXCLUDED.stock, updated_at = CURRENT_TIMESTAMP`
+
+	findByIdQuery = `SELECT refund_id, order_id, quantity, amount, reason, note, restock, refunded_by, provider_refund_id, status, return_id, created_at FROM refunds WHERE refund_id = $1`
+
+	findAllByOrderIdQuery = `SELECT refund_id, order_id, quantity, amount, reason, note, restock, refunded_by, provider_refund_id, status, return_id, created_at FROM refunds WHERE order_id = $1 ORDER BY created_at`
+)
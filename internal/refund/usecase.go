@@ -0,0 +1,28 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase.go -package mock
+package refund
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/pkg/jobs"
+)
+
+// JobKindComplete kind of the jobs completing a refund the provider did not answer for
+const JobKindComplete = "refunds.complete"
+
+// Refund UseCase interface
+type RefundUseCase interface {
+	Create(ctx context.Context, order *models.Order, refund *models.Refund) (*models.Refund, error)
+	FindAllByOrderId(ctx context.Context, orderID uuid.UUID) ([]models.Refund, error)
+	Complete(ctx context.Context, job *models.RefundJob) error
+}
+
+// JobQueue queue the refund jobs are enqueued to, *jobs.Queue
+type JobQueue interface {
+	Enqueue(ctx context.Context, kind string, payload interface{}, opts ...jobs.EnqueueOption) (*jobs.Job, error)
+}
+
+var _ JobQueue = (*jobs.Queue)(nil)
@@ -0,0 +1,155 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/internal/order"
+	"github.com/dinorain/kalobranded/internal/payment"
+	"github.com/dinorain/kalobranded/internal/refund"
+	"github.com/dinorain/kalobranded/pkg/jobs"
+	"github.com/dinorain/kalobranded/pkg/logger"
+)
+
+// Refund UseCase
+type refundUseCase struct {
+	cfg            *config.Config
+	logger         logger.Logger
+	refundPgRepo   refund.RefundPGRepository
+	orderRedisRepo order.OrderRedisRepository
+	paymentUC      payment.PaymentUseCase
+	queue          refund.JobQueue
+}
+
+var _ refund.RefundUseCase = (*refundUseCase)(nil)
+
+// New Refund UseCase
+func NewRefundUseCase(
+	cfg *config.Config,
+	logger logger.Logger,
+	refundRepo refund.RefundPGRepository,
+	orderRedisRepo order.OrderRedisRepository,
+	paymentUC payment.PaymentUseCase,
+	queue refund.JobQueue,
+) *refundUseCase {
+	return &refundUseCase{cfg: cfg, logger: logger, refundPgRepo: refundRepo, orderRedisRepo: orderRedisRepo, paymentUC: paymentUC, queue: queue}
+}
+
+// Create refund newRefund.Quantity of the order line, the whole refundable quantity when zero. The amount is the
+// share of the paid total, see models.Order.RefundAmount, and refunding the last units marks the order refunded. The
+// refund is pending when the provider did not answer, a background job completes it then
+func (u *refundUseCase) Create(ctx context.Context, order *models.Order, newRefund *models.Refund) (*models.Refund, error) {
+	if !order.CanTransitionTo(models.OrderStatusRefunded) {
+		return nil, errors.Wrapf(models.ErrInvalidStatusTransition, "order is %s", order.Status)
+	}
+
+	if newRefund.Quantity == 0 {
+		newRefund.Quantity = order.RefundableQuantity()
+	}
+	if newRefund.Quantity == 0 || newRefund.Quantity > order.RefundableQuantity() {
+		return nil, errors.Wrapf(models.ErrRefundExceedsQuantity, "%d of %d", newRefund.Quantity, order.RefundableQuantity())
+	}
+
+	newRefund.Amount = order.RefundAmount(newRefund.Quantity)
+	refundedOrder := *order
+	refundedOrder.RefundedQuantity += newRefund.Quantity
+	refundedOrder.RefundedAmount += newRefund.Amount
+	newRefund.OrderID = order.OrderID
+
+	// the quantity is taken off the order before any money moves, so concurrent refunds cannot both pay it out
+	pendingRefund, err := u.refundPgRepo.Create(ctx, newRefund, &refundedOrder)
+	if err != nil {
+		return nil, errors.Wrap(err, "refundPgRepo.Create")
+	}
+	u.deleteCachedOrder(ctx, order.OrderID)
+
+	completedRefund, err := u.complete(ctx, pendingRefund)
+	if err != nil {
+		if isRejected(err) {
+			return nil, err
+		}
+
+		u.logger.Errorf("refund %s left pending: %v", pendingRefund.RefundID, err)
+		job := &models.RefundJob{RefundID: pendingRefund.RefundID}
+		if _, err := u.queue.Enqueue(ctx, refund.JobKindComplete, job, jobs.UniqueKey(pendingRefund.RefundID.String())); err != nil {
+			u.logger.Errorf("queue.Enqueue: %v", err)
+		}
+		return pendingRefund, nil
+	}
+
+	return completedRefund, nil
+}
+
+// Complete complete the pending refund of job, asking the provider again with the same idempotency key so that the
+// money is given back once however many times it is asked
+func (u *refundUseCase) Complete(ctx context.Context, job *models.RefundJob) error {
+	foundRefund, err := u.refundPgRepo.FindById(ctx, job.RefundID)
+	if err != nil {
+		return errors.Wrap(err, "refundPgRepo.FindById")
+	}
+	if foundRefund.Status != models.RefundStatusPending {
+		return nil
+	}
+
+	if _, err := u.complete(ctx, foundRefund); err != nil {
+		if isRejected(err) {
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
+
+// complete have the provider make the pending refund, idempotent on its id. The refund is failed and its quantity
+// given back to the order when the provider turns it down
+func (u *refundUseCase) complete(ctx context.Context, pendingRefund *models.Refund) (*models.Refund, error) {
+	providerRefund, err := u.paymentUC.Refund(ctx, pendingRefund.OrderID, pendingRefund.Amount, pendingRefund.RefundID.String())
+	if err != nil {
+		if isRejected(err) {
+			if _, err := u.refundPgRepo.Fail(ctx, pendingRefund); err != nil {
+				u.logger.Errorf("refundPgRepo.Fail: %v", err)
+			}
+			u.deleteCachedOrder(ctx, pendingRefund.OrderID)
+		}
+		return nil, errors.Wrap(err, "paymentUC.Refund")
+	}
+
+	completedRefund, err := u.refundPgRepo.Complete(ctx, pendingRefund, providerRefund.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "refundPgRepo.Complete")
+	}
+	u.deleteCachedOrder(ctx, pendingRefund.OrderID)
+
+	return completedRefund, nil
+}
+
+func (u *refundUseCase) deleteCachedOrder(ctx context.Context, orderID uuid.UUID) {
+	if err := u.orderRedisRepo.DeleteOrderCtx(ctx, orderID.String()); err != nil {
+		u.logger.Errorf("orderRedisRepo.DeleteOrderCtx", err)
+	}
+}
+
+// isRejected whether the provider turned the refund down, as opposed to not answering
+func isRejected(err error) bool {
+	for _, rejected := range []error{payment.ErrRefundExceedsAmount, payment.ErrPaymentNotCaptured, payment.ErrIntentNotCapturable, payment.ErrIntentNotFound} {
+		if errors.Is(err, rejected) {
+			return true
+		}
+	}
+	return false
+}
+
+// FindAllByOrderId find refunds of order
+func (u *refundUseCase) FindAllByOrderId(ctx context.Context, orderID uuid.UUID) ([]models.Refund, error) {
+	refunds, err := u.refundPgRepo.FindAllByOrderId(ctx, orderID)
+	if err != nil {
+		return nil, errors.Wrap(err, "refundPgRepo.FindAllByOrderId")
+	}
+
+	return refunds, nil
+}
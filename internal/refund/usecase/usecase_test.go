@@ -0,0 +1,249 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/models"
+	orderMock "github.com/dinorain/kalobranded/internal/order/mock"
+	"github.com/dinorain/kalobranded/internal/payment"
+	paymentMock "github.com/dinorain/kalobranded/internal/payment/mock"
+	"github.com/dinorain/kalobranded/internal/refund"
+	"github.com/dinorain/kalobranded/internal/refund/mock"
+	"github.com/dinorain/kalobranded/pkg/jobs"
+	"github.com/dinorain/kalobranded/pkg/logger"
+)
+
+func TestRefundUseCase_Create(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	refundPGRepository := mock.NewMockRefundPGRepository(ctrl)
+	orderRedisRepository := orderMock.NewMockOrderRedisRepository(ctrl)
+	paymentUC := paymentMock.NewMockPaymentUseCase(ctrl)
+	queue := mock.NewMockJobQueue(ctrl)
+	apiLogger := logger.NewAppLogger(&config.Config{})
+	apiLogger.InitLogger()
+
+	cfg := &config.Config{}
+	refundUC := NewRefundUseCase(cfg, apiLogger, refundPGRepository, orderRedisRepository, paymentUC, queue)
+
+	ctx := context.Background()
+	adminUUID := uuid.New()
+	newOrder := func() *models.Order {
+		return &models.Order{
+			OrderID:    uuid.New(),
+			Item:       models.OrderItem{ProductID: uuid.New(), Price: 10000.0},
+			Quantity:   3,
+			TotalPrice: 30000.0,
+			Status:     models.OrderStatusAccepted,
+			Version:    2,
+		}
+	}
+	// reserve expect the refund saved pending, checking the order it is taken off
+	reserve := func(check func(refund *models.Refund, refundedOrder *models.Order)) *models.Refund {
+		pendingRefund := &models.Refund{}
+		refundPGRepository.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, refund *models.Refund, refundedOrder *models.Order) (*models.Refund, error) {
+			check(refund, refundedOrder)
+			*pendingRefund = *refund
+			pendingRefund.RefundID = uuid.New()
+			pendingRefund.Status = models.RefundStatusPending
+			return pendingRefund, nil
+		})
+		return pendingRefund
+	}
+	complete := func(pendingRefund *models.Refund, providerRefundID string) {
+		refundPGRepository.EXPECT().Complete(gomock.Any(), pendingRefund, providerRefundID).DoAndReturn(func(_ context.Context, refund *models.Refund, providerRefundID string) (*models.Refund, error) {
+			completedRefund := *refund
+			completedRefund.Status, completedRefund.ProviderRefundID = models.RefundStatusSucceeded, providerRefundID
+			return &completedRefund, nil
+		})
+	}
+
+	t.Run("Partial", func(t *testing.T) {
+		order := newOrder()
+
+		pendingRefund := reserve(func(refund *models.Refund, refundedOrder *models.Order) {
+			require.Empty(t, refund.ProviderRefundID)
+			require.Equal(t, uint64(2), refundedOrder.RefundedQuantity)
+			require.Equal(t, 20000.0, refundedOrder.RefundedAmount)
+			require.Equal(t, models.OrderStatusAccepted, refundedOrder.Status)
+			require.Equal(t, 2, refundedOrder.Version)
+		})
+		paymentUC.EXPECT().Refund(gomock.Any(), order.OrderID, 20000.0, gomock.Any()).DoAndReturn(func(_ context.Context, _ uuid.UUID, amount float64, idempotencyKey string) (*payment.Refund, error) {
+			require.Equal(t, pendingRefund.RefundID.String(), idempotencyKey)
+			return &payment.Refund{ID: "re_fake_000001", Amount: amount}, nil
+		})
+		complete(pendingRefund, "re_fake_000001")
+		orderRedisRepository.EXPECT().DeleteOrderCtx(gomock.Any(), order.OrderID.String()).Return(nil).Times(2)
+
+		createdRefund, err := refundUC.Create(ctx, order, &models.Refund{Quantity: 2, Reason: models.RefundReasonDamaged, RefundedBy: adminUUID})
+		require.NoError(t, err)
+		require.Equal(t, 20000.0, createdRefund.Amount)
+		require.Equal(t, order.OrderID, createdRefund.OrderID)
+		require.Equal(t, models.RefundStatusSucceeded, createdRefund.Status)
+	})
+
+	t.Run("Remaining", func(t *testing.T) {
+		order := newOrder()
+		order.RefundedQuantity = 1
+		order.RefundedAmount = 10000.0
+
+		pendingRefund := reserve(func(refund *models.Refund, refundedOrder *models.Order) {
+			require.Equal(t, uint64(3), refundedOrder.RefundedQuantity)
+			require.Equal(t, 30000.0, refundedOrder.RefundedAmount)
+		})
+		paymentUC.EXPECT().Refund(gomock.Any(), order.OrderID, 20000.0, gomock.Any()).Return(&payment.Refund{ID: "re_fake_000002", Amount: 20000.0}, nil)
+		complete(pendingRefund, "re_fake_000002")
+		orderRedisRepository.EXPECT().DeleteOrderCtx(gomock.Any(), order.OrderID.String()).Return(nil).Times(2)
+
+		createdRefund, err := refundUC.Create(ctx, order, &models.Refund{Reason: models.RefundReasonCustomerRequest, RefundedBy: adminUUID})
+		require.NoError(t, err)
+		require.Equal(t, uint64(2), createdRefund.Quantity)
+	})
+
+	t.Run("Discounted", func(t *testing.T) {
+		// 30000 less 3000 discount, 11% tax of 2970 and a 5000 delivery fee
+		order := newOrder()
+		order.DiscountTotal, order.TaxTotal, order.DeliveryFee = 3000.0, 2970.0, 5000.0
+		order.TotalPrice = 34970.0
+
+		pendingRefund := reserve(func(refund *models.Refund, refundedOrder *models.Order) {
+			require.Equal(t, 9990.0, refund.Amount)
+			require.Equal(t, 9990.0, refundedOrder.RefundedAmount)
+		})
+		paymentUC.EXPECT().Refund(gomock.Any(), order.OrderID, 9990.0, gomock.Any()).Return(&payment.Refund{ID: "re_fake_000003", Amount: 9990.0}, nil)
+		complete(pendingRefund, "re_fake_000003")
+		orderRedisRepository.EXPECT().DeleteOrderCtx(gomock.Any(), order.OrderID.String()).Return(nil).Times(2)
+
+		createdRefund, err := refundUC.Create(ctx, order, &models.Refund{Quantity: 1, Reason: models.RefundReasonDamaged, RefundedBy: adminUUID})
+		require.NoError(t, err)
+		require.Equal(t, 9990.0, createdRefund.Amount)
+
+		// the last units get the rest, delivery fee included
+		order.RefundedQuantity, order.RefundedAmount = 1, 9990.0
+		pendingRefund = reserve(func(refund *models.Refund, refundedOrder *models.Order) {
+			require.Equal(t, 24980.0, refund.Amount)
+			require.Equal(t, order.TotalPrice, refundedOrder.RefundedAmount)
+		})
+		paymentUC.EXPECT().Refund(gomock.Any(), order.OrderID, 24980.0, gomock.Any()).Return(&payment.Refund{ID: "re_fake_000004", Amount: 24980.0}, nil)
+		complete(pendingRefund, "re_fake_000004")
+		orderRedisRepository.EXPECT().DeleteOrderCtx(gomock.Any(), order.OrderID.String()).Return(nil).Times(2)
+
+		_, err = refundUC.Create(ctx, order, &models.Refund{Reason: models.RefundReasonDamaged, RefundedBy: adminUUID})
+		require.NoError(t, err)
+	})
+
+	t.Run("CappedAtNetTotal", func(t *testing.T) {
+		order := newOrder()
+		order.RefundedQuantity, order.RefundedAmount = 1, 25000.0
+
+		pendingRefund := reserve(func(refund *models.Refund, refundedOrder *models.Order) {
+			require.Equal(t, 5000.0, refund.Amount)
+			require.Equal(t, order.TotalPrice, refundedOrder.RefundedAmount)
+		})
+		paymentUC.EXPECT().Refund(gomock.Any(), order.OrderID, 5000.0, gomock.Any()).Return(&payment.Refund{ID: "re_fake_000005", Amount: 5000.0}, nil)
+		complete(pendingRefund, "re_fake_000005")
+		orderRedisRepository.EXPECT().DeleteOrderCtx(gomock.Any(), order.OrderID.String()).Return(nil).Times(2)
+
+		createdRefund, err := refundUC.Create(ctx, order, &models.Refund{Quantity: 1, Reason: models.RefundReasonDamaged, RefundedBy: adminUUID})
+		require.NoError(t, err)
+		require.Equal(t, 5000.0, createdRefund.Amount)
+	})
+
+	t.Run("ExceedsQuantity", func(t *testing.T) {
+		_, err := refundUC.Create(ctx, newOrder(), &models.Refund{Quantity: 4, Reason: models.RefundReasonDamaged, RefundedBy: adminUUID})
+		require.ErrorIs(t, err, models.ErrRefundExceedsQuantity)
+	})
+
+	t.Run("NotPaid", func(t *testing.T) {
+		order := newOrder()
+		order.Status = models.OrderStatusPending
+
+		_, err := refundUC.Create(ctx, order, &models.Refund{Reason: models.RefundReasonDamaged, RefundedBy: adminUUID})
+		require.ErrorIs(t, err, models.ErrInvalidStatusTransition)
+	})
+
+	t.Run("VersionConflict", func(t *testing.T) {
+		// the order changed meanwhile, nothing is paid out
+		refundPGRepository.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, models.ErrVersionConflict)
+
+		_, err := refundUC.Create(ctx, newOrder(), &models.Refund{Reason: models.RefundReasonDamaged, RefundedBy: adminUUID})
+		require.ErrorIs(t, err, models.ErrVersionConflict)
+	})
+
+	t.Run("NotCaptured", func(t *testing.T) {
+		order := newOrder()
+
+		pendingRefund := reserve(func(*models.Refund, *models.Order) {})
+		paymentUC.EXPECT().Refund(gomock.Any(), order.OrderID, 30000.0, gomock.Any()).Return(nil, payment.ErrPaymentNotCaptured)
+		refundPGRepository.EXPECT().Fail(gomock.Any(), pendingRefund).Return(pendingRefund, nil)
+		orderRedisRepository.EXPECT().DeleteOrderCtx(gomock.Any(), order.OrderID.String()).Return(nil).Times(2)
+
+		_, err := refundUC.Create(ctx, order, &models.Refund{Reason: models.RefundReasonDamaged, RefundedBy: adminUUID})
+		require.ErrorIs(t, err, payment.ErrPaymentNotCaptured)
+	})
+
+	t.Run("ProviderUnavailable", func(t *testing.T) {
+		order := newOrder()
+
+		pendingRefund := reserve(func(*models.Refund, *models.Order) {})
+		paymentUC.EXPECT().Refund(gomock.Any(), order.OrderID, 30000.0, gomock.Any()).Return(nil, errors.New("gateway timeout"))
+		queue.EXPECT().Enqueue(gomock.Any(), refund.JobKindComplete, gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, _ string, payload interface{}, _ ...jobs.EnqueueOption) (*jobs.Job, error) {
+			require.Equal(t, &models.RefundJob{RefundID: pendingRefund.RefundID}, payload)
+			return &jobs.Job{}, nil
+		})
+		orderRedisRepository.EXPECT().DeleteOrderCtx(gomock.Any(), order.OrderID.String()).Return(nil)
+
+		createdRefund, err := refundUC.Create(ctx, order, &models.Refund{Reason: models.RefundReasonDamaged, RefundedBy: adminUUID})
+		require.NoError(t, err)
+		require.Equal(t, models.RefundStatusPending, createdRefund.Status)
+	})
+}
+
+func TestRefundUseCase_Complete(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	refundPGRepository := mock.NewMockRefundPGRepository(ctrl)
+	orderRedisRepository := orderMock.NewMockOrderRedisRepository(ctrl)
+	paymentUC := paymentMock.NewMockPaymentUseCase(ctrl)
+	apiLogger := logger.NewAppLogger(&config.Config{})
+	apiLogger.InitLogger()
+
+	refundUC := NewRefundUseCase(&config.Config{}, apiLogger, refundPGRepository, orderRedisRepository, paymentUC, mock.NewMockJobQueue(ctrl))
+
+	ctx := context.Background()
+	pendingRefund := &models.Refund{RefundID: uuid.New(), OrderID: uuid.New(), Amount: 10000.0, Status: models.RefundStatusPending}
+
+	refundPGRepository.EXPECT().FindById(gomock.Any(), pendingRefund.RefundID).Return(pendingRefund, nil)
+	paymentUC.EXPECT().Refund(gomock.Any(), pendingRefund.OrderID, 10000.0, pendingRefund.RefundID.String()).Return(&payment.Refund{ID: "re_fake_000003"}, nil)
+	refundPGRepository.EXPECT().Complete(gomock.Any(), pendingRefund, "re_fake_000003").Return(&models.Refund{Status: models.RefundStatusSucceeded}, nil)
+	orderRedisRepository.EXPECT().DeleteOrderCtx(gomock.Any(), pendingRefund.OrderID.String()).Return(nil)
+
+	require.NoError(t, refundUC.Complete(ctx, &models.RefundJob{RefundID: pendingRefund.RefundID}))
+
+	t.Run("Unavailable", func(t *testing.T) {
+		refundPGRepository.EXPECT().FindById(gomock.Any(), pendingRefund.RefundID).Return(pendingRefund, nil)
+		paymentUC.EXPECT().Refund(gomock.Any(), pendingRefund.OrderID, 10000.0, pendingRefund.RefundID.String()).Return(nil, errors.New("gateway timeout"))
+
+		require.Error(t, refundUC.Complete(ctx, &models.RefundJob{RefundID: pendingRefund.RefundID}))
+	})
+
+	t.Run("AlreadyCompleted", func(t *testing.T) {
+		completedRefund := &models.Refund{RefundID: uuid.New(), Status: models.RefundStatusSucceeded}
+		refundPGRepository.EXPECT().FindById(gomock.Any(), completedRefund.RefundID).Return(completedRefund, nil)
+
+		require.NoError(t, refundUC.Complete(ctx, &models.RefundJob{RefundID: completedRefund.RefundID}))
+	})
+}
@@ -0,0 +1,29 @@
+package dto
+
+import (
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/pkg/utils"
+)
+
+// SalesResponseDto sales by period on the days from From to To, both included
+type SalesResponseDto struct {
+	From     string               `json:"from"`
+	To       string               `json:"to"`
+	Interval string               `json:"interval"`
+	Data     []models.SalesPeriod `json:"data"`
+}
+
+// ProductSalesResponseDto sales by product on the days from From to To, both included
+type ProductSalesResponseDto struct {
+	From string                  `json:"from"`
+	To   string                  `json:"to"`
+	Meta utils.PaginationMetaDto `json:"meta"`
+	Data []models.ProductSales   `json:"data"`
+}
+
+// SalesSummaryResponseDto sales totals on the days from From to To, both included
+type SalesSummaryResponseDto struct {
+	From string               `json:"from"`
+	To   string               `json:"to"`
+	Data *models.SalesSummary `json:"data"`
+}
@@ -0,0 +1,261 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/middlewares"
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/internal/report"
+	"github.com/dinorain/kalobranded/internal/report/delivery/http/dto"
+	"github.com/dinorain/kalobranded/internal/server/router"
+	"github.com/dinorain/kalobranded/pkg/constants"
+	httpErrors "github.com/dinorain/kalobranded/pkg/http_errors"
+	"github.com/dinorain/kalobranded/pkg/logger"
+	"github.com/dinorain/kalobranded/pkg/utils"
+)
+
+const (
+	day          = 24 * time.Hour
+	dateLayout   = "2006-01-02"
+	defaultDays  = 30
+	maxRangeDays = 3 * 366
+)
+
+type reportHandlersHTTP struct {
+	router   *router.Router
+	logger   logger.Logger
+	cfg      *config.Config
+	mw       middlewares.MiddlewareManager
+	reportUC report.ReportUseCase
+}
+
+var _ report.ReportHandlers = (*reportHandlersHTTP)(nil)
+
+func NewReportHandlersHTTP(
+	router *router.Router,
+	logger logger.Logger,
+	cfg *config.Config,
+	mw middlewares.MiddlewareManager,
+	reportUC report.ReportUseCase,
+) *reportHandlersHTTP {
+	return &reportHandlersHTTP{router: router, logger: logger, cfg: cfg, mw: mw, reportUC: reportUC}
+}
+
+// Sales
+// @Tags Reports
+// @Summary Sales by period
+// @Description Admin or seller find revenue, order count, units sold and average order value by day, week or month. Sellers get the sales of their brand, admins of brand_id or of every brand. Sales are paid, accepted, shipped and delivered orders net of partial refunds, by the UTC day they were placed, from the rollups refreshed on a schedule. Days default to the last 30, periods without sales are left out
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param brand_id query string false "brand uuid, admin only"
+// @Param from query string false "first day, RFC 3339 time or date"
+// @Param to query string false "last day, RFC 3339 time or date"
+// @Param interval query string false "day, week or month, day by default"
+// @Success 200 {object} dto.SalesResponseDto
+// @Router /reports/sales [get]
+func (h *reportHandlersHTTP) Sales(w http.ResponseWriter, r *http.Request) {
+	filter, err := h.salesFilter(w, r)
+	if err != nil {
+		return
+	}
+
+	interval := r.URL.Query().Get(constants.Interval)
+	if interval == "" {
+		interval = models.SalesIntervalDay
+	}
+	if !models.IsSalesInterval(interval) {
+		_ = httpErrors.NewBadRequestError(w, "invalid interval: "+interval, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	periods, err := h.reportUC.Sales(r.Context(), filter, interval)
+	if err != nil {
+		h.logger.Errorf("reportUC.Sales: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	from, to := reportRange(filter)
+	res, _ := json.Marshal(dto.SalesResponseDto{
+		From:     from,
+		To:       to,
+		Interval: interval,
+		Data:     append([]models.SalesPeriod{}, periods...),
+	})
+	w.WriteHeader(http.StatusOK)
+	w.Write(res)
+	return
+}
+
+// TopProducts
+// @Tags Reports
+// @Summary Top products
+// @Description Admin or seller find the best selling products, by revenue unless sorted. sort is one of revenue, units and orders, descending when prefixed with -. Brand and days are chosen as for sales
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param brand_id query string false "brand uuid, admin only"
+// @Param from query string false "first day, RFC 3339 time or date"
+// @Param to query string false "last day, RFC 3339 time or date"
+// @Param sort query string false "sort key, e.g. -units"
+// @Param size query string false "pagination size"
+// @Param page query string false "pagination page"
+// @Success 200 {object} dto.ProductSalesResponseDto
+// @Router /reports/products [get]
+func (h *reportHandlersHTTP) TopProducts(w http.ResponseWriter, r *http.Request) {
+	queryParam := r.URL.Query()
+	pq := utils.NewPaginationFromQueryParams(queryParam.Get(constants.Size), queryParam.Get(constants.Page))
+	pq.SetOrderBy(queryParam.Get(constants.Sort))
+	if err := models.ProductSalesSorts.Validate(pq.GetOrderBy()); err != nil {
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	filter, err := h.salesFilter(w, r)
+	if err != nil {
+		return
+	}
+
+	products, err := h.reportUC.TopProducts(r.Context(), filter, pq)
+	if err != nil {
+		h.logger.Errorf("reportUC.TopProducts: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	from, to := reportRange(filter)
+	res, _ := json.Marshal(dto.ProductSalesResponseDto{
+		From: from,
+		To:   to,
+		Meta: utils.PaginationMetaDto{
+			Limit:  pq.GetLimit(),
+			Offset: pq.GetOffset(),
+			Page:   pq.GetPage(),
+		},
+		Data: append([]models.ProductSales{}, products...),
+	})
+	w.WriteHeader(http.StatusOK)
+	w.Write(res)
+	return
+}
+
+// Summary
+// @Tags Reports
+// @Summary Sales summary
+// @Description Admin or seller find total revenue, orders, units sold, average order value and repeat-buyer rate, the share of buyers with more than one order. Brand and days are chosen as for sales
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param brand_id query string false "brand uuid, admin only"
+// @Param from query string false "first day, RFC 3339 time or date"
+// @Param to query string false "last day, RFC 3339 time or date"
+// @Success 200 {object} dto.SalesSummaryResponseDto
+// @Router /reports/summary [get]
+func (h *reportHandlersHTTP) Summary(w http.ResponseWriter, r *http.Request) {
+	filter, err := h.salesFilter(w, r)
+	if err != nil {
+		return
+	}
+
+	summary, err := h.reportUC.Summary(r.Context(), filter)
+	if err != nil {
+		h.logger.Errorf("reportUC.Summary: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	from, to := reportRange(filter)
+	res, _ := json.Marshal(dto.SalesSummaryResponseDto{From: from, To: to, Data: summary})
+	w.WriteHeader(http.StatusOK)
+	w.Write(res)
+	return
+}
+
+// Refresh
+// @Tags Reports
+// @Summary Refresh reports
+// @Description Admin refresh the sales rollups now rather than on the next scheduled refresh
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 204
+// @Router /reports/refresh [post]
+func (h *reportHandlersHTTP) Refresh(w http.ResponseWriter, r *http.Request) {
+	if err := h.reportUC.Refresh(r.Context()); err != nil {
+		h.logger.Errorf("reportUC.Refresh: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return
+}
+
+// salesFilter sales filter of the query parameters. Sellers get the sales of their brand only, admins of brand_id
+// or of every brand. The days run from from to to included, the last defaultDays up to today when not given
+func (h *reportHandlersHTTP) salesFilter(w http.ResponseWriter, r *http.Request) (*models.SalesFilter, error) {
+	jwtClaims, err := h.mw.GetJWTClaims(w, r)
+	if err != nil {
+		return nil, err
+	}
+	claims := *jwtClaims
+	role, _ := claims["role"].(string)
+
+	queryParam := r.URL.Query()
+	brandID, err := utils.ParseQueryUUID(queryParam, constants.BrandID)
+	if err != nil {
+		return nil, httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+	}
+	if role != models.UserRoleAdmin {
+		claimBrandID, _ := claims["brand_id"].(string)
+		sellerBrandID, err := uuid.Parse(claimBrandID)
+		if err != nil || (brandID != nil && *brandID != sellerBrandID) {
+			_ = httpErrors.NewForbiddenError(w, nil, h.cfg.Http.DebugErrorsResponse)
+			return nil, errors.New("forbidden")
+		}
+		brandID = &sellerBrandID
+	}
+
+	from, err := utils.ParseQueryTime(queryParam, constants.From, false)
+	if err != nil {
+		return nil, httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+	}
+	to, err := utils.ParseQueryTime(queryParam, constants.To, true)
+	if err != nil {
+		return nil, httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+	}
+
+	filter := &models.SalesFilter{BrandID: brandID, To: time.Now().UTC().Truncate(day).Add(day)}
+	if to != nil {
+		// a time includes the day it falls in
+		filter.To = to.UTC().Truncate(day)
+		if filter.To.Before(to.UTC()) {
+			filter.To = filter.To.Add(day)
+		}
+	}
+	filter.From = filter.To.AddDate(0, 0, -defaultDays)
+	if from != nil {
+		filter.From = from.UTC().Truncate(day)
+	}
+
+	if !filter.From.Before(filter.To) {
+		return nil, httpErrors.NewBadRequestError(w, "from must be before to", h.cfg.Http.DebugErrorsResponse)
+	}
+	if filter.To.Sub(filter.From) > maxRangeDays*day {
+		return nil, httpErrors.NewBadRequestError(w, "range over 3 years", h.cfg.Http.DebugErrorsResponse)
+	}
+
+	return filter, nil
+}
+
+// reportRange first and last days of filter
+func reportRange(filter *models.SalesFilter) (string, string) {
+	return filter.From.Format(dateLayout), filter.To.Add(-day).Format(dateLayout)
+}
@@ -0,0 +1,233 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/middlewares"
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/internal/report/delivery/http/dto"
+	"github.com/dinorain/kalobranded/internal/report/mock"
+	"github.com/dinorain/kalobranded/internal/server/router"
+	"github.com/dinorain/kalobranded/pkg/logger"
+	"github.com/dinorain/kalobranded/pkg/utils"
+)
+
+func signedToken(t *testing.T, cfg *config.Config, userUUID uuid.UUID, role string, brandUUID *uuid.UUID) string {
+	token := jwt.New(jwt.SigningMethodHS256)
+	claims := token.Claims.(jwt.MapClaims)
+	claims["session_id"] = uuid.New().String()
+	claims["user_id"] = userUUID.String()
+	claims["role"] = role
+	if brandUUID != nil {
+		claims["brand_id"] = brandUUID.String()
+	}
+	claims["exp"] = time.Now().Add(time.Minute * 15).Unix()
+	validToken, err := token.SignedString([]byte(cfg.Server.JwtSecretKey))
+	require.NoError(t, err)
+	return validToken
+}
+
+func TestReportHandler_Sales(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reportUC := mock.NewMockReportUseCase(ctrl)
+
+	cfg := &config.Config{Session: config.Session{Expire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	appLogger := logger.NewAppLogger(cfg)
+	appLogger.InitLogger()
+	mw := middlewares.NewMiddlewareManager(appLogger, cfg)
+
+	handlers := NewReportHandlersHTTP(router.NewRouter(false), appLogger, cfg, mw, reportUC)
+
+	brandUUID := uuid.New()
+	sellerToken := signedToken(t, cfg, uuid.New(), models.UserRoleSeller, &brandUUID)
+	adminToken := signedToken(t, cfg, uuid.New(), models.UserRoleAdmin, nil)
+
+	t.Run("Seller", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/reports/sales?interval=week", nil)
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", sellerToken))
+		w := httptest.NewRecorder()
+
+		reportUC.EXPECT().Sales(gomock.Any(), gomock.Any(), models.SalesIntervalWeek).DoAndReturn(func(_ context.Context, filter *models.SalesFilter, _ string) ([]models.SalesPeriod, error) {
+			tomorrow := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 1)
+			require.Equal(t, brandUUID, *filter.BrandID)
+			require.Equal(t, tomorrow, filter.To)
+			require.Equal(t, tomorrow.AddDate(0, 0, -30), filter.From)
+			return nil, nil
+		})
+
+		http.HandlerFunc(handlers.Sales).ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		resDto := &dto.SalesResponseDto{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), resDto))
+		require.Equal(t, time.Now().UTC().Format("2006-01-02"), resDto.To)
+		require.NotNil(t, resDto.Data)
+	})
+
+	t.Run("Admin", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/reports/sales?from=2024-05-01&to=2024-05-31T12:00:00Z", nil)
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", adminToken))
+		w := httptest.NewRecorder()
+
+		reportUC.EXPECT().Sales(gomock.Any(), gomock.Any(), models.SalesIntervalDay).DoAndReturn(func(_ context.Context, filter *models.SalesFilter, _ string) ([]models.SalesPeriod, error) {
+			require.Nil(t, filter.BrandID)
+			require.Equal(t, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), filter.From)
+			require.Equal(t, time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), filter.To)
+			return []models.SalesPeriod{{Period: filter.From, Orders: 2, Units: 3, Revenue: 50000, AverageOrderValue: 25000}}, nil
+		})
+
+		http.HandlerFunc(handlers.Sales).ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		resDto := &dto.SalesResponseDto{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), resDto))
+		require.Equal(t, "2024-05-01", resDto.From)
+		require.Equal(t, "2024-05-31", resDto.To)
+		require.Equal(t, 25000.0, resDto.Data[0].AverageOrderValue)
+	})
+
+	t.Run("OtherBrand", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/reports/sales?brand_id="+uuid.New().String(), nil)
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", sellerToken))
+		w := httptest.NewRecorder()
+
+		http.HandlerFunc(handlers.Sales).ServeHTTP(w, req)
+		require.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	for name, query := range map[string]string{
+		"InvalidInterval": "?interval=year",
+		"InvalidFrom":     "?from=last-month",
+		"InvalidBrand":    "?brand_id=42",
+		"InvalidRange":    "?from=2024-06-01&to=2024-05-01",
+		"LongRange":       "?from=2000-01-01&to=2024-05-01",
+	} {
+		query := query
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/reports/sales"+query, nil)
+			req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", adminToken))
+			w := httptest.NewRecorder()
+
+			http.HandlerFunc(handlers.Sales).ServeHTTP(w, req)
+			require.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
+
+func TestReportHandler_TopProducts(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reportUC := mock.NewMockReportUseCase(ctrl)
+
+	cfg := &config.Config{Session: config.Session{Expire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	appLogger := logger.NewAppLogger(cfg)
+	appLogger.InitLogger()
+	mw := middlewares.NewMiddlewareManager(appLogger, cfg)
+
+	handlers := NewReportHandlersHTTP(router.NewRouter(false), appLogger, cfg, mw, reportUC)
+
+	brandUUID, productUUID := uuid.New(), uuid.New()
+	sellerToken := signedToken(t, cfg, uuid.New(), models.UserRoleSeller, &brandUUID)
+
+	req := httptest.NewRequest(http.MethodGet, "/reports/products?sort=-units&size=5&brand_id="+brandUUID.String(), nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", sellerToken))
+	w := httptest.NewRecorder()
+
+	reportUC.EXPECT().TopProducts(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, filter *models.SalesFilter, pq *utils.Pagination) ([]models.ProductSales, error) {
+		require.Equal(t, brandUUID, *filter.BrandID)
+		require.Equal(t, "-units", pq.GetOrderBy())
+		require.Equal(t, 5, pq.GetLimit())
+		return []models.ProductSales{{ProductID: productUUID, ProductName: "Kalo Tee", Orders: 3, Units: 7, Revenue: 350000}}, nil
+	})
+
+	http.HandlerFunc(handlers.TopProducts).ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	resDto := &dto.ProductSalesResponseDto{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), resDto))
+	require.Equal(t, productUUID, resDto.Data[0].ProductID)
+
+	t.Run("InvalidSort", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/reports/products?sort=price", nil)
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", sellerToken))
+		w := httptest.NewRecorder()
+
+		http.HandlerFunc(handlers.TopProducts).ServeHTTP(w, req)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestReportHandler_Summary(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reportUC := mock.NewMockReportUseCase(ctrl)
+
+	cfg := &config.Config{Session: config.Session{Expire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	appLogger := logger.NewAppLogger(cfg)
+	appLogger.InitLogger()
+	mw := middlewares.NewMiddlewareManager(appLogger, cfg)
+
+	handlers := NewReportHandlersHTTP(router.NewRouter(false), appLogger, cfg, mw, reportUC)
+
+	brandUUID := uuid.New()
+	req := httptest.NewRequest(http.MethodGet, "/reports/summary?from=2024-05-01&to=2024-05-31", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", signedToken(t, cfg, uuid.New(), models.UserRoleSeller, &brandUUID)))
+	w := httptest.NewRecorder()
+
+	reportUC.EXPECT().Summary(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, filter *models.SalesFilter) (*models.SalesSummary, error) {
+		require.Equal(t, brandUUID, *filter.BrandID)
+		return &models.SalesSummary{Orders: 10, Revenue: 500000, AverageOrderValue: 50000, Buyers: 8, RepeatBuyers: 2, RepeatBuyerRate: 0.25}, nil
+	})
+
+	http.HandlerFunc(handlers.Summary).ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	resDto := &dto.SalesSummaryResponseDto{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), resDto))
+	require.Equal(t, 0.25, resDto.Data.RepeatBuyerRate)
+	require.Equal(t, "2024-05-31", resDto.To)
+}
+
+func TestReportHandler_Refresh(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	reportUC := mock.NewMockReportUseCase(ctrl)
+
+	cfg := &config.Config{Session: config.Session{Expire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	appLogger := logger.NewAppLogger(cfg)
+	appLogger.InitLogger()
+	mw := middlewares.NewMiddlewareManager(appLogger, cfg)
+
+	handlers := NewReportHandlersHTTP(router.NewRouter(false), appLogger, cfg, mw, reportUC)
+
+	reportUC.EXPECT().Refresh(gomock.Any()).Return(nil)
+
+	w := httptest.NewRecorder()
+	http.HandlerFunc(handlers.Refresh).ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/reports/refresh", nil))
+	require.Equal(t, http.StatusNoContent, w.Code)
+}
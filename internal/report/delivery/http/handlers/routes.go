@@ -0,0 +1,9 @@
+package handlers
+
+func (h *reportHandlersHTTP) ReportMapRoutes() {
+	reports := h.router.Group("/reports")
+	reports.Get("/sales", h.Sales, h.mw.IsAdminOrSeller)
+	reports.Get("/products", h.TopProducts, h.mw.IsAdminOrSeller)
+	reports.Get("/summary", h.Summary, h.mw.IsAdminOrSeller)
+	reports.Post("/refresh", h.Refresh, h.mw.IsAdmin)
+}
@@ -0,0 +1,13 @@
+package report
+
+import (
+	"net/http"
+)
+
+// Report HTTP Handlers interface
+type ReportHandlers interface {
+	Sales(w http.ResponseWriter, r *http.Request)
+	TopProducts(w http.ResponseWriter, r *http.Request)
+	Summary(w http.ResponseWriter, r *http.Request)
+	Refresh(w http.ResponseWriter, r *http.Request)
+}
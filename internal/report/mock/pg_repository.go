@@ -0,0 +1,98 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pg_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/dinorain/kalobranded/internal/models"
+	utils "github.com/dinorain/kalobranded/pkg/utils"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockReportPGRepository is a mock of ReportPGRepository interface.
+type MockReportPGRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReportPGRepositoryMockRecorder
+}
+
+// MockReportPGRepositoryMockRecorder is the mock recorder for MockReportPGRepository.
+type MockReportPGRepositoryMockRecorder struct {
+	mock *MockReportPGRepository
+}
+
+// NewMockReportPGRepository creates a new mock instance.
+func NewMockReportPGRepository(ctrl *gomock.Controller) *MockReportPGRepository {
+	mock := &MockReportPGRepository{ctrl: ctrl}
+	mock.recorder = &MockReportPGRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReportPGRepository) EXPECT() *MockReportPGRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Refresh mocks base method.
+func (m *MockReportPGRepository) Refresh(ctx context.Context, overlap time.Duration) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", ctx, overlap)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockReportPGRepositoryMockRecorder) Refresh(ctx, overlap interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockReportPGRepository)(nil).Refresh), ctx, overlap)
+}
+
+// Sales mocks base method.
+func (m *MockReportPGRepository) Sales(ctx context.Context, filter *models.SalesFilter, interval string) ([]models.SalesPeriod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sales", ctx, filter, interval)
+	ret0, _ := ret[0].([]models.SalesPeriod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Sales indicates an expected call of Sales.
+func (mr *MockReportPGRepositoryMockRecorder) Sales(ctx, filter, interval interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sales", reflect.TypeOf((*MockReportPGRepository)(nil).Sales), ctx, filter, interval)
+}
+
+// Summary mocks base method.
+func (m *MockReportPGRepository) Summary(ctx context.Context, filter *models.SalesFilter) (*models.SalesSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Summary", ctx, filter)
+	ret0, _ := ret[0].(*models.SalesSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Summary indicates an expected call of Summary.
+func (mr *MockReportPGRepositoryMockRecorder) Summary(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Summary", reflect.TypeOf((*MockReportPGRepository)(nil).Summary), ctx, filter)
+}
+
+// TopProducts mocks base method.
+func (m *MockReportPGRepository) TopProducts(ctx context.Context, filter *models.SalesFilter, pagination *utils.Pagination) ([]models.ProductSales, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TopProducts", ctx, filter, pagination)
+	ret0, _ := ret[0].([]models.ProductSales)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TopProducts indicates an expected call of TopProducts.
+func (mr *MockReportPGRepositoryMockRecorder) TopProducts(ctx, filter, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TopProducts", reflect.TypeOf((*MockReportPGRepository)(nil).TopProducts), ctx, filter, pagination)
+}
@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/dinorain/kalobranded/internal/models"
+	utils "github.com/dinorain/kalobranded/pkg/utils"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockReportUseCase is a mock of ReportUseCase interface.
+type MockReportUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockReportUseCaseMockRecorder
+}
+
+// MockReportUseCaseMockRecorder is the mock recorder for MockReportUseCase.
+type MockReportUseCaseMockRecorder struct {
+	mock *MockReportUseCase
+}
+
+// NewMockReportUseCase creates a new mock instance.
+func NewMockReportUseCase(ctrl *gomock.Controller) *MockReportUseCase {
+	mock := &MockReportUseCase{ctrl: ctrl}
+	mock.recorder = &MockReportUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReportUseCase) EXPECT() *MockReportUseCaseMockRecorder {
+	return m.recorder
+}
+
+// Refresh mocks base method.
+func (m *MockReportUseCase) Refresh(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockReportUseCaseMockRecorder) Refresh(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockReportUseCase)(nil).Refresh), ctx)
+}
+
+// Sales mocks base method.
+func (m *MockReportUseCase) Sales(ctx context.Context, filter *models.SalesFilter, interval string) ([]models.SalesPeriod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sales", ctx, filter, interval)
+	ret0, _ := ret[0].([]models.SalesPeriod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Sales indicates an expected call of Sales.
+func (mr *MockReportUseCaseMockRecorder) Sales(ctx, filter, interval interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sales", reflect.TypeOf((*MockReportUseCase)(nil).Sales), ctx, filter, interval)
+}
+
+// Summary mocks base method.
+func (m *MockReportUseCase) Summary(ctx context.Context, filter *models.SalesFilter) (*models.SalesSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Summary", ctx, filter)
+	ret0, _ := ret[0].(*models.SalesSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Summary indicates an expected call of Summary.
+func (mr *MockReportUseCaseMockRecorder) Summary(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Summary", reflect.TypeOf((*MockReportUseCase)(nil).Summary), ctx, filter)
+}
+
+// TopProducts mocks base method.
+func (m *MockReportUseCase) TopProducts(ctx context.Context, filter *models.SalesFilter, pagination *utils.Pagination) ([]models.ProductSales, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TopProducts", ctx, filter, pagination)
+	ret0, _ := ret[0].([]models.ProductSales)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TopProducts indicates an expected call of TopProducts.
+func (mr *MockReportUseCaseMockRecorder) TopProducts(ctx, filter, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TopProducts", reflect.TypeOf((*MockReportUseCase)(nil).TopProducts), ctx, filter, pagination)
+}
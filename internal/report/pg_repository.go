@@ -0,0 +1,18 @@
+//go:generate mockgen -source pg_repository.go -destination mock/pg_repository.go -package mock
+package report
+
+import (
+	"context"
+	"time"
+
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/pkg/utils"
+)
+
+// Report pg repository
+type ReportPGRepository interface {
+	Refresh(ctx context.Context, overlap time.Duration) (int64, error)
+	Sales(ctx context.Context, filter *models.SalesFilter, interval string) ([]models.SalesPeriod, error)
+	TopProducts(ctx context.Context, filter *models.SalesFilter, pagination *utils.Pagination) ([]models.ProductSales, error)
+	Summary(ctx context.Context, filter *models.SalesFilter) (*models.SalesSummary, error)
+}
@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/internal/report"
+	"github.com/dinorain/kalobranded/pkg/utils"
+)
+
+const (
+	dateLayout = "2006-01-02"
+
+	salesRefreshName = "sales"
+)
+
+// periodSorts sales periods are listed oldest first
+var periodSorts = utils.Sorts{Columns: map[string]string{"period": "period"}, Default: "period"}
+
+// Report repository
+type ReportRepository struct {
+	db *sqlx.DB
+}
+
+var _ report.ReportPGRepository = (*ReportRepository)(nil)
+
+// Report repository constructor
+func NewReportPGRepository(db *sqlx.DB) *ReportRepository {
+	return &ReportRepository{db: db}
+}
+
+// Refresh recompute the sales rollups of the brand days with orders changed since the previous refresh less overlap.
+// The days are recomputed from a single snapshot of the orders, returns how many were
+func (r *ReportRepository) Refresh(ctx context.Context, overlap time.Duration) (int64, error) {
+	tx, err := r.db.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead})
+	if err != nil {
+		return 0, errors.Wrap(err, "ReportRepository.Refresh.BeginTxx")
+	}
+	defer tx.Rollback()
+
+	var refreshedThrough, now time.Time
+	if err := tx.QueryRowxContext(ctx, lockRefreshQuery, salesRefreshName).Scan(&refreshedThrough, &now); err != nil {
+		return 0, errors.Wrap(err, "ReportRepository.Refresh.QueryRowxContext")
+	}
+	since := refreshedThrough.Add(-overlap)
+
+	var days int64
+	if err := tx.GetContext(ctx, &days, countTouchedDaysQuery, since); err != nil {
+		return 0, errors.Wrap(err, "ReportRepository.Refresh.GetContext")
+	}
+
+	statuses := pq.Array(models.SalesStatuses)
+	for _, step := range []struct {
+		query string
+		args  []interface{}
+	}{
+		{deleteProductSalesQuery, []interface{}{since}},
+		{insertProductSalesQuery, []interface{}{since, statuses}},
+		{deleteBuyerSalesQuery, []interface{}{since}},
+		{insertBuyerSalesQuery, []interface{}{since, statuses}},
+		{updateRefreshQuery, []interface{}{salesRefreshName, now}},
+	} {
+		if _, err := tx.ExecContext(ctx, step.query, step.args...); err != nil {
+			return 0, errors.Wrap(err, "ReportRepository.Refresh.ExecContext")
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, errors.Wrap(err, "ReportRepository.Refresh.Commit")
+	}
+
+	return days, nil
+}
+
+// Sales sales matching filter by interval, one of models.SalesIntervals, periods without sales are left out
+func (r *ReportRepository) Sales(ctx context.Context, filter *models.SalesFilter, interval string) ([]models.SalesPeriod, error) {
+	if !models.IsSalesInterval(interval) {
+		return nil, errors.Errorf("ReportRepository.Sales: invalid interval %q", interval)
+	}
+
+	query, args, err := salesQuery(fmt.Sprintf(salesByPeriodQuery, interval), filter).
+		GroupBy("period").
+		OrderBy(periodSorts, "").
+		Build()
+	if err != nil {
+		return nil, errors.Wrap(err, "ReportRepository.Sales.Build")
+	}
+
+	var periods []models.SalesPeriod
+	if err := r.db.SelectContext(ctx, &periods, query, args...); err != nil {
+		return nil, errors.Wrap(err, "ReportRepository.Sales.SelectContext")
+	}
+
+	return periods, nil
+}
+
+// TopProducts sales matching filter by product, sorted out of models.ProductSalesSorts
+func (r *ReportRepository) TopProducts(ctx context.Context, filter *models.SalesFilter, pagination *utils.Pagination) ([]models.ProductSales, error) {
+	query, args, err := salesQuery(productSalesQuery, filter).
+		GroupBy("product_id").
+		Paginate(models.ProductSalesSorts, pagination).
+		Build()
+	if err != nil {
+		return nil, errors.Wrap(err, "ReportRepository.TopProducts.Build")
+	}
+
+	var products []models.ProductSales
+	if err := r.db.SelectContext(ctx, &products, query, args...); err != nil {
+		return nil, errors.Wrap(err, "ReportRepository.TopProducts.SelectContext")
+	}
+
+	return products, nil
+}
+
+// Summary sales totals and buyers matching filter
+func (r *ReportRepository) Summary(ctx context.Context, filter *models.SalesFilter) (*models.SalesSummary, error) {
+	query, args, err := salesQuery(salesTotalsQuery, filter).Build()
+	if err != nil {
+		return nil, errors.Wrap(err, "ReportRepository.Summary.Build")
+	}
+
+	summary := &models.SalesSummary{}
+	if err := r.db.GetContext(ctx, summary, query, args...); err != nil {
+		return nil, errors.Wrap(err, "ReportRepository.Summary.GetContext")
+	}
+
+	query, args, err = salesQuery(buyerOrdersQuery, filter).GroupBy("user_id").Build()
+	if err != nil {
+		return nil, errors.Wrap(err, "ReportRepository.Summary.Build")
+	}
+
+	if err := r.db.QueryRowxContext(ctx, fmt.Sprintf(buyersQuery, query), args...).Scan(&summary.Buyers, &summary.RepeatBuyers); err != nil {
+		return nil, errors.Wrap(err, "ReportRepository.Summary.QueryRowxContext")
+	}
+
+	return summary, nil
+}
+
+// salesQuery builder of query over a sales rollup, limited to the days and brand of filter
+func salesQuery(query string, filter *models.SalesFilter) *utils.QueryBuilder {
+	b := utils.NewQueryBuilder(query).
+		Where("day >= ?", filter.From.UTC().Format(dateLayout)).
+		Where("day < ?", filter.To.UTC().Format(dateLayout))
+	if filter.BrandID != nil {
+		b.Where("brand_id = ?", *filter.BrandID)
+	}
+	return b
+}
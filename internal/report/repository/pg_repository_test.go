@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/pkg/utils"
+)
+
+func newTestRepository(t *testing.T) (*ReportRepository, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	return NewReportPGRepository(sqlx.NewDb(db, "sqlmock")), mock
+}
+
+func TestReportRepository_Refresh(t *testing.T) {
+	t.Parallel()
+
+	reportPGRepository, mock := newTestRepository(t)
+
+	refreshedThrough := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	now := refreshedThrough.Add(15 * time.Minute)
+	since := refreshedThrough.Add(-10 * time.Minute)
+	statuses := `{"paid","accepted","shipped","delivered"}`
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(lockRefreshQuery).WithArgs(salesRefreshName).
+		WillReturnRows(sqlmock.NewRows([]string{"refreshed_through", "current_timestamp"}).AddRow(refreshedThrough, now))
+	mock.ExpectQuery(countTouchedDaysQuery).WithArgs(since).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectExec(deleteProductSalesQuery).WithArgs(since).WillReturnResult(sqlmock.NewResult(0, 5))
+	mock.ExpectExec(insertProductSalesQuery).WithArgs(since, statuses).WillReturnResult(sqlmock.NewResult(0, 6))
+	mock.ExpectExec(deleteBuyerSalesQuery).WithArgs(since).WillReturnResult(sqlmock.NewResult(0, 4))
+	mock.ExpectExec(insertBuyerSalesQuery).WithArgs(since, statuses).WillReturnResult(sqlmock.NewResult(0, 4))
+	mock.ExpectExec(updateRefreshQuery).WithArgs(salesRefreshName, now).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	days, err := reportPGRepository.Refresh(context.Background(), 10*time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), days)
+
+	t.Run("Failed", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockRefreshQuery).WithArgs(salesRefreshName).
+			WillReturnRows(sqlmock.NewRows([]string{"refreshed_through", "current_timestamp"}).AddRow(refreshedThrough, now))
+		mock.ExpectQuery(countTouchedDaysQuery).WithArgs(since).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+		mock.ExpectExec(deleteProductSalesQuery).WithArgs(since).WillReturnError(fmt.Errorf("could not serialize access"))
+		mock.ExpectRollback()
+
+		_, err := reportPGRepository.Refresh(context.Background(), 10*time.Minute)
+		require.Error(t, err)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReportRepository_Sales(t *testing.T) {
+	t.Parallel()
+
+	reportPGRepository, mock := newTestRepository(t)
+
+	brandUUID := uuid.New()
+	filter := &models.SalesFilter{
+		BrandID: &brandUUID,
+		From:    time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		To:      time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	mock.ExpectQuery(fmt.Sprintf(salesByPeriodQuery, models.SalesIntervalWeek)+" WHERE day >= $1 AND day < $2 AND brand_id = $3 GROUP BY period ORDER BY period ASC").
+		WithArgs("2024-05-01", "2024-06-01", brandUUID).
+		WillReturnRows(sqlmock.NewRows([]string{"period", "orders", "units", "revenue"}).
+			AddRow(time.Date(2024, 4, 29, 0, 0, 0, 0, time.UTC), 4, 6, "120000.50").
+			AddRow(time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC), 1, 1, "25000"))
+
+	periods, err := reportPGRepository.Sales(context.Background(), filter, models.SalesIntervalWeek)
+	require.NoError(t, err)
+	require.Len(t, periods, 2)
+	require.Equal(t, int64(4), periods[0].Orders)
+	require.Equal(t, int64(6), periods[0].Units)
+	require.Equal(t, 120000.5, periods[0].Revenue)
+
+	t.Run("InvalidInterval", func(t *testing.T) {
+		_, err := reportPGRepository.Sales(context.Background(), filter, "day', day) --")
+		require.Error(t, err)
+	})
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReportRepository_TopProducts(t *testing.T) {
+	t.Parallel()
+
+	reportPGRepository, mock := newTestRepository(t)
+
+	productUUID := uuid.New()
+	filter := &models.SalesFilter{From: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2024, 5, 8, 0, 0, 0, 0, time.UTC)}
+
+	mock.ExpectQuery(productSalesQuery+" WHERE day >= $1 AND day < $2 GROUP BY product_id ORDER BY units DESC, product_id DESC LIMIT $3 OFFSET $4").
+		WithArgs("2024-05-01", "2024-05-08", 5, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"product_id", "product_name", "orders", "units", "revenue"}).AddRow(productUUID, "Kalo Tee", 3, 7, "350000"))
+
+	pagination := utils.NewPaginationQuery(5, 1)
+	pagination.SetOrderBy("-units")
+	products, err := reportPGRepository.TopProducts(context.Background(), filter, pagination)
+	require.NoError(t, err)
+	require.Equal(t, []models.ProductSales{{ProductID: productUUID, ProductName: "Kalo Tee", Orders: 3, Units: 7, Revenue: 350000}}, products)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReportRepository_Summary(t *testing.T) {
+	t.Parallel()
+
+	reportPGRepository, mock := newTestRepository(t)
+
+	brandUUID := uuid.New()
+	filter := &models.SalesFilter{BrandID: &brandUUID, From: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)}
+
+	mock.ExpectQuery(salesTotalsQuery+" WHERE day >= $1 AND day < $2 AND brand_id = $3").
+		WithArgs("2024-05-01", "2024-06-01", brandUUID).
+		WillReturnRows(sqlmock.NewRows([]string{"orders", "units", "revenue"}).AddRow(10, 14, "500000"))
+	mock.ExpectQuery(fmt.Sprintf(buyersQuery, buyerOrdersQuery+" WHERE day >= $1 AND day < $2 AND brand_id = $3 GROUP BY user_id")).
+		WithArgs("2024-05-01", "2024-06-01", brandUUID).
+		WillReturnRows(sqlmock.NewRows([]string{"buyers", "repeat_buyers"}).AddRow(8, 2))
+
+	summary, err := reportPGRepository.Summary(context.Background(), filter)
+	require.NoError(t, err)
+	require.Equal(t, &models.SalesSummary{Orders: 10, Units: 14, Revenue: 500000, Buyers: 8, RepeatBuyers: 2}, summary)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,46 @@
+package repository
+
+const (
+	// touchedDaysQuery brand days with orders created, updated or deleted since $1, a day being the UTC date an
+	// order was created on
+	touchedDaysQuery = `SELECT DISTINCT brand_id, (created_at AT TIME ZONE 'UTC')::date AS day FROM orders
+		WHERE brand_id IS NOT NULL AND (updated_at >= $1 OR deleted_at >= $1)`
+
+	lockRefreshQuery = `SELECT refreshed_through, CURRENT_TIMESTAMP FROM report_refreshes WHERE name = $1 FOR UPDATE`
+
+	countTouchedDaysQuery = `SELECT COUNT(*) FROM (` + touchedDaysQuery + `) AS touched`
+
+	deleteProductSalesQuery = `DELETE FROM sales_daily_products s USING (` + touchedDaysQuery + `) AS touched
+		WHERE s.brand_id = touched.brand_id AND s.day = touched.day`
+
+	insertProductSalesQuery = `INSERT INTO sales_daily_products (brand_id, day, product_id, product_name, orders, units, revenue)
+		SELECT o.brand_id, touched.day, (o.item->>'product_id')::uuid, COALESCE(MAX(o.item->>'name'), ''), COUNT(*), SUM(o.quantity - o.refunded_quantity), SUM(o.total_price - o.refunded_amount)
+		FROM orders o JOIN (` + touchedDaysQuery + `) AS touched ON o.brand_id = touched.brand_id AND (o.created_at AT TIME ZONE 'UTC')::date = touched.day
+		WHERE o.deleted_at IS NULL AND o.status::text = ANY($2) AND o.item->>'product_id' IS NOT NULL
+		GROUP BY o.brand_id, touched.day, (o.item->>'product_id')::uuid`
+
+	deleteBuyerSalesQuery = `DELETE FROM sales_daily_buyers s USING (` + touchedDaysQuery + `) AS touched
+		WHERE s.brand_id = touched.brand_id AND s.day = touched.day`
+
+	insertBuyerSalesQuery = `INSERT INTO sales_daily_buyers (brand_id, day, user_id, orders)
+		SELECT o.brand_id, touched.day, o.user_id, COUNT(*)
+		FROM orders o JOIN (` + touchedDaysQuery + `) AS touched ON o.brand_id = touched.brand_id AND (o.created_at AT TIME ZONE 'UTC')::date = touched.day
+		WHERE o.deleted_at IS NULL AND o.status::text = ANY($2) AND o.user_id IS NOT NULL
+		GROUP BY o.brand_id, touched.day, o.user_id`
+
+	updateRefreshQuery = `UPDATE report_refreshes SET refreshed_through = $2 WHERE name = $1`
+
+	// salesByPeriodQuery takes the date_trunc unit of the period, one of models.SalesIntervals. The builder adds the
+	// conditions, grouping and order
+	salesByPeriodQuery = `SELECT date_trunc('%s', day)::date AS period, SUM(orders) AS orders, SUM(units) AS units, SUM(revenue) AS revenue FROM sales_daily_products`
+
+	// productSalesQuery names products as they were on the last day they sold
+	productSalesQuery = `SELECT product_id, (array_agg(product_name ORDER BY day DESC))[1] AS product_name, SUM(orders) AS orders, SUM(units) AS units, SUM(revenue) AS revenue FROM sales_daily_products`
+
+	salesTotalsQuery = `SELECT COALESCE(SUM(orders), 0) AS orders, COALESCE(SUM(units), 0) AS units, COALESCE(SUM(revenue), 0) AS revenue FROM sales_daily_products`
+
+	buyerOrdersQuery = `SELECT user_id, SUM(orders) AS orders FROM sales_daily_buyers`
+
+	// buyersQuery counts the buyers of the buyerOrdersQuery it wraps
+	buyersQuery = `SELECT COUNT(*) AS buyers, COUNT(*) FILTER (WHERE orders > 1) AS repeat_buyers FROM (%s) AS buyers`
+)
@@ -0,0 +1,20 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase.go -package mock
+package report
+
+import (
+	"context"
+
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/pkg/utils"
+)
+
+// JobKindRefresh kind of the scheduled jobs refreshing the sales rollups
+const JobKindRefresh = "reports.refresh"
+
+// Report UseCase interface
+type ReportUseCase interface {
+	Refresh(ctx context.Context) error
+	Sales(ctx context.Context, filter *models.SalesFilter, interval string) ([]models.SalesPeriod, error)
+	TopProducts(ctx context.Context, filter *models.SalesFilter, pagination *utils.Pagination) ([]models.ProductSales, error)
+	Summary(ctx context.Context, filter *models.SalesFilter) (*models.SalesSummary, error)
+}
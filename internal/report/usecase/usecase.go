@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/internal/report"
+	"github.com/dinorain/kalobranded/pkg/logger"
+	"github.com/dinorain/kalobranded/pkg/utils"
+)
+
+const defaultRefreshOverlap = 10 * time.Minute
+
+// Report UseCase
+type reportUseCase struct {
+	cfg          *config.Config
+	logger       logger.Logger
+	reportPgRepo report.ReportPGRepository
+}
+
+var _ report.ReportUseCase = (*reportUseCase)(nil)
+
+// New Report UseCase
+func NewReportUseCase(cfg *config.Config, logger logger.Logger, reportRepo report.ReportPGRepository) *reportUseCase {
+	return &reportUseCase{cfg: cfg, logger: logger, reportPgRepo: reportRepo}
+}
+
+// Refresh recompute the sales rollups of the days with orders changed since the previous refresh
+func (u *reportUseCase) Refresh(ctx context.Context) error {
+	overlap := u.cfg.Report.RefreshOverlap
+	if overlap <= 0 {
+		overlap = defaultRefreshOverlap
+	}
+
+	days, err := u.reportPgRepo.Refresh(ctx, overlap)
+	if err != nil {
+		return errors.Wrap(err, "reportPgRepo.Refresh")
+	}
+	if days > 0 {
+		u.logger.Infof("reports refreshed %d brand days", days)
+	}
+
+	return nil
+}
+
+// Sales sales matching filter by interval, one of models.SalesIntervals
+func (u *reportUseCase) Sales(ctx context.Context, filter *models.SalesFilter, interval string) ([]models.SalesPeriod, error) {
+	periods, err := u.reportPgRepo.Sales(ctx, filter, interval)
+	if err != nil {
+		return nil, errors.Wrap(err, "reportPgRepo.Sales")
+	}
+
+	for i := range periods {
+		periods[i].AverageOrderValue = models.AverageOrderValue(periods[i].Revenue, periods[i].Orders)
+	}
+
+	return periods, nil
+}
+
+// TopProducts sales matching filter by product
+func (u *reportUseCase) TopProducts(ctx context.Context, filter *models.SalesFilter, pagination *utils.Pagination) ([]models.ProductSales, error) {
+	products, err := u.reportPgRepo.TopProducts(ctx, filter, pagination)
+	if err != nil {
+		return nil, errors.Wrap(err, "reportPgRepo.TopProducts")
+	}
+
+	return products, nil
+}
+
+// Summary sales totals matching filter, with the average order value and the share of buyers with more than one order
+func (u *reportUseCase) Summary(ctx context.Context, filter *models.SalesFilter) (*models.SalesSummary, error) {
+	summary, err := u.reportPgRepo.Summary(ctx, filter)
+	if err != nil {
+		return nil, errors.Wrap(err, "reportPgRepo.Summary")
+	}
+
+	summary.AverageOrderValue = models.AverageOrderValue(summary.Revenue, summary.Orders)
+	if summary.Buyers > 0 {
+		summary.RepeatBuyerRate = float64(summary.RepeatBuyers) / float64(summary.Buyers)
+	}
+
+	return summary, nil
+}
@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/internal/report/mock"
+	"github.com/dinorain/kalobranded/pkg/logger"
+)
+
+func newTestUseCase(t *testing.T, cfg *config.Config) (*reportUseCase, *mock.MockReportPGRepository) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	apiLogger := logger.NewAppLogger(cfg)
+	apiLogger.InitLogger()
+
+	reportPGRepository := mock.NewMockReportPGRepository(ctrl)
+	return NewReportUseCase(cfg, apiLogger, reportPGRepository), reportPGRepository
+}
+
+func TestReportUseCase_Refresh(t *testing.T) {
+	t.Parallel()
+
+	reportUC, reportPGRepository := newTestUseCase(t, &config.Config{})
+	reportPGRepository.EXPECT().Refresh(gomock.Any(), defaultRefreshOverlap).Return(int64(3), nil)
+	require.NoError(t, reportUC.Refresh(context.Background()))
+
+	reportUC, reportPGRepository = newTestUseCase(t, &config.Config{Report: config.Report{RefreshOverlap: time.Hour}})
+	reportPGRepository.EXPECT().Refresh(gomock.Any(), time.Hour).Return(int64(0), errors.New("could not serialize access"))
+	require.Error(t, reportUC.Refresh(context.Background()))
+}
+
+func TestReportUseCase_Sales(t *testing.T) {
+	t.Parallel()
+
+	reportUC, reportPGRepository := newTestUseCase(t, &config.Config{})
+
+	filter := &models.SalesFilter{From: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)}
+	reportPGRepository.EXPECT().Sales(gomock.Any(), filter, models.SalesIntervalMonth).Return([]models.SalesPeriod{
+		{Period: filter.From, Orders: 4, Units: 6, Revenue: 100000},
+		{Period: filter.To, Orders: 0, Units: 0, Revenue: 0},
+	}, nil)
+
+	periods, err := reportUC.Sales(context.Background(), filter, models.SalesIntervalMonth)
+	require.NoError(t, err)
+	require.Equal(t, 25000.0, periods[0].AverageOrderValue)
+	require.Equal(t, 0.0, periods[1].AverageOrderValue)
+}
+
+func TestReportUseCase_Summary(t *testing.T) {
+	t.Parallel()
+
+	reportUC, reportPGRepository := newTestUseCase(t, &config.Config{})
+
+	filter := &models.SalesFilter{From: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)}
+	reportPGRepository.EXPECT().Summary(gomock.Any(), filter).Return(&models.SalesSummary{Orders: 10, Units: 14, Revenue: 500000, Buyers: 8, RepeatBuyers: 2}, nil)
+
+	summary, err := reportUC.Summary(context.Background(), filter)
+	require.NoError(t, err)
+	require.Equal(t, 50000.0, summary.AverageOrderValue)
+	require.Equal(t, 0.25, summary.RepeatBuyerRate)
+
+	t.Run("NoSales", func(t *testing.T) {
+		reportPGRepository.EXPECT().Summary(gomock.Any(), filter).Return(&models.SalesSummary{}, nil)
+
+		summary, err := reportUC.Summary(context.Background(), filter)
+		require.NoError(t, err)
+		require.Zero(t, summary.AverageOrderValue)
+		require.Zero(t, summary.RepeatBuyerRate)
+	})
+}
@@ -0,0 +1,8 @@
+package dto
+
+type ReturnCreateRequestDto struct {
+	Quantity  uint64   `json:"quantity" validate:"required,gt=0"`
+	Reason    string   `json:"reason" validate:"required,oneof=damaged wrong_item not_received customer_request other"`
+	Note      string   `json:"note" validate:"lte=500"`
+	PhotoURLs []string `json:"photo_urls" validate:"lte=10,dive,url"`
+}
@@ -0,0 +1,5 @@
+package dto
+
+type ReturnFindResponseDto struct {
+	Data []*ReturnResponseDto `json:"data"`
+}
@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/internal/models"
+)
+
+type ReturnResponseDto struct {
+	ReturnID        uuid.UUID  `json:"return_id"`
+	OrderID         uuid.UUID  `json:"order_id"`
+	UserID          uuid.UUID  `json:"user_id"`
+	BrandID         uuid.UUID  `json:"brand_id"`
+	Quantity        uint64     `json:"quantity"`
+	Reason          string     `json:"reason"`
+	Note            string     `json:"note"`
+	PhotoURLs       []string   `json:"photo_urls"`
+	Status          string     `json:"status"`
+	LabelAddress    string     `json:"label_address,omitempty"`
+	RejectionReason string     `json:"rejection_reason,omitempty"`
+	RefundID        *uuid.UUID `json:"refund_id,omitempty"`
+	Version         int        `json:"version"`
+	CreatedAt       time.Time  `json:"created_at,omitempty"`
+	UpdatedAt       time.Time  `json:"updated_at,omitempty"`
+}
+
+func ReturnResponseFromModel(returnRequest *models.ReturnRequest) *ReturnResponseDto {
+	photoURLs := []string(returnRequest.PhotoURLs)
+	if photoURLs == nil {
+		photoURLs = []string{}
+	}
+
+	return &ReturnResponseDto{
+		ReturnID:        returnRequest.ReturnID,
+		OrderID:         returnRequest.OrderID,
+		UserID:          returnRequest.UserID,
+		BrandID:         returnRequest.BrandID,
+		Quantity:        returnRequest.Quantity,
+		Reason:          returnRequest.Reason,
+		Note:            returnRequest.Note,
+		PhotoURLs:       photoURLs,
+		Status:          returnRequest.Status,
+		LabelAddress:    returnRequest.LabelAddress,
+		RejectionReason: returnRequest.RejectionReason,
+		RefundID:        returnRequest.RefundID,
+		Version:         returnRequest.Version,
+		CreatedAt:       returnRequest.CreatedAt,
+		UpdatedAt:       returnRequest.UpdatedAt,
+	}
+}
@@ -0,0 +1,9 @@
+package dto
+
+type ReturnRejectRequestDto struct {
+	Reason string `json:"reason" validate:"required,lte=500"`
+}
+
+type ReturnRefundRequestDto struct {
+	Restock bool `json:"restock"`
+}
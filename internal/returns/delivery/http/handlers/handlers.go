@@ -0,0 +1,385 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/middlewares"
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/internal/order"
+	"github.com/dinorain/kalobranded/internal/payment"
+	"github.com/dinorain/kalobranded/internal/returns"
+	"github.com/dinorain/kalobranded/internal/returns/delivery/http/dto"
+	"github.com/dinorain/kalobranded/internal/server/router"
+	"github.com/dinorain/kalobranded/pkg/constants"
+	httpErrors "github.com/dinorain/kalobranded/pkg/http_errors"
+	"github.com/dinorain/kalobranded/pkg/logger"
+)
+
+type returnHandlersHTTP struct {
+	router   *router.Router
+	logger   logger.Logger
+	cfg      *config.Config
+	mw       middlewares.MiddlewareManager
+	v        *validator.Validate
+	returnUC returns.ReturnUseCase
+	orderUC  order.OrderUseCase
+}
+
+var _ returns.ReturnHandlers = (*returnHandlersHTTP)(nil)
+
+func NewReturnHandlersHTTP(
+	router *router.Router,
+	logger logger.Logger,
+	cfg *config.Config,
+	mw middlewares.MiddlewareManager,
+	v *validator.Validate,
+	returnUC returns.ReturnUseCase,
+	orderUC order.OrderUseCase,
+) *returnHandlersHTTP {
+	return &returnHandlersHTTP{router: router, logger: logger, cfg: cfg, mw: mw, v: v, returnUC: returnUC, orderUC: orderUC}
+}
+
+// Create
+// @Tags Returns
+// @Summary Request return
+// @Description Buyer requests returning part of a shipped or delivered order within the return window of its brand, counted from delivery
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "order uuid"
+// @Param payload body dto.ReturnCreateRequestDto true "Payload"
+// @Success 201 {object} dto.ReturnResponseDto
+// @Router /orders/{id}/returns [post]
+func (h *returnHandlersHTTP) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	orderUUID, err := uuid.Parse(router.Param(r, constants.ID))
+	if err != nil {
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	userUUID, err := h.getCaller(w, r)
+	if err != nil {
+		return
+	}
+
+	foundOrder, err := h.orderUC.FindById(ctx, orderUUID)
+	if err != nil {
+		h.logger.Errorf("orderUC.FindById: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	if foundOrder.UserID != userUUID {
+		_ = httpErrors.NewForbiddenError(w, nil, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	createDto := &dto.ReturnCreateRequestDto{}
+	if err := json.NewDecoder(r.Body).Decode(createDto); err != nil {
+		h.logger.Errorf("decoder.Decode: %v", err)
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	if err := h.v.Struct(createDto); err != nil {
+		h.logger.Errorf("h.v.Struct: %v", err)
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	createdReturn, err := h.returnUC.Create(ctx, foundOrder, &models.ReturnRequest{
+		Quantity:  createDto.Quantity,
+		Reason:    createDto.Reason,
+		Note:      createDto.Note,
+		PhotoURLs: createDto.PhotoURLs,
+	})
+	if err != nil {
+		h.logger.Errorf("returnUC.Create: %v", err)
+		if errors.Is(err, models.ErrReturnNotReturnable) || errors.Is(err, models.ErrReturnWindowClosed) || errors.Is(err, models.ErrRefundExceedsQuantity) {
+			_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+			return
+		}
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	res, _ := json.Marshal(dto.ReturnResponseFromModel(createdReturn))
+	w.WriteHeader(http.StatusCreated)
+	w.Write(res)
+	return
+}
+
+// FindAllByOrderId
+// @Tags Returns
+// @Summary Find order returns
+// @Description Find return requests of an order, users can only find returns of their own orders and sellers of their brand orders
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "order uuid"
+// @Success 200 {object} dto.ReturnFindResponseDto
+// @Router /orders/{id}/returns [get]
+func (h *returnHandlersHTTP) FindAllByOrderId(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	orderUUID, err := uuid.Parse(router.Param(r, constants.ID))
+	if err != nil {
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	userUUID, err := h.getCaller(w, r)
+	if err != nil {
+		return
+	}
+
+	foundOrder, err := h.orderUC.FindById(ctx, orderUUID)
+	if err != nil {
+		h.logger.Errorf("orderUC.FindById: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	if foundOrder.UserID != userUUID {
+		if err := h.mw.CheckBrand(w, r, foundOrder.BrandID); err != nil {
+			return
+		}
+	}
+
+	returnRequests, err := h.returnUC.FindAllByOrderId(ctx, foundOrder.OrderID)
+	if err != nil {
+		h.logger.Errorf("returnUC.FindAllByOrderId: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	resDto := dto.ReturnFindResponseDto{Data: make([]*dto.ReturnResponseDto, 0, len(returnRequests))}
+	for i := range returnRequests {
+		resDto.Data = append(resDto.Data, dto.ReturnResponseFromModel(&returnRequests[i]))
+	}
+
+	res, _ := json.Marshal(resDto)
+	w.WriteHeader(http.StatusOK)
+	w.Write(res)
+	return
+}
+
+// FindById
+// @Tags Returns
+// @Summary Find return
+// @Description Find return request by id, users can only find their own returns and sellers returns of their brand
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "return uuid"
+// @Success 200 {object} dto.ReturnResponseDto
+// @Router /returns/{id} [get]
+func (h *returnHandlersHTTP) FindById(w http.ResponseWriter, r *http.Request) {
+	foundReturn, _, err := h.findReturn(w, r, true)
+	if err != nil {
+		return
+	}
+
+	res, _ := json.Marshal(dto.ReturnResponseFromModel(foundReturn))
+	w.WriteHeader(http.StatusOK)
+	w.Write(res)
+	return
+}
+
+// Approve
+// @Tags Returns
+// @Summary Approve return
+// @Description Admin or seller of the brand approves a requested return, the label address tells the buyer where to ship the goods
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "return uuid"
+// @Success 200 {object} dto.ReturnResponseDto
+// @Router /returns/{id}/approve [post]
+func (h *returnHandlersHTTP) Approve(w http.ResponseWriter, r *http.Request) {
+	foundReturn, _, err := h.findReturn(w, r, false)
+	if err != nil {
+		return
+	}
+
+	approvedReturn, err := h.returnUC.Approve(r.Context(), foundReturn)
+	if err != nil {
+		h.logger.Errorf("returnUC.Approve: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	res, _ := json.Marshal(dto.ReturnResponseFromModel(approvedReturn))
+	w.WriteHeader(http.StatusOK)
+	w.Write(res)
+	return
+}
+
+// Reject
+// @Tags Returns
+// @Summary Reject return
+// @Description Admin or seller of the brand rejects a return before it is refunded
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "return uuid"
+// @Param payload body dto.ReturnRejectRequestDto true "Payload"
+// @Success 200 {object} dto.ReturnResponseDto
+// @Router /returns/{id}/reject [post]
+func (h *returnHandlersHTTP) Reject(w http.ResponseWriter, r *http.Request) {
+	foundReturn, _, err := h.findReturn(w, r, false)
+	if err != nil {
+		return
+	}
+
+	rejectDto := &dto.ReturnRejectRequestDto{}
+	if err := json.NewDecoder(r.Body).Decode(rejectDto); err != nil {
+		h.logger.Errorf("decoder.Decode: %v", err)
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	if err := h.v.Struct(rejectDto); err != nil {
+		h.logger.Errorf("h.v.Struct: %v", err)
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	rejectedReturn, err := h.returnUC.Reject(r.Context(), foundReturn, rejectDto.Reason)
+	if err != nil {
+		h.logger.Errorf("returnUC.Reject: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	res, _ := json.Marshal(dto.ReturnResponseFromModel(rejectedReturn))
+	w.WriteHeader(http.StatusOK)
+	w.Write(res)
+	return
+}
+
+// Receive
+// @Tags Returns
+// @Summary Receive return
+// @Description Admin or seller of the brand marks the goods of an approved return arrived
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "return uuid"
+// @Success 200 {object} dto.ReturnResponseDto
+// @Router /returns/{id}/receive [post]
+func (h *returnHandlersHTTP) Receive(w http.ResponseWriter, r *http.Request) {
+	foundReturn, _, err := h.findReturn(w, r, false)
+	if err != nil {
+		return
+	}
+
+	receivedReturn, err := h.returnUC.Receive(r.Context(), foundReturn)
+	if err != nil {
+		h.logger.Errorf("returnUC.Receive: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	res, _ := json.Marshal(dto.ReturnResponseFromModel(receivedReturn))
+	w.WriteHeader(http.StatusOK)
+	w.Write(res)
+	return
+}
+
+// Refund
+// @Tags Returns
+// @Summary Refund return
+// @Description Admin or seller of the brand refunds the received goods of a return, restock puts them back to the product stock
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "return uuid"
+// @Param payload body dto.ReturnRefundRequestDto false "Payload"
+// @Success 200 {object} dto.ReturnResponseDto
+// @Router /returns/{id}/refund [post]
+func (h *returnHandlersHTTP) Refund(w http.ResponseWriter, r *http.Request) {
+	foundReturn, userUUID, err := h.findReturn(w, r, false)
+	if err != nil {
+		return
+	}
+
+	refundDto := &dto.ReturnRefundRequestDto{}
+	if err := json.NewDecoder(r.Body).Decode(refundDto); err != nil && !errors.Is(err, io.EOF) {
+		h.logger.Errorf("decoder.Decode: %v", err)
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	refundedReturn, err := h.returnUC.Refund(r.Context(), foundReturn, userUUID, refundDto.Restock)
+	if err != nil {
+		h.logger.Errorf("returnUC.Refund: %v", err)
+		if errors.Is(err, models.ErrRefundExceedsQuantity) || errors.Is(err, payment.ErrRefundExceedsAmount) || errors.Is(err, payment.ErrPaymentNotCaptured) {
+			_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+			return
+		}
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	res, _ := json.Marshal(dto.ReturnResponseFromModel(refundedReturn))
+	w.WriteHeader(http.StatusOK)
+	w.Write(res)
+	return
+}
+
+// findReturn find the return of the path id for the caller, admins reach every return, sellers the returns of their brand
+// and, when allowBuyer, users their own returns. Error response is already written when err is not nil
+func (h *returnHandlersHTTP) findReturn(w http.ResponseWriter, r *http.Request, allowBuyer bool) (*models.ReturnRequest, uuid.UUID, error) {
+	returnUUID, err := uuid.Parse(router.Param(r, constants.ID))
+	if err != nil {
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return nil, uuid.Nil, err
+	}
+
+	userUUID, err := h.getCaller(w, r)
+	if err != nil {
+		return nil, uuid.Nil, err
+	}
+
+	foundReturn, err := h.returnUC.FindById(r.Context(), returnUUID)
+	if err != nil {
+		h.logger.Errorf("returnUC.FindById: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return nil, uuid.Nil, err
+	}
+
+	if !allowBuyer || foundReturn.UserID != userUUID {
+		if err := h.mw.CheckBrand(w, r, foundReturn.BrandID); err != nil {
+			return nil, uuid.Nil, err
+		}
+	}
+
+	return foundReturn, userUUID, nil
+}
+
+// getCaller user uuid of the token, error response is already written when err is not nil
+func (h *returnHandlersHTTP) getCaller(w http.ResponseWriter, r *http.Request) (userUUID uuid.UUID, err error) {
+	jwtClaims, err := h.mw.GetJWTClaims(w, r)
+	if err != nil {
+		return
+	}
+	claims := *jwtClaims
+	userID, _ := claims["user_id"].(string)
+
+	userUUID, err = uuid.Parse(userID)
+	if err != nil {
+		_ = httpErrors.NewUnauthorizedError(w, nil, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+	return
+}
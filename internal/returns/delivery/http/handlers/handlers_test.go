@@ -0,0 +1,215 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/golang-jwt/jwt"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/middlewares"
+	"github.com/dinorain/kalobranded/internal/models"
+	mockOrderUC "github.com/dinorain/kalobranded/internal/order/mock"
+	"github.com/dinorain/kalobranded/internal/returns/delivery/http/dto"
+	"github.com/dinorain/kalobranded/internal/returns/mock"
+	"github.com/dinorain/kalobranded/internal/server/router"
+	"github.com/dinorain/kalobranded/pkg/logger"
+)
+
+func signedToken(t *testing.T, cfg *config.Config, userUUID uuid.UUID, role string, brandUUID *uuid.UUID) string {
+	token := jwt.New(jwt.SigningMethodHS256)
+	claims := token.Claims.(jwt.MapClaims)
+	claims["session_id"] = uuid.New().String()
+	claims["user_id"] = userUUID.String()
+	claims["role"] = role
+	if brandUUID != nil {
+		claims["brand_id"] = brandUUID.String()
+	}
+	claims["exp"] = time.Now().Add(time.Minute * 15).Unix()
+	validToken, err := token.SignedString([]byte(cfg.Server.JwtSecretKey))
+	require.NoError(t, err)
+	return validToken
+}
+
+func TestReturnsHandler_Create(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	returnUC := mock.NewMockReturnUseCase(ctrl)
+	orderUC := mockOrderUC.NewMockOrderUseCase(ctrl)
+
+	cfg := &config.Config{Session: config.Session{Expire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	appLogger := logger.NewAppLogger(cfg)
+	appLogger.InitLogger()
+	mw := middlewares.NewMiddlewareManager(appLogger, cfg)
+
+	v := validator.New()
+
+	rt := router.NewRouter(false)
+	handlers := NewReturnHandlersHTTP(rt, appLogger, cfg, mw, v, returnUC, orderUC)
+
+	buyerUUID := uuid.New()
+	orderUUID := uuid.New()
+	mockOrder := &models.Order{OrderID: orderUUID, UserID: buyerUUID, BrandID: uuid.New(), Quantity: 2, Status: models.OrderStatusAccepted}
+	body := `{"quantity": 1, "reason": "damaged", "note": "cracked", "photo_urls": ["https://example.com/photo.jpg"]}`
+
+	t.Run("Buyer", func(t *testing.T) {
+		req := router.WithParams(httptest.NewRequest(http.MethodPost, "/orders/"+orderUUID.String()+"/returns", strings.NewReader(body)), map[string]string{"id": orderUUID.String()})
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", signedToken(t, cfg, buyerUUID, models.UserRoleUser, nil)))
+		w := httptest.NewRecorder()
+
+		orderUC.EXPECT().FindById(gomock.Any(), orderUUID).Return(mockOrder, nil)
+		returnUC.EXPECT().Create(gomock.Any(), mockOrder, &models.ReturnRequest{
+			Quantity:  1,
+			Reason:    models.RefundReasonDamaged,
+			Note:      "cracked",
+			PhotoURLs: models.ReturnPhotos{"https://example.com/photo.jpg"},
+		}).Return(&models.ReturnRequest{ReturnID: uuid.New(), OrderID: orderUUID, Quantity: 1, Status: models.ReturnStatusRequested}, nil)
+
+		http.HandlerFunc(handlers.Create).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusCreated, w.Code)
+		resDto := &dto.ReturnResponseDto{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), resDto))
+		require.Equal(t, models.ReturnStatusRequested, resDto.Status)
+	})
+
+	t.Run("OtherUser", func(t *testing.T) {
+		req := router.WithParams(httptest.NewRequest(http.MethodPost, "/orders/"+orderUUID.String()+"/returns", strings.NewReader(body)), map[string]string{"id": orderUUID.String()})
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", signedToken(t, cfg, uuid.New(), models.UserRoleUser, nil)))
+		w := httptest.NewRecorder()
+
+		orderUC.EXPECT().FindById(gomock.Any(), orderUUID).Return(mockOrder, nil)
+
+		http.HandlerFunc(handlers.Create).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("WindowClosed", func(t *testing.T) {
+		req := router.WithParams(httptest.NewRequest(http.MethodPost, "/orders/"+orderUUID.String()+"/returns", strings.NewReader(body)), map[string]string{"id": orderUUID.String()})
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", signedToken(t, cfg, buyerUUID, models.UserRoleUser, nil)))
+		w := httptest.NewRecorder()
+
+		orderUC.EXPECT().FindById(gomock.Any(), orderUUID).Return(mockOrder, nil)
+		returnUC.EXPECT().Create(gomock.Any(), mockOrder, gomock.Any()).Return(nil, models.ErrReturnWindowClosed)
+
+		http.HandlerFunc(handlers.Create).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestReturnsHandler_Approve(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	returnUC := mock.NewMockReturnUseCase(ctrl)
+	orderUC := mockOrderUC.NewMockOrderUseCase(ctrl)
+
+	cfg := &config.Config{Session: config.Session{Expire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	appLogger := logger.NewAppLogger(cfg)
+	appLogger.InitLogger()
+	mw := middlewares.NewMiddlewareManager(appLogger, cfg)
+
+	v := validator.New()
+
+	rt := router.NewRouter(false)
+	handlers := NewReturnHandlersHTTP(rt, appLogger, cfg, mw, v, returnUC, orderUC)
+
+	brandUUID := uuid.New()
+	returnUUID := uuid.New()
+	mockReturn := &models.ReturnRequest{ReturnID: returnUUID, BrandID: brandUUID, Status: models.ReturnStatusRequested}
+
+	t.Run("Seller", func(t *testing.T) {
+		req := router.WithParams(httptest.NewRequest(http.MethodPost, "/returns/"+returnUUID.String()+"/approve", nil), map[string]string{"id": returnUUID.String()})
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", signedToken(t, cfg, uuid.New(), models.UserRoleSeller, &brandUUID)))
+		w := httptest.NewRecorder()
+
+		returnUC.EXPECT().FindById(gomock.Any(), returnUUID).Return(mockReturn, nil)
+		returnUC.EXPECT().Approve(gomock.Any(), mockReturn).Return(&models.ReturnRequest{ReturnID: returnUUID, BrandID: brandUUID, Status: models.ReturnStatusApproved, LabelAddress: "PickupAddress"}, nil)
+
+		http.HandlerFunc(handlers.Approve).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		resDto := &dto.ReturnResponseDto{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), resDto))
+		require.Equal(t, "PickupAddress", resDto.LabelAddress)
+	})
+
+	t.Run("OtherBrandSeller", func(t *testing.T) {
+		otherBrandUUID := uuid.New()
+		req := router.WithParams(httptest.NewRequest(http.MethodPost, "/returns/"+returnUUID.String()+"/approve", nil), map[string]string{"id": returnUUID.String()})
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", signedToken(t, cfg, uuid.New(), models.UserRoleSeller, &otherBrandUUID)))
+		w := httptest.NewRecorder()
+
+		returnUC.EXPECT().FindById(gomock.Any(), returnUUID).Return(mockReturn, nil)
+
+		http.HandlerFunc(handlers.Approve).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("InvalidTransition", func(t *testing.T) {
+		req := router.WithParams(httptest.NewRequest(http.MethodPost, "/returns/"+returnUUID.String()+"/approve", nil), map[string]string{"id": returnUUID.String()})
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", signedToken(t, cfg, uuid.New(), models.UserRoleAdmin, nil)))
+		w := httptest.NewRecorder()
+
+		returnUC.EXPECT().FindById(gomock.Any(), returnUUID).Return(mockReturn, nil)
+		returnUC.EXPECT().Approve(gomock.Any(), mockReturn).Return(nil, models.ErrInvalidStatusTransition)
+
+		http.HandlerFunc(handlers.Approve).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusConflict, w.Code)
+	})
+}
+
+func TestReturnsHandler_Reject(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	returnUC := mock.NewMockReturnUseCase(ctrl)
+	orderUC := mockOrderUC.NewMockOrderUseCase(ctrl)
+
+	cfg := &config.Config{Session: config.Session{Expire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	appLogger := logger.NewAppLogger(cfg)
+	appLogger.InitLogger()
+	mw := middlewares.NewMiddlewareManager(appLogger, cfg)
+
+	v := validator.New()
+
+	rt := router.NewRouter(false)
+	handlers := NewReturnHandlersHTTP(rt, appLogger, cfg, mw, v, returnUC, orderUC)
+
+	returnUUID := uuid.New()
+	mockReturn := &models.ReturnRequest{ReturnID: returnUUID, BrandID: uuid.New(), Status: models.ReturnStatusReceived}
+
+	req := router.WithParams(httptest.NewRequest(http.MethodPost, "/returns/"+returnUUID.String()+"/reject", strings.NewReader(`{"reason": "used"}`)), map[string]string{"id": returnUUID.String()})
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", signedToken(t, cfg, uuid.New(), models.UserRoleAdmin, nil)))
+	w := httptest.NewRecorder()
+
+	returnUC.EXPECT().FindById(gomock.Any(), returnUUID).Return(mockReturn, nil)
+	returnUC.EXPECT().Reject(gomock.Any(), mockReturn, "used").Return(&models.ReturnRequest{ReturnID: returnUUID, Status: models.ReturnStatusRejected, RejectionReason: "used"}, nil)
+
+	http.HandlerFunc(handlers.Reject).ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	resDto := &dto.ReturnResponseDto{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), resDto))
+	require.Equal(t, models.ReturnStatusRejected, resDto.Status)
+}
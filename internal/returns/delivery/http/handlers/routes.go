@@ -0,0 +1,14 @@
+package handlers
+
+func (h *returnHandlersHTTP) ReturnMapRoutes() {
+	orderReturns := h.router.Group("/orders/{id}/returns", h.mw.IsLoggedIn)
+	orderReturns.Post("", h.Create, h.mw.Idempotent)
+	orderReturns.Get("", h.FindAllByOrderId)
+
+	returns := h.router.Group("/returns", h.mw.IsLoggedIn)
+	returns.Get("/{id}", h.FindById)
+	returns.Post("/{id}/approve", h.Approve, h.mw.IsAdminOrSeller)
+	returns.Post("/{id}/reject", h.Reject, h.mw.IsAdminOrSeller)
+	returns.Post("/{id}/receive", h.Receive, h.mw.IsAdminOrSeller)
+	returns.Post("/{id}/refund", h.Refund, h.mw.IsAdminOrSeller, h.mw.Idempotent)
+}
@@ -0,0 +1,16 @@
+package returns
+
+import (
+	"net/http"
+)
+
+// Return HTTP Handlers interface
+type ReturnHandlers interface {
+	Create(w http.ResponseWriter, r *http.Request)
+	FindAllByOrderId(w http.ResponseWriter, r *http.Request)
+	FindById(w http.ResponseWriter, r *http.Request)
+	Approve(w http.ResponseWriter, r *http.Request)
+	Reject(w http.ResponseWriter, r *http.Request)
+	Receive(w http.ResponseWriter, r *http.Request)
+	Refund(w http.ResponseWriter, r *http.Request)
+}
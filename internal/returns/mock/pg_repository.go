@@ -0,0 +1,97 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pg_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/dinorain/kalobranded/internal/models"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockReturnPGRepository is a mock of ReturnPGRepository interface.
+type MockReturnPGRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReturnPGRepositoryMockRecorder
+}
+
+// MockReturnPGRepositoryMockRecorder is the mock recorder for MockReturnPGRepository.
+type MockReturnPGRepositoryMockRecorder struct {
+	mock *MockReturnPGRepository
+}
+
+// NewMockReturnPGRepository creates a new mock instance.
+func NewMockReturnPGRepository(ctrl *gomock.Controller) *MockReturnPGRepository {
+	mock := &MockReturnPGRepository{ctrl: ctrl}
+	mock.recorder = &MockReturnPGRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReturnPGRepository) EXPECT() *MockReturnPGRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockReturnPGRepository) Create(ctx context.Context, returnRequest *models.ReturnRequest) (*models.ReturnRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, returnRequest)
+	ret0, _ := ret[0].(*models.ReturnRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockReturnPGRepositoryMockRecorder) Create(ctx, returnRequest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockReturnPGRepository)(nil).Create), ctx, returnRequest)
+}
+
+// FindAllByOrderId mocks base method.
+func (m *MockReturnPGRepository) FindAllByOrderId(ctx context.Context, orderID uuid.UUID) ([]models.ReturnRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByOrderId", ctx, orderID)
+	ret0, _ := ret[0].([]models.ReturnRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByOrderId indicates an expected call of FindAllByOrderId.
+func (mr *MockReturnPGRepositoryMockRecorder) FindAllByOrderId(ctx, orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByOrderId", reflect.TypeOf((*MockReturnPGRepository)(nil).FindAllByOrderId), ctx, orderID)
+}
+
+// FindById mocks base method.
+func (m *MockReturnPGRepository) FindById(ctx context.Context, returnID uuid.UUID) (*models.ReturnRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, returnID)
+	ret0, _ := ret[0].(*models.ReturnRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockReturnPGRepositoryMockRecorder) FindById(ctx, returnID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockReturnPGRepository)(nil).FindById), ctx, returnID)
+}
+
+// UpdateById mocks base method.
+func (m *MockReturnPGRepository) UpdateById(ctx context.Context, returnRequest *models.ReturnRequest) (*models.ReturnRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateById", ctx, returnRequest)
+	ret0, _ := ret[0].(*models.ReturnRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateById indicates an expected call of UpdateById.
+func (mr *MockReturnPGRepositoryMockRecorder) UpdateById(ctx, returnRequest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateById", reflect.TypeOf((*MockReturnPGRepository)(nil).UpdateById), ctx, returnRequest)
+}
@@ -0,0 +1,142 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/dinorain/kalobranded/internal/models"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockReturnUseCase is a mock of ReturnUseCase interface.
+type MockReturnUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockReturnUseCaseMockRecorder
+}
+
+// MockReturnUseCaseMockRecorder is the mock recorder for MockReturnUseCase.
+type MockReturnUseCaseMockRecorder struct {
+	mock *MockReturnUseCase
+}
+
+// NewMockReturnUseCase creates a new mock instance.
+func NewMockReturnUseCase(ctrl *gomock.Controller) *MockReturnUseCase {
+	mock := &MockReturnUseCase{ctrl: ctrl}
+	mock.recorder = &MockReturnUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReturnUseCase) EXPECT() *MockReturnUseCaseMockRecorder {
+	return m.recorder
+}
+
+// Approve mocks base method.
+func (m *MockReturnUseCase) Approve(ctx context.Context, returnRequest *models.ReturnRequest) (*models.ReturnRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Approve", ctx, returnRequest)
+	ret0, _ := ret[0].(*models.ReturnRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Approve indicates an expected call of Approve.
+func (mr *MockReturnUseCaseMockRecorder) Approve(ctx, returnRequest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Approve", reflect.TypeOf((*MockReturnUseCase)(nil).Approve), ctx, returnRequest)
+}
+
+// Create mocks base method.
+func (m *MockReturnUseCase) Create(ctx context.Context, order *models.Order, returnRequest *models.ReturnRequest) (*models.ReturnRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, order, returnRequest)
+	ret0, _ := ret[0].(*models.ReturnRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockReturnUseCaseMockRecorder) Create(ctx, order, returnRequest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockReturnUseCase)(nil).Create), ctx, order, returnRequest)
+}
+
+// FindAllByOrderId mocks base method.
+func (m *MockReturnUseCase) FindAllByOrderId(ctx context.Context, orderID uuid.UUID) ([]models.ReturnRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByOrderId", ctx, orderID)
+	ret0, _ := ret[0].([]models.ReturnRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByOrderId indicates an expected call of FindAllByOrderId.
+func (mr *MockReturnUseCaseMockRecorder) FindAllByOrderId(ctx, orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByOrderId", reflect.TypeOf((*MockReturnUseCase)(nil).FindAllByOrderId), ctx, orderID)
+}
+
+// FindById mocks base method.
+func (m *MockReturnUseCase) FindById(ctx context.Context, returnID uuid.UUID) (*models.ReturnRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, returnID)
+	ret0, _ := ret[0].(*models.ReturnRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockReturnUseCaseMockRecorder) FindById(ctx, returnID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockReturnUseCase)(nil).FindById), ctx, returnID)
+}
+
+// Receive mocks base method.
+func (m *MockReturnUseCase) Receive(ctx context.Context, returnRequest *models.ReturnRequest) (*models.ReturnRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Receive", ctx, returnRequest)
+	ret0, _ := ret[0].(*models.ReturnRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Receive indicates an expected call of Receive.
+func (mr *MockReturnUseCaseMockRecorder) Receive(ctx, returnRequest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Receive", reflect.TypeOf((*MockReturnUseCase)(nil).Receive), ctx, returnRequest)
+}
+
+// Refund mocks base method.
+func (m *MockReturnUseCase) Refund(ctx context.Context, returnRequest *models.ReturnRequest, refundedBy uuid.UUID, restock bool) (*models.ReturnRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refund", ctx, returnRequest, refundedBy, restock)
+	ret0, _ := ret[0].(*models.ReturnRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Refund indicates an expected call of Refund.
+func (mr *MockReturnUseCaseMockRecorder) Refund(ctx, returnRequest, refundedBy, restock interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refund", reflect.TypeOf((*MockReturnUseCase)(nil).Refund), ctx, returnRequest, refundedBy, restock)
+}
+
+// Reject mocks base method.
+func (m *MockReturnUseCase) Reject(ctx context.Context, returnRequest *models.ReturnRequest, reason string) (*models.ReturnRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reject", ctx, returnRequest, reason)
+	ret0, _ := ret[0].(*models.ReturnRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reject indicates an expected call of Reject.
+func (mr *MockReturnUseCaseMockRecorder) Reject(ctx, returnRequest, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reject", reflect.TypeOf((*MockReturnUseCase)(nil).Reject), ctx, returnRequest, reason)
+}
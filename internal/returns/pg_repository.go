@@ -0,0 +1,18 @@
+//go:generate mockgen -source pg_repository.go -destination mock/pg_repository.go -package mock
+package returns
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/internal/models"
+)
+
+// Return pg repository
+type ReturnPGRepository interface {
+	Create(ctx context.Context, returnRequest *models.ReturnRequest) (*models.ReturnRequest, error)
+	FindById(ctx context.Context, returnID uuid.UUID) (*models.ReturnRequest, error)
+	FindAllByOrderId(ctx context.Context, orderID uuid.UUID) ([]models.ReturnRequest, error)
+	UpdateById(ctx context.Context, returnRequest *models.ReturnRequest) (*models.ReturnRequest, error)
+}
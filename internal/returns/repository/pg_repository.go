@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/internal/returns"
+)
+
+// Return repository
+type ReturnRepository struct {
+	db *sqlx.DB
+}
+
+var _ returns.ReturnPGRepository = (*ReturnRepository)(nil)
+
+// Return repository constructor
+func NewReturnPGRepository(db *sqlx.DB) *ReturnRepository {
+	return &ReturnRepository{db: db}
+}
+
+// Create new return request, the order is locked so that open returns and refunds together never claim more than
+// its quantity
+func (r *ReturnRepository) Create(ctx context.Context, returnRequest *models.ReturnRequest) (*models.ReturnRequest, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "ReturnRepository.Create.BeginTxx")
+	}
+	defer tx.Rollback()
+
+	var refundable, open uint64
+	if err := tx.GetContext(ctx, &refundable, lockRefundableQuantityQuery, returnRequest.OrderID); err != nil {
+		return nil, errors.Wrap(err, "ReturnRepository.Create.LockOrder")
+	}
+	if err := tx.GetContext(ctx, &open, openReturnsQuantityQuery, returnRequest.OrderID); err != nil {
+		return nil, errors.Wrap(err, "ReturnRepository.Create.OpenReturns")
+	}
+	returnable := uint64(0)
+	if refundable > open {
+		returnable = refundable - open
+	}
+	if returnRequest.Quantity > returnable {
+		return nil, errors.Wrapf(models.ErrRefundExceedsQuantity, "%d of %d", returnRequest.Quantity, returnable)
+	}
+
+	createdReturn := &models.ReturnRequest{}
+	if err := tx.QueryRowxContext(
+		ctx,
+		createReturnQuery,
+		returnRequest.OrderID,
+		returnRequest.UserID,
+		returnRequest.BrandID,
+		returnRequest.Quantity,
+		returnRequest.Reason,
+		returnRequest.Note,
+		returnRequest.PhotoURLs,
+		returnRequest.Status,
+	).StructScan(createdReturn); err != nil {
+		return nil, errors.Wrap(err, "ReturnRepository.Create.QueryRowxContext")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "ReturnRepository.Create.Commit")
+	}
+
+	return createdReturn, nil
+}
+
+// FindById Find return request by uuid
+func (r *ReturnRepository) FindById(ctx context.Context, returnID uuid.UUID) (*models.ReturnRequest, error) {
+	returnRequest := &models.ReturnRequest{}
+	if err := r.db.GetContext(ctx, returnRequest, findByIdQuery, returnID); err != nil {
+		return nil, errors.Wrap(err, "ReturnRepository.FindById.GetContext")
+	}
+
+	return returnRequest, nil
+}
+
+// FindAllByOrderId Find return requests of order uuid, oldest first
+func (r *ReturnRepository) FindAllByOrderId(ctx context.Context, orderID uuid.UUID) ([]models.ReturnRequest, error) {
+	var returnRequests []models.ReturnRequest
+	if err := r.db.SelectContext(ctx, &returnRequests, findAllByOrderIdQuery, orderID); err != nil {
+		return nil, errors.Wrap(err, "ReturnRepository.FindAllByOrderId.SelectContext")
+	}
+
+	return returnRequests, nil
+}
+
+// UpdateById update return request status and the fields set along with it, only at the version it was read with
+func (r *ReturnRepository) UpdateById(ctx context.Context, returnRequest *models.ReturnRequest) (*models.ReturnRequest, error) {
+	updatedReturn := &models.ReturnRequest{}
+	if err := r.db.QueryRowxContext(
+		ctx,
+		updateByIdQuery,
+		returnRequest.ReturnID,
+		returnRequest.Status,
+		returnRequest.LabelAddress,
+		returnRequest.RejectionReason,
+		returnRequest.RefundID,
+		returnRequest.Version,
+	).StructScan(updatedReturn); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, models.ErrVersionConflict
+		}
+		return nil, errors.Wrap(err, "ReturnRepository.UpdateById.QueryRowxContext")
+	}
+
+	return updatedReturn, nil
+}
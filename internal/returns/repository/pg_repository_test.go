@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/kalobranded/internal/models"
+)
+
+var returnColumns = []string{"return_id", "order_id", "user_id", "brand_id", "quantity", "reason", "note", "photo_urls", "status", "label_address", "rejection_reason", "refund_id", "version", "created_at", "updated_at"}
+
+func TestReturnRepository_Create(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	returnPGRepository := NewReturnPGRepository(sqlxDB)
+
+	returnUUID := uuid.New()
+	mockReturn := &models.ReturnRequest{
+		OrderID:   uuid.New(),
+		UserID:    uuid.New(),
+		BrandID:   uuid.New(),
+		Quantity:  1,
+		Reason:    models.RefundReasonDamaged,
+		Note:      "Note",
+		PhotoURLs: models.ReturnPhotos{"https://example.com/photo.jpg"},
+		Status:    models.ReturnStatusRequested,
+	}
+
+	photosJson, _ := json.Marshal(mockReturn.PhotoURLs)
+
+	rows := sqlmock.NewRows(returnColumns).AddRow(
+		returnUUID,
+		mockReturn.OrderID,
+		mockReturn.UserID,
+		mockReturn.BrandID,
+		mockReturn.Quantity,
+		mockReturn.Reason,
+		mockReturn.Note,
+		photosJson,
+		mockReturn.Status,
+		"",
+		"",
+		nil,
+		1,
+		time.Now(),
+		time.Now(),
+	)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(lockRefundableQuantityQuery).WithArgs(mockReturn.OrderID).WillReturnRows(sqlmock.NewRows([]string{"refundable"}).AddRow(3))
+	mock.ExpectQuery(openReturnsQuantityQuery).WithArgs(mockReturn.OrderID).WillReturnRows(sqlmock.NewRows([]string{"open"}).AddRow(2))
+	mock.ExpectQuery(createReturnQuery).WithArgs(
+		mockReturn.OrderID,
+		mockReturn.UserID,
+		mockReturn.BrandID,
+		mockReturn.Quantity,
+		mockReturn.Reason,
+		mockReturn.Note,
+		photosJson,
+		mockReturn.Status,
+	).WillReturnRows(rows)
+	mock.ExpectCommit()
+
+	createdReturn, err := returnPGRepository.Create(context.Background(), mockReturn)
+	require.NoError(t, err)
+	require.Equal(t, returnUUID, createdReturn.ReturnID)
+	require.Equal(t, mockReturn.PhotoURLs, createdReturn.PhotoURLs)
+	require.Nil(t, createdReturn.RefundID)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	t.Run("ExceedsQuantity", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockRefundableQuantityQuery).WithArgs(mockReturn.OrderID).WillReturnRows(sqlmock.NewRows([]string{"refundable"}).AddRow(3))
+		mock.ExpectQuery(openReturnsQuantityQuery).WithArgs(mockReturn.OrderID).WillReturnRows(sqlmock.NewRows([]string{"open"}).AddRow(3))
+		mock.ExpectRollback()
+
+		_, err := returnPGRepository.Create(context.Background(), mockReturn)
+		require.ErrorIs(t, err, models.ErrRefundExceedsQuantity)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestReturnRepository_UpdateById(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	returnPGRepository := NewReturnPGRepository(sqlxDB)
+
+	mockReturn := &models.ReturnRequest{
+		ReturnID:     uuid.New(),
+		OrderID:      uuid.New(),
+		UserID:       uuid.New(),
+		BrandID:      uuid.New(),
+		Quantity:     1,
+		Reason:       models.RefundReasonDamaged,
+		Status:       models.ReturnStatusApproved,
+		LabelAddress: "PickupAddress",
+		Version:      1,
+	}
+
+	rows := sqlmock.NewRows(returnColumns).AddRow(
+		mockReturn.ReturnID,
+		mockReturn.OrderID,
+		mockReturn.UserID,
+		mockReturn.BrandID,
+		mockReturn.Quantity,
+		mockReturn.Reason,
+		"",
+		[]byte("[]"),
+		mockReturn.Status,
+		mockReturn.LabelAddress,
+		"",
+		nil,
+		2,
+		time.Now(),
+		time.Now(),
+	)
+
+	mock.ExpectQuery(updateByIdQuery).WithArgs(
+		mockReturn.ReturnID,
+		mockReturn.Status,
+		mockReturn.LabelAddress,
+		mockReturn.RejectionReason,
+		mockReturn.RefundID,
+		mockReturn.Version,
+	).WillReturnRows(rows)
+
+	updatedReturn, err := returnPGRepository.UpdateById(context.Background(), mockReturn)
+	require.NoError(t, err)
+	require.Equal(t, 2, updatedReturn.Version)
+	require.Equal(t, models.ReturnStatusApproved, updatedReturn.Status)
+
+	t.Run("VersionConflict", func(t *testing.T) {
+		mock.ExpectQuery(updateByIdQuery).WithArgs(
+			mockReturn.ReturnID,
+			mockReturn.Status,
+			mockReturn.LabelAddress,
+			mockReturn.RejectionReason,
+			mockReturn.RefundID,
+			mockReturn.Version,
+		).WillReturnRows(sqlmock.NewRows(returnColumns))
+
+		_, err := returnPGRepository.UpdateById(context.Background(), mockReturn)
+		require.ErrorIs(t, err, models.ErrVersionConflict)
+	})
+}
@@ -0,0 +1,18 @@
+package repository
+
+const (
+	lockRefundableQuantityQuery = `SELECT quantity - refunded_quantity FROM orders WHERE order_id = $1 AND deleted_at IS NULL FOR UPDATE`
+
+	openReturnsQuantityQuery = `SELECT COALESCE(SUM(quantity), 0) FROM returns WHERE order_id = $1 AND status IN ('requested', 'approved', 'received')`
+
+	createReturnQuery = `INSERT INTO returns (order_id, user_id, brand_id, quantity, reason, note, photo_urls, status) 
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING return_id, order_id, user_id, brand_id, quantity, reason, note, photo_urls, status, label_address, rejection_reason, refund_id, version, created_at, updated_at`
+
+	findByIdQuery = `SELECT return_id, order_id, user_id, brand_id, quantity, reason, note, photo_urls, status, label_address, rejection_reason, refund_id, version, created_at, updated_at FROM returns WHERE return_id = $1`
+
+	findAllByOrderIdQuery = `SELECT return_id, order_id, user_id, brand_id, quantity, reason, note, photo_urls, status, label_address, rejection_reason, refund_id, version, created_at, updated_at FROM returns WHERE order_id = $1 ORDER BY created_at`
+
+	updateByIdQuery = `UPDATE returns SET status = $2, label_address = $3, rejection_reason = $4, refund_id = $5, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE return_id = $1 AND version = $6
+		RETURNING return_id, order_id, user_id, brand_id, quantity, reason, note, photo_urls, status, label_address, rejection_reason, refund_id, version, created_at, updated_at`
+)
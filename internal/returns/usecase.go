@@ -0,0 +1,21 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase.go -package mock
+package returns
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/internal/models"
+)
+
+// Return UseCase interface
+type ReturnUseCase interface {
+	Create(ctx context.Context, order *models.Order, returnRequest *models.ReturnRequest) (*models.ReturnRequest, error)
+	FindById(ctx context.Context, returnID uuid.UUID) (*models.ReturnRequest, error)
+	FindAllByOrderId(ctx context.Context, orderID uuid.UUID) ([]models.ReturnRequest, error)
+	Approve(ctx context.Context, returnRequest *models.ReturnRequest) (*models.ReturnRequest, error)
+	Reject(ctx context.Context, returnRequest *models.ReturnRequest, reason string) (*models.ReturnRequest, error)
+	Receive(ctx context.Context, returnRequest *models.ReturnRequest) (*models.ReturnRequest, error)
+	Refund(ctx context.Context, returnRequest *models.ReturnRequest, refundedBy uuid.UUID, restock bool) (*models.ReturnRequest, error)
+}
@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/brand"
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/internal/order"
+	"github.com/dinorain/kalobranded/internal/refund"
+	"github.com/dinorain/kalobranded/internal/returns"
+	"github.com/dinorain/kalobranded/pkg/logger"
+)
+
+// Return UseCase
+type returnUseCase struct {
+	cfg          *config.Config
+	logger       logger.Logger
+	returnPgRepo returns.ReturnPGRepository
+	orderUC      order.OrderUseCase
+	brandUC      brand.BrandUseCase
+	refundUC     refund.RefundUseCase
+}
+
+var _ returns.ReturnUseCase = (*returnUseCase)(nil)
+
+// New Return UseCase
+func NewReturnUseCase(
+	cfg *config.Config,
+	logger logger.Logger,
+	returnRepo returns.ReturnPGRepository,
+	orderUC order.OrderUseCase,
+	brandUC brand.BrandUseCase,
+	refundUC refund.RefundUseCase,
+) *returnUseCase {
+	return &returnUseCase{cfg: cfg, logger: logger, returnPgRepo: returnRepo, orderUC: orderUC, brandUC: brandUC, refundUC: refundUC}
+}
+
+// Create request returning returnRequest.Quantity of a shipped or delivered order within the return window of its
+// brand, counted from delivery. Open returns of the order count against its refundable quantity
+func (u *returnUseCase) Create(ctx context.Context, order *models.Order, returnRequest *models.ReturnRequest) (*models.ReturnRequest, error) {
+	switch order.Status {
+	case models.OrderStatusShipped, models.OrderStatusDelivered:
+	default:
+		return nil, errors.Wrapf(models.ErrReturnNotReturnable, "order is %s", order.Status)
+	}
+
+	foundBrand, err := u.brandUC.CachedFindById(ctx, order.BrandID)
+	if err != nil {
+		return nil, errors.Wrap(err, "brandUC.CachedFindById")
+	}
+	// the window of an order still on its way has not started yet
+	if order.DeliveredAt != nil && time.Now().After(order.DeliveredAt.AddDate(0, 0, foundBrand.ReturnWindowDays)) {
+		return nil, errors.Wrapf(models.ErrReturnWindowClosed, "%d days", foundBrand.ReturnWindowDays)
+	}
+
+	if returnRequest.Quantity == 0 {
+		return nil, errors.Wrapf(models.ErrRefundExceedsQuantity, "%d of %d", returnRequest.Quantity, order.RefundableQuantity())
+	}
+
+	returnRequest.OrderID = order.OrderID
+	returnRequest.UserID = order.UserID
+	returnRequest.BrandID = order.BrandID
+	returnRequest.Status = models.ReturnStatusRequested
+
+	createdReturn, err := u.returnPgRepo.Create(ctx, returnRequest)
+	if err != nil {
+		return nil, errors.Wrap(err, "returnPgRepo.Create")
+	}
+
+	return createdReturn, nil
+}
+
+// FindById find return request by uuid
+func (u *returnUseCase) FindById(ctx context.Context, returnID uuid.UUID) (*models.ReturnRequest, error) {
+	foundReturn, err := u.returnPgRepo.FindById(ctx, returnID)
+	if err != nil {
+		return nil, errors.Wrap(err, "returnPgRepo.FindById")
+	}
+
+	return foundReturn, nil
+}
+
+// FindAllByOrderId find return requests of order
+func (u *returnUseCase) FindAllByOrderId(ctx context.Context, orderID uuid.UUID) ([]models.ReturnRequest, error) {
+	returnRequests, err := u.returnPgRepo.FindAllByOrderId(ctx, orderID)
+	if err != nil {
+		return nil, errors.Wrap(err, "returnPgRepo.FindAllByOrderId")
+	}
+
+	return returnRequests, nil
+}
+
+// Approve approve return request, goods are to be shipped back to the brand pickup address
+func (u *returnUseCase) Approve(ctx context.Context, returnRequest *models.ReturnRequest) (*models.ReturnRequest, error) {
+	foundBrand, err := u.brandUC.CachedFindById(ctx, returnRequest.BrandID)
+	if err != nil {
+		return nil, errors.Wrap(err, "brandUC.CachedFindById")
+	}
+
+	approvedReturn := *returnRequest
+	approvedReturn.LabelAddress = fmt.Sprintf("%s\nRMA %s", foundBrand.PickupAddress, returnRequest.ReturnID)
+	return u.transition(ctx, &approvedReturn, models.ReturnStatusApproved)
+}
+
+// Reject reject return request at any step before it is refunded
+func (u *returnUseCase) Reject(ctx context.Context, returnRequest *models.ReturnRequest, reason string) (*models.ReturnRequest, error) {
+	rejectedReturn := *returnRequest
+	rejectedReturn.RejectionReason = reason
+	return u.transition(ctx, &rejectedReturn, models.ReturnStatusRejected)
+}
+
+// Receive mark returned goods arrived at the brand
+func (u *returnUseCase) Receive(ctx context.Context, returnRequest *models.ReturnRequest) (*models.ReturnRequest, error) {
+	receivedReturn := *returnRequest
+	return u.transition(ctx, &receivedReturn, models.ReturnStatusReceived)
+}
+
+// Refund refund the received goods of the return request on its order, the return is received again when the
+// provider turns the refund down
+func (u *returnUseCase) Refund(ctx context.Context, returnRequest *models.ReturnRequest, refundedBy uuid.UUID, restock bool) (*models.ReturnRequest, error) {
+	if !returnRequest.CanTransitionTo(models.ReturnStatusRefunded) {
+		return nil, errors.Wrapf(models.ErrInvalidStatusTransition, "return is %s", returnRequest.Status)
+	}
+
+	foundOrder, err := u.orderUC.FindById(ctx, returnRequest.OrderID)
+	if err != nil {
+		return nil, errors.Wrap(err, "orderUC.FindById")
+	}
+
+	// the refund marks the return refunded in its own transaction, so a return is never paid out twice
+	if _, err := u.refundUC.Create(ctx, foundOrder, &models.Refund{
+		Quantity:   returnRequest.Quantity,
+		Reason:     returnRequest.Reason,
+		Note:       fmt.Sprintf("RMA %s", returnRequest.ReturnID),
+		Restock:    restock,
+		RefundedBy: refundedBy,
+		ReturnID:   &returnRequest.ReturnID,
+	}); err != nil {
+		return nil, errors.Wrap(err, "refundUC.Create")
+	}
+
+	refundedReturn, err := u.returnPgRepo.FindById(ctx, returnRequest.ReturnID)
+	if err != nil {
+		return nil, errors.Wrap(err, "returnPgRepo.FindById")
+	}
+
+	return refundedReturn, nil
+}
+
+func (u *returnUseCase) transition(ctx context.Context, returnRequest *models.ReturnRequest, status string) (*models.ReturnRequest, error) {
+	if !returnRequest.CanTransitionTo(status) {
+		return nil, errors.Wrapf(models.ErrInvalidStatusTransition, "return is %s", returnRequest.Status)
+	}
+
+	returnRequest.Status = status
+	updatedReturn, err := u.returnPgRepo.UpdateById(ctx, returnRequest)
+	if err != nil {
+		return nil, errors.Wrap(err, "returnPgRepo.UpdateById")
+	}
+
+	return updatedReturn, nil
+}
@@ -0,0 +1,178 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/kalobranded/config"
+	brandMock "github.com/dinorain/kalobranded/internal/brand/mock"
+	"github.com/dinorain/kalobranded/internal/models"
+	orderMock "github.com/dinorain/kalobranded/internal/order/mock"
+	refundMock "github.com/dinorain/kalobranded/internal/refund/mock"
+	"github.com/dinorain/kalobranded/internal/returns/mock"
+	"github.com/dinorain/kalobranded/pkg/logger"
+)
+
+func TestReturnUseCase_Create(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	returnPGRepository := mock.NewMockReturnPGRepository(ctrl)
+	orderUC := orderMock.NewMockOrderUseCase(ctrl)
+	brandUC := brandMock.NewMockBrandUseCase(ctrl)
+	refundUC := refundMock.NewMockRefundUseCase(ctrl)
+	apiLogger := logger.NewAppLogger(nil)
+
+	cfg := &config.Config{}
+	returnUC := NewReturnUseCase(cfg, apiLogger, returnPGRepository, orderUC, brandUC, refundUC)
+
+	ctx := context.Background()
+	brandUUID := uuid.New()
+	brandUC.EXPECT().CachedFindById(gomock.Any(), brandUUID).AnyTimes().Return(&models.Brand{BrandID: brandUUID, ReturnWindowDays: 7}, nil)
+
+	deliveredAt := time.Now().AddDate(0, 0, -2)
+	mockOrder := &models.Order{
+		OrderID:     uuid.New(),
+		UserID:      uuid.New(),
+		BrandID:     brandUUID,
+		Quantity:    3,
+		Status:      models.OrderStatusDelivered,
+		DeliveredAt: &deliveredAt,
+		CreatedAt:   time.Now().AddDate(0, 0, -30),
+	}
+
+	t.Run("Requested", func(t *testing.T) {
+		returnPGRepository.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, r *models.ReturnRequest) (*models.ReturnRequest, error) {
+			require.Equal(t, mockOrder.UserID, r.UserID)
+			require.Equal(t, brandUUID, r.BrandID)
+			require.Equal(t, models.ReturnStatusRequested, r.Status)
+			return r, nil
+		})
+
+		createdReturn, err := returnUC.Create(ctx, mockOrder, &models.ReturnRequest{Quantity: 2, Reason: models.RefundReasonDamaged})
+		require.NoError(t, err)
+		require.Equal(t, mockOrder.OrderID, createdReturn.OrderID)
+	})
+
+	t.Run("Shipped", func(t *testing.T) {
+		// the window starts on delivery
+		shippedOrder := *mockOrder
+		shippedOrder.Status, shippedOrder.DeliveredAt = models.OrderStatusShipped, nil
+		returnPGRepository.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, r *models.ReturnRequest) (*models.ReturnRequest, error) {
+			return r, nil
+		})
+
+		_, err := returnUC.Create(ctx, &shippedOrder, &models.ReturnRequest{Quantity: 1, Reason: models.RefundReasonDamaged})
+		require.NoError(t, err)
+	})
+
+	t.Run("OpenReturnsExceedQuantity", func(t *testing.T) {
+		returnPGRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, models.ErrRefundExceedsQuantity)
+
+		_, err := returnUC.Create(ctx, mockOrder, &models.ReturnRequest{Quantity: 2, Reason: models.RefundReasonDamaged})
+		require.ErrorIs(t, err, models.ErrRefundExceedsQuantity)
+	})
+
+	t.Run("WindowClosed", func(t *testing.T) {
+		oldOrder := *mockOrder
+		deliveredAt := time.Now().AddDate(0, 0, -8)
+		oldOrder.DeliveredAt = &deliveredAt
+
+		_, err := returnUC.Create(ctx, &oldOrder, &models.ReturnRequest{Quantity: 1, Reason: models.RefundReasonDamaged})
+		require.ErrorIs(t, err, models.ErrReturnWindowClosed)
+	})
+
+	t.Run("NotShipped", func(t *testing.T) {
+		for _, status := range []string{models.OrderStatusPaid, models.OrderStatusAccepted} {
+			notShippedOrder := *mockOrder
+			notShippedOrder.Status, notShippedOrder.DeliveredAt = status, nil
+
+			_, err := returnUC.Create(ctx, &notShippedOrder, &models.ReturnRequest{Quantity: 1, Reason: models.RefundReasonDamaged})
+			require.ErrorIs(t, err, models.ErrReturnNotReturnable)
+		}
+	})
+}
+
+func TestReturnUseCase_Workflow(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	returnPGRepository := mock.NewMockReturnPGRepository(ctrl)
+	orderUC := orderMock.NewMockOrderUseCase(ctrl)
+	brandUC := brandMock.NewMockBrandUseCase(ctrl)
+	refundUC := refundMock.NewMockRefundUseCase(ctrl)
+	apiLogger := logger.NewAppLogger(nil)
+
+	cfg := &config.Config{}
+	returnUC := NewReturnUseCase(cfg, apiLogger, returnPGRepository, orderUC, brandUC, refundUC)
+
+	ctx := context.Background()
+	adminUUID := uuid.New()
+	brandUUID := uuid.New()
+	mockReturn := &models.ReturnRequest{
+		ReturnID: uuid.New(),
+		OrderID:  uuid.New(),
+		BrandID:  brandUUID,
+		Quantity: 1,
+		Reason:   models.RefundReasonWrongItem,
+		Status:   models.ReturnStatusRequested,
+	}
+
+	returnPGRepository.EXPECT().UpdateById(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(_ context.Context, r *models.ReturnRequest) (*models.ReturnRequest, error) {
+		return r, nil
+	})
+
+	t.Run("ReceiveBeforeApprove", func(t *testing.T) {
+		_, err := returnUC.Receive(ctx, mockReturn)
+		require.ErrorIs(t, err, models.ErrInvalidStatusTransition)
+	})
+
+	brandUC.EXPECT().CachedFindById(gomock.Any(), brandUUID).Return(&models.Brand{BrandID: brandUUID, PickupAddress: "PickupAddress"}, nil)
+	approvedReturn, err := returnUC.Approve(ctx, mockReturn)
+	require.NoError(t, err)
+	require.Equal(t, models.ReturnStatusApproved, approvedReturn.Status)
+	require.True(t, strings.HasPrefix(approvedReturn.LabelAddress, "PickupAddress"))
+	require.Equal(t, models.ReturnStatusRequested, mockReturn.Status)
+
+	receivedReturn, err := returnUC.Receive(ctx, approvedReturn)
+	require.NoError(t, err)
+	require.Equal(t, models.ReturnStatusReceived, receivedReturn.Status)
+
+	mockOrder := &models.Order{OrderID: mockReturn.OrderID, Quantity: 1, Status: models.OrderStatusAccepted}
+	refundUUID := uuid.New()
+	orderUC.EXPECT().FindById(gomock.Any(), mockReturn.OrderID).Return(mockOrder, nil)
+	refundUC.EXPECT().Create(gomock.Any(), mockOrder, gomock.Any()).DoAndReturn(func(_ context.Context, _ *models.Order, refund *models.Refund) (*models.Refund, error) {
+		require.Equal(t, uint64(1), refund.Quantity)
+		require.Equal(t, models.RefundReasonWrongItem, refund.Reason)
+		require.Equal(t, adminUUID, refund.RefundedBy)
+		require.True(t, refund.Restock)
+		require.Equal(t, mockReturn.ReturnID, *refund.ReturnID)
+		refund.RefundID = refundUUID
+		return refund, nil
+	})
+	returnPGRepository.EXPECT().FindById(gomock.Any(), mockReturn.ReturnID).DoAndReturn(func(_ context.Context, _ uuid.UUID) (*models.ReturnRequest, error) {
+		refundedReturn := *receivedReturn
+		refundedReturn.Status, refundedReturn.RefundID = models.ReturnStatusRefunded, &refundUUID
+		return &refundedReturn, nil
+	})
+
+	refundedReturn, err := returnUC.Refund(ctx, receivedReturn, adminUUID, true)
+	require.NoError(t, err)
+	require.Equal(t, models.ReturnStatusRefunded, refundedReturn.Status)
+	require.Equal(t, refundUUID, *refundedReturn.RefundID)
+
+	t.Run("RejectRefunded", func(t *testing.T) {
+		_, err := returnUC.Reject(ctx, refundedReturn, "Reason")
+		require.ErrorIs(t, err, models.ErrInvalidStatusTransition)
+	})
+}
@@ -13,6 +13,8 @@ const (
 	maxHeaderBytes = 1 << 20
 	readTimeout    = 15 * time.Second
 	writeTimeout   = 15 * time.Second
+
+	shutdownTimeout = 5 * time.Second
 )
 
 func (s *Server) runHttpServer() error {
@@ -20,7 +22,7 @@ func (s *Server) runHttpServer() error {
 
 	s.httpS = &http.Server{
 		Addr:           s.cfg.Http.Port,
-		Handler:        s.mw.RequestLoggerMiddleware(s.mux),
+		Handler:        s.mw.RequestLoggerMiddleware(s.router),
 		ReadTimeout:    readTimeout,
 		WriteTimeout:   writeTimeout,
 		MaxHeaderBytes: maxHeaderBytes,
@@ -36,5 +38,5 @@ func (s *Server) mapRoutes() {
 	docs.SwaggerInfo.Version = "1.0"
 	docs.SwaggerInfo.BasePath = "/"
 
-	s.mux.HandleFunc("/swagger/", httpSwagger.WrapHandler)
+	s.router.Get("/swagger/*", httpSwagger.WrapHandler)
 }
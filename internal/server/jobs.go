@@ -0,0 +1,64 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/dinorain/kalobranded/internal/export"
+	"github.com/dinorain/kalobranded/internal/notification"
+	"github.com/dinorain/kalobranded/internal/refund"
+	"github.com/dinorain/kalobranded/internal/report"
+	"github.com/dinorain/kalobranded/pkg/jobs"
+)
+
+const (
+	jobsDefaultKeepFinished = 7 * 24 * time.Hour
+	reportsDefaultSchedule  = "*/15 * * * *"
+
+	jobKindPurgeFinished = "jobs.purge_finished"
+)
+
+// registerJobs register the handlers of the background jobs and the scheduled ones
+func (s *Server) registerJobs(queue *jobs.Queue, notificationUC notification.NotificationUseCase, refundUC refund.RefundUseCase, reportUC report.ReportUseCase, exportUC export.ExportUseCase) error {
+	queue.Handle(notification.JobKindSend, jobs.Typed(notificationUC.Send))
+	queue.Handle(refund.JobKindComplete, jobs.Typed(refundUC.Complete))
+	queue.Handle(export.JobKindRun, jobs.Typed(exportUC.Run))
+	queue.Handle(report.JobKindRefresh, func(ctx context.Context, job *jobs.Job) error {
+		return reportUC.Refresh(ctx)
+	})
+	queue.Handle(jobKindPurgeFinished, func(ctx context.Context, job *jobs.Job) error {
+		keep := s.cfg.Jobs.KeepFinished
+		if keep <= 0 {
+			keep = jobsDefaultKeepFinished
+		}
+		finishedBefore := time.Now().Add(-keep)
+
+		purged, err := queue.PurgeFinished(ctx, finishedBefore)
+		if err != nil {
+			return err
+		}
+		if purged > 0 {
+			s.logger.Infof("jobs purged %d finished before %s", purged, finishedBefore.Format(time.RFC3339))
+		}
+		return nil
+	})
+
+	if err := queue.Schedule("purge-finished-jobs", "30 3 * * *", jobKindPurgeFinished, struct{}{}); err != nil {
+		return err
+	}
+
+	reportsSchedule := s.cfg.Report.RefreshSchedule
+	if reportsSchedule == "" {
+		reportsSchedule = reportsDefaultSchedule
+	}
+	return queue.Schedule("refresh-reports", reportsSchedule, report.JobKindRefresh, struct{}{})
+}
+
+// runJobs run the job queue until ctx is done and running jobs are drained, done is closed once they are
+func (s *Server) runJobs(ctx context.Context, queue *jobs.Queue, done chan<- struct{}) {
+	defer close(done)
+
+	if err := queue.Run(ctx); err != nil {
+		s.logger.Errorf("queue.Run: %v", err)
+	}
+}
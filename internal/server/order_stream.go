@@ -0,0 +1,26 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/dinorain/kalobranded/internal/orderstream"
+)
+
+const orderStreamRetryDelay = time.Second
+
+// runOrderStream fan published order updates out to the streams of this instance until ctx is done, the
+// subscription is renewed whenever it is lost
+func (s *Server) runOrderStream(ctx context.Context, orderStreamUC orderstream.OrderStreamUseCase) {
+	for ctx.Err() == nil {
+		if err := orderStreamUC.Run(ctx); err != nil {
+			s.logger.Errorf("orderStreamUC.Run: %v", err)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(orderStreamRetryDelay):
+			}
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/internal/outbox"
+)
+
+const (
+	outboxDefaultInterval = time.Second
+	outboxRetryDelay      = time.Second
+)
+
+type outboxConsumer struct {
+	group   string
+	handler outbox.Handler
+}
+
+// runOutboxRelay publish pending outbox events on every tick until ctx is done, batches are relayed back to back
+// until none is left so a backlog drains without waiting for the ticker
+func (s *Server) runOutboxRelay(ctx context.Context, outboxUC outbox.OutboxUseCase) {
+	interval := s.cfg.Outbox.Interval
+	if interval <= 0 {
+		interval = outboxDefaultInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			published, err := outboxUC.Relay(ctx)
+			if err != nil {
+				s.logger.Errorf("outboxUC.Relay: %v", err)
+				break
+			}
+			if published == 0 {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runOutboxConsumers subscribe each consumer group to the event stream and hand it events until ctx is done
+func (s *Server) runOutboxConsumers(ctx context.Context, outboxUC outbox.OutboxUseCase, consumers []outboxConsumer) {
+	hostname, _ := os.Hostname()
+	name := fmt.Sprintf("%s-%d", hostname, os.Getpid())
+
+	for _, c := range consumers {
+		if err := outboxUC.Subscribe(ctx, c.group); err != nil {
+			s.logger.Errorf("outboxUC.Subscribe %s: %v", c.group, err)
+			continue
+		}
+		go s.consumeOutbox(ctx, outboxUC, c, name)
+	}
+}
+
+func (s *Server) consumeOutbox(ctx context.Context, outboxUC outbox.OutboxUseCase, c outboxConsumer, name string) {
+	for ctx.Err() == nil {
+		if _, err := outboxUC.Consume(ctx, c.group, name, c.handler); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			s.logger.Errorf("outboxUC.Consume %s: %v", c.group, err)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(outboxRetryDelay):
+			}
+		}
+	}
+}
+
+// logOutboxEvent audit consumer writing every domain event to the application log
+func (s *Server) logOutboxEvent(ctx context.Context, event *models.OutboxEvent) error {
+	s.logger.Infof("event %s %s %s/%s: %s", event.EventID, event.EventType, event.AggregateType, event.AggregateID, event.Payload)
+	return nil
+}
@@ -0,0 +1,61 @@
+package server
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	retentionDefaultDeletedDays = 30
+	retentionDefaultInterval    = 24 * time.Hour
+)
+
+type purger interface {
+	PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error)
+}
+
+type retentionTarget struct {
+	name   string
+	purger purger
+}
+
+// runRetentionPurge hard delete rows soft deleted longer than the retention window on every tick until ctx is done,
+// targets are purged in order so rows referencing others go first
+func (s *Server) runRetentionPurge(ctx context.Context, targets []retentionTarget) {
+	interval := s.cfg.Retention.Interval
+	if interval <= 0 {
+		interval = retentionDefaultInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.purgeDeleted(ctx, targets)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Server) purgeDeleted(ctx context.Context, targets []retentionTarget) {
+	deletedDays := s.cfg.Retention.DeletedDays
+	if deletedDays <= 0 {
+		deletedDays = retentionDefaultDeletedDays
+	}
+	deletedBefore := time.Now().AddDate(0, 0, -deletedDays)
+
+	for _, t := range targets {
+		purged, err := t.purger.PurgeDeleted(ctx, deletedBefore)
+		if err != nil {
+			s.logger.Errorf("%s.PurgeDeleted: %v", t.name, err)
+			continue
+		}
+		if purged > 0 {
+			s.logger.Infof("retention purged %d %s deleted before %s", purged, t.name, deletedBefore.Format(time.RFC3339))
+		}
+	}
+}
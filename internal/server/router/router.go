@@ -0,0 +1,201 @@
+package router
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+
+	httpErrors "github.com/dinorain/kalobranded/pkg/http_errors"
+)
+
+type paramsCtxKey struct{}
+
+// Middleware wraps a handler, e.g. middlewares.MiddlewareManager.IsAdmin
+type Middleware func(http.Handler) http.Handler
+
+type route struct {
+	method   string
+	segments []string
+	handler  http.Handler
+	static   int
+}
+
+type table struct {
+	routes []*route
+}
+
+// Router method and path parameter aware multiplexer, groups share the routing table of their parent
+type Router struct {
+	table       *table
+	prefix      string
+	middlewares []Middleware
+	debug       bool
+}
+
+var _ http.Handler = (*Router)(nil)
+
+// NewRouter Router constructor, debug controls error causes in 404/405 responses
+func NewRouter(debug bool) *Router {
+	return &Router{table: &table{}, debug: debug}
+}
+
+// Use append middlewares applied to routes registered afterwards on this router and its groups
+func (rt *Router) Use(middlewares ...Middleware) {
+	rt.middlewares = append(rt.middlewares, middlewares...)
+}
+
+// Group sub router under prefix with additional middlewares
+func (rt *Router) Group(prefix string, middlewares ...Middleware) *Router {
+	return &Router{
+		table:       rt.table,
+		prefix:      joinPath(rt.prefix, prefix),
+		middlewares: append(append([]Middleware{}, rt.middlewares...), middlewares...),
+		debug:       rt.debug,
+	}
+}
+
+// Handle register handler for method and pattern, pattern segments like {id} are path parameters
+// and a trailing * matches the rest of the path
+func (rt *Router) Handle(method string, pattern string, handler http.Handler, middlewares ...Middleware) {
+	all := append(append([]Middleware{}, rt.middlewares...), middlewares...)
+	for i := len(all) - 1; i >= 0; i-- {
+		handler = all[i](handler)
+	}
+
+	r := &route{method: method, segments: splitPath(joinPath(rt.prefix, pattern)), handler: handler}
+	for _, s := range r.segments {
+		if !isParam(s) && s != "*" {
+			r.static++
+		}
+	}
+	rt.table.routes = append(rt.table.routes, r)
+}
+
+// Get register GET handler
+func (rt *Router) Get(pattern string, handler http.HandlerFunc, middlewares ...Middleware) {
+	rt.Handle(http.MethodGet, pattern, handler, middlewares...)
+}
+
+// Post register POST handler
+func (rt *Router) Post(pattern string, handler http.HandlerFunc, middlewares ...Middleware) {
+	rt.Handle(http.MethodPost, pattern, handler, middlewares...)
+}
+
+// Put register PUT handler
+func (rt *Router) Put(pattern string, handler http.HandlerFunc, middlewares ...Middleware) {
+	rt.Handle(http.MethodPut, pattern, handler, middlewares...)
+}
+
+// Patch register PATCH handler
+func (rt *Router) Patch(pattern string, handler http.HandlerFunc, middlewares ...Middleware) {
+	rt.Handle(http.MethodPatch, pattern, handler, middlewares...)
+}
+
+// Delete register DELETE handler
+func (rt *Router) Delete(pattern string, handler http.HandlerFunc, middlewares ...Middleware) {
+	rt.Handle(http.MethodDelete, pattern, handler, middlewares...)
+}
+
+// ServeHTTP dispatch to the most specific matching route, 404 when no path matches and 405 when no method does
+func (rt *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	segments := splitPath(r.URL.Path)
+
+	var (
+		best       *route
+		bestParams map[string]string
+		allowed    []string
+	)
+	for _, candidate := range rt.table.routes {
+		params, ok := candidate.match(segments)
+		if !ok {
+			continue
+		}
+		if candidate.method != r.Method && !(r.Method == http.MethodHead && candidate.method == http.MethodGet) {
+			allowed = append(allowed, candidate.method)
+			continue
+		}
+		if best == nil || candidate.static > best.static || (candidate.static == best.static && len(candidate.segments) > len(best.segments)) {
+			best, bestParams = candidate, params
+		}
+	}
+
+	if best == nil {
+		if len(allowed) > 0 {
+			w.Header().Set("Allow", strings.Join(uniqueSorted(allowed), ", "))
+			_ = httpErrors.NewStatusMethodNotAllowedError(w, nil, rt.debug)
+			return
+		}
+		_ = httpErrors.NewNotFoundError(w, nil, rt.debug)
+		return
+	}
+
+	if len(bestParams) > 0 {
+		r = r.WithContext(context.WithValue(r.Context(), paramsCtxKey{}, bestParams))
+	}
+	best.handler.ServeHTTP(w, r)
+}
+
+// Param path parameter value of the matched route
+func Param(r *http.Request, name string) string {
+	params, _ := r.Context().Value(paramsCtxKey{}).(map[string]string)
+	return params[name]
+}
+
+// WithParams attach path parameters to request, for calling handlers without routing
+func WithParams(r *http.Request, params map[string]string) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), paramsCtxKey{}, params))
+}
+
+func (rt *route) match(segments []string) (map[string]string, bool) {
+	var params map[string]string
+	for i, s := range rt.segments {
+		if s == "*" && i == len(rt.segments)-1 {
+			return params, true
+		}
+		if i >= len(segments) {
+			return nil, false
+		}
+		if isParam(s) {
+			if params == nil {
+				params = map[string]string{}
+			}
+			params[s[1:len(s)-1]] = segments[i]
+			continue
+		}
+		if s != segments[i] {
+			return nil, false
+		}
+	}
+
+	return params, len(rt.segments) == len(segments)
+}
+
+func isParam(segment string) bool {
+	return len(segment) > 2 && strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}")
+}
+
+func splitPath(path string) []string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, "/")
+}
+
+func joinPath(prefix string, pattern string) string {
+	return "/" + strings.Trim(strings.TrimRight(prefix, "/")+"/"+strings.TrimLeft(pattern, "/"), "/")
+}
+
+func uniqueSorted(methods []string) []string {
+	seen := map[string]bool{}
+	var unique []string
+	for _, m := range methods {
+		if !seen[m] {
+			seen[m] = true
+			unique = append(unique, m)
+		}
+	}
+	sort.Strings(unique)
+	return unique
+}
@@ -0,0 +1,128 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func handlerWriting(body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(body))
+	}
+}
+
+func headerMiddleware(value string) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("X-Middleware", value)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func serve(rt *Router, method string, target string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	rt.ServeHTTP(w, httptest.NewRequest(method, target, nil))
+	return w
+}
+
+func TestRouter_PathParams(t *testing.T) {
+	t.Parallel()
+
+	rt := NewRouter(false)
+	rt.Get("/brands/{id}/products", func(w http.ResponseWriter, r *http.Request) {
+		handlerWriting("brand products "+Param(r, "id"))(w, r)
+	})
+	rt.Get("/products/{id}", func(w http.ResponseWriter, r *http.Request) {
+		handlerWriting("product "+Param(r, "id"))(w, r)
+	})
+	rt.Get("/products/featured", handlerWriting("featured"))
+
+	w := serve(rt, http.MethodGet, "/products/123")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "product 123", w.Body.String())
+
+	w = serve(rt, http.MethodGet, "/brands/abc/products/")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "brand products abc", w.Body.String())
+
+	t.Run("StaticPrecedence", func(t *testing.T) {
+		w := serve(rt, http.MethodGet, "/products/featured")
+		require.Equal(t, "featured", w.Body.String())
+	})
+
+	t.Run("Head", func(t *testing.T) {
+		w := serve(rt, http.MethodHead, "/products/123")
+		require.Equal(t, http.StatusOK, w.Code)
+	})
+}
+
+func TestRouter_NotFoundAndMethodNotAllowed(t *testing.T) {
+	t.Parallel()
+
+	rt := NewRouter(false)
+	rt.Get("/products/{id}", handlerWriting("get"))
+	rt.Patch("/products/{id}", handlerWriting("patch"))
+	rt.Delete("/products/{id}", handlerWriting("delete"))
+
+	w := serve(rt, http.MethodGet, "/products")
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	w = serve(rt, http.MethodGet, "/products/1/extra")
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	w = serve(rt, http.MethodPost, "/products/1")
+	require.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	require.Equal(t, "DELETE, GET, PATCH", w.Header().Get("Allow"))
+
+	w = serve(rt, http.MethodDelete, "/products/1")
+	require.Equal(t, "delete", w.Body.String())
+}
+
+func TestRouter_Group(t *testing.T) {
+	t.Parallel()
+
+	rt := NewRouter(false)
+	rt.Use(headerMiddleware("root"))
+
+	api := rt.Group("/api", headerMiddleware("api"))
+	admin := api.Group("/admin", headerMiddleware("admin"))
+	admin.Get("/users", handlerWriting("users"), headerMiddleware("route"))
+	api.Get("", handlerWriting("index"))
+
+	w := serve(rt, http.MethodGet, "/api/admin/users")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, []string{"root", "api", "admin", "route"}, w.Header().Values("X-Middleware"))
+
+	w = serve(rt, http.MethodGet, "/api")
+	require.Equal(t, "index", w.Body.String())
+	require.Equal(t, []string{"root", "api"}, w.Header().Values("X-Middleware"))
+
+	t.Run("Forbidden", func(t *testing.T) {
+		guarded := rt.Group("/guarded", func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusForbidden)
+			})
+		})
+		guarded.Get("", handlerWriting("never"))
+
+		w := serve(rt, http.MethodGet, "/guarded")
+		require.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
+
+func TestRouter_Wildcard(t *testing.T) {
+	t.Parallel()
+
+	rt := NewRouter(false)
+	rt.Get("/swagger/*", handlerWriting("swagger"))
+
+	for _, target := range []string{"/swagger", "/swagger/index.html", "/swagger/a/b/c"} {
+		w := serve(rt, http.MethodGet, target)
+		require.Equal(t, "swagger", w.Body.String(), target)
+	}
+}
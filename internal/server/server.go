@@ -14,28 +14,85 @@ import (
 
 	"github.com/dinorain/kalobranded/config"
 	"github.com/dinorain/kalobranded/internal/middlewares"
+	paymentProvider "github.com/dinorain/kalobranded/internal/payment/provider"
+	"github.com/dinorain/kalobranded/internal/server/router"
+	shipmentCourier "github.com/dinorain/kalobranded/internal/shipment/courier"
+	exportFile "github.com/dinorain/kalobranded/pkg/export"
+	"github.com/dinorain/kalobranded/pkg/geo"
+	"github.com/dinorain/kalobranded/pkg/http_client"
+	"github.com/dinorain/kalobranded/pkg/jobs"
 	"github.com/dinorain/kalobranded/pkg/logger"
+	"github.com/dinorain/kalobranded/pkg/mailer"
+	"github.com/dinorain/kalobranded/pkg/oidc"
 
+	addressDeliveryHTTP "github.com/dinorain/kalobranded/internal/address/delivery/http/handlers"
 	brandDeliveryHTTP "github.com/dinorain/kalobranded/internal/brand/delivery/http/handlers"
+	identityDeliveryHTTP "github.com/dinorain/kalobranded/internal/identity/delivery/http/handlers"
+	jobDeliveryHTTP "github.com/dinorain/kalobranded/internal/job/delivery/http/handlers"
+	locationDeliveryHTTP "github.com/dinorain/kalobranded/internal/location/delivery/http/handlers"
+	notificationDeliveryHTTP "github.com/dinorain/kalobranded/internal/notification/delivery/http/handlers"
 	orderDeliveryHTTP "github.com/dinorain/kalobranded/internal/order/delivery/http/handlers"
+	orderStreamDeliveryHTTP "github.com/dinorain/kalobranded/internal/orderstream/delivery/http/handlers"
+	paymentDeliveryHTTP "github.com/dinorain/kalobranded/internal/payment/delivery/http/handlers"
 	productDeliveryHTTP "github.com/dinorain/kalobranded/internal/product/delivery/http/handlers"
+	promotionDeliveryHTTP "github.com/dinorain/kalobranded/internal/promotion/delivery/http/handlers"
+	refundDeliveryHTTP "github.com/dinorain/kalobranded/internal/refund/delivery/http/handlers"
+	returnDeliveryHTTP "github.com/dinorain/kalobranded/internal/returns/delivery/http/handlers"
+	shipmentDeliveryHTTP "github.com/dinorain/kalobranded/internal/shipment/delivery/http/handlers"
+	taxRateDeliveryHTTP "github.com/dinorain/kalobranded/internal/taxrate/delivery/http/handlers"
 	userDeliveryHTTP "github.com/dinorain/kalobranded/internal/user/delivery/http/handlers"
 
+	addressUseCase "github.com/dinorain/kalobranded/internal/address/usecase"
 	brandUseCase "github.com/dinorain/kalobranded/internal/brand/usecase"
+	deliveryFeeUseCase "github.com/dinorain/kalobranded/internal/deliveryfee/usecase"
+	exportDeliveryHTTP "github.com/dinorain/kalobranded/internal/export/delivery/http/handlers"
+	exportRepository "github.com/dinorain/kalobranded/internal/export/repository"
+	exportUseCase "github.com/dinorain/kalobranded/internal/export/usecase"
+	identityUseCase "github.com/dinorain/kalobranded/internal/identity/usecase"
+	locationUseCase "github.com/dinorain/kalobranded/internal/location/usecase"
+	notificationTemplates "github.com/dinorain/kalobranded/internal/notification/templates"
+	notificationUseCase "github.com/dinorain/kalobranded/internal/notification/usecase"
 	orderUseCase "github.com/dinorain/kalobranded/internal/order/usecase"
+	orderStreamUseCase "github.com/dinorain/kalobranded/internal/orderstream/usecase"
+	paymentUseCase "github.com/dinorain/kalobranded/internal/payment/usecase"
 	productUseCase "github.com/dinorain/kalobranded/internal/product/usecase"
+	promotionUseCase "github.com/dinorain/kalobranded/internal/promotion/usecase"
+	refundUseCase "github.com/dinorain/kalobranded/internal/refund/usecase"
+	reportDeliveryHTTP "github.com/dinorain/kalobranded/internal/report/delivery/http/handlers"
+	reportRepository "github.com/dinorain/kalobranded/internal/report/repository"
+	reportUseCase "github.com/dinorain/kalobranded/internal/report/usecase"
+	returnUseCase "github.com/dinorain/kalobranded/internal/returns/usecase"
 	sessUseCase "github.com/dinorain/kalobranded/internal/session/usecase"
+	shipmentUseCase "github.com/dinorain/kalobranded/internal/shipment/usecase"
+	taxRateUseCase "github.com/dinorain/kalobranded/internal/taxrate/usecase"
 	userUseCase "github.com/dinorain/kalobranded/internal/user/usecase"
+	webhookDeliveryHTTP "github.com/dinorain/kalobranded/internal/webhook/delivery/http/handlers"
+	webhookRepository "github.com/dinorain/kalobranded/internal/webhook/repository"
+	webhookUseCase "github.com/dinorain/kalobranded/internal/webhook/usecase"
 
+	addressRepository "github.com/dinorain/kalobranded/internal/address/repository"
 	brandRepository "github.com/dinorain/kalobranded/internal/brand/repository"
+	idempotencyRepository "github.com/dinorain/kalobranded/internal/idempotency/repository"
+	identityRepository "github.com/dinorain/kalobranded/internal/identity/repository"
+	locationRepository "github.com/dinorain/kalobranded/internal/location/repository"
+	notificationRepository "github.com/dinorain/kalobranded/internal/notification/repository"
 	orderRepository "github.com/dinorain/kalobranded/internal/order/repository"
+	orderStreamRepository "github.com/dinorain/kalobranded/internal/orderstream/repository"
+	outboxRepository "github.com/dinorain/kalobranded/internal/outbox/repository"
+	outboxUseCase "github.com/dinorain/kalobranded/internal/outbox/usecase"
+	paymentRepository "github.com/dinorain/kalobranded/internal/payment/repository"
 	productRepository "github.com/dinorain/kalobranded/internal/product/repository"
+	promotionRepository "github.com/dinorain/kalobranded/internal/promotion/repository"
+	refundRepository "github.com/dinorain/kalobranded/internal/refund/repository"
+	returnRepository "github.com/dinorain/kalobranded/internal/returns/repository"
 	sessRepository "github.com/dinorain/kalobranded/internal/session/repository"
+	shipmentRepository "github.com/dinorain/kalobranded/internal/shipment/repository"
+	taxRateRepository "github.com/dinorain/kalobranded/internal/taxrate/repository"
 	userRepository "github.com/dinorain/kalobranded/internal/user/repository"
 )
 
 type Server struct {
-	mux         *http.ServeMux
+	router      *router.Router
 	httpS       *http.Server
 	logger      logger.Logger
 	cfg         *config.Config
@@ -51,7 +108,7 @@ func NewAppServer(logger logger.Logger, cfg *config.Config, db *sqlx.DB, redisCl
 		logger:      logger,
 		cfg:         cfg,
 		v:           validator.New(),
-		mux:         http.NewServeMux(),
+		router:      router.NewRouter(cfg.Http.DebugErrorsResponse),
 		db:          db,
 		redisClient: redisClient,
 	}
@@ -59,24 +116,98 @@ func NewAppServer(logger logger.Logger, cfg *config.Config, db *sqlx.DB, redisCl
 
 // Run service
 func (s *Server) Run() error {
-	s.mw = middlewares.NewMiddlewareManager(s.logger, s.cfg)
-
 	userRepo := userRepository.NewUserPGRepository(s.db)
 	brandRepo := brandRepository.NewBrandPGRepository(s.db)
 	productRepo := productRepository.NewProductPGRepository(s.db)
 	orderRepo := orderRepository.NewOrderPGRepository(s.db)
+	identityRepo := identityRepository.NewIdentityPGRepository(s.db)
+	paymentRepo := paymentRepository.NewPaymentPGRepository(s.db)
+	refundRepo := refundRepository.NewRefundPGRepository(s.db)
+	returnRepo := returnRepository.NewReturnPGRepository(s.db)
+	promotionRepo := promotionRepository.NewPromotionPGRepository(s.db)
+	taxRateRepo := taxRateRepository.NewTaxRatePGRepository(s.db)
+	addressRepo := addressRepository.NewAddressPGRepository(s.db)
+	locationRepo := locationRepository.NewLocationPGRepository(s.db)
+	shipmentRepo := shipmentRepository.NewShipmentPGRepository(s.db)
+	outboxRepo := outboxRepository.NewOutboxPGRepository(s.db)
+	webhookRepo := webhookRepository.NewWebhookPGRepository(s.db)
+	notificationRepo := notificationRepository.NewNotificationPGRepository(s.db)
+	reportRepo := reportRepository.NewReportPGRepository(s.db)
+	exportRepo := exportRepository.NewExportPGRepository(s.db)
 
 	sessRepo := sessRepository.NewSessionRepository(s.redisClient, s.cfg)
 	userRedisRepo := userRepository.NewUserRedisRepo(s.redisClient, s.logger)
 	brandRedisRepo := brandRepository.NewBrandRedisRepo(s.redisClient, s.logger)
 	productRedisRepo := productRepository.NewProductRedisRepo(s.redisClient, s.logger)
 	orderRedisRepo := orderRepository.NewOrderRedisRepo(s.redisClient, s.logger)
+	identityRedisRepo := identityRepository.NewIdentityRedisRepo(s.redisClient, s.logger)
+	idempotencyRedisRepo := idempotencyRepository.NewIdempotencyRedisRepo(s.redisClient, s.logger)
+	outboxRedisRepo := outboxRepository.NewOutboxRedisRepo(s.redisClient, s.cfg, s.logger)
+	orderStreamRedisRepo := orderStreamRepository.NewOrderStreamRedisRepo(s.redisClient, s.cfg, s.logger)
+
+	oidcProviders := oidc.NewProviders(s.cfg, http_client.NewHttpClient(s.cfg.Http.HttpClientDebug))
+	paymentGateway, err := paymentProvider.NewProvider(s.cfg, http_client.NewHttpClient(s.cfg.Http.HttpClientDebug))
+	if err != nil {
+		return err
+	}
+
+	courier, err := shipmentCourier.NewCourier(s.cfg)
+	if err != nil {
+		return err
+	}
+
+	notificationMailer, err := mailer.NewMailer(s.cfg)
+	if err != nil {
+		return err
+	}
+	notificationRenderer, err := notificationTemplates.NewRenderer(s.cfg.Notification.DefaultLocale, s.cfg.Payment.Currency)
+	if err != nil {
+		return err
+	}
+
+	var geocoder geo.Geocoder = geo.NewStaticGeocoder(nil)
+	if s.cfg.Delivery.GeocoderFile != "" {
+		fileGeocoder, err := geo.NewFileGeocoder(s.cfg.Delivery.GeocoderFile)
+		if err != nil {
+			return err
+		}
+		geocoder = fileGeocoder
+	}
 
 	sessUC := sessUseCase.NewSessionUseCase(sessRepo, s.cfg)
+	jobQueue := jobs.NewQueue(s.db, s.cfg.Jobs, s.logger)
+
 	userUC := userUseCase.NewUserUseCase(s.cfg, s.logger, userRepo, userRedisRepo)
 	brandUC := brandUseCase.NewBrandUseCase(s.cfg, s.logger, brandRepo, brandRedisRepo)
 	productUC := productUseCase.NewProductUseCase(s.cfg, s.logger, productRepo, productRedisRepo)
 	orderUC := orderUseCase.NewOrderUseCase(s.cfg, s.logger, orderRepo, orderRedisRepo)
+	identityUC := identityUseCase.NewIdentityUseCase(s.cfg, s.logger, identityRepo, identityRedisRepo, oidcProviders)
+	paymentUC := paymentUseCase.NewPaymentUseCase(s.cfg, s.logger, paymentRepo, orderUC, paymentGateway)
+	refundUC := refundUseCase.NewRefundUseCase(s.cfg, s.logger, refundRepo, orderRedisRepo, paymentUC, jobQueue)
+	returnUC := returnUseCase.NewReturnUseCase(s.cfg, s.logger, returnRepo, orderUC, brandUC, refundUC)
+	promotionUC := promotionUseCase.NewPromotionUseCase(s.cfg, s.logger, promotionRepo)
+	taxRateUC := taxRateUseCase.NewTaxRateUseCase(s.cfg, s.logger, taxRateRepo)
+	deliveryFeeUC := deliveryFeeUseCase.NewDeliveryFeeUseCase(s.cfg, s.logger, geocoder)
+	addressUC := addressUseCase.NewAddressUseCase(s.cfg, s.logger, addressRepo)
+	locationUC := locationUseCase.NewLocationUseCase(s.cfg, s.logger, locationRepo, geocoder)
+	shipmentUC := shipmentUseCase.NewShipmentUseCase(s.cfg, s.logger, shipmentRepo, orderUC, courier)
+	outboxUC := outboxUseCase.NewOutboxUseCase(s.cfg, s.logger, outboxRepo, outboxRedisRepo)
+	orderStreamUC := orderStreamUseCase.NewOrderStreamUseCase(s.cfg, s.logger, orderStreamRedisRepo)
+	// deliveries are retried with backoff by the dispatcher rather than by the client, which refuses internal
+	// addresses unless they are explicitly allowed
+	webhookHttpClient := http_client.NewPublicHttpClient(s.cfg.Http.HttpClientDebug)
+	if s.cfg.Webhook.AllowPrivateNetworks {
+		webhookHttpClient = http_client.NewHttpClient(s.cfg.Http.HttpClientDebug)
+	}
+	webhookUC := webhookUseCase.NewWebhookUseCase(s.cfg, s.logger, webhookRepo, webhookHttpClient.SetRetryCount(0))
+
+	reportUC := reportUseCase.NewReportUseCase(s.cfg, s.logger, reportRepo)
+
+	notificationUC := notificationUseCase.NewNotificationUseCase(s.cfg, s.logger, notificationRepo, orderUC, brandUC, jobQueue, notificationRenderer, notificationMailer)
+	exportUC := exportUseCase.NewExportUseCase(s.cfg, s.logger, exportRepo, orderUC, productUC, userUC, jobQueue, exportFile.NewFileStore(s.cfg.Export.Dir))
+	if err := s.registerJobs(jobQueue, notificationUC, refundUC, reportUC, exportUC); err != nil {
+		return err
+	}
 
 	l, err := net.Listen("tcp", s.cfg.Server.Port)
 	if err != nil {
@@ -84,21 +215,107 @@ func (s *Server) Run() error {
 	}
 	defer l.Close()
 
-	userHandlers := userDeliveryHTTP.NewUserHandlersHTTP(s.mux, s.logger, s.cfg, s.mw, s.v, userUC, sessUC)
+	idempotencyMW := middlewares.NewIdempotencyMiddleware(s.logger, s.cfg, idempotencyRedisRepo)
+	s.mw = middlewares.NewMiddlewareManager(s.logger, s.cfg, middlewares.WithIdempotency(idempotencyMW))
+
+	userHandlers := userDeliveryHTTP.NewUserHandlersHTTP(s.router, s.logger, s.cfg, s.mw, s.v, brandUC, userUC, sessUC)
 	userHandlers.UserMapRoutes()
 
-	brandHandlers := brandDeliveryHTTP.NewBrandHandlersHTTP(s.mux, s.logger, s.cfg, s.mw, s.v, brandUC, sessUC)
+	identityHandlers := identityDeliveryHTTP.NewIdentityHandlersHTTP(s.router, s.logger, s.cfg, s.mw, s.v, identityUC, userUC, sessUC)
+	identityHandlers.IdentityMapRoutes()
+
+	brandHandlers := brandDeliveryHTTP.NewBrandHandlersHTTP(s.router, s.logger, s.cfg, s.mw, s.v, brandUC, sessUC)
 	brandHandlers.BrandMapRoutes()
 
-	productHandlers := productDeliveryHTTP.NewProductHandlersHTTP(s.mux, s.logger, s.cfg, s.mw, s.v, brandUC, productUC, sessUC)
+	productHandlers := productDeliveryHTTP.NewProductHandlersHTTP(s.router, s.logger, s.cfg, s.mw, s.v, brandUC, productUC, sessUC)
 	productHandlers.ProductMapRoutes()
 
-	orderHandlers := orderDeliveryHTTP.NewOrderHandlersHTTP(s.mux, s.logger, s.cfg, s.mw, s.v, orderUC, userUC, brandUC, productUC, sessUC)
+	orderHandlers := orderDeliveryHTTP.NewOrderHandlersHTTP(s.router, s.logger, s.cfg, s.mw, s.v, orderUC, userUC, addressUC, brandUC, productUC, promotionUC, taxRateUC, deliveryFeeUC, locationUC, sessUC)
 	orderHandlers.OrderMapRoutes()
 
+	paymentHandlers := paymentDeliveryHTTP.NewPaymentHandlersHTTP(s.router, s.logger, s.cfg, s.mw, s.v, paymentUC, orderUC)
+	paymentHandlers.PaymentMapRoutes()
+
+	refundHandlers := refundDeliveryHTTP.NewRefundHandlersHTTP(s.router, s.logger, s.cfg, s.mw, s.v, refundUC, orderUC)
+	refundHandlers.RefundMapRoutes()
+
+	returnHandlers := returnDeliveryHTTP.NewReturnHandlersHTTP(s.router, s.logger, s.cfg, s.mw, s.v, returnUC, orderUC)
+	returnHandlers.ReturnMapRoutes()
+
+	promotionHandlers := promotionDeliveryHTTP.NewPromotionHandlersHTTP(s.router, s.logger, s.cfg, s.mw, s.v, promotionUC, brandUC, productUC)
+	promotionHandlers.PromotionMapRoutes()
+
+	taxRateHandlers := taxRateDeliveryHTTP.NewTaxRateHandlersHTTP(s.router, s.logger, s.cfg, s.mw, s.v, taxRateUC)
+	taxRateHandlers.TaxRateMapRoutes()
+
+	addressHandlers := addressDeliveryHTTP.NewAddressHandlersHTTP(s.router, s.logger, s.cfg, s.mw, s.v, addressUC)
+	addressHandlers.AddressMapRoutes()
+
+	locationHandlers := locationDeliveryHTTP.NewLocationHandlersHTTP(s.router, s.logger, s.cfg, s.mw, s.v, locationUC, brandUC, productUC)
+	locationHandlers.LocationMapRoutes()
+
+	shipmentHandlers := shipmentDeliveryHTTP.NewShipmentHandlersHTTP(s.router, s.logger, s.cfg, s.mw, s.v, shipmentUC, orderUC)
+	shipmentHandlers.ShipmentMapRoutes()
+
+	webhookHandlers := webhookDeliveryHTTP.NewWebhookHandlersHTTP(s.router, s.logger, s.cfg, s.mw, s.v, webhookUC, brandUC)
+	webhookHandlers.WebhookMapRoutes()
+
+	notificationHandlers := notificationDeliveryHTTP.NewNotificationHandlersHTTP(s.router, s.logger, s.cfg, s.mw, s.v, notificationUC)
+	notificationHandlers.NotificationMapRoutes()
+
+	if s.cfg.OrderStream.Enabled {
+		orderStreamHandlers := orderStreamDeliveryHTTP.NewOrderStreamHandlersHTTP(s.router, s.logger, s.cfg, s.mw, orderStreamUC)
+		orderStreamHandlers.OrderStreamMapRoutes()
+	}
+
+	reportHandlers := reportDeliveryHTTP.NewReportHandlersHTTP(s.router, s.logger, s.cfg, s.mw, reportUC)
+	reportHandlers.ReportMapRoutes()
+
+	exportHandlers := exportDeliveryHTTP.NewExportHandlersHTTP(s.router, s.logger, s.cfg, s.mw, exportUC)
+	exportHandlers.ExportMapRoutes()
+
+	jobHandlers := jobDeliveryHTTP.NewJobHandlersHTTP(s.router, s.logger, s.cfg, s.mw, jobQueue)
+	jobHandlers.JobMapRoutes()
+
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM, syscall.SIGINT)
 	defer cancel()
 
+	if s.cfg.Retention.PurgeEnabled {
+		go s.runRetentionPurge(ctx, []retentionTarget{
+			{name: "orders", purger: orderUC},
+			{name: "products", purger: productUC},
+			{name: "brands", purger: brandUC},
+			{name: "users", purger: userUC},
+		})
+	}
+
+	if s.cfg.Outbox.RelayEnabled {
+		go s.runOutboxRelay(ctx, outboxUC)
+	}
+	consumers := []outboxConsumer{
+		{group: "audit", handler: s.logOutboxEvent},
+		{group: "webhooks", handler: webhookUC.HandleEvent},
+	}
+	if s.cfg.Notification.Enabled {
+		consumers = append(consumers, outboxConsumer{group: "notifications", handler: notificationUC.HandleEvent})
+	}
+	if s.cfg.OrderStream.Enabled {
+		// a single instance publishes each update, the pub/sub channel fans it out to the streams of every instance
+		consumers = append(consumers, outboxConsumer{group: "order-stream", handler: orderStreamUC.HandleEvent})
+		go s.runOrderStream(ctx, orderStreamUC)
+	}
+	s.runOutboxConsumers(ctx, outboxUC, consumers)
+	if s.cfg.Webhook.DispatchEnabled {
+		go s.runWebhookDispatch(ctx, webhookUC)
+	}
+
+	jobsDone := make(chan struct{})
+	if s.cfg.Jobs.Enabled {
+		go s.runJobs(ctx, jobQueue, jobsDone)
+	} else {
+		close(jobsDone)
+	}
+
 	go func() {
 		if err := s.runHttpServer(); err != nil {
 			s.logger.Errorf("s.runHttpServer: %v", err)
@@ -107,9 +324,13 @@ func (s *Server) Run() error {
 	}()
 
 	<-ctx.Done()
-	if err := s.httpS.Shutdown(ctx); err != nil {
+	// order streams end once ctx is done, in-flight requests get shutdownTimeout to finish
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer shutdownCancel()
+	if err := s.httpS.Shutdown(shutdownCtx); err != nil {
 		s.logger.WarnMsg("httpS.Server.Shutdown", err)
 	}
+	<-jobsDone
 
 	return nil
 }
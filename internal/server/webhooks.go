@@ -0,0 +1,41 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/dinorain/kalobranded/internal/webhook"
+)
+
+const webhookDefaultInterval = 5 * time.Second
+
+// runWebhookDispatch attempt due brand webhook deliveries on every tick until ctx is done, batches are dispatched
+// back to back until none is due
+func (s *Server) runWebhookDispatch(ctx context.Context, webhookUC webhook.WebhookUseCase) {
+	interval := s.cfg.Webhook.Interval
+	if interval <= 0 {
+		interval = webhookDefaultInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			attempted, err := webhookUC.Dispatch(ctx)
+			if err != nil {
+				s.logger.Errorf("webhookUC.Dispatch: %v", err)
+				break
+			}
+			if attempted == 0 {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
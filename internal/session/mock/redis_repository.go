@@ -10,6 +10,7 @@ import (
 
 	models "github.com/dinorain/kalobranded/internal/models"
 	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
 )
 
 // MockSessRepository is a mock of SessRepository interface.
@@ -50,6 +51,20 @@ func (mr *MockSessRepositoryMockRecorder) CreateSession(ctx, session, expire int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockSessRepository)(nil).CreateSession), ctx, session, expire)
 }
 
+// DeleteAllByUserId mocks base method.
+func (m *MockSessRepository) DeleteAllByUserId(ctx context.Context, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAllByUserId", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAllByUserId indicates an expected call of DeleteAllByUserId.
+func (mr *MockSessRepositoryMockRecorder) DeleteAllByUserId(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAllByUserId", reflect.TypeOf((*MockSessRepository)(nil).DeleteAllByUserId), ctx, userID)
+}
+
 // DeleteById mocks base method.
 func (m *MockSessRepository) DeleteById(ctx context.Context, sessionID string) error {
 	m.ctrl.T.Helper()
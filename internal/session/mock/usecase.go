@@ -10,6 +10,7 @@ import (
 
 	models "github.com/dinorain/kalobranded/internal/models"
 	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
 )
 
 // MockSessUseCase is a mock of SessUseCase interface.
@@ -50,6 +51,20 @@ func (mr *MockSessUseCaseMockRecorder) CreateSession(ctx, session, expire interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockSessUseCase)(nil).CreateSession), ctx, session, expire)
 }
 
+// DeleteAllByUserId mocks base method.
+func (m *MockSessUseCase) DeleteAllByUserId(ctx context.Context, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAllByUserId", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAllByUserId indicates an expected call of DeleteAllByUserId.
+func (mr *MockSessUseCaseMockRecorder) DeleteAllByUserId(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAllByUserId", reflect.TypeOf((*MockSessUseCase)(nil).DeleteAllByUserId), ctx, userID)
+}
+
 // DeleteById mocks base method.
 func (m *MockSessUseCase) DeleteById(ctx context.Context, sessionID string) error {
 	m.ctrl.T.Helper()
@@ -4,6 +4,8 @@ package session
 import (
 	"context"
 
+	"github.com/google/uuid"
+
 	"github.com/dinorain/kalobranded/internal/models"
 )
 
@@ -12,4 +14,5 @@ type SessRepository interface {
 	CreateSession(ctx context.Context, session *models.Session, expire int) (string, error)
 	GetSessionById(ctx context.Context, sessionID string) (*models.Session, error)
 	DeleteById(ctx context.Context, sessionID string) error
+	DeleteAllByUserId(ctx context.Context, userID uuid.UUID) error
 }
@@ -16,7 +16,8 @@ import (
 )
 
 const (
-	basePrefix = "sessions:"
+	basePrefix         = "sessions:"
+	userSessionsPrefix = "user_sessions:"
 )
 
 // Session repository
@@ -33,17 +34,23 @@ func NewSessionRepository(redisClient *redis.Client, cfg *config.Config) session
 	return &sessionRepo{redisClient: redisClient, basePrefix: basePrefix, cfg: cfg}
 }
 
-// Create session in redis
+// Create session in redis, indexed by user so all sessions of the user can be deleted at once
 func (s *sessionRepo) CreateSession(ctx context.Context, sess *models.Session, expire int) (string, error) {
 	sess.SessionID = uuid.New().String()
 	sessionKey := s.generateKey(sess.SessionID)
+	userSessionsKey := s.generateUserSessionsKey(sess.UserID)
 
 	sessBytes, err := json.Marshal(&sess)
 	if err != nil {
 		return "", errors.WithMessage(err, "sessionRepo.CreateSession.json.Marshal")
 	}
-	if err = s.redisClient.Set(ctx, sessionKey, sessBytes, time.Second*time.Duration(expire)).Err(); err != nil {
-		return "", errors.Wrap(err, "sessionRepo.CreateSession.redisClient.Set")
+	if _, err = s.redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, sessionKey, sessBytes, time.Second*time.Duration(expire))
+		pipe.SAdd(ctx, userSessionsKey, sess.SessionID)
+		pipe.Expire(ctx, userSessionsKey, time.Second*time.Duration(expire))
+		return nil
+	}); err != nil {
+		return "", errors.Wrap(err, "sessionRepo.CreateSession.redisClient.TxPipelined")
 	}
 	return sess.SessionID, nil
 }
@@ -70,6 +77,31 @@ func (s *sessionRepo) DeleteById(ctx context.Context, sessionID string) error {
 	return nil
 }
 
+// Delete all sessions of user
+func (s *sessionRepo) DeleteAllByUserId(ctx context.Context, userID uuid.UUID) error {
+	userSessionsKey := s.generateUserSessionsKey(userID)
+
+	sessionIDs, err := s.redisClient.SMembers(ctx, userSessionsKey).Result()
+	if err != nil {
+		return errors.Wrap(err, "sessionRepo.DeleteAllByUserId.redisClient.SMembers")
+	}
+
+	keys := make([]string, 0, len(sessionIDs)+1)
+	for _, sessionID := range sessionIDs {
+		keys = append(keys, s.generateKey(sessionID))
+	}
+	keys = append(keys, userSessionsKey)
+
+	if err := s.redisClient.Del(ctx, keys...).Err(); err != nil {
+		return errors.Wrap(err, "sessionRepo.DeleteAllByUserId.redisClient.Del")
+	}
+	return nil
+}
+
 func (s *sessionRepo) generateKey(sessionID string) string {
 	return fmt.Sprintf("%s: %s", s.basePrefix, sessionID)
 }
+
+func (s *sessionRepo) generateUserSessionsKey(userID uuid.UUID) string {
+	return fmt.Sprintf("%s: %s", userSessionsPrefix, userID)
+}
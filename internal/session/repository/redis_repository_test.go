@@ -76,3 +76,31 @@ func TestDeleteSession(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func TestDeleteAllSessionsByUserId(t *testing.T) {
+	t.Parallel()
+
+	sessRepository := SetupRedis()
+
+	t.Run("DeleteAllByUserId", func(t *testing.T) {
+		userUUID := uuid.New()
+		otherUserUUID := uuid.New()
+
+		first, err := sessRepository.CreateSession(context.Background(), &models.Session{UserID: userUUID}, 10)
+		require.NoError(t, err)
+		second, err := sessRepository.CreateSession(context.Background(), &models.Session{UserID: userUUID}, 10)
+		require.NoError(t, err)
+		other, err := sessRepository.CreateSession(context.Background(), &models.Session{UserID: otherUserUUID}, 10)
+		require.NoError(t, err)
+
+		err = sessRepository.DeleteAllByUserId(context.Background(), userUUID)
+		require.NoError(t, err)
+
+		_, err = sessRepository.GetSessionById(context.Background(), first)
+		require.ErrorIs(t, err, redis.Nil)
+		_, err = sessRepository.GetSessionById(context.Background(), second)
+		require.ErrorIs(t, err, redis.Nil)
+		_, err = sessRepository.GetSessionById(context.Background(), other)
+		require.NoError(t, err)
+	})
+}
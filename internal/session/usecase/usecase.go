@@ -3,6 +3,8 @@ package usecase
 import (
 	"context"
 
+	"github.com/google/uuid"
+
 	"github.com/dinorain/kalobranded/config"
 	"github.com/dinorain/kalobranded/internal/models"
 	"github.com/dinorain/kalobranded/internal/session"
@@ -31,6 +33,11 @@ func (u *sessionUC) DeleteById(ctx context.Context, sessionID string) error {
 	return u.sessionRepo.DeleteById(ctx, sessionID)
 }
 
+// Delete all sessions of user
+func (u *sessionUC) DeleteAllByUserId(ctx context.Context, userID uuid.UUID) error {
+	return u.sessionRepo.DeleteAllByUserId(ctx, userID)
+}
+
 // get session by id
 func (u *sessionUC) GetSessionById(ctx context.Context, sessionID string) (*models.Session, error) {
 	return u.sessionRepo.GetSessionById(ctx, sessionID)
@@ -0,0 +1,58 @@
+//go:generate mockgen -source courier.go -destination mock/courier.go -package mock
+package shipment
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidSignature   = errors.New("invalid webhook signature")
+	ErrUnknownService     = errors.New("unknown courier service")
+	ErrShipmentNotFound   = errors.New("courier shipment not found")
+	ErrUnknownEventStatus = errors.New("unknown courier event status")
+)
+
+// Parcel goods handed to the courier, Weight in grams
+type Parcel struct {
+	OrderID uuid.UUID `json:"order_id"`
+	From    string    `json:"from"`
+	To      string    `json:"to"`
+	Weight  uint64    `json:"weight"`
+}
+
+// Rate price of shipping a parcel with a courier service
+type Rate struct {
+	Service       string  `json:"service"`
+	Amount        float64 `json:"amount"`
+	EstimatedDays int     `json:"estimated_days"`
+}
+
+// Label courier side shipment, TrackingNumber identifies it in webhook events
+type Label struct {
+	TrackingNumber string  `json:"tracking_number"`
+	LabelURL       string  `json:"label_url"`
+	Service        string  `json:"service"`
+	Amount         float64 `json:"amount"`
+}
+
+// Event webhook notification of a tracking scan sent by the courier
+type Event struct {
+	ID             string    `json:"id"`
+	TrackingNumber string    `json:"tracking_number"`
+	Status         string    `json:"status"`
+	Description    string    `json:"description"`
+	Location       string    `json:"location"`
+	OccurredAt     time.Time `json:"occurred_at"`
+}
+
+// Courier carrier shipping parcels
+type Courier interface {
+	Name() string
+	Rates(ctx context.Context, parcel *Parcel) ([]Rate, error)
+	CreateShipment(ctx context.Context, parcel *Parcel, service string) (*Label, error)
+	ParseWebhook(payload []byte, signature string) (*Event, error)
+}
@@ -0,0 +1,19 @@
+package courier
+
+import (
+	"fmt"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/shipment"
+	"github.com/dinorain/kalobranded/internal/shipment/fake"
+)
+
+// NewCourier Build the configured courier, the fake courier when none is configured
+func NewCourier(cfg *config.Config) (shipment.Courier, error) {
+	switch cfg.Courier.Provider {
+	case "", fake.CourierName:
+		return fake.NewCourier(cfg.Courier.WebhookSecret), nil
+	default:
+		return nil, fmt.Errorf("unknown courier %q", cfg.Courier.Provider)
+	}
+}
@@ -0,0 +1,5 @@
+package dto
+
+type ShipmentCreateRequestDto struct {
+	Service string `json:"service" validate:"required,lte=32"`
+}
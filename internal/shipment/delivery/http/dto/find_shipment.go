@@ -0,0 +1,5 @@
+package dto
+
+type ShipmentFindResponseDto struct {
+	Data []*ShipmentResponseDto `json:"data"`
+}
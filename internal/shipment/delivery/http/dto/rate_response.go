@@ -0,0 +1,23 @@
+package dto
+
+import (
+	"github.com/dinorain/kalobranded/internal/shipment"
+)
+
+type RateResponseDto struct {
+	Service       string  `json:"service"`
+	Amount        float64 `json:"amount"`
+	EstimatedDays int     `json:"estimated_days"`
+}
+
+type RateFindResponseDto struct {
+	Data []*RateResponseDto `json:"data"`
+}
+
+func RateResponseFromCourier(rate *shipment.Rate) *RateResponseDto {
+	return &RateResponseDto{
+		Service:       rate.Service,
+		Amount:        rate.Amount,
+		EstimatedDays: rate.EstimatedDays,
+	}
+}
@@ -0,0 +1,58 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/internal/models"
+)
+
+type ShipmentResponseDto struct {
+	ShipmentID     uuid.UUID                  `json:"shipment_id"`
+	OrderID        uuid.UUID                  `json:"order_id"`
+	Carrier        string                     `json:"carrier"`
+	Service        string                     `json:"service"`
+	TrackingNumber string                     `json:"tracking_number"`
+	LabelURL       string                     `json:"label_url"`
+	Rate           float64                    `json:"rate"`
+	Status         string                     `json:"status"`
+	Events         []TrackingEventResponseDto `json:"events"`
+	Version        int                        `json:"version"`
+	CreatedAt      time.Time                  `json:"created_at,omitempty"`
+	UpdatedAt      time.Time                  `json:"updated_at,omitempty"`
+}
+
+type TrackingEventResponseDto struct {
+	Status      string    `json:"status"`
+	Description string    `json:"description"`
+	Location    string    `json:"location"`
+	OccurredAt  time.Time `json:"occurred_at"`
+}
+
+func ShipmentResponseFromModel(shipment *models.Shipment) *ShipmentResponseDto {
+	events := make([]TrackingEventResponseDto, 0, len(shipment.Events))
+	for _, e := range shipment.Events {
+		events = append(events, TrackingEventResponseDto{
+			Status:      e.Status,
+			Description: e.Description,
+			Location:    e.Location,
+			OccurredAt:  e.OccurredAt,
+		})
+	}
+
+	return &ShipmentResponseDto{
+		ShipmentID:     shipment.ShipmentID,
+		OrderID:        shipment.OrderID,
+		Carrier:        shipment.Carrier,
+		Service:        shipment.Service,
+		TrackingNumber: shipment.TrackingNumber,
+		LabelURL:       shipment.LabelURL,
+		Rate:           shipment.Rate,
+		Status:         shipment.Status,
+		Events:         events,
+		Version:        shipment.Version,
+		CreatedAt:      shipment.CreatedAt,
+		UpdatedAt:      shipment.UpdatedAt,
+	}
+}
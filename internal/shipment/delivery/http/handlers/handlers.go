@@ -0,0 +1,243 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/middlewares"
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/internal/order"
+	"github.com/dinorain/kalobranded/internal/server/router"
+	"github.com/dinorain/kalobranded/internal/shipment"
+	"github.com/dinorain/kalobranded/internal/shipment/delivery/http/dto"
+	"github.com/dinorain/kalobranded/pkg/constants"
+	httpErrors "github.com/dinorain/kalobranded/pkg/http_errors"
+	"github.com/dinorain/kalobranded/pkg/logger"
+)
+
+const (
+	maxWebhookBytes = 1 << 20
+)
+
+type shipmentHandlersHTTP struct {
+	router     *router.Router
+	logger     logger.Logger
+	cfg        *config.Config
+	mw         middlewares.MiddlewareManager
+	v          *validator.Validate
+	shipmentUC shipment.ShipmentUseCase
+	orderUC    order.OrderUseCase
+}
+
+var _ shipment.ShipmentHandlers = (*shipmentHandlersHTTP)(nil)
+
+func NewShipmentHandlersHTTP(
+	router *router.Router,
+	logger logger.Logger,
+	cfg *config.Config,
+	mw middlewares.MiddlewareManager,
+	v *validator.Validate,
+	shipmentUC shipment.ShipmentUseCase,
+	orderUC order.OrderUseCase,
+) *shipmentHandlersHTTP {
+	return &shipmentHandlersHTTP{router: router, logger: logger, cfg: cfg, mw: mw, v: v, shipmentUC: shipmentUC, orderUC: orderUC}
+}
+
+// Create
+// @Tags Shipments
+// @Summary Ship order
+// @Description Admin or seller of the order brand books a courier shipment of an accepted order, its label is issued right away
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "order uuid"
+// @Param payload body dto.ShipmentCreateRequestDto true "Payload"
+// @Success 201 {object} dto.ShipmentResponseDto
+// @Router /orders/{id}/shipments [post]
+func (h *shipmentHandlersHTTP) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	foundOrder, err := h.findOrder(w, r, false)
+	if err != nil {
+		return
+	}
+
+	createDto := &dto.ShipmentCreateRequestDto{}
+	if err := json.NewDecoder(r.Body).Decode(createDto); err != nil {
+		h.logger.Errorf("decoder.Decode: %v", err)
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	if err := h.v.Struct(createDto); err != nil {
+		h.logger.Errorf("h.v.Struct: %v", err)
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	createdShipment, err := h.shipmentUC.Create(ctx, foundOrder, createDto.Service)
+	if err != nil {
+		h.logger.Errorf("shipmentUC.Create: %v", err)
+		if errors.Is(err, models.ErrNotShippable) || errors.Is(err, models.ErrShipmentExists) {
+			_ = httpErrors.NewConflictError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+			return
+		}
+		if errors.Is(err, shipment.ErrUnknownService) {
+			_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+			return
+		}
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	res, _ := json.Marshal(dto.ShipmentResponseFromModel(createdShipment))
+	w.WriteHeader(http.StatusCreated)
+	w.Write(res)
+	return
+}
+
+// FindAllByOrderId
+// @Tags Shipments
+// @Summary Track order
+// @Description Find shipments of an order with their tracking events, buyers can only track their own orders
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "order uuid"
+// @Success 200 {object} dto.ShipmentFindResponseDto
+// @Router /orders/{id}/shipments [get]
+func (h *shipmentHandlersHTTP) FindAllByOrderId(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	foundOrder, err := h.findOrder(w, r, true)
+	if err != nil {
+		return
+	}
+
+	shipments, err := h.shipmentUC.FindAllByOrderId(ctx, foundOrder.OrderID)
+	if err != nil {
+		h.logger.Errorf("shipmentUC.FindAllByOrderId: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	resDto := dto.ShipmentFindResponseDto{Data: make([]*dto.ShipmentResponseDto, 0, len(shipments))}
+	for i := range shipments {
+		resDto.Data = append(resDto.Data, dto.ShipmentResponseFromModel(&shipments[i]))
+	}
+
+	res, _ := json.Marshal(resDto)
+	w.WriteHeader(http.StatusOK)
+	w.Write(res)
+	return
+}
+
+// Rates
+// @Tags Shipments
+// @Summary Find shipping rates
+// @Description Admin or seller of the order brand compares courier services for shipping the order
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "order uuid"
+// @Success 200 {object} dto.RateFindResponseDto
+// @Router /orders/{id}/shipments/rates [get]
+func (h *shipmentHandlersHTTP) Rates(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	foundOrder, err := h.findOrder(w, r, false)
+	if err != nil {
+		return
+	}
+
+	rates, err := h.shipmentUC.Rates(ctx, foundOrder)
+	if err != nil {
+		h.logger.Errorf("shipmentUC.Rates: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	resDto := dto.RateFindResponseDto{Data: make([]*dto.RateResponseDto, 0, len(rates))}
+	for i := range rates {
+		resDto.Data = append(resDto.Data, dto.RateResponseFromCourier(&rates[i]))
+	}
+
+	res, _ := json.Marshal(resDto)
+	w.WriteHeader(http.StatusOK)
+	w.Write(res)
+	return
+}
+
+// Webhook
+// @Tags Shipments
+// @Summary Courier webhook
+// @Description Receive tracking events signed by the courier, a picked up shipment marks its order shipped and a delivered one delivered
+// @Accept json
+// @Produce json
+// @Param Courier-Signature header string true "hex HMAC-SHA256 of the body"
+// @Success 200 {object} dto.ShipmentResponseDto
+// @Router /shipments/webhook [post]
+func (h *shipmentHandlersHTTP) Webhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	payload, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBytes))
+	if err != nil {
+		h.logger.Errorf("ioutil.ReadAll: %v", err)
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	handledShipment, err := h.shipmentUC.HandleWebhook(ctx, payload, r.Header.Get(constants.CourierSignature))
+	if err != nil {
+		h.logger.Errorf("shipmentUC.HandleWebhook: %v", err)
+		if errors.Is(err, shipment.ErrInvalidSignature) {
+			_ = httpErrors.NewUnauthorizedError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+			return
+		}
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	res, _ := json.Marshal(dto.ShipmentResponseFromModel(handledShipment))
+	w.WriteHeader(http.StatusOK)
+	w.Write(res)
+	return
+}
+
+// findOrder find the order of the path id for the caller, admins reach every order, sellers the orders of their brand
+// and, when allowBuyer, users their own orders. Error response is already written when err is not nil
+func (h *shipmentHandlersHTTP) findOrder(w http.ResponseWriter, r *http.Request, allowBuyer bool) (*models.Order, error) {
+	orderUUID, err := uuid.Parse(router.Param(r, constants.ID))
+	if err != nil {
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return nil, err
+	}
+
+	jwtClaims, err := h.mw.GetJWTClaims(w, r)
+	if err != nil {
+		return nil, err
+	}
+	claims := *jwtClaims
+	userID, _ := claims["user_id"].(string)
+
+	foundOrder, err := h.orderUC.FindById(r.Context(), orderUUID)
+	if err != nil {
+		h.logger.Errorf("orderUC.FindById: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return nil, err
+	}
+
+	if !allowBuyer || foundOrder.UserID.String() != userID {
+		if err := h.mw.CheckBrand(w, r, foundOrder.BrandID); err != nil {
+			return nil, err
+		}
+	}
+
+	return foundOrder, nil
+}
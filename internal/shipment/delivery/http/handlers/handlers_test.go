@@ -0,0 +1,239 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/golang-jwt/jwt"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/middlewares"
+	"github.com/dinorain/kalobranded/internal/models"
+	mockOrderUC "github.com/dinorain/kalobranded/internal/order/mock"
+	"github.com/dinorain/kalobranded/internal/server/router"
+	"github.com/dinorain/kalobranded/internal/shipment"
+	"github.com/dinorain/kalobranded/internal/shipment/delivery/http/dto"
+	"github.com/dinorain/kalobranded/internal/shipment/mock"
+	"github.com/dinorain/kalobranded/pkg/constants"
+	"github.com/dinorain/kalobranded/pkg/logger"
+)
+
+func signedToken(t *testing.T, cfg *config.Config, userUUID uuid.UUID, role string, brandUUID *uuid.UUID) string {
+	token := jwt.New(jwt.SigningMethodHS256)
+	claims := token.Claims.(jwt.MapClaims)
+	claims["session_id"] = uuid.New().String()
+	claims["user_id"] = userUUID.String()
+	claims["role"] = role
+	if brandUUID != nil {
+		claims["brand_id"] = brandUUID.String()
+	}
+	claims["exp"] = time.Now().Add(time.Minute * 15).Unix()
+	validToken, err := token.SignedString([]byte(cfg.Server.JwtSecretKey))
+	require.NoError(t, err)
+	return validToken
+}
+
+func TestShipmentsHandler_Create(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	shipmentUC := mock.NewMockShipmentUseCase(ctrl)
+	orderUC := mockOrderUC.NewMockOrderUseCase(ctrl)
+
+	cfg := &config.Config{Session: config.Session{Expire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	appLogger := logger.NewAppLogger(cfg)
+	appLogger.InitLogger()
+	mw := middlewares.NewMiddlewareManager(appLogger, cfg)
+
+	v := validator.New()
+
+	rt := router.NewRouter(false)
+	handlers := NewShipmentHandlersHTTP(rt, appLogger, cfg, mw, v, shipmentUC, orderUC)
+
+	sellerUUID := uuid.New()
+	brandUUID := uuid.New()
+	orderUUID := uuid.New()
+	mockOrder := &models.Order{OrderID: orderUUID, UserID: uuid.New(), BrandID: brandUUID, Quantity: 1, Status: models.OrderStatusAccepted}
+
+	t.Run("Seller", func(t *testing.T) {
+		req := router.WithParams(httptest.NewRequest(http.MethodPost, "/orders/"+orderUUID.String()+"/shipments", strings.NewReader(`{"service": "regular"}`)), map[string]string{"id": orderUUID.String()})
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", signedToken(t, cfg, sellerUUID, models.UserRoleSeller, &brandUUID)))
+		w := httptest.NewRecorder()
+
+		orderUC.EXPECT().FindById(gomock.Any(), orderUUID).Return(mockOrder, nil)
+		shipmentUC.EXPECT().Create(gomock.Any(), mockOrder, "regular").Return(&models.Shipment{
+			ShipmentID:     uuid.New(),
+			OrderID:        orderUUID,
+			Carrier:        "fake",
+			Service:        "regular",
+			TrackingNumber: "FAKE0000000001",
+			Status:         models.ShipmentStatusLabelCreated,
+		}, nil)
+
+		http.HandlerFunc(handlers.Create).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusCreated, w.Code)
+		resDto := &dto.ShipmentResponseDto{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), resDto))
+		require.Equal(t, "FAKE0000000001", resDto.TrackingNumber)
+		require.Empty(t, resDto.Events)
+	})
+
+	t.Run("Buyer", func(t *testing.T) {
+		req := router.WithParams(httptest.NewRequest(http.MethodPost, "/orders/"+orderUUID.String()+"/shipments", strings.NewReader(`{"service": "regular"}`)), map[string]string{"id": orderUUID.String()})
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", signedToken(t, cfg, mockOrder.UserID, models.UserRoleUser, nil)))
+		w := httptest.NewRecorder()
+
+		orderUC.EXPECT().FindById(gomock.Any(), orderUUID).Return(mockOrder, nil)
+
+		http.HandlerFunc(handlers.Create).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("NotShippable", func(t *testing.T) {
+		req := router.WithParams(httptest.NewRequest(http.MethodPost, "/orders/"+orderUUID.String()+"/shipments", strings.NewReader(`{"service": "regular"}`)), map[string]string{"id": orderUUID.String()})
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", signedToken(t, cfg, uuid.New(), models.UserRoleAdmin, nil)))
+		w := httptest.NewRecorder()
+
+		orderUC.EXPECT().FindById(gomock.Any(), orderUUID).Return(mockOrder, nil)
+		shipmentUC.EXPECT().Create(gomock.Any(), mockOrder, "regular").Return(nil, errors.Wrap(models.ErrShipmentExists, "shipmentUC.Create"))
+
+		http.HandlerFunc(handlers.Create).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("UnknownService", func(t *testing.T) {
+		req := router.WithParams(httptest.NewRequest(http.MethodPost, "/orders/"+orderUUID.String()+"/shipments", strings.NewReader(`{"service": "overnight"}`)), map[string]string{"id": orderUUID.String()})
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", signedToken(t, cfg, uuid.New(), models.UserRoleAdmin, nil)))
+		w := httptest.NewRecorder()
+
+		orderUC.EXPECT().FindById(gomock.Any(), orderUUID).Return(mockOrder, nil)
+		shipmentUC.EXPECT().Create(gomock.Any(), mockOrder, "overnight").Return(nil, errors.Wrap(shipment.ErrUnknownService, "courier.CreateShipment"))
+
+		http.HandlerFunc(handlers.Create).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestShipmentsHandler_FindAllByOrderId(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	shipmentUC := mock.NewMockShipmentUseCase(ctrl)
+	orderUC := mockOrderUC.NewMockOrderUseCase(ctrl)
+
+	cfg := &config.Config{Session: config.Session{Expire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	appLogger := logger.NewAppLogger(cfg)
+	appLogger.InitLogger()
+	mw := middlewares.NewMiddlewareManager(appLogger, cfg)
+
+	v := validator.New()
+
+	rt := router.NewRouter(false)
+	handlers := NewShipmentHandlersHTTP(rt, appLogger, cfg, mw, v, shipmentUC, orderUC)
+
+	orderUUID := uuid.New()
+	mockOrder := &models.Order{OrderID: orderUUID, UserID: uuid.New(), BrandID: uuid.New(), Quantity: 1, Status: models.OrderStatusShipped}
+
+	t.Run("Buyer", func(t *testing.T) {
+		req := router.WithParams(httptest.NewRequest(http.MethodGet, "/orders/"+orderUUID.String()+"/shipments", nil), map[string]string{"id": orderUUID.String()})
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", signedToken(t, cfg, mockOrder.UserID, models.UserRoleUser, nil)))
+		w := httptest.NewRecorder()
+
+		orderUC.EXPECT().FindById(gomock.Any(), orderUUID).Return(mockOrder, nil)
+		shipmentUC.EXPECT().FindAllByOrderId(gomock.Any(), orderUUID).Return([]models.Shipment{{
+			ShipmentID:     uuid.New(),
+			OrderID:        orderUUID,
+			TrackingNumber: "FAKE0000000001",
+			Status:         models.ShipmentStatusInTransit,
+			Events: models.TrackingEvents{
+				{ID: "evt_fake_000002", Status: models.ShipmentStatusPickedUp, Location: "Jakarta"},
+				{ID: "evt_fake_000003", Status: models.ShipmentStatusInTransit, Location: "Jakarta hub"},
+			},
+		}}, nil)
+
+		http.HandlerFunc(handlers.FindAllByOrderId).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		resDto := &dto.ShipmentFindResponseDto{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), resDto))
+		require.Len(t, resDto.Data, 1)
+		require.Len(t, resDto.Data[0].Events, 2)
+		require.Equal(t, "Jakarta hub", resDto.Data[0].Events[1].Location)
+	})
+
+	t.Run("OtherUser", func(t *testing.T) {
+		req := router.WithParams(httptest.NewRequest(http.MethodGet, "/orders/"+orderUUID.String()+"/shipments", nil), map[string]string{"id": orderUUID.String()})
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", signedToken(t, cfg, uuid.New(), models.UserRoleUser, nil)))
+		w := httptest.NewRecorder()
+
+		orderUC.EXPECT().FindById(gomock.Any(), orderUUID).Return(mockOrder, nil)
+
+		http.HandlerFunc(handlers.FindAllByOrderId).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
+
+func TestShipmentsHandler_Webhook(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	shipmentUC := mock.NewMockShipmentUseCase(ctrl)
+	orderUC := mockOrderUC.NewMockOrderUseCase(ctrl)
+
+	cfg := &config.Config{Session: config.Session{Expire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	appLogger := logger.NewAppLogger(cfg)
+	appLogger.InitLogger()
+	mw := middlewares.NewMiddlewareManager(appLogger, cfg)
+
+	v := validator.New()
+
+	rt := router.NewRouter(false)
+	handlers := NewShipmentHandlersHTTP(rt, appLogger, cfg, mw, v, shipmentUC, orderUC)
+
+	payload := `{"id":"evt_fake_000002","tracking_number":"FAKE0000000001","status":"delivered","location":"Bandung"}`
+
+	t.Run("Handled", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/shipments/webhook", strings.NewReader(payload))
+		req.Header.Set(constants.CourierSignature, "signature")
+		w := httptest.NewRecorder()
+
+		shipmentUC.EXPECT().HandleWebhook(gomock.Any(), []byte(payload), "signature").Return(&models.Shipment{ShipmentID: uuid.New(), Status: models.ShipmentStatusDelivered}, nil)
+
+		http.HandlerFunc(handlers.Webhook).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("InvalidSignature", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/shipments/webhook", strings.NewReader(payload))
+		req.Header.Set(constants.CourierSignature, "forged")
+		w := httptest.NewRecorder()
+
+		shipmentUC.EXPECT().HandleWebhook(gomock.Any(), []byte(payload), "forged").Return(nil, errors.Wrap(shipment.ErrInvalidSignature, "courier.ParseWebhook"))
+
+		http.HandlerFunc(handlers.Webhook).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
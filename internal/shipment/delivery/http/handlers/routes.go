@@ -0,0 +1,11 @@
+package handlers
+
+func (h *shipmentHandlersHTTP) ShipmentMapRoutes() {
+	orderShipments := h.router.Group("/orders/{id}/shipments", h.mw.IsLoggedIn)
+	orderShipments.Post("", h.Create, h.mw.IsAdminOrSeller)
+	orderShipments.Get("", h.FindAllByOrderId)
+	orderShipments.Get("/rates", h.Rates, h.mw.IsAdminOrSeller)
+
+	shipments := h.router.Group("/shipments")
+	shipments.Post("/webhook", h.Webhook)
+}
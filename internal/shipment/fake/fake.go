@@ -0,0 +1,137 @@
+package fake
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/internal/shipment"
+)
+
+const (
+	CourierName = "fake"
+
+	ServiceRegular = "regular"
+	ServiceExpress = "express"
+)
+
+// service rate of a fake courier service, Base plus PerKg per started kilogram
+type service struct {
+	Base          float64
+	PerKg         float64
+	EstimatedDays int
+}
+
+var services = []struct {
+	Name string
+	service
+}{
+	{Name: ServiceRegular, service: service{Base: 9000, PerKg: 2000, EstimatedDays: 3}},
+	{Name: ServiceExpress, service: service{Base: 15000, PerKg: 3500, EstimatedDays: 1}},
+}
+
+// Courier deterministic in-process courier, tracking numbers are sequential and nothing leaves the process
+type Courier struct {
+	secret string
+
+	mu        sync.Mutex
+	seq       int
+	shipments map[string]*shipment.Parcel
+}
+
+var _ shipment.Courier = (*Courier)(nil)
+
+// NewCourier fake courier constructor, webhook events are signed with webhookSecret
+func NewCourier(webhookSecret string) *Courier {
+	return &Courier{secret: webhookSecret, shipments: map[string]*shipment.Parcel{}}
+}
+
+// Name carrier name stored on shipments
+func (c *Courier) Name() string {
+	return CourierName
+}
+
+// Rates price of the parcel with every service, cheapest first
+func (c *Courier) Rates(ctx context.Context, parcel *shipment.Parcel) ([]shipment.Rate, error) {
+	rates := make([]shipment.Rate, 0, len(services))
+	for _, s := range services {
+		rates = append(rates, shipment.Rate{Service: s.Name, Amount: s.amount(parcel.Weight), EstimatedDays: s.EstimatedDays})
+	}
+	return rates, nil
+}
+
+// CreateShipment book the parcel with service and issue its label
+func (c *Courier) CreateShipment(ctx context.Context, parcel *shipment.Parcel, serviceName string) (*shipment.Label, error) {
+	for _, s := range services {
+		if s.Name != serviceName {
+			continue
+		}
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		c.seq++
+		trackingNumber := fmt.Sprintf("FAKE%010d", c.seq)
+		booked := *parcel
+		c.shipments[trackingNumber] = &booked
+
+		return &shipment.Label{
+			TrackingNumber: trackingNumber,
+			LabelURL:       fmt.Sprintf("https://courier.fake/labels/%s.pdf", trackingNumber),
+			Service:        s.Name,
+			Amount:         s.amount(parcel.Weight),
+		}, nil
+	}
+
+	return nil, shipment.ErrUnknownService
+}
+
+// Scan simulate the courier scanning the parcel of trackingNumber, it returns the webhook payload and signature
+// the courier would deliver
+func (c *Courier) Scan(ctx context.Context, trackingNumber string, status string, location string) ([]byte, string, error) {
+	switch status {
+	case models.ShipmentStatusPickedUp, models.ShipmentStatusInTransit, models.ShipmentStatusOutForDelivery,
+		models.ShipmentStatusDelivered, models.ShipmentStatusFailed:
+	default:
+		return nil, "", shipment.ErrUnknownEventStatus
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.shipments[trackingNumber]; !ok {
+		return nil, "", shipment.ErrShipmentNotFound
+	}
+
+	c.seq++
+	event := &shipment.Event{
+		ID:             fmt.Sprintf("evt_fake_%06d", c.seq),
+		TrackingNumber: trackingNumber,
+		Status:         status,
+		Description:    fmt.Sprintf("parcel %s", status),
+		Location:       location,
+		OccurredAt:     time.Now().UTC(),
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, "", err
+	}
+	return payload, shipment.Sign(c.secret, payload), nil
+}
+
+// ParseWebhook verify an event signed by Scan
+func (c *Courier) ParseWebhook(payload []byte, signature string) (*shipment.Event, error) {
+	return shipment.VerifyEvent(c.secret, payload, signature)
+}
+
+func (s service) amount(weight uint64) float64 {
+	kg := (weight + 999) / 1000
+	if kg == 0 {
+		kg = 1
+	}
+	return s.Base + s.PerKg*float64(kg)
+}
@@ -0,0 +1,60 @@
+package fake
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/internal/shipment"
+)
+
+func TestCourier_Flow(t *testing.T) {
+	t.Parallel()
+
+	c := NewCourier("secret")
+	ctx := context.Background()
+	parcel := &shipment.Parcel{OrderID: uuid.New(), From: "Jakarta, ID", To: "Bandung, ID", Weight: 1500}
+
+	rates, err := c.Rates(ctx, parcel)
+	require.NoError(t, err)
+	require.Equal(t, []shipment.Rate{
+		{Service: ServiceRegular, Amount: 13000, EstimatedDays: 3},
+		{Service: ServiceExpress, Amount: 22000, EstimatedDays: 1},
+	}, rates)
+
+	_, err = c.CreateShipment(ctx, parcel, "overnight")
+	require.ErrorIs(t, err, shipment.ErrUnknownService)
+
+	label, err := c.CreateShipment(ctx, parcel, ServiceExpress)
+	require.NoError(t, err)
+	require.Equal(t, "FAKE0000000001", label.TrackingNumber)
+	require.Equal(t, "https://courier.fake/labels/FAKE0000000001.pdf", label.LabelURL)
+	require.Equal(t, 22000.0, label.Amount)
+
+	payload, signature, err := c.Scan(ctx, label.TrackingNumber, models.ShipmentStatusInTransit, "Jakarta hub")
+	require.NoError(t, err)
+
+	event, err := c.ParseWebhook(payload, signature)
+	require.NoError(t, err)
+	require.Equal(t, label.TrackingNumber, event.TrackingNumber)
+	require.Equal(t, models.ShipmentStatusInTransit, event.Status)
+	require.Equal(t, "Jakarta hub", event.Location)
+
+	t.Run("InvalidSignature", func(t *testing.T) {
+		_, err := c.ParseWebhook(payload, shipment.Sign("other", payload))
+		require.ErrorIs(t, err, shipment.ErrInvalidSignature)
+	})
+
+	t.Run("UnknownShipment", func(t *testing.T) {
+		_, _, err := c.Scan(ctx, "FAKE9999999999", models.ShipmentStatusDelivered, "")
+		require.ErrorIs(t, err, shipment.ErrShipmentNotFound)
+	})
+
+	t.Run("UnknownStatus", func(t *testing.T) {
+		_, _, err := c.Scan(ctx, label.TrackingNumber, "lost", "")
+		require.ErrorIs(t, err, shipment.ErrUnknownEventStatus)
+	})
+}
@@ -0,0 +1,13 @@
+package shipment
+
+import (
+	"net/http"
+)
+
+// Shipment HTTP Handlers interface
+type ShipmentHandlers interface {
+	Create(w http.ResponseWriter, r *http.Request)
+	FindAllByOrderId(w http.ResponseWriter, r *http.Request)
+	Rates(w http.ResponseWriter, r *http.Request)
+	Webhook(w http.ResponseWriter, r *http.Request)
+}
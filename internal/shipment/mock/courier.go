@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: courier.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	shipment "github.com/dinorain/kalobranded/internal/shipment"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCourier is a mock of Courier interface.
+type MockCourier struct {
+	ctrl     *gomock.Controller
+	recorder *MockCourierMockRecorder
+}
+
+// MockCourierMockRecorder is the mock recorder for MockCourier.
+type MockCourierMockRecorder struct {
+	mock *MockCourier
+}
+
+// NewMockCourier creates a new mock instance.
+func NewMockCourier(ctrl *gomock.Controller) *MockCourier {
+	mock := &MockCourier{ctrl: ctrl}
+	mock.recorder = &MockCourierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCourier) EXPECT() *MockCourierMockRecorder {
+	return m.recorder
+}
+
+// CreateShipment mocks base method.
+func (m *MockCourier) CreateShipment(ctx context.Context, parcel *shipment.Parcel, service string) (*shipment.Label, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateShipment", ctx, parcel, service)
+	ret0, _ := ret[0].(*shipment.Label)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateShipment indicates an expected call of CreateShipment.
+func (mr *MockCourierMockRecorder) CreateShipment(ctx, parcel, service interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShipment", reflect.TypeOf((*MockCourier)(nil).CreateShipment), ctx, parcel, service)
+}
+
+// Name mocks base method.
+func (m *MockCourier) Name() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Name indicates an expected call of Name.
+func (mr *MockCourierMockRecorder) Name() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockCourier)(nil).Name))
+}
+
+// ParseWebhook mocks base method.
+func (m *MockCourier) ParseWebhook(payload []byte, signature string) (*shipment.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseWebhook", payload, signature)
+	ret0, _ := ret[0].(*shipment.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseWebhook indicates an expected call of ParseWebhook.
+func (mr *MockCourierMockRecorder) ParseWebhook(payload, signature interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseWebhook", reflect.TypeOf((*MockCourier)(nil).ParseWebhook), payload, signature)
+}
+
+// Rates mocks base method.
+func (m *MockCourier) Rates(ctx context.Context, parcel *shipment.Parcel) ([]shipment.Rate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rates", ctx, parcel)
+	ret0, _ := ret[0].([]shipment.Rate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rates indicates an expected call of Rates.
+func (mr *MockCourierMockRecorder) Rates(ctx, parcel interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rates", reflect.TypeOf((*MockCourier)(nil).Rates), ctx, parcel)
+}
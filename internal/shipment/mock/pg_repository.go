@@ -0,0 +1,97 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pg_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/dinorain/kalobranded/internal/models"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockShipmentPGRepository is a mock of ShipmentPGRepository interface.
+type MockShipmentPGRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockShipmentPGRepositoryMockRecorder
+}
+
+// MockShipmentPGRepositoryMockRecorder is the mock recorder for MockShipmentPGRepository.
+type MockShipmentPGRepositoryMockRecorder struct {
+	mock *MockShipmentPGRepository
+}
+
+// NewMockShipmentPGRepository creates a new mock instance.
+func NewMockShipmentPGRepository(ctrl *gomock.Controller) *MockShipmentPGRepository {
+	mock := &MockShipmentPGRepository{ctrl: ctrl}
+	mock.recorder = &MockShipmentPGRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShipmentPGRepository) EXPECT() *MockShipmentPGRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockShipmentPGRepository) Create(ctx context.Context, shipment *models.Shipment) (*models.Shipment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, shipment)
+	ret0, _ := ret[0].(*models.Shipment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockShipmentPGRepositoryMockRecorder) Create(ctx, shipment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockShipmentPGRepository)(nil).Create), ctx, shipment)
+}
+
+// FindAllByOrderId mocks base method.
+func (m *MockShipmentPGRepository) FindAllByOrderId(ctx context.Context, orderID uuid.UUID) ([]models.Shipment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByOrderId", ctx, orderID)
+	ret0, _ := ret[0].([]models.Shipment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByOrderId indicates an expected call of FindAllByOrderId.
+func (mr *MockShipmentPGRepositoryMockRecorder) FindAllByOrderId(ctx, orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByOrderId", reflect.TypeOf((*MockShipmentPGRepository)(nil).FindAllByOrderId), ctx, orderID)
+}
+
+// FindByTrackingNumber mocks base method.
+func (m *MockShipmentPGRepository) FindByTrackingNumber(ctx context.Context, carrier, trackingNumber string) (*models.Shipment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByTrackingNumber", ctx, carrier, trackingNumber)
+	ret0, _ := ret[0].(*models.Shipment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByTrackingNumber indicates an expected call of FindByTrackingNumber.
+func (mr *MockShipmentPGRepositoryMockRecorder) FindByTrackingNumber(ctx, carrier, trackingNumber interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByTrackingNumber", reflect.TypeOf((*MockShipmentPGRepository)(nil).FindByTrackingNumber), ctx, carrier, trackingNumber)
+}
+
+// UpdateById mocks base method.
+func (m *MockShipmentPGRepository) UpdateById(ctx context.Context, shipment *models.Shipment) (*models.Shipment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateById", ctx, shipment)
+	ret0, _ := ret[0].(*models.Shipment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateById indicates an expected call of UpdateById.
+func (mr *MockShipmentPGRepositoryMockRecorder) UpdateById(ctx, shipment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateById", reflect.TypeOf((*MockShipmentPGRepository)(nil).UpdateById), ctx, shipment)
+}
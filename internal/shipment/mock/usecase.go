@@ -0,0 +1,98 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/dinorain/kalobranded/internal/models"
+	shipment "github.com/dinorain/kalobranded/internal/shipment"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockShipmentUseCase is a mock of ShipmentUseCase interface.
+type MockShipmentUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockShipmentUseCaseMockRecorder
+}
+
+// MockShipmentUseCaseMockRecorder is the mock recorder for MockShipmentUseCase.
+type MockShipmentUseCaseMockRecorder struct {
+	mock *MockShipmentUseCase
+}
+
+// NewMockShipmentUseCase creates a new mock instance.
+func NewMockShipmentUseCase(ctrl *gomock.Controller) *MockShipmentUseCase {
+	mock := &MockShipmentUseCase{ctrl: ctrl}
+	mock.recorder = &MockShipmentUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShipmentUseCase) EXPECT() *MockShipmentUseCaseMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockShipmentUseCase) Create(ctx context.Context, order *models.Order, service string) (*models.Shipment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, order, service)
+	ret0, _ := ret[0].(*models.Shipment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockShipmentUseCaseMockRecorder) Create(ctx, order, service interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockShipmentUseCase)(nil).Create), ctx, order, service)
+}
+
+// FindAllByOrderId mocks base method.
+func (m *MockShipmentUseCase) FindAllByOrderId(ctx context.Context, orderID uuid.UUID) ([]models.Shipment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByOrderId", ctx, orderID)
+	ret0, _ := ret[0].([]models.Shipment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByOrderId indicates an expected call of FindAllByOrderId.
+func (mr *MockShipmentUseCaseMockRecorder) FindAllByOrderId(ctx, orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByOrderId", reflect.TypeOf((*MockShipmentUseCase)(nil).FindAllByOrderId), ctx, orderID)
+}
+
+// HandleWebhook mocks base method.
+func (m *MockShipmentUseCase) HandleWebhook(ctx context.Context, payload []byte, signature string) (*models.Shipment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleWebhook", ctx, payload, signature)
+	ret0, _ := ret[0].(*models.Shipment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleWebhook indicates an expected call of HandleWebhook.
+func (mr *MockShipmentUseCaseMockRecorder) HandleWebhook(ctx, payload, signature interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleWebhook", reflect.TypeOf((*MockShipmentUseCase)(nil).HandleWebhook), ctx, payload, signature)
+}
+
+// Rates mocks base method.
+func (m *MockShipmentUseCase) Rates(ctx context.Context, order *models.Order) ([]shipment.Rate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rates", ctx, order)
+	ret0, _ := ret[0].([]shipment.Rate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rates indicates an expected call of Rates.
+func (mr *MockShipmentUseCaseMockRecorder) Rates(ctx, order interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rates", reflect.TypeOf((*MockShipmentUseCase)(nil).Rates), ctx, order)
+}
@@ -0,0 +1,18 @@
+//go:generate mockgen -source pg_repository.go -destination mock/pg_repository.go -package mock
+package shipment
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/internal/models"
+)
+
+// Shipment pg repository
+type ShipmentPGRepository interface {
+	Create(ctx context.Context, shipment *models.Shipment) (*models.Shipment, error)
+	FindByTrackingNumber(ctx context.Context, carrier string, trackingNumber string) (*models.Shipment, error)
+	FindAllByOrderId(ctx context.Context, orderID uuid.UUID) ([]models.Shipment, error)
+	UpdateById(ctx context.Context, shipment *models.Shipment) (*models.Shipment, error)
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/internal/shipment"
+)
+
+// Shipment repository
+type ShipmentRepository struct {
+	db *sqlx.DB
+}
+
+var _ shipment.ShipmentPGRepository = (*ShipmentRepository)(nil)
+
+// Shipment repository constructor
+func NewShipmentPGRepository(db *sqlx.DB) *ShipmentRepository {
+	return &ShipmentRepository{db: db}
+}
+
+// Create new shipment
+func (r *ShipmentRepository) Create(ctx context.Context, shipment *models.Shipment) (*models.Shipment, error) {
+	createdShipment := &models.Shipment{}
+	if err := r.db.QueryRowxContext(
+		ctx,
+		createShipmentQuery,
+		shipment.OrderID,
+		shipment.Carrier,
+		shipment.Service,
+		shipment.TrackingNumber,
+		shipment.LabelURL,
+		shipment.Rate,
+		shipment.Status,
+		shipment.Events,
+	).StructScan(createdShipment); err != nil {
+		return nil, errors.Wrap(err, "ShipmentRepository.Create.QueryRowxContext")
+	}
+
+	return createdShipment, nil
+}
+
+// FindByTrackingNumber Find shipment by carrier name and tracking number
+func (r *ShipmentRepository) FindByTrackingNumber(ctx context.Context, carrier string, trackingNumber string) (*models.Shipment, error) {
+	shipment := &models.Shipment{}
+	if err := r.db.GetContext(ctx, shipment, findByTrackingNumberQuery, carrier, trackingNumber); err != nil {
+		return nil, errors.Wrap(err, "ShipmentRepository.FindByTrackingNumber.GetContext")
+	}
+
+	return shipment, nil
+}
+
+// FindAllByOrderId Find shipments of order uuid, oldest first
+func (r *ShipmentRepository) FindAllByOrderId(ctx context.Context, orderID uuid.UUID) ([]models.Shipment, error) {
+	var shipments []models.Shipment
+	if err := r.db.SelectContext(ctx, &shipments, findAllByOrderIdQuery, orderID); err != nil {
+		return nil, errors.Wrap(err, "ShipmentRepository.FindAllByOrderId.SelectContext")
+	}
+
+	return shipments, nil
+}
+
+// UpdateById update shipment status and tracking events, only at the version it was read with
+func (r *ShipmentRepository) UpdateById(ctx context.Context, shipment *models.Shipment) (*models.Shipment, error) {
+	updatedShipment := &models.Shipment{}
+	if err := r.db.QueryRowxContext(
+		ctx,
+		updateByIdQuery,
+		shipment.ShipmentID,
+		shipment.Status,
+		shipment.Events,
+		shipment.Version,
+	).StructScan(updatedShipment); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, models.ErrVersionConflict
+		}
+		return nil, errors.Wrap(err, "ShipmentRepository.UpdateById.QueryRowxContext")
+	}
+
+	return updatedShipment, nil
+}
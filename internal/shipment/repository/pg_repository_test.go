@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/kalobranded/internal/models"
+)
+
+var shipmentColumns = []string{"shipment_id", "order_id", "carrier", "service", "tracking_number", "label_url", "rate", "status", "events", "version", "created_at", "updated_at"}
+
+func TestShipmentRepository_Create(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	shipmentPGRepository := NewShipmentPGRepository(sqlxDB)
+
+	shipmentUUID := uuid.New()
+	mockShipment := &models.Shipment{
+		OrderID:        uuid.New(),
+		Carrier:        "fake",
+		Service:        "regular",
+		TrackingNumber: "FAKE0000000001",
+		LabelURL:       "https://courier.fake/labels/FAKE0000000001.pdf",
+		Rate:           11000,
+		Status:         models.ShipmentStatusLabelCreated,
+	}
+
+	rows := sqlmock.NewRows(shipmentColumns).AddRow(
+		shipmentUUID,
+		mockShipment.OrderID,
+		mockShipment.Carrier,
+		mockShipment.Service,
+		mockShipment.TrackingNumber,
+		mockShipment.LabelURL,
+		mockShipment.Rate,
+		mockShipment.Status,
+		[]byte("[]"),
+		1,
+		time.Now(),
+		time.Now(),
+	)
+
+	mock.ExpectQuery(createShipmentQuery).WithArgs(
+		mockShipment.OrderID,
+		mockShipment.Carrier,
+		mockShipment.Service,
+		mockShipment.TrackingNumber,
+		mockShipment.LabelURL,
+		mockShipment.Rate,
+		mockShipment.Status,
+		[]byte("[]"),
+	).WillReturnRows(rows)
+
+	createdShipment, err := shipmentPGRepository.Create(context.Background(), mockShipment)
+	require.NoError(t, err)
+	require.Equal(t, shipmentUUID, createdShipment.ShipmentID)
+	require.Equal(t, mockShipment.TrackingNumber, createdShipment.TrackingNumber)
+	require.Empty(t, createdShipment.Events)
+}
+
+func TestShipmentRepository_UpdateById(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	shipmentPGRepository := NewShipmentPGRepository(sqlxDB)
+
+	mockShipment := &models.Shipment{
+		ShipmentID:     uuid.New(),
+		OrderID:        uuid.New(),
+		Carrier:        "fake",
+		Service:        "regular",
+		TrackingNumber: "FAKE0000000001",
+		Status:         models.ShipmentStatusInTransit,
+		Events: models.TrackingEvents{
+			{ID: "evt_fake_000002", Status: models.ShipmentStatusInTransit, Location: "Jakarta hub", OccurredAt: time.Now().UTC()},
+		},
+		Version: 1,
+	}
+
+	eventsJson, _ := json.Marshal(mockShipment.Events)
+
+	rows := sqlmock.NewRows(shipmentColumns).AddRow(
+		mockShipment.ShipmentID,
+		mockShipment.OrderID,
+		mockShipment.Carrier,
+		mockShipment.Service,
+		mockShipment.TrackingNumber,
+		"",
+		0,
+		mockShipment.Status,
+		eventsJson,
+		2,
+		time.Now(),
+		time.Now(),
+	)
+
+	mock.ExpectQuery(updateByIdQuery).WithArgs(
+		mockShipment.ShipmentID,
+		mockShipment.Status,
+		eventsJson,
+		mockShipment.Version,
+	).WillReturnRows(rows)
+
+	updatedShipment, err := shipmentPGRepository.UpdateById(context.Background(), mockShipment)
+	require.NoError(t, err)
+	require.Equal(t, 2, updatedShipment.Version)
+	require.Len(t, updatedShipment.Events, 1)
+	require.Equal(t, "Jakarta hub", updatedShipment.Events[0].Location)
+
+	t.Run("VersionConflict", func(t *testing.T) {
+		mock.ExpectQuery(updateByIdQuery).WithArgs(
+			mockShipment.ShipmentID,
+			mockShipment.Status,
+			eventsJson,
+			mockShipment.Version,
+		).WillReturnRows(sqlmock.NewRows(shipmentColumns))
+
+		_, err := shipmentPGRepository.UpdateById(context.Background(), mockShipment)
+		require.ErrorIs(t, err, models.ErrVersionConflict)
+	})
+}
@@ -0,0 +1,14 @@
+package repository
+
+const (
+	createShipmentQuery = `INSERT INTO shipments (order_id, carrier, service, tracking_number, label_url, rate, status, events) 
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING shipment_id, order_id, carrier, service, tracking_number, label_url, rate, status, events, version, created_at, updated_at`
+
+	findByTrackingNumberQuery = `SELECT shipment_id, order_id, carrier, service, tracking_number, label_url, rate, status, events, version, created_at, updated_at FROM shipments WHERE carrier = $1 AND tracking_number = $2`
+
+	findAllByOrderIdQuery = `SELECT shipment_id, order_id, carrier, service, tracking_number, label_url, rate, status, events, version, created_at, updated_at FROM shipments WHERE order_id = $1 ORDER BY created_at`
+
+	updateByIdQuery = `UPDATE shipments SET status = $2, events = $3, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE shipment_id = $1 AND version = $4
+		RETURNING shipment_id, order_id, carrier, service, tracking_number, label_url, rate, status, events, version, created_at, updated_at`
+)
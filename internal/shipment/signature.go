@@ -0,0 +1,28 @@
+package shipment
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+)
+
+// Sign hex encoded HMAC-SHA256 of payload with the webhook secret
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyEvent check payload signature in constant time and decode the event
+func VerifyEvent(secret string, payload []byte, signature string) (*Event, error) {
+	if secret == "" || !hmac.Equal([]byte(Sign(secret, payload)), []byte(signature)) {
+		return nil, ErrInvalidSignature
+	}
+
+	event := &Event{}
+	if err := json.Unmarshal(payload, event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
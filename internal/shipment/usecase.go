@@ -0,0 +1,18 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase.go -package mock
+package shipment
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/internal/models"
+)
+
+// Shipment UseCase interface
+type ShipmentUseCase interface {
+	Rates(ctx context.Context, order *models.Order) ([]Rate, error)
+	Create(ctx context.Context, order *models.Order, service string) (*models.Shipment, error)
+	FindAllByOrderId(ctx context.Context, orderID uuid.UUID) ([]models.Shipment, error)
+	HandleWebhook(ctx context.Context, payload []byte, signature string) (*models.Shipment, error)
+}
@@ -0,0 +1,170 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/internal/order"
+	"github.com/dinorain/kalobranded/internal/shipment"
+	"github.com/dinorain/kalobranded/pkg/logger"
+)
+
+// Shipment UseCase
+type shipmentUseCase struct {
+	cfg            *config.Config
+	logger         logger.Logger
+	shipmentPgRepo shipment.ShipmentPGRepository
+	orderUC        order.OrderUseCase
+	courier        shipment.Courier
+}
+
+var _ shipment.ShipmentUseCase = (*shipmentUseCase)(nil)
+
+// New Shipment UseCase
+func NewShipmentUseCase(
+	cfg *config.Config,
+	logger logger.Logger,
+	shipmentRepo shipment.ShipmentPGRepository,
+	orderUC order.OrderUseCase,
+	courier shipment.Courier,
+) *shipmentUseCase {
+	return &shipmentUseCase{cfg: cfg, logger: logger, shipmentPgRepo: shipmentRepo, orderUC: orderUC, courier: courier}
+}
+
+// Rates courier rates of shipping the order
+func (u *shipmentUseCase) Rates(ctx context.Context, order *models.Order) ([]shipment.Rate, error) {
+	rates, err := u.courier.Rates(ctx, parcelOf(order))
+	if err != nil {
+		return nil, errors.Wrap(err, "courier.Rates")
+	}
+
+	return rates, nil
+}
+
+// Create book a shipment of an accepted order with the courier service, an order has one open shipment at a time
+func (u *shipmentUseCase) Create(ctx context.Context, order *models.Order, service string) (*models.Shipment, error) {
+	if order.Status != models.OrderStatusAccepted {
+		return nil, errors.Wrapf(models.ErrNotShippable, "order is %s", order.Status)
+	}
+
+	shipments, err := u.shipmentPgRepo.FindAllByOrderId(ctx, order.OrderID)
+	if err != nil {
+		return nil, errors.Wrap(err, "shipmentPgRepo.FindAllByOrderId")
+	}
+	for i := range shipments {
+		if shipments[i].IsOpen() {
+			return nil, errors.Wrapf(models.ErrShipmentExists, "tracking number %s", shipments[i].TrackingNumber)
+		}
+	}
+
+	label, err := u.courier.CreateShipment(ctx, parcelOf(order), service)
+	if err != nil {
+		return nil, errors.Wrap(err, "courier.CreateShipment")
+	}
+
+	createdShipment, err := u.shipmentPgRepo.Create(ctx, &models.Shipment{
+		OrderID:        order.OrderID,
+		Carrier:        u.courier.Name(),
+		Service:        label.Service,
+		TrackingNumber: label.TrackingNumber,
+		LabelURL:       label.LabelURL,
+		Rate:           label.Amount,
+		Status:         models.ShipmentStatusLabelCreated,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "shipmentPgRepo.Create")
+	}
+
+	return createdShipment, nil
+}
+
+// FindAllByOrderId find shipments of order
+func (u *shipmentUseCase) FindAllByOrderId(ctx context.Context, orderID uuid.UUID) ([]models.Shipment, error) {
+	shipments, err := u.shipmentPgRepo.FindAllByOrderId(ctx, orderID)
+	if err != nil {
+		return nil, errors.Wrap(err, "shipmentPgRepo.FindAllByOrderId")
+	}
+
+	return shipments, nil
+}
+
+// HandleWebhook verify and record a courier tracking event, then move the order along with its shipment.
+// Redelivered events are recorded once, and events arriving late never move the shipment back
+func (u *shipmentUseCase) HandleWebhook(ctx context.Context, payload []byte, signature string) (*models.Shipment, error) {
+	event, err := u.courier.ParseWebhook(payload, signature)
+	if err != nil {
+		return nil, errors.Wrap(err, "courier.ParseWebhook")
+	}
+
+	foundShipment, err := u.shipmentPgRepo.FindByTrackingNumber(ctx, u.courier.Name(), event.TrackingNumber)
+	if err != nil {
+		return nil, errors.Wrap(err, "shipmentPgRepo.FindByTrackingNumber")
+	}
+
+	if !foundShipment.HasEvent(event.ID) {
+		foundShipment.Events = append(foundShipment.Events, models.TrackingEvent{
+			ID:          event.ID,
+			Status:      event.Status,
+			Description: event.Description,
+			Location:    event.Location,
+			OccurredAt:  event.OccurredAt,
+		})
+		sort.SliceStable(foundShipment.Events, func(i, j int) bool {
+			return foundShipment.Events[i].OccurredAt.Before(foundShipment.Events[j].OccurredAt)
+		})
+		if foundShipment.CanAdvanceTo(event.Status) {
+			foundShipment.Status = event.Status
+		} else {
+			u.logger.Infof("shipment %s event %s at %s kept at %s", foundShipment.ShipmentID, event.ID, event.Status, foundShipment.Status)
+		}
+
+		updatedShipment, err := u.shipmentPgRepo.UpdateById(ctx, foundShipment)
+		if err != nil {
+			return nil, errors.Wrap(err, "shipmentPgRepo.UpdateById")
+		}
+		foundShipment = updatedShipment
+	}
+
+	if err := u.advanceOrder(ctx, foundShipment); err != nil {
+		return nil, err
+	}
+
+	return foundShipment, nil
+}
+
+// advanceOrder move the order of s to the status its shipment reached, skipped when a previous delivery already did it
+func (u *shipmentUseCase) advanceOrder(ctx context.Context, s *models.Shipment) error {
+	status := models.ShipmentOrderStatus(s.Status)
+	if status == "" {
+		return nil
+	}
+
+	foundOrder, err := u.orderUC.FindById(ctx, s.OrderID)
+	if err != nil {
+		return errors.Wrap(err, "orderUC.FindById")
+	}
+	if !foundOrder.CanTransitionTo(status) {
+		return nil
+	}
+
+	foundOrder.Status = status
+	if _, err := u.orderUC.UpdateById(ctx, foundOrder); err != nil {
+		return errors.Wrap(err, "orderUC.UpdateById")
+	}
+
+	return nil
+}
+
+func parcelOf(order *models.Order) *shipment.Parcel {
+	return &shipment.Parcel{
+		OrderID: order.OrderID,
+		From:    order.DeliverySourceAddress,
+		To:      order.DeliveryDestinationAddress,
+		Weight:  order.Item.Weight * order.Quantity,
+	}
+}
@@ -0,0 +1,179 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/models"
+	orderMock "github.com/dinorain/kalobranded/internal/order/mock"
+	"github.com/dinorain/kalobranded/internal/shipment"
+	"github.com/dinorain/kalobranded/internal/shipment/fake"
+	"github.com/dinorain/kalobranded/internal/shipment/mock"
+	"github.com/dinorain/kalobranded/pkg/logger"
+)
+
+func TestShipmentUseCase_Create(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	shipmentPGRepository := mock.NewMockShipmentPGRepository(ctrl)
+	orderUC := orderMock.NewMockOrderUseCase(ctrl)
+	apiLogger := logger.NewAppLogger(nil)
+
+	cfg := &config.Config{Courier: config.Courier{WebhookSecret: "secret"}}
+	shipmentUC := NewShipmentUseCase(cfg, apiLogger, shipmentPGRepository, orderUC, fake.NewCourier("secret"))
+
+	mockOrder := &models.Order{
+		OrderID:                    uuid.New(),
+		Item:                       models.OrderItem{Weight: 400},
+		Quantity:                   2,
+		Status:                     models.OrderStatusAccepted,
+		DeliverySourceAddress:      "Jakarta, ID",
+		DeliveryDestinationAddress: "Bandung, ID",
+	}
+
+	ctx := context.Background()
+
+	shipmentPGRepository.EXPECT().FindAllByOrderId(gomock.Any(), mockOrder.OrderID).Return([]models.Shipment{
+		{OrderID: mockOrder.OrderID, TrackingNumber: "FAKE0000000000", Status: models.ShipmentStatusFailed},
+	}, nil)
+	shipmentPGRepository.EXPECT().Create(gomock.Any(), &models.Shipment{
+		OrderID:        mockOrder.OrderID,
+		Carrier:        fake.CourierName,
+		Service:        fake.ServiceRegular,
+		TrackingNumber: "FAKE0000000001",
+		LabelURL:       "https://courier.fake/labels/FAKE0000000001.pdf",
+		Rate:           11000,
+		Status:         models.ShipmentStatusLabelCreated,
+	}).DoAndReturn(func(ctx context.Context, s *models.Shipment) (*models.Shipment, error) {
+		createdShipment := *s
+		createdShipment.ShipmentID = uuid.New()
+		createdShipment.Version = 1
+		return &createdShipment, nil
+	})
+
+	createdShipment, err := shipmentUC.Create(ctx, mockOrder, fake.ServiceRegular)
+	require.NoError(t, err)
+	require.Equal(t, "FAKE0000000001", createdShipment.TrackingNumber)
+
+	t.Run("OpenShipment", func(t *testing.T) {
+		shipmentPGRepository.EXPECT().FindAllByOrderId(gomock.Any(), mockOrder.OrderID).Return([]models.Shipment{*createdShipment}, nil)
+
+		_, err := shipmentUC.Create(ctx, mockOrder, fake.ServiceRegular)
+		require.ErrorIs(t, err, models.ErrShipmentExists)
+	})
+
+	t.Run("NotAccepted", func(t *testing.T) {
+		paidOrder := *mockOrder
+		paidOrder.Status = models.OrderStatusPaid
+
+		_, err := shipmentUC.Create(ctx, &paidOrder, fake.ServiceRegular)
+		require.ErrorIs(t, err, models.ErrNotShippable)
+	})
+
+	t.Run("UnknownService", func(t *testing.T) {
+		shipmentPGRepository.EXPECT().FindAllByOrderId(gomock.Any(), mockOrder.OrderID).Return(nil, nil)
+
+		_, err := shipmentUC.Create(ctx, mockOrder, "overnight")
+		require.ErrorIs(t, err, shipment.ErrUnknownService)
+	})
+}
+
+func TestShipmentUseCase_HandleWebhook(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	shipmentPGRepository := mock.NewMockShipmentPGRepository(ctrl)
+	orderUC := orderMock.NewMockOrderUseCase(ctrl)
+	apiLogger := logger.NewAppLogger(nil)
+	courier := fake.NewCourier("secret")
+
+	cfg := &config.Config{Courier: config.Courier{WebhookSecret: "secret"}}
+	shipmentUC := NewShipmentUseCase(cfg, apiLogger, shipmentPGRepository, orderUC, courier)
+
+	ctx := context.Background()
+	mockOrder := &models.Order{OrderID: uuid.New(), Quantity: 1, Status: models.OrderStatusAccepted, Version: 3}
+
+	label, err := courier.CreateShipment(ctx, &shipment.Parcel{OrderID: mockOrder.OrderID}, fake.ServiceRegular)
+	require.NoError(t, err)
+
+	mockShipment := &models.Shipment{
+		ShipmentID:     uuid.New(),
+		OrderID:        mockOrder.OrderID,
+		Carrier:        fake.CourierName,
+		TrackingNumber: label.TrackingNumber,
+		Status:         models.ShipmentStatusLabelCreated,
+		Events:         models.TrackingEvents{},
+		Version:        1,
+	}
+
+	payload, signature, err := courier.Scan(ctx, label.TrackingNumber, models.ShipmentStatusPickedUp, "Jakarta")
+	require.NoError(t, err)
+
+	shipmentPGRepository.EXPECT().FindByTrackingNumber(gomock.Any(), fake.CourierName, label.TrackingNumber).Return(mockShipment, nil)
+	shipmentPGRepository.EXPECT().UpdateById(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, s *models.Shipment) (*models.Shipment, error) {
+		updatedShipment := *s
+		updatedShipment.Version++
+		return &updatedShipment, nil
+	})
+	orderUC.EXPECT().FindById(gomock.Any(), mockOrder.OrderID).Return(mockOrder, nil)
+	orderUC.EXPECT().UpdateById(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, o *models.Order) (*models.Order, error) {
+		require.Equal(t, models.OrderStatusShipped, o.Status)
+		return o, nil
+	})
+
+	pickedUpShipment, err := shipmentUC.HandleWebhook(ctx, payload, signature)
+	require.NoError(t, err)
+	require.Equal(t, models.ShipmentStatusPickedUp, pickedUpShipment.Status)
+	require.Len(t, pickedUpShipment.Events, 1)
+	require.Equal(t, 2, pickedUpShipment.Version)
+
+	t.Run("Redelivered", func(t *testing.T) {
+		shippedOrder := *mockOrder
+		shippedOrder.Status = models.OrderStatusShipped
+
+		shipmentPGRepository.EXPECT().FindByTrackingNumber(gomock.Any(), fake.CourierName, label.TrackingNumber).Return(pickedUpShipment, nil)
+		orderUC.EXPECT().FindById(gomock.Any(), mockOrder.OrderID).Return(&shippedOrder, nil)
+
+		redeliveredShipment, err := shipmentUC.HandleWebhook(ctx, payload, signature)
+		require.NoError(t, err)
+		require.Len(t, redeliveredShipment.Events, 1)
+	})
+
+	t.Run("Delivered", func(t *testing.T) {
+		shippedOrder := *mockOrder
+		shippedOrder.Status = models.OrderStatusShipped
+
+		payload, signature, err := courier.Scan(ctx, label.TrackingNumber, models.ShipmentStatusDelivered, "Bandung")
+		require.NoError(t, err)
+
+		shipmentPGRepository.EXPECT().FindByTrackingNumber(gomock.Any(), fake.CourierName, label.TrackingNumber).Return(pickedUpShipment, nil)
+		shipmentPGRepository.EXPECT().UpdateById(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, s *models.Shipment) (*models.Shipment, error) {
+			return s, nil
+		})
+		orderUC.EXPECT().FindById(gomock.Any(), mockOrder.OrderID).Return(&shippedOrder, nil)
+		orderUC.EXPECT().UpdateById(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, o *models.Order) (*models.Order, error) {
+			require.Equal(t, models.OrderStatusDelivered, o.Status)
+			return o, nil
+		})
+
+		deliveredShipment, err := shipmentUC.HandleWebhook(ctx, payload, signature)
+		require.NoError(t, err)
+		require.Equal(t, models.ShipmentStatusDelivered, deliveredShipment.Status)
+		require.Len(t, deliveredShipment.Events, 2)
+	})
+
+	t.Run("InvalidSignature", func(t *testing.T) {
+		_, err := shipmentUC.HandleWebhook(ctx, payload, shipment.Sign("other", payload))
+		require.ErrorIs(t, err, shipment.ErrInvalidSignature)
+	})
+}
@@ -0,0 +1,17 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+)
+
+type TaxRateCreateRequestDto struct {
+	Region           string             `json:"region" validate:"required,lte=32"`
+	Name             string             `json:"name" validate:"required,lte=100"`
+	Rate             float64            `json:"rate" validate:"gte=0,lte=100"`
+	PricesIncludeTax bool               `json:"prices_include_tax"`
+	CategoryRates    map[string]float64 `json:"category_rates" validate:"omitempty,dive,keys,required,lte=64,endkeys,gte=0,lte=100"`
+}
+
+type TaxRateCreateResponseDto struct {
+	TaxRateID uuid.UUID `json:"tax_rate_id" validate:"required"`
+}
@@ -0,0 +1,8 @@
+package dto
+
+import "github.com/dinorain/kalobranded/pkg/utils"
+
+type TaxRateFindResponseDto struct {
+	Meta utils.PaginationMetaDto `json:"meta"`
+	Data []*TaxRateResponseDto   `json:"data"`
+}
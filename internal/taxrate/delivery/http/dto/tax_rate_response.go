@@ -0,0 +1,35 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/internal/models"
+)
+
+type TaxRateResponseDto struct {
+	TaxRateID        uuid.UUID               `json:"tax_rate_id"`
+	Region           string                  `json:"region"`
+	Name             string                  `json:"name"`
+	Rate             float64                 `json:"rate"`
+	PricesIncludeTax bool                    `json:"prices_include_tax"`
+	CategoryRates    models.TaxCategoryRates `json:"category_rates"`
+	Version          int                     `json:"version"`
+	CreatedAt        time.Time               `json:"created_at"`
+	UpdatedAt        time.Time               `json:"updated_at"`
+}
+
+func TaxRateResponseFromModel(taxRate *models.TaxRate) *TaxRateResponseDto {
+	return &TaxRateResponseDto{
+		TaxRateID:        taxRate.TaxRateID,
+		Region:           taxRate.Region,
+		Name:             taxRate.Name,
+		Rate:             taxRate.Rate,
+		PricesIncludeTax: taxRate.PricesIncludeTax,
+		CategoryRates:    taxRate.CategoryRates,
+		Version:          taxRate.Version,
+		CreatedAt:        taxRate.CreatedAt,
+		UpdatedAt:        taxRate.UpdatedAt,
+	}
+}
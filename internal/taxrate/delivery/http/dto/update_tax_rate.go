@@ -0,0 +1,8 @@
+package dto
+
+type TaxRateUpdateRequestDto struct {
+	Name             *string            `json:"name" validate:"omitempty,lte=100"`
+	Rate             *float64           `json:"rate" validate:"omitempty,gte=0,lte=100"`
+	PricesIncludeTax *bool              `json:"prices_include_tax"`
+	CategoryRates    map[string]float64 `json:"category_rates" validate:"omitempty,dive,keys,required,lte=64,endkeys,gte=0,lte=100"`
+}
@@ -0,0 +1,277 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/middlewares"
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/internal/server/router"
+	"github.com/dinorain/kalobranded/internal/taxrate"
+	"github.com/dinorain/kalobranded/internal/taxrate/delivery/http/dto"
+	"github.com/dinorain/kalobranded/pkg/constants"
+	httpErrors "github.com/dinorain/kalobranded/pkg/http_errors"
+	"github.com/dinorain/kalobranded/pkg/logger"
+	"github.com/dinorain/kalobranded/pkg/utils"
+)
+
+type taxRateHandlersHTTP struct {
+	router    *router.Router
+	logger    logger.Logger
+	cfg       *config.Config
+	mw        middlewares.MiddlewareManager
+	v         *validator.Validate
+	taxRateUC taxrate.TaxRateUseCase
+}
+
+var _ taxrate.TaxRateHandlers = (*taxRateHandlersHTTP)(nil)
+
+func NewTaxRateHandlersHTTP(
+	router *router.Router,
+	logger logger.Logger,
+	cfg *config.Config,
+	mw middlewares.MiddlewareManager,
+	v *validator.Validate,
+	taxRateUC taxrate.TaxRateUseCase,
+) *taxRateHandlersHTTP {
+	return &taxRateHandlersHTTP{router: router, logger: logger, cfg: cfg, mw: mw, v: v, taxRateUC: taxRateUC}
+}
+
+// Create
+// @Tags TaxRates
+// @Summary Create tax rate
+// @Description Admin create the tax rate table of a region, orders delivered to addresses ending with the region code are taxed with it
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param payload body dto.TaxRateCreateRequestDto true "Payload"
+// @Success 201 {object} dto.TaxRateCreateResponseDto
+// @Router /tax-rates [post]
+func (h *taxRateHandlersHTTP) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	createDto := &dto.TaxRateCreateRequestDto{}
+	if err := json.NewDecoder(r.Body).Decode(createDto); err != nil {
+		h.logger.Errorf("decoder.Decode: %v", err)
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	if err := h.v.Struct(createDto); err != nil {
+		h.logger.Errorf("h.v.Struct: %v", err)
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	taxRate := &models.TaxRate{
+		Region:           createDto.Region,
+		Name:             createDto.Name,
+		Rate:             createDto.Rate,
+		PricesIncludeTax: createDto.PricesIncludeTax,
+		CategoryRates:    createDto.CategoryRates,
+	}
+	if err := taxRate.PrepareCreate(); err != nil {
+		h.logger.Errorf("taxRate.PrepareCreate: %v", err)
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	createdTaxRate, err := h.taxRateUC.Create(ctx, taxRate)
+	if err != nil {
+		h.logger.Errorf("taxRateUC.Create: %v", err)
+		if errors.Is(err, models.ErrTaxRateExists) {
+			_ = httpErrors.NewConflictError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+			return
+		}
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	res, _ := json.Marshal(dto.TaxRateCreateResponseDto{TaxRateID: createdTaxRate.TaxRateID})
+	w.WriteHeader(http.StatusCreated)
+	w.Write(res)
+	return
+}
+
+// FindAll
+// @Tags TaxRates
+// @Summary Find all tax rates
+// @Description Admin find all tax rates ordered by region
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param size query string false "pagination size"
+// @Param page query string false "pagination page"
+// @Success 200 {object} dto.TaxRateFindResponseDto
+// @Router /tax-rates [get]
+func (h *taxRateHandlersHTTP) FindAll(w http.ResponseWriter, r *http.Request) {
+	queryParam := r.URL.Query()
+	pq := utils.NewPaginationFromQueryParams(queryParam.Get(constants.Size), queryParam.Get(constants.Page))
+
+	taxRates, err := h.taxRateUC.FindAll(r.Context(), pq)
+	if err != nil {
+		h.logger.Errorf("taxRateUC.FindAll: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	resDto := dto.TaxRateFindResponseDto{
+		Data: make([]*dto.TaxRateResponseDto, 0, len(taxRates)),
+		Meta: utils.PaginationMetaDto{
+			Limit:  pq.GetLimit(),
+			Offset: pq.GetOffset(),
+			Page:   pq.GetPage(),
+		},
+	}
+	for i := range taxRates {
+		resDto.Data = append(resDto.Data, dto.TaxRateResponseFromModel(&taxRates[i]))
+	}
+
+	res, _ := json.Marshal(resDto)
+	w.WriteHeader(http.StatusOK)
+	w.Write(res)
+	return
+}
+
+// FindById
+// @Tags TaxRates
+// @Summary Find tax rate by id
+// @Description Admin find tax rate by id
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "tax rate uuid"
+// @Success 200 {object} dto.TaxRateResponseDto
+// @Header 200 {string} ETag "resource version"
+// @Router /tax-rates/{id} [get]
+func (h *taxRateHandlersHTTP) FindById(w http.ResponseWriter, r *http.Request) {
+	taxRateUUID, err := uuid.Parse(router.Param(r, constants.ID))
+	if err != nil {
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	taxRate, err := h.taxRateUC.FindById(r.Context(), taxRateUUID)
+	if err != nil {
+		h.logger.Errorf("taxRateUC.FindById: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	w.Header().Set(constants.ETag, utils.ETag(taxRate.Version))
+	res, _ := json.Marshal(dto.TaxRateResponseFromModel(taxRate))
+	w.WriteHeader(http.StatusOK)
+	w.Write(res)
+	return
+}
+
+// UpdateById
+// @Tags TaxRates
+// @Summary Update tax rate
+// @Description Admin update tax rate, only provided fields are changed, category_rates replaces every override when given. The region is kept, existing orders keep their tax lines
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "tax rate uuid"
+// @Param If-Match header string false "ETag of the version being changed"
+// @Param payload body dto.TaxRateUpdateRequestDto true "Payload"
+// @Success 200 {object} dto.TaxRateResponseDto
+// @Header 200 {string} ETag "resource version"
+// @Router /tax-rates/{id} [patch]
+func (h *taxRateHandlersHTTP) UpdateById(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	taxRateUUID, err := uuid.Parse(router.Param(r, constants.ID))
+	if err != nil {
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	updateDto := &dto.TaxRateUpdateRequestDto{}
+	if err := json.NewDecoder(r.Body).Decode(updateDto); err != nil {
+		h.logger.Errorf("decoder.Decode: %v", err)
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	if err := h.v.Struct(updateDto); err != nil {
+		h.logger.Errorf("h.v.Struct: %v", err)
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	taxRate, err := h.taxRateUC.FindById(ctx, taxRateUUID)
+	if err != nil {
+		h.logger.Errorf("taxRateUC.FindById: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	if !utils.IfMatch(r.Header.Get(constants.IfMatch), taxRate.Version) {
+		_ = httpErrors.ErrorCtxResponse(w, httpErrors.PreconditionFailed, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	if updateDto.Name != nil {
+		taxRate.Name = *updateDto.Name
+	}
+	if updateDto.Rate != nil {
+		taxRate.Rate = *updateDto.Rate
+	}
+	if updateDto.PricesIncludeTax != nil {
+		taxRate.PricesIncludeTax = *updateDto.PricesIncludeTax
+	}
+	if updateDto.CategoryRates != nil {
+		taxRate.CategoryRates = updateDto.CategoryRates
+	}
+
+	if err := taxRate.PrepareCreate(); err != nil {
+		h.logger.Errorf("taxRate.PrepareCreate: %v", err)
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	updatedTaxRate, err := h.taxRateUC.UpdateById(ctx, taxRate)
+	if err != nil {
+		h.logger.Errorf("taxRateUC.UpdateById: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	w.Header().Set(constants.ETag, utils.ETag(updatedTaxRate.Version))
+	res, _ := json.Marshal(dto.TaxRateResponseFromModel(updatedTaxRate))
+	w.WriteHeader(http.StatusOK)
+	w.Write(res)
+	return
+}
+
+// DeleteById
+// @Tags TaxRates
+// @Summary Delete tax rate
+// @Description Admin delete tax rate, orders to its region are no longer taxed unless a parent region has a rate
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "tax rate uuid"
+// @Success 204 {object} nil
+// @Router /tax-rates/{id} [delete]
+func (h *taxRateHandlersHTTP) DeleteById(w http.ResponseWriter, r *http.Request) {
+	taxRateUUID, err := uuid.Parse(router.Param(r, constants.ID))
+	if err != nil {
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	if err := h.taxRateUC.DeleteById(r.Context(), taxRateUUID); err != nil {
+		h.logger.Errorf("taxRateUC.DeleteById: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return
+}
@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/middlewares"
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/internal/server/router"
+	"github.com/dinorain/kalobranded/internal/taxrate/delivery/http/dto"
+	"github.com/dinorain/kalobranded/internal/taxrate/mock"
+	"github.com/dinorain/kalobranded/pkg/logger"
+	"github.com/dinorain/kalobranded/pkg/utils"
+)
+
+func TestTaxRatesHandler_Create(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taxRateUC := mock.NewMockTaxRateUseCase(ctrl)
+
+	cfg := &config.Config{Session: config.Session{Expire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	appLogger := logger.NewAppLogger(cfg)
+	appLogger.InitLogger()
+	mw := middlewares.NewMiddlewareManager(appLogger, cfg)
+
+	v := validator.New()
+
+	rt := router.NewRouter(false)
+	handlers := NewTaxRateHandlersHTTP(rt, appLogger, cfg, mw, v, taxRateUC)
+
+	t.Run("Created", func(t *testing.T) {
+		body := `{"region": " us-ca ", "name": "California", "rate": 7.25, "category_rates": {" Books ": 0}}`
+		req := httptest.NewRequest(http.MethodPost, "/tax-rates", strings.NewReader(body))
+		w := httptest.NewRecorder()
+
+		taxRateUUID := uuid.New()
+		taxRateUC.EXPECT().Create(gomock.Any(), &models.TaxRate{
+			Region:        "US-CA",
+			Name:          "California",
+			Rate:          7.25,
+			CategoryRates: models.TaxCategoryRates{"books": 0},
+		}).Return(&models.TaxRate{TaxRateID: taxRateUUID}, nil)
+
+		http.HandlerFunc(handlers.Create).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusCreated, w.Code)
+		resDto := &dto.TaxRateCreateResponseDto{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), resDto))
+		require.Equal(t, taxRateUUID, resDto.TaxRateID)
+	})
+
+	t.Run("ThreeDecimals", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/tax-rates", strings.NewReader(`{"region": "ID", "name": "PPN", "rate": 11.125}`))
+		w := httptest.NewRecorder()
+
+		http.HandlerFunc(handlers.Create).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Exists", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/tax-rates", strings.NewReader(`{"region": "ID", "name": "PPN", "rate": 11}`))
+		w := httptest.NewRecorder()
+
+		taxRateUC.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, models.ErrTaxRateExists)
+
+		http.HandlerFunc(handlers.Create).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusConflict, w.Code)
+	})
+}
+
+func TestTaxRatesHandler_UpdateById(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taxRateUC := mock.NewMockTaxRateUseCase(ctrl)
+
+	cfg := &config.Config{Session: config.Session{Expire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	appLogger := logger.NewAppLogger(cfg)
+	appLogger.InitLogger()
+	mw := middlewares.NewMiddlewareManager(appLogger, cfg)
+
+	v := validator.New()
+
+	rt := router.NewRouter(false)
+	handlers := NewTaxRateHandlersHTTP(rt, appLogger, cfg, mw, v, taxRateUC)
+
+	taxRateUUID := uuid.New()
+	newTaxRate := func() *models.TaxRate {
+		return &models.TaxRate{TaxRateID: taxRateUUID, Region: "DE", Name: "MwSt", Rate: 19, PricesIncludeTax: true, Version: 1}
+	}
+
+	t.Run("Updated", func(t *testing.T) {
+		req := router.WithParams(httptest.NewRequest(http.MethodPatch, "/tax-rates/"+taxRateUUID.String(), strings.NewReader(`{"rate": 7}`)), map[string]string{"id": taxRateUUID.String()})
+		req.Header.Set("If-Match", utils.ETag(1))
+		w := httptest.NewRecorder()
+
+		taxRateUC.EXPECT().FindById(gomock.Any(), taxRateUUID).Return(newTaxRate(), nil)
+		taxRateUC.EXPECT().UpdateById(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, taxRate *models.TaxRate) (*models.TaxRate, error) {
+			require.Equal(t, 7.0, taxRate.Rate)
+			require.True(t, taxRate.PricesIncludeTax)
+			updated := *taxRate
+			updated.Version = 2
+			return &updated, nil
+		})
+
+		http.HandlerFunc(handlers.UpdateById).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, utils.ETag(2), w.Header().Get("ETag"))
+	})
+
+	t.Run("StaleVersion", func(t *testing.T) {
+		req := router.WithParams(httptest.NewRequest(http.MethodPatch, "/tax-rates/"+taxRateUUID.String(), strings.NewReader(`{"rate": 7}`)), map[string]string{"id": taxRateUUID.String()})
+		req.Header.Set("If-Match", utils.ETag(0))
+		w := httptest.NewRecorder()
+
+		taxRateUC.EXPECT().FindById(gomock.Any(), taxRateUUID).Return(newTaxRate(), nil)
+
+		http.HandlerFunc(handlers.UpdateById).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusPreconditionFailed, w.Code)
+	})
+}
@@ -0,0 +1,10 @@
+package handlers
+
+func (h *taxRateHandlersHTTP) TaxRateMapRoutes() {
+	taxRates := h.router.Group("/tax-rates", h.mw.IsAdmin)
+	taxRates.Post("", h.Create)
+	taxRates.Get("", h.FindAll)
+	taxRates.Get("/{id}", h.FindById)
+	taxRates.Patch("/{id}", h.UpdateById)
+	taxRates.Delete("/{id}", h.DeleteById)
+}
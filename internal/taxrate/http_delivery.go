@@ -0,0 +1,14 @@
+package taxrate
+
+import (
+	"net/http"
+)
+
+// TaxRate HTTP Handlers interface
+type TaxRateHandlers interface {
+	Create(w http.ResponseWriter, r *http.Request)
+	FindAll(w http.ResponseWriter, r *http.Request)
+	FindById(w http.ResponseWriter, r *http.Request)
+	UpdateById(w http.ResponseWriter, r *http.Request)
+	DeleteById(w http.ResponseWriter, r *http.Request)
+}
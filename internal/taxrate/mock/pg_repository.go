@@ -0,0 +1,127 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pg_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/dinorain/kalobranded/internal/models"
+	utils "github.com/dinorain/kalobranded/pkg/utils"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockTaxRatePGRepository is a mock of TaxRatePGRepository interface.
+type MockTaxRatePGRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaxRatePGRepositoryMockRecorder
+}
+
+// MockTaxRatePGRepositoryMockRecorder is the mock recorder for MockTaxRatePGRepository.
+type MockTaxRatePGRepositoryMockRecorder struct {
+	mock *MockTaxRatePGRepository
+}
+
+// NewMockTaxRatePGRepository creates a new mock instance.
+func NewMockTaxRatePGRepository(ctrl *gomock.Controller) *MockTaxRatePGRepository {
+	mock := &MockTaxRatePGRepository{ctrl: ctrl}
+	mock.recorder = &MockTaxRatePGRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaxRatePGRepository) EXPECT() *MockTaxRatePGRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockTaxRatePGRepository) Create(ctx context.Context, taxRate *models.TaxRate) (*models.TaxRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, taxRate)
+	ret0, _ := ret[0].(*models.TaxRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockTaxRatePGRepositoryMockRecorder) Create(ctx, taxRate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTaxRatePGRepository)(nil).Create), ctx, taxRate)
+}
+
+// DeleteById mocks base method.
+func (m *MockTaxRatePGRepository) DeleteById(ctx context.Context, taxRateID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteById", ctx, taxRateID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteById indicates an expected call of DeleteById.
+func (mr *MockTaxRatePGRepositoryMockRecorder) DeleteById(ctx, taxRateID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockTaxRatePGRepository)(nil).DeleteById), ctx, taxRateID)
+}
+
+// FindAll mocks base method.
+func (m *MockTaxRatePGRepository) FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.TaxRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, pagination)
+	ret0, _ := ret[0].([]models.TaxRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockTaxRatePGRepositoryMockRecorder) FindAll(ctx, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockTaxRatePGRepository)(nil).FindAll), ctx, pagination)
+}
+
+// FindAllByRegions mocks base method.
+func (m *MockTaxRatePGRepository) FindAllByRegions(ctx context.Context, regions []string) ([]models.TaxRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByRegions", ctx, regions)
+	ret0, _ := ret[0].([]models.TaxRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByRegions indicates an expected call of FindAllByRegions.
+func (mr *MockTaxRatePGRepositoryMockRecorder) FindAllByRegions(ctx, regions interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByRegions", reflect.TypeOf((*MockTaxRatePGRepository)(nil).FindAllByRegions), ctx, regions)
+}
+
+// FindById mocks base method.
+func (m *MockTaxRatePGRepository) FindById(ctx context.Context, taxRateID uuid.UUID) (*models.TaxRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, taxRateID)
+	ret0, _ := ret[0].(*models.TaxRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockTaxRatePGRepositoryMockRecorder) FindById(ctx, taxRateID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockTaxRatePGRepository)(nil).FindById), ctx, taxRateID)
+}
+
+// UpdateById mocks base method.
+func (m *MockTaxRatePGRepository) UpdateById(ctx context.Context, taxRate *models.TaxRate) (*models.TaxRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateById", ctx, taxRate)
+	ret0, _ := ret[0].(*models.TaxRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateById indicates an expected call of UpdateById.
+func (mr *MockTaxRatePGRepositoryMockRecorder) UpdateById(ctx, taxRate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateById", reflect.TypeOf((*MockTaxRatePGRepository)(nil).UpdateById), ctx, taxRate)
+}
@@ -0,0 +1,127 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/dinorain/kalobranded/internal/models"
+	utils "github.com/dinorain/kalobranded/pkg/utils"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockTaxRateUseCase is a mock of TaxRateUseCase interface.
+type MockTaxRateUseCase struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaxRateUseCaseMockRecorder
+}
+
+// MockTaxRateUseCaseMockRecorder is the mock recorder for MockTaxRateUseCase.
+type MockTaxRateUseCaseMockRecorder struct {
+	mock *MockTaxRateUseCase
+}
+
+// NewMockTaxRateUseCase creates a new mock instance.
+func NewMockTaxRateUseCase(ctrl *gomock.Controller) *MockTaxRateUseCase {
+	mock := &MockTaxRateUseCase{ctrl: ctrl}
+	mock.recorder = &MockTaxRateUseCaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaxRateUseCase) EXPECT() *MockTaxRateUseCaseMockRecorder {
+	return m.recorder
+}
+
+// Apply mocks base method.
+func (m *MockTaxRateUseCase) Apply(ctx context.Context, order *models.Order) (*models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Apply", ctx, order)
+	ret0, _ := ret[0].(*models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Apply indicates an expected call of Apply.
+func (mr *MockTaxRateUseCaseMockRecorder) Apply(ctx, order interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Apply", reflect.TypeOf((*MockTaxRateUseCase)(nil).Apply), ctx, order)
+}
+
+// Create mocks base method.
+func (m *MockTaxRateUseCase) Create(ctx context.Context, taxRate *models.TaxRate) (*models.TaxRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, taxRate)
+	ret0, _ := ret[0].(*models.TaxRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockTaxRateUseCaseMockRecorder) Create(ctx, taxRate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTaxRateUseCase)(nil).Create), ctx, taxRate)
+}
+
+// DeleteById mocks base method.
+func (m *MockTaxRateUseCase) DeleteById(ctx context.Context, taxRateID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteById", ctx, taxRateID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteById indicates an expected call of DeleteById.
+func (mr *MockTaxRateUseCaseMockRecorder) DeleteById(ctx, taxRateID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockTaxRateUseCase)(nil).DeleteById), ctx, taxRateID)
+}
+
+// FindAll mocks base method.
+func (m *MockTaxRateUseCase) FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.TaxRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, pagination)
+	ret0, _ := ret[0].([]models.TaxRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockTaxRateUseCaseMockRecorder) FindAll(ctx, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockTaxRateUseCase)(nil).FindAll), ctx, pagination)
+}
+
+// FindById mocks base method.
+func (m *MockTaxRateUseCase) FindById(ctx context.Context, taxRateID uuid.UUID) (*models.TaxRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, taxRateID)
+	ret0, _ := ret[0].(*models.TaxRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockTaxRateUseCaseMockRecorder) FindById(ctx, taxRateID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockTaxRateUseCase)(nil).FindById), ctx, taxRateID)
+}
+
+// UpdateById mocks base method.
+func (m *MockTaxRateUseCase) UpdateById(ctx context.Context, taxRate *models.TaxRate) (*models.TaxRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateById", ctx, taxRate)
+	ret0, _ := ret[0].(*models.TaxRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateById indicates an expected call of UpdateById.
+func (mr *MockTaxRateUseCaseMockRecorder) UpdateById(ctx, taxRate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateById", reflect.TypeOf((*MockTaxRateUseCase)(nil).UpdateById), ctx, taxRate)
+}
@@ -0,0 +1,21 @@
+//go:generate mockgen -source pg_repository.go -destination mock/pg_repository.go -package mock
+package taxrate
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/pkg/utils"
+)
+
+// TaxRate pg repository
+type TaxRatePGRepository interface {
+	Create(ctx context.Context, taxRate *models.TaxRate) (*models.TaxRate, error)
+	FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.TaxRate, error)
+	FindAllByRegions(ctx context.Context, regions []string) ([]models.TaxRate, error)
+	FindById(ctx context.Context, taxRateID uuid.UUID) (*models.TaxRate, error)
+	UpdateById(ctx context.Context, taxRate *models.TaxRate) (*models.TaxRate, error)
+	DeleteById(ctx context.Context, taxRateID uuid.UUID) error
+}
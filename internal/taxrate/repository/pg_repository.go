@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/internal/taxrate"
+	"github.com/dinorain/kalobranded/pkg/utils"
+)
+
+// TaxRate repository
+type TaxRateRepository struct {
+	db *sqlx.DB
+}
+
+var _ taxrate.TaxRatePGRepository = (*TaxRateRepository)(nil)
+
+// TaxRate repository constructor
+func NewTaxRatePGRepository(db *sqlx.DB) *TaxRateRepository {
+	return &TaxRateRepository{db: db}
+}
+
+// Create new tax rate
+func (r *TaxRateRepository) Create(ctx context.Context, taxRate *models.TaxRate) (*models.TaxRate, error) {
+	createdTaxRate := &models.TaxRate{}
+	if err := r.db.QueryRowxContext(
+		ctx,
+		createTaxRateQuery,
+		taxRate.Region,
+		taxRate.Name,
+		taxRate.Rate,
+		taxRate.PricesIncludeTax,
+		taxRate.CategoryRates,
+	).StructScan(createdTaxRate); err != nil {
+		return nil, errors.Wrap(err, "TaxRateRepository.Create.QueryRowxContext")
+	}
+
+	return createdTaxRate, nil
+}
+
+// FindAll Find tax rates ordered by region
+func (r *TaxRateRepository) FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.TaxRate, error) {
+	var taxRates []models.TaxRate
+	if err := r.db.SelectContext(ctx, &taxRates, findAllQuery, pagination.GetLimit(), pagination.GetOffset()); err != nil {
+		return nil, errors.Wrap(err, "TaxRateRepository.FindAll.SelectContext")
+	}
+
+	return taxRates, nil
+}
+
+// FindAllByRegions Find tax rates of regions
+func (r *TaxRateRepository) FindAllByRegions(ctx context.Context, regions []string) ([]models.TaxRate, error) {
+	var taxRates []models.TaxRate
+	if err := r.db.SelectContext(ctx, &taxRates, findAllByRegionsQuery, pq.Array(regions)); err != nil {
+		return nil, errors.Wrap(err, "TaxRateRepository.FindAllByRegions.SelectContext")
+	}
+
+	return taxRates, nil
+}
+
+// FindById Find tax rate by uuid
+func (r *TaxRateRepository) FindById(ctx context.Context, taxRateID uuid.UUID) (*models.TaxRate, error) {
+	taxRate := &models.TaxRate{}
+	if err := r.db.GetContext(ctx, taxRate, findByIdQuery, taxRateID); err != nil {
+		return nil, errors.Wrap(err, "TaxRateRepository.FindById.GetContext")
+	}
+
+	return taxRate, nil
+}
+
+// UpdateById update existing tax rate when its version is unchanged, the region is kept
+func (r *TaxRateRepository) UpdateById(ctx context.Context, taxRate *models.TaxRate) (*models.TaxRate, error) {
+	updatedTaxRate := &models.TaxRate{}
+	if err := r.db.QueryRowxContext(
+		ctx,
+		updateByIdQuery,
+		taxRate.TaxRateID,
+		taxRate.Name,
+		taxRate.Rate,
+		taxRate.PricesIncludeTax,
+		taxRate.CategoryRates,
+		taxRate.Version,
+	).StructScan(updatedTaxRate); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, models.ErrVersionConflict
+		}
+		return nil, errors.Wrap(err, "TaxRateRepository.UpdateById.QueryRowxContext")
+	}
+
+	return updatedTaxRate, nil
+}
+
+// DeleteById delete tax rate by uuid, taxed orders keep their tax lines
+func (r *TaxRateRepository) DeleteById(ctx context.Context, taxRateID uuid.UUID) error {
+	if res, err := r.db.ExecContext(ctx, deleteByIdQuery, taxRateID); err != nil {
+		return errors.Wrap(err, "TaxRateRepository.DeleteById.ExecContext")
+	} else {
+		cnt, err := res.RowsAffected()
+		if err != nil {
+			return errors.Wrap(err, "TaxRateRepository.DeleteById.RowsAffected")
+		} else if cnt == 0 {
+			return sql.ErrNoRows
+		}
+	}
+
+	return nil
+}
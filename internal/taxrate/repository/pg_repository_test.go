@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/kalobranded/internal/models"
+)
+
+func TestTaxRateRepository_Create(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	taxRatePGRepository := NewTaxRatePGRepository(sqlxDB)
+
+	mockTaxRate := &models.TaxRate{
+		Region:        "ID",
+		Name:          "PPN",
+		Rate:          11,
+		CategoryRates: models.TaxCategoryRates{"books": 0},
+	}
+
+	taxRateUUID := uuid.New()
+	rows := sqlmock.NewRows([]string{"tax_rate_id", "region", "name", "rate", "prices_include_tax", "category_rates"}).AddRow(
+		taxRateUUID,
+		mockTaxRate.Region,
+		mockTaxRate.Name,
+		mockTaxRate.Rate,
+		false,
+		[]byte(`{"books": 0}`),
+	)
+
+	mock.ExpectQuery(createTaxRateQuery).WithArgs(
+		mockTaxRate.Region,
+		mockTaxRate.Name,
+		mockTaxRate.Rate,
+		mockTaxRate.PricesIncludeTax,
+		mockTaxRate.CategoryRates,
+	).WillReturnRows(rows)
+
+	createdTaxRate, err := taxRatePGRepository.Create(context.Background(), mockTaxRate)
+	require.NoError(t, err)
+	require.Equal(t, taxRateUUID, createdTaxRate.TaxRateID)
+	require.Equal(t, models.TaxCategoryRates{"books": 0}, createdTaxRate.CategoryRates)
+}
+
+func TestTaxRateRepository_FindAllByRegions(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	taxRatePGRepository := NewTaxRatePGRepository(sqlxDB)
+
+	regions := []string{"US-CA", "US"}
+	rows := sqlmock.NewRows([]string{"tax_rate_id", "region", "rate", "category_rates"}).
+		AddRow(uuid.New(), "US", 5, []byte(`{}`))
+
+	mock.ExpectQuery(findAllByRegionsQuery).WithArgs(pq.Array(regions)).WillReturnRows(rows)
+
+	taxRates, err := taxRatePGRepository.FindAllByRegions(context.Background(), regions)
+	require.NoError(t, err)
+	require.Len(t, taxRates, 1)
+	require.Equal(t, "US", taxRates[0].Region)
+}
+
+func TestTaxRateRepository_UpdateById(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	taxRatePGRepository := NewTaxRatePGRepository(sqlxDB)
+
+	mockTaxRate := &models.TaxRate{
+		TaxRateID:        uuid.New(),
+		Region:           "DE",
+		Name:             "MwSt",
+		Rate:             19,
+		PricesIncludeTax: true,
+		Version:          1,
+	}
+
+	mock.ExpectQuery(updateByIdQuery).WithArgs(
+		mockTaxRate.TaxRateID,
+		mockTaxRate.Name,
+		mockTaxRate.Rate,
+		mockTaxRate.PricesIncludeTax,
+		mockTaxRate.CategoryRates,
+		mockTaxRate.Version,
+	).WillReturnRows(sqlmock.NewRows([]string{"tax_rate_id", "rate", "version"}).AddRow(mockTaxRate.TaxRateID, 19, 2))
+
+	updatedTaxRate, err := taxRatePGRepository.UpdateById(context.Background(), mockTaxRate)
+	require.NoError(t, err)
+	require.Equal(t, 2, updatedTaxRate.Version)
+
+	t.Run("VersionConflict", func(t *testing.T) {
+		mock.ExpectQuery(updateByIdQuery).WillReturnError(sql.ErrNoRows)
+
+		_, err := taxRatePGRepository.UpdateById(context.Background(), mockTaxRate)
+		require.ErrorIs(t, err, models.ErrVersionConflict)
+	})
+}
+
+func TestTaxRateRepository_DeleteById(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	taxRatePGRepository := NewTaxRatePGRepository(sqlxDB)
+
+	taxRateUUID := uuid.New()
+	mock.ExpectExec(deleteByIdQuery).WithArgs(taxRateUUID).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = taxRatePGRepository.DeleteById(context.Background(), taxRateUUID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
@@ -0,0 +1,18 @@
+package repository
+
+const (
+	createTaxRateQuery = `INSERT INTO tax_rates (region, name, rate, prices_include_tax, category_rates) 
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING tax_rate_id, region, name, rate, prices_include_tax, category_rates, created_at, updated_at, version`
+
+	findByIdQuery = `SELECT tax_rate_id, region, name, rate, prices_include_tax, category_rates, created_at, updated_at, version FROM tax_rates WHERE tax_rate_id = $1`
+
+	findAllQuery = `SELECT tax_rate_id, region, name, rate, prices_include_tax, category_rates, created_at, updated_at, version FROM tax_rates ORDER BY region LIMIT $1 OFFSET $2`
+
+	findAllByRegionsQuery = `SELECT tax_rate_id, region, name, rate, prices_include_tax, category_rates, created_at, updated_at, version FROM tax_rates WHERE region = ANY($1)`
+
+	updateByIdQuery = `UPDATE tax_rates SET name = $2, rate = $3, prices_include_tax = $4, category_rates = $5, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE tax_rate_id = $1 AND version = $6
+		RETURNING tax_rate_id, region, name, rate, prices_include_tax, category_rates, created_at, updated_at, version`
+
+	deleteByIdQuery = `DELETE FROM tax_rates WHERE tax_rate_id = $1`
+)
@@ -0,0 +1,21 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase.go -package mock
+package taxrate
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/pkg/utils"
+)
+
+// TaxRate UseCase interface
+type TaxRateUseCase interface {
+	Create(ctx context.Context, taxRate *models.TaxRate) (*models.TaxRate, error)
+	FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.TaxRate, error)
+	FindById(ctx context.Context, taxRateID uuid.UUID) (*models.TaxRate, error)
+	UpdateById(ctx context.Context, taxRate *models.TaxRate) (*models.TaxRate, error)
+	DeleteById(ctx context.Context, taxRateID uuid.UUID) error
+	Apply(ctx context.Context, order *models.Order) (*models.Order, error)
+}
@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/internal/taxrate"
+	"github.com/dinorain/kalobranded/pkg/logger"
+	"github.com/dinorain/kalobranded/pkg/tax"
+	"github.com/dinorain/kalobranded/pkg/utils"
+)
+
+// TaxRate UseCase
+type taxRateUseCase struct {
+	cfg           *config.Config
+	logger        logger.Logger
+	taxRatePgRepo taxrate.TaxRatePGRepository
+}
+
+var _ taxrate.TaxRateUseCase = (*taxRateUseCase)(nil)
+
+// New TaxRate UseCase
+func NewTaxRateUseCase(cfg *config.Config, logger logger.Logger, taxRateRepo taxrate.TaxRatePGRepository) *taxRateUseCase {
+	return &taxRateUseCase{cfg: cfg, logger: logger, taxRatePgRepo: taxRateRepo}
+}
+
+// Create new tax rate, one per region
+func (u *taxRateUseCase) Create(ctx context.Context, taxRate *models.TaxRate) (*models.TaxRate, error) {
+	existing, err := u.taxRatePgRepo.FindAllByRegions(ctx, []string{taxRate.Region})
+	if err != nil {
+		return nil, errors.Wrap(err, "taxRatePgRepo.FindAllByRegions")
+	} else if len(existing) > 0 {
+		return nil, models.ErrTaxRateExists
+	}
+
+	createdTaxRate, err := u.taxRatePgRepo.Create(ctx, taxRate)
+	if err != nil {
+		return nil, errors.Wrap(err, "taxRatePgRepo.Create")
+	}
+
+	return createdTaxRate, nil
+}
+
+// FindAll find tax rates
+func (u *taxRateUseCase) FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.TaxRate, error) {
+	taxRates, err := u.taxRatePgRepo.FindAll(ctx, pagination)
+	if err != nil {
+		return nil, errors.Wrap(err, "taxRatePgRepo.FindAll")
+	}
+
+	return taxRates, nil
+}
+
+// FindById find tax rate by uuid
+func (u *taxRateUseCase) FindById(ctx context.Context, taxRateID uuid.UUID) (*models.TaxRate, error) {
+	foundTaxRate, err := u.taxRatePgRepo.FindById(ctx, taxRateID)
+	if err != nil {
+		return nil, errors.Wrap(err, "taxRatePgRepo.FindById")
+	}
+
+	return foundTaxRate, nil
+}
+
+// UpdateById update existing tax rate
+func (u *taxRateUseCase) UpdateById(ctx context.Context, taxRate *models.TaxRate) (*models.TaxRate, error) {
+	updatedTaxRate, err := u.taxRatePgRepo.UpdateById(ctx, taxRate)
+	if err != nil {
+		return nil, errors.Wrap(err, "taxRatePgRepo.UpdateById")
+	}
+
+	return updatedTaxRate, nil
+}
+
+// DeleteById delete tax rate by uuid
+func (u *taxRateUseCase) DeleteById(ctx context.Context, taxRateID uuid.UUID) error {
+	if err := u.taxRatePgRepo.DeleteById(ctx, taxRateID); err != nil {
+		return errors.Wrap(err, "taxRatePgRepo.DeleteById")
+	}
+
+	return nil
+}
+
+// Apply tax order with the rate table of its delivery region, taken from its address book address when it has one,
+// falling back to the parent regions. The total price, already less discounts, gets the tax added when prices
+// exclude it and is kept as is otherwise. Orders delivered outside every known region are not taxed
+func (u *taxRateUseCase) Apply(ctx context.Context, order *models.Order) (*models.Order, error) {
+	region := order.TaxRegion()
+
+	taxRates, err := u.taxRatePgRepo.FindAllByRegions(ctx, tax.RegionCandidates(region))
+	if err != nil {
+		return nil, errors.Wrap(err, "taxRatePgRepo.FindAllByRegions")
+	}
+
+	jurisdictions := make([]tax.Jurisdiction, 0, len(taxRates))
+	for i := range taxRates {
+		jurisdictions = append(jurisdictions, taxRates[i].Jurisdiction())
+	}
+
+	res := tax.NewCalculator(jurisdictions...).CalculateRegion(region, []tax.Item{
+		{Category: order.Item.Category, Amount: order.TotalPrice},
+	})
+
+	taxedOrder := *order
+	taxedOrder.TaxLines = res.Lines
+	taxedOrder.TaxTotal = res.Total
+	if !res.Inclusive {
+		taxedOrder.TotalPrice = models.RoundAmount(order.TotalPrice + res.Total)
+	}
+
+	return &taxedOrder, nil
+}
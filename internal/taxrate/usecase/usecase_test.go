@@ -0,0 +1,123 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/internal/taxrate/mock"
+	"github.com/dinorain/kalobranded/pkg/logger"
+)
+
+func TestTaxRateUseCase_Create(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taxRatePGRepository := mock.NewMockTaxRatePGRepository(ctrl)
+	apiLogger := logger.NewAppLogger(nil)
+
+	cfg := &config.Config{}
+	taxRateUC := NewTaxRateUseCase(cfg, apiLogger, taxRatePGRepository)
+
+	ctx := context.Background()
+	mockTaxRate := &models.TaxRate{Region: "ID", Name: "PPN", Rate: 11}
+
+	taxRatePGRepository.EXPECT().FindAllByRegions(gomock.Any(), []string{"ID"}).Return([]models.TaxRate{}, nil)
+	taxRatePGRepository.EXPECT().Create(gomock.Any(), mockTaxRate).Return(&models.TaxRate{TaxRateID: uuid.New(), Region: "ID"}, nil)
+
+	createdTaxRate, err := taxRateUC.Create(ctx, mockTaxRate)
+	require.NoError(t, err)
+	require.Equal(t, "ID", createdTaxRate.Region)
+
+	t.Run("Exists", func(t *testing.T) {
+		taxRatePGRepository.EXPECT().FindAllByRegions(gomock.Any(), []string{"ID"}).Return([]models.TaxRate{*mockTaxRate}, nil)
+
+		_, err := taxRateUC.Create(ctx, mockTaxRate)
+		require.ErrorIs(t, err, models.ErrTaxRateExists)
+	})
+}
+
+func TestTaxRateUseCase_Apply(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taxRatePGRepository := mock.NewMockTaxRatePGRepository(ctrl)
+	apiLogger := logger.NewAppLogger(nil)
+
+	cfg := &config.Config{}
+	taxRateUC := NewTaxRateUseCase(cfg, apiLogger, taxRatePGRepository)
+
+	ctx := context.Background()
+	mockOrder := &models.Order{
+		UserID:                     uuid.New(),
+		Item:                       models.OrderItem{ProductID: uuid.New(), Price: 10000.0, Category: "books"},
+		Quantity:                   3,
+		TotalPrice:                 27000.0,
+		DiscountTotal:              3000.0,
+		DeliveryDestinationAddress: "1 Main St, Los Angeles, US-CA",
+	}
+
+	t.Run("Exclusive", func(t *testing.T) {
+		taxRatePGRepository.EXPECT().FindAllByRegions(gomock.Any(), []string{"US-CA", "US"}).Return([]models.TaxRate{
+			{Region: "US", Name: "Federal", Rate: 5},
+			{Region: "US-CA", Name: "California", Rate: 7.25, CategoryRates: models.TaxCategoryRates{"books": 2.5}},
+		}, nil)
+
+		taxedOrder, err := taxRateUC.Apply(ctx, mockOrder)
+		require.NoError(t, err)
+		require.Equal(t, 675.0, taxedOrder.TaxTotal)
+		require.Equal(t, 27675.0, taxedOrder.TotalPrice)
+		require.Len(t, taxedOrder.TaxLines, 1)
+		require.Equal(t, "US-CA", taxedOrder.TaxLines[0].Region)
+		require.Equal(t, 2.5, taxedOrder.TaxLines[0].Rate)
+		require.Equal(t, 27000.0, mockOrder.TotalPrice)
+	})
+
+	t.Run("Inclusive", func(t *testing.T) {
+		taxRatePGRepository.EXPECT().FindAllByRegions(gomock.Any(), []string{"US-CA", "US"}).Return([]models.TaxRate{
+			{Region: "US", Name: "Federal", Rate: 8, PricesIncludeTax: true},
+		}, nil)
+
+		taxedOrder, err := taxRateUC.Apply(ctx, mockOrder)
+		require.NoError(t, err)
+		require.Equal(t, 2000.0, taxedOrder.TaxTotal)
+		require.Equal(t, 27000.0, taxedOrder.TotalPrice)
+		require.Equal(t, 25000.0, taxedOrder.TaxLines[0].Taxable)
+	})
+
+	t.Run("AddressBookRegion", func(t *testing.T) {
+		addressOrder := *mockOrder
+		addressOrder.DeliveryDestinationAddress = "1 Main St, 90012, Los Angeles, US"
+		addressOrder.DeliveryAddress = &models.OrderAddress{Street: "1 Main St", PostalCode: "90012", City: "Los Angeles", Region: "CA", Country: "US"}
+
+		taxRatePGRepository.EXPECT().FindAllByRegions(gomock.Any(), []string{"US-CA", "US"}).Return([]models.TaxRate{
+			{Region: "US", Name: "Federal", Rate: 5},
+			{Region: "US-CA", Name: "California", Rate: 7.25},
+		}, nil)
+
+		taxedOrder, err := taxRateUC.Apply(ctx, &addressOrder)
+		require.NoError(t, err)
+		require.Len(t, taxedOrder.TaxLines, 1)
+		require.Equal(t, "US-CA", taxedOrder.TaxLines[0].Region)
+		require.Equal(t, 7.25, taxedOrder.TaxLines[0].Rate)
+	})
+
+	t.Run("UnknownRegion", func(t *testing.T) {
+		taxRatePGRepository.EXPECT().FindAllByRegions(gomock.Any(), []string{"US-CA", "US"}).Return([]models.TaxRate{}, nil)
+
+		taxedOrder, err := taxRateUC.Apply(ctx, mockOrder)
+		require.NoError(t, err)
+		require.Zero(t, taxedOrder.TaxTotal)
+		require.Empty(t, taxedOrder.TaxLines)
+		require.Equal(t, 27000.0, taxedOrder.TotalPrice)
+	})
+}
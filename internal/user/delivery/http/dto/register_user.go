@@ -5,12 +5,13 @@ import (
 )
 
 type UserRegisterRequestDto struct {
-	Email           string `json:"email" validate:"required,lte=60,email"`
-	FirstName       string `json:"first_name" validate:"required,lte=30"`
-	LastName        string `json:"last_name" validate:"required,lte=30"`
-	Password        string `json:"password" validate:"required"`
-	Role            string `json:"role" validate:"required"`
-	DeliveryAddress string `json:"delivery_address" validate:"required"`
+	Email             string   `json:"email" validate:"required,lte=60,email"`
+	FirstName         string   `json:"first_name" validate:"required,lte=30"`
+	LastName          string   `json:"last_name" validate:"required,lte=30"`
+	Password          string   `json:"password" validate:"required"`
+	DeliveryAddress   string   `json:"delivery_address" validate:"required"`
+	DeliveryLatitude  *float64 `json:"delivery_latitude" validate:"omitempty,gte=-90,lte=90"`
+	DeliveryLongitude *float64 `json:"delivery_longitude" validate:"omitempty,gte=-180,lte=180"`
 }
 
 type UserRegisterResponseDto struct {
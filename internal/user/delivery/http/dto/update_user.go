@@ -1,9 +1,12 @@
 package dto
 
 type UserUpdateRequestDto struct {
-	FirstName       *string `json:"first_name" validate:"omitempty,lte=30"`
-	LastName        *string `json:"last_name" validate:"omitempty,lte=30"`
-	Password        *string `json:"password" validate:"omitempty"`
-	Avatar          *string `json:"avatar" validate:"omitempty"`
-	DeliveryAddress *string `json:"delivery_address" validate:"omitempty"`
+	FirstName         *string  `json:"first_name" validate:"omitempty,lte=30"`
+	LastName          *string  `json:"last_name" validate:"omitempty,lte=30"`
+	Password          *string  `json:"password" validate:"omitempty,min=1"`
+	CurrentPassword   *string  `json:"current_password" validate:"omitempty,min=1"`
+	Avatar            *string  `json:"avatar" validate:"omitempty"`
+	DeliveryAddress   *string  `json:"delivery_address" validate:"omitempty"`
+	DeliveryLatitude  *float64 `json:"delivery_latitude" validate:"omitempty,gte=-90,lte=90"`
+	DeliveryLongitude *float64 `json:"delivery_longitude" validate:"omitempty,gte=-180,lte=180"`
 }
@@ -9,27 +9,37 @@ import (
 )
 
 type UserResponseDto struct {
-	UserID          uuid.UUID `json:"user_id"`
-	Email           string    `json:"email"`
-	FirstName       string    `json:"first_name"`
-	LastName        string    `json:"last_name"`
-	Role            string    `json:"role"`
-	Avatar          *string   `json:"avatar"`
-	DeliveryAddress string    `json:"delivery_address"`
-	CreatedAt       time.Time `json:"created_at"`
-	UpdatedAt       time.Time `json:"updated_at"`
+	UserID            uuid.UUID  `json:"user_id"`
+	Email             string     `json:"email"`
+	FirstName         string     `json:"first_name"`
+	LastName          string     `json:"last_name"`
+	Role              string     `json:"role"`
+	Avatar            *string    `json:"avatar"`
+	BrandID           *uuid.UUID `json:"brand_id,omitempty"`
+	DeliveryAddress   string     `json:"delivery_address"`
+	DeliveryLatitude  *float64   `json:"delivery_latitude,omitempty"`
+	DeliveryLongitude *float64   `json:"delivery_longitude,omitempty"`
+	Version           int        `json:"version"`
+	DeletedAt         *time.Time `json:"deleted_at,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
 }
 
 func UserResponseFromModel(user *models.User) *UserResponseDto {
 	return &UserResponseDto{
-		UserID:          user.UserID,
-		Email:           user.Email,
-		FirstName:       user.FirstName,
-		LastName:        user.LastName,
-		Role:            user.Role,
-		Avatar:          user.Avatar,
-		DeliveryAddress: user.DeliveryAddress,
-		CreatedAt:       user.CreatedAt,
-		UpdatedAt:       user.UpdatedAt,
+		UserID:            user.UserID,
+		Email:             user.Email,
+		FirstName:         user.FirstName,
+		LastName:          user.LastName,
+		Role:              user.Role,
+		Avatar:            user.Avatar,
+		BrandID:           user.BrandID,
+		DeliveryAddress:   user.DeliveryAddress,
+		DeliveryLatitude:  user.DeliveryLatitude,
+		DeliveryLongitude: user.DeliveryLongitude,
+		Version:           user.Version,
+		DeletedAt:         user.DeletedAt,
+		CreatedAt:         user.CreatedAt,
+		UpdatedAt:         user.UpdatedAt,
 	}
 }
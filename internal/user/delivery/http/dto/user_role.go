@@ -0,0 +1,10 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+)
+
+type UserRoleRequestDto struct {
+	Role    string     `json:"role" validate:"required,oneof=admin user seller"`
+	BrandID *uuid.UUID `json:"brand_id"`
+}
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
 
 	"github.com/go-playground/validator"
 	"github.com/go-redis/redis/v8"
@@ -12,51 +14,56 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/brand"
 	"github.com/dinorain/kalobranded/internal/middlewares"
 	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/internal/server/router"
 	"github.com/dinorain/kalobranded/internal/session"
 	"github.com/dinorain/kalobranded/internal/user"
 	"github.com/dinorain/kalobranded/internal/user/delivery/http/dto"
 	"github.com/dinorain/kalobranded/pkg/constants"
+	"github.com/dinorain/kalobranded/pkg/geo"
 	httpErrors "github.com/dinorain/kalobranded/pkg/http_errors"
 	"github.com/dinorain/kalobranded/pkg/logger"
 	"github.com/dinorain/kalobranded/pkg/utils"
 )
 
 type userHandlersHTTP struct {
-	mux    *http.ServeMux
-	logger logger.Logger
-	cfg    *config.Config
-	mw     middlewares.MiddlewareManager
-	v      *validator.Validate
-	userUC user.UserUseCase
-	sessUC session.SessUseCase
+	router  *router.Router
+	logger  logger.Logger
+	cfg     *config.Config
+	mw      middlewares.MiddlewareManager
+	v       *validator.Validate
+	brandUC brand.BrandUseCase
+	userUC  user.UserUseCase
+	sessUC  session.SessUseCase
 }
 
 var _ user.UserHandlers = (*userHandlersHTTP)(nil)
 
 func NewUserHandlersHTTP(
-	mux *http.ServeMux,
+	router *router.Router,
 	logger logger.Logger,
 	cfg *config.Config,
 	mw middlewares.MiddlewareManager,
 	v *validator.Validate,
+	brandUC brand.BrandUseCase,
 	userUC user.UserUseCase,
 	sessUC session.SessUseCase,
 ) *userHandlersHTTP {
-	return &userHandlersHTTP{mux: mux, logger: logger, cfg: cfg, mw: mw, v: v, userUC: userUC, sessUC: sessUC}
+	return &userHandlersHTTP{router: router, logger: logger, cfg: cfg, mw: mw, v: v, brandUC: brandUC, userUC: userUC, sessUC: sessUC}
 }
 
 // Register
 // @Tags Users
 // @Summary Register user
-// @Description Create user
+// @Description Create a buyer account, admins make sellers and other admins with PUT /users/{id}/role
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
 // @Param payload body dto.UserRegisterRequestDto true "Payload"
 // @Success 200 {object} dto.UserRegisterResponseDto
-// @Router /user/create [post]
+// @Router /users [post]
 func (h *userHandlersHTTP) Register(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -74,6 +81,11 @@ func (h *userHandlersHTTP) Register(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := geo.CheckCoordinates(createDto.DeliveryLatitude, createDto.DeliveryLongitude); err != nil {
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
 	user, err := h.registerReqToUserModel(createDto)
 
 	if err != nil {
@@ -103,7 +115,7 @@ func (h *userHandlersHTTP) Register(w http.ResponseWriter, r *http.Request) {
 // @Produce json
 // @Param payload body dto.UserLoginRequestDto true "Payload"
 // @Success 200 {object} dto.UserLoginResponseDto
-// @Router /user/login [post]
+// @Router /users/login [post]
 func (h *userHandlersHTTP) Login(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -162,25 +174,43 @@ func (h *userHandlersHTTP) Login(w http.ResponseWriter, r *http.Request) {
 // FindAll
 // @Tags Users
 // @Summary Find all users
-// @Description Admin find all users
+// @Description Admin find all users, newest first unless sorted. sort is one of created_at, email, first_name and last_name, descending when prefixed with -
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
+// @Param role query string false "admin, user or seller"
+// @Param brand_id query string false "brand uuid of sellers"
+// @Param email query string false "part of the email address, case insensitive"
+// @Param sort query string false "sort key, e.g. email"
 // @Param size query string false "pagination size"
 // @Param page query string false "pagination page"
 // @Success 200 {object} dto.UserFindResponseDto
-// @Router /user [get]
+// @Param include_deleted query bool false "admin only, include soft deleted users"
+// @Router /users [get]
 func (h *userHandlersHTTP) FindAll(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	queryParam := r.URL.Query()
+	pq := utils.NewPaginationFromQueryParams(queryParam.Get(constants.Size), queryParam.Get(constants.Page))
+	pq.SetOrderBy(queryParam.Get(constants.Sort))
 
-	if queryParam.Get("id") != "" {
-		h.FindById(w, r)
+	filter, err := parseUserFilter(queryParam, pq)
+	if err != nil {
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
 	}
 
-	pq := utils.NewPaginationFromQueryParams(queryParam.Get(constants.Size), queryParam.Get(constants.Page))
-	users, err := h.userUC.FindAll(ctx, pq)
+	includeDeleted, err := h.mw.IncludeDeleted(w, r)
+	if err != nil {
+		return
+	}
+
+	findAll := h.userUC.FindAll
+	if includeDeleted {
+		findAll = h.userUC.FindAllWithDeleted
+	}
+
+	users, err := findAll(ctx, filter, pq)
 	if err != nil {
 		h.logger.Errorf("userUC.FindAll: %v", err)
 		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
@@ -206,32 +236,39 @@ func (h *userHandlersHTTP) FindAll(w http.ResponseWriter, r *http.Request) {
 // @Accept json
 // @Produce json
 // @Security ApiKeyAuth
-// @Param id query string true "user uuid"
+// @Param id path string true "user uuid"
+// @Param include_deleted query bool false "admin only, find soft deleted user"
 // @Success 200 {object} dto.UserResponseDto
-// @Router /user [get]
+// @Header 200 {string} ETag "resource version"
+// @Router /users/{id} [get]
 func (h *userHandlersHTTP) FindById(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	queryParam := r.URL.Query()
-
-	if queryParam.Get("id") == "" {
-		_ = httpErrors.NewBadRequestError(w, nil, h.cfg.Http.DebugErrorsResponse)
-		return
-	}
-	userUUID, err := uuid.Parse(queryParam.Get("id"))
+	userUUID, err := uuid.Parse(router.Param(r, constants.ID))
 	if err != nil {
 		h.logger.WarnMsg("uuid.FromString", err)
 		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
 		return
 	}
 
-	user, err := h.userUC.CachedFindById(ctx, userUUID)
+	includeDeleted, err := h.mw.IncludeDeleted(w, r)
 	if err != nil {
-		h.logger.Errorf("userUC.CachedFindById: %v", err)
+		return
+	}
+
+	findById := h.userUC.CachedFindById
+	if includeDeleted {
+		findById = h.userUC.FindByIdWithDeleted
+	}
+
+	user, err := findById(ctx, userUUID)
+	if err != nil {
+		h.logger.Errorf("userUC.FindById: %v", err)
 		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
 		return
 	}
 
+	w.Header().Set(constants.ETag, utils.ETag(user.Version))
 	res, _ := json.Marshal(dto.UserResponseFromModel(user))
 	w.WriteHeader(http.StatusOK)
 	w.Write(res)
@@ -246,7 +283,7 @@ func (h *userHandlersHTTP) FindById(w http.ResponseWriter, r *http.Request) {
 // @Produce json
 // @Security ApiKeyAuth
 // @Success 200 {object} dto.UserResponseDto
-// @Router /user/me [get]
+// @Router /users/me [get]
 func (h *userHandlersHTTP) GetMe(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	sessID, _, _, err := h.getSessionIDFromCtx(w, r)
@@ -287,7 +324,7 @@ func (h *userHandlersHTTP) GetMe(w http.ResponseWriter, r *http.Request) {
 // @Produce json
 // @Security ApiKeyAuth
 // @Success 200 {object} nil
-// @Router /user/logout [post]
+// @Router /users/logout [post]
 func (h *userHandlersHTTP) Logout(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	sessID, _, _, err := h.getSessionIDFromCtx(w, r)
@@ -313,7 +350,7 @@ func (h *userHandlersHTTP) Logout(w http.ResponseWriter, r *http.Request) {
 // @Produce json
 // @Param payload body dto.UserRefreshTokenDto true "Payload"
 // @Success 200 {object} dto.UserRefreshTokenResponseDto
-// @Router /user/refresh [post]
+// @Router /users/refresh [post]
 func (h *userHandlersHTTP) RefreshToken(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -398,6 +435,250 @@ func (h *userHandlersHTTP) RefreshToken(w http.ResponseWriter, r *http.Request)
 	return
 }
 
+// UpdateById
+// @Tags Users
+// @Summary Update user
+// @Description Update user, only provided fields are changed, users can only update themselves unless admin. Users changing their own password give their current_password, a password change signs the user out of every session
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "user uuid"
+// @Param If-Match header string false "ETag of the version being changed"
+// @Param payload body dto.UserUpdateRequestDto true "Payload"
+// @Success 200 {object} dto.UserResponseDto
+// @Header 200 {string} ETag "resource version"
+// @Router /users/{id} [patch]
+func (h *userHandlersHTTP) UpdateById(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userUUID, err := uuid.Parse(router.Param(r, constants.ID))
+	if err != nil {
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	_, userID, role, err := h.getSessionIDFromCtx(w, r)
+	if err != nil {
+		h.logger.Errorf("getSessionIDFromCtx: %v", err)
+		return
+	}
+
+	if role != models.UserRoleAdmin && userID != userUUID.String() {
+		_ = httpErrors.NewForbiddenError(w, nil, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	updateDto := &dto.UserUpdateRequestDto{}
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&updateDto); err != nil {
+		h.logger.Errorf("decoder.Decode: %v", err)
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	if err := h.v.Struct(updateDto); err != nil {
+		h.logger.Errorf("h.v.Struct: %v", err)
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	if err := geo.CheckCoordinates(updateDto.DeliveryLatitude, updateDto.DeliveryLongitude); err != nil {
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	user, err := h.userUC.FindById(ctx, userUUID)
+	if err != nil {
+		h.logger.Errorf("userUC.FindById: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	if !utils.IfMatch(r.Header.Get(constants.IfMatch), user.Version) {
+		_ = httpErrors.ErrorCtxResponse(w, httpErrors.PreconditionFailed, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	// a stolen access token must not be enough to take the account over
+	if updateDto.Password != nil && userID == userUUID.String() {
+		if updateDto.CurrentPassword == nil {
+			_ = httpErrors.NewBadRequestError(w, "current_password is required to change the password", h.cfg.Http.DebugErrorsResponse)
+			return
+		}
+		if err := user.ComparePasswords(strings.TrimSpace(*updateDto.CurrentPassword)); err != nil {
+			h.logger.Errorf("user.ComparePasswords: %v", err)
+			_ = httpErrors.ErrorCtxResponse(w, httpErrors.WrongCredentials, h.cfg.Http.DebugErrorsResponse)
+			return
+		}
+	}
+
+	if err := h.updateReqToUserModel(user, updateDto); err != nil {
+		h.logger.Errorf("updateReqToUserModel: %v", err)
+		if errors.Is(err, models.ErrDeliveryAddressRequired) {
+			_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+			return
+		}
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	updatedUser, err := h.userUC.UpdateById(ctx, user)
+	if err != nil {
+		h.logger.Errorf("userUC.UpdateById: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	if updateDto.Password != nil {
+		if err := h.sessUC.DeleteAllByUserId(ctx, updatedUser.UserID); err != nil {
+			h.logger.Errorf("sessUC.DeleteAllByUserId: %v", err)
+			_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+			return
+		}
+	}
+
+	w.Header().Set(constants.ETag, utils.ETag(updatedUser.Version))
+	res, _ := json.Marshal(dto.UserResponseFromModel(updatedUser))
+	w.WriteHeader(http.StatusOK)
+	w.Write(res)
+	return
+}
+
+// UpdateRoleById
+// @Tags Users
+// @Summary Update user role
+// @Description Admin change the role of a user, sellers are given the brand they sell for
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "user uuid"
+// @Param If-Match header string false "ETag of the version being changed"
+// @Param payload body dto.UserRoleRequestDto true "Payload"
+// @Success 200 {object} dto.UserResponseDto
+// @Header 200 {string} ETag "resource version"
+// @Router /users/{id}/role [put]
+func (h *userHandlersHTTP) UpdateRoleById(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userUUID, err := uuid.Parse(router.Param(r, constants.ID))
+	if err != nil {
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	roleDto := &dto.UserRoleRequestDto{}
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&roleDto); err != nil {
+		h.logger.Errorf("decoder.Decode: %v", err)
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	if err := h.v.Struct(roleDto); err != nil {
+		h.logger.Errorf("h.v.Struct: %v", err)
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	if roleDto.BrandID != nil {
+		if _, err := h.brandUC.CachedFindById(ctx, *roleDto.BrandID); err != nil {
+			h.logger.Errorf("brandUC.CachedFindById: %v", err)
+			_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+			return
+		}
+	}
+
+	user, err := h.userUC.FindById(ctx, userUUID)
+	if err != nil {
+		h.logger.Errorf("userUC.FindById: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	if !utils.IfMatch(r.Header.Get(constants.IfMatch), user.Version) {
+		_ = httpErrors.ErrorCtxResponse(w, httpErrors.PreconditionFailed, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	if err := user.SetRole(roleDto.Role, roleDto.BrandID); err != nil {
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	updatedUser, err := h.userUC.UpdateById(ctx, user)
+	if err != nil {
+		h.logger.Errorf("userUC.UpdateById: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	w.Header().Set(constants.ETag, utils.ETag(updatedUser.Version))
+	res, _ := json.Marshal(dto.UserResponseFromModel(updatedUser))
+	w.WriteHeader(http.StatusOK)
+	w.Write(res)
+	return
+}
+
+// DeleteById
+// @Tags Users
+// @Summary Delete user
+// @Description Admin delete user
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "user uuid"
+// @Param If-Match header string false "ETag of the version being changed"
+// @Success 204 {object} nil
+// @Router /users/{id} [delete]
+func (h *userHandlersHTTP) DeleteById(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userUUID, err := uuid.Parse(router.Param(r, constants.ID))
+	if err != nil {
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	if err := h.userUC.DeleteById(ctx, userUUID, utils.IfMatchVersions(r.Header.Get(constants.IfMatch))); err != nil {
+		h.logger.Errorf("userUC.DeleteById: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return
+}
+
+// RestoreById
+// @Tags Users
+// @Summary Restore user
+// @Description Admin restore soft deleted user
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "user uuid"
+// @Success 200 {object} dto.UserResponseDto
+// @Header 200 {string} ETag "resource version"
+// @Router /users/{id}/restore [post]
+func (h *userHandlersHTTP) RestoreById(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userUUID, err := uuid.Parse(router.Param(r, constants.ID))
+	if err != nil {
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	user, err := h.userUC.RestoreById(ctx, userUUID)
+	if err != nil {
+		h.logger.Errorf("userUC.RestoreById: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	w.Header().Set(constants.ETag, utils.ETag(user.Version))
+	res, _ := json.Marshal(dto.UserResponseFromModel(user))
+	w.WriteHeader(http.StatusOK)
+	w.Write(res)
+	return
+}
+
 func (h *userHandlersHTTP) getSessionIDFromCtx(w http.ResponseWriter, r *http.Request) (sessionID string, userID string, role string, err error) {
 	jwtClaims, err := h.mw.GetJWTClaims(w, r)
 	if err != nil {
@@ -427,13 +708,15 @@ func (h *userHandlersHTTP) getSessionIDFromCtx(w http.ResponseWriter, r *http.Re
 
 func (h *userHandlersHTTP) registerReqToUserModel(r *dto.UserRegisterRequestDto) (*models.User, error) {
 	userCandidate := &models.User{
-		Email:           r.Email,
-		FirstName:       r.FirstName,
-		LastName:        r.LastName,
-		Role:            r.Role,
-		Avatar:          nil,
-		Password:        r.Password,
-		DeliveryAddress: r.DeliveryAddress,
+		Email:             r.Email,
+		FirstName:         r.FirstName,
+		LastName:          r.LastName,
+		Role:              models.UserRoleUser,
+		Avatar:            nil,
+		Password:          r.Password,
+		DeliveryAddress:   r.DeliveryAddress,
+		DeliveryLatitude:  r.DeliveryLatitude,
+		DeliveryLongitude: r.DeliveryLongitude,
 	}
 
 	if err := userCandidate.PrepareCreate(); err != nil {
@@ -442,3 +725,46 @@ func (h *userHandlersHTTP) registerReqToUserModel(r *dto.UserRegisterRequestDto)
 
 	return userCandidate, nil
 }
+
+func (h *userHandlersHTTP) updateReqToUserModel(user *models.User, r *dto.UserUpdateRequestDto) error {
+	if r.FirstName != nil {
+		user.FirstName = strings.TrimSpace(*r.FirstName)
+	}
+	if r.LastName != nil {
+		user.LastName = strings.TrimSpace(*r.LastName)
+	}
+	if r.Avatar != nil {
+		user.Avatar = r.Avatar
+	}
+	if r.DeliveryAddress != nil {
+		deliveryAddress := strings.TrimSpace(*r.DeliveryAddress)
+		if deliveryAddress == "" {
+			return models.ErrDeliveryAddressRequired
+		}
+		if deliveryAddress != user.DeliveryAddress {
+			// coordinates of the previous address would misplace the new one, it gets geocoded instead
+			user.DeliveryLatitude, user.DeliveryLongitude = nil, nil
+		}
+		user.DeliveryAddress = deliveryAddress
+	}
+	if r.DeliveryLatitude != nil {
+		user.DeliveryLatitude, user.DeliveryLongitude = r.DeliveryLatitude, r.DeliveryLongitude
+	}
+	if r.Password != nil {
+		user.Password = strings.TrimSpace(*r.Password)
+		if err := user.HashPassword(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parseUserFilter user filter of the query parameters, checking the sort of pagination too
+func parseUserFilter(queryParam url.Values, pagination *utils.Pagination) (*models.UserFilter, error) {
+	if err := models.UserSorts.Validate(pagination.GetOrderBy()); err != nil {
+		return nil, err
+	}
+
+	return models.ParseUserFilter(queryParam)
+}
@@ -18,8 +18,10 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/dinorain/kalobranded/config"
+	mockBrandUC "github.com/dinorain/kalobranded/internal/brand/mock"
 	"github.com/dinorain/kalobranded/internal/middlewares"
 	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/internal/server/router"
 	mockSessUC "github.com/dinorain/kalobranded/internal/session/mock"
 	"github.com/dinorain/kalobranded/internal/user/delivery/http/dto"
 	"github.com/dinorain/kalobranded/internal/user/mock"
@@ -33,6 +35,7 @@ func TestUsersHandler_Register(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
+	brandUC := mockBrandUC.NewMockBrandUseCase(ctrl)
 	userUC := mock.NewMockUserUseCase(ctrl)
 	sessUC := mockSessUC.NewMockSessUseCase(ctrl)
 
@@ -42,22 +45,21 @@ func TestUsersHandler_Register(t *testing.T) {
 
 	v := validator.New()
 
-	mux := http.NewServeMux()
-	handlers := NewUserHandlersHTTP(mux, appLogger, cfg, mw, v, userUC, sessUC)
+	rt := router.NewRouter(false)
+	handlers := NewUserHandlersHTTP(rt, appLogger, cfg, mw, v, brandUC, userUC, sessUC)
 
 	reqDto := &dto.UserRegisterRequestDto{
 		Email:           "email@gmail.com",
 		FirstName:       "FirstName",
 		LastName:        "LastName",
 		Password:        "123456",
-		Role:            models.UserRoleUser,
 		DeliveryAddress: "DeliveryAddress",
 	}
 
 	buf := &bytes.Buffer{}
 	_ = json.NewEncoder(buf).Encode(reqDto)
 
-	req := httptest.NewRequest(http.MethodPost, "/user", buf)
+	req := httptest.NewRequest(http.MethodPost, "/users", buf)
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 
@@ -67,7 +69,11 @@ func TestUsersHandler_Register(t *testing.T) {
 
 	buf, _ = converter.AnyToBytesBuffer(resDto)
 
-	userUC.EXPECT().Register(gomock.Any(), gomock.Any()).AnyTimes().Return(&models.User{}, nil)
+	userUC.EXPECT().Register(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, u *models.User) (*models.User, error) {
+		require.Equal(t, models.UserRoleUser, u.Role)
+		require.Nil(t, u.BrandID)
+		return &models.User{}, nil
+	})
 
 	handler := http.HandlerFunc(handlers.Register)
 	handler.ServeHTTP(w, req)
@@ -89,6 +95,7 @@ func TestUsersHandler_Login(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
+	brandUC := mockBrandUC.NewMockBrandUseCase(ctrl)
 	userUC := mock.NewMockUserUseCase(ctrl)
 	sessUC := mockSessUC.NewMockSessUseCase(ctrl)
 
@@ -98,8 +105,8 @@ func TestUsersHandler_Login(t *testing.T) {
 
 	v := validator.New()
 
-	mux := http.NewServeMux()
-	handlers := NewUserHandlersHTTP(mux, appLogger, cfg, mw, v, userUC, sessUC)
+	rt := router.NewRouter(false)
+	handlers := NewUserHandlersHTTP(rt, appLogger, cfg, mw, v, brandUC, userUC, sessUC)
 
 	reqDto := &dto.UserLoginRequestDto{
 		Email:    "email@gmail.com",
@@ -109,7 +116,7 @@ func TestUsersHandler_Login(t *testing.T) {
 	var buf bytes.Buffer
 	_ = json.NewEncoder(&buf).Encode(reqDto)
 
-	req := httptest.NewRequest(http.MethodPost, "/user/login", &buf)
+	req := httptest.NewRequest(http.MethodPost, "/users/login", &buf)
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 
@@ -154,6 +161,7 @@ func TestUsersHandler_FindAll(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
+	brandUC := mockBrandUC.NewMockBrandUseCase(ctrl)
 	userUC := mock.NewMockUserUseCase(ctrl)
 	sessUC := mockSessUC.NewMockSessUseCase(ctrl)
 
@@ -163,10 +171,10 @@ func TestUsersHandler_FindAll(t *testing.T) {
 
 	v := validator.New()
 
-	mux := http.NewServeMux()
-	handlers := NewUserHandlersHTTP(mux, appLogger, cfg, mw, v, userUC, sessUC)
+	rt := router.NewRouter(false)
+	handlers := NewUserHandlersHTTP(rt, appLogger, cfg, mw, v, brandUC, userUC, sessUC)
 
-	req := httptest.NewRequest(http.MethodGet, "/user", nil)
+	req := httptest.NewRequest(http.MethodGet, "/users?role=user&email=gmail&sort=-last_name", nil)
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 
@@ -181,7 +189,7 @@ func TestUsersHandler_FindAll(t *testing.T) {
 		DeliveryAddress: "DeliveryAddress",
 	})
 
-	userUC.EXPECT().FindAll(gomock.Any(), gomock.Any()).AnyTimes().Return(users, nil)
+	userUC.EXPECT().FindAll(gomock.Any(), &models.UserFilter{Role: models.UserRoleUser, Email: "gmail"}, gomock.Any()).AnyTimes().Return(users, nil)
 
 	handler := http.HandlerFunc(handlers.FindAll)
 	handler.ServeHTTP(w, req)
@@ -207,6 +215,7 @@ func TestUsersHandler_FindById(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
+	brandUC := mockBrandUC.NewMockBrandUseCase(ctrl)
 	userUC := mock.NewMockUserUseCase(ctrl)
 	sessUC := mockSessUC.NewMockSessUseCase(ctrl)
 
@@ -216,12 +225,12 @@ func TestUsersHandler_FindById(t *testing.T) {
 
 	v := validator.New()
 
-	mux := http.NewServeMux()
-	handlers := NewUserHandlersHTTP(mux, appLogger, cfg, mw, v, userUC, sessUC)
+	rt := router.NewRouter(false)
+	handlers := NewUserHandlersHTTP(rt, appLogger, cfg, mw, v, brandUC, userUC, sessUC)
 
 	userUUID := uuid.New()
 
-	req := httptest.NewRequest(http.MethodGet, "/user?id="+userUUID.String(), nil)
+	req := router.WithParams(httptest.NewRequest(http.MethodGet, "/users/"+userUUID.String(), nil), map[string]string{"id": userUUID.String()})
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 
@@ -254,6 +263,7 @@ func TestUsersHandler_GetMe(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
+	brandUC := mockBrandUC.NewMockBrandUseCase(ctrl)
 	userUC := mock.NewMockUserUseCase(ctrl)
 	sessUC := mockSessUC.NewMockSessUseCase(ctrl)
 
@@ -263,8 +273,8 @@ func TestUsersHandler_GetMe(t *testing.T) {
 
 	v := validator.New()
 
-	mux := http.NewServeMux()
-	handlers := NewUserHandlersHTTP(mux, appLogger, cfg, mw, v, userUC, sessUC)
+	rt := router.NewRouter(false)
+	handlers := NewUserHandlersHTTP(rt, appLogger, cfg, mw, v, brandUC, userUC, sessUC)
 
 	userUUID := uuid.New()
 	token := jwt.New(jwt.SigningMethodHS256)
@@ -275,7 +285,7 @@ func TestUsersHandler_GetMe(t *testing.T) {
 	claims["exp"] = time.Now().Add(time.Minute * 15).Unix()
 	validToken, _ := token.SignedString([]byte("secret"))
 
-	req := httptest.NewRequest(http.MethodGet, "/user/me", nil)
+	req := httptest.NewRequest(http.MethodGet, "/users/me", nil)
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", validToken))
 
@@ -311,6 +321,7 @@ func TestUsersHandler_Logout(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
+	brandUC := mockBrandUC.NewMockBrandUseCase(ctrl)
 	userUC := mock.NewMockUserUseCase(ctrl)
 	sessUC := mockSessUC.NewMockSessUseCase(ctrl)
 
@@ -320,8 +331,8 @@ func TestUsersHandler_Logout(t *testing.T) {
 
 	v := validator.New()
 
-	mux := http.NewServeMux()
-	handlers := NewUserHandlersHTTP(mux, appLogger, cfg, mw, v, userUC, sessUC)
+	rt := router.NewRouter(false)
+	handlers := NewUserHandlersHTTP(rt, appLogger, cfg, mw, v, brandUC, userUC, sessUC)
 
 	userUUID := uuid.New()
 	token := jwt.New(jwt.SigningMethodHS256)
@@ -332,7 +343,7 @@ func TestUsersHandler_Logout(t *testing.T) {
 	claims["exp"] = time.Now().Add(time.Minute * 15).Unix()
 	validToken, _ := token.SignedString([]byte("secret"))
 
-	req := httptest.NewRequest(http.MethodGet, "/user/logout", nil)
+	req := httptest.NewRequest(http.MethodPost, "/users/logout", nil)
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", validToken))
 
@@ -359,6 +370,7 @@ func TestUsersHandler_RefreshToken(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
+	brandUC := mockBrandUC.NewMockBrandUseCase(ctrl)
 	userUC := mock.NewMockUserUseCase(ctrl)
 	sessUC := mockSessUC.NewMockSessUseCase(ctrl)
 
@@ -368,8 +380,8 @@ func TestUsersHandler_RefreshToken(t *testing.T) {
 
 	v := validator.New()
 
-	mux := http.NewServeMux()
-	handlers := NewUserHandlersHTTP(mux, appLogger, cfg, mw, v, userUC, sessUC)
+	rt := router.NewRouter(false)
+	handlers := NewUserHandlersHTTP(rt, appLogger, cfg, mw, v, brandUC, userUC, sessUC)
 
 	token := jwt.New(jwt.SigningMethodHS256)
 	claims := token.Claims.(jwt.MapClaims)
@@ -384,7 +396,7 @@ func TestUsersHandler_RefreshToken(t *testing.T) {
 	buf := &bytes.Buffer{}
 	_ = json.NewEncoder(buf).Encode(reqDto)
 
-	req := httptest.NewRequest(http.MethodPost, "/user/refresh", buf)
+	req := httptest.NewRequest(http.MethodPost, "/users/refresh", buf)
 	req.Header.Set("Content-Type", "application/json")
 
 	w := httptest.NewRecorder()
@@ -405,3 +417,278 @@ func TestUsersHandler_RefreshToken(t *testing.T) {
 	require.NotNil(t, data)
 	require.Equal(t, http.StatusOK, w.Code)
 }
+
+func TestUsersHandler_UpdateById(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	brandUC := mockBrandUC.NewMockBrandUseCase(ctrl)
+	userUC := mock.NewMockUserUseCase(ctrl)
+	sessUC := mockSessUC.NewMockSessUseCase(ctrl)
+
+	cfg := &config.Config{Session: config.Session{Expire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	appLogger := logger.NewAppLogger(cfg)
+	appLogger.InitLogger()
+	mw := middlewares.NewMiddlewareManager(appLogger, cfg)
+
+	v := validator.New()
+
+	rt := router.NewRouter(false)
+	handlers := NewUserHandlersHTTP(rt, appLogger, cfg, mw, v, brandUC, userUC, sessUC)
+
+	userUUID := uuid.New()
+	tokenFor := func(userID string, role string) string {
+		token := jwt.New(jwt.SigningMethodHS256)
+		claims := token.Claims.(jwt.MapClaims)
+		claims["session_id"] = uuid.New().String()
+		claims["user_id"] = userID
+		claims["role"] = role
+		claims["exp"] = time.Now().Add(time.Minute * 15).Unix()
+		validToken, _ := token.SignedString([]byte("secret"))
+		return validToken
+	}
+
+	storedUser := func() *models.User {
+		u := &models.User{UserID: userUUID, FirstName: "FirstName", LastName: "LastName", Password: "oldpassword"}
+		require.NoError(t, u.HashPassword())
+		return u
+	}
+
+	t.Run("Self", func(t *testing.T) {
+		req := router.WithParams(httptest.NewRequest(http.MethodPatch, "/users/"+userUUID.String(), strings.NewReader(`{"first_name": "NewFirstName", "password": "newpassword", "current_password": "oldpassword"}`)), map[string]string{"id": userUUID.String()})
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", tokenFor(userUUID.String(), models.UserRoleUser)))
+		w := httptest.NewRecorder()
+
+		userUC.EXPECT().FindById(gomock.Any(), userUUID).Return(storedUser(), nil)
+		userUC.EXPECT().UpdateById(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, u *models.User) (*models.User, error) {
+			require.Equal(t, "NewFirstName", u.FirstName)
+			require.Equal(t, "LastName", u.LastName)
+			require.NoError(t, u.ComparePasswords("newpassword"))
+			return u, nil
+		})
+		sessUC.EXPECT().DeleteAllByUserId(gomock.Any(), userUUID).Return(nil)
+
+		http.HandlerFunc(handlers.UpdateById).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		resDto := &dto.UserResponseDto{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), resDto))
+		require.Equal(t, "NewFirstName", resDto.FirstName)
+	})
+
+	t.Run("PasswordWithoutCurrentPassword", func(t *testing.T) {
+		req := router.WithParams(httptest.NewRequest(http.MethodPatch, "/users/"+userUUID.String(), strings.NewReader(`{"password": "newpassword"}`)), map[string]string{"id": userUUID.String()})
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", tokenFor(userUUID.String(), models.UserRoleUser)))
+		w := httptest.NewRecorder()
+
+		userUC.EXPECT().FindById(gomock.Any(), userUUID).Return(storedUser(), nil)
+
+		http.HandlerFunc(handlers.UpdateById).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("WrongCurrentPassword", func(t *testing.T) {
+		req := router.WithParams(httptest.NewRequest(http.MethodPatch, "/users/"+userUUID.String(), strings.NewReader(`{"password": "newpassword", "current_password": "wrongpassword"}`)), map[string]string{"id": userUUID.String()})
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", tokenFor(userUUID.String(), models.UserRoleUser)))
+		w := httptest.NewRecorder()
+
+		userUC.EXPECT().FindById(gomock.Any(), userUUID).Return(storedUser(), nil)
+
+		http.HandlerFunc(handlers.UpdateById).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("AdminSetsPassword", func(t *testing.T) {
+		req := router.WithParams(httptest.NewRequest(http.MethodPatch, "/users/"+userUUID.String(), strings.NewReader(`{"password": "newpassword"}`)), map[string]string{"id": userUUID.String()})
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", tokenFor(uuid.New().String(), models.UserRoleAdmin)))
+		w := httptest.NewRecorder()
+
+		userUC.EXPECT().FindById(gomock.Any(), userUUID).Return(storedUser(), nil)
+		userUC.EXPECT().UpdateById(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, u *models.User) (*models.User, error) {
+			require.NoError(t, u.ComparePasswords("newpassword"))
+			return u, nil
+		})
+		sessUC.EXPECT().DeleteAllByUserId(gomock.Any(), userUUID).Return(nil)
+
+		http.HandlerFunc(handlers.UpdateById).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("OtherUser", func(t *testing.T) {
+		req := router.WithParams(httptest.NewRequest(http.MethodPatch, "/users/"+userUUID.String(), strings.NewReader(`{"first_name": "NewFirstName"}`)), map[string]string{"id": userUUID.String()})
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", tokenFor(uuid.New().String(), models.UserRoleUser)))
+		w := httptest.NewRecorder()
+
+		http.HandlerFunc(handlers.UpdateById).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("Admin", func(t *testing.T) {
+		req := router.WithParams(httptest.NewRequest(http.MethodPatch, "/users/"+userUUID.String(), strings.NewReader(`{"last_name": "NewLastName"}`)), map[string]string{"id": userUUID.String()})
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", tokenFor(uuid.New().String(), models.UserRoleAdmin)))
+		w := httptest.NewRecorder()
+
+		userUC.EXPECT().FindById(gomock.Any(), userUUID).Return(&models.User{UserID: userUUID, LastName: "LastName"}, nil)
+		userUC.EXPECT().UpdateById(gomock.Any(), &models.User{UserID: userUUID, LastName: "NewLastName"}).Return(&models.User{UserID: userUUID, LastName: "NewLastName"}, nil)
+
+		http.HandlerFunc(handlers.UpdateById).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("EmptyDeliveryAddress", func(t *testing.T) {
+		req := router.WithParams(httptest.NewRequest(http.MethodPatch, "/users/"+userUUID.String(), strings.NewReader(`{"delivery_address": "  "}`)), map[string]string{"id": userUUID.String()})
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", tokenFor(userUUID.String(), models.UserRoleUser)))
+		w := httptest.NewRecorder()
+
+		userUC.EXPECT().FindById(gomock.Any(), userUUID).Return(&models.User{UserID: userUUID, DeliveryAddress: "Home, Bandung, ID"}, nil)
+
+		http.HandlerFunc(handlers.UpdateById).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestUsersHandler_UpdateRoleById(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	brandUC := mockBrandUC.NewMockBrandUseCase(ctrl)
+	userUC := mock.NewMockUserUseCase(ctrl)
+	sessUC := mockSessUC.NewMockSessUseCase(ctrl)
+
+	cfg := &config.Config{Session: config.Session{Expire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	appLogger := logger.NewAppLogger(cfg)
+	appLogger.InitLogger()
+	mw := middlewares.NewMiddlewareManager(appLogger, cfg)
+
+	v := validator.New()
+
+	rt := router.NewRouter(false)
+	handlers := NewUserHandlersHTTP(rt, appLogger, cfg, mw, v, brandUC, userUC, sessUC)
+
+	userUUID := uuid.New()
+	brandUUID := uuid.New()
+	newRequest := func(body string) *http.Request {
+		return router.WithParams(httptest.NewRequest(http.MethodPut, "/users/"+userUUID.String()+"/role", strings.NewReader(body)), map[string]string{"id": userUUID.String()})
+	}
+
+	t.Run("Seller", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		brandUC.EXPECT().CachedFindById(gomock.Any(), brandUUID).Return(&models.Brand{BrandID: brandUUID}, nil)
+		userUC.EXPECT().FindById(gomock.Any(), userUUID).Return(&models.User{UserID: userUUID, Role: models.UserRoleUser, Version: 1}, nil)
+		userUC.EXPECT().UpdateById(gomock.Any(), &models.User{UserID: userUUID, Role: models.UserRoleSeller, BrandID: &brandUUID, Version: 1}).
+			Return(&models.User{UserID: userUUID, Role: models.UserRoleSeller, BrandID: &brandUUID, Version: 2}, nil)
+
+		http.HandlerFunc(handlers.UpdateRoleById).ServeHTTP(w, newRequest(fmt.Sprintf(`{"role": "seller", "brand_id": %q}`, brandUUID)))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, `"2"`, w.Header().Get("ETag"))
+		resDto := &dto.UserResponseDto{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), resDto))
+		require.Equal(t, models.UserRoleSeller, resDto.Role)
+		require.Equal(t, &brandUUID, resDto.BrandID)
+	})
+
+	t.Run("SellerWithoutBrand", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		userUC.EXPECT().FindById(gomock.Any(), userUUID).Return(&models.User{UserID: userUUID, Role: models.UserRoleUser}, nil)
+
+		http.HandlerFunc(handlers.UpdateRoleById).ServeHTTP(w, newRequest(`{"role": "seller"}`))
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("InvalidRole", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		http.HandlerFunc(handlers.UpdateRoleById).ServeHTTP(w, newRequest(`{"role": "owner"}`))
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("VersionMismatch", func(t *testing.T) {
+		req := newRequest(`{"role": "admin"}`)
+		req.Header.Set("If-Match", `"1"`)
+		w := httptest.NewRecorder()
+
+		userUC.EXPECT().FindById(gomock.Any(), userUUID).Return(&models.User{UserID: userUUID, Role: models.UserRoleUser, Version: 2}, nil)
+
+		http.HandlerFunc(handlers.UpdateRoleById).ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusPreconditionFailed, w.Code)
+	})
+}
+
+func TestUsersHandler_DeleteById(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	brandUC := mockBrandUC.NewMockBrandUseCase(ctrl)
+	userUC := mock.NewMockUserUseCase(ctrl)
+	sessUC := mockSessUC.NewMockSessUseCase(ctrl)
+
+	cfg := &config.Config{Session: config.Session{Expire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	appLogger := logger.NewAppLogger(cfg)
+	mw := middlewares.NewMiddlewareManager(appLogger, cfg)
+
+	v := validator.New()
+
+	rt := router.NewRouter(false)
+	handlers := NewUserHandlersHTTP(rt, appLogger, cfg, mw, v, brandUC, userUC, sessUC)
+
+	userUUID := uuid.New()
+
+	req := router.WithParams(httptest.NewRequest(http.MethodDelete, "/users/"+userUUID.String(), nil), map[string]string{"id": userUUID.String()})
+	w := httptest.NewRecorder()
+
+	userUC.EXPECT().DeleteById(gomock.Any(), userUUID, nil).Return(nil)
+
+	http.HandlerFunc(handlers.DeleteById).ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusNoContent, w.Code)
+}
+
+func TestUsersHandler_RestoreById(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	brandUC := mockBrandUC.NewMockBrandUseCase(ctrl)
+	userUC := mock.NewMockUserUseCase(ctrl)
+	sessUC := mockSessUC.NewMockSessUseCase(ctrl)
+
+	cfg := &config.Config{Session: config.Session{Expire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	appLogger := logger.NewAppLogger(cfg)
+	mw := middlewares.NewMiddlewareManager(appLogger, cfg)
+
+	v := validator.New()
+
+	rt := router.NewRouter(false)
+	handlers := NewUserHandlersHTTP(rt, appLogger, cfg, mw, v, brandUC, userUC, sessUC)
+
+	userUUID := uuid.New()
+
+	req := router.WithParams(httptest.NewRequest(http.MethodPost, "/users/"+userUUID.String()+"/restore", nil), map[string]string{"id": userUUID.String()})
+	w := httptest.NewRecorder()
+
+	userUC.EXPECT().RestoreById(gomock.Any(), userUUID).Return(&models.User{UserID: userUUID, Version: 2}, nil)
+
+	http.HandlerFunc(handlers.RestoreById).ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, `"2"`, w.Header().Get("ETag"))
+}
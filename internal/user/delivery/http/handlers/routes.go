@@ -1,13 +1,18 @@
 package handlers
 
-import "net/http"
-
 func (h *userHandlersHTTP) UserMapRoutes() {
-	h.mux.Handle("/user/create", http.HandlerFunc(h.Register))
-	h.mux.Handle("/user", h.mw.GetHandler(http.HandlerFunc(h.FindAll)))
-	h.mux.Handle("/user?id=", h.mw.GetHandler(http.HandlerFunc(h.FindById)))
-	h.mux.Handle("/user/me", h.mw.GetHandler(http.HandlerFunc(h.GetMe)))
-	h.mux.Handle("/user/login", h.mw.PostHandler(http.HandlerFunc(h.Login)))
-	h.mux.Handle("/user/logout", h.mw.PostHandler(http.HandlerFunc(h.Logout)))
-	h.mux.Handle("/user/refresh", h.mw.PostHandler(http.HandlerFunc(h.RefreshToken)))
+	users := h.router.Group("/users")
+	users.Post("", h.Register)
+	users.Post("/login", h.Login)
+	users.Post("/logout", h.Logout)
+	users.Post("/refresh", h.RefreshToken)
+	users.Get("/me", h.GetMe)
+	users.Patch("/{id}", h.UpdateById, h.mw.IsLoggedIn)
+
+	admin := users.Group("", h.mw.IsAdmin)
+	admin.Get("", h.FindAll)
+	admin.Get("/{id}", h.FindById)
+	admin.Put("/{id}/role", h.UpdateRoleById)
+	admin.Delete("/{id}", h.DeleteById)
+	admin.Post("/{id}/restore", h.RestoreById)
 }
@@ -13,4 +13,8 @@ type UserHandlers interface {
 	FindById(w http.ResponseWriter, r *http.Request)
 	Logout(w http.ResponseWriter, r *http.Request)
 	RefreshToken(w http.ResponseWriter, r *http.Request)
+	UpdateById(w http.ResponseWriter, r *http.Request)
+	UpdateRoleById(w http.ResponseWriter, r *http.Request)
+	DeleteById(w http.ResponseWriter, r *http.Request)
+	RestoreById(w http.ResponseWriter, r *http.Request)
 }
@@ -7,6 +7,7 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	models "github.com/dinorain/kalobranded/internal/models"
 	utils "github.com/dinorain/kalobranded/pkg/utils"
@@ -53,32 +54,61 @@ func (mr *MockUserPGRepositoryMockRecorder) Create(ctx, user interface{}) *gomoc
 }
 
 // DeleteById mocks base method.
-func (m *MockUserPGRepository) DeleteById(ctx context.Context, userID uuid.UUID) error {
+func (m *MockUserPGRepository) DeleteById(ctx context.Context, userID uuid.UUID, versions []int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteById", ctx, userID)
+	ret := m.ctrl.Call(m, "DeleteById", ctx, userID, versions)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteById indicates an expected call of DeleteById.
-func (mr *MockUserPGRepositoryMockRecorder) DeleteById(ctx, userID interface{}) *gomock.Call {
+func (mr *MockUserPGRepositoryMockRecorder) DeleteById(ctx, userID, versions interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockUserPGRepository)(nil).DeleteById), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockUserPGRepository)(nil).DeleteById), ctx, userID, versions)
+}
+
+// Export mocks base method.
+func (m *MockUserPGRepository) Export(ctx context.Context, filter *models.UserFilter, sort string, fn func(*models.User) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, filter, sort, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockUserPGRepositoryMockRecorder) Export(ctx, filter, sort, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockUserPGRepository)(nil).Export), ctx, filter, sort, fn)
 }
 
 // FindAll mocks base method.
-func (m *MockUserPGRepository) FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.User, error) {
+func (m *MockUserPGRepository) FindAll(ctx context.Context, filter *models.UserFilter, pagination *utils.Pagination) ([]models.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAll", ctx, pagination)
+	ret := m.ctrl.Call(m, "FindAll", ctx, filter, pagination)
 	ret0, _ := ret[0].([]models.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindAll indicates an expected call of FindAll.
-func (mr *MockUserPGRepositoryMockRecorder) FindAll(ctx, pagination interface{}) *gomock.Call {
+func (mr *MockUserPGRepositoryMockRecorder) FindAll(ctx, filter, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockUserPGRepository)(nil).FindAll), ctx, filter, pagination)
+}
+
+// FindAllWithDeleted mocks base method.
+func (m *MockUserPGRepository) FindAllWithDeleted(ctx context.Context, filter *models.UserFilter, pagination *utils.Pagination) ([]models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllWithDeleted", ctx, filter, pagination)
+	ret0, _ := ret[0].([]models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllWithDeleted indicates an expected call of FindAllWithDeleted.
+func (mr *MockUserPGRepositoryMockRecorder) FindAllWithDeleted(ctx, filter, pagination interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockUserPGRepository)(nil).FindAll), ctx, pagination)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllWithDeleted", reflect.TypeOf((*MockUserPGRepository)(nil).FindAllWithDeleted), ctx, filter, pagination)
 }
 
 // FindByEmail mocks base method.
@@ -111,6 +141,51 @@ func (mr *MockUserPGRepositoryMockRecorder) FindById(ctx, userID interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockUserPGRepository)(nil).FindById), ctx, userID)
 }
 
+// FindByIdWithDeleted mocks base method.
+func (m *MockUserPGRepository) FindByIdWithDeleted(ctx context.Context, userID uuid.UUID) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIdWithDeleted", ctx, userID)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIdWithDeleted indicates an expected call of FindByIdWithDeleted.
+func (mr *MockUserPGRepositoryMockRecorder) FindByIdWithDeleted(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIdWithDeleted", reflect.TypeOf((*MockUserPGRepository)(nil).FindByIdWithDeleted), ctx, userID)
+}
+
+// PurgeDeleted mocks base method.
+func (m *MockUserPGRepository) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeleted", ctx, deletedBefore)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeleted indicates an expected call of PurgeDeleted.
+func (mr *MockUserPGRepositoryMockRecorder) PurgeDeleted(ctx, deletedBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeleted", reflect.TypeOf((*MockUserPGRepository)(nil).PurgeDeleted), ctx, deletedBefore)
+}
+
+// RestoreById mocks base method.
+func (m *MockUserPGRepository) RestoreById(ctx context.Context, userID uuid.UUID) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreById", ctx, userID)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreById indicates an expected call of RestoreById.
+func (mr *MockUserPGRepositoryMockRecorder) RestoreById(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreById", reflect.TypeOf((*MockUserPGRepository)(nil).RestoreById), ctx, userID)
+}
+
 // UpdateById mocks base method.
 func (m *MockUserPGRepository) UpdateById(ctx context.Context, user *models.User) (*models.User, error) {
 	m.ctrl.T.Helper()
@@ -7,6 +7,7 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	models "github.com/dinorain/kalobranded/internal/models"
 	utils "github.com/dinorain/kalobranded/pkg/utils"
@@ -53,32 +54,61 @@ func (mr *MockUserUseCaseMockRecorder) CachedFindById(ctx, userID interface{}) *
 }
 
 // DeleteById mocks base method.
-func (m *MockUserUseCase) DeleteById(ctx context.Context, userID uuid.UUID) error {
+func (m *MockUserUseCase) DeleteById(ctx context.Context, userID uuid.UUID, versions []int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteById", ctx, userID)
+	ret := m.ctrl.Call(m, "DeleteById", ctx, userID, versions)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteById indicates an expected call of DeleteById.
-func (mr *MockUserUseCaseMockRecorder) DeleteById(ctx, userID interface{}) *gomock.Call {
+func (mr *MockUserUseCaseMockRecorder) DeleteById(ctx, userID, versions interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockUserUseCase)(nil).DeleteById), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockUserUseCase)(nil).DeleteById), ctx, userID, versions)
+}
+
+// Export mocks base method.
+func (m *MockUserUseCase) Export(ctx context.Context, filter *models.UserFilter, sort string, fn func(*models.User) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, filter, sort, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockUserUseCaseMockRecorder) Export(ctx, filter, sort, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockUserUseCase)(nil).Export), ctx, filter, sort, fn)
 }
 
 // FindAll mocks base method.
-func (m *MockUserUseCase) FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.User, error) {
+func (m *MockUserUseCase) FindAll(ctx context.Context, filter *models.UserFilter, pagination *utils.Pagination) ([]models.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAll", ctx, pagination)
+	ret := m.ctrl.Call(m, "FindAll", ctx, filter, pagination)
 	ret0, _ := ret[0].([]models.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindAll indicates an expected call of FindAll.
-func (mr *MockUserUseCaseMockRecorder) FindAll(ctx, pagination interface{}) *gomock.Call {
+func (mr *MockUserUseCaseMockRecorder) FindAll(ctx, filter, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockUserUseCase)(nil).FindAll), ctx, filter, pagination)
+}
+
+// FindAllWithDeleted mocks base method.
+func (m *MockUserUseCase) FindAllWithDeleted(ctx context.Context, filter *models.UserFilter, pagination *utils.Pagination) ([]models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllWithDeleted", ctx, filter, pagination)
+	ret0, _ := ret[0].([]models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllWithDeleted indicates an expected call of FindAllWithDeleted.
+func (mr *MockUserUseCaseMockRecorder) FindAllWithDeleted(ctx, filter, pagination interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockUserUseCase)(nil).FindAll), ctx, pagination)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllWithDeleted", reflect.TypeOf((*MockUserUseCase)(nil).FindAllWithDeleted), ctx, filter, pagination)
 }
 
 // FindByEmail mocks base method.
@@ -111,6 +141,21 @@ func (mr *MockUserUseCaseMockRecorder) FindById(ctx, userID interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockUserUseCase)(nil).FindById), ctx, userID)
 }
 
+// FindByIdWithDeleted mocks base method.
+func (m *MockUserUseCase) FindByIdWithDeleted(ctx context.Context, userID uuid.UUID) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIdWithDeleted", ctx, userID)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIdWithDeleted indicates an expected call of FindByIdWithDeleted.
+func (mr *MockUserUseCaseMockRecorder) FindByIdWithDeleted(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIdWithDeleted", reflect.TypeOf((*MockUserUseCase)(nil).FindByIdWithDeleted), ctx, userID)
+}
+
 // GenerateTokenPair mocks base method.
 func (m *MockUserUseCase) GenerateTokenPair(user *models.User, sessionID string) (string, string, error) {
 	m.ctrl.T.Helper()
@@ -142,6 +187,21 @@ func (mr *MockUserUseCaseMockRecorder) Login(ctx, email, password interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockUserUseCase)(nil).Login), ctx, email, password)
 }
 
+// PurgeDeleted mocks base method.
+func (m *MockUserUseCase) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeleted", ctx, deletedBefore)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeleted indicates an expected call of PurgeDeleted.
+func (mr *MockUserUseCaseMockRecorder) PurgeDeleted(ctx, deletedBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeleted", reflect.TypeOf((*MockUserUseCase)(nil).PurgeDeleted), ctx, deletedBefore)
+}
+
 // Register mocks base method.
 func (m *MockUserUseCase) Register(ctx context.Context, user *models.User) (*models.User, error) {
 	m.ctrl.T.Helper()
@@ -157,6 +217,21 @@ func (mr *MockUserUseCaseMockRecorder) Register(ctx, user interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockUserUseCase)(nil).Register), ctx, user)
 }
 
+// RestoreById mocks base method.
+func (m *MockUserUseCase) RestoreById(ctx context.Context, userID uuid.UUID) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreById", ctx, userID)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreById indicates an expected call of RestoreById.
+func (mr *MockUserUseCaseMockRecorder) RestoreById(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreById", reflect.TypeOf((*MockUserUseCase)(nil).RestoreById), ctx, userID)
+}
+
 // UpdateById mocks base method.
 func (m *MockUserUseCase) UpdateById(ctx context.Context, user *models.User) (*models.User, error) {
 	m.ctrl.T.Helper()
@@ -3,6 +3,7 @@ package user
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -13,9 +14,14 @@ import (
 // User pg repository
 type UserPGRepository interface {
 	Create(ctx context.Context, user *models.User) (*models.User, error)
-	FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.User, error)
+	FindAll(ctx context.Context, filter *models.UserFilter, pagination *utils.Pagination) ([]models.User, error)
+	Export(ctx context.Context, filter *models.UserFilter, sort string, fn func(user *models.User) error) error
 	FindByEmail(ctx context.Context, email string) (*models.User, error)
 	FindById(ctx context.Context, userID uuid.UUID) (*models.User, error)
 	UpdateById(ctx context.Context, user *models.User) (*models.User, error)
-	DeleteById(ctx context.Context, userID uuid.UUID) error
+	DeleteById(ctx context.Context, userID uuid.UUID, versions []int) error
+	FindAllWithDeleted(ctx context.Context, filter *models.UserFilter, pagination *utils.Pagination) ([]models.User, error)
+	FindByIdWithDeleted(ctx context.Context, userID uuid.UUID) (*models.User, error)
+	RestoreById(ctx context.Context, userID uuid.UUID) (*models.User, error)
+	PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error)
 }
@@ -3,12 +3,15 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/pkg/errors"
 
 	"github.com/dinorain/kalobranded/internal/models"
+	outboxRepository "github.com/dinorain/kalobranded/internal/outbox/repository"
 	"github.com/dinorain/kalobranded/internal/user"
 	"github.com/dinorain/kalobranded/pkg/utils"
 )
@@ -25,12 +28,33 @@ func NewUserPGRepository(db *sqlx.DB) *UserRepository {
 	return &UserRepository{db: db}
 }
 
-// Create new user
+// Create new user along with its user.registered event
 func (r *UserRepository) Create(ctx context.Context, user *models.User) (*models.User, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "UserRepository.Create.BeginTxx")
+	}
+	defer tx.Rollback()
+
+	createdUser, err := CreateUser(ctx, tx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "UserRepository.Create.Commit")
+	}
+
+	return createdUser, nil
+}
+
+// CreateUser insert user with its registered event, other repositories call it with the transaction the user is
+// created in
+func CreateUser(ctx context.Context, tx *sqlx.Tx, user *models.User) (*models.User, error) {
 	createdUser := &models.User{}
-	if err := r.db.QueryRowxContext(
+	if err := tx.QueryRowxContext(
 		ctx,
-		createUserQuery,
+		CreateUserQuery,
 		user.FirstName,
 		user.LastName,
 		user.Email,
@@ -38,14 +62,21 @@ func (r *UserRepository) Create(ctx context.Context, user *models.User) (*models
 		user.Role,
 		user.Avatar,
 		user.DeliveryAddress,
+		user.DeliveryLatitude,
+		user.DeliveryLongitude,
+		user.BrandID,
 	).StructScan(createdUser); err != nil {
 		return nil, errors.Wrap(err, "UserRepository.Create.QueryRowxContext")
 	}
 
+	if err := outboxRepository.AddEvent(ctx, tx, models.AggregateUser, createdUser.UserID, models.EventUserRegistered, createdUser); err != nil {
+		return nil, errors.Wrap(err, "UserRepository.Create.AddEvent")
+	}
+
 	return createdUser, nil
 }
 
-// UpdateById update existing user
+// UpdateById update existing user when its version is unchanged, bumping the version
 func (r *UserRepository) UpdateById(ctx context.Context, user *models.User) (*models.User, error) {
 	if res, err := r.db.ExecContext(
 		ctx,
@@ -58,28 +89,68 @@ func (r *UserRepository) UpdateById(ctx context.Context, user *models.User) (*mo
 		user.Role,
 		user.Avatar,
 		user.DeliveryAddress,
+		user.DeliveryLatitude,
+		user.DeliveryLongitude,
+		user.BrandID,
+		user.Version,
 	); err != nil {
 		return nil, errors.Wrap(err, "UserRepository.Update.ExecContext")
 	} else {
-		_, err := res.RowsAffected()
+		cnt, err := res.RowsAffected()
 		if err != nil {
 			return nil, errors.Wrap(err, "UserRepository.Update.RowsAffected")
+		} else if cnt == 0 {
+			return nil, models.ErrVersionConflict
 		}
 	}
 
+	user.Version++
+
 	return user, nil
 }
 
-// FindAll Find users
-func (r *UserRepository) FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.User, error) {
+// FindAll Find users matching filter
+func (r *UserRepository) FindAll(ctx context.Context, filter *models.UserFilter, pagination *utils.Pagination) ([]models.User, error) {
+	query, args, err := userListQuery(findAllQuery, filter, false).Paginate(models.UserSorts, pagination).Build()
+	if err != nil {
+		return nil, errors.Wrap(err, "UserRepository.FindAll.Build")
+	}
+
 	var users []models.User
-	if err := r.db.SelectContext(ctx, &users, findAllQuery, pagination.GetLimit(), pagination.GetOffset()); err != nil {
-		return nil, errors.Wrap(err, "UserRepository.FindById.SelectContext")
+	if err := r.db.SelectContext(ctx, &users, query, args...); err != nil {
+		return nil, errors.Wrap(err, "UserRepository.FindAll.SelectContext")
 	}
 
 	return users, nil
 }
 
+// Export call fn with each user matching filter in turn, sorted by sort out of models.UserSorts. The users are
+// read from the database as fn takes them rather than all at once, the first error of fn stops the export
+func (r *UserRepository) Export(ctx context.Context, filter *models.UserFilter, sort string, fn func(user *models.User) error) error {
+	query, args, err := userListQuery(exportQuery, filter, false).OrderBy(models.UserSorts, sort).Build()
+	if err != nil {
+		return errors.Wrap(err, "UserRepository.Export.Build")
+	}
+
+	rows, err := r.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return errors.Wrap(err, "UserRepository.Export.QueryxContext")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		user := &models.User{}
+		if err := rows.StructScan(user); err != nil {
+			return errors.Wrap(err, "UserRepository.Export.StructScan")
+		}
+		if err := fn(user); err != nil {
+			return err
+		}
+	}
+
+	return errors.Wrap(rows.Err(), "UserRepository.Export.Rows")
+}
+
 // FindByEmail Find by user email address
 func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*models.User, error) {
 	user := &models.User{}
@@ -100,14 +171,16 @@ func (r *UserRepository) FindById(ctx context.Context, userID uuid.UUID) (*model
 	return user, nil
 }
 
-// DeleteById Find user by uuid
-func (r *UserRepository) DeleteById(ctx context.Context, userID uuid.UUID) error {
-	if res, err := r.db.ExecContext(ctx, deleteByIdQuery, userID); err != nil {
+// DeleteById soft delete user by uuid, at one of versions unless nil
+func (r *UserRepository) DeleteById(ctx context.Context, userID uuid.UUID, versions []int) error {
+	if res, err := r.db.ExecContext(ctx, deleteByIdQuery, userID, pq.Array(versions)); err != nil {
 		return errors.Wrap(err, "UserRepository.DeleteById.ExecContext")
 	} else {
 		cnt, err := res.RowsAffected()
 		if err != nil {
 			return errors.Wrap(err, "UserRepository.DeleteById.RowsAffected")
+		} else if cnt == 0 && versions != nil {
+			return models.ErrPreconditionFailed
 		} else if cnt == 0 {
 			return sql.ErrNoRows
 		}
@@ -115,3 +188,76 @@ func (r *UserRepository) DeleteById(ctx context.Context, userID uuid.UUID) error
 
 	return nil
 }
+
+// FindAllWithDeleted Find users matching filter including soft deleted ones
+func (r *UserRepository) FindAllWithDeleted(ctx context.Context, filter *models.UserFilter, pagination *utils.Pagination) ([]models.User, error) {
+	query, args, err := userListQuery(findAllQuery, filter, true).Paginate(models.UserSorts, pagination).Build()
+	if err != nil {
+		return nil, errors.Wrap(err, "UserRepository.FindAllWithDeleted.Build")
+	}
+
+	var users []models.User
+	if err := r.db.SelectContext(ctx, &users, query, args...); err != nil {
+		return nil, errors.Wrap(err, "UserRepository.FindAllWithDeleted.SelectContext")
+	}
+
+	return users, nil
+}
+
+// FindByIdWithDeleted Find user by uuid including soft deleted ones
+func (r *UserRepository) FindByIdWithDeleted(ctx context.Context, userID uuid.UUID) (*models.User, error) {
+	user := &models.User{}
+	if err := r.db.GetContext(ctx, user, findByIdWithDeletedQuery, userID); err != nil {
+		return nil, errors.Wrap(err, "UserRepository.FindByIdWithDeleted.GetContext")
+	}
+
+	return user, nil
+}
+
+// RestoreById restore soft deleted user by uuid
+func (r *UserRepository) RestoreById(ctx context.Context, userID uuid.UUID) (*models.User, error) {
+	user := &models.User{}
+	if err := r.db.QueryRowxContext(ctx, restoreByIdQuery, userID).StructScan(user); err != nil {
+		return nil, errors.Wrap(err, "UserRepository.RestoreById.QueryRowxContext")
+	}
+
+	return user, nil
+}
+
+// PurgeDeleted hard delete users soft deleted before deletedBefore, returns purged count
+func (r *UserRepository) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	res, err := r.db.ExecContext(ctx, purgeDeletedQuery, deletedBefore)
+	if err != nil {
+		return 0, errors.Wrap(err, "UserRepository.PurgeDeleted.ExecContext")
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "UserRepository.PurgeDeleted.RowsAffected")
+	}
+
+	return cnt, nil
+}
+
+// userListQuery list of the users matching filter, selected by query
+func userListQuery(query string, filter *models.UserFilter, withDeleted bool) *utils.QueryBuilder {
+	qb := utils.NewQueryBuilder(query)
+	if !withDeleted {
+		qb.Where("deleted_at IS NULL")
+	}
+	if filter == nil {
+		return qb
+	}
+
+	if filter.Role != "" {
+		qb.Where("role = ?", filter.Role)
+	}
+	if filter.BrandID != nil {
+		qb.Where("brand_id = ?", *filter.BrandID)
+	}
+	if filter.Email != "" {
+		qb.Where("email ILIKE ?", utils.ContainsPattern(filter.Email))
+	}
+
+	return qb
+}
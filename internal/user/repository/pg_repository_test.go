@@ -2,15 +2,18 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/require"
 
 	"github.com/dinorain/kalobranded/internal/models"
+	outboxRepository "github.com/dinorain/kalobranded/internal/outbox/repository"
 	"github.com/dinorain/kalobranded/pkg/utils"
 )
 
@@ -52,7 +55,8 @@ func TestUserRepository_Create(t *testing.T) {
 		time.Now(),
 	)
 
-	mock.ExpectQuery(createUserQuery).WithArgs(
+	mock.ExpectBegin()
+	mock.ExpectQuery(CreateUserQuery).WithArgs(
 		mockUser.FirstName,
 		mockUser.LastName,
 		mockUser.Email,
@@ -60,7 +64,12 @@ func TestUserRepository_Create(t *testing.T) {
 		mockUser.Role,
 		mockUser.Avatar,
 		mockUser.DeliveryAddress,
+		mockUser.DeliveryLatitude,
+		mockUser.DeliveryLongitude,
+		mockUser.BrandID,
 	).WillReturnRows(rows)
+	mock.ExpectExec(outboxRepository.AddEventQuery).WithArgs(sqlmock.AnyArg(), models.AggregateUser, userUUID, models.EventUserRegistered, sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
 
 	createdUser, err := userPGRepository.Create(context.Background(), mockUser)
 	require.NoError(t, err)
@@ -152,16 +161,57 @@ func TestUserRepository_FindAll(t *testing.T) {
 	)
 
 	size := 10
-	mock.ExpectQuery(findAllQuery).WithArgs(size, 0).WillReturnRows(rows)
-	foundUsers, err := userPGRepository.FindAll(context.Background(), utils.NewPaginationQuery(size, 1))
+	query := findAllQuery + " WHERE deleted_at IS NULL ORDER BY created_at DESC, user_id DESC LIMIT $1 OFFSET $2"
+	mock.ExpectQuery(query).WithArgs(size, 0).WillReturnRows(rows)
+	foundUsers, err := userPGRepository.FindAll(context.Background(), &models.UserFilter{}, utils.NewPaginationQuery(size, 1))
 	require.NoError(t, err)
 	require.NotNil(t, foundUsers)
 	require.Equal(t, len(foundUsers), 1)
 
-	mock.ExpectQuery(findAllQuery).WithArgs(size, 10).WillReturnRows(rows)
-	foundUsers, err = userPGRepository.FindAll(context.Background(), utils.NewPaginationQuery(size, 2))
+	mock.ExpectQuery(query).WithArgs(size, 10).WillReturnRows(rows)
+	foundUsers, err = userPGRepository.FindAll(context.Background(), nil, utils.NewPaginationQuery(size, 2))
 	require.NoError(t, err)
 	require.Nil(t, foundUsers)
+
+	t.Run("Filtered", func(t *testing.T) {
+		brandUUID := uuid.New()
+		pagination := utils.NewPaginationQuery(size, 1)
+		pagination.SetOrderBy("-email")
+		mock.ExpectQuery(findAllQuery+" WHERE role = $1 AND brand_id = $2 AND email ILIKE $3 ORDER BY email DESC, user_id DESC LIMIT $4 OFFSET $5").
+			WithArgs(models.UserRoleSeller, brandUUID, "%@kalo.id%", size, 0).WillReturnRows(sqlmock.NewRows(columns))
+		foundUsers, err := userPGRepository.FindAllWithDeleted(context.Background(), &models.UserFilter{Role: models.UserRoleSeller, BrandID: &brandUUID, Email: "@kalo.id"}, pagination)
+		require.NoError(t, err)
+		require.Empty(t, foundUsers)
+	})
+}
+
+func TestUserRepository_Export(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	userPGRepository := NewUserPGRepository(sqlxDB)
+
+	// exports never select the password hashes
+	require.NotContains(t, exportQuery, "password")
+	mock.ExpectQuery(exportQuery + " WHERE deleted_at IS NULL AND role = $1 ORDER BY email ASC, user_id ASC").
+		WithArgs(models.UserRoleSeller).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "email", "role"}).AddRow(uuid.New(), "seller@kalobranded.local", models.UserRoleSeller))
+
+	var emails []string
+	err = userPGRepository.Export(context.Background(), &models.UserFilter{Role: models.UserRoleSeller}, "email", func(user *models.User) error {
+		require.Empty(t, user.Password)
+		emails = append(emails, user.Email)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"seller@kalobranded.local"}, emails)
+	require.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestUserRepository_FindById(t *testing.T) {
@@ -258,6 +308,10 @@ func TestUserRepository_UpdateById(t *testing.T) {
 		mockUser.Role,
 		mockUser.Avatar,
 		mockUser.DeliveryAddress,
+		mockUser.DeliveryLatitude,
+		mockUser.DeliveryLongitude,
+		mockUser.BrandID,
+		mockUser.Version,
 	).WillReturnResult(sqlmock.NewResult(0, 1))
 
 	updatedUser, err := userPGRepository.UpdateById(context.Background(), mockUser)
@@ -265,6 +319,14 @@ func TestUserRepository_UpdateById(t *testing.T) {
 	require.NotNil(t, mockUser)
 	require.Equal(t, updatedUser.FirstName, mockUser.FirstName)
 	require.Equal(t, updatedUser.UserID, mockUser.UserID)
+	require.Equal(t, 1, updatedUser.Version)
+
+	t.Run("VersionConflict", func(t *testing.T) {
+		mock.ExpectExec(updateByIdQuery).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		_, err := userPGRepository.UpdateById(context.Background(), mockUser)
+		require.ErrorIs(t, err, models.ErrVersionConflict)
+	})
 }
 
 func TestUserRepository_DeleteById(t *testing.T) {
@@ -305,9 +367,78 @@ func TestUserRepository_DeleteById(t *testing.T) {
 		time.Now(),
 	)
 
-	mock.ExpectExec(deleteByIdQuery).WithArgs(mockUser.UserID).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(deleteByIdQuery).WithArgs(mockUser.UserID, pq.Array([]int(nil))).WillReturnResult(sqlmock.NewResult(0, 1))
 
-	err = userPGRepository.DeleteById(context.Background(), mockUser.UserID)
+	err = userPGRepository.DeleteById(context.Background(), mockUser.UserID, nil)
 	require.NoError(t, err)
+
+	t.Run("PreconditionFailed", func(t *testing.T) {
+		mock.ExpectExec(deleteByIdQuery).WithArgs(mockUser.UserID, pq.Array([]int{1})).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := userPGRepository.DeleteById(context.Background(), mockUser.UserID, []int{1})
+		require.ErrorIs(t, err, models.ErrPreconditionFailed)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mock.ExpectExec(deleteByIdQuery).WithArgs(mockUser.UserID, pq.Array([]int(nil))).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := userPGRepository.DeleteById(context.Background(), mockUser.UserID, nil)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
 	require.NotNil(t, mockUser)
 }
+
+func TestUserRepository_RestoreById(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	userPGRepository := NewUserPGRepository(sqlxDB)
+
+	columns := []string{"user_id", "version", "deleted_at"}
+	userUUID := uuid.New()
+
+	t.Run("Restored", func(t *testing.T) {
+		rows := sqlmock.NewRows(columns).AddRow(userUUID, 3, nil)
+		mock.ExpectQuery(restoreByIdQuery).WithArgs(userUUID).WillReturnRows(rows)
+
+		restoredUser, err := userPGRepository.RestoreById(context.Background(), userUUID)
+		require.NoError(t, err)
+		require.Equal(t, userUUID, restoredUser.UserID)
+		require.Equal(t, 3, restoredUser.Version)
+		require.Nil(t, restoredUser.DeletedAt)
+	})
+
+	t.Run("NotDeleted", func(t *testing.T) {
+		mock.ExpectQuery(restoreByIdQuery).WithArgs(userUUID).WillReturnRows(sqlmock.NewRows(columns))
+
+		_, err := userPGRepository.RestoreById(context.Background(), userUUID)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	})
+}
+
+func TestUserRepository_PurgeDeleted(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	userPGRepository := NewUserPGRepository(sqlxDB)
+
+	deletedBefore := time.Now().AddDate(0, 0, -30)
+	mock.ExpectExec(purgeDeletedQuery).WithArgs(deletedBefore).WillReturnResult(sqlmock.NewResult(0, 2))
+
+	purged, err := userPGRepository.PurgeDeleted(context.Background(), deletedBefore)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), purged)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -1,18 +1,32 @@
 package repository
 
 const (
-	createUserQuery = `INSERT INTO users (first_name, last_name, email, password, role, avatar, delivery_address) 
-		VALUES ($1, $2, $3, $4, $5, COALESCE(NULLIF($6, ''), null), $7)
-		RETURNING user_id, first_name, last_name, email, password, avatar, created_at, updated_at, role, delivery_address`
+	// CreateUserQuery is run by CreateUser within the transactions of other repositories
+	CreateUserQuery = `INSERT INTO users (first_name, last_name, email, password, role, avatar, delivery_address, delivery_latitude, delivery_longitude, brand_id) 
+		VALUES ($1, $2, $3, $4, $5, COALESCE(NULLIF($6, ''), null), $7, $8, $9, $10)
+		RETURNING user_id, first_name, last_name, email, password, avatar, brand_id, created_at, updated_at, version, deleted_at, role, delivery_address, delivery_latitude, delivery_longitude`
 
-	findByEmailQuery = `SELECT user_id, email, first_name, last_name, role, avatar, password, delivery_address, created_at, updated_at FROM users WHERE email = $1`
+	findByEmailQuery = `SELECT user_id, email, first_name, last_name, role, avatar, brand_id, password, delivery_address, delivery_latitude, delivery_longitude, created_at, updated_at, version, deleted_at FROM users WHERE email = $1 AND deleted_at IS NULL`
 
-	findByIdQuery = `SELECT user_id, email, first_name, last_name, role, avatar, password, delivery_address, created_at, updated_at FROM users WHERE user_id = $1`
+	findByIdQuery = `SELECT user_id, email, first_name, last_name, role, avatar, brand_id, password, delivery_address, delivery_latitude, delivery_longitude, created_at, updated_at, version, deleted_at FROM users WHERE user_id = $1 AND deleted_at IS NULL`
 
-	findAllQuery = `SELECT user_id, email, first_name, last_name, role, avatar, password, delivery_address, created_at, updated_at FROM users LIMIT $1 OFFSET $2`
+	findByIdWithDeletedQuery = `SELECT user_id, email, first_name, last_name, role, avatar, brand_id, password, delivery_address, delivery_latitude, delivery_longitude, created_at, updated_at, version, deleted_at FROM users WHERE user_id = $1`
 
-	updateByIdQuery = `UPDATE users SET first_name = $2, last_name = $3, email = $4, password = $5, role = $6, avatar = $7, delivery_address = $8 WHERE user_id = $1
-		RETURNING user_id, first_name, last_name, email, password, avatar, delivery_address, created_at, updated_at, role`
+	// findAllQuery base of user lists, conditions, sort and pagination are added by utils.QueryBuilder
+	findAllQuery = `SELECT user_id, email, first_name, last_name, role, avatar, brand_id, password, delivery_address, delivery_latitude, delivery_longitude, created_at, updated_at, version, deleted_at FROM users`
 
-	deleteByIdQuery = `DELETE FROM users WHERE user_id = $1`
+	// exportQuery base of user exports, the user list without the password hashes
+	exportQuery = `SELECT user_id, email, first_name, last_name, role, avatar, brand_id, delivery_address, delivery_latitude, delivery_longitude, created_at, updated_at, version, deleted_at FROM users`
+
+	updateByIdQuery = `UPDATE users SET first_name = $2, last_name = $3, email = $4, password = $5, role = $6, avatar = $7, delivery_address = $8, delivery_latitude = $9, delivery_longitude = $10, brand_id = $11, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE user_id = $1 AND version = $12 AND deleted_at IS NULL
+		RETURNING user_id, first_name, last_name, email, password, avatar, brand_id, delivery_address, delivery_latitude, delivery_longitude, created_at, updated_at, version, deleted_at, role`
+
+	deleteByIdQuery = `UPDATE users SET deleted_at = CURRENT_TIMESTAMP, version = version + 1 WHERE user_id = $1 AND deleted_at IS NULL AND ($2::int[] IS NULL OR version = ANY($2))`
+
+	restoreByIdQuery = `UPDATE users SET deleted_at = NULL, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE user_id = $1 AND deleted_at IS NOT NULL
+		RETURNING user_id, email, first_name, last_name, role, avatar, brand_id, password, delivery_address, delivery_latitude, delivery_longitude, created_at, updated_at, version, deleted_at`
+
+	purgeDeletedQuery = `DELETE FROM users u WHERE u.deleted_at < $1
+		AND NOT EXISTS (SELECT 1 FROM orders o WHERE o.user_id = u.user_id)
+		AND NOT EXISTS (SELECT 1 FROM refunds rf WHERE rf.refunded_by = u.user_id)`
 )
@@ -3,6 +3,7 @@ package user
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -14,11 +15,16 @@ import (
 type UserUseCase interface {
 	Register(ctx context.Context, user *models.User) (*models.User, error)
 	Login(ctx context.Context, email string, password string) (*models.User, error)
-	FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.User, error)
+	FindAll(ctx context.Context, filter *models.UserFilter, pagination *utils.Pagination) ([]models.User, error)
+	Export(ctx context.Context, filter *models.UserFilter, sort string, fn func(user *models.User) error) error
 	FindByEmail(ctx context.Context, email string) (*models.User, error)
 	FindById(ctx context.Context, userID uuid.UUID) (*models.User, error)
 	CachedFindById(ctx context.Context, userID uuid.UUID) (*models.User, error)
 	UpdateById(ctx context.Context, user *models.User) (*models.User, error)
-	DeleteById(ctx context.Context, userID uuid.UUID) error
+	DeleteById(ctx context.Context, userID uuid.UUID, versions []int) error
+	FindAllWithDeleted(ctx context.Context, filter *models.UserFilter, pagination *utils.Pagination) ([]models.User, error)
+	FindByIdWithDeleted(ctx context.Context, userID uuid.UUID) (*models.User, error)
+	RestoreById(ctx context.Context, userID uuid.UUID) (*models.User, error)
+	PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error)
 	GenerateTokenPair(user *models.User, sessionID string) (access string, refresh string, err error)
 }
@@ -45,9 +45,9 @@ func (u *userUseCase) Register(ctx context.Context, user *models.User) (*models.
 	return u.userPgRepo.Create(ctx, user)
 }
 
-// FindAll find users
-func (u *userUseCase) FindAll(ctx context.Context, pagination *utils.Pagination) ([]models.User, error) {
-	users, err := u.userPgRepo.FindAll(ctx, pagination)
+// FindAll find users matching filter
+func (u *userUseCase) FindAll(ctx context.Context, filter *models.UserFilter, pagination *utils.Pagination) ([]models.User, error) {
+	users, err := u.userPgRepo.FindAll(ctx, filter, pagination)
 	if err != nil {
 		return nil, errors.Wrap(err, "userPgRepo.FindAll")
 	}
@@ -55,6 +55,15 @@ func (u *userUseCase) FindAll(ctx context.Context, pagination *utils.Pagination)
 	return users, nil
 }
 
+// Export call fn with each user matching filter in turn, sorted by sort
+func (u *userUseCase) Export(ctx context.Context, filter *models.UserFilter, sort string, fn func(user *models.User) error) error {
+	if err := u.userPgRepo.Export(ctx, filter, sort, fn); err != nil {
+		return errors.Wrap(err, "userPgRepo.Export")
+	}
+
+	return nil
+}
+
 // FindByEmail find user by email address
 func (u *userUseCase) FindByEmail(ctx context.Context, email string) (*models.User, error) {
 	findByEmail, err := u.userPgRepo.FindByEmail(ctx, email)
@@ -115,9 +124,9 @@ func (u *userUseCase) UpdateById(ctx context.Context, user *models.User) (*model
 	return updatedUser, nil
 }
 
-// DeleteById delete user by uuid
-func (u *userUseCase) DeleteById(ctx context.Context, userID uuid.UUID) error {
-	err := u.userPgRepo.DeleteById(ctx, userID)
+// DeleteById delete user by uuid, at one of versions unless nil
+func (u *userUseCase) DeleteById(ctx context.Context, userID uuid.UUID, versions []int) error {
+	err := u.userPgRepo.DeleteById(ctx, userID, versions)
 	if err != nil {
 		return errors.Wrap(err, "userPgRepo.DeleteById")
 	}
@@ -129,6 +138,52 @@ func (u *userUseCase) DeleteById(ctx context.Context, userID uuid.UUID) error {
 	return nil
 }
 
+// FindAllWithDeleted find users matching filter including soft deleted ones
+func (u *userUseCase) FindAllWithDeleted(ctx context.Context, filter *models.UserFilter, pagination *utils.Pagination) ([]models.User, error) {
+	users, err := u.userPgRepo.FindAllWithDeleted(ctx, filter, pagination)
+	if err != nil {
+		return nil, errors.Wrap(err, "userPgRepo.FindAllWithDeleted")
+	}
+
+	return users, nil
+}
+
+// FindByIdWithDeleted find user by uuid including soft deleted ones
+func (u *userUseCase) FindByIdWithDeleted(ctx context.Context, userID uuid.UUID) (*models.User, error) {
+	foundUser, err := u.userPgRepo.FindByIdWithDeleted(ctx, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "userPgRepo.FindByIdWithDeleted")
+	}
+
+	return foundUser, nil
+}
+
+// RestoreById restore soft deleted user by uuid
+func (u *userUseCase) RestoreById(ctx context.Context, userID uuid.UUID) (*models.User, error) {
+	restoredUser, err := u.userPgRepo.RestoreById(ctx, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "userPgRepo.RestoreById")
+	}
+
+	if err := u.redisRepo.SetUserCtx(ctx, restoredUser.UserID.String(), userByIdCacheDuration, restoredUser); err != nil {
+		u.logger.Errorf("redisRepo.SetUserCtx", err)
+	}
+
+	restoredUser.SanitizePassword()
+
+	return restoredUser, nil
+}
+
+// PurgeDeleted hard delete users soft deleted before deletedBefore
+func (u *userUseCase) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	purged, err := u.userPgRepo.PurgeDeleted(ctx, deletedBefore)
+	if err != nil {
+		return 0, errors.Wrap(err, "userPgRepo.PurgeDeleted")
+	}
+
+	return purged, nil
+}
+
 // Login user with email and password
 func (u *userUseCase) Login(ctx context.Context, email string, password string) (*models.User, error) {
 	foundUser, err := u.userPgRepo.FindByEmail(ctx, email)
@@ -151,6 +206,9 @@ func (u *userUseCase) GenerateTokenPair(user *models.User, sessionID string) (ac
 	claims["user_id"] = user.UserID
 	claims["email"] = user.Email
 	claims["role"] = user.Role
+	if user.BrandID != nil {
+		claims["brand_id"] = user.BrandID
+	}
 	claims["exp"] = time.Now().Add(time.Minute * 15).Unix()
 
 	access, err = token.SignedString([]byte(u.cfg.Server.JwtSecretKey))
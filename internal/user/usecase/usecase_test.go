@@ -155,9 +155,10 @@ func TestUserUseCase_FindAll(t *testing.T) {
 
 	ctx := context.Background()
 
-	userPGRepository.EXPECT().FindAll(gomock.Any(), nil).AnyTimes().Return(append([]models.User{}, *mockUser), nil)
+	filter := &models.UserFilter{Role: models.UserRoleUser}
+	userPGRepository.EXPECT().FindAll(gomock.Any(), filter, nil).AnyTimes().Return(append([]models.User{}, *mockUser), nil)
 
-	users, err := userUC.FindAll(ctx, nil)
+	users, err := userUC.FindAll(ctx, filter, nil)
 	require.NoError(t, err)
 	require.NotNil(t, users)
 	require.Equal(t, len(users), 1)
@@ -301,10 +302,10 @@ func TestUserUseCase_DeleteById(t *testing.T) {
 
 	ctx := context.Background()
 
-	userPGRepository.EXPECT().DeleteById(gomock.Any(), mockUser.UserID).Return(nil)
+	userPGRepository.EXPECT().DeleteById(gomock.Any(), mockUser.UserID, []int{1}).Return(nil)
 	userRedisRepository.EXPECT().DeleteUserCtx(gomock.Any(), mockUser.UserID.String()).AnyTimes().Return(nil)
 
-	err := userUC.DeleteById(ctx, mockUser.UserID)
+	err := userUC.DeleteById(ctx, mockUser.UserID, []int{1})
 	require.NoError(t, err)
 
 	userPGRepository.EXPECT().FindById(gomock.Any(), mockUser.UserID).AnyTimes().Return(nil, nil)
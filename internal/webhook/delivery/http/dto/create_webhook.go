@@ -0,0 +1,18 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+)
+
+type WebhookCreateRequestDto struct {
+	URL        string   `json:"url" validate:"required,lte=512"`
+	EventTypes []string `json:"event_types" validate:"required,min=1"`
+	Secret     string   `json:"secret" validate:"omitempty,min=16,lte=128"`
+	Active     *bool    `json:"active"`
+}
+
+// WebhookCreateResponseDto the secret is only ever returned here, receivers need it to check signatures
+type WebhookCreateResponseDto struct {
+	SubscriptionID uuid.UUID `json:"subscription_id" validate:"required"`
+	Secret         string    `json:"secret" validate:"required"`
+}
@@ -0,0 +1,13 @@
+package dto
+
+import "github.com/dinorain/kalobranded/pkg/utils"
+
+type WebhookFindResponseDto struct {
+	Meta utils.PaginationMetaDto `json:"meta"`
+	Data []*WebhookResponseDto   `json:"data"`
+}
+
+type WebhookDeliveryFindResponseDto struct {
+	Meta utils.PaginationMetaDto       `json:"meta"`
+	Data []*WebhookDeliveryResponseDto `json:"data"`
+}
@@ -0,0 +1,7 @@
+package dto
+
+type WebhookUpdateRequestDto struct {
+	URL        *string  `json:"url" validate:"omitempty,min=1,lte=512"`
+	EventTypes []string `json:"event_types" validate:"omitempty,min=1"`
+	Active     *bool    `json:"active"`
+}
@@ -0,0 +1,33 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/internal/models"
+)
+
+type WebhookResponseDto struct {
+	SubscriptionID uuid.UUID `json:"subscription_id"`
+	BrandID        uuid.UUID `json:"brand_id"`
+	URL            string    `json:"url"`
+	EventTypes     []string  `json:"event_types"`
+	Active         bool      `json:"active"`
+	Version        int       `json:"version"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+func WebhookResponseFromModel(subscription *models.WebhookSubscription) *WebhookResponseDto {
+	return &WebhookResponseDto{
+		SubscriptionID: subscription.SubscriptionID,
+		BrandID:        subscription.BrandID,
+		URL:            subscription.URL,
+		EventTypes:     subscription.EventTypes,
+		Active:         subscription.Active,
+		Version:        subscription.Version,
+		CreatedAt:      subscription.CreatedAt,
+		UpdatedAt:      subscription.UpdatedAt,
+	}
+}
@@ -0,0 +1,422 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/brand"
+	"github.com/dinorain/kalobranded/internal/middlewares"
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/internal/server/router"
+	"github.com/dinorain/kalobranded/internal/webhook"
+	"github.com/dinorain/kalobranded/internal/webhook/delivery/http/dto"
+	"github.com/dinorain/kalobranded/pkg/constants"
+	httpErrors "github.com/dinorain/kalobranded/pkg/http_errors"
+	"github.com/dinorain/kalobranded/pkg/logger"
+	"github.com/dinorain/kalobranded/pkg/utils"
+)
+
+type webhookHandlersHTTP struct {
+	router    *router.Router
+	logger    logger.Logger
+	cfg       *config.Config
+	mw        middlewares.MiddlewareManager
+	v         *validator.Validate
+	webhookUC webhook.WebhookUseCase
+	brandUC   brand.BrandUseCase
+}
+
+var _ webhook.WebhookHandlers = (*webhookHandlersHTTP)(nil)
+
+func NewWebhookHandlersHTTP(
+	router *router.Router,
+	logger logger.Logger,
+	cfg *config.Config,
+	mw middlewares.MiddlewareManager,
+	v *validator.Validate,
+	webhookUC webhook.WebhookUseCase,
+	brandUC brand.BrandUseCase,
+) *webhookHandlersHTTP {
+	return &webhookHandlersHTTP{router: router, logger: logger, cfg: cfg, mw: mw, v: v, webhookUC: webhookUC, brandUC: brandUC}
+}
+
+// Create
+// @Tags Webhooks
+// @Summary Create brand webhook
+// @Description Admin or seller of the brand subscribe a url to order events of the brand. Deliveries are posted as JSON signed with HMAC-SHA256 of "timestamp.body" in the Webhook-Signature header, timestamp being the Webhook-Timestamp header. The url must resolve to public addresses. The secret is generated when not given and only returned here
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "brand uuid"
+// @Param payload body dto.WebhookCreateRequestDto true "Payload"
+// @Success 201 {object} dto.WebhookCreateResponseDto
+// @Router /brands/{id}/webhooks [post]
+func (h *webhookHandlersHTTP) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	brandUUID, err := uuid.Parse(router.Param(r, constants.ID))
+	if err != nil {
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	createDto := &dto.WebhookCreateRequestDto{}
+	if err := json.NewDecoder(r.Body).Decode(createDto); err != nil {
+		h.logger.Errorf("decoder.Decode: %v", err)
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	if err := h.v.Struct(createDto); err != nil {
+		h.logger.Errorf("h.v.Struct: %v", err)
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	if err := h.mw.CheckBrand(w, r, brandUUID); err != nil {
+		return
+	}
+
+	if _, err := h.brandUC.CachedFindById(ctx, brandUUID); err != nil {
+		h.logger.Errorf("brandUC.CachedFindById: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	subscription := &models.WebhookSubscription{
+		BrandID:    brandUUID,
+		URL:        createDto.URL,
+		EventTypes: createDto.EventTypes,
+		Secret:     createDto.Secret,
+		Active:     true,
+	}
+	if createDto.Active != nil {
+		subscription.Active = *createDto.Active
+	}
+	if err := subscription.PrepareCreate(); err != nil {
+		h.logger.Errorf("subscription.PrepareCreate: %v", err)
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	createdSubscription, err := h.webhookUC.Create(ctx, subscription)
+	if err != nil {
+		h.logger.Errorf("webhookUC.Create: %v", err)
+		if errors.Is(err, models.ErrInvalidWebhookURL) || errors.Is(err, models.ErrNonPublicWebhookURL) {
+			_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+			return
+		}
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	res, _ := json.Marshal(dto.WebhookCreateResponseDto{SubscriptionID: createdSubscription.SubscriptionID, Secret: createdSubscription.Secret})
+	w.WriteHeader(http.StatusCreated)
+	w.Write(res)
+	return
+}
+
+// FindAllByBrandId
+// @Tags Webhooks
+// @Summary Find brand webhooks
+// @Description Admin or seller of the brand find the webhooks of the brand, oldest first
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "brand uuid"
+// @Param size query string false "pagination size"
+// @Param page query string false "pagination page"
+// @Success 200 {object} dto.WebhookFindResponseDto
+// @Router /brands/{id}/webhooks [get]
+func (h *webhookHandlersHTTP) FindAllByBrandId(w http.ResponseWriter, r *http.Request) {
+	queryParam := r.URL.Query()
+	pq := utils.NewPaginationFromQueryParams(queryParam.Get(constants.Size), queryParam.Get(constants.Page))
+
+	brandUUID, err := uuid.Parse(router.Param(r, constants.ID))
+	if err != nil {
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	if err := h.mw.CheckBrand(w, r, brandUUID); err != nil {
+		return
+	}
+
+	subscriptions, err := h.webhookUC.FindAllByBrandId(r.Context(), brandUUID, pq)
+	if err != nil {
+		h.logger.Errorf("webhookUC.FindAllByBrandId: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	resDto := dto.WebhookFindResponseDto{
+		Data: make([]*dto.WebhookResponseDto, 0, len(subscriptions)),
+		Meta: utils.PaginationMetaDto{
+			Limit:  pq.GetLimit(),
+			Offset: pq.GetOffset(),
+			Page:   pq.GetPage(),
+		},
+	}
+	for i := range subscriptions {
+		resDto.Data = append(resDto.Data, dto.WebhookResponseFromModel(&subscriptions[i]))
+	}
+
+	res, _ := json.Marshal(resDto)
+	w.WriteHeader(http.StatusOK)
+	w.Write(res)
+	return
+}
+
+// FindById
+// @Tags Webhooks
+// @Summary Find webhook by id
+// @Description Admin or seller of the webhook brand find webhook by id
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "webhook uuid"
+// @Success 200 {object} dto.WebhookResponseDto
+// @Header 200 {string} ETag "resource version"
+// @Router /webhooks/{id} [get]
+func (h *webhookHandlersHTTP) FindById(w http.ResponseWriter, r *http.Request) {
+	subscription, err := h.findSubscription(w, r)
+	if err != nil {
+		return
+	}
+
+	w.Header().Set(constants.ETag, utils.ETag(subscription.Version))
+	res, _ := json.Marshal(dto.WebhookResponseFromModel(subscription))
+	w.WriteHeader(http.StatusOK)
+	w.Write(res)
+	return
+}
+
+// UpdateById
+// @Tags Webhooks
+// @Summary Update webhook
+// @Description Admin or seller of the webhook brand update webhook, only provided fields are changed. Inactive webhooks get no new deliveries
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "webhook uuid"
+// @Param If-Match header string false "ETag of the version being changed"
+// @Param payload body dto.WebhookUpdateRequestDto true "Payload"
+// @Success 200 {object} dto.WebhookResponseDto
+// @Header 200 {string} ETag "resource version"
+// @Router /webhooks/{id} [patch]
+func (h *webhookHandlersHTTP) UpdateById(w http.ResponseWriter, r *http.Request) {
+	updateDto := &dto.WebhookUpdateRequestDto{}
+	if err := json.NewDecoder(r.Body).Decode(updateDto); err != nil {
+		h.logger.Errorf("decoder.Decode: %v", err)
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	if err := h.v.Struct(updateDto); err != nil {
+		h.logger.Errorf("h.v.Struct: %v", err)
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	subscription, err := h.findSubscription(w, r)
+	if err != nil {
+		return
+	}
+
+	if !utils.IfMatch(r.Header.Get(constants.IfMatch), subscription.Version) {
+		_ = httpErrors.ErrorCtxResponse(w, httpErrors.PreconditionFailed, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	if updateDto.URL != nil {
+		subscription.URL = *updateDto.URL
+	}
+	if updateDto.EventTypes != nil {
+		subscription.EventTypes = updateDto.EventTypes
+	}
+	if updateDto.Active != nil {
+		subscription.Active = *updateDto.Active
+	}
+	if err := subscription.PrepareCreate(); err != nil {
+		h.logger.Errorf("subscription.PrepareCreate: %v", err)
+		_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	updatedSubscription, err := h.webhookUC.UpdateById(r.Context(), subscription)
+	if err != nil {
+		h.logger.Errorf("webhookUC.UpdateById: %v", err)
+		if errors.Is(err, models.ErrInvalidWebhookURL) || errors.Is(err, models.ErrNonPublicWebhookURL) {
+			_ = httpErrors.NewBadRequestError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+			return
+		}
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	w.Header().Set(constants.ETag, utils.ETag(updatedSubscription.Version))
+	res, _ := json.Marshal(dto.WebhookResponseFromModel(updatedSubscription))
+	w.WriteHeader(http.StatusOK)
+	w.Write(res)
+	return
+}
+
+// DeleteById
+// @Tags Webhooks
+// @Summary Delete webhook
+// @Description Admin or seller of the webhook brand delete webhook along with its delivery log
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "webhook uuid"
+// @Param If-Match header string false "ETag of the version being changed"
+// @Success 204 {object} nil
+// @Router /webhooks/{id} [delete]
+func (h *webhookHandlersHTTP) DeleteById(w http.ResponseWriter, r *http.Request) {
+	subscription, err := h.findSubscription(w, r)
+	if err != nil {
+		return
+	}
+
+	if ifMatch := r.Header.Get(constants.IfMatch); ifMatch != "" && !utils.IfMatch(ifMatch, subscription.Version) {
+		_ = httpErrors.ErrorCtxResponse(w, httpErrors.PreconditionFailed, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	if err := h.webhookUC.DeleteById(r.Context(), subscription.SubscriptionID); err != nil {
+		h.logger.Errorf("webhookUC.DeleteById: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return
+}
+
+// FindAllDeliveries
+// @Tags Webhooks
+// @Summary Find webhook deliveries
+// @Description Admin or seller of the webhook brand find the delivery log of the webhook, latest first. Pending deliveries are retried with exponential backoff until they succeed or run out of attempts and fail
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "webhook uuid"
+// @Param size query string false "pagination size"
+// @Param page query string false "pagination page"
+// @Success 200 {object} dto.WebhookDeliveryFindResponseDto
+// @Router /webhooks/{id}/deliveries [get]
+func (h *webhookHandlersHTTP) FindAllDeliveries(w http.ResponseWriter, r *http.Request) {
+	queryParam := r.URL.Query()
+	pq := utils.NewPaginationFromQueryParams(queryParam.Get(constants.Size), queryParam.Get(constants.Page))
+
+	subscription, err := h.findSubscription(w, r)
+	if err != nil {
+		return
+	}
+
+	deliveries, err := h.webhookUC.FindAllDeliveries(r.Context(), subscription.SubscriptionID, pq)
+	if err != nil {
+		h.logger.Errorf("webhookUC.FindAllDeliveries: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	resDto := dto.WebhookDeliveryFindResponseDto{
+		Data: make([]*dto.WebhookDeliveryResponseDto, 0, len(deliveries)),
+		Meta: utils.PaginationMetaDto{
+			Limit:  pq.GetLimit(),
+			Offset: pq.GetOffset(),
+			Page:   pq.GetPage(),
+		},
+	}
+	for i := range deliveries {
+		resDto.Data = append(resDto.Data, dto.WebhookDeliveryResponseFromModel(&deliveries[i]))
+	}
+
+	res, _ := json.Marshal(resDto)
+	w.WriteHeader(http.StatusOK)
+	w.Write(res)
+	return
+}
+
+// Redeliver
+// @Tags Webhooks
+// @Summary Redeliver webhook delivery
+// @Description Admin or seller of the webhook brand send a delivery that succeeded or failed again, with a fresh set of attempts. The delivery is queued for the next dispatch
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "webhook uuid"
+// @Param delivery_id path string true "delivery uuid"
+// @Success 202 {object} dto.WebhookDeliveryResponseDto
+// @Router /webhooks/{id}/deliveries/{delivery_id}/redeliver [post]
+func (h *webhookHandlersHTTP) Redeliver(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	deliveryUUID, err := uuid.Parse(router.Param(r, constants.DeliveryID))
+	if err != nil {
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	subscription, err := h.findSubscription(w, r)
+	if err != nil {
+		return
+	}
+
+	delivery, err := h.webhookUC.FindDeliveryById(ctx, deliveryUUID)
+	if err != nil {
+		h.logger.Errorf("webhookUC.FindDeliveryById: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	if delivery.SubscriptionID != subscription.SubscriptionID {
+		_ = httpErrors.NewNotFoundError(w, nil, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	redelivery, err := h.webhookUC.Redeliver(ctx, delivery.DeliveryID)
+	if err != nil {
+		h.logger.Errorf("webhookUC.Redeliver: %v", err)
+		if errors.Is(err, models.ErrDeliveryPending) {
+			_ = httpErrors.NewConflictError(w, err.Error(), h.cfg.Http.DebugErrorsResponse)
+			return
+		}
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return
+	}
+
+	res, _ := json.Marshal(dto.WebhookDeliveryResponseFromModel(redelivery))
+	w.WriteHeader(http.StatusAccepted)
+	w.Write(res)
+	return
+}
+
+// findSubscription find webhook subscription by id, sellers can only act on webhooks of their brand, error response
+// is already written when err is not nil
+func (h *webhookHandlersHTTP) findSubscription(w http.ResponseWriter, r *http.Request) (*models.WebhookSubscription, error) {
+	subscriptionUUID, err := uuid.Parse(router.Param(r, constants.ID))
+	if err != nil {
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return nil, err
+	}
+
+	subscription, err := h.webhookUC.FindById(r.Context(), subscriptionUUID)
+	if err != nil {
+		h.logger.Errorf("webhookUC.FindById: %v", err)
+		_ = httpErrors.ErrorCtxResponse(w, err, h.cfg.Http.DebugErrorsResponse)
+		return nil, err
+	}
+
+	if err := h.mw.CheckBrand(w, r, subscription.BrandID); err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
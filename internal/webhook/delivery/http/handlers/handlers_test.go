@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/golang-jwt/jwt"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/kalobranded/config"
+	mockBrandUC "github.com/dinorain/kalobranded/internal/brand/mock"
+	"github.com/dinorain/kalobranded/internal/middlewares"
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/internal/server/router"
+	"github.com/dinorain/kalobranded/internal/webhook/delivery/http/dto"
+	"github.com/dinorain/kalobranded/internal/webhook/mock"
+	"github.com/dinorain/kalobranded/pkg/constants"
+	"github.com/dinorain/kalobranded/pkg/logger"
+)
+
+func signedToken(t *testing.T, cfg *config.Config, role string, brandUUID *uuid.UUID) string {
+	token := jwt.New(jwt.SigningMethodHS256)
+	claims := token.Claims.(jwt.MapClaims)
+	claims["session_id"] = uuid.New().String()
+	claims["user_id"] = uuid.New().String()
+	claims["role"] = role
+	if brandUUID != nil {
+		claims["brand_id"] = brandUUID.String()
+	}
+	claims["exp"] = time.Now().Add(time.Minute * 15).Unix()
+	validToken, err := token.SignedString([]byte(cfg.Server.JwtSecretKey))
+	require.NoError(t, err)
+	return validToken
+}
+
+func TestWebhooksHandler_Create(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	webhookUC := mock.NewMockWebhookUseCase(ctrl)
+	brandUC := mockBrandUC.NewMockBrandUseCase(ctrl)
+
+	cfg := &config.Config{Session: config.Session{Expire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	appLogger := logger.NewAppLogger(cfg)
+	appLogger.InitLogger()
+	mw := middlewares.NewMiddlewareManager(appLogger, cfg)
+
+	v := validator.New()
+
+	rt := router.NewRouter(false)
+	handlers := NewWebhookHandlersHTTP(rt, appLogger, cfg, mw, v, webhookUC, brandUC)
+
+	brandUUID := uuid.New()
+	newRequest := func(token, body string) *http.Request {
+		req := router.WithParams(httptest.NewRequest(http.MethodPost, "/brands/"+brandUUID.String()+"/webhooks", strings.NewReader(body)), map[string]string{constants.ID: brandUUID.String()})
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", token))
+		return req
+	}
+
+	t.Run("Seller", func(t *testing.T) {
+		body := `{"url": " https://brand.example/hooks ", "event_types": ["order.created", "order.created", "order.status_changed"]}`
+		w := httptest.NewRecorder()
+
+		subscriptionUUID := uuid.New()
+		brandUC.EXPECT().CachedFindById(gomock.Any(), brandUUID).Return(&models.Brand{BrandID: brandUUID}, nil)
+		webhookUC.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, s *models.WebhookSubscription) (*models.WebhookSubscription, error) {
+			require.Equal(t, brandUUID, s.BrandID)
+			require.Equal(t, "https://brand.example/hooks", s.URL)
+			require.Equal(t, []string{models.EventOrderCreated, models.EventOrderStatusChanged}, []string(s.EventTypes))
+			require.True(t, s.Active)
+			s.SubscriptionID = subscriptionUUID
+			s.Secret = "whsec_generated"
+			return s, nil
+		})
+
+		http.HandlerFunc(handlers.Create).ServeHTTP(w, newRequest(signedToken(t, cfg, models.UserRoleSeller, &brandUUID), body))
+
+		require.Equal(t, http.StatusCreated, w.Code)
+		resDto := &dto.WebhookCreateResponseDto{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), resDto))
+		require.Equal(t, subscriptionUUID, resDto.SubscriptionID)
+		require.Equal(t, "whsec_generated", resDto.Secret)
+	})
+
+	t.Run("OtherBrandSeller", func(t *testing.T) {
+		otherBrandUUID := uuid.New()
+		w := httptest.NewRecorder()
+
+		http.HandlerFunc(handlers.Create).ServeHTTP(w, newRequest(signedToken(t, cfg, models.UserRoleSeller, &otherBrandUUID), `{"url": "https://brand.example/hooks", "event_types": ["order.created"]}`))
+
+		require.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("UnsupportedEvent", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		brandUC.EXPECT().CachedFindById(gomock.Any(), brandUUID).Return(&models.Brand{BrandID: brandUUID}, nil)
+
+		http.HandlerFunc(handlers.Create).ServeHTTP(w, newRequest(signedToken(t, cfg, models.UserRoleAdmin, nil), `{"url": "https://brand.example/hooks", "event_types": ["user.registered"]}`))
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("NotHttpURL", func(t *testing.T) {
+		w := httptest.NewRecorder()
+
+		brandUC.EXPECT().CachedFindById(gomock.Any(), brandUUID).Return(&models.Brand{BrandID: brandUUID}, nil)
+
+		http.HandlerFunc(handlers.Create).ServeHTTP(w, newRequest(signedToken(t, cfg, models.UserRoleAdmin, nil), `{"url": "ftp://brand.example/hooks", "event_types": ["order.created"]}`))
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestWebhooksHandler_Redeliver(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	webhookUC := mock.NewMockWebhookUseCase(ctrl)
+	brandUC := mockBrandUC.NewMockBrandUseCase(ctrl)
+
+	cfg := &config.Config{Session: config.Session{Expire: 1234}, Server: config.ServerConfig{JwtSecretKey: "secret"}}
+	appLogger := logger.NewAppLogger(cfg)
+	appLogger.InitLogger()
+	mw := middlewares.NewMiddlewareManager(appLogger, cfg)
+
+	v := validator.New()
+
+	rt := router.NewRouter(false)
+	handlers := NewWebhookHandlersHTTP(rt, appLogger, cfg, mw, v, webhookUC, brandUC)
+
+	brandUUID := uuid.New()
+	subscription := &models.WebhookSubscription{SubscriptionID: uuid.New(), BrandID: brandUUID, URL: "https://brand.example/hooks", Active: true, Version: 1}
+	newRequest := func(deliveryUUID uuid.UUID) *http.Request {
+		req := router.WithParams(
+			httptest.NewRequest(http.MethodPost, "/webhooks/"+subscription.SubscriptionID.String()+"/deliveries/"+deliveryUUID.String()+"/redeliver", nil),
+			map[string]string{constants.ID: subscription.SubscriptionID.String(), constants.DeliveryID: deliveryUUID.String()},
+		)
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %v", signedToken(t, cfg, models.UserRoleSeller, &brandUUID)))
+		return req
+	}
+
+	t.Run("Redeliver", func(t *testing.T) {
+		delivery := &models.WebhookDelivery{DeliveryID: uuid.New(), SubscriptionID: subscription.SubscriptionID, Status: models.WebhookDeliveryStatusFailed, Attempts: 8}
+		w := httptest.NewRecorder()
+
+		webhookUC.EXPECT().FindById(gomock.Any(), subscription.SubscriptionID).Return(subscription, nil)
+		webhookUC.EXPECT().FindDeliveryById(gomock.Any(), delivery.DeliveryID).Return(delivery, nil)
+		webhookUC.EXPECT().Redeliver(gomock.Any(), delivery.DeliveryID).Return(&models.WebhookDelivery{DeliveryID: delivery.DeliveryID, SubscriptionID: subscription.SubscriptionID, Status: models.WebhookDeliveryStatusPending}, nil)
+
+		http.HandlerFunc(handlers.Redeliver).ServeHTTP(w, newRequest(delivery.DeliveryID))
+
+		require.Equal(t, http.StatusAccepted, w.Code)
+		resDto := &dto.WebhookDeliveryResponseDto{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), resDto))
+		require.Equal(t, models.WebhookDeliveryStatusPending, resDto.Status)
+	})
+
+	t.Run("Pending", func(t *testing.T) {
+		delivery := &models.WebhookDelivery{DeliveryID: uuid.New(), SubscriptionID: subscription.SubscriptionID, Status: models.WebhookDeliveryStatusPending}
+		w := httptest.NewRecorder()
+
+		webhookUC.EXPECT().FindById(gomock.Any(), subscription.SubscriptionID).Return(subscription, nil)
+		webhookUC.EXPECT().FindDeliveryById(gomock.Any(), delivery.DeliveryID).Return(delivery, nil)
+		webhookUC.EXPECT().Redeliver(gomock.Any(), delivery.DeliveryID).Return(nil, models.ErrDeliveryPending)
+
+		http.HandlerFunc(handlers.Redeliver).ServeHTTP(w, newRequest(delivery.DeliveryID))
+
+		require.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("OtherWebhookDelivery", func(t *testing.T) {
+		delivery := &models.WebhookDelivery{DeliveryID: uuid.New(), SubscriptionID: uuid.New(), Status: models.WebhookDeliveryStatusFailed}
+		w := httptest.NewRecorder()
+
+		webhookUC.EXPECT().FindById(gomock.Any(), subscription.SubscriptionID).Return(subscription, nil)
+		webhookUC.EXPECT().FindDeliveryById(gomock.Any(), delivery.DeliveryID).Return(delivery, nil)
+
+		http.HandlerFunc(handlers.Redeliver).ServeHTTP(w, newRequest(delivery.DeliveryID))
+
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
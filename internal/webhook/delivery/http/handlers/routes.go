@@ -0,0 +1,13 @@
+package handlers
+
+func (h *webhookHandlersHTTP) WebhookMapRoutes() {
+	webhooks := h.router.Group("/webhooks")
+	webhooks.Get("/{id}", h.FindById, h.mw.IsAdminOrSeller)
+	webhooks.Patch("/{id}", h.UpdateById, h.mw.IsAdminOrSeller)
+	webhooks.Delete("/{id}", h.DeleteById, h.mw.IsAdminOrSeller)
+	webhooks.Get("/{id}/deliveries", h.FindAllDeliveries, h.mw.IsAdminOrSeller)
+	webhooks.Post("/{id}/deliveries/{delivery_id}/redeliver", h.Redeliver, h.mw.IsAdminOrSeller)
+
+	h.router.Get("/brands/{id}/webhooks", h.FindAllByBrandId, h.mw.IsAdminOrSeller)
+	h.router.Post("/brands/{id}/webhooks", h.Create, h.mw.IsAdminOrSeller)
+}
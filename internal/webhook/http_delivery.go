@@ -0,0 +1,16 @@
+package webhook
+
+import (
+	"net/http"
+)
+
+// Webhook HTTP Handlers interface
+type WebhookHandlers interface {
+	Create(w http.ResponseWriter, r *http.Request)
+	FindAllByBrandId(w http.ResponseWriter, r *http.Request)
+	FindById(w http.ResponseWriter, r *http.Request)
+	UpdateById(w http.ResponseWriter, r *http.Request)
+	DeleteById(w http.ResponseWriter, r *http.Request)
+	FindAllDeliveries(w http.ResponseWriter, r *http.Request)
+	Redeliver(w http.ResponseWriter, r *http.Request)
+}
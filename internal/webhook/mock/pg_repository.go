@@ -0,0 +1,202 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pg_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/dinorain/kalobranded/internal/models"
+	utils "github.com/dinorain/kalobranded/pkg/utils"
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+)
+
+// MockWebhookPGRepository is a mock of WebhookPGRepository interface.
+type MockWebhookPGRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookPGRepositoryMockRecorder
+}
+
+// MockWebhookPGRepositoryMockRecorder is the mock recorder for MockWebhookPGRepository.
+type MockWebhookPGRepositoryMockRecorder struct {
+	mock *MockWebhookPGRepository
+}
+
+// NewMockWebhookPGRepository creates a new mock instance.
+func NewMockWebhookPGRepository(ctrl *gomock.Controller) *MockWebhookPGRepository {
+	mock := &MockWebhookPGRepository{ctrl: ctrl}
+	mock.recorder = &MockWebhookPGRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookPGRepository) EXPECT() *MockWebhookPGRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimDue mocks base method.
+func (m *MockWebhookPGRepository) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]models.DueWebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDue", ctx, limit, lease)
+	ret0, _ := ret[0].([]models.DueWebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDue indicates an expected call of ClaimDue.
+func (mr *MockWebhookPGRepositoryMockRecorder) ClaimDue(ctx, limit, lease interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDue", reflect.TypeOf((*MockWebhookPGRepository)(nil).ClaimDue), ctx, limit, lease)
+}
+
+// Create mocks base method.
+func (m *MockWebhookPGRepository) Create(ctx context.Context, subscription *models.WebhookSubscription) (*models.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, subscription)
+	ret0, _ := ret[0].(*models.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWebhookPGRepositoryMockRecorder) Create(ctx, subscription interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWebhookPGRepository)(nil).Create), ctx, subscription)
+}
+
+// DeleteById mocks base method.
+func (m *MockWebhookPGRepository) DeleteById(ctx context.Context, subscriptionID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteById", ctx, subscriptionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteById indicates an expected call of DeleteById.
+func (mr *MockWebhookPGRepositoryMockRecorder) DeleteById(ctx, subscriptionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockWebhookPGRepository)(nil).DeleteById), ctx, subscriptionID)
+}
+
+// Enqueue mocks base method.
+func (m *MockWebhookPGRepository) Enqueue(ctx context.Context, brandID uuid.UUID, event *models.OutboxEvent) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enqueue", ctx, brandID, event)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Enqueue indicates an expected call of Enqueue.
+func (mr *MockWebhookPGRepositoryMockRecorder) Enqueue(ctx, brandID, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enqueue", reflect.TypeOf((*MockWebhookPGRepository)(nil).Enqueue), ctx, brandID, event)
+}
+
+// FindAllByBrandId mocks base method.
+func (m *MockWebhookPGRepository) FindAllByBrandId(ctx context.Context, brandID uuid.UUID, pagination *utils.Pagination) ([]models.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByBrandId", ctx, brandID, pagination)
+	ret0, _ := ret[0].([]models.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByBrandId indicates an expected call of FindAllByBrandId.
+func (mr *MockWebhookPGRepositoryMockRecorder) FindAllByBrandId(ctx, brandID, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByBrandId", reflect.TypeOf((*MockWebhookPGRepository)(nil).FindAllByBrandId), ctx, brandID, pagination)
+}
+
+// FindAllDeliveriesBySubscriptionId mocks base method.
+func (m *MockWebhookPGRepository) FindAllDeliveriesBySubscriptionId(ctx context.Context, subscriptionID uuid.UUID, pagination *utils.Pagination) ([]models.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllDeliveriesBySubscriptionId", ctx, subscriptionID, pagination)
+	ret0, _ := ret[0].([]models.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllDeliveriesBySubscriptionId indicates an expected call of FindAllDeliveriesBySubscriptionId.
+func (mr *MockWebhookPGRepositoryMockRecorder) FindAllDeliveriesBySubscriptionId(ctx, subscriptionID, pagination interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllDeliveriesBySubscriptionId", reflect.TypeOf((*MockWebhookPGRepository)(nil).FindAllDeliveriesBySubscriptionId), ctx, subscriptionID, pagination)
+}
+
+// FindById mocks base method.
+func (m *MockWebhookPGRepository) FindById(ctx context.Context, subscriptionID uuid.UUID) (*models.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", ctx, subscriptionID)
+	ret0, _ := ret[0].(*models.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockWebhookPGRepositoryMockRecorder) FindById(ctx, subscriptionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockWebhookPGRepository)(nil).FindById), ctx, subscriptionID)
+}
+
+// FindDeliveryById mocks base method.
+func (m *MockWebhookPGRepository) FindDeliveryById(ctx context.Context, deliveryID uuid.UUID) (*models.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDeliveryById", ctx, deliveryID)
+	ret0, _ := ret[0].(*models.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDeliveryById indicates an expected call of FindDeliveryById.
+func (mr *MockWebhookPGRepositoryMockRecorder) FindDeliveryById(ctx, deliveryID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDeliveryById", reflect.TypeOf((*MockWebhookPGRepository)(nil).FindDeliveryById), ctx, deliveryID)
+}
+
+// RecordAttempt mocks base method.
+func (m *MockWebhookPGRepository) RecordAttempt(ctx context.Context, delivery *models.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordAttempt", ctx, delivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordAttempt indicates an expected call of RecordAttempt.
+func (mr *MockWebhookPGRepositoryMockRecorder) RecordAttempt(ctx, delivery interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordAttempt", reflect.TypeOf((*MockWebhookPGRepository)(nil).RecordAttempt), ctx, delivery)
+}
+
+// Redeliver mocks base method.
+func (m *MockWebhookPGRepository) Redeliver(ctx context.Context, deliveryID uuid.UUID) (*models.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Redeliver", ctx, deliveryID)
+	ret0, _ := ret[0].(*models.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Redeliver indicates an expected call of Redeliver.
+func (mr *MockWebhookPGRepositoryMockRecorder) Redeliver(ctx, deliveryID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redeliver", reflect.TypeOf((*MockWebhookPGRepository)(nil).Redeliver), ctx, deliveryID)
+}
+
+// UpdateById mocks base method.
+func (m *MockWebhookPGRepository) UpdateById(ctx context.Context, subscription *models.WebhookSubscription) (*models.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateById", ctx, subscription)
+	ret0, _ := ret[0].(*models.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateById indicates an expected call of UpdateById.
+func (mr *MockWebhookPGRepositoryMockRecorder) UpdateById(ctx, subscription interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateById", reflect.TypeOf((*MockWebhookPGRepository)(nil).UpdateById), ctx, subscription)
+}
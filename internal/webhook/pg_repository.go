@@ -0,0 +1,27 @@
+//go:generate mockgen -source pg_repository.go -destination mock/pg_repository.go -package mock
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/pkg/utils"
+)
+
+// Webhook pg repository
+type WebhookPGRepository interface {
+	Create(ctx context.Context, subscription *models.WebhookSubscription) (*models.WebhookSubscription, error)
+	FindAllByBrandId(ctx context.Context, brandID uuid.UUID, pagination *utils.Pagination) ([]models.WebhookSubscription, error)
+	FindById(ctx context.Context, subscriptionID uuid.UUID) (*models.WebhookSubscription, error)
+	UpdateById(ctx context.Context, subscription *models.WebhookSubscription) (*models.WebhookSubscription, error)
+	DeleteById(ctx context.Context, subscriptionID uuid.UUID) error
+	Enqueue(ctx context.Context, brandID uuid.UUID, event *models.OutboxEvent) (int64, error)
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]models.DueWebhookDelivery, error)
+	RecordAttempt(ctx context.Context, delivery *models.WebhookDelivery) error
+	FindAllDeliveriesBySubscriptionId(ctx context.Context, subscriptionID uuid.UUID, pagination *utils.Pagination) ([]models.WebhookDelivery, error)
+	FindDeliveryById(ctx context.Context, deliveryID uuid.UUID) (*models.WebhookDelivery, error)
+	Redeliver(ctx context.Context, deliveryID uuid.UUID) (*models.WebhookDelivery, error)
+}
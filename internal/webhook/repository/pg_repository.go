@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/internal/webhook"
+	"github.com/dinorain/kalobranded/pkg/utils"
+)
+
+// Webhook repository
+type WebhookRepository struct {
+	db *sqlx.DB
+}
+
+var _ webhook.WebhookPGRepository = (*WebhookRepository)(nil)
+
+// Webhook repository constructor
+func NewWebhookPGRepository(db *sqlx.DB) *WebhookRepository {
+	return &WebhookRepository{db: db}
+}
+
+// Create new webhook subscription
+func (r *WebhookRepository) Create(ctx context.Context, subscription *models.WebhookSubscription) (*models.WebhookSubscription, error) {
+	createdSubscription := &models.WebhookSubscription{}
+	if err := r.db.QueryRowxContext(
+		ctx,
+		createSubscriptionQuery,
+		subscription.BrandID,
+		subscription.URL,
+		subscription.EventTypes,
+		subscription.Secret,
+		subscription.Active,
+	).StructScan(createdSubscription); err != nil {
+		return nil, errors.Wrap(err, "WebhookRepository.Create.QueryRowxContext")
+	}
+
+	return createdSubscription, nil
+}
+
+// FindAllByBrandId Find webhook subscriptions of brand uuid, oldest first
+func (r *WebhookRepository) FindAllByBrandId(ctx context.Context, brandID uuid.UUID, pagination *utils.Pagination) ([]models.WebhookSubscription, error) {
+	var subscriptions []models.WebhookSubscription
+	if err := r.db.SelectContext(ctx, &subscriptions, findAllByBrandIdQuery, brandID, pagination.GetLimit(), pagination.GetOffset()); err != nil {
+		return nil, errors.Wrap(err, "WebhookRepository.FindAllByBrandId.SelectContext")
+	}
+
+	return subscriptions, nil
+}
+
+// FindById Find webhook subscription by uuid
+func (r *WebhookRepository) FindById(ctx context.Context, subscriptionID uuid.UUID) (*models.WebhookSubscription, error) {
+	subscription := &models.WebhookSubscription{}
+	if err := r.db.GetContext(ctx, subscription, findByIdQuery, subscriptionID); err != nil {
+		return nil, errors.Wrap(err, "WebhookRepository.FindById.GetContext")
+	}
+
+	return subscription, nil
+}
+
+// UpdateById update url, event types and active flag of webhook subscription when its version is unchanged
+func (r *WebhookRepository) UpdateById(ctx context.Context, subscription *models.WebhookSubscription) (*models.WebhookSubscription, error) {
+	updatedSubscription := &models.WebhookSubscription{}
+	if err := r.db.QueryRowxContext(
+		ctx,
+		updateByIdQuery,
+		subscription.SubscriptionID,
+		subscription.URL,
+		subscription.EventTypes,
+		subscription.Active,
+		subscription.Version,
+	).StructScan(updatedSubscription); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, models.ErrVersionConflict
+		}
+		return nil, errors.Wrap(err, "WebhookRepository.UpdateById.QueryRowxContext")
+	}
+
+	return updatedSubscription, nil
+}
+
+// DeleteById delete webhook subscription by uuid along with its delivery log
+func (r *WebhookRepository) DeleteById(ctx context.Context, subscriptionID uuid.UUID) error {
+	if res, err := r.db.ExecContext(ctx, deleteByIdQuery, subscriptionID); err != nil {
+		return errors.Wrap(err, "WebhookRepository.DeleteById.ExecContext")
+	} else {
+		cnt, err := res.RowsAffected()
+		if err != nil {
+			return errors.Wrap(err, "WebhookRepository.DeleteById.RowsAffected")
+		} else if cnt == 0 {
+			return sql.ErrNoRows
+		}
+	}
+
+	return nil
+}
+
+// Enqueue add a pending delivery of event for every active subscription of brand uuid to its event type, and
+// report how many were added. An event already enqueued for a subscription is skipped
+func (r *WebhookRepository) Enqueue(ctx context.Context, brandID uuid.UUID, event *models.OutboxEvent) (int64, error) {
+	res, err := r.db.ExecContext(ctx, enqueueQuery, brandID, event.EventID, event.EventType, event.Payload)
+	if err != nil {
+		return 0, errors.Wrap(err, "WebhookRepository.Enqueue.ExecContext")
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "WebhookRepository.Enqueue.RowsAffected")
+	}
+
+	return cnt, nil
+}
+
+// ClaimDue lock up to limit pending deliveries due for an attempt for lease, earliest due first. Deliveries claimed
+// by another dispatcher are skipped
+func (r *WebhookRepository) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]models.DueWebhookDelivery, error) {
+	var deliveries []models.DueWebhookDelivery
+	if err := r.db.SelectContext(ctx, &deliveries, claimDueQuery, limit, lease.Seconds()); err != nil {
+		return nil, errors.Wrap(err, "WebhookRepository.ClaimDue.SelectContext")
+	}
+
+	return deliveries, nil
+}
+
+// RecordAttempt save the outcome of a delivery attempt and release its claim
+func (r *WebhookRepository) RecordAttempt(ctx context.Context, delivery *models.WebhookDelivery) error {
+	if _, err := r.db.ExecContext(
+		ctx,
+		recordAttemptQuery,
+		delivery.DeliveryID,
+		delivery.Status,
+		delivery.Attempts,
+		delivery.NextAttemptAt,
+		delivery.ResponseStatus,
+		delivery.ResponseBody,
+		delivery.Error,
+		delivery.DeliveredAt,
+	); err != nil {
+		return errors.Wrap(err, "WebhookRepository.RecordAttempt.ExecContext")
+	}
+
+	return nil
+}
+
+// FindAllDeliveriesBySubscriptionId Find deliveries of webhook subscription uuid, latest first
+func (r *WebhookRepository) FindAllDeliveriesBySubscriptionId(ctx context.Context, subscriptionID uuid.UUID, pagination *utils.Pagination) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+	if err := r.db.SelectContext(ctx, &deliveries, findAllDeliveriesBySubscriptionIdQuery, subscriptionID, pagination.GetLimit(), pagination.GetOffset()); err != nil {
+		return nil, errors.Wrap(err, "WebhookRepository.FindAllDeliveriesBySubscriptionId.SelectContext")
+	}
+
+	return deliveries, nil
+}
+
+// FindDeliveryById Find webhook delivery by uuid
+func (r *WebhookRepository) FindDeliveryById(ctx context.Context, deliveryID uuid.UUID) (*models.WebhookDelivery, error) {
+	delivery := &models.WebhookDelivery{}
+	if err := r.db.GetContext(ctx, delivery, findDeliveryByIdQuery, deliveryID); err != nil {
+		return nil, errors.Wrap(err, "WebhookRepository.FindDeliveryById.GetContext")
+	}
+
+	return delivery, nil
+}
+
+// Redeliver make a delivery that succeeded or failed pending again with a fresh set of attempts, due straight away
+func (r *WebhookRepository) Redeliver(ctx context.Context, deliveryID uuid.UUID) (*models.WebhookDelivery, error) {
+	delivery := &models.WebhookDelivery{}
+	if err := r.db.QueryRowxContext(ctx, redeliverQuery, deliveryID).StructScan(delivery); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, models.ErrDeliveryPending
+		}
+		return nil, errors.Wrap(err, "WebhookRepository.Redeliver.QueryRowxContext")
+	}
+
+	return delivery, nil
+}
@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/kalobranded/internal/models"
+)
+
+var (
+	subscriptionColumns = []string{"subscription_id", "brand_id", "url", "event_types", "secret", "active", "version", "created_at", "updated_at"}
+	deliveryColumns     = []string{"delivery_id", "subscription_id", "event_id", "event_type", "payload", "status", "attempts", "next_attempt_at", "response_status", "response_body", "error", "delivered_at", "created_at", "updated_at"}
+)
+
+func TestWebhookRepository_Create(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	webhookPGRepository := NewWebhookPGRepository(sqlxDB)
+
+	mockSubscription := &models.WebhookSubscription{
+		BrandID:    uuid.New(),
+		URL:        "https://brand.example/hooks",
+		EventTypes: pq.StringArray{models.EventOrderCreated},
+		Secret:     "whsec_secret",
+		Active:     true,
+	}
+
+	subscriptionUUID := uuid.New()
+	mock.ExpectQuery(createSubscriptionQuery).WithArgs(
+		mockSubscription.BrandID,
+		mockSubscription.URL,
+		mockSubscription.EventTypes,
+		mockSubscription.Secret,
+		mockSubscription.Active,
+	).WillReturnRows(sqlmock.NewRows(subscriptionColumns).AddRow(
+		subscriptionUUID,
+		mockSubscription.BrandID,
+		mockSubscription.URL,
+		"{order.created}",
+		mockSubscription.Secret,
+		true,
+		1,
+		time.Now(),
+		time.Now(),
+	))
+
+	createdSubscription, err := webhookPGRepository.Create(context.Background(), mockSubscription)
+	require.NoError(t, err)
+	require.Equal(t, subscriptionUUID, createdSubscription.SubscriptionID)
+	require.Equal(t, mockSubscription.EventTypes, createdSubscription.EventTypes)
+}
+
+func TestWebhookRepository_Enqueue(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	webhookPGRepository := NewWebhookPGRepository(sqlxDB)
+
+	brandUUID := uuid.New()
+	event, err := models.NewOutboxEvent(models.AggregateOrder, uuid.New(), models.EventOrderCreated, map[string]string{"brand_id": brandUUID.String()})
+	require.NoError(t, err)
+
+	mock.ExpectExec(enqueueQuery).WithArgs(brandUUID, event.EventID, event.EventType, event.Payload).WillReturnResult(sqlmock.NewResult(0, 2))
+
+	enqueued, err := webhookPGRepository.Enqueue(context.Background(), brandUUID, event)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), enqueued)
+}
+
+func TestWebhookRepository_ClaimDue(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	webhookPGRepository := NewWebhookPGRepository(sqlxDB)
+
+	deliveryUUID := uuid.New()
+	rows := sqlmock.NewRows(append(deliveryColumns, "url", "secret")).AddRow(
+		deliveryUUID, uuid.New(), uuid.New(), models.EventOrderCreated, []byte(`{"order_id":"1"}`), models.WebhookDeliveryStatusPending, 1, time.Now(), 503, "", "unexpected status 503", nil, time.Now(), time.Now(),
+		"https://brand.example/hooks", "whsec_secret",
+	)
+	mock.ExpectQuery(claimDueQuery).WithArgs(10, 60.0).WillReturnRows(rows)
+
+	deliveries, err := webhookPGRepository.ClaimDue(context.Background(), 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	require.Equal(t, deliveryUUID, deliveries[0].DeliveryID)
+	require.Equal(t, "https://brand.example/hooks", deliveries[0].URL)
+	require.Equal(t, "whsec_secret", deliveries[0].Secret)
+	require.Equal(t, 1, deliveries[0].Attempts)
+}
+
+func TestWebhookRepository_Redeliver(t *testing.T) {
+	t.Parallel()
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	defer sqlxDB.Close()
+
+	webhookPGRepository := NewWebhookPGRepository(sqlxDB)
+
+	deliveryUUID := uuid.New()
+	mock.ExpectQuery(redeliverQuery).WithArgs(deliveryUUID).WillReturnRows(sqlmock.NewRows(deliveryColumns).AddRow(
+		deliveryUUID, uuid.New(), uuid.New(), models.EventOrderCreated, []byte(`{}`), models.WebhookDeliveryStatusPending, 0, time.Now(), 410, "gone", "unexpected status 410", nil, time.Now(), time.Now(),
+	))
+
+	delivery, err := webhookPGRepository.Redeliver(context.Background(), deliveryUUID)
+	require.NoError(t, err)
+	require.Equal(t, models.WebhookDeliveryStatusPending, delivery.Status)
+	require.Equal(t, 0, delivery.Attempts)
+
+	t.Run("Pending", func(t *testing.T) {
+		mock.ExpectQuery(redeliverQuery).WithArgs(deliveryUUID).WillReturnRows(sqlmock.NewRows(deliveryColumns))
+
+		_, err := webhookPGRepository.Redeliver(context.Background(), deliveryUUID)
+		require.ErrorIs(t, err, models.ErrDeliveryPending)
+	})
+}
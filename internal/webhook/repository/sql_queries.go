@@ -0,0 +1,39 @@
+package repository
+
+const (
+	createSubscriptionQuery = `INSERT INTO webhook_subscriptions (brand_id, url, event_types, secret, active) VALUES ($1, $2, $3, $4, $5)
+		RETURNING subscription_id, brand_id, url, event_types, secret, active, version, created_at, updated_at`
+
+	findByIdQuery = `SELECT subscription_id, brand_id, url, event_types, secret, active, version, created_at, updated_at FROM webhook_subscriptions WHERE subscription_id = $1`
+
+	findAllByBrandIdQuery = `SELECT subscription_id, brand_id, url, event_types, secret, active, version, created_at, updated_at FROM webhook_subscriptions WHERE brand_id = $1 ORDER BY created_at LIMIT $2 OFFSET $3`
+
+	updateByIdQuery = `UPDATE webhook_subscriptions SET url = $2, event_types = $3, active = $4, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE subscription_id = $1 AND version = $5
+		RETURNING subscription_id, brand_id, url, event_types, secret, active, version, created_at, updated_at`
+
+	deleteByIdQuery = `DELETE FROM webhook_subscriptions WHERE subscription_id = $1`
+
+	enqueueQuery = `INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload)
+		SELECT subscription_id, $2, $3, $4 FROM webhook_subscriptions WHERE brand_id = $1 AND active AND $3 = ANY(event_types)
+		ON CONFLICT (subscription_id, event_id) DO NOTHING`
+
+	claimDueQuery = `WITH due AS (
+			SELECT delivery_id FROM webhook_deliveries WHERE status = 'pending' AND next_attempt_at <= CURRENT_TIMESTAMP AND (locked_until IS NULL OR locked_until < CURRENT_TIMESTAMP)
+			ORDER BY next_attempt_at LIMIT $1 FOR UPDATE SKIP LOCKED
+		), claimed AS (
+			UPDATE webhook_deliveries d SET locked_until = CURRENT_TIMESTAMP + $2 * INTERVAL '1 second' FROM due WHERE d.delivery_id = due.delivery_id
+			RETURNING d.delivery_id, d.subscription_id, d.event_id, d.event_type, d.payload, d.status, d.attempts, d.next_attempt_at, d.response_status, d.response_body, d.error, d.delivered_at, d.created_at, d.updated_at
+		)
+		SELECT c.delivery_id, c.subscription_id, c.event_id, c.event_type, c.payload, c.status, c.attempts, c.next_attempt_at, c.response_status, c.response_body, c.error, c.delivered_at, c.created_at, c.updated_at, s.url, s.secret
+		FROM claimed c JOIN webhook_subscriptions s ON s.subscription_id = c.subscription_id ORDER BY c.next_attempt_at`
+
+	recordAttemptQuery = `UPDATE webhook_deliveries SET status = $2, attempts = $3, next_attempt_at = $4, response_status = $5, response_body = $6, error = $7, delivered_at = $8, locked_until = NULL, updated_at = CURRENT_TIMESTAMP
+		WHERE delivery_id = $1`
+
+	findAllDeliveriesBySubscriptionIdQuery = `SELECT delivery_id, subscription_id, event_id, event_type, payload, status, attempts, next_attempt_at, response_status, response_body, error, delivered_at, created_at, updated_at FROM webhook_deliveries WHERE subscription_id = $1 ORDER BY created_at DESC LIMIT $2 OFFSET $3`
+
+	findDeliveryByIdQuery = `SELECT delivery_id, subscription_id, event_id, event_type, payload, status, attempts, next_attempt_at, response_status, response_body, error, delivered_at, created_at, updated_at FROM webhook_deliveries WHERE delivery_id = $1`
+
+	redeliverQuery = `UPDATE webhook_deliveries SET status = 'pending', attempts = 0, next_attempt_at = CURRENT_TIMESTAMP, locked_until = NULL, updated_at = CURRENT_TIMESTAMP WHERE delivery_id = $1 AND status <> 'pending'
+		RETURNING delivery_id, subscription_id, event_id, event_type, payload, status, attempts, next_attempt_at, response_status, response_body, error, delivered_at, created_at, updated_at`
+)
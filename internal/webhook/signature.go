@@ -0,0 +1,22 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// Sign hex encoded HMAC-SHA256 of "timestamp.body" with the subscription secret. Receivers recompute it from the
+// Webhook-Timestamp header and the raw body, and can reject old timestamps to stop replays
+func Sign(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify check the signature of body in constant time
+func Verify(secret string, timestamp string, body []byte, signature string) bool {
+	return secret != "" && hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
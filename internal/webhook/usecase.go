@@ -0,0 +1,25 @@
+//go:generate mockgen -source usecase.go -destination mock/usecase.go -package mock
+package webhook
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/pkg/utils"
+)
+
+// Webhook UseCase interface
+type WebhookUseCase interface {
+	Create(ctx context.Context, subscription *models.WebhookSubscription) (*models.WebhookSubscription, error)
+	FindAllByBrandId(ctx context.Context, brandID uuid.UUID, pagination *utils.Pagination) ([]models.WebhookSubscription, error)
+	FindById(ctx context.Context, subscriptionID uuid.UUID) (*models.WebhookSubscription, error)
+	UpdateById(ctx context.Context, subscription *models.WebhookSubscription) (*models.WebhookSubscription, error)
+	DeleteById(ctx context.Context, subscriptionID uuid.UUID) error
+	FindAllDeliveries(ctx context.Context, subscriptionID uuid.UUID, pagination *utils.Pagination) ([]models.WebhookDelivery, error)
+	FindDeliveryById(ctx context.Context, deliveryID uuid.UUID) (*models.WebhookDelivery, error)
+	Redeliver(ctx context.Context, deliveryID uuid.UUID) (*models.WebhookDelivery, error)
+	HandleEvent(ctx context.Context, event *models.OutboxEvent) error
+	Dispatch(ctx context.Context) (int, error)
+}
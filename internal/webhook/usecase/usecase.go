@@ -0,0 +1,365 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/internal/webhook"
+	"github.com/dinorain/kalobranded/pkg/constants"
+	"github.com/dinorain/kalobranded/pkg/http_client"
+	"github.com/dinorain/kalobranded/pkg/logger"
+	"github.com/dinorain/kalobranded/pkg/utils"
+)
+
+const (
+	defaultBatchSize    = 50
+	defaultLease        = time.Minute
+	defaultBackoff      = 30 * time.Second
+	defaultMaxBackoff   = 6 * time.Hour
+	defaultMaxAttempts  = 8
+	secretBytes         = 32
+	secretPrefix        = "whsec_"
+	maxResponseBodySize = 1024
+)
+
+// Webhook UseCase
+type webhookUseCase struct {
+	cfg           *config.Config
+	logger        logger.Logger
+	webhookPgRepo webhook.WebhookPGRepository
+	httpClient    *resty.Client
+}
+
+var _ webhook.WebhookUseCase = (*webhookUseCase)(nil)
+
+// New Webhook UseCase, httpClient should not retry on its own since failed deliveries are retried with backoff, and
+// should refuse non public addresses unless Webhook.AllowPrivateNetworks is set, see http_client.NewPublicHttpClient
+func NewWebhookUseCase(cfg *config.Config, logger logger.Logger, webhookRepo webhook.WebhookPGRepository, httpClient *resty.Client) *webhookUseCase {
+	return &webhookUseCase{cfg: cfg, logger: logger, webhookPgRepo: webhookRepo, httpClient: httpClient}
+}
+
+// Create new webhook subscription, a signing secret is generated when none is given
+func (u *webhookUseCase) Create(ctx context.Context, subscription *models.WebhookSubscription) (*models.WebhookSubscription, error) {
+	if err := u.checkURL(ctx, subscription.URL); err != nil {
+		return nil, err
+	}
+
+	if subscription.Secret == "" {
+		secret, err := newSecret()
+		if err != nil {
+			return nil, errors.Wrap(err, "newSecret")
+		}
+		subscription.Secret = secret
+	}
+
+	createdSubscription, err := u.webhookPgRepo.Create(ctx, subscription)
+	if err != nil {
+		return nil, errors.Wrap(err, "webhookPgRepo.Create")
+	}
+
+	return createdSubscription, nil
+}
+
+// FindAllByBrandId find webhook subscriptions of brand
+func (u *webhookUseCase) FindAllByBrandId(ctx context.Context, brandID uuid.UUID, pagination *utils.Pagination) ([]models.WebhookSubscription, error) {
+	subscriptions, err := u.webhookPgRepo.FindAllByBrandId(ctx, brandID, pagination)
+	if err != nil {
+		return nil, errors.Wrap(err, "webhookPgRepo.FindAllByBrandId")
+	}
+
+	return subscriptions, nil
+}
+
+// FindById find webhook subscription by uuid
+func (u *webhookUseCase) FindById(ctx context.Context, subscriptionID uuid.UUID) (*models.WebhookSubscription, error) {
+	subscription, err := u.webhookPgRepo.FindById(ctx, subscriptionID)
+	if err != nil {
+		return nil, errors.Wrap(err, "webhookPgRepo.FindById")
+	}
+
+	return subscription, nil
+}
+
+// UpdateById update existing webhook subscription
+func (u *webhookUseCase) UpdateById(ctx context.Context, subscription *models.WebhookSubscription) (*models.WebhookSubscription, error) {
+	if err := u.checkURL(ctx, subscription.URL); err != nil {
+		return nil, err
+	}
+
+	updatedSubscription, err := u.webhookPgRepo.UpdateById(ctx, subscription)
+	if err != nil {
+		return nil, errors.Wrap(err, "webhookPgRepo.UpdateById")
+	}
+
+	return updatedSubscription, nil
+}
+
+// DeleteById delete webhook subscription by uuid
+func (u *webhookUseCase) DeleteById(ctx context.Context, subscriptionID uuid.UUID) error {
+	if err := u.webhookPgRepo.DeleteById(ctx, subscriptionID); err != nil {
+		return errors.Wrap(err, "webhookPgRepo.DeleteById")
+	}
+
+	return nil
+}
+
+// FindAllDeliveries find the delivery log of webhook subscription
+func (u *webhookUseCase) FindAllDeliveries(ctx context.Context, subscriptionID uuid.UUID, pagination *utils.Pagination) ([]models.WebhookDelivery, error) {
+	deliveries, err := u.webhookPgRepo.FindAllDeliveriesBySubscriptionId(ctx, subscriptionID, pagination)
+	if err != nil {
+		return nil, errors.Wrap(err, "webhookPgRepo.FindAllDeliveriesBySubscriptionId")
+	}
+
+	return deliveries, nil
+}
+
+// FindDeliveryById find webhook delivery by uuid
+func (u *webhookUseCase) FindDeliveryById(ctx context.Context, deliveryID uuid.UUID) (*models.WebhookDelivery, error) {
+	delivery, err := u.webhookPgRepo.FindDeliveryById(ctx, deliveryID)
+	if err != nil {
+		return nil, errors.Wrap(err, "webhookPgRepo.FindDeliveryById")
+	}
+
+	return delivery, nil
+}
+
+// Redeliver queue a delivery that succeeded or failed for the next dispatch, with a fresh set of attempts
+func (u *webhookUseCase) Redeliver(ctx context.Context, deliveryID uuid.UUID) (*models.WebhookDelivery, error) {
+	delivery, err := u.webhookPgRepo.Redeliver(ctx, deliveryID)
+	if err != nil {
+		return nil, errors.Wrap(err, "webhookPgRepo.Redeliver")
+	}
+
+	return delivery, nil
+}
+
+// HandleEvent outbox consumer queueing a delivery of event to every webhook of its brand subscribed to it. Events
+// brands can't subscribe to are ignored
+func (u *webhookUseCase) HandleEvent(ctx context.Context, event *models.OutboxEvent) error {
+	if !models.IsWebhookEventType(event.EventType) {
+		return nil
+	}
+
+	var payload struct {
+		BrandID uuid.UUID `json:"brand_id"`
+	}
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		return errors.Wrap(err, "json.Unmarshal")
+	}
+	if payload.BrandID == uuid.Nil {
+		return nil
+	}
+
+	if _, err := u.webhookPgRepo.Enqueue(ctx, payload.BrandID, event); err != nil {
+		return errors.Wrap(err, "webhookPgRepo.Enqueue")
+	}
+
+	return nil
+}
+
+// Dispatch attempt a batch of due deliveries concurrently and report how many were attempted. A failed delivery is
+// retried after Webhook.Backoff doubled on every attempt, and marked failed after Webhook.MaxAttempts
+func (u *webhookUseCase) Dispatch(ctx context.Context) (int, error) {
+	deliveries, err := u.webhookPgRepo.ClaimDue(ctx, u.getBatchSize(), u.getLease())
+	if err != nil {
+		return 0, errors.Wrap(err, "webhookPgRepo.ClaimDue")
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	for i := range deliveries {
+		wg.Add(1)
+		go func(d *models.DueWebhookDelivery) {
+			defer wg.Done()
+
+			u.attempt(ctx, d)
+			if err := u.webhookPgRepo.RecordAttempt(ctx, &d.WebhookDelivery); err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = errors.Wrapf(err, "webhookPgRepo.RecordAttempt %s", d.DeliveryID)
+				}
+				mu.Unlock()
+			}
+		}(&deliveries[i])
+	}
+	wg.Wait()
+
+	return len(deliveries), firstErr
+}
+
+// attempt post the delivery to its subscription url and set the outcome on it
+func (u *webhookUseCase) attempt(ctx context.Context, d *models.DueWebhookDelivery) {
+	now := time.Now()
+	d.Attempts++
+
+	body, err := json.Marshal(models.WebhookEnvelope{
+		DeliveryID: d.DeliveryID,
+		EventID:    d.EventID,
+		EventType:  d.EventType,
+		Attempt:    d.Attempts,
+		SentAt:     now.UTC(),
+		Data:       d.Payload,
+	})
+	if err != nil {
+		u.fail(d, now, 0, "", err.Error())
+		return
+	}
+
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	res, err := u.httpClient.R().
+		SetContext(ctx).
+		SetHeader("Content-Type", "application/json").
+		SetHeader(constants.WebhookSignature, webhook.Sign(d.Secret, timestamp, body)).
+		SetHeader(constants.WebhookTimestamp, timestamp).
+		SetHeader(constants.WebhookEvent, d.EventType).
+		SetHeader(constants.WebhookDelivery, d.DeliveryID.String()).
+		SetBody(body).
+		SetDoNotParseResponse(true).
+		Post(d.URL)
+	if err != nil {
+		u.fail(d, now, 0, "", err.Error())
+		return
+	}
+
+	responseBody := readResponseBody(res.RawBody())
+	if !res.IsSuccess() {
+		u.fail(d, now, res.StatusCode(), responseBody, fmt.Sprintf("unexpected status %d", res.StatusCode()))
+		return
+	}
+
+	d.Status = models.WebhookDeliveryStatusSucceeded
+	d.ResponseStatus = res.StatusCode()
+	d.ResponseBody = responseBody
+	d.Error = ""
+	d.DeliveredAt = &now
+	d.NextAttemptAt = nil
+}
+
+// fail record a failed attempt, scheduling the next one unless attempts are used up
+func (u *webhookUseCase) fail(d *models.DueWebhookDelivery, now time.Time, status int, responseBody string, reason string) {
+	d.ResponseStatus = status
+	d.ResponseBody = responseBody
+	d.Error = reason
+
+	if d.Attempts >= u.getMaxAttempts() {
+		d.Status = models.WebhookDeliveryStatusFailed
+		d.NextAttemptAt = nil
+		u.logger.Warnf("webhook delivery %s of %s to %s failed after %d attempts: %s", d.DeliveryID, d.EventType, d.URL, d.Attempts, reason)
+		return
+	}
+
+	next := now.Add(u.backoff(d.Attempts))
+	d.Status = models.WebhookDeliveryStatusPending
+	d.NextAttemptAt = &next
+}
+
+// backoff wait after the attempts-th failed attempt, Webhook.Backoff doubled on every attempt up to Webhook.MaxBackoff
+func (u *webhookUseCase) backoff(attempts int) time.Duration {
+	wait, maxWait := u.getBackoff(), u.getMaxBackoff()
+	for i := 1; i < attempts && wait < maxWait; i++ {
+		wait *= 2
+	}
+	if wait > maxWait {
+		return maxWait
+	}
+	return wait
+}
+
+// checkURL reject urls whose host does not resolve to public addresses, the dispatcher http client enforces the
+// same on every connection since the host may resolve differently later
+func (u *webhookUseCase) checkURL(ctx context.Context, rawURL string) error {
+	if u.cfg.Webhook.AllowPrivateNetworks {
+		return nil
+	}
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return models.ErrInvalidWebhookURL
+	}
+
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, parsed.Hostname())
+	if err != nil || len(addrs) == 0 {
+		return models.ErrNonPublicWebhookURL
+	}
+	for _, addr := range addrs {
+		if !http_client.IsPublicIP(addr.IP) {
+			return models.ErrNonPublicWebhookURL
+		}
+	}
+
+	return nil
+}
+
+// readResponseBody read at most maxResponseBodySize bytes of a receiver response, kept as valid text
+func readResponseBody(body io.ReadCloser) string {
+	if body == nil {
+		return ""
+	}
+	defer body.Close()
+
+	b, _ := ioutil.ReadAll(io.LimitReader(body, maxResponseBodySize))
+	return strings.ToValidUTF8(strings.ReplaceAll(string(b), "\x00", ""), "")
+}
+
+func newSecret() (string, error) {
+	b := make([]byte, secretBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return secretPrefix + hex.EncodeToString(b), nil
+}
+
+func (u *webhookUseCase) getBatchSize() int {
+	if u.cfg.Webhook.BatchSize > 0 {
+		return u.cfg.Webhook.BatchSize
+	}
+	return defaultBatchSize
+}
+
+func (u *webhookUseCase) getLease() time.Duration {
+	if u.cfg.Webhook.Lease > 0 {
+		return u.cfg.Webhook.Lease
+	}
+	return defaultLease
+}
+
+func (u *webhookUseCase) getBackoff() time.Duration {
+	if u.cfg.Webhook.Backoff > 0 {
+		return u.cfg.Webhook.Backoff
+	}
+	return defaultBackoff
+}
+
+func (u *webhookUseCase) getMaxBackoff() time.Duration {
+	if u.cfg.Webhook.MaxBackoff > 0 {
+		return u.cfg.Webhook.MaxBackoff
+	}
+	return defaultMaxBackoff
+}
+
+func (u *webhookUseCase) getMaxAttempts() int {
+	if u.cfg.Webhook.MaxAttempts > 0 {
+		return u.cfg.Webhook.MaxAttempts
+	}
+	return defaultMaxAttempts
+}
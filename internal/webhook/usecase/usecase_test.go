@@ -0,0 +1,230 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/internal/models"
+	"github.com/dinorain/kalobranded/internal/webhook"
+	"github.com/dinorain/kalobranded/internal/webhook/mock"
+	"github.com/dinorain/kalobranded/pkg/constants"
+	"github.com/dinorain/kalobranded/pkg/http_client"
+	"github.com/dinorain/kalobranded/pkg/logger"
+)
+
+func TestWebhookUseCase_Create(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	webhookPGRepository := mock.NewMockWebhookPGRepository(ctrl)
+	apiLogger := logger.NewAppLogger(nil)
+
+	webhookUC := NewWebhookUseCase(&config.Config{}, apiLogger, webhookPGRepository, http_client.NewHttpClient(false))
+
+	webhookPGRepository.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, s *models.WebhookSubscription) (*models.WebhookSubscription, error) {
+		return s, nil
+	}).Times(2)
+
+	createdSubscription, err := webhookUC.Create(context.Background(), &models.WebhookSubscription{BrandID: uuid.New(), URL: "https://93.184.216.34/hooks"})
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(createdSubscription.Secret, secretPrefix))
+	require.Len(t, createdSubscription.Secret, len(secretPrefix)+2*secretBytes)
+
+	createdSubscription, err = webhookUC.Create(context.Background(), &models.WebhookSubscription{BrandID: uuid.New(), URL: "https://93.184.216.34/hooks", Secret: "my-own-secret-value"})
+	require.NoError(t, err)
+	require.Equal(t, "my-own-secret-value", createdSubscription.Secret)
+
+	t.Run("NonPublicURL", func(t *testing.T) {
+		for _, url := range []string{"http://127.0.0.1:8080/hooks", "http://localhost/hooks", "http://10.1.2.3/hooks", "http://169.254.169.254/latest/meta-data", "http://[::1]/hooks", "http://[fd00::1]/hooks"} {
+			_, err := webhookUC.Create(context.Background(), &models.WebhookSubscription{BrandID: uuid.New(), URL: url})
+			require.ErrorIs(t, err, models.ErrNonPublicWebhookURL, url)
+		}
+	})
+}
+
+func TestWebhookUseCase_HandleEvent(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	webhookPGRepository := mock.NewMockWebhookPGRepository(ctrl)
+	apiLogger := logger.NewAppLogger(nil)
+
+	webhookUC := NewWebhookUseCase(&config.Config{}, apiLogger, webhookPGRepository, http_client.NewHttpClient(false))
+
+	brandUUID := uuid.New()
+	event, err := models.NewOutboxEvent(models.AggregateOrder, uuid.New(), models.EventOrderStatusChanged, &models.OrderStatusChange{BrandID: brandUUID, To: models.OrderStatusAccepted})
+	require.NoError(t, err)
+
+	webhookPGRepository.EXPECT().Enqueue(gomock.Any(), brandUUID, event).Return(int64(1), nil)
+
+	err = webhookUC.HandleEvent(context.Background(), event)
+	require.NoError(t, err)
+
+	t.Run("NotSubscribable", func(t *testing.T) {
+		event, err := models.NewOutboxEvent(models.AggregateUser, uuid.New(), models.EventUserRegistered, map[string]string{"brand_id": brandUUID.String()})
+		require.NoError(t, err)
+
+		err = webhookUC.HandleEvent(context.Background(), event)
+		require.NoError(t, err)
+	})
+}
+
+func TestWebhookUseCase_Dispatch(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	webhookPGRepository := mock.NewMockWebhookPGRepository(ctrl)
+
+	cfg := &config.Config{Webhook: config.Webhook{BatchSize: 10, Lease: time.Minute, Backoff: time.Minute, MaxBackoff: 5 * time.Minute, MaxAttempts: 3}}
+	apiLogger := logger.NewAppLogger(cfg)
+	apiLogger.InitLogger()
+
+	webhookUC := NewWebhookUseCase(cfg, apiLogger, webhookPGRepository, http_client.NewHttpClient(false).SetRetryCount(0))
+
+	var failing int32
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if !webhook.Verify("receiver-secret", r.Header.Get(constants.WebhookTimestamp), body, r.Header.Get(constants.WebhookSignature)) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if atomic.LoadInt32(&failing) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte("down for maintenance"))
+			return
+		}
+
+		envelope := models.WebhookEnvelope{}
+		if err := json.Unmarshal(body, &envelope); err != nil || envelope.EventType != r.Header.Get(constants.WebhookEvent) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer receiver.Close()
+
+	newDelivery := func(attempts int) models.DueWebhookDelivery {
+		return models.DueWebhookDelivery{
+			WebhookDelivery: models.WebhookDelivery{
+				DeliveryID: uuid.New(),
+				EventID:    uuid.New(),
+				EventType:  models.EventOrderCreated,
+				Payload:    models.EventPayload(`{"order_id":"1"}`),
+				Status:     models.WebhookDeliveryStatusPending,
+				Attempts:   attempts,
+			},
+			URL:    receiver.URL,
+			Secret: "receiver-secret",
+		}
+	}
+
+	ctx := context.Background()
+
+	delivery := newDelivery(0)
+	webhookPGRepository.EXPECT().ClaimDue(gomock.Any(), 10, time.Minute).Return([]models.DueWebhookDelivery{delivery}, nil)
+	webhookPGRepository.EXPECT().RecordAttempt(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, d *models.WebhookDelivery) error {
+		require.Equal(t, models.WebhookDeliveryStatusSucceeded, d.Status)
+		require.Equal(t, 1, d.Attempts)
+		require.Equal(t, http.StatusOK, d.ResponseStatus)
+		require.Equal(t, "ok", d.ResponseBody)
+		require.NotNil(t, d.DeliveredAt)
+		require.Nil(t, d.NextAttemptAt)
+		return nil
+	})
+
+	attempted, err := webhookUC.Dispatch(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, attempted)
+
+	t.Run("Retry", func(t *testing.T) {
+		atomic.StoreInt32(&failing, 1)
+		defer atomic.StoreInt32(&failing, 0)
+
+		delivery := newDelivery(1)
+		webhookPGRepository.EXPECT().ClaimDue(gomock.Any(), 10, time.Minute).Return([]models.DueWebhookDelivery{delivery}, nil)
+		webhookPGRepository.EXPECT().RecordAttempt(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, d *models.WebhookDelivery) error {
+			require.Equal(t, models.WebhookDeliveryStatusPending, d.Status)
+			require.Equal(t, 2, d.Attempts)
+			require.Equal(t, http.StatusServiceUnavailable, d.ResponseStatus)
+			require.Equal(t, "down for maintenance", d.ResponseBody)
+			require.NotNil(t, d.NextAttemptAt)
+			require.WithinDuration(t, time.Now().Add(2*time.Minute), *d.NextAttemptAt, 10*time.Second)
+			return nil
+		})
+
+		_, err := webhookUC.Dispatch(ctx)
+		require.NoError(t, err)
+	})
+
+	t.Run("Failed", func(t *testing.T) {
+		delivery := newDelivery(2)
+		delivery.Secret = "rotated-secret"
+		webhookPGRepository.EXPECT().ClaimDue(gomock.Any(), 10, time.Minute).Return([]models.DueWebhookDelivery{delivery}, nil)
+		webhookPGRepository.EXPECT().RecordAttempt(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, d *models.WebhookDelivery) error {
+			require.Equal(t, models.WebhookDeliveryStatusFailed, d.Status)
+			require.Equal(t, 3, d.Attempts)
+			require.Equal(t, http.StatusUnauthorized, d.ResponseStatus)
+			require.Nil(t, d.NextAttemptAt)
+			return nil
+		})
+
+		_, err := webhookUC.Dispatch(ctx)
+		require.NoError(t, err)
+	})
+
+	t.Run("NonPublicAddress", func(t *testing.T) {
+		publicUC := NewWebhookUseCase(cfg, apiLogger, webhookPGRepository, http_client.NewPublicHttpClient(false).SetRetryCount(0))
+
+		delivery := newDelivery(0)
+		webhookPGRepository.EXPECT().ClaimDue(gomock.Any(), 10, time.Minute).Return([]models.DueWebhookDelivery{delivery}, nil)
+		webhookPGRepository.EXPECT().RecordAttempt(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, d *models.WebhookDelivery) error {
+			require.Equal(t, models.WebhookDeliveryStatusPending, d.Status)
+			require.Equal(t, 0, d.ResponseStatus)
+			require.Contains(t, d.Error, http_client.ErrNonPublicAddress.Error())
+			return nil
+		})
+
+		_, err := publicUC.Dispatch(ctx)
+		require.NoError(t, err)
+	})
+}
+
+func TestReadResponseBody(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "", readResponseBody(nil))
+	require.Equal(t, "ok", readResponseBody(ioutil.NopCloser(strings.NewReader("o\x00k"))))
+	require.Len(t, readResponseBody(ioutil.NopCloser(strings.NewReader(strings.Repeat("a", 10*maxResponseBodySize)))), maxResponseBodySize)
+	require.Equal(t, strings.Repeat("a", maxResponseBodySize-1), readResponseBody(ioutil.NopCloser(strings.NewReader(strings.Repeat("a", maxResponseBodySize-1)+"é"))))
+}
+
+func TestWebhookUseCase_Backoff(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.Config{Webhook: config.Webhook{Backoff: 30 * time.Second, MaxBackoff: 3 * time.Minute}}
+	webhookUC := NewWebhookUseCase(cfg, logger.NewAppLogger(nil), nil, nil)
+
+	require.Equal(t, 30*time.Second, webhookUC.backoff(1))
+	require.Equal(t, time.Minute, webhookUC.backoff(2))
+	require.Equal(t, 2*time.Minute, webhookUC.backoff(3))
+	require.Equal(t, 3*time.Minute, webhookUC.backoff(4))
+	require.Equal(t, 3*time.Minute, webhookUC.backoff(40))
+}
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS user_identities CASCADE;
+ALTER TABLE users ALTER COLUMN delivery_address DROP DEFAULT;
+ALTER TABLE users ADD CONSTRAINT users_delivery_address_check CHECK ( delivery_address <> '' );
\ No newline at end of file
@@ -0,0 +1,19 @@
+-- users signing in with an OIDC provider are created without a delivery address, the provider claims carry none.
+-- The application still refuses to clear the address of a user and to place orders without one
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_delivery_address_check;
+ALTER TABLE users ALTER COLUMN delivery_address SET DEFAULT '';
+
+DROP TABLE IF EXISTS user_identities CASCADE;
+CREATE TABLE user_identities
+(
+    identity_id UUID PRIMARY KEY                  DEFAULT uuid_generate_v4(),
+    user_id     UUID REFERENCES users (user_id) ON DELETE CASCADE,
+    provider    VARCHAR(64)              NOT NULL CHECK ( provider <> '' ),
+    subject     VARCHAR(250)             NOT NULL CHECK ( subject <> '' ),
+    email       VARCHAR(64)              NOT NULL DEFAULT '',
+
+    created_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMP WITH TIME ZONE          DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (provider, subject)
+);
+CREATE INDEX idx_user_identities__user_id ON user_identities(user_id);
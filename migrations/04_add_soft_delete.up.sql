@@ -0,0 +1,9 @@
+ALTER TABLE users ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE brands ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE products ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE orders ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX idx_users__deleted_at ON users(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_brands__deleted_at ON brands(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_products__deleted_at ON products(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_orders__deleted_at ON orders(deleted_at) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,10 @@
+DROP TABLE IF EXISTS payments CASCADE;
+DROP TYPE IF EXISTS payment_status;
+
+UPDATE orders SET status = 'pending' WHERE status = 'paid';
+ALTER TYPE status RENAME TO status_old;
+CREATE TYPE status AS ENUM ('pending', 'accepted');
+ALTER TABLE orders ALTER COLUMN status DROP DEFAULT;
+ALTER TABLE orders ALTER COLUMN status TYPE status USING status::text::status;
+ALTER TABLE orders ALTER COLUMN status SET DEFAULT 'pending';
+DROP TYPE status_old;
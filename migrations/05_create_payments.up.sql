@@ -0,0 +1,20 @@
+ALTER TYPE status ADD VALUE IF NOT EXISTS 'paid' BEFORE 'accepted';
+
+CREATE TYPE payment_status AS ENUM ('pending', 'authorized', 'captured', 'failed');
+
+DROP TABLE IF EXISTS payments CASCADE;
+CREATE TABLE payments
+(
+    payment_id          UUID PRIMARY KEY                  DEFAULT uuid_generate_v4(),
+    order_id            UUID           NOT NULL REFERENCES orders (order_id) ON DELETE CASCADE,
+    provider            VARCHAR(32)    NOT NULL CHECK ( provider <> '' ),
+    provider_payment_id VARCHAR(250)   NOT NULL CHECK ( provider_payment_id <> '' ),
+    amount              NUMERIC        NOT NULL,
+    currency            VARCHAR(3)     NOT NULL,
+    status              payment_status NOT NULL DEFAULT 'pending',
+
+    created_at          TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at          TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX idx_payments__order_id ON payments(order_id);
+CREATE UNIQUE INDEX idx_payments__provider_payment_id ON payments(provider, provider_payment_id);
@@ -0,0 +1,24 @@
+DROP TABLE IF EXISTS refunds CASCADE;
+DROP TYPE IF EXISTS refund_reason;
+
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS orders_refunded_quantity_check;
+ALTER TABLE orders DROP COLUMN IF EXISTS refunded_amount;
+ALTER TABLE orders DROP COLUMN IF EXISTS refunded_quantity;
+ALTER TABLE products DROP COLUMN IF EXISTS stock;
+ALTER TABLE users DROP COLUMN IF EXISTS brand_id;
+
+UPDATE orders SET status = 'accepted' WHERE status = 'refunded';
+ALTER TYPE status RENAME TO status_old;
+CREATE TYPE status AS ENUM ('pending', 'paid', 'accepted');
+ALTER TABLE orders ALTER COLUMN status DROP DEFAULT;
+ALTER TABLE orders ALTER COLUMN status TYPE status USING status::text::status;
+ALTER TABLE orders ALTER COLUMN status SET DEFAULT 'pending';
+DROP TYPE status_old;
+
+UPDATE users SET role = 'user' WHERE role = 'seller';
+ALTER TYPE role RENAME TO role_old;
+CREATE TYPE role AS ENUM ('admin', 'user');
+ALTER TABLE users ALTER COLUMN role DROP DEFAULT;
+ALTER TABLE users ALTER COLUMN role TYPE role USING role::text::role;
+ALTER TABLE users ALTER COLUMN role SET DEFAULT 'user';
+DROP TYPE role_old;
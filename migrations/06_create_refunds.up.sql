@@ -0,0 +1,29 @@
+ALTER TYPE role ADD VALUE IF NOT EXISTS 'seller';
+ALTER TYPE status ADD VALUE IF NOT EXISTS 'refunded';
+
+ALTER TABLE users ADD COLUMN brand_id UUID REFERENCES brands (brand_id);
+ALTER TABLE products ADD COLUMN stock NUMERIC NOT NULL DEFAULT 0 CHECK ( stock >= 0 );
+ALTER TABLE orders ADD COLUMN refunded_quantity NUMERIC NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD COLUMN refunded_amount NUMERIC NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD CONSTRAINT orders_refunded_quantity_check CHECK ( refunded_quantity <= quantity );
+
+CREATE INDEX idx_users__brand_id ON users(brand_id) WHERE brand_id IS NOT NULL;
+
+CREATE TYPE refund_reason AS ENUM ('damaged', 'wrong_item', 'not_received', 'customer_request', 'other');
+
+DROP TABLE IF EXISTS refunds CASCADE;
+CREATE TABLE refunds
+(
+    refund_id          UUID PRIMARY KEY                 DEFAULT uuid_generate_v4(),
+    order_id           UUID          NOT NULL REFERENCES orders (order_id) ON DELETE CASCADE,
+    quantity           NUMERIC       NOT NULL CHECK ( quantity > 0 ),
+    amount             NUMERIC       NOT NULL CHECK ( amount >= 0 ),
+    reason             refund_reason NOT NULL,
+    note               VARCHAR(500)  NOT NULL DEFAULT '',
+    restock            BOOLEAN       NOT NULL DEFAULT FALSE,
+    refunded_by        UUID          NOT NULL REFERENCES users (user_id),
+    provider_refund_id VARCHAR(250)  NOT NULL DEFAULT '',
+
+    created_at         TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX idx_refunds__order_id ON refunds(order_id);
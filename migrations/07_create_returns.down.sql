@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS returns CASCADE;
+DROP TYPE IF EXISTS return_status;
+
+ALTER TABLE brands DROP COLUMN IF EXISTS return_window_days;
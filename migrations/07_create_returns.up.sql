@@ -0,0 +1,26 @@
+ALTER TABLE brands ADD COLUMN return_window_days INTEGER NOT NULL DEFAULT 30 CHECK ( return_window_days >= 0 );
+
+CREATE TYPE return_status AS ENUM ('requested', 'approved', 'received', 'refunded', 'rejected');
+
+DROP TABLE IF EXISTS returns CASCADE;
+CREATE TABLE returns
+(
+    return_id        UUID PRIMARY KEY                 DEFAULT uuid_generate_v4(),
+    order_id         UUID          NOT NULL REFERENCES orders (order_id) ON DELETE CASCADE,
+    user_id          UUID          NOT NULL REFERENCES users (user_id),
+    brand_id         UUID          NOT NULL REFERENCES brands (brand_id),
+    quantity         NUMERIC       NOT NULL CHECK ( quantity > 0 ),
+    reason           refund_reason NOT NULL,
+    note             VARCHAR(500)  NOT NULL DEFAULT '',
+    photo_urls       JSONB         NOT NULL DEFAULT '[]',
+    status           return_status NOT NULL DEFAULT 'requested',
+    label_address    VARCHAR(250)  NOT NULL DEFAULT '',
+    rejection_reason VARCHAR(500)  NOT NULL DEFAULT '',
+    refund_id        UUID REFERENCES refunds (refund_id),
+    version          INTEGER       NOT NULL DEFAULT 1,
+
+    created_at       TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at       TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX idx_returns__order_id ON returns(order_id);
+CREATE INDEX idx_returns__brand_id ON returns(brand_id);
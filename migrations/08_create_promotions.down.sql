@@ -0,0 +1,11 @@
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS discount_total,
+    DROP COLUMN IF EXISTS applied_promotions,
+    DROP COLUMN IF EXISTS free_shipping;
+
+DROP TABLE IF EXISTS promotion_redemptions CASCADE;
+DROP TABLE IF EXISTS promotions CASCADE;
+DROP TYPE IF EXISTS promotion_type;
+
+DROP INDEX IF EXISTS idx_products__category;
+ALTER TABLE products DROP COLUMN IF EXISTS category;
@@ -0,0 +1,55 @@
+ALTER TABLE products ADD COLUMN category VARCHAR(64) NOT NULL DEFAULT '';
+CREATE INDEX idx_products__category ON products(category);
+
+CREATE TYPE promotion_type AS ENUM ('percentage', 'fixed_amount', 'buy_x_get_y', 'free_shipping');
+
+DROP TABLE IF EXISTS promotions CASCADE;
+CREATE TABLE promotions
+(
+    promotion_id   UUID PRIMARY KEY        DEFAULT uuid_generate_v4(),
+    code           VARCHAR(64) UNIQUE,
+    name           VARCHAR(100)   NOT NULL,
+    type           promotion_type NOT NULL,
+    value          NUMERIC        NOT NULL DEFAULT 0 CHECK ( value >= 0 ),
+    buy_quantity   INTEGER        NOT NULL DEFAULT 0 CHECK ( buy_quantity >= 0 ),
+    get_quantity   INTEGER        NOT NULL DEFAULT 0 CHECK ( get_quantity >= 0 ),
+    brand_id       UUID REFERENCES brands (brand_id),
+    product_id     UUID REFERENCES products (product_id),
+    category       VARCHAR(64),
+    starts_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    ends_at        TIMESTAMP WITH TIME ZONE,
+    usage_limit    INTEGER CHECK ( usage_limit > 0 ),
+    per_user_limit INTEGER CHECK ( per_user_limit > 0 ),
+    usage_count    INTEGER        NOT NULL DEFAULT 0 CHECK ( usage_count >= 0 ),
+    stackable      BOOLEAN        NOT NULL DEFAULT FALSE,
+    active         BOOLEAN        NOT NULL DEFAULT TRUE,
+    version        INTEGER        NOT NULL DEFAULT 1,
+    deleted_at     TIMESTAMP WITH TIME ZONE,
+
+    created_at     TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at     TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CHECK ( ends_at IS NULL OR ends_at > starts_at ),
+    CHECK ( usage_limit IS NULL OR usage_count <= usage_limit )
+);
+CREATE INDEX idx_promotions__brand_id ON promotions(brand_id);
+
+DROP TABLE IF EXISTS promotion_redemptions CASCADE;
+CREATE TABLE promotion_redemptions
+(
+    redemption_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    promotion_id  UUID    NOT NULL REFERENCES promotions (promotion_id),
+    order_id      UUID    NOT NULL REFERENCES orders (order_id) ON DELETE CASCADE,
+    user_id       UUID    NOT NULL REFERENCES users (user_id),
+    discount      NUMERIC NOT NULL DEFAULT 0 CHECK ( discount >= 0 ),
+
+    created_at    TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    UNIQUE (promotion_id, order_id)
+);
+CREATE INDEX idx_promotion_redemptions__promotion_id_user_id ON promotion_redemptions(promotion_id, user_id);
+
+ALTER TABLE orders
+    ADD COLUMN discount_total     NUMERIC NOT NULL DEFAULT 0 CHECK ( discount_total >= 0 ),
+    ADD COLUMN applied_promotions JSONB   NOT NULL DEFAULT '[]',
+    ADD COLUMN free_shipping      BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,5 @@
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS tax_total,
+    DROP COLUMN IF EXISTS tax_lines;
+
+DROP TABLE IF EXISTS tax_rates CASCADE;
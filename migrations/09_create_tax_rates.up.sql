@@ -0,0 +1,18 @@
+DROP TABLE IF EXISTS tax_rates CASCADE;
+CREATE TABLE tax_rates
+(
+    tax_rate_id        UUID PRIMARY KEY       DEFAULT uuid_generate_v4(),
+    region             VARCHAR(32)   NOT NULL UNIQUE CHECK ( region <> '' ),
+    name               VARCHAR(100)  NOT NULL,
+    rate               NUMERIC(5, 2) NOT NULL CHECK ( rate >= 0 AND rate <= 100 ),
+    prices_include_tax BOOLEAN       NOT NULL DEFAULT FALSE,
+    category_rates     JSONB         NOT NULL DEFAULT '{}',
+    version            INTEGER       NOT NULL DEFAULT 1,
+
+    created_at         TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at         TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE orders
+    ADD COLUMN tax_total NUMERIC NOT NULL DEFAULT 0 CHECK ( tax_total >= 0 ),
+    ADD COLUMN tax_lines JSONB   NOT NULL DEFAULT '[]';
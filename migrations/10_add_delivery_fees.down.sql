@@ -0,0 +1,13 @@
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS delivery_fee,
+    DROP COLUMN IF EXISTS delivery_distance;
+
+ALTER TABLE products DROP COLUMN IF EXISTS weight;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS delivery_latitude,
+    DROP COLUMN IF EXISTS delivery_longitude;
+
+ALTER TABLE brands
+    DROP COLUMN IF EXISTS pickup_latitude,
+    DROP COLUMN IF EXISTS pickup_longitude;
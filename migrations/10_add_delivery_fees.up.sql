@@ -0,0 +1,15 @@
+ALTER TABLE brands
+    ADD COLUMN pickup_latitude  DOUBLE PRECISION CHECK ( pickup_latitude BETWEEN -90 AND 90 ),
+    ADD COLUMN pickup_longitude DOUBLE PRECISION CHECK ( pickup_longitude BETWEEN -180 AND 180 ),
+    ADD CHECK ( (pickup_latitude IS NULL) = (pickup_longitude IS NULL) );
+
+ALTER TABLE users
+    ADD COLUMN delivery_latitude  DOUBLE PRECISION CHECK ( delivery_latitude BETWEEN -90 AND 90 ),
+    ADD COLUMN delivery_longitude DOUBLE PRECISION CHECK ( delivery_longitude BETWEEN -180 AND 180 ),
+    ADD CHECK ( (delivery_latitude IS NULL) = (delivery_longitude IS NULL) );
+
+ALTER TABLE products ADD COLUMN weight INTEGER NOT NULL DEFAULT 0 CHECK ( weight >= 0 );
+
+ALTER TABLE orders
+    ADD COLUMN delivery_fee      NUMERIC NOT NULL DEFAULT 0 CHECK ( delivery_fee >= 0 ),
+    ADD COLUMN delivery_distance NUMERIC NOT NULL DEFAULT 0 CHECK ( delivery_distance >= 0 );
@@ -0,0 +1,3 @@
+ALTER TABLE orders DROP COLUMN IF EXISTS delivery_address;
+
+DROP TABLE IF EXISTS user_addresses CASCADE;
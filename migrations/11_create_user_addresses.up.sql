@@ -0,0 +1,26 @@
+DROP TABLE IF EXISTS user_addresses CASCADE;
+CREATE TABLE user_addresses
+(
+    address_id  UUID PRIMARY KEY      DEFAULT uuid_generate_v4(),
+    user_id     UUID         NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+    label       VARCHAR(32)  NOT NULL DEFAULT '',
+    recipient   VARCHAR(60)  NOT NULL CHECK ( recipient <> '' ),
+    phone       VARCHAR(20)  NOT NULL CHECK ( phone <> '' ),
+    street      VARCHAR(250) NOT NULL CHECK ( street <> '' ),
+    city        VARCHAR(60)  NOT NULL CHECK ( city <> '' ),
+    postal_code VARCHAR(16)  NOT NULL DEFAULT '',
+    country     VARCHAR(2)   NOT NULL CHECK ( country <> '' ),
+    latitude    DOUBLE PRECISION CHECK ( latitude BETWEEN -90 AND 90 ),
+    longitude   DOUBLE PRECISION CHECK ( longitude BETWEEN -180 AND 180 ),
+    is_default  BOOLEAN      NOT NULL DEFAULT FALSE,
+    version     INTEGER      NOT NULL DEFAULT 1,
+
+    created_at  TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CHECK ( (latitude IS NULL) = (longitude IS NULL) )
+);
+CREATE INDEX idx_user_addresses__user_id ON user_addresses(user_id);
+CREATE UNIQUE INDEX idx_user_addresses__user_id__default ON user_addresses(user_id) WHERE is_default;
+
+ALTER TABLE orders ADD COLUMN delivery_address JSONB;
@@ -0,0 +1,4 @@
+ALTER TABLE orders DROP COLUMN IF EXISTS location_id;
+
+DROP TABLE IF EXISTS location_stocks CASCADE;
+DROP TABLE IF EXISTS brand_locations CASCADE;
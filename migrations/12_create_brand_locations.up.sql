@@ -0,0 +1,35 @@
+DROP TABLE IF EXISTS brand_locations CASCADE;
+CREATE TABLE brand_locations
+(
+    location_id     UUID PRIMARY KEY      DEFAULT uuid_generate_v4(),
+    brand_id        UUID         NOT NULL REFERENCES brands (brand_id) ON DELETE CASCADE,
+    name            VARCHAR(60)  NOT NULL CHECK ( name <> '' ),
+    address         VARCHAR(250) NOT NULL CHECK ( address <> '' ),
+    latitude        DOUBLE PRECISION CHECK ( latitude BETWEEN -90 AND 90 ),
+    longitude       DOUBLE PRECISION CHECK ( longitude BETWEEN -180 AND 180 ),
+    operating_hours JSONB        NOT NULL DEFAULT '[]',
+    active          BOOLEAN      NOT NULL DEFAULT TRUE,
+    version         INTEGER      NOT NULL DEFAULT 1,
+    deleted_at      TIMESTAMP WITH TIME ZONE,
+
+    created_at      TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CHECK ( (latitude IS NULL) = (longitude IS NULL) )
+);
+CREATE INDEX idx_brand_locations__brand_id ON brand_locations(brand_id);
+
+DROP TABLE IF EXISTS location_stocks CASCADE;
+CREATE TABLE location_stocks
+(
+    location_id UUID    NOT NULL REFERENCES brand_locations (location_id) ON DELETE CASCADE,
+    product_id  UUID    NOT NULL REFERENCES products (product_id) ON DELETE CASCADE,
+    stock       INTEGER NOT NULL DEFAULT 0 CHECK ( stock >= 0 ),
+
+    updated_at  TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (location_id, product_id)
+);
+CREATE INDEX idx_location_stocks__product_id ON location_stocks(product_id);
+
+ALTER TABLE orders ADD COLUMN location_id UUID REFERENCES brand_locations (location_id);
@@ -0,0 +1,10 @@
+DROP TABLE IF EXISTS shipments CASCADE;
+DROP TYPE IF EXISTS shipment_status;
+
+UPDATE orders SET status = 'accepted' WHERE status IN ('shipped', 'delivered');
+ALTER TYPE status RENAME TO status_old;
+CREATE TYPE status AS ENUM ('pending', 'paid', 'accepted', 'refunded');
+ALTER TABLE orders ALTER COLUMN status DROP DEFAULT;
+ALTER TABLE orders ALTER COLUMN status TYPE status USING status::text::status;
+ALTER TABLE orders ALTER COLUMN status SET DEFAULT 'pending';
+DROP TYPE status_old;
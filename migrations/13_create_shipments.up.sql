@@ -0,0 +1,25 @@
+ALTER TYPE status ADD VALUE IF NOT EXISTS 'shipped';
+ALTER TYPE status ADD VALUE IF NOT EXISTS 'delivered';
+
+CREATE TYPE shipment_status AS ENUM ('label_created', 'picked_up', 'in_transit', 'out_for_delivery', 'delivered', 'failed');
+
+DROP TABLE IF EXISTS shipments CASCADE;
+CREATE TABLE shipments
+(
+    shipment_id     UUID PRIMARY KEY                DEFAULT uuid_generate_v4(),
+    order_id        UUID            NOT NULL REFERENCES orders (order_id) ON DELETE CASCADE,
+    carrier         VARCHAR(32)     NOT NULL CHECK ( carrier <> '' ),
+    service         VARCHAR(32)     NOT NULL CHECK ( service <> '' ),
+    tracking_number VARCHAR(64)     NOT NULL CHECK ( tracking_number <> '' ),
+    label_url       VARCHAR(512)    NOT NULL DEFAULT '',
+    rate            NUMERIC         NOT NULL DEFAULT 0,
+    status          shipment_status NOT NULL DEFAULT 'label_created',
+    events          JSONB           NOT NULL DEFAULT '[]',
+    version         INTEGER         NOT NULL DEFAULT 1,
+
+    created_at      TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX idx_shipments__order_id ON shipments(order_id);
+CREATE UNIQUE INDEX idx_shipments__tracking_number ON shipments(carrier, tracking_number);
+CREATE UNIQUE INDEX idx_shipments__order_id__open ON shipments(order_id) WHERE status <> 'failed';
@@ -0,0 +1,16 @@
+DROP TABLE IF EXISTS outbox CASCADE;
+CREATE TABLE outbox
+(
+    event_id       UUID PRIMARY KEY,
+    aggregate_type VARCHAR(32) NOT NULL CHECK ( aggregate_type <> '' ),
+    aggregate_id   UUID        NOT NULL,
+    event_type     VARCHAR(64) NOT NULL CHECK ( event_type <> '' ),
+    payload        JSONB       NOT NULL DEFAULT '{}',
+    attempts       INTEGER     NOT NULL DEFAULT 0,
+    locked_until   TIMESTAMP WITH TIME ZONE,
+    published_at   TIMESTAMP WITH TIME ZONE,
+
+    created_at     TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX idx_outbox__pending ON outbox(created_at) WHERE published_at IS NULL;
+CREATE INDEX idx_outbox__aggregate ON outbox(aggregate_type, aggregate_id);
@@ -0,0 +1,42 @@
+CREATE TYPE webhook_delivery_status AS ENUM ('pending', 'succeeded', 'failed');
+
+DROP TABLE IF EXISTS webhook_subscriptions CASCADE;
+CREATE TABLE webhook_subscriptions
+(
+    subscription_id UUID PRIMARY KEY      DEFAULT uuid_generate_v4(),
+    brand_id        UUID         NOT NULL REFERENCES brands (brand_id) ON DELETE CASCADE,
+    url             VARCHAR(512) NOT NULL CHECK ( url <> '' ),
+    event_types     TEXT[]       NOT NULL CHECK ( cardinality(event_types) > 0 ),
+    secret          VARCHAR(128) NOT NULL CHECK ( secret <> '' ),
+    active          BOOLEAN      NOT NULL DEFAULT TRUE,
+    version         INTEGER      NOT NULL DEFAULT 1,
+
+    created_at      TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX idx_webhook_subscriptions__brand_id ON webhook_subscriptions(brand_id);
+
+DROP TABLE IF EXISTS webhook_deliveries CASCADE;
+CREATE TABLE webhook_deliveries
+(
+    delivery_id     UUID PRIMARY KEY                 DEFAULT uuid_generate_v4(),
+    subscription_id UUID                    NOT NULL REFERENCES webhook_subscriptions (subscription_id) ON DELETE CASCADE,
+    event_id        UUID                    NOT NULL,
+    event_type      VARCHAR(64)             NOT NULL CHECK ( event_type <> '' ),
+    payload         JSONB                   NOT NULL DEFAULT '{}',
+    status          webhook_delivery_status NOT NULL DEFAULT 'pending',
+    attempts        INTEGER                 NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE         DEFAULT CURRENT_TIMESTAMP,
+    locked_until    TIMESTAMP WITH TIME ZONE,
+    response_status INTEGER                 NOT NULL DEFAULT 0,
+    response_body   TEXT                    NOT NULL DEFAULT '',
+    error           TEXT                    NOT NULL DEFAULT '',
+    delivered_at    TIMESTAMP WITH TIME ZONE,
+
+    created_at      TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    UNIQUE (subscription_id, event_id)
+);
+CREATE INDEX idx_webhook_deliveries__subscription_id ON webhook_deliveries(subscription_id, created_at DESC);
+CREATE INDEX idx_webhook_deliveries__due ON webhook_deliveries(next_attempt_at) WHERE status = 'pending';
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS job_schedules CASCADE;
+DROP TABLE IF EXISTS jobs CASCADE;
+DROP TYPE IF EXISTS job_status;
@@ -0,0 +1,31 @@
+CREATE TYPE job_status AS ENUM ('queued', 'running', 'succeeded', 'failed');
+
+DROP TABLE IF EXISTS jobs CASCADE;
+CREATE TABLE jobs
+(
+    job_id       UUID PRIMARY KEY      DEFAULT uuid_generate_v4(),
+    kind         VARCHAR(64)  NOT NULL CHECK ( kind <> '' ),
+    payload      JSONB        NOT NULL DEFAULT '{}',
+    unique_key   VARCHAR(255),
+    status       job_status   NOT NULL DEFAULT 'queued',
+    attempts     INTEGER      NOT NULL DEFAULT 0,
+    max_attempts INTEGER      NOT NULL DEFAULT 5 CHECK ( max_attempts > 0 ),
+    run_at       TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    locked_until TIMESTAMP WITH TIME ZONE,
+    last_error   TEXT         NOT NULL DEFAULT '',
+    finished_at  TIMESTAMP WITH TIME ZONE,
+
+    created_at   TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at   TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX idx_jobs__due ON jobs(run_at) WHERE status IN ('queued', 'running');
+CREATE INDEX idx_jobs__status ON jobs(status, created_at DESC);
+CREATE UNIQUE INDEX idx_jobs__unique_key ON jobs(unique_key) WHERE status IN ('queued', 'running');
+
+DROP TABLE IF EXISTS job_schedules CASCADE;
+CREATE TABLE job_schedules
+(
+    name        VARCHAR(64) PRIMARY KEY,
+    spec        VARCHAR(64) NOT NULL CHECK ( spec <> '' ),
+    next_run_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS notification_preferences CASCADE;
+CREATE TABLE notification_preferences
+(
+    user_id       UUID PRIMARY KEY     REFERENCES users (user_id) ON DELETE CASCADE,
+    locale        VARCHAR(10) NOT NULL DEFAULT '',
+    order_updates BOOLEAN     NOT NULL DEFAULT TRUE,
+    new_orders    BOOLEAN     NOT NULL DEFAULT TRUE,
+
+    created_at    TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at    TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_orders__brand_id__created_at;
+
+UPDATE orders SET status = 'paid' WHERE status = 'rejected';
+ALTER TYPE status RENAME TO status_old;
+CREATE TYPE status AS ENUM ('pending', 'paid', 'accepted', 'refunded', 'shipped', 'delivered');
+ALTER TABLE orders ALTER COLUMN status DROP DEFAULT;
+ALTER TABLE orders ALTER COLUMN status TYPE status USING status::text::status;
+ALTER TABLE orders ALTER COLUMN status SET DEFAULT 'pending';
+DROP TYPE status_old;
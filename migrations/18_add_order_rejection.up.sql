@@ -0,0 +1,3 @@
+ALTER TYPE status ADD VALUE IF NOT EXISTS 'rejected' AFTER 'paid';
+
+CREATE INDEX idx_orders__brand_id__created_at ON orders(brand_id, created_at DESC) WHERE deleted_at IS NULL;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_orders__brand_id__created_at;
+CREATE INDEX idx_orders__brand_id__created_at ON orders(brand_id, created_at DESC) WHERE deleted_at IS NULL;
+
+DROP INDEX IF EXISTS idx_orders__user_id__created_at;
+DROP INDEX IF EXISTS idx_orders__created_at__order_id;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_orders__updated_at;
+
+DROP TABLE IF EXISTS report_refreshes;
+DROP TABLE IF EXISTS sales_daily_buyers;
+DROP TABLE IF EXISTS sales_daily_products;
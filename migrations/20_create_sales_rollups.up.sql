@@ -0,0 +1,31 @@
+CREATE TABLE sales_daily_products
+(
+    brand_id     UUID         NOT NULL,
+    day          DATE         NOT NULL,
+    product_id   UUID         NOT NULL,
+    product_name VARCHAR(250) NOT NULL DEFAULT '',
+    orders       BIGINT       NOT NULL,
+    units        NUMERIC      NOT NULL,
+    revenue      NUMERIC      NOT NULL,
+    PRIMARY KEY (brand_id, day, product_id)
+);
+CREATE INDEX idx_sales_daily_products__day ON sales_daily_products(day);
+
+CREATE TABLE sales_daily_buyers
+(
+    brand_id UUID   NOT NULL,
+    day      DATE   NOT NULL,
+    user_id  UUID   NOT NULL,
+    orders   BIGINT NOT NULL,
+    PRIMARY KEY (brand_id, day, user_id)
+);
+CREATE INDEX idx_sales_daily_buyers__day ON sales_daily_buyers(day);
+
+CREATE TABLE report_refreshes
+(
+    name              VARCHAR(50) PRIMARY KEY,
+    refreshed_through TIMESTAMP WITH TIME ZONE NOT NULL
+);
+INSERT INTO report_refreshes (name, refreshed_through) VALUES ('sales', 'epoch');
+
+CREATE INDEX idx_orders__updated_at ON orders(updated_at);
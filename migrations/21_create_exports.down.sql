@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS exports CASCADE;
+DROP TYPE IF EXISTS export_status;
@@ -0,0 +1,20 @@
+CREATE TYPE export_status AS ENUM ('pending', 'running', 'done', 'failed');
+
+DROP TABLE IF EXISTS exports CASCADE;
+CREATE TABLE exports
+(
+    export_id   UUID PRIMARY KEY       DEFAULT uuid_generate_v4(),
+    user_id     UUID          NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+    resource    VARCHAR(32)   NOT NULL CHECK ( resource <> '' ),
+    format      VARCHAR(16)   NOT NULL CHECK ( format <> '' ),
+    columns     TEXT[]        NOT NULL CHECK ( cardinality(columns) > 0 ),
+    query       TEXT          NOT NULL DEFAULT '',
+    status      export_status NOT NULL DEFAULT 'pending',
+    file_name   VARCHAR(255),
+    row_count   BIGINT        NOT NULL DEFAULT 0,
+    error       TEXT,
+
+    created_at  TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    finished_at TIMESTAMP WITH TIME ZONE
+);
+CREATE INDEX idx_exports__user_id ON exports(user_id);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_refunds__pending;
+DELETE FROM refunds WHERE status <> 'succeeded';
+ALTER TABLE refunds DROP COLUMN IF EXISTS status;
+DROP TYPE IF EXISTS refund_status;
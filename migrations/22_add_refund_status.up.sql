@@ -0,0 +1,7 @@
+CREATE TYPE refund_status AS ENUM ('pending', 'succeeded', 'failed');
+
+-- refunds recorded so far were made with the provider before they were written
+ALTER TABLE refunds ADD COLUMN status refund_status NOT NULL DEFAULT 'succeeded';
+ALTER TABLE refunds ALTER COLUMN status SET DEFAULT 'pending';
+
+CREATE INDEX idx_refunds__pending ON refunds(order_id) WHERE status = 'pending';
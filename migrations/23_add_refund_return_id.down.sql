@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_refunds__return_id;
+ALTER TABLE refunds DROP COLUMN IF EXISTS return_id;
@@ -0,0 +1,6 @@
+ALTER TABLE refunds ADD COLUMN return_id UUID;
+
+UPDATE refunds rf SET return_id = r.return_id FROM returns r WHERE r.refund_id = rf.refund_id;
+
+-- a return is refunded at most once, a refund the provider turned down does not count
+CREATE UNIQUE INDEX idx_refunds__return_id ON refunds(return_id) WHERE status <> 'failed';
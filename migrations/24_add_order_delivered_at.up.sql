@@ -0,0 +1,4 @@
+ALTER TABLE orders ADD COLUMN delivered_at TIMESTAMP WITH TIME ZONE;
+
+-- orders delivered so far were last changed when they were delivered, unless refunded since
+UPDATE orders SET delivered_at = updated_at WHERE status = 'delivered';
@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS sent_notifications CASCADE;
+CREATE TABLE sent_notifications
+(
+    event_id UUID        NOT NULL,
+    user_id  UUID        NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+    template VARCHAR(64) NOT NULL,
+
+    sent_at  TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (event_id, user_id)
+);
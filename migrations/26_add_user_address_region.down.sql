@@ -0,0 +1 @@
+ALTER TABLE user_addresses DROP COLUMN IF EXISTS region;
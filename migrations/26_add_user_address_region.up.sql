@@ -0,0 +1 @@
+ALTER TABLE user_addresses ADD COLUMN region VARCHAR(3) NOT NULL DEFAULT '';
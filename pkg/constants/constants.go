@@ -20,8 +20,45 @@ const (
 	REPLY    = "REPLY"
 	TIME     = "TIME"
 
-	Page   = "page"
-	Size   = "size"
-	Search = "search"
-	ID     = "id"
+	Page           = "page"
+	Size           = "size"
+	Search         = "search"
+	ID             = "id"
+	ProductID      = "product_id"
+	BrandID        = "brand_id"
+	UserID         = "user_id"
+	CreatedFrom    = "created_from"
+	CreatedTo      = "created_to"
+	Sort           = "sort"
+	Cursor         = "cursor"
+	Count          = "count"
+	From           = "from"
+	To             = "to"
+	Interval       = "interval"
+	MinTotal       = "min_total"
+	MaxTotal       = "max_total"
+	MinPrice       = "min_price"
+	MaxPrice       = "max_price"
+	Category       = "category"
+	Role           = "role"
+	Name           = "name"
+	Email          = "email"
+	DeliveryID     = "delivery_id"
+	IncludeDeleted = "include_deleted"
+	Status         = "status"
+	Kind           = "kind"
+	Resource       = "resource"
+	Format         = "format"
+	Columns        = "columns"
+
+	ETag             = "ETag"
+	IfMatch          = "If-Match"
+	PaymentSignature = "Payment-Signature"
+	CourierSignature = "Courier-Signature"
+	WebhookSignature = "Webhook-Signature"
+	WebhookTimestamp = "Webhook-Timestamp"
+	WebhookEvent     = "Webhook-Event"
+	WebhookDelivery  = "Webhook-Delivery"
+	IdempotencyKey   = "Idempotency-Key"
+	IdempotentReplay = "Idempotent-Replayed"
 )
@@ -0,0 +1,50 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+// CSVWriter writes rows as CSV records. Text starting like a formula is prefixed with a quote, so spreadsheets
+// opening the file show it rather than evaluate it
+type CSVWriter struct {
+	w *csv.Writer
+}
+
+var _ Writer = (*CSVWriter)(nil)
+
+// NewCSVWriter writer of CSV rows to w
+func NewCSVWriter(w io.Writer) *CSVWriter {
+	return &CSVWriter{w: csv.NewWriter(w)}
+}
+
+// Write write a row
+func (c *CSVWriter) Write(values []interface{}) error {
+	record := make([]string, len(values))
+	for i, value := range values {
+		text, number := formatValue(value)
+		if !number && isFormula(text) {
+			text = "'" + text
+		}
+		record[i] = text
+	}
+	return c.w.Write(record)
+}
+
+// Close write the buffered rows
+func (c *CSVWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// isFormula whether spreadsheets take text for a formula
+func isFormula(text string) bool {
+	if text == "" {
+		return false
+	}
+	switch text[0] {
+	case '=', '+', '-', '@', '\t', '\r':
+		return true
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+// Package export writes rows of values as CSV or XLSX spreadsheets, streaming them to the output as they come, and
+// keeps the files of exports run in the background in a Store
+package export
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+// Formats formats rows can be exported in
+var Formats = []string{FormatCSV, FormatXLSX}
+
+// IsFormat whether format is one of Formats
+func IsFormat(format string) bool {
+	for _, f := range Formats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+// ContentType media type of the files of format
+func ContentType(format string) string {
+	switch format {
+	case FormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	default:
+		return "text/csv; charset=utf-8"
+	}
+}
+
+// Writer writes rows to an output, the values of a row being strings, numbers, booleans, times, fmt.Stringers or
+// nil for an empty cell
+type Writer interface {
+	Write(values []interface{}) error
+	// Close write what is buffered and the end of the file, the output itself is left open
+	Close() error
+}
+
+// NewWriter writer of rows in format to w
+func NewWriter(format string, w io.Writer) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return NewCSVWriter(w), nil
+	case FormatXLSX:
+		return NewXLSXWriter(w)
+	default:
+		return nil, fmt.Errorf("invalid format: %q", format)
+	}
+}
+
+// formatValue value as text, times in UTC RFC 3339. number tells whether it is a number
+func formatValue(value interface{}) (text string, number bool) {
+	switch v := value.(type) {
+	case nil:
+		return "", false
+	case string:
+		return v, false
+	case bool:
+		return strconv.FormatBool(v), false
+	case int:
+		return strconv.Itoa(v), true
+	case int64:
+		return strconv.FormatInt(v, 10), true
+	case uint64:
+		return strconv.FormatUint(v, 10), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case time.Time:
+		return v.UTC().Format(time.RFC3339), false
+	case fmt.Stringer:
+		return v.String(), false
+	default:
+		return fmt.Sprint(v), false
+	}
+}
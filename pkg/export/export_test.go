@@ -0,0 +1,160 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/xml"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCSVWriter(t *testing.T) {
+	t.Parallel()
+
+	id := uuid.New()
+	createdAt := time.Date(2026, 3, 1, 10, 30, 0, 0, time.FixedZone("WIB", 7*60*60))
+
+	var buf bytes.Buffer
+	w, err := NewWriter(FormatCSV, &buf)
+	require.NoError(t, err)
+	require.NoError(t, w.Write([]interface{}{"order_id", "total_price", "note", "created_at", "paid"}))
+	require.NoError(t, w.Write([]interface{}{id, 125000.5, "=HYPERLINK(\"x\")", createdAt, true}))
+	require.NoError(t, w.Write([]interface{}{nil, -3.0, "a, \"quoted\"\nline", nil, false}))
+	require.NoError(t, w.Close())
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Equal(t, [][]string{
+		{"order_id", "total_price", "note", "created_at", "paid"},
+		{id.String(), "125000.5", "'=HYPERLINK(\"x\")", "2026-03-01T03:30:00Z", "true"},
+		{"", "-3", "a, \"quoted\"\nline", "", "false"},
+	}, records)
+}
+
+func TestXLSXWriter(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	w, err := NewWriter(FormatXLSX, &buf)
+	require.NoError(t, err)
+	require.NoError(t, w.Write([]interface{}{"name", "price", "stock"}))
+	require.NoError(t, w.Write([]interface{}{"Kaos <polos> & co", 99000.0, uint64(12)}))
+	require.NoError(t, w.Write([]interface{}{"=1+1", nil, uint64(0)}))
+	require.NoError(t, w.Close())
+
+	z, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	parts := map[string][]byte{}
+	for _, f := range z.File {
+		r, err := f.Open()
+		require.NoError(t, err)
+		parts[f.Name], err = ioutil.ReadAll(r)
+		require.NoError(t, err)
+		r.Close()
+	}
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels"} {
+		require.Contains(t, parts, name)
+		require.NoError(t, xml.Unmarshal(parts[name], new(interface{})), name)
+	}
+
+	var sheet struct {
+		Rows []struct {
+			R     string `xml:"r,attr"`
+			Cells []struct {
+				R      string `xml:"r,attr"`
+				T      string `xml:"t,attr"`
+				V      string `xml:"v"`
+				Inline string `xml:"is>t"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+	require.NoError(t, xml.Unmarshal(parts["xl/worksheets/sheet1.xml"], &sheet))
+	require.Len(t, sheet.Rows, 3)
+
+	row := sheet.Rows[1]
+	require.Equal(t, "2", row.R)
+	require.Len(t, row.Cells, 3)
+	require.Equal(t, "A2", row.Cells[0].R)
+	require.Equal(t, "inlineStr", row.Cells[0].T)
+	require.Equal(t, "Kaos <polos> & co", row.Cells[0].Inline)
+	require.Equal(t, "B2", row.Cells[1].R)
+	require.Equal(t, "", row.Cells[1].T)
+	require.Equal(t, "99000", row.Cells[1].V)
+	require.Equal(t, "12", row.Cells[2].V)
+
+	// empty cells are left out and text is never a formula
+	row = sheet.Rows[2]
+	require.Len(t, row.Cells, 2)
+	require.Equal(t, "=1+1", row.Cells[0].Inline)
+	require.Equal(t, "C3", row.Cells[1].R)
+}
+
+func TestNewWriterInvalidFormat(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewWriter("pdf", &bytes.Buffer{})
+	require.Error(t, err)
+}
+
+func TestColumnName(t *testing.T) {
+	t.Parallel()
+
+	for i, name := range map[int]string{0: "A", 25: "Z", 26: "AA", 51: "AZ", 52: "BA", 701: "ZZ", 702: "AAA"} {
+		require.Equal(t, name, columnName(i), i)
+	}
+}
+
+func TestFileStore(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dir := t.TempDir()
+	store := NewFileStore(dir)
+
+	_, err := store.Open(ctx, "orders.csv")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	upload, err := store.Create(ctx, "orders.csv")
+	require.NoError(t, err)
+	_, err = upload.Write([]byte("order_id\n"))
+	require.NoError(t, err)
+
+	// the file appears once committed only
+	_, err = store.Open(ctx, "orders.csv")
+	require.ErrorIs(t, err, ErrNotFound)
+	require.NoError(t, upload.Commit())
+
+	r, err := store.Open(ctx, "orders.csv")
+	require.NoError(t, err)
+	data, err := ioutil.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	require.Equal(t, "order_id\n", string(data))
+
+	aborted, err := store.Create(ctx, "users.csv")
+	require.NoError(t, err)
+	require.NoError(t, aborted.Abort())
+	_, err = store.Open(ctx, "users.csv")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	files, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	require.NoError(t, store.Delete(ctx, "orders.csv"))
+	require.NoError(t, store.Delete(ctx, "orders.csv"))
+	_, err = store.Open(ctx, "orders.csv")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	for _, name := range []string{"", "..", "../orders.csv", "a/b.csv"} {
+		_, err := store.Create(ctx, name)
+		require.Error(t, err, name)
+	}
+}
@@ -0,0 +1,110 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// ErrNotFound no file is stored under the name
+var ErrNotFound = errors.New("export file not found")
+
+// Store keeps export files by name, on local disk with FileStore or in an object store
+type Store interface {
+	// Create upload of a new file, stored under name once committed
+	Create(ctx context.Context, name string) (Upload, error)
+	// Open file stored under name, ErrNotFound when there is none
+	Open(ctx context.Context, name string) (io.ReadCloser, error)
+	Delete(ctx context.Context, name string) error
+}
+
+// Upload file being written to a Store, it appears under its name on Commit and is dropped on Abort
+type Upload interface {
+	io.Writer
+	Commit() error
+	Abort() error
+}
+
+// FileStore stores the files in a local directory
+type FileStore struct {
+	dir string
+}
+
+var _ Store = (*FileStore)(nil)
+
+// NewFileStore store of files in dir, created on the first file
+func NewFileStore(dir string) *FileStore {
+	return &FileStore{dir: dir}
+}
+
+// Create upload written to a temporary file of the directory, renamed to name on Commit
+func (s *FileStore) Create(ctx context.Context, name string) (Upload, error) {
+	path, err := s.path(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	f, err := os.CreateTemp(s.dir, ".upload-*")
+	if err != nil {
+		return nil, err
+	}
+	return &fileUpload{File: f, path: path}, nil
+}
+
+// Open file stored under name
+func (s *FileStore) Open(ctx context.Context, name string) (io.ReadCloser, error) {
+	path, err := s.path(name)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return f, err
+}
+
+// Delete file stored under name, nothing when there is none
+func (s *FileStore) Delete(ctx context.Context, name string) error {
+	path, err := s.path(name)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// path file of name, which must name a file of the directory itself
+func (s *FileStore) path(name string) (string, error) {
+	if name == "" || name != filepath.Base(name) || name == "." || name == ".." {
+		return "", errors.New("invalid export file name: " + name)
+	}
+	return filepath.Join(s.dir, name), nil
+}
+
+type fileUpload struct {
+	*os.File
+	path string
+}
+
+func (u *fileUpload) Commit() error {
+	if err := u.File.Close(); err != nil {
+		os.Remove(u.File.Name())
+		return err
+	}
+	return os.Rename(u.File.Name(), u.path)
+}
+
+func (u *fileUpload) Abort() error {
+	u.File.Close()
+	return os.Remove(u.File.Name())
+}
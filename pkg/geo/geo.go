@@ -0,0 +1,119 @@
+// Package geo locates addresses and measures distances between coordinates
+package geo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+)
+
+// earthRadiusKm mean earth radius
+const earthRadiusKm = 6371.0088
+
+// ErrAddressNotFound address unknown to the geocoder
+var ErrAddressNotFound = errors.New("address could not be located")
+
+// ErrIncompleteCoordinates latitude given without longitude or the other way around
+var ErrIncompleteCoordinates = errors.New("latitude and longitude must be set together")
+
+// Point coordinates in decimal degrees
+type Point struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// NewPoint point of latitude and longitude, nil unless both are set
+func NewPoint(latitude, longitude *float64) *Point {
+	if latitude == nil || longitude == nil {
+		return nil
+	}
+	return &Point{Latitude: *latitude, Longitude: *longitude}
+}
+
+// CheckCoordinates ErrIncompleteCoordinates unless latitude and longitude are both set or both unset
+func CheckCoordinates(latitude, longitude *float64) error {
+	if (latitude == nil) != (longitude == nil) {
+		return ErrIncompleteCoordinates
+	}
+	return nil
+}
+
+// Haversine great circle distance between a and b in kilometers
+func Haversine(a, b Point) float64 {
+	lat1 := a.Latitude * math.Pi / 180
+	lat2 := b.Latitude * math.Pi / 180
+	dLat := lat2 - lat1
+	dLng := (b.Longitude - a.Longitude) * math.Pi / 180
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// Geocoder locates free text addresses
+type Geocoder interface {
+	Geocode(ctx context.Context, address string) (Point, error)
+}
+
+// Locate point when set, else the geocoded address
+func Locate(ctx context.Context, geocoder Geocoder, point *Point, address string) (Point, error) {
+	if point != nil {
+		return *point, nil
+	}
+	return geocoder.Geocode(ctx, address)
+}
+
+// StaticGeocoder geocoder over a fixed address book. An address is matched as a whole first, then without its
+// leading comma separated parts, so "Jl. Sudirman 1, Jakarta, ID" falls back to an entry for "Jakarta, ID"
+type StaticGeocoder struct {
+	points map[string]Point
+}
+
+var _ Geocoder = (*StaticGeocoder)(nil)
+
+// NewStaticGeocoder geocoder over points keyed by address
+func NewStaticGeocoder(points map[string]Point) *StaticGeocoder {
+	g := &StaticGeocoder{points: make(map[string]Point, len(points))}
+	for address, p := range points {
+		g.points[normalizeAddress(address)] = p
+	}
+	return g
+}
+
+// NewFileGeocoder geocoder over the JSON object at path mapping addresses to points
+func NewFileGeocoder(path string) (*StaticGeocoder, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var points map[string]Point
+	if err := json.Unmarshal(raw, &points); err != nil {
+		return nil, fmt.Errorf("geocoder file %s: %w", path, err)
+	}
+
+	return NewStaticGeocoder(points), nil
+}
+
+// Geocode point of address or its closest known parent area
+func (g *StaticGeocoder) Geocode(_ context.Context, address string) (Point, error) {
+	parts := strings.Split(normalizeAddress(address), ",")
+	for i := range parts {
+		if p, ok := g.points[strings.Join(parts[i:], ",")]; ok {
+			return p, nil
+		}
+	}
+	return Point{}, fmt.Errorf("%w: %s", ErrAddressNotFound, address)
+}
+
+// normalizeAddress lower case address with single spaced parts and no space around commas
+func normalizeAddress(address string) string {
+	parts := strings.Split(strings.ToLower(address), ",")
+	for i, part := range parts {
+		parts[i] = strings.Join(strings.Fields(part), " ")
+	}
+	return strings.Join(parts, ",")
+}
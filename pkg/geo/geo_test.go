@@ -0,0 +1,79 @@
+package geo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHaversine(t *testing.T) {
+	t.Parallel()
+
+	jakarta := Point{Latitude: -6.2088, Longitude: 106.8456}
+	bandung := Point{Latitude: -6.9175, Longitude: 107.6191}
+	london := Point{Latitude: 51.5074, Longitude: -0.1278}
+	paris := Point{Latitude: 48.8566, Longitude: 2.3522}
+
+	require.Zero(t, Haversine(jakarta, jakarta))
+	require.InDelta(t, 116.0, Haversine(jakarta, bandung), 1)
+	require.InDelta(t, 343.5, Haversine(london, paris), 1)
+	require.Equal(t, Haversine(london, paris), Haversine(paris, london))
+	require.InDelta(t, 20015.1, Haversine(Point{Latitude: 0, Longitude: 0}, Point{Latitude: 0, Longitude: 180}), 1)
+}
+
+func TestStaticGeocoder_Geocode(t *testing.T) {
+	t.Parallel()
+
+	g, err := NewFileGeocoder("testdata/geocoder.json")
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	p, err := g.Geocode(ctx, "jl.  Sudirman 1 ,Jakarta,  id")
+	require.NoError(t, err)
+	require.Equal(t, Point{Latitude: -6.2146, Longitude: 106.8223}, p)
+
+	t.Run("ParentArea", func(t *testing.T) {
+		p, err := g.Geocode(ctx, "Jl. Thamrin 10, Jakarta, ID")
+		require.NoError(t, err)
+		require.Equal(t, Point{Latitude: -6.2088, Longitude: 106.8456}, p)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := g.Geocode(ctx, "Shibuya, Tokyo, JP")
+		require.ErrorIs(t, err, ErrAddressNotFound)
+	})
+}
+
+func TestNewPoint(t *testing.T) {
+	t.Parallel()
+
+	lat, lng := -6.2, 106.8
+	require.Equal(t, &Point{Latitude: lat, Longitude: lng}, NewPoint(&lat, &lng))
+	require.Nil(t, NewPoint(&lat, nil))
+}
+
+func TestCheckCoordinates(t *testing.T) {
+	t.Parallel()
+
+	lat, lng := -6.2, 106.8
+	require.NoError(t, CheckCoordinates(&lat, &lng))
+	require.NoError(t, CheckCoordinates(nil, nil))
+	require.ErrorIs(t, CheckCoordinates(&lat, nil), ErrIncompleteCoordinates)
+	require.ErrorIs(t, CheckCoordinates(nil, &lng), ErrIncompleteCoordinates)
+}
+
+func TestLocate(t *testing.T) {
+	t.Parallel()
+
+	g := NewStaticGeocoder(map[string]Point{"Jakarta, ID": {Latitude: -6.2088, Longitude: 106.8456}})
+	ctx := context.Background()
+
+	p, err := Locate(ctx, g, &Point{Latitude: 1, Longitude: 2}, "Jakarta, ID")
+	require.NoError(t, err)
+	require.Equal(t, Point{Latitude: 1, Longitude: 2}, p)
+
+	p, err = Locate(ctx, g, nil, "Jakarta, ID")
+	require.NoError(t, err)
+	require.Equal(t, Point{Latitude: -6.2088, Longitude: 106.8456}, p)
+}
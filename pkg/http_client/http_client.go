@@ -1,8 +1,11 @@
 package http_client
 
 import (
+	"errors"
+	"fmt"
 	"net"
 	"net/http"
+	"syscall"
 	"time"
 
 	"github.com/go-resty/resty/v2"
@@ -16,9 +19,62 @@ const (
 	retryCount                = 3
 )
 
+// ErrNonPublicAddress dialed address is loopback, private, link-local or otherwise not publicly routable
+var ErrNonPublicAddress = errors.New("address is not public")
+
+// nonPublicNetworks ranges IsPublicIP rejects besides loopback, link-local, multicast and unspecified addresses
+var nonPublicNetworks = parseCIDRs(
+	"0.0.0.0/8",
+	"10.0.0.0/8",
+	"100.64.0.0/10",
+	"172.16.0.0/12",
+	"192.0.0.0/24",
+	"192.168.0.0/16",
+	"198.18.0.0/15",
+	"240.0.0.0/4",
+	"fc00::/7",
+	"fec0::/10",
+)
+
 func NewHttpClient(debugMode bool) *resty.Client {
+	return newHttpClient(debugMode, &net.Dialer{Timeout: dialContextTimeout})
+}
+
+// NewPublicHttpClient http client refusing to connect to non public addresses, for urls given by users. The check
+// runs on the resolved address of every connection, so redirects and DNS rebinding can't reach internal hosts
+func NewPublicHttpClient(debugMode bool) *resty.Client {
+	return newHttpClient(debugMode, &net.Dialer{
+		Timeout: dialContextTimeout,
+		Control: func(network string, address string, c syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if !IsPublicIP(net.ParseIP(host)) {
+				return fmt.Errorf("dial %s: %w", address, ErrNonPublicAddress)
+			}
+			return nil
+		},
+	})
+}
+
+// IsPublicIP reports whether ip is a publicly routable unicast address
+func IsPublicIP(ip net.IP) bool {
+	if ip == nil || ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return false
+	}
+	for _, network := range nonPublicNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+func newHttpClient(debugMode bool, dialer *net.Dialer) *resty.Client {
 	t := &http.Transport{
-		DialContext:         (&net.Dialer{Timeout: dialContextTimeout}).DialContext,
+		DialContext:         dialer.DialContext,
 		TLSHandshakeTimeout: clientTLSHandshakeTimeout,
 	}
 
@@ -31,3 +87,15 @@ func NewHttpClient(debugMode bool) *resty.Client {
 
 	return client
 }
+
+func parseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
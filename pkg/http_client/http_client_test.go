@@ -0,0 +1,40 @@
+package http_client
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsPublicIP(t *testing.T) {
+	t.Parallel()
+
+	for _, ip := range []string{"93.184.216.34", "8.8.8.8", "2606:4700:4700::1111"} {
+		require.True(t, IsPublicIP(net.ParseIP(ip)), ip)
+	}
+	for _, ip := range []string{"127.0.0.1", "10.0.0.1", "172.16.5.4", "192.168.1.1", "169.254.169.254", "100.64.0.1", "0.0.0.0", "::1", "::", "fe80::1", "fd00::1", "::ffff:10.0.0.1", "224.0.0.1"} {
+		require.False(t, IsPublicIP(net.ParseIP(ip)), ip)
+	}
+	require.False(t, IsPublicIP(nil))
+}
+
+func TestNewPublicHttpClient(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	res, err := NewHttpClient(false).SetRetryCount(0).R().Get(server.URL)
+	require.NoError(t, err)
+	require.Equal(t, "ok", res.String())
+
+	_, err = NewPublicHttpClient(false).SetRetryCount(0).R().Get(server.URL)
+	require.Error(t, err)
+	require.True(t, errors.Is(err, ErrNonPublicAddress))
+}
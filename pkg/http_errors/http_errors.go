@@ -19,6 +19,9 @@ const (
 	ErrNotFound            = "Not Found"
 	ErrUnauthorized        = "Unauthorized"
 	ErrRequestTimeout      = "Request Timeout"
+	ErrConflict            = "Conflict"
+	ErrPreconditionFailed  = "Precondition Failed"
+	ErrUnprocessableEntity = "Unprocessable Entity"
 	ErrInvalidEmail        = "Invalid email"
 	ErrInvalidPassword     = "Invalid password"
 	ErrInvalidField        = "Invalid field"
@@ -31,6 +34,7 @@ var (
 	NotFound            = errors.New("Not Found")
 	Unauthorized        = errors.New("Unauthorized")
 	Forbidden           = errors.New("Forbidden")
+	PreconditionFailed  = errors.New("Precondition Failed")
 	InternalServerError = errors.New("Internal Server Error")
 )
 
@@ -202,34 +206,66 @@ func NewForbiddenError(w http.ResponseWriter, causes interface{}, debug bool) er
 	return restError
 }
 
-// NewInternalServerError New Internal Server Error
-func NewInternalServerError(w http.ResponseWriter, causes interface{}, debug bool) error {
+// NewConflictError New Conflict Error
+func NewConflictError(w http.ResponseWriter, causes interface{}, debug bool) error {
 
 	restError := RestError{
-		ErrStatus: http.StatusInternalServerError,
-		ErrError:  InternalServerError.Error(),
+		ErrStatus: http.StatusConflict,
+		ErrError:  ErrConflict,
 		Timestamp: time.Now().UTC(),
 	}
 	if debug {
 		restError.ErrMessage = causes
 	}
-	if b, err := json.Marshal(restError.Causes()); err != nil {
+	if b, err := json.Marshal(restError); err != nil {
 		return err
 	} else {
-		w.WriteHeader(http.StatusInternalServerError)
+		w.WriteHeader(http.StatusConflict)
 		w.Write(b)
 	}
-	return nil
+	return restError
+}
 
+// NewUnprocessableEntityError New Unprocessable Entity Error
+func NewUnprocessableEntityError(w http.ResponseWriter, causes interface{}, debug bool) error {
+
+	restError := RestError{
+		ErrStatus: http.StatusUnprocessableEntity,
+		ErrError:  ErrUnprocessableEntity,
+		Timestamp: time.Now().UTC(),
+	}
+	if debug {
+		restError.ErrMessage = causes
+	}
 	if b, err := json.Marshal(restError); err != nil {
 		return err
 	} else {
-		w.WriteHeader(http.StatusInternalServerError)
+		w.WriteHeader(http.StatusUnprocessableEntity)
 		w.Write(b)
 	}
 	return restError
 }
 
+// NewInternalServerError New Internal Server Error
+func NewInternalServerError(w http.ResponseWriter, causes interface{}, debug bool) error {
+
+	restError := RestError{
+		ErrStatus: http.StatusInternalServerError,
+		ErrError:  InternalServerError.Error(),
+		Timestamp: time.Now().UTC(),
+	}
+	if debug {
+		restError.ErrMessage = causes
+	}
+	if b, err := json.Marshal(restError.Causes()); err != nil {
+		return err
+	} else {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write(b)
+	}
+	return nil
+}
+
 // ParseErrors Parser of error string messages returns RestError
 func ParseErrors(err error, debug bool) RestErr {
 	switch {
@@ -241,6 +277,14 @@ func ParseErrors(err error, debug bool) RestErr {
 		return NewRestError(http.StatusUnauthorized, ErrUnauthorized, err.Error(), debug)
 	case errors.Is(err, WrongCredentials):
 		return NewRestError(http.StatusUnauthorized, ErrUnauthorized, err.Error(), debug)
+	case errors.Is(err, PreconditionFailed):
+		return NewRestError(http.StatusPreconditionFailed, ErrPreconditionFailed, err.Error(), debug)
+	case strings.Contains(strings.ToLower(err.Error()), "precondition failed"):
+		return NewRestError(http.StatusPreconditionFailed, ErrPreconditionFailed, err.Error(), debug)
+	case strings.Contains(strings.ToLower(err.Error()), "version conflict"):
+		return NewRestError(http.StatusConflict, ErrConflict, err.Error(), debug)
+	case strings.Contains(strings.ToLower(err.Error()), "invalid status transition"):
+		return NewRestError(http.StatusConflict, ErrConflict, err.Error(), debug)
 	case strings.Contains(strings.ToLower(err.Error()), "sqlstate"):
 		return parseSqlErrors(err, debug)
 	case strings.Contains(strings.ToLower(err.Error()), "field validation"):
@@ -0,0 +1,62 @@
+package jobs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSchedule_Next(t *testing.T) {
+	t.Parallel()
+
+	// a Wednesday
+	from := time.Date(2026, time.January, 14, 10, 17, 30, 0, time.UTC)
+
+	cases := []struct {
+		name string
+		spec string
+		next time.Time
+	}{
+		{"EveryMinute", "* * * * *", time.Date(2026, time.January, 14, 10, 18, 0, 0, time.UTC)},
+		{"Step", "*/15 * * * *", time.Date(2026, time.January, 14, 10, 30, 0, 0, time.UTC)},
+		{"StepFrom", "5/20 * * * *", time.Date(2026, time.January, 14, 10, 25, 0, 0, time.UTC)},
+		{"List", "0 9,18 * * *", time.Date(2026, time.January, 14, 18, 0, 0, 0, time.UTC)},
+		{"Range", "30 1-3 * * *", time.Date(2026, time.January, 15, 1, 30, 0, 0, time.UTC)},
+		{"Daily", "@daily", time.Date(2026, time.January, 15, 0, 0, 0, 0, time.UTC)},
+		{"Hourly", "@hourly", time.Date(2026, time.January, 14, 11, 0, 0, 0, time.UTC)},
+		{"Weekly", "@weekly", time.Date(2026, time.January, 18, 0, 0, 0, 0, time.UTC)},
+		{"Monthly", "@monthly", time.Date(2026, time.February, 1, 0, 0, 0, 0, time.UTC)},
+		{"Yearly", "@yearly", time.Date(2027, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{"Weekdays", "0 8 * * 1-5", time.Date(2026, time.January, 15, 8, 0, 0, 0, time.UTC)},
+		{"DayOfMonthOrWeek", "0 0 20 * 5", time.Date(2026, time.January, 16, 0, 0, 0, 0, time.UTC)},
+		{"LeapDay", "0 0 29 2 *", time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{"Every", "@every 90s", time.Date(2026, time.January, 14, 10, 19, 0, 0, time.UTC)},
+	}
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			sched, err := ParseSchedule(c.spec)
+			require.NoError(t, err)
+			require.Equal(t, c.next, sched.Next(from))
+		})
+	}
+}
+
+func TestParseSchedule_Never(t *testing.T) {
+	t.Parallel()
+
+	sched, err := ParseSchedule("0 0 31 2 *")
+	require.NoError(t, err)
+	require.True(t, sched.Next(time.Now()).IsZero())
+}
+
+func TestParseSchedule_Invalid(t *testing.T) {
+	t.Parallel()
+
+	for _, spec := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "* * * * 7",
+		"*/0 * * * *", "5-1 * * * *", "a * * * *", "@every", "@every 10ms", "@fortnightly"} {
+		_, err := ParseSchedule(spec)
+		require.Error(t, err, spec)
+	}
+}
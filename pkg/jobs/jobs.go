@@ -0,0 +1,138 @@
+// Package jobs runs work outside of requests from a Postgres backed queue. Workers claim due jobs with
+// FOR UPDATE SKIP LOCKED, so any number of instances can share the queue, retry failed jobs with exponential
+// backoff and run cron style scheduled jobs once across instances
+package jobs
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	StatusQueued    = "queued"
+	StatusRunning   = "running"
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+)
+
+var (
+	// ErrDuplicate a queued or running job already holds the unique key
+	ErrDuplicate = errors.New("job with the same unique key already queued")
+	// ErrNotFailed only failed jobs can be retried
+	ErrNotFailed = errors.New("job has not failed")
+	// ErrUnknownKind no handler is registered for the job kind
+	ErrUnknownKind = errors.New("unknown job kind")
+	// ErrDrainTimeout running jobs did not finish within the drain timeout and were cancelled
+	ErrDrainTimeout = errors.New("jobs drain timed out")
+)
+
+// Job unit of work of a kind, run by the handler registered for its kind
+type Job struct {
+	JobID       uuid.UUID  `json:"job_id" db:"job_id"`
+	Kind        string     `json:"kind" db:"kind"`
+	Payload     Payload    `json:"payload" db:"payload"`
+	UniqueKey   *string    `json:"unique_key,omitempty" db:"unique_key"`
+	Status      string     `json:"status" db:"status"`
+	Attempts    int        `json:"attempts" db:"attempts"`
+	MaxAttempts int        `json:"max_attempts" db:"max_attempts"`
+	RunAt       time.Time  `json:"run_at" db:"run_at"`
+	LastError   string     `json:"last_error" db:"last_error"`
+	FinishedAt  *time.Time `json:"finished_at,omitempty" db:"finished_at"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// Decode the job payload into v
+func (j *Job) Decode(v interface{}) error {
+	return json.Unmarshal(j.Payload, v)
+}
+
+// Handler runs a job, returning an error has the job retried until it runs out of attempts. Jobs may run more than
+// once, when a worker dies mid job, so handlers should be idempotent
+type Handler func(ctx context.Context, job *Job) error
+
+var (
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// Typed handler calling fn with the job payload decoded into its argument, fn must be a
+// func(context.Context, *T) error. A payload that does not decode fails the job without retries
+func Typed(fn interface{}) Handler {
+	fnValue := reflect.ValueOf(fn)
+	fnType := fnValue.Type()
+	if fnType.Kind() != reflect.Func || fnType.NumIn() != 2 || fnType.NumOut() != 1 ||
+		fnType.In(0) != contextType || fnType.In(1).Kind() != reflect.Ptr || fnType.Out(0) != errorType {
+		panic(fmt.Sprintf("jobs.Typed: %s is not a func(context.Context, *T) error", fnType))
+	}
+	argType := fnType.In(1).Elem()
+
+	return func(ctx context.Context, job *Job) error {
+		arg := reflect.New(argType)
+		if err := job.Decode(arg.Interface()); err != nil {
+			return Permanent(fmt.Errorf("decode %s payload: %w", job.Kind, err))
+		}
+
+		out := fnValue.Call([]reflect.Value{reflect.ValueOf(ctx), arg})
+		if err, _ := out[0].Interface().(error); err != nil {
+			return err
+		}
+		return nil
+	}
+}
+
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent wrap err to fail the job straight away, for errors retrying can't fix
+func Permanent(err error) error {
+	return &permanentError{err: err}
+}
+
+// IsPermanent reports whether err fails the job without retries
+func IsPermanent(err error) bool {
+	var p *permanentError
+	return errors.As(err, &p)
+}
+
+// Payload JSON document of a job
+type Payload json.RawMessage
+
+func (p *Payload) Scan(value interface{}) error {
+	val, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("unable to scan")
+	}
+	*p = append(Payload(nil), val...)
+	return nil
+}
+
+func (p Payload) Value() (driver.Value, error) {
+	if len(p) == 0 {
+		return []byte("{}"), nil
+	}
+	return []byte(p), nil
+}
+
+func (p Payload) MarshalJSON() ([]byte, error) {
+	if len(p) == 0 {
+		return []byte("null"), nil
+	}
+	return p, nil
+}
+
+func (p *Payload) UnmarshalJSON(data []byte) error {
+	*p = append(Payload(nil), data...)
+	return nil
+}
@@ -0,0 +1,255 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/pkg/logger"
+	"github.com/dinorain/kalobranded/pkg/utils"
+)
+
+const (
+	defaultWorkers      = 4
+	defaultPollInterval = time.Second
+	defaultLease        = 5 * time.Minute
+	defaultBackoff      = 10 * time.Second
+	defaultMaxBackoff   = time.Hour
+	defaultMaxAttempts  = 5
+	defaultDrainTimeout = 25 * time.Second
+
+	uniqueViolation = "23505"
+)
+
+// Queue of jobs, handlers and schedules are registered before Run
+type Queue struct {
+	db     *sqlx.DB
+	cfg    config.Jobs
+	logger logger.Logger
+
+	mu        sync.RWMutex
+	handlers  map[string]Handler
+	schedules []*schedule
+}
+
+// Filter of the jobs listed by FindAll, empty fields match every job
+type Filter struct {
+	Status string
+	Kind   string
+}
+
+// NewQueue job queue of the jobs config
+func NewQueue(db *sqlx.DB, cfg config.Jobs, logger logger.Logger) *Queue {
+	return &Queue{db: db, cfg: cfg, logger: logger, handlers: make(map[string]Handler)}
+}
+
+// Handle register handler running the jobs of kind
+func (q *Queue) Handle(kind string, handler Handler) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.handlers[kind] = handler
+}
+
+// Schedule enqueue a job of kind with payload on the cron spec, in UTC. Specs have five fields, minute hour
+// day-of-month month day-of-week, or are one of @hourly, @daily, @weekly, @monthly, @yearly and @every <duration>.
+// Each run is enqueued once across instances sharing the queue, and skipped while the previous run is still queued
+// or running
+func (q *Queue) Schedule(name string, spec string, kind string, payload interface{}) error {
+	sched, err := ParseSchedule(spec)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrap(err, "json.Marshal")
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.schedules = append(q.schedules, &schedule{name: name, spec: spec, kind: kind, payload: data, schedule: sched})
+	return nil
+}
+
+// EnqueueOption optional setting of an enqueued job
+type EnqueueOption func(*enqueueOptions)
+
+type enqueueOptions struct {
+	runAt       time.Time
+	uniqueKey   *string
+	maxAttempts int
+}
+
+// RunAt run the job no earlier than t
+func RunAt(t time.Time) EnqueueOption {
+	return func(o *enqueueOptions) { o.runAt = t }
+}
+
+// Delay run the job no earlier than d from now
+func Delay(d time.Duration) EnqueueOption {
+	return func(o *enqueueOptions) { o.runAt = time.Now().Add(d) }
+}
+
+// UniqueKey enqueue the job only when no queued or running job holds key
+func UniqueKey(key string) EnqueueOption {
+	return func(o *enqueueOptions) { o.uniqueKey = &key }
+}
+
+// MaxAttempts give up on the job after n attempts instead of Jobs.MaxAttempts
+func MaxAttempts(n int) EnqueueOption {
+	return func(o *enqueueOptions) { o.maxAttempts = n }
+}
+
+// Enqueue add a job of kind with payload encoded as JSON, ErrDuplicate is returned when its unique key is taken
+func (q *Queue) Enqueue(ctx context.Context, kind string, payload interface{}, opts ...EnqueueOption) (*Job, error) {
+	return q.enqueue(ctx, q.db, kind, payload, opts...)
+}
+
+func (q *Queue) enqueue(ctx context.Context, db sqlx.QueryerContext, kind string, payload interface{}, opts ...EnqueueOption) (*Job, error) {
+	o := &enqueueOptions{runAt: time.Now(), maxAttempts: q.getMaxAttempts()}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, errors.Wrap(err, "json.Marshal")
+	}
+
+	job := &Job{}
+	if err := db.QueryRowxContext(ctx, enqueueQuery, kind, Payload(data), o.uniqueKey, o.maxAttempts, o.runAt).StructScan(job); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrDuplicate
+		}
+		return nil, errors.Wrap(err, "Queue.Enqueue.QueryRowxContext")
+	}
+
+	return job, nil
+}
+
+// FindAll find jobs matching filter, latest first
+func (q *Queue) FindAll(ctx context.Context, filter Filter, pagination *utils.Pagination) ([]Job, error) {
+	var jobs []Job
+	if err := q.db.SelectContext(ctx, &jobs, findAllQuery, filter.Status, filter.Kind, pagination.GetLimit(), pagination.GetOffset()); err != nil {
+		return nil, errors.Wrap(err, "Queue.FindAll.SelectContext")
+	}
+
+	return jobs, nil
+}
+
+// FindById find job by uuid
+func (q *Queue) FindById(ctx context.Context, jobID uuid.UUID) (*Job, error) {
+	job := &Job{}
+	if err := q.db.GetContext(ctx, job, findByIdQuery, jobID); err != nil {
+		return nil, errors.Wrap(err, "Queue.FindById.GetContext")
+	}
+
+	return job, nil
+}
+
+// Retry queue a failed job again with a fresh set of attempts
+func (q *Queue) Retry(ctx context.Context, jobID uuid.UUID) (*Job, error) {
+	job := &Job{}
+	if err := q.db.QueryRowxContext(ctx, retryQuery, jobID).StructScan(job); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFailed
+		}
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+			return nil, ErrDuplicate
+		}
+		return nil, errors.Wrap(err, "Queue.Retry.QueryRowxContext")
+	}
+
+	return job, nil
+}
+
+// PurgeFinished delete jobs that succeeded or failed before finishedBefore and report how many were deleted
+func (q *Queue) PurgeFinished(ctx context.Context, finishedBefore time.Time) (int64, error) {
+	res, err := q.db.ExecContext(ctx, purgeFinishedQuery, finishedBefore)
+	if err != nil {
+		return 0, errors.Wrap(err, "Queue.PurgeFinished.ExecContext")
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "Queue.PurgeFinished.RowsAffected")
+	}
+
+	return cnt, nil
+}
+
+func (q *Queue) handler(kind string) (Handler, bool) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	h, ok := q.handlers[kind]
+	return h, ok
+}
+
+func (q *Queue) kinds() []string {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	kinds := make([]string, 0, len(q.handlers))
+	for kind := range q.handlers {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+func (q *Queue) getWorkers() int {
+	if q.cfg.Workers > 0 {
+		return q.cfg.Workers
+	}
+	return defaultWorkers
+}
+
+func (q *Queue) getPollInterval() time.Duration {
+	if q.cfg.PollInterval > 0 {
+		return q.cfg.PollInterval
+	}
+	return defaultPollInterval
+}
+
+func (q *Queue) getLease() time.Duration {
+	if q.cfg.Lease > 0 {
+		return q.cfg.Lease
+	}
+	return defaultLease
+}
+
+func (q *Queue) getBackoff() time.Duration {
+	if q.cfg.Backoff > 0 {
+		return q.cfg.Backoff
+	}
+	return defaultBackoff
+}
+
+func (q *Queue) getMaxBackoff() time.Duration {
+	if q.cfg.MaxBackoff > 0 {
+		return q.cfg.MaxBackoff
+	}
+	return defaultMaxBackoff
+}
+
+func (q *Queue) getMaxAttempts() int {
+	if q.cfg.MaxAttempts > 0 {
+		return q.cfg.MaxAttempts
+	}
+	return defaultMaxAttempts
+}
+
+func (q *Queue) getDrainTimeout() time.Duration {
+	if q.cfg.DrainTimeout > 0 {
+		return q.cfg.DrainTimeout
+	}
+	return defaultDrainTimeout
+}
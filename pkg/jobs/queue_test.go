@@ -0,0 +1,251 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/kalobranded/config"
+	"github.com/dinorain/kalobranded/pkg/logger"
+)
+
+var jobColumns = []string{"job_id", "kind", "payload", "unique_key", "status", "attempts", "max_attempts", "run_at", "last_error", "finished_at", "created_at", "updated_at"}
+
+type purgePayload struct {
+	Before time.Time `json:"before"`
+}
+
+func newTestQueue(t *testing.T) (*Queue, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	cfg := &config.Config{Jobs: config.Jobs{Workers: 1, Lease: time.Minute, Backoff: 10 * time.Second, MaxBackoff: time.Minute, MaxAttempts: 3}}
+	appLogger := logger.NewAppLogger(cfg)
+	appLogger.InitLogger()
+
+	return NewQueue(sqlx.NewDb(db, "sqlmock"), cfg.Jobs, appLogger), mock
+}
+
+func TestQueue_Enqueue(t *testing.T) {
+	t.Parallel()
+
+	queue, mock := newTestQueue(t)
+
+	jobUUID := uuid.New()
+	runAt := time.Now().Add(time.Hour)
+	mock.ExpectQuery(enqueueQuery).
+		WithArgs("purge", Payload(`{"before":"2026-01-01T00:00:00Z"}`), "purge:daily", 3, runAt).
+		WillReturnRows(sqlmock.NewRows(jobColumns).
+			AddRow(jobUUID, "purge", []byte(`{"before": "2026-01-01T00:00:00Z"}`), "purge:daily", StatusQueued, 0, 3, runAt, "", nil, time.Now(), time.Now()))
+
+	job, err := queue.Enqueue(context.Background(), "purge", purgePayload{Before: time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		RunAt(runAt), UniqueKey("purge:daily"))
+	require.NoError(t, err)
+	require.Equal(t, jobUUID, job.JobID)
+	require.Equal(t, StatusQueued, job.Status)
+
+	var payload purgePayload
+	require.NoError(t, job.Decode(&payload))
+	require.Equal(t, 2026, payload.Before.Year())
+
+	t.Run("Duplicate", func(t *testing.T) {
+		mock.ExpectQuery(enqueueQuery).
+			WithArgs("purge", Payload(`{}`), "purge:daily", 5, sqlmock.AnyArg()).
+			WillReturnError(sql.ErrNoRows)
+
+		_, err := queue.Enqueue(context.Background(), "purge", struct{}{}, UniqueKey("purge:daily"), MaxAttempts(5))
+		require.True(t, errors.Is(err, ErrDuplicate))
+	})
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestQueue_Retry(t *testing.T) {
+	t.Parallel()
+
+	queue, mock := newTestQueue(t)
+
+	jobUUID := uuid.New()
+	mock.ExpectQuery(retryQuery).WithArgs(jobUUID).
+		WillReturnRows(sqlmock.NewRows(jobColumns).
+			AddRow(jobUUID, "purge", []byte(`{}`), nil, StatusQueued, 0, 3, time.Now(), "", nil, time.Now(), time.Now()))
+
+	job, err := queue.Retry(context.Background(), jobUUID)
+	require.NoError(t, err)
+	require.Equal(t, StatusQueued, job.Status)
+
+	t.Run("NotFailed", func(t *testing.T) {
+		mock.ExpectQuery(retryQuery).WithArgs(jobUUID).WillReturnError(sql.ErrNoRows)
+
+		_, err := queue.Retry(context.Background(), jobUUID)
+		require.True(t, errors.Is(err, ErrNotFailed))
+	})
+
+	t.Run("UniqueKeyTaken", func(t *testing.T) {
+		mock.ExpectQuery(retryQuery).WithArgs(jobUUID).WillReturnError(&pq.Error{Code: uniqueViolation})
+
+		_, err := queue.Retry(context.Background(), jobUUID)
+		require.True(t, errors.Is(err, ErrDuplicate))
+	})
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestQueue_Process(t *testing.T) {
+	t.Parallel()
+
+	queue, mock := newTestQueue(t)
+
+	handlerErr := errors.New("upstream unavailable")
+	queue.Handle("purge", Typed(func(ctx context.Context, p *purgePayload) error {
+		if p.Before.IsZero() {
+			return handlerErr
+		}
+		return nil
+	}))
+	queue.Handle("panics", func(ctx context.Context, job *Job) error {
+		panic("boom")
+	})
+
+	t.Run("Succeeded", func(t *testing.T) {
+		job := &Job{JobID: uuid.New(), Kind: "purge", Payload: Payload(`{"before":"2026-01-01T00:00:00Z"}`), Attempts: 1, MaxAttempts: 3}
+		mock.ExpectExec(recordQuery).WithArgs(job.JobID, StatusSucceeded, sqlmock.AnyArg(), "", sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		queue.process(context.Background(), job)
+		require.Equal(t, StatusSucceeded, job.Status)
+		require.NotNil(t, job.FinishedAt)
+	})
+
+	t.Run("RetriedWithBackoff", func(t *testing.T) {
+		job := &Job{JobID: uuid.New(), Kind: "purge", Payload: Payload(`{}`), Attempts: 2, MaxAttempts: 3}
+		mock.ExpectExec(recordQuery).WithArgs(job.JobID, StatusQueued, sqlmock.AnyArg(), handlerErr.Error(), nil).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		before := time.Now()
+		queue.process(context.Background(), job)
+		require.Equal(t, StatusQueued, job.Status)
+		require.Nil(t, job.FinishedAt)
+		require.WithinDuration(t, before.Add(20*time.Second), job.RunAt, time.Second)
+	})
+
+	t.Run("OutOfAttempts", func(t *testing.T) {
+		job := &Job{JobID: uuid.New(), Kind: "purge", Payload: Payload(`{}`), Attempts: 3, MaxAttempts: 3}
+		mock.ExpectExec(recordQuery).WithArgs(job.JobID, StatusFailed, sqlmock.AnyArg(), handlerErr.Error(), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		queue.process(context.Background(), job)
+		require.Equal(t, StatusFailed, job.Status)
+	})
+
+	t.Run("UndecodablePayload", func(t *testing.T) {
+		job := &Job{JobID: uuid.New(), Kind: "purge", Payload: Payload(`[]`), Attempts: 1, MaxAttempts: 3}
+		mock.ExpectExec(recordQuery).WithArgs(job.JobID, StatusFailed, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		queue.process(context.Background(), job)
+		require.Equal(t, StatusFailed, job.Status)
+		require.Contains(t, job.LastError, "decode purge payload")
+	})
+
+	t.Run("UnknownKind", func(t *testing.T) {
+		job := &Job{JobID: uuid.New(), Kind: "unknown", Payload: Payload(`{}`), Attempts: 1, MaxAttempts: 3}
+		mock.ExpectExec(recordQuery).WithArgs(job.JobID, StatusFailed, sqlmock.AnyArg(), "unknown job kind: unknown", sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		queue.process(context.Background(), job)
+		require.Equal(t, StatusFailed, job.Status)
+	})
+
+	t.Run("Panic", func(t *testing.T) {
+		job := &Job{JobID: uuid.New(), Kind: "panics", Payload: Payload(`{}`), Attempts: 1, MaxAttempts: 3}
+		mock.ExpectExec(recordQuery).WithArgs(job.JobID, StatusQueued, sqlmock.AnyArg(), "panic: boom", nil).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		queue.process(context.Background(), job)
+		require.Equal(t, StatusQueued, job.Status)
+	})
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestQueue_Backoff(t *testing.T) {
+	t.Parallel()
+
+	queue, _ := newTestQueue(t)
+
+	require.Equal(t, 10*time.Second, queue.backoff(1))
+	require.Equal(t, 20*time.Second, queue.backoff(2))
+	require.Equal(t, 40*time.Second, queue.backoff(3))
+	require.Equal(t, time.Minute, queue.backoff(4))
+	require.Equal(t, time.Minute, queue.backoff(10))
+}
+
+func TestQueue_Fire(t *testing.T) {
+	t.Parallel()
+
+	queue, mock := newTestQueue(t)
+	require.NoError(t, queue.Schedule("purge-daily", "@daily", "purge", purgePayload{}))
+	s := queue.schedules[0]
+
+	now := time.Date(2026, time.January, 14, 0, 0, 5, 0, time.UTC)
+
+	t.Run("Due", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockScheduleQuery).WithArgs("purge-daily").
+			WillReturnRows(sqlmock.NewRows([]string{"next_run_at"}).AddRow(now.Truncate(time.Hour)))
+		mock.ExpectQuery(enqueueQuery).WithArgs("purge", sqlmock.AnyArg(), "schedule:purge-daily", 3, sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows(jobColumns).
+				AddRow(uuid.New(), "purge", []byte(`{}`), "schedule:purge-daily", StatusQueued, 0, 3, now, "", nil, now, now))
+		mock.ExpectExec(advanceScheduleQuery).WithArgs("purge-daily", time.Date(2026, time.January, 15, 0, 0, 0, 0, time.UTC)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		require.NoError(t, queue.fire(context.Background(), s, now))
+	})
+
+	t.Run("PreviousRunPending", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockScheduleQuery).WithArgs("purge-daily").
+			WillReturnRows(sqlmock.NewRows([]string{"next_run_at"}).AddRow(now.Truncate(time.Hour)))
+		mock.ExpectQuery(enqueueQuery).WithArgs("purge", sqlmock.AnyArg(), "schedule:purge-daily", 3, sqlmock.AnyArg()).
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectExec(advanceScheduleQuery).WithArgs("purge-daily", sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		require.NoError(t, queue.fire(context.Background(), s, now))
+	})
+
+	t.Run("NotDue", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockScheduleQuery).WithArgs("purge-daily").
+			WillReturnRows(sqlmock.NewRows([]string{"next_run_at"}).AddRow(now.Add(time.Hour)))
+		mock.ExpectRollback()
+
+		require.NoError(t, queue.fire(context.Background(), s, now))
+	})
+
+	t.Run("LockedElsewhere", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockScheduleQuery).WithArgs("purge-daily").WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+
+		require.NoError(t, queue.fire(context.Background(), s, now))
+	})
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTyped_InvalidSignature(t *testing.T) {
+	t.Parallel()
+
+	require.Panics(t, func() { Typed(func(p *purgePayload) error { return nil }) })
+	require.Panics(t, func() { Typed(func(ctx context.Context, p purgePayload) error { return nil }) })
+	require.Panics(t, func() { Typed(func(ctx context.Context, p *purgePayload) {}) })
+}
@@ -0,0 +1,35 @@
+package jobs
+
+const (
+	enqueueQuery = `INSERT INTO jobs (kind, payload, unique_key, max_attempts, run_at) VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (unique_key) WHERE status IN ('queued', 'running') DO NOTHING
+		RETURNING job_id, kind, payload, unique_key, status, attempts, max_attempts, run_at, last_error, finished_at, created_at, updated_at`
+
+	claimQuery = `UPDATE jobs SET status = 'running', attempts = attempts + 1, locked_until = CURRENT_TIMESTAMP + $2 * INTERVAL '1 second', updated_at = CURRENT_TIMESTAMP
+		WHERE job_id = (
+			SELECT job_id FROM jobs WHERE kind = ANY($1) AND (
+				(status = 'queued' AND run_at <= CURRENT_TIMESTAMP) OR (status = 'running' AND locked_until < CURRENT_TIMESTAMP)
+			)
+			ORDER BY run_at LIMIT 1 FOR UPDATE SKIP LOCKED
+		)
+		RETURNING job_id, kind, payload, unique_key, status, attempts, max_attempts, run_at, last_error, finished_at, created_at, updated_at`
+
+	recordQuery = `UPDATE jobs SET status = $2, run_at = $3, last_error = $4, finished_at = $5, locked_until = NULL, updated_at = CURRENT_TIMESTAMP WHERE job_id = $1`
+
+	findAllQuery = `SELECT job_id, kind, payload, unique_key, status, attempts, max_attempts, run_at, last_error, finished_at, created_at, updated_at FROM jobs WHERE ($1 = '' OR status::text = $1) AND ($2 = '' OR kind = $2) ORDER BY created_at DESC LIMIT $3 OFFSET $4`
+
+	findByIdQuery = `SELECT job_id, kind, payload, unique_key, status, attempts, max_attempts, run_at, last_error, finished_at, created_at, updated_at FROM jobs WHERE job_id = $1`
+
+	retryQuery = `UPDATE jobs SET status = 'queued', attempts = 0, run_at = CURRENT_TIMESTAMP, last_error = '', finished_at = NULL, updated_at = CURRENT_TIMESTAMP
+		WHERE job_id = $1 AND status = 'failed'
+		RETURNING job_id, kind, payload, unique_key, status, attempts, max_attempts, run_at, last_error, finished_at, created_at, updated_at`
+
+	purgeFinishedQuery = `DELETE FROM jobs WHERE status IN ('succeeded', 'failed') AND finished_at < $1`
+
+	upsertScheduleQuery = `INSERT INTO job_schedules (name, spec, next_run_at) VALUES ($1, $2, $3)
+		ON CONFLICT (name) DO UPDATE SET spec = EXCLUDED.spec, next_run_at = EXCLUDED.next_run_at WHERE job_schedules.spec <> EXCLUDED.spec`
+
+	lockScheduleQuery = `SELECT next_run_at FROM job_schedules WHERE name = $1 FOR UPDATE SKIP LOCKED`
+
+	advanceScheduleQuery = `UPDATE job_schedules SET next_run_at = $2 WHERE name = $1`
+)
@@ -0,0 +1,241 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+const (
+	scheduleCheckInterval = 10 * time.Second
+	scheduleUniquePrefix  = "schedule:"
+)
+
+// Run claim and run due jobs of the registered kinds with Jobs.Workers workers, and enqueue scheduled jobs, until
+// ctx is done. Workers then stop claiming jobs and running ones get Jobs.DrainTimeout to finish before their context
+// is cancelled, Run returns once they all returned
+func (q *Queue) Run(ctx context.Context) error {
+	kinds := q.kinds()
+	if err := q.registerSchedules(ctx); err != nil {
+		return err
+	}
+
+	// running jobs outlive ctx so they can finish while draining
+	jobsCtx, cancelJobs := context.WithCancel(context.Background())
+	defer cancelJobs()
+
+	var wg sync.WaitGroup
+	if len(kinds) > 0 {
+		for i := 0; i < q.getWorkers(); i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				q.work(ctx, jobsCtx, kinds)
+			}()
+		}
+	}
+	if len(q.schedules) > 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			q.runSchedules(ctx)
+		}()
+	}
+
+	<-ctx.Done()
+
+	drained := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		return nil
+	case <-time.After(q.getDrainTimeout()):
+		cancelJobs()
+		<-drained
+		return ErrDrainTimeout
+	}
+}
+
+func (q *Queue) work(ctx context.Context, jobsCtx context.Context, kinds []string) {
+	for ctx.Err() == nil {
+		job, err := q.claim(ctx, kinds)
+		if err != nil && ctx.Err() == nil {
+			q.logger.Errorf("jobs claim: %v", err)
+		}
+		if job == nil {
+			select {
+			case <-ctx.Done():
+			case <-time.After(q.getPollInterval()):
+			}
+			continue
+		}
+
+		q.process(jobsCtx, job)
+	}
+}
+
+// claim lock the earliest due job of kinds for Jobs.Lease, nil when none is due. Running jobs whose lease is over,
+// their worker having died, are due again
+func (q *Queue) claim(ctx context.Context, kinds []string) (*Job, error) {
+	job := &Job{}
+	if err := q.db.QueryRowxContext(ctx, claimQuery, pq.Array(kinds), q.getLease().Seconds()).StructScan(job); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "Queue.claim.QueryRowxContext")
+	}
+
+	return job, nil
+}
+
+// process run a claimed job and record its outcome
+func (q *Queue) process(ctx context.Context, job *Job) {
+	now := time.Now()
+
+	var err error
+	if job.Attempts > job.MaxAttempts {
+		// claimed again after its worker died on the last attempt
+		err = Permanent(errors.New("lease expired on the last attempt"))
+	} else {
+		err = q.run(ctx, job)
+	}
+
+	switch {
+	case err == nil:
+		job.Status = StatusSucceeded
+		job.LastError = ""
+		job.FinishedAt = &now
+	case IsPermanent(err) || job.Attempts >= job.MaxAttempts:
+		job.Status = StatusFailed
+		job.LastError = err.Error()
+		job.FinishedAt = &now
+		q.logger.Warnf("job %s %s failed after %d attempts: %v", job.Kind, job.JobID, job.Attempts, err)
+	default:
+		job.Status = StatusQueued
+		job.LastError = err.Error()
+		job.RunAt = now.Add(q.backoff(job.Attempts))
+	}
+
+	if err := q.record(ctx, job); err != nil {
+		q.logger.Errorf("jobs record %s %s: %v", job.Kind, job.JobID, err)
+	}
+}
+
+// run the job handler within the lease, a panic fails the attempt
+func (q *Queue) run(ctx context.Context, job *Job) (err error) {
+	handler, ok := q.handler(job.Kind)
+	if !ok {
+		return Permanent(fmt.Errorf("%w: %s", ErrUnknownKind, job.Kind))
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, q.getLease())
+	defer cancel()
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	return handler(ctx, job)
+}
+
+func (q *Queue) record(ctx context.Context, job *Job) error {
+	if _, err := q.db.ExecContext(ctx, recordQuery, job.JobID, job.Status, job.RunAt, job.LastError, job.FinishedAt); err != nil {
+		return errors.Wrap(err, "Queue.record.ExecContext")
+	}
+	return nil
+}
+
+// backoff wait after the attempts-th failed attempt, Jobs.Backoff doubled on every attempt up to Jobs.MaxBackoff
+func (q *Queue) backoff(attempts int) time.Duration {
+	wait, maxWait := q.getBackoff(), q.getMaxBackoff()
+	for i := 1; i < attempts && wait < maxWait; i++ {
+		wait *= 2
+	}
+	if wait > maxWait {
+		return maxWait
+	}
+	return wait
+}
+
+type schedule struct {
+	name     string
+	spec     string
+	kind     string
+	payload  Payload
+	schedule Schedule
+}
+
+// registerSchedules store the schedules, the next run of a schedule is kept unless its spec changed
+func (q *Queue) registerSchedules(ctx context.Context) error {
+	now := time.Now().UTC()
+	for _, s := range q.schedules {
+		if _, err := q.db.ExecContext(ctx, upsertScheduleQuery, s.name, s.spec, s.schedule.Next(now)); err != nil {
+			return errors.Wrapf(err, "Queue.registerSchedules.ExecContext %s", s.name)
+		}
+	}
+	return nil
+}
+
+func (q *Queue) runSchedules(ctx context.Context) {
+	ticker := time.NewTicker(scheduleCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		for _, s := range q.schedules {
+			if err := q.fire(ctx, s, time.Now().UTC()); err != nil && ctx.Err() == nil {
+				q.logger.Errorf("jobs schedule %s: %v", s.name, err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// fire enqueue the job of schedule when its next run is due and move the next run on. The schedule row is locked
+// while doing so, instances finding it locked leave it to the one holding it
+func (q *Queue) fire(ctx context.Context, s *schedule, now time.Time) error {
+	tx, err := q.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "Queue.fire.BeginTxx")
+	}
+	defer tx.Rollback()
+
+	var nextRunAt time.Time
+	if err := tx.GetContext(ctx, &nextRunAt, lockScheduleQuery, s.name); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return errors.Wrap(err, "Queue.fire.GetContext")
+	}
+	if nextRunAt.After(now) {
+		return nil
+	}
+
+	if _, err := q.enqueue(ctx, tx, s.kind, s.payload, UniqueKey(scheduleUniquePrefix+s.name)); err != nil {
+		if !errors.Is(err, ErrDuplicate) {
+			return err
+		}
+		q.logger.Warnf("jobs schedule %s skipped, previous run still queued or running", s.name)
+	}
+
+	if _, err := tx.ExecContext(ctx, advanceScheduleQuery, s.name, s.schedule.Next(now)); err != nil {
+		return errors.Wrap(err, "Queue.fire.ExecContext")
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,163 @@
+// Package mailer sends email messages with an HTML body and a plain text fallback, over SMTP or by dropping them as
+// .eml files in a directory
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"github.com/dinorain/kalobranded/config"
+)
+
+const (
+	MailerFile = "file"
+	MailerSMTP = "smtp"
+
+	defaultFileDir = "mail"
+)
+
+// Message email with a plain text body and an optional HTML alternative
+type Message struct {
+	From    string
+	To      []string
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// Mailer sends messages
+type Mailer interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+// NewMailer Build the configured mailer, the file mailer when none is configured
+func NewMailer(cfg *config.Config) (Mailer, error) {
+	switch cfg.Notification.Mailer {
+	case "", MailerFile:
+		dir := cfg.Notification.FileDir
+		if dir == "" {
+			dir = defaultFileDir
+		}
+		return NewFileMailer(dir), nil
+	case MailerSMTP:
+		return NewSMTPMailer(cfg.Notification.SMTPHost, cfg.Notification.SMTPPort, cfg.Notification.SMTPUsername, cfg.Notification.SMTPPassword), nil
+	default:
+		return nil, fmt.Errorf("unknown mailer %q", cfg.Notification.Mailer)
+	}
+}
+
+// Bytes message in RFC 5322 format, a multipart/alternative one when it has an HTML body
+func (m *Message) Bytes() ([]byte, error) {
+	from, err := mail.ParseAddress(m.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid from address %q: %w", m.From, err)
+	}
+	if len(m.To) == 0 {
+		return nil, fmt.Errorf("message without recipients")
+	}
+	to := make([]string, 0, len(m.To))
+	for _, addr := range m.To {
+		parsed, err := mail.ParseAddress(addr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid to address %q: %w", addr, err)
+		}
+		to = append(to, parsed.String())
+	}
+
+	messageID, err := newMessageID(from.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	writeHeader := func(key, value string) {
+		fmt.Fprintf(&buf, "%s: %s\r\n", key, value)
+	}
+	writeHeader("From", from.String())
+	writeHeader("To", strings.Join(to, ", "))
+	writeHeader("Subject", mime.QEncoding.Encode("utf-8", m.Subject))
+	writeHeader("Date", time.Now().Format(time.RFC1123Z))
+	writeHeader("Message-ID", messageID)
+	writeHeader("MIME-Version", "1.0")
+
+	if m.HTML == "" {
+		writeHeader("Content-Type", "text/plain; charset=utf-8")
+		writeHeader("Content-Transfer-Encoding", "quoted-printable")
+		buf.WriteString("\r\n")
+		if err := writeQuotedPrintable(&buf, m.Text); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	parts := multipart.NewWriter(&buf)
+	writeHeader("Content-Type", mime.FormatMediaType("multipart/alternative", map[string]string{"boundary": parts.Boundary()}))
+	buf.WriteString("\r\n")
+
+	for _, part := range []struct {
+		contentType string
+		body        string
+	}{
+		{contentType: "text/plain; charset=utf-8", body: m.Text},
+		{contentType: "text/html; charset=utf-8", body: m.HTML},
+	} {
+		w, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := writeQuotedPrintable(w, part.body); err != nil {
+			return nil, err
+		}
+	}
+	if err := parts.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Recipients bare addresses of the message recipients, as given to the SMTP server
+func (m *Message) Recipients() ([]string, error) {
+	recipients := make([]string, 0, len(m.To))
+	for _, addr := range m.To {
+		parsed, err := mail.ParseAddress(addr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid to address %q: %w", addr, err)
+		}
+		recipients = append(recipients, parsed.Address)
+	}
+	return recipients, nil
+}
+
+func writeQuotedPrintable(w interface{ Write([]byte) (int, error) }, body string) error {
+	qp := quotedprintable.NewWriter(w)
+	if _, err := qp.Write([]byte(body)); err != nil {
+		return err
+	}
+	return qp.Close()
+}
+
+func newMessageID(from string) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	domain := "localhost"
+	if i := strings.LastIndex(from, "@"); i >= 0 {
+		domain = from[i+1:]
+	}
+	return fmt.Sprintf("<%s@%s>", hex.EncodeToString(b), domain), nil
+}
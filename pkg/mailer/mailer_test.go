@@ -0,0 +1,183 @@
+package mailer
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testMessage() *Message {
+	return &Message{
+		From:    "Kalobranded <no-reply@kalobranded.local>",
+		To:      []string{"Budi <budi@example.com>"},
+		Subject: "Pesanan Anda diterima ✓",
+		Text:    "Hi Budi, your order is accepted.",
+		HTML:    "<p>Hi Budi, your order is <b>accepted</b>.</p>",
+	}
+}
+
+// parseParts text and HTML bodies of a multipart/alternative message
+func parseParts(t *testing.T, msg *mail.Message) map[string]string {
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	require.Equal(t, "multipart/alternative", mediaType)
+
+	bodies := make(map[string]string)
+	reader := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		part, err := reader.NextRawPart()
+		if err != nil {
+			break
+		}
+		body, err := ioutil.ReadAll(quotedprintable.NewReader(part))
+		require.NoError(t, err)
+		contentType, _, err := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		require.NoError(t, err)
+		bodies[contentType] = string(body)
+	}
+	return bodies
+}
+
+func TestMessage_Bytes(t *testing.T) {
+	t.Parallel()
+
+	data, err := testMessage().Bytes()
+	require.NoError(t, err)
+
+	msg, err := mail.ReadMessage(bytes.NewReader(data))
+	require.NoError(t, err)
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	require.NoError(t, err)
+	require.Equal(t, "Pesanan Anda diterima ✓", subject)
+	require.Equal(t, `"Budi" <budi@example.com>`, msg.Header.Get("To"))
+	require.NotEmpty(t, msg.Header.Get("Message-ID"))
+
+	bodies := parseParts(t, msg)
+	require.Equal(t, "Hi Budi, your order is accepted.", bodies["text/plain"])
+	require.Equal(t, "<p>Hi Budi, your order is <b>accepted</b>.</p>", bodies["text/html"])
+
+	t.Run("TextOnly", func(t *testing.T) {
+		m := testMessage()
+		m.HTML = ""
+		data, err := m.Bytes()
+		require.NoError(t, err)
+
+		msg, err := mail.ReadMessage(bytes.NewReader(data))
+		require.NoError(t, err)
+		require.Equal(t, "text/plain; charset=utf-8", msg.Header.Get("Content-Type"))
+	})
+
+	t.Run("InvalidAddress", func(t *testing.T) {
+		m := testMessage()
+		m.To = []string{"not an address"}
+		_, err := m.Bytes()
+		require.Error(t, err)
+	})
+}
+
+func TestFileMailer_Send(t *testing.T) {
+	t.Parallel()
+
+	dir := filepath.Join(t.TempDir(), "mail")
+	m := NewFileMailer(dir)
+
+	require.NoError(t, m.Send(context.Background(), testMessage()))
+	require.NoError(t, m.Send(context.Background(), testMessage()))
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+
+	f, err := os.Open(files[0])
+	require.NoError(t, err)
+	defer f.Close()
+	msg, err := mail.ReadMessage(f)
+	require.NoError(t, err)
+	require.Equal(t, "Hi Budi, your order is accepted.", parseParts(t, msg)["text/plain"])
+}
+
+// smtpServer minimal SMTP server accepting a single message without extensions
+func smtpServer(t *testing.T) (addr string, received <-chan []string) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+
+	ch := make(chan []string, 1)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		tp := textproto.NewConn(conn)
+		var envelope []string
+		tp.PrintfLine("220 localhost ESMTP")
+		for {
+			line, err := tp.ReadLine()
+			if err != nil {
+				return
+			}
+			switch verb := strings.ToUpper(strings.SplitN(line, " ", 2)[0]); verb {
+			case "EHLO", "HELO":
+				tp.PrintfLine("250 localhost")
+			case "MAIL", "RCPT":
+				envelope = append(envelope, line)
+				tp.PrintfLine("250 OK")
+			case "DATA":
+				tp.PrintfLine("354 go ahead")
+				data, err := tp.ReadDotBytes()
+				if err != nil {
+					return
+				}
+				envelope = append(envelope, string(data))
+				tp.PrintfLine("250 queued")
+			case "QUIT":
+				tp.PrintfLine("221 bye")
+				ch <- envelope
+				return
+			default:
+				tp.PrintfLine("502 %s not implemented", verb)
+			}
+		}
+	}()
+
+	return l.Addr().String(), ch
+}
+
+func TestSMTPMailer_Send(t *testing.T) {
+	t.Parallel()
+
+	addr, received := smtpServer(t)
+	host, port, err := net.SplitHostPort(addr)
+	require.NoError(t, err)
+	portNum, err := strconv.Atoi(port)
+	require.NoError(t, err)
+
+	m := NewSMTPMailer(host, portNum, "", "")
+	require.NoError(t, m.Send(context.Background(), testMessage()))
+
+	envelope := <-received
+	require.Len(t, envelope, 3)
+	require.Equal(t, "MAIL FROM:<no-reply@kalobranded.local>", envelope[0])
+	require.Equal(t, "RCPT TO:<budi@example.com>", envelope[1])
+
+	msg, err := mail.ReadMessage(bufio.NewReader(strings.NewReader(envelope[2])))
+	require.NoError(t, err)
+	require.Equal(t, "<p>Hi Budi, your order is <b>accepted</b>.</p>", parseParts(t, msg)["text/html"])
+}
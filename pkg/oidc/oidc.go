@@ -0,0 +1,326 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/golang-jwt/jwt"
+	"github.com/pkg/errors"
+
+	"github.com/dinorain/kalobranded/config"
+)
+
+const (
+	discoveryPath = "/.well-known/openid-configuration"
+	randomBytes   = 32
+)
+
+var (
+	ErrInvalidIDToken = errors.New("invalid id token")
+	ErrUnknownKey     = errors.New("unknown id token signing key")
+)
+
+// Provider OpenID Connect relying party of a single identity provider
+type Provider interface {
+	Name() string
+	AuthCodeURL(ctx context.Context, state string, nonce string, codeChallenge string) (string, error)
+	Exchange(ctx context.Context, code string, codeVerifier string) (*Token, error)
+	VerifyIDToken(ctx context.Context, rawIDToken string, nonce string) (*Claims, error)
+}
+
+// Token endpoint response
+type Token struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
+	IDToken      string `json:"id_token"`
+}
+
+// Claims verified ID token claims
+type Claims struct {
+	Issuer        string `json:"iss"`
+	Subject       string `json:"sub"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	GivenName     string `json:"given_name"`
+	FamilyName    string `json:"family_name"`
+	Picture       string `json:"picture"`
+	Nonce         string `json:"nonce"`
+}
+
+type discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JwksURI               string `json:"jwks_uri"`
+}
+
+type jwks struct {
+	Keys []jwk `json:"keys"`
+}
+
+type jwk struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+type provider struct {
+	name   string
+	cfg    config.OidcProvider
+	client *resty.Client
+
+	mu        sync.RWMutex
+	discovery *discovery
+	keys      map[string]*rsa.PublicKey
+}
+
+var _ Provider = (*provider)(nil)
+
+// NewProvider OpenID Connect provider constructor, discovery is done lazily on first use
+func NewProvider(name string, cfg config.OidcProvider, client *resty.Client) *provider {
+	return &provider{name: name, cfg: cfg, client: client, keys: map[string]*rsa.PublicKey{}}
+}
+
+// NewProviders Build providers for every configured identity provider
+func NewProviders(cfg *config.Config, client *resty.Client) map[string]Provider {
+	providers := make(map[string]Provider, len(cfg.Oidc.Providers))
+	for name, providerCfg := range cfg.Oidc.Providers {
+		providers[name] = NewProvider(name, providerCfg, client)
+	}
+	return providers
+}
+
+// Name provider name as configured
+func (p *provider) Name() string {
+	return p.name
+}
+
+// AuthCodeURL Authorization code flow URL with PKCE S256 challenge
+func (p *provider) AuthCodeURL(ctx context.Context, state string, nonce string, codeChallenge string) (string, error) {
+	d, err := p.getDiscovery(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	scopes := p.cfg.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{"openid", "email", "profile"}
+	}
+
+	v := url.Values{}
+	v.Set("response_type", "code")
+	v.Set("client_id", p.cfg.ClientID)
+	v.Set("redirect_uri", p.cfg.RedirectURL)
+	v.Set("scope", strings.Join(scopes, " "))
+	v.Set("state", state)
+	v.Set("nonce", nonce)
+	v.Set("code_challenge", codeChallenge)
+	v.Set("code_challenge_method", "S256")
+
+	sep := "?"
+	if strings.Contains(d.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+	return d.AuthorizationEndpoint + sep + v.Encode(), nil
+}
+
+// Exchange authorization code for tokens
+func (p *provider) Exchange(ctx context.Context, code string, codeVerifier string) (*Token, error) {
+	d, err := p.getDiscovery(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	token := &Token{}
+	res, err := p.client.R().
+		SetContext(ctx).
+		SetHeader("Accept", "application/json").
+		SetFormData(map[string]string{
+			"grant_type":    "authorization_code",
+			"code":          code,
+			"redirect_uri":  p.cfg.RedirectURL,
+			"client_id":     p.cfg.ClientID,
+			"client_secret": p.cfg.ClientSecret,
+			"code_verifier": codeVerifier,
+		}).
+		SetResult(token).
+		Post(d.TokenEndpoint)
+	if err != nil {
+		return nil, errors.Wrap(err, "oidc.Exchange.Post")
+	}
+	if res.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("oidc.Exchange: token endpoint status %d", res.StatusCode())
+	}
+	if token.IDToken == "" {
+		return nil, errors.Wrap(ErrInvalidIDToken, "oidc.Exchange: missing id_token")
+	}
+
+	return token, nil
+}
+
+// VerifyIDToken Validate ID token signature against provider JWKS, issuer, audience, expiry and nonce
+func (p *provider) VerifyIDToken(ctx context.Context, rawIDToken string, nonce string) (*Claims, error) {
+	d, err := p.getDiscovery(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := jwt.Parse(rawIDToken, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		kid, _ := token.Header["kid"].(string)
+		return p.getKey(ctx, d, kid)
+	})
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidIDToken, err.Error())
+	}
+
+	mapClaims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return nil, ErrInvalidIDToken
+	}
+	if !mapClaims.VerifyIssuer(d.Issuer, true) {
+		return nil, errors.Wrap(ErrInvalidIDToken, "issuer mismatch")
+	}
+	if !mapClaims.VerifyAudience(p.cfg.ClientID, true) {
+		return nil, errors.Wrap(ErrInvalidIDToken, "audience mismatch")
+	}
+	if _, ok := mapClaims["exp"]; !ok {
+		return nil, errors.Wrap(ErrInvalidIDToken, "missing exp")
+	}
+
+	claimsBytes, err := json.Marshal(mapClaims)
+	if err != nil {
+		return nil, errors.Wrap(err, "oidc.VerifyIDToken.json.Marshal")
+	}
+	claims := &Claims{}
+	if err := json.Unmarshal(claimsBytes, claims); err != nil {
+		return nil, errors.Wrap(err, "oidc.VerifyIDToken.json.Unmarshal")
+	}
+	if claims.Subject == "" {
+		return nil, errors.Wrap(ErrInvalidIDToken, "missing sub")
+	}
+	if claims.Nonce != nonce {
+		return nil, errors.Wrap(ErrInvalidIDToken, "nonce mismatch")
+	}
+
+	return claims, nil
+}
+
+func (p *provider) getDiscovery(ctx context.Context) (*discovery, error) {
+	p.mu.RLock()
+	d := p.discovery
+	p.mu.RUnlock()
+	if d != nil {
+		return d, nil
+	}
+
+	d = &discovery{}
+	res, err := p.client.R().
+		SetContext(ctx).
+		SetResult(d).
+		Get(strings.TrimSuffix(p.cfg.Issuer, "/") + discoveryPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "oidc.getDiscovery.Get")
+	}
+	if res.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("oidc.getDiscovery: status %d", res.StatusCode())
+	}
+	if d.Issuer != p.cfg.Issuer {
+		return nil, fmt.Errorf("oidc.getDiscovery: issuer mismatch %q", d.Issuer)
+	}
+
+	p.mu.Lock()
+	p.discovery = d
+	p.mu.Unlock()
+
+	return d, nil
+}
+
+func (p *provider) getKey(ctx context.Context, d *discovery, kid string) (*rsa.PublicKey, error) {
+	p.mu.RLock()
+	key, ok := p.keys[kid]
+	p.mu.RUnlock()
+	if ok {
+		return key, nil
+	}
+
+	// Unknown kid, the provider may have rotated its keys
+	set := &jwks{}
+	res, err := p.client.R().SetContext(ctx).SetResult(set).Get(d.JwksURI)
+	if err != nil {
+		return nil, errors.Wrap(err, "oidc.getKey.Get")
+	}
+	if res.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("oidc.getKey: status %d", res.StatusCode())
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Kty != "RSA" || (k.Use != "" && k.Use != "sig") {
+			continue
+		}
+		pub, err := parseRSAKey(k)
+		if err != nil {
+			return nil, err
+		}
+		keys[k.Kid] = pub
+	}
+
+	p.mu.Lock()
+	p.keys = keys
+	p.mu.Unlock()
+
+	if key, ok := keys[kid]; ok {
+		return key, nil
+	}
+	return nil, ErrUnknownKey
+}
+
+func parseRSAKey(k jwk) (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil {
+		return nil, errors.Wrap(err, "oidc.parseRSAKey.n")
+	}
+	e, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil {
+		return nil, errors.Wrap(err, "oidc.parseRSAKey.e")
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(new(big.Int).SetBytes(e).Int64()),
+	}, nil
+}
+
+// RandomString URL safe random string for state, nonce and PKCE verifier
+func RandomString() (string, error) {
+	b := make([]byte, randomBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// CodeChallengeS256 PKCE S256 challenge of given verifier
+func CodeChallengeS256(codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,118 @@
+package oidc
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/kalobranded/pkg/http_client"
+	"github.com/dinorain/kalobranded/pkg/oidc/oidctest"
+)
+
+func TestProvider_AuthorizationCodeFlow(t *testing.T) {
+	t.Parallel()
+
+	stub := oidctest.NewServer("client-id", "client-secret")
+	defer stub.Close()
+
+	p := NewProvider("stub", stub.ProviderConfig("http://localhost/callback"), http_client.NewHttpClient(false))
+	ctx := context.Background()
+
+	verifier, err := RandomString()
+	require.NoError(t, err)
+
+	authURL, err := p.AuthCodeURL(ctx, "state", "nonce", CodeChallengeS256(verifier))
+	require.NoError(t, err)
+
+	u, err := url.Parse(authURL)
+	require.NoError(t, err)
+	require.Equal(t, "S256", u.Query().Get("code_challenge_method"))
+	require.Equal(t, "client-id", u.Query().Get("client_id"))
+
+	code, state, err := stub.Authorize(authURL)
+	require.NoError(t, err)
+	require.Equal(t, "state", state)
+
+	t.Run("WrongVerifier", func(t *testing.T) {
+		code, _, err := stub.Authorize(authURL)
+		require.NoError(t, err)
+
+		_, err = p.Exchange(ctx, code, "wrong")
+		require.Error(t, err)
+	})
+
+	token, err := p.Exchange(ctx, code, verifier)
+	require.NoError(t, err)
+	require.NotEmpty(t, token.IDToken)
+
+	t.Run("VerifyIDToken", func(t *testing.T) {
+		claims, err := p.VerifyIDToken(ctx, token.IDToken, "nonce")
+		require.NoError(t, err)
+		require.Equal(t, stub.Identity.Subject, claims.Subject)
+		require.Equal(t, stub.Identity.Email, claims.Email)
+		require.True(t, claims.EmailVerified)
+	})
+
+	t.Run("NonceMismatch", func(t *testing.T) {
+		_, err := p.VerifyIDToken(ctx, token.IDToken, "other")
+		require.ErrorIs(t, err, ErrInvalidIDToken)
+	})
+}
+
+func TestProvider_VerifyIDToken(t *testing.T) {
+	t.Parallel()
+
+	stub := oidctest.NewServer("client-id", "client-secret")
+	defer stub.Close()
+
+	p := NewProvider("stub", stub.ProviderConfig("http://localhost/callback"), http_client.NewHttpClient(false))
+	ctx := context.Background()
+
+	validClaims := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"iss":   stub.Issuer(),
+			"aud":   "client-id",
+			"sub":   "subject",
+			"nonce": "nonce",
+			"exp":   time.Now().Add(time.Minute).Unix(),
+		}
+	}
+
+	claims, err := p.VerifyIDToken(ctx, stub.SignIDToken(validClaims()), "nonce")
+	require.NoError(t, err)
+	require.Equal(t, "subject", claims.Subject)
+
+	for name, mutate := range map[string]func(jwt.MapClaims){
+		"Expired":       func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Minute).Unix() },
+		"MissingExp":    func(c jwt.MapClaims) { delete(c, "exp") },
+		"WrongIssuer":   func(c jwt.MapClaims) { c["iss"] = "https://evil.example.com" },
+		"WrongAudience": func(c jwt.MapClaims) { c["aud"] = "other-client" },
+		"MissingSub":    func(c jwt.MapClaims) { delete(c, "sub") },
+	} {
+		mutate := mutate
+		t.Run(name, func(t *testing.T) {
+			c := validClaims()
+			mutate(c)
+			_, err := p.VerifyIDToken(ctx, stub.SignIDToken(c), "nonce")
+			require.ErrorIs(t, err, ErrInvalidIDToken)
+		})
+	}
+
+	t.Run("HMACSigned", func(t *testing.T) {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, validClaims()).SignedString([]byte("client-secret"))
+		require.NoError(t, err)
+
+		_, err = p.VerifyIDToken(ctx, token, "nonce")
+		require.ErrorIs(t, err, ErrInvalidIDToken)
+	})
+}
+
+func TestCodeChallengeS256(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "1ZF7vmhCGEDY6-C7zxNtlrWf6qfvzjQ_gbFl0o4-Ngo", CodeChallengeS256("dBjftJeZ4CVhp-jEj8DBo8NORKB8TAhx4mkMlPd8Ubg"))
+}
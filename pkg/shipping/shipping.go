@@ -0,0 +1,60 @@
+// Package shipping prices deliveries from their distance and weight
+package shipping
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/dinorain/kalobranded/config"
+)
+
+// ErrTooHeavy shipment heavier than the heaviest weight tier
+var ErrTooHeavy = errors.New("shipment too heavy")
+
+// FeeTable base fee plus a fee per started kilometer, plus the fee of the lightest weight tier holding the shipment
+type FeeTable struct {
+	baseFee     float64
+	perKm       float64
+	weightTiers []config.DeliveryWeightTier
+}
+
+// NewFeeTable fee table of the delivery config
+func NewFeeTable(cfg config.Delivery) *FeeTable {
+	tiers := make([]config.DeliveryWeightTier, len(cfg.WeightTiers))
+	copy(tiers, cfg.WeightTiers)
+	sort.SliceStable(tiers, func(i, j int) bool {
+		if tiers[i].MaxWeight == 0 || tiers[j].MaxWeight == 0 {
+			return tiers[j].MaxWeight == 0 && tiers[i].MaxWeight != 0
+		}
+		return tiers[i].MaxWeight < tiers[j].MaxWeight
+	})
+
+	return &FeeTable{baseFee: cfg.BaseFee, perKm: cfg.PerKm, weightTiers: tiers}
+}
+
+// Fee of shipping weight grams over distanceKm, rounded to cents. Shipments are not charged for weight when the
+// table has no tiers
+func (t *FeeTable) Fee(distanceKm float64, weight uint64) (float64, error) {
+	fee := t.baseFee + t.perKm*math.Ceil(distanceKm)
+
+	if len(t.weightTiers) > 0 {
+		tier, ok := t.weightTier(weight)
+		if !ok {
+			return 0, fmt.Errorf("%w: %d grams", ErrTooHeavy, weight)
+		}
+		fee += tier.Fee
+	}
+
+	return math.Round(fee*100) / 100, nil
+}
+
+func (t *FeeTable) weightTier(weight uint64) (config.DeliveryWeightTier, bool) {
+	for _, tier := range t.weightTiers {
+		if tier.MaxWeight == 0 || weight <= tier.MaxWeight {
+			return tier, true
+		}
+	}
+	return config.DeliveryWeightTier{}, false
+}
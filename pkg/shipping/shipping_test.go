@@ -0,0 +1,67 @@
+package shipping
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dinorain/kalobranded/config"
+)
+
+func TestFeeTable_Fee(t *testing.T) {
+	t.Parallel()
+
+	table := NewFeeTable(config.Delivery{
+		BaseFee: 8000,
+		PerKm:   1500,
+		WeightTiers: []config.DeliveryWeightTier{
+			{MaxWeight: 5000, Fee: 5000},
+			{MaxWeight: 1000, Fee: 0},
+			{MaxWeight: 30000, Fee: 15000},
+		},
+	})
+
+	cases := []struct {
+		name       string
+		distanceKm float64
+		weight     uint64
+		fee        float64
+	}{
+		{"SamePlace", 0, 500, 8000},
+		{"StartedKilometer", 0.2, 500, 9500},
+		{"WholeKilometers", 12, 1000, 26000},
+		{"SecondTier", 12.4, 1001, 32500},
+		{"HeaviestTier", 116.03, 30000, 198500},
+	}
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			fee, err := table.Fee(c.distanceKm, c.weight)
+			require.NoError(t, err)
+			require.Equal(t, c.fee, fee)
+		})
+	}
+
+	t.Run("TooHeavy", func(t *testing.T) {
+		_, err := table.Fee(1, 30001)
+		require.ErrorIs(t, err, ErrTooHeavy)
+	})
+
+	t.Run("UnboundedTier", func(t *testing.T) {
+		table := NewFeeTable(config.Delivery{BaseFee: 1000, WeightTiers: []config.DeliveryWeightTier{{MaxWeight: 0, Fee: 9000}, {MaxWeight: 1000, Fee: 0}}})
+
+		fee, err := table.Fee(0, 1000)
+		require.NoError(t, err)
+		require.Equal(t, 1000.0, fee)
+
+		fee, err = table.Fee(0, 100000)
+		require.NoError(t, err)
+		require.Equal(t, 10000.0, fee)
+	})
+
+	t.Run("NoTiers", func(t *testing.T) {
+		fee, err := NewFeeTable(config.Delivery{BaseFee: 1000, PerKm: 100}).Fee(2.5, 100000)
+		require.NoError(t, err)
+		require.Equal(t, 1300.0, fee)
+	})
+}
@@ -0,0 +1,202 @@
+// Package tax calculates sales taxes from jurisdiction rate tables keyed by region. Amounts are worked out in
+// integer cents and rates in basis points, so every tax line is rounded half away from zero exactly once
+package tax
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// ErrInvalidRate rate out of [0, 100] or with more than 2 decimals
+var ErrInvalidRate = errors.New("invalid tax rate")
+
+// Jurisdiction rate table of a region. Rate is a percentage applied to every category without its own rate in
+// CategoryRates. PricesIncludeTax tells whether the prices taxed in the region already contain the tax
+type Jurisdiction struct {
+	Region           string
+	Name             string
+	Rate             float64
+	PricesIncludeTax bool
+	CategoryRates    map[string]float64
+}
+
+// Validate check the region is set and every rate is a percentage with up to 2 decimals
+func (j *Jurisdiction) Validate() error {
+	if j.Region == "" {
+		return fmt.Errorf("%w: region is required", ErrInvalidRate)
+	}
+	if err := validateRate(j.Rate); err != nil {
+		return err
+	}
+	for category, rate := range j.CategoryRates {
+		if err := validateRate(rate); err != nil {
+			return fmt.Errorf("category %s: %w", category, err)
+		}
+	}
+	return nil
+}
+
+// RateFor rate of category, the jurisdiction rate when category has no override
+func (j *Jurisdiction) RateFor(category string) float64 {
+	if rate, ok := j.CategoryRates[category]; ok {
+		return rate
+	}
+	return j.Rate
+}
+
+// Item amount to be taxed, the price times quantity less discounts
+type Item struct {
+	Category string
+	Amount   float64
+}
+
+// Line tax computed on an item. Taxable is the amount before tax, equal to the item amount when prices
+// exclude tax and the item amount less the tax otherwise
+type Line struct {
+	Region    string  `json:"region"`
+	Name      string  `json:"name"`
+	Category  string  `json:"category,omitempty"`
+	Rate      float64 `json:"rate"`
+	Taxable   float64 `json:"taxable"`
+	Amount    float64 `json:"amount"`
+	Inclusive bool    `json:"inclusive"`
+}
+
+// Result tax lines of items with their total. When Inclusive the total is already part of the item amounts,
+// otherwise it is charged on top of them
+type Result struct {
+	Lines     []Line
+	Total     float64
+	Inclusive bool
+}
+
+// Calculator taxes items with the jurisdiction of their delivery region
+type Calculator struct {
+	jurisdictions map[string]Jurisdiction
+}
+
+// NewCalculator calculator over jurisdictions, a later jurisdiction of the same region replaces an earlier one
+func NewCalculator(jurisdictions ...Jurisdiction) *Calculator {
+	c := &Calculator{jurisdictions: make(map[string]Jurisdiction, len(jurisdictions))}
+	for _, j := range jurisdictions {
+		c.jurisdictions[NormalizeRegion(j.Region)] = j
+	}
+	return c
+}
+
+// Lookup jurisdiction of region, falling back to its parent regions, "US-CA" is taxed as "US" when it has no
+// rate table of its own
+func (c *Calculator) Lookup(region string) (Jurisdiction, bool) {
+	for _, candidate := range RegionCandidates(region) {
+		if j, ok := c.jurisdictions[candidate]; ok {
+			return j, true
+		}
+	}
+	return Jurisdiction{}, false
+}
+
+// Calculate tax items delivered to address. Items delivered outside every known jurisdiction are not taxed
+func (c *Calculator) Calculate(address string, items []Item) Result {
+	return c.CalculateRegion(RegionFromAddress(address), items)
+}
+
+// CalculateRegion tax items delivered to region. Items delivered outside every known jurisdiction are not taxed
+func (c *Calculator) CalculateRegion(region string, items []Item) Result {
+	j, ok := c.Lookup(region)
+	if !ok {
+		return Result{Lines: []Line{}}
+	}
+
+	res := Result{Lines: make([]Line, 0, len(items)), Inclusive: j.PricesIncludeTax}
+	var totalCents int64
+	for _, item := range items {
+		rate := j.RateFor(item.Category)
+		amountCents := toCents(item.Amount)
+		rateBasisPoints := toBasisPoints(rate)
+
+		var taxCents int64
+		if j.PricesIncludeTax {
+			taxCents = roundDiv(amountCents*rateBasisPoints, 10000+rateBasisPoints)
+		} else {
+			taxCents = roundDiv(amountCents*rateBasisPoints, 10000)
+		}
+
+		taxableCents := amountCents
+		if j.PricesIncludeTax {
+			taxableCents -= taxCents
+		}
+
+		res.Lines = append(res.Lines, Line{
+			Region:    j.Region,
+			Name:      j.Name,
+			Category:  item.Category,
+			Rate:      rate,
+			Taxable:   fromCents(taxableCents),
+			Amount:    fromCents(taxCents),
+			Inclusive: j.PricesIncludeTax,
+		})
+		totalCents += taxCents
+	}
+	res.Total = fromCents(totalCents)
+
+	return res
+}
+
+// NormalizeRegion upper case region code without surrounding spaces
+func NormalizeRegion(region string) string {
+	return strings.ToUpper(strings.TrimSpace(region))
+}
+
+// RegionFromAddress region code ending address, the text after its last comma, e.g. "ID-JK" for
+// "Jl. Sudirman 1, Jakarta, ID-JK"
+func RegionFromAddress(address string) string {
+	parts := strings.Split(address, ",")
+	return NormalizeRegion(parts[len(parts)-1])
+}
+
+// RegionCandidates region followed by its parent regions, most specific first
+func RegionCandidates(region string) []string {
+	region = NormalizeRegion(region)
+	if region == "" {
+		return []string{}
+	}
+
+	candidates := []string{region}
+	for i := strings.LastIndex(region, "-"); i > 0; i = strings.LastIndex(region, "-") {
+		region = region[:i]
+		candidates = append(candidates, region)
+	}
+	return candidates
+}
+
+func validateRate(rate float64) error {
+	if rate < 0 || rate > 100 || math.IsNaN(rate) {
+		return fmt.Errorf("%w: %v is not within [0, 100]", ErrInvalidRate, rate)
+	}
+	if math.Abs(rate*100-math.Round(rate*100)) > 1e-6 {
+		return fmt.Errorf("%w: %v has more than 2 decimals", ErrInvalidRate, rate)
+	}
+	return nil
+}
+
+func toCents(amount float64) int64 {
+	return int64(math.Round(amount * 100))
+}
+
+func fromCents(cents int64) float64 {
+	return float64(cents) / 100
+}
+
+func toBasisPoints(rate float64) int64 {
+	return int64(math.Round(rate * 100))
+}
+
+// roundDiv n / d rounded half away from zero, d must be positive
+func roundDiv(n, d int64) int64 {
+	if n < 0 {
+		return -roundDiv(-n, d)
+	}
+	return (2*n + d) / (2 * d)
+}
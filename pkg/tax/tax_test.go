@@ -0,0 +1,101 @@
+package tax
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type calculateFixture struct {
+	Name          string `json:"name"`
+	Jurisdictions []struct {
+		Region           string             `json:"region"`
+		Name             string             `json:"name"`
+		Rate             float64            `json:"rate"`
+		PricesIncludeTax bool               `json:"prices_include_tax"`
+		CategoryRates    map[string]float64 `json:"category_rates"`
+	} `json:"jurisdictions"`
+	Address string `json:"address"`
+	Items   []struct {
+		Category string  `json:"category"`
+		Amount   float64 `json:"amount"`
+	} `json:"items"`
+	Want struct {
+		Lines     []Line  `json:"lines"`
+		Total     float64 `json:"total"`
+		Inclusive bool    `json:"inclusive"`
+	} `json:"want"`
+}
+
+func TestCalculator_Calculate(t *testing.T) {
+	t.Parallel()
+
+	raw, err := os.ReadFile("testdata/calculate.json")
+	require.NoError(t, err)
+
+	var fixtures []calculateFixture
+	require.NoError(t, json.Unmarshal(raw, &fixtures))
+	require.NotEmpty(t, fixtures)
+
+	for _, f := range fixtures {
+		f := f
+		t.Run(f.Name, func(t *testing.T) {
+			jurisdictions := make([]Jurisdiction, 0, len(f.Jurisdictions))
+			for _, j := range f.Jurisdictions {
+				jurisdiction := Jurisdiction{Region: j.Region, Name: j.Name, Rate: j.Rate, PricesIncludeTax: j.PricesIncludeTax, CategoryRates: j.CategoryRates}
+				require.NoError(t, jurisdiction.Validate())
+				jurisdictions = append(jurisdictions, jurisdiction)
+			}
+			items := make([]Item, 0, len(f.Items))
+			for _, item := range f.Items {
+				items = append(items, Item{Category: item.Category, Amount: item.Amount})
+			}
+
+			res := NewCalculator(jurisdictions...).Calculate(f.Address, items)
+			require.Equal(t, f.Want.Lines, res.Lines)
+			require.Equal(t, f.Want.Total, res.Total)
+			require.Equal(t, f.Want.Inclusive, res.Inclusive)
+		})
+	}
+}
+
+func TestJurisdiction_Validate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name         string
+		jurisdiction Jurisdiction
+		valid        bool
+	}{
+		{"Valid", Jurisdiction{Region: "ID", Rate: 11, CategoryRates: map[string]float64{"books": 0}}, true},
+		{"TwoDecimals", Jurisdiction{Region: "US-CA", Rate: 7.25}, true},
+		{"Hundred", Jurisdiction{Region: "DE", Rate: 100}, true},
+		{"NoRegion", Jurisdiction{Rate: 11}, false},
+		{"Negative", Jurisdiction{Region: "ID", Rate: -1}, false},
+		{"AboveHundred", Jurisdiction{Region: "ID", Rate: 100.01}, false},
+		{"ThreeDecimals", Jurisdiction{Region: "ID", Rate: 7.125}, false},
+		{"InvalidCategoryRate", Jurisdiction{Region: "ID", Rate: 11, CategoryRates: map[string]float64{"books": 101}}, false},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			err := c.jurisdiction.Validate()
+			if c.valid {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, ErrInvalidRate)
+			}
+		})
+	}
+}
+
+func TestRegionCandidates(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, []string{"US-CA-LA", "US-CA", "US"}, RegionCandidates(" us-ca-la "))
+	require.Equal(t, []string{"ID"}, RegionCandidates(RegionFromAddress("Jl. Sudirman 1, Jakarta, ID")))
+	require.Empty(t, RegionCandidates(RegionFromAddress("Jakarta,")))
+}
@@ -0,0 +1,79 @@
+package utils
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidCursor cursor not issued for the list read with it
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// CursorRow row of a list read with cursors, SortValue is its value in one of the sort columns or the unique column
+type CursorRow interface {
+	SortValue(column string) interface{}
+}
+
+// Cursor position in a list sorted by Sort, reading goes on after the row with Values in the sort column and the
+// unique column, or before it for Prev. It is handed to clients encoded and opaque
+type Cursor struct {
+	Sort   string   `json:"s"`
+	Values []string `json:"v"`
+	Prev   bool     `json:"p,omitempty"`
+}
+
+// NewCursor cursor of the list sorted by sort after row, or before it for prev
+func NewCursor(sorts Sorts, sort string, row CursorRow, prev bool) (*Cursor, error) {
+	if sort == "" {
+		sort = sorts.Default
+	}
+	column, _, err := sorts.Parse(sort)
+	if err != nil {
+		return nil, err
+	}
+
+	values := []string{cursorValue(row.SortValue(column))}
+	if sorts.Unique != "" && sorts.Unique != column {
+		values = append(values, cursorValue(row.SortValue(sorts.Unique)))
+	}
+
+	return &Cursor{Sort: sort, Values: values, Prev: prev}, nil
+}
+
+// DecodeCursor cursor out of its encoded form
+func DecodeCursor(cursor string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var c Cursor
+	if err := json.Unmarshal(data, &c); err != nil || len(c.Values) == 0 {
+		return nil, ErrInvalidCursor
+	}
+	return &c, nil
+}
+
+// Encode opaque form of the cursor, safe in a query string
+func (c *Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// cursorValue text of a sort value, Postgres reads it back as the type of the column it is compared with
+func cursorValue(value interface{}) string {
+	switch v := value.(type) {
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case uuid.UUID:
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,164 @@
+package utils
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testRow struct {
+	id        int
+	createdAt time.Time
+	total     float64
+}
+
+func (r testRow) SortValue(column string) interface{} {
+	switch column {
+	case "created_at":
+		return r.createdAt
+	case "total_price":
+		return r.total
+	default:
+		return r.id
+	}
+}
+
+func TestCursor(t *testing.T) {
+	t.Parallel()
+
+	row := testRow{id: 7, createdAt: time.Date(2024, 5, 1, 10, 0, 0, 123456000, time.FixedZone("WIB", 7*3600)), total: 1500.5}
+
+	c, err := NewCursor(testSorts, "", row, false)
+	require.NoError(t, err)
+	require.Equal(t, &Cursor{Sort: "-created_at", Values: []string{"2024-05-01T03:00:00.123456Z", "7"}}, c)
+
+	decoded, err := DecodeCursor(c.Encode())
+	require.NoError(t, err)
+	require.Equal(t, c, decoded)
+
+	c, err = NewCursor(testSorts, "total", row, true)
+	require.NoError(t, err)
+	require.Equal(t, &Cursor{Sort: "total", Values: []string{"1500.5", "7"}, Prev: true}, c)
+
+	for _, cursor := range []string{"page-2", "e30", "bnVsbA"} {
+		_, err := DecodeCursor(cursor)
+		require.True(t, errors.Is(err, ErrInvalidCursor), cursor)
+	}
+
+	t.Run("Pagination", func(t *testing.T) {
+		pagination := NewPaginationQuery(20, 3)
+		require.NoError(t, pagination.SetCursor(c.Encode()))
+		require.Equal(t, "total", pagination.GetOrderBy())
+		require.Equal(t, 0, pagination.GetOffset())
+
+		pagination = NewPaginationQuery(20, 1)
+		pagination.SetOrderBy("-created_at")
+		require.True(t, errors.Is(pagination.SetCursor(c.Encode()), ErrInvalidCursor))
+	})
+}
+
+func TestQueryBuilder_Cursor(t *testing.T) {
+	t.Parallel()
+
+	pagination := NewPaginationQuery(20, 1)
+	require.NoError(t, pagination.SetCursor((&Cursor{Sort: "-created_at", Values: []string{"2024-05-01T03:00:00Z", "7"}}).Encode()))
+	query, args, err := NewQueryBuilder("SELECT id FROM orders").Where("user_id = ?", 1).Paginate(testSorts, pagination).Build()
+	require.NoError(t, err)
+	require.Equal(t, "SELECT id FROM orders WHERE user_id = $1 AND (created_at, id) < ($2, $3) ORDER BY created_at DESC, id DESC LIMIT $4", query)
+	require.Equal(t, []interface{}{1, "2024-05-01T03:00:00Z", "7", 20}, args)
+
+	t.Run("Prev", func(t *testing.T) {
+		pagination := NewPaginationQuery(20, 1)
+		require.NoError(t, pagination.SetCursor((&Cursor{Sort: "-created_at", Values: []string{"2024-05-01T03:00:00Z", "7"}, Prev: true}).Encode()))
+		query, _, err := NewQueryBuilder("SELECT id FROM orders").Paginate(testSorts, pagination).Build()
+		require.NoError(t, err)
+		require.Equal(t, "SELECT * FROM (SELECT id FROM orders WHERE (created_at, id) > ($1, $2) ORDER BY created_at ASC, id ASC LIMIT $3) AS page ORDER BY created_at DESC, id DESC", query)
+	})
+
+	t.Run("Ascending", func(t *testing.T) {
+		pagination := NewPaginationQuery(20, 1)
+		require.NoError(t, pagination.SetCursor((&Cursor{Sort: "total", Values: []string{"1500.5", "7"}}).Encode()))
+		query, _, err := NewQueryBuilder("SELECT id FROM orders").Paginate(testSorts, pagination).Build()
+		require.NoError(t, err)
+		require.Equal(t, "SELECT id FROM orders WHERE (total_price, id) > ($1, $2) ORDER BY total_price ASC, id ASC LIMIT $3", query)
+	})
+
+	t.Run("InvalidValues", func(t *testing.T) {
+		pagination := NewPaginationQuery(20, 1)
+		require.NoError(t, pagination.SetCursor((&Cursor{Sort: "total", Values: []string{"1500.5"}}).Encode()))
+		_, _, err := NewQueryBuilder("SELECT id FROM orders").Paginate(testSorts, pagination).Build()
+		require.True(t, errors.Is(err, ErrInvalidCursor))
+	})
+
+	t.Run("Count", func(t *testing.T) {
+		query, args, err := NewQueryBuilder("SELECT id FROM orders").Where("user_id = ?", 1).Paginate(testSorts, pagination).BuildCount(false)
+		require.NoError(t, err)
+		require.Equal(t, "SELECT COUNT(*) FROM (SELECT id FROM orders WHERE user_id = $1 AND (created_at, id) < ($2, $3)) AS list", query)
+		require.Len(t, args, 3)
+
+		query, _, err = NewQueryBuilder("SELECT id FROM orders").Where("user_id = ?", 1).BuildCount(true)
+		require.NoError(t, err)
+		require.Equal(t, "EXPLAIN (FORMAT JSON) SELECT id FROM orders WHERE user_id = $1", query)
+
+		rows, err := ParseEstimate([]byte(`[{"Plan": {"Node Type": "Seq Scan", "Plan Rows": 1234}}]`))
+		require.NoError(t, err)
+		require.Equal(t, 1234, rows)
+	})
+}
+
+func TestNewPaginationMetaDto(t *testing.T) {
+	t.Parallel()
+
+	first := testRow{id: 1, createdAt: time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC)}
+	last := testRow{id: 2, createdAt: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)}
+
+	t.Run("FirstPage", func(t *testing.T) {
+		u, _ := url.Parse("/orders?status=paid&size=2")
+		meta, err := NewPaginationMetaDto(u, NewPaginationQuery(2, 1), testSorts, 2, first, last, nil)
+		require.NoError(t, err)
+		require.Empty(t, meta.Prev)
+		require.Nil(t, meta.TotalCount)
+
+		next, err := url.Parse(meta.Next)
+		require.NoError(t, err)
+		require.Equal(t, "/orders", next.Path)
+		require.Equal(t, "paid", next.Query().Get("status"))
+		c, err := DecodeCursor(next.Query().Get("cursor"))
+		require.NoError(t, err)
+		require.Equal(t, &Cursor{Sort: "-created_at", Values: []string{"2024-05-01T00:00:00Z", "2"}}, c)
+	})
+
+	t.Run("LastPage", func(t *testing.T) {
+		pagination := NewPaginationQuery(2, 1)
+		require.NoError(t, pagination.SetCursor((&Cursor{Sort: "-created_at", Values: []string{"2024-05-03T00:00:00Z", "3"}}).Encode()))
+		u, _ := url.Parse("/orders?cursor=x")
+		meta, err := NewPaginationMetaDto(u, pagination, testSorts, 1, first, first, nil)
+		require.NoError(t, err)
+		require.Empty(t, meta.Next)
+
+		prev, _ := url.Parse(meta.Prev)
+		c, err := DecodeCursor(prev.Query().Get("cursor"))
+		require.NoError(t, err)
+		require.True(t, c.Prev)
+		require.Equal(t, []string{"2024-05-02T00:00:00Z", "1"}, c.Values)
+	})
+
+	t.Run("Page", func(t *testing.T) {
+		u, _ := url.Parse("/orders?page=2&size=2")
+		total := 5
+		meta, err := NewPaginationMetaDto(u, NewPaginationQuery(2, 2), testSorts, 2, first, last, &total)
+		require.NoError(t, err)
+		require.Equal(t, 5, *meta.TotalCount)
+		require.Equal(t, 3, *meta.TotalPages)
+		require.Equal(t, "/orders?page=3&size=2", meta.Next)
+		require.Equal(t, "/orders?page=1&size=2", meta.Prev)
+
+		u, _ = url.Parse("/orders?page=3&size=2")
+		meta, err = NewPaginationMetaDto(u, NewPaginationQuery(2, 3), testSorts, 1, first, first, &total)
+		require.NoError(t, err)
+		require.Empty(t, meta.Next)
+	})
+}
@@ -1,7 +1,72 @@
 package utils
 
+import (
+	"net/url"
+	"strconv"
+)
+
 type PaginationMetaDto struct {
-	Limit  int `json:"limit"`
-	Offset int `json:"offset"`
-	Page   int `json:"page"`
+	Limit      int    `json:"limit"`
+	Offset     int    `json:"offset"`
+	Page       int    `json:"page"`
+	TotalCount *int   `json:"total_count,omitempty"`
+	TotalPages *int   `json:"total_pages,omitempty"`
+	Next       string `json:"next,omitempty"`
+	Prev       string `json:"prev,omitempty"`
+}
+
+// NewPaginationMetaDto meta of a page of n rows, first and last its first and last rows, read from u with pagination
+// and sorted out of sorts. total counts the whole list, nil when it is not counted. A page read by page number links
+// to the pages around it by page number, any other to the ones around it by cursor
+func NewPaginationMetaDto(u *url.URL, pagination *Pagination, sorts Sorts, n int, first, last CursorRow, total *int) (PaginationMetaDto, error) {
+	meta := PaginationMetaDto{Limit: pagination.GetLimit(), Offset: pagination.GetOffset(), Page: pagination.GetPage()}
+	if total != nil && pagination.GetSize() > 0 {
+		pages := pagination.GetTotalPages(*total)
+		meta.TotalCount, meta.TotalPages = total, &pages
+	}
+
+	if pagination.Cursor == nil && u.Query().Get("page") != "" {
+		more := n == pagination.GetLimit()
+		if meta.TotalCount != nil {
+			more = pagination.GetHasMore(*total)
+		}
+		if more {
+			meta.Next = link(u, "page", strconv.Itoa(pagination.GetPage()+1))
+		}
+		if pagination.GetPage() > 1 {
+			meta.Prev = link(u, "page", strconv.Itoa(pagination.GetPage()-1))
+		}
+		return meta, nil
+	}
+
+	if n == 0 {
+		return meta, nil
+	}
+	// a short page is the end of the list in the direction it was read, the cursor row is on its other side
+	backwards := pagination.Cursor != nil && pagination.Cursor.Prev
+	if backwards || n == pagination.GetLimit() {
+		next, err := NewCursor(sorts, pagination.GetOrderBy(), last, false)
+		if err != nil {
+			return meta, err
+		}
+		meta.Next = link(u, "cursor", next.Encode())
+	}
+	if pagination.Cursor != nil && (!backwards || n == pagination.GetLimit()) {
+		prev, err := NewCursor(sorts, pagination.GetOrderBy(), first, true)
+		if err != nil {
+			return meta, err
+		}
+		meta.Prev = link(u, "cursor", prev.Encode())
+	}
+
+	return meta, nil
+}
+
+// link u with param set to value, in place of the page or cursor it was read with
+func link(u *url.URL, param, value string) string {
+	values := u.Query()
+	values.Del("page")
+	values.Del("cursor")
+	values.Set(param, value)
+	return u.Path + "?" + values.Encode()
 }
@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ETag strong entity tag of a resource version
+func ETag(version int) string {
+	return strconv.Quote(strconv.Itoa(version))
+}
+
+// IfMatch check If-Match header value against resource version, an absent header always matches
+func IfMatch(header string, version int) bool {
+	if header == "" {
+		return true
+	}
+
+	etag := ETag(version)
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || tag == etag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IfMatchVersions versions the If-Match header value accepts, nil when it accepts any version
+func IfMatchVersions(header string) []int {
+	if header == "" {
+		return nil
+	}
+
+	versions := []int{}
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" {
+			return nil
+		}
+		if unquoted, err := strconv.Unquote(tag); err == nil {
+			if version, err := strconv.Atoi(unquoted); err == nil {
+				versions = append(versions, version)
+			}
+		}
+	}
+
+	return versions
+}
@@ -0,0 +1,18 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIfMatchVersions(t *testing.T) {
+	t.Parallel()
+
+	require.Nil(t, IfMatchVersions(""))
+	require.Nil(t, IfMatchVersions("*"))
+	require.Nil(t, IfMatchVersions(`"1", *`))
+	require.Equal(t, []int{1}, IfMatchVersions(`"1"`))
+	require.Equal(t, []int{1, 3}, IfMatchVersions(`"1", "3"`))
+	require.Equal(t, []int{}, IfMatchVersions(`W/"1", "v2"`))
+}
@@ -12,9 +12,10 @@ const (
 
 // Pagination query params
 type Pagination struct {
-	Size    int    `json:"size,omitempty"`
-	Page    int    `json:"page,omitempty"`
-	OrderBy string `json:"orderBy,omitempty"`
+	Size    int     `json:"size,omitempty"`
+	Page    int     `json:"page,omitempty"`
+	OrderBy string  `json:"orderBy,omitempty"`
+	Cursor  *Cursor `json:"-"`
 }
 
 // NewPaginationQuery Pagination query constructor
@@ -69,6 +70,25 @@ func (q *Pagination) SetOrderBy(orderByQuery string) {
 	q.OrderBy = orderByQuery
 }
 
+// SetCursor Set cursor, the page read is the one it points at and the list is sorted as it was when it was issued
+func (q *Pagination) SetCursor(cursorQuery string) error {
+	if cursorQuery == "" {
+		return nil
+	}
+	c, err := DecodeCursor(cursorQuery)
+	if err != nil {
+		return err
+	}
+	if q.OrderBy != "" && q.OrderBy != c.Sort {
+		return fmt.Errorf("%w: issued for sort %q", ErrInvalidCursor, c.Sort)
+	}
+	q.OrderBy = c.Sort
+	q.Cursor = c
+	q.Page = 0
+
+	return nil
+}
+
 // GetOffset Get offset
 func (q *Pagination) GetOffset() int {
 	if q.Page == 0 {
@@ -110,5 +130,5 @@ func (q *Pagination) GetTotalPages(totalCount int) int {
 
 // GetHasMore Get has more
 func (q *Pagination) GetHasMore(totalCount int) bool {
-	return q.GetPage() < totalCount/q.GetSize()
+	return q.GetPage() < q.GetTotalPages(totalCount)
 }
@@ -0,0 +1,291 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const dateLayout = "2006-01-02"
+
+const (
+	CountExact    = "exact"
+	CountEstimate = "estimate"
+)
+
+// ErrInvalidSort sort key out of the allow-list of a list
+var ErrInvalidSort = errors.New("invalid sort")
+
+// Sorts allow-list of the sort keys of a list and the column each one sorts by. A sort is a key, prefixed with "-"
+// for descending, Default is used when none is given and Unique breaks ties so pages do not overlap
+type Sorts struct {
+	Columns map[string]string
+	Default string
+	Unique  string
+}
+
+// Parse column and direction of sort, empty sort is the default
+func (s Sorts) Parse(sort string) (column string, desc bool, err error) {
+	if sort == "" {
+		sort = s.Default
+	}
+	key := strings.TrimPrefix(sort, "-")
+	column, ok := s.Columns[key]
+	if !ok {
+		return "", false, fmt.Errorf("%w: %q, sort by one of %s", ErrInvalidSort, key, strings.Join(s.Keys(), ", "))
+	}
+
+	return column, key != sort, nil
+}
+
+// Validate check sort is allowed
+func (s Sorts) Validate(sort string) error {
+	_, _, err := s.Parse(sort)
+	return err
+}
+
+// Keys allowed sort keys in alphabetical order
+func (s Sorts) Keys() []string {
+	keys := make([]string, 0, len(s.Columns))
+	for key := range s.Columns {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// QueryBuilder builds a list query out of a SELECT, conditions joined with AND, an allow-listed ORDER BY and the
+// pagination. Conditions are SQL written in code with "?" for each value, values are only ever passed as arguments
+type QueryBuilder struct {
+	query      string
+	where      []string
+	args       []interface{}
+	groupBy    string
+	orderBy    []string
+	desc       bool
+	reverse    bool
+	pagination *Pagination
+	err        error
+}
+
+// NewQueryBuilder builder of a list query, query selects from the table without WHERE
+func NewQueryBuilder(query string) *QueryBuilder {
+	return &QueryBuilder{query: query}
+}
+
+// Where add condition, each "?" in it is bound to the next of args
+func (b *QueryBuilder) Where(condition string, args ...interface{}) *QueryBuilder {
+	if n := strings.Count(condition, "?"); n != len(args) {
+		b.err = fmt.Errorf("condition %q takes %d values, got %d", condition, n, len(args))
+		return b
+	}
+
+	var sb strings.Builder
+	for _, arg := range args {
+		i := strings.IndexByte(condition, '?')
+		b.args = append(b.args, arg)
+		sb.WriteString(condition[:i])
+		sb.WriteString("$" + strconv.Itoa(len(b.args)))
+		condition = condition[i+1:]
+	}
+	sb.WriteString(condition)
+	b.where = append(b.where, sb.String())
+
+	return b
+}
+
+// GroupBy group the rows by columns, a list of columns written in code
+func (b *QueryBuilder) GroupBy(columns string) *QueryBuilder {
+	b.groupBy = columns
+	return b
+}
+
+// OrderBy sort by the column sorts allows for sort
+func (b *QueryBuilder) OrderBy(sorts Sorts, sort string) *QueryBuilder {
+	column, desc, err := sorts.Parse(sort)
+	if err != nil {
+		b.err = err
+		return b
+	}
+
+	b.orderBy, b.desc = []string{column}, desc
+	if sorts.Unique != "" && sorts.Unique != column {
+		b.orderBy = append(b.orderBy, sorts.Unique)
+	}
+
+	return b
+}
+
+// Paginate sort by the pagination OrderBy, out of sorts, and take its page. With a cursor the page is the one after
+// the cursor row, or before it, found by the sort and unique columns instead of an OFFSET scan
+func (b *QueryBuilder) Paginate(sorts Sorts, pagination *Pagination) *QueryBuilder {
+	b.pagination = pagination
+	b.OrderBy(sorts, pagination.GetOrderBy())
+	if pagination.Cursor == nil || b.err != nil {
+		return b
+	}
+
+	c := pagination.Cursor
+	if c.Sort != pagination.GetOrderBy() || len(c.Values) != len(b.orderBy) {
+		b.err = ErrInvalidCursor
+		return b
+	}
+	operator := ">"
+	if b.desc != c.Prev {
+		operator = "<"
+	}
+	args := make([]interface{}, 0, len(c.Values))
+	for _, value := range c.Values {
+		args = append(args, value)
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(args)), ", ")
+	b.reverse = c.Prev
+
+	return b.Where(fmt.Sprintf("(%s) %s (%s)", strings.Join(b.orderBy, ", "), operator, placeholders), args...)
+}
+
+// Build query and its arguments
+func (b *QueryBuilder) Build() (string, []interface{}, error) {
+	if b.err != nil {
+		return "", nil, b.err
+	}
+
+	query, args := b.filtered()
+	if len(b.orderBy) > 0 {
+		query += " ORDER BY " + b.orderClause(b.desc != b.reverse)
+	}
+	switch {
+	case b.pagination != nil && b.pagination.Cursor != nil:
+		args = append(args, b.pagination.GetLimit())
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	case b.pagination != nil:
+		args = append(args, b.pagination.GetLimit(), b.pagination.GetOffset())
+		query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+	}
+	if b.reverse {
+		// read backwards from the cursor, the page is then put back in list order
+		query = "SELECT * FROM (" + query + ") AS page ORDER BY " + b.orderClause(b.desc)
+	}
+
+	return query, args, nil
+}
+
+// BuildCount query counting the rows the conditions match, regardless of the page. With estimate it asks the planner
+// for its estimate instead, an EXPLAIN reading a JSON plan ParseEstimate takes the row count from
+func (b *QueryBuilder) BuildCount(estimate bool) (string, []interface{}, error) {
+	if b.err != nil {
+		return "", nil, b.err
+	}
+
+	query, args := b.filtered()
+	if estimate {
+		return "EXPLAIN (FORMAT JSON) " + query, args, nil
+	}
+	return "SELECT COUNT(*) FROM (" + query + ") AS list", args, nil
+}
+
+// ParseEstimate rows the planner estimates out of the JSON plan of an EXPLAIN
+func ParseEstimate(plan []byte) (int, error) {
+	var plans []struct {
+		Plan struct {
+			Rows float64 `json:"Plan Rows"`
+		}
+	}
+	if err := json.Unmarshal(plan, &plans); err != nil {
+		return 0, err
+	}
+	if len(plans) == 0 {
+		return 0, errors.New("empty plan")
+	}
+	return int(plans[0].Plan.Rows), nil
+}
+
+func (b *QueryBuilder) filtered() (string, []interface{}) {
+	query, args := b.query, append([]interface{}{}, b.args...)
+	if len(b.where) > 0 {
+		query += " WHERE " + strings.Join(b.where, " AND ")
+	}
+	if b.groupBy != "" {
+		query += " GROUP BY " + b.groupBy
+	}
+	return query, args
+}
+
+func (b *QueryBuilder) orderClause(desc bool) string {
+	direction := " ASC"
+	if desc {
+		direction = " DESC"
+	}
+	return strings.Join(b.orderBy, direction+", ") + direction
+}
+
+// ContainsPattern LIKE pattern matching text anywhere, with the LIKE wildcards in text matched literally
+func ContainsPattern(text string) string {
+	return "%" + likeEscaper.Replace(text) + "%"
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// ParseQueryTime RFC3339 time or date of the query parameter, nil when it is not set. With end set a date moves to
+// the start of the next day, so it bounds a range including the whole day
+func ParseQueryTime(values url.Values, param string, end bool) (*time.Time, error) {
+	value := values.Get(param)
+	if value == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+	t, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %q is neither RFC3339 nor %s", param, value, dateLayout)
+	}
+	if end {
+		t = t.AddDate(0, 0, 1)
+	}
+	return &t, nil
+}
+
+// ParseQueryUUID uuid of the query parameter, nil when it is not set
+func ParseQueryUUID(values url.Values, param string) (*uuid.UUID, error) {
+	value := values.Get(param)
+	if value == "" {
+		return nil, nil
+	}
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", param, err)
+	}
+	return &id, nil
+}
+
+// ParseQueryAmount non negative amount of the query parameter, nil when it is not set
+func ParseQueryAmount(values url.Values, param string) (*float64, error) {
+	value := values.Get(param)
+	if value == "" {
+		return nil, nil
+	}
+	amount, err := strconv.ParseFloat(value, 64)
+	if err != nil || amount < 0 || math.IsInf(amount, 0) || math.IsNaN(amount) {
+		return nil, fmt.Errorf("invalid %s: %q", param, value)
+	}
+	return &amount, nil
+}
+
+// ParseQueryCount how the query parameter asks to count a list, CountExact or CountEstimate, empty when it is not set
+func ParseQueryCount(values url.Values, param string) (string, error) {
+	switch count := values.Get(param); count {
+	case "", CountExact, CountEstimate:
+		return count, nil
+	default:
+		return "", fmt.Errorf("invalid %s: %q, count is %s or %s", param, count, CountExact, CountEstimate)
+	}
+}
@@ -0,0 +1,145 @@
+package utils
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testSorts = Sorts{
+	Columns: map[string]string{"created_at": "created_at", "total": "total_price"},
+	Default: "-created_at",
+	Unique:  "id",
+}
+
+func TestSorts_Parse(t *testing.T) {
+	t.Parallel()
+
+	for sort, want := range map[string]struct {
+		column string
+		desc   bool
+	}{
+		"":            {"created_at", true},
+		"total":       {"total_price", false},
+		"-total":      {"total_price", true},
+		"-created_at": {"created_at", true},
+	} {
+		column, desc, err := testSorts.Parse(sort)
+		require.NoError(t, err, sort)
+		require.Equal(t, want.column, column, sort)
+		require.Equal(t, want.desc, desc, sort)
+	}
+
+	for _, sort := range []string{"total_price", "--total", "total DESC", "id; DROP TABLE orders"} {
+		_, _, err := testSorts.Parse(sort)
+		require.True(t, errors.Is(err, ErrInvalidSort), sort)
+	}
+}
+
+func TestQueryBuilder_Build(t *testing.T) {
+	t.Parallel()
+
+	pagination := NewPaginationQuery(20, 3)
+	pagination.SetOrderBy("total")
+	query, args, err := NewQueryBuilder("SELECT id FROM orders").
+		Where("deleted_at IS NULL").
+		Where("status = ANY(?)", "{paid}").
+		Where("(total_price BETWEEN ? AND ?)", 10, 20).
+		Paginate(testSorts, pagination).
+		Build()
+	require.NoError(t, err)
+	require.Equal(t, "SELECT id FROM orders WHERE deleted_at IS NULL AND status = ANY($1) AND (total_price BETWEEN $2 AND $3) ORDER BY total_price ASC, id ASC LIMIT $4 OFFSET $5", query)
+	require.Equal(t, []interface{}{"{paid}", 10, 20, 20, 40}, args)
+
+	t.Run("Unfiltered", func(t *testing.T) {
+		query, args, err := NewQueryBuilder("SELECT id FROM orders").Build()
+		require.NoError(t, err)
+		require.Equal(t, "SELECT id FROM orders", query)
+		require.Empty(t, args)
+	})
+
+	t.Run("UniqueSort", func(t *testing.T) {
+		query, _, err := NewQueryBuilder("SELECT id FROM orders").OrderBy(Sorts{Columns: map[string]string{"id": "id"}, Unique: "id"}, "-id").Build()
+		require.NoError(t, err)
+		require.Equal(t, "SELECT id FROM orders ORDER BY id DESC", query)
+	})
+
+	t.Run("GroupBy", func(t *testing.T) {
+		query, args, err := NewQueryBuilder("SELECT product_id, SUM(revenue) AS revenue FROM sales").
+			Where("day >= ?", "2024-05-01").
+			GroupBy("product_id").
+			OrderBy(Sorts{Columns: map[string]string{"revenue": "revenue"}, Unique: "product_id"}, "-revenue").
+			Build()
+		require.NoError(t, err)
+		require.Equal(t, "SELECT product_id, SUM(revenue) AS revenue FROM sales WHERE day >= $1 GROUP BY product_id ORDER BY revenue DESC, product_id DESC", query)
+		require.Equal(t, []interface{}{"2024-05-01"}, args)
+	})
+
+	t.Run("InvalidSort", func(t *testing.T) {
+		pagination := NewPaginationQuery(20, 1)
+		pagination.SetOrderBy("total_price")
+		_, _, err := NewQueryBuilder("SELECT id FROM orders").Paginate(testSorts, pagination).Build()
+		require.True(t, errors.Is(err, ErrInvalidSort))
+	})
+
+	t.Run("ArgumentCount", func(t *testing.T) {
+		_, _, err := NewQueryBuilder("SELECT id FROM orders").Where("user_id = ? AND brand_id = ?", 1).Build()
+		require.Error(t, err)
+	})
+}
+
+func TestContainsPattern(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "%kalo%", ContainsPattern("kalo"))
+	require.Equal(t, `%50\%\_off\\%`, ContainsPattern(`50%_off\`))
+}
+
+func TestParseQueryParams(t *testing.T) {
+	t.Parallel()
+
+	values := url.Values{
+		"from":   {"2024-05-01"},
+		"to":     {"2024-05-31"},
+		"at":     {"2024-05-01T10:00:00+07:00"},
+		"id":     {"6d3f1a2e-9a7c-4b8e-8f4e-2b7f0c1d9e5a"},
+		"amount": {"1500.50"},
+		"bad":    {"-1"},
+	}
+
+	from, err := ParseQueryTime(values, "from", false)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), *from)
+
+	to, err := ParseQueryTime(values, "to", true)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), *to)
+
+	at, err := ParseQueryTime(values, "at", true)
+	require.NoError(t, err)
+	require.True(t, at.Equal(time.Date(2024, 5, 1, 3, 0, 0, 0, time.UTC)))
+
+	missing, err := ParseQueryTime(values, "missing", false)
+	require.NoError(t, err)
+	require.Nil(t, missing)
+
+	_, err = ParseQueryTime(values, "amount", false)
+	require.Error(t, err)
+
+	id, err := ParseQueryUUID(values, "id")
+	require.NoError(t, err)
+	require.Equal(t, "6d3f1a2e-9a7c-4b8e-8f4e-2b7f0c1d9e5a", id.String())
+	_, err = ParseQueryUUID(values, "amount")
+	require.Error(t, err)
+
+	amount, err := ParseQueryAmount(values, "amount")
+	require.NoError(t, err)
+	require.Equal(t, 1500.5, *amount)
+	for _, param := range []string{"bad", "from"} {
+		_, err = ParseQueryAmount(values, param)
+		require.Error(t, err, param)
+	}
+}